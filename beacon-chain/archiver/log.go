@@ -0,0 +1,7 @@
+package archiver
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "archiver")
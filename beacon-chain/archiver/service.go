@@ -0,0 +1,132 @@
+// Package archiver archives the inclusions of attestations in the blocks processed by the beacon
+// node, so that the inclusion of the attestation of any validator in a past epoch can be looked up
+// without regenerating the states of the epoch.
+package archiver
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+var _ shared.Service = (*Service)(nil)
+
+// Config to set up the attestation inclusions archiving service.
+type Config struct {
+	BeaconDB      db.NoHeadAccessDatabase
+	StateNotifier statefeed.Notifier
+	HeadFetcher   blockchain.HeadFetcher
+}
+
+// Service archives the attestation inclusions of the processed blocks.
+type Service struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	cfg          *Config
+	stateChannel chan *feed.Event
+	stateSub     event.Subscription
+}
+
+// NewService configures the attestation inclusions archiving service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		cfg:          cfg,
+		stateChannel: make(chan *feed.Event, 1),
+	}
+}
+
+// Start archiving the attestation inclusions of the processed blocks.
+func (s *Service) Start() {
+	s.stateSub = s.cfg.StateNotifier.StateFeed().Subscribe(s.stateChannel)
+	go s.run()
+}
+
+// Stop archiving.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status always returns nil.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	defer s.stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-s.stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.BlockProcessedData)
+			if !ok {
+				log.Error("Event feed data is not type *statefeed.BlockProcessedData")
+				continue
+			}
+			headState, err := s.cfg.HeadFetcher.HeadState(s.ctx)
+			if err != nil || headState == nil {
+				log.WithError(err).Debug("Could not get head state")
+				continue
+			}
+			if err := s.archiveBlock(s.ctx, headState, data.SignedBlock); err != nil {
+				log.WithError(err).WithField("slot", data.Slot).Error("Could not archive attestation inclusions")
+			}
+		case err := <-s.stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// archiveBlock archives the inclusions of the attestations of a processed block. The committees of
+// the attestations are computed from the head state, and the attestations whose committee cannot
+// be computed from it are skipped.
+func (s *Service) archiveBlock(ctx context.Context, headState *state.BeaconState, blk *ethpb.SignedBeaconBlock) error {
+	if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+		return nil
+	}
+	var inclusions []*db.AttestationInclusion
+	for _, att := range blk.Block.Body.Attestations {
+		if att == nil || att.Data == nil {
+			continue
+		}
+		committee, err := helpers.BeaconCommitteeFromState(headState, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			log.WithError(err).Debug("Could not get attestation committee")
+			continue
+		}
+		attesting, err := attestationutil.AttestingIndices(att.AggregationBits, committee)
+		if err != nil {
+			log.WithError(err).Debug("Could not get attesting indices")
+			continue
+		}
+		for _, idx := range attesting {
+			inclusions = append(inclusions, &db.AttestationInclusion{
+				ValidatorIndex:  types.ValidatorIndex(idx),
+				AttestationSlot: att.Data.Slot,
+				InclusionSlot:   blk.Block.Slot,
+			})
+		}
+	}
+	if len(inclusions) == 0 {
+		return nil
+	}
+	return s.cfg.BeaconDB.SaveAttestationInclusions(ctx, inclusions)
+}
@@ -0,0 +1,47 @@
+package archiver
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_ArchiveBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 1)
+	chain := &mock.ChainService{State: beaconState}
+	s := NewService(ctx, &Config{
+		BeaconDB:      testDB.SetupDB(t),
+		StateNotifier: chain.StateNotifier(),
+		HeadFetcher:   chain,
+	})
+
+	// Only the second validator of the committee attested.
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(1, true)
+	att := testutil.HydrateAttestation(&ethpb.Attestation{AggregationBits: bits})
+	att.Data.Slot = 1
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = 3
+	blk.Block.Body.Attestations = []*ethpb.Attestation{att}
+	require.NoError(t, s.archiveBlock(ctx, beaconState, blk))
+
+	inclusion, err := s.cfg.BeaconDB.AttestationInclusion(ctx, 0, committee[1])
+	require.NoError(t, err)
+	assert.DeepEqual(t, &db.AttestationInclusion{ValidatorIndex: committee[1], AttestationSlot: 1, InclusionSlot: 3}, inclusion)
+	inclusion, err = s.cfg.BeaconDB.AttestationInclusion(ctx, 0, committee[0])
+	require.NoError(t, err)
+	assert.Equal(t, (*db.AttestationInclusion)(nil), inclusion)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
@@ -98,7 +99,7 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock,
 	if err != nil {
 		return errors.Wrap(err, "could not execute state transition")
 	}
-	valid, err := set.Verify()
+	valid, err := set.VerifyVerbosely()
 	if err != nil {
 		return errors.Wrap(err, "could not batch verify signature")
 	}
@@ -238,9 +239,11 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []*ethpb.SignedBeaconBl
 		fCheckpoints[i] = preState.FinalizedCheckpoint()
 		sigSet.Join(set)
 	}
+	// The batch is rejected as a whole when verification fails. Verifying every signature of a sync
+	// batch one by one to find the invalid one is too costly to be triggered by a single bad peer.
 	verify, err := sigSet.Verify()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, errors.Wrap(err, "could not batch verify signatures")
 	}
 	if !verify {
 		return nil, nil, errors.New("batch block signature verification failed")
@@ -340,6 +343,31 @@ func (s *Service) handleEpochBoundary(ctx context.Context, postState *stateTrie.
 	return nil
 }
 
+// This caches the shuffled committees of the next epoch from the head state at the start of the last
+// slot of an epoch. The epoch boundary handling does the same when processing the block of that slot,
+// but duty requests and gossip validation would otherwise recompute the shuffling on demand at the
+// start of the next epoch whenever that block is missing.
+func (s *Service) prewarmCommitteeCache(ctx context.Context, slot types.Slot) {
+	if !helpers.IsEpochStart(slot + 1) {
+		return
+	}
+	s.headLock.RLock()
+	if !s.hasHeadState() {
+		s.headLock.RUnlock()
+		return
+	}
+	headState := s.headState(ctx)
+	s.headLock.RUnlock()
+
+	// A head state of an older epoch would not yield the seed and active validators of the next epoch.
+	if helpers.CurrentEpoch(headState) != helpers.SlotToEpoch(slot) {
+		return
+	}
+	if err := helpers.UpdateCommitteeCache(headState, helpers.NextEpoch(headState)); err != nil {
+		log.WithError(err).Debug("Could not pre-warm committee cache")
+	}
+}
+
 // This feeds in the block and block's attestations to fork choice store. It's allows fork choice store
 // to gain information on the most current chain.
 func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Context, blk *ethpb.BeaconBlock, root [32]byte,
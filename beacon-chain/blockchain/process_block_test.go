@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -915,6 +918,37 @@ func TestHandleEpochBoundary_UpdateFirstSlot(t *testing.T) {
 	require.Equal(t, 3*params.BeaconConfig().SlotsPerEpoch, service.nextEpochBoundarySlot)
 }
 
+func TestPrewarmCommitteeCache(t *testing.T) {
+	ctx := context.Background()
+	service, err := NewService(ctx, &Config{})
+	require.NoError(t, err)
+
+	s, _ := testutil.DeterministicGenesisState(t, 1024)
+	lastSlot := 2*params.BeaconConfig().SlotsPerEpoch - 1
+	require.NoError(t, s.SetSlot(lastSlot))
+	service.head = &head{state: s}
+	nextEpochMisses := func() float64 {
+		before := promtestutil.ToFloat64(cache.CommitteeCacheMiss)
+		_, err := helpers.ActiveValidatorCount(s, helpers.NextEpoch(s))
+		require.NoError(t, err)
+		return promtestutil.ToFloat64(cache.CommitteeCacheMiss) - before
+	}
+
+	// Nothing is cached before the last slot of the epoch.
+	helpers.ClearCache()
+	service.prewarmCommitteeCache(ctx, lastSlot-1)
+	assert.Equal(t, float64(1), nextEpochMisses())
+
+	helpers.ClearCache()
+	service.prewarmCommitteeCache(ctx, lastSlot)
+	assert.Equal(t, float64(0), nextEpochMisses())
+
+	// A head state of an older epoch is not used.
+	helpers.ClearCache()
+	service.prewarmCommitteeCache(ctx, lastSlot+params.BeaconConfig().SlotsPerEpoch)
+	assert.Equal(t, float64(1), nextEpochMisses())
+}
+
 func TestOnBlock_CanFinalize(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
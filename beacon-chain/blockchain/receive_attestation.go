@@ -118,7 +118,8 @@ func (s *Service) processAttestationsRoutine(subscribedToStateEvents chan<- stru
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-st.C():
+		case slot := <-st.C():
+			s.prewarmCommitteeCache(s.ctx, slot)
 			// Continue when there's no fork choice attestation, there's nothing to process and update head.
 			// This covers the condition when the node is still initial syncing to the head of the chain.
 			if s.attPool.ForkchoiceAttestationCount() == 0 {
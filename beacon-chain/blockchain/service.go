@@ -201,6 +201,9 @@ func (s *Service) Start() {
 		s.finalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.prevFinalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
+		if err := s.insertOriginBlock(s.ctx, justifiedCheckpoint, finalizedCheckpoint); err != nil {
+			log.Fatalf("Could not insert origin block to fork choice store: %v", err)
+		}
 
 		ss, err := helpers.StartSlot(s.finalizedCheckpt.Epoch)
 		if err != nil {
@@ -509,6 +512,29 @@ func (s *Service) resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint *eth
 	s.forkChoiceStore = store
 }
 
+// insertOriginBlock inserts the block a node was started from into fork choice while it is the
+// finalized block, as its ancestors are not in the db to fill in fork choice from.
+func (s *Service) insertOriginBlock(ctx context.Context, justifiedCheckpoint, finalizedCheckpoint *ethpb.Checkpoint) error {
+	originRoot, err := s.beaconDB.OriginBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block root")
+	}
+	if originRoot == params.BeaconConfig().ZeroHash || originRoot != bytesutil.ToBytes32(finalizedCheckpoint.Root) {
+		return nil
+	}
+	b, err := s.beaconDB.Block(ctx, originRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block")
+	}
+	if b == nil || b.Block == nil {
+		return errors.New("origin block is not in db")
+	}
+	return s.forkChoiceStore.ProcessBlock(ctx,
+		b.Block.Slot, originRoot, bytesutil.ToBytes32(b.Block.ParentRoot), bytesutil.ToBytes32(b.Block.Body.Graffiti),
+		justifiedCheckpoint.Epoch,
+		finalizedCheckpoint.Epoch)
+}
+
 // This returns true if block has been processed before. Two ways to verify the block has been processed:
 // 1.) Check fork choice store.
 // 2.) Check DB.
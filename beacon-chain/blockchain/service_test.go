@@ -447,6 +447,29 @@ func TestHasBlock_ForkChoiceAndDB(t *testing.T) {
 	assert.Equal(t, true, s.hasBlock(ctx, r), "Should have block")
 }
 
+func TestInsertOriginBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	s := &Service{
+		forkChoiceStore: protoarray.New(0, 0, [32]byte{}),
+		beaconDB:        beaconDB,
+	}
+	block := testutil.NewBeaconBlock()
+	block.Block.Slot = 100
+	r, err := block.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, block))
+	cp := &ethpb.Checkpoint{Epoch: 4, Root: r[:]}
+
+	// Without an origin the finalized block is not inserted.
+	require.NoError(t, s.insertOriginBlock(ctx, cp, cp))
+	assert.Equal(t, false, s.forkChoiceStore.HasNode(r))
+
+	require.NoError(t, beaconDB.SaveOriginBlockRoot(ctx, r))
+	require.NoError(t, s.insertOriginBlock(ctx, cp, cp))
+	assert.Equal(t, true, s.forkChoiceStore.HasNode(r))
+}
+
 func TestServiceStop_SaveCachedBlocks(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	beaconDB := testDB.SetupDB(t)
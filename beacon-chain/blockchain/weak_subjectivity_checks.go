@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
@@ -33,28 +33,64 @@ func (s *Service) VerifyWeakSubjectivityRoot(ctx context.Context) error {
 	if err := s.beaconDB.SaveBlocks(ctx, s.getInitSyncBlocks()); err != nil {
 		return err
 	}
-	// A node should have the weak subjectivity block in the DB.
-	if !s.beaconDB.HasBlock(ctx, r) {
-		return fmt.Errorf("node does not have root in DB: %#x", r)
-	}
-
 	startSlot, err := helpers.StartSlot(s.wsEpoch)
 	if err != nil {
 		return err
 	}
-	// A node should have the weak subjectivity block corresponds to the correct epoch in the DB.
-	filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(startSlot + params.BeaconConfig().SlotsPerEpoch)
-	roots, err := s.beaconDB.BlockRoots(ctx, filter)
+	// A node should have the weak subjectivity block in the DB.
+	blk, err := s.beaconDB.Block(ctx, r)
 	if err != nil {
 		return err
 	}
-	for _, root := range roots {
-		if r == root {
-			log.Info("Weak subjectivity check has passed")
-			s.wsVerified = true
+	if blk == nil || blk.Block == nil {
+		// A node started from a checkpoint after the weak subjectivity epoch only has the weak
+		// subjectivity block once the blocks below the checkpoint are backfilled.
+		belowOrigin, err := s.isBelowOrigin(ctx, startSlot)
+		if err != nil {
+			return err
+		}
+		if belowOrigin {
+			log.Info("Weak subjectivity check is deferred until blocks are backfilled")
 			return nil
 		}
+		return fmt.Errorf("node does not have root in DB: %#x", r)
+	}
+
+	// The weak subjectivity block is the latest block of the finalized chain at the start slot of
+	// the weak subjectivity epoch.
+	if blk.Block.Slot > startSlot {
+		return fmt.Errorf("node does not have root in db corresponding to epoch: %#x %d, block slot %d is after epoch start slot %d",
+			r, s.wsEpoch, blk.Block.Slot, startSlot)
+	}
+	if !s.beaconDB.IsFinalizedBlock(ctx, r) {
+		return fmt.Errorf("weak subjectivity root %#x is not in the finalized chain of the node, "+
+			"which conflicts with the weak subjectivity checkpoint", r)
 	}
+	child, err := s.beaconDB.FinalizedChildBlock(ctx, r)
+	if err != nil {
+		return err
+	}
+	if child != nil && child.Block != nil && child.Block.Slot <= startSlot {
+		return fmt.Errorf("node does not have root in db corresponding to epoch: %#x %d, finalized block at slot %d is the checkpoint of the epoch",
+			r, s.wsEpoch, child.Block.Slot)
+	}
+	log.Info("Weak subjectivity check has passed")
+	s.wsVerified = true
+	return nil
+}
 
-	return fmt.Errorf("node does not have root in db corresponding to epoch: %#x %d", r, s.wsEpoch)
+// isBelowOrigin returns true if the node was started from a checkpoint after the slot.
+func (s *Service) isBelowOrigin(ctx context.Context, slot types.Slot) (bool, error) {
+	originRoot, err := s.beaconDB.OriginBlockRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+	if originRoot == params.BeaconConfig().ZeroHash {
+		return false, nil
+	}
+	origin, err := s.beaconDB.Block(ctx, originRoot)
+	if err != nil {
+		return false, err
+	}
+	return origin != nil && origin.Block != nil && origin.Block.Slot > slot, nil
 }
@@ -6,19 +6,37 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
 
-func TestService_VerifyWeakSubjectivityRoot(t *testing.T) {
-	beaconDB := testDB.SetupDB(t)
-
+// saveWeakSubjectivityBlock saves a block at the slot with the parent root, and returns its root.
+func saveWeakSubjectivityBlock(t *testing.T, beaconDB db.Database, slot types.Slot, parentRoot [32]byte) [32]byte {
 	b := testutil.NewBeaconBlock()
-	b.Block.Slot = 32
+	b.Block.Slot = slot
+	b.Block.ParentRoot = parentRoot[:]
 	require.NoError(t, beaconDB.SaveBlock(context.Background(), b))
 	r, err := b.Block.HashTreeRoot()
 	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveStateSummary(context.Background(), &pb.StateSummary{Slot: slot, Root: r[:]}))
+	return r
+}
+
+func TestService_VerifyWeakSubjectivityRoot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	// The finalized chain is genesis <- b (slot 32) <- c (slot 40), while d (slot 33) is a fork of b.
+	genesisRoot := saveWeakSubjectivityBlock(t, beaconDB, 0, [32]byte{})
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	b := saveWeakSubjectivityBlock(t, beaconDB, 32, genesisRoot)
+	c := saveWeakSubjectivityBlock(t, beaconDB, 40, b)
+	d := saveWeakSubjectivityBlock(t, beaconDB, 33, b)
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: c[:]}))
 	tests := []struct {
 		wsVerified     bool
 		wantErr        bool
@@ -54,17 +72,40 @@ func TestService_VerifyWeakSubjectivityRoot(t *testing.T) {
 			errString:      "node does not have root in DB",
 		},
 		{
-			name:           "can't find the block corresponds to ws epoch in DB",
+			name:           "block is after the ws epoch start slot",
+			wsEpoch:        1,
+			wsRoot:         c, // Root belongs in epoch 1 after its start slot.
+			finalizedEpoch: 3,
+			wantErr:        true,
+			errString:      "node does not have root in db corresponding to epoch",
+		},
+		{
+			name:           "a later finalized block is the checkpoint of the ws epoch",
 			wsEpoch:        2,
-			wsRoot:         r, // Root belongs in epoch 1.
+			wsRoot:         b,
 			finalizedEpoch: 3,
 			wantErr:        true,
 			errString:      "node does not have root in db corresponding to epoch",
 		},
+		{
+			name:           "block is not in the finalized chain",
+			wsEpoch:        2,
+			wsRoot:         d,
+			finalizedEpoch: 3,
+			wantErr:        true,
+			errString:      "is not in the finalized chain",
+		},
 		{
 			name:           "can verify and pass",
 			wsEpoch:        1,
-			wsRoot:         r,
+			wsRoot:         b,
+			finalizedEpoch: 3,
+			wantErr:        false,
+		},
+		{
+			name:           "can verify skipped slots and pass",
+			wsEpoch:        2,
+			wsRoot:         c,
 			finalizedEpoch: 3,
 			wantErr:        false,
 		},
@@ -78,9 +119,33 @@ func TestService_VerifyWeakSubjectivityRoot(t *testing.T) {
 				wsVerified:       tt.wsVerified,
 				finalizedCheckpt: &ethpb.Checkpoint{Epoch: tt.finalizedEpoch},
 			}
-			if err := s.VerifyWeakSubjectivityRoot(context.Background()); (err != nil) != tt.wantErr {
+			err := s.VerifyWeakSubjectivityRoot(ctx)
+			if tt.wantErr {
 				require.ErrorContains(t, tt.errString, err)
+			} else {
+				require.NoError(t, err)
 			}
 		})
 	}
 }
+
+func TestService_VerifyWeakSubjectivityRoot_BelowOrigin(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	origin := saveWeakSubjectivityBlock(t, beaconDB, 100, [32]byte{'p'})
+	require.NoError(t, beaconDB.SaveOriginBlockRoot(ctx, origin))
+
+	s := &Service{
+		beaconDB:         beaconDB,
+		wsRoot:           []byte{'a'},
+		wsEpoch:          1,
+		finalizedCheckpt: &ethpb.Checkpoint{Epoch: 4},
+	}
+	// The weak subjectivity block is not backfilled yet.
+	require.NoError(t, s.VerifyWeakSubjectivityRoot(ctx))
+	assert.Equal(t, false, s.wsVerified)
+
+	// The weak subjectivity epoch is after the origin.
+	s.wsEpoch = 4
+	require.ErrorContains(t, "node does not have root in DB", s.VerifyWeakSubjectivityRoot(ctx))
+}
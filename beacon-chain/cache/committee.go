@@ -6,16 +6,16 @@ import (
 	"errors"
 	"sync"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
-	"k8s.io/client-go/tools/cache"
 )
 
 var (
-	// maxCommitteesCacheSize defines the max number of shuffled committees on per randao basis can cache.
+	// maxCommitteesCacheSize defines the default max number of shuffled committees on per randao basis can cache.
 	// Due to reorgs and long finality, it's good to keep the old cache around for quickly switch over.
 	maxCommitteesCacheSize = uint64(32)
 
@@ -29,11 +29,19 @@ var (
 		Name: "committee_cache_hit",
 		Help: "The number of committee requests that are present in the cache.",
 	})
+	committeeCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "committee_cache_evictions_total",
+		Help: "The number of shuffled committees evicted from the cache as the least recently used.",
+	})
+	committeeCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "committee_cache_size",
+		Help: "The number of shuffled committees held in the cache.",
+	})
 )
 
-// CommitteeCache is a struct with 1 queue for looking up shuffled indices list by seed.
+// CommitteeCache is a struct with 1 LRU cache for looking up shuffled indices list by seed.
 type CommitteeCache struct {
-	CommitteeCache *cache.FIFO
+	CommitteeCache *lru.Cache
 	lock           sync.RWMutex
 }
 
@@ -49,8 +57,24 @@ func committeeKeyFn(obj interface{}) (string, error) {
 
 // NewCommitteesCache creates a new committee cache for storing/accessing shuffled indices of a committee.
 func NewCommitteesCache() *CommitteeCache {
+	return NewCommitteesCacheWithSize(int(maxCommitteesCacheSize))
+}
+
+// NewCommitteesCacheWithSize creates a new committee cache holding the shuffled indices of up to size
+// seeds, the least recently used being evicted first. A non positive size uses the default size.
+func NewCommitteesCacheWithSize(size int) *CommitteeCache {
+	if size <= 0 {
+		size = int(maxCommitteesCacheSize)
+	}
+	cache, err := lru.NewWithEvict(size, func(_, _ interface{}) {
+		committeeCacheEvictions.Inc()
+	})
+	if err != nil {
+		panic(err)
+	}
+	committeeCacheSize.Set(0)
 	return &CommitteeCache{
-		CommitteeCache: cache.NewFIFO(committeeKeyFn),
+		CommitteeCache: cache,
 	}
 }
 
@@ -60,11 +84,7 @@ func (c *CommitteeCache) Committee(slot types.Slot, seed [32]byte, index types.C
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
-	obj, exists, err := c.CommitteeCache.GetByKey(key(seed))
-	if err != nil {
-		return nil, err
-	}
-
+	obj, exists := c.CommitteeCache.Get(key(seed))
 	if exists {
 		CommitteeCacheHit.Inc()
 	} else {
@@ -92,16 +112,18 @@ func (c *CommitteeCache) Committee(slot types.Slot, seed [32]byte, index types.C
 	return item.ShuffledIndices[start:end], nil
 }
 
-// AddCommitteeShuffledList adds Committee shuffled list object to the cache. T
-// his method also trims the least recently list if the cache size has ready the max cache size limit.
+// AddCommitteeShuffledList adds Committee shuffled list object to the cache. This method also
+// evicts the least recently used list if the cache size has reached its limit.
 func (c *CommitteeCache) AddCommitteeShuffledList(committees *Committees) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if err := c.CommitteeCache.AddIfNotPresent(committees); err != nil {
+	k, err := committeeKeyFn(committees)
+	if err != nil {
 		return err
 	}
-	trim(c.CommitteeCache, maxCommitteesCacheSize)
+	c.CommitteeCache.ContainsOrAdd(k, committees)
+	committeeCacheSize.Set(float64(c.CommitteeCache.Len()))
 	return nil
 }
 
@@ -109,11 +131,7 @@ func (c *CommitteeCache) AddCommitteeShuffledList(committees *Committees) error
 func (c *CommitteeCache) ActiveIndices(seed [32]byte) ([]types.ValidatorIndex, error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	obj, exists, err := c.CommitteeCache.GetByKey(key(seed))
-	if err != nil {
-		return nil, err
-	}
-
+	obj, exists := c.CommitteeCache.Get(key(seed))
 	if exists {
 		CommitteeCacheHit.Inc()
 	} else {
@@ -133,11 +151,7 @@ func (c *CommitteeCache) ActiveIndices(seed [32]byte) ([]types.ValidatorIndex, e
 func (c *CommitteeCache) ActiveIndicesCount(seed [32]byte) (int, error) {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	obj, exists, err := c.CommitteeCache.GetByKey(key(seed))
-	if err != nil {
-		return 0, err
-	}
-
+	obj, exists := c.CommitteeCache.Get(key(seed))
 	if exists {
 		CommitteeCacheHit.Inc()
 	} else {
@@ -155,8 +169,9 @@ func (c *CommitteeCache) ActiveIndicesCount(seed [32]byte) (int, error) {
 
 // HasEntry returns true if the committee cache has a value.
 func (c *CommitteeCache) HasEntry(seed string) bool {
-	_, ok, err := c.CommitteeCache.GetByKey(seed)
-	return err == nil && ok
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.CommitteeCache.Contains(seed)
 }
 
 func startEndIndices(c *Committees, index uint64) (uint64, uint64) {
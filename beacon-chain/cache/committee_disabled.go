@@ -14,6 +14,11 @@ func NewCommitteesCache() *FakeCommitteeCache {
 	return &FakeCommitteeCache{}
 }
 
+// NewCommitteesCacheWithSize creates a new committee cache for storing/accessing shuffled indices of a committee.
+func NewCommitteesCacheWithSize(int) *FakeCommitteeCache {
+	return &FakeCommitteeCache{}
+}
+
 // Committee fetches the shuffled indices by slot and committee index. Every list of indices
 // represent one committee. Returns true if the list exists with slot and committee index. Otherwise returns false, nil.
 func (c *FakeCommitteeCache) Committee(slot types.Slot, seed [32]byte, index types.CommitteeIndex) ([]types.ValidatorIndex, error) {
@@ -100,7 +100,10 @@ func TestCommitteeCache_CanRotate(t *testing.T) {
 		require.NoError(t, cache.AddCommitteeShuffledList(item))
 	}
 
-	k := cache.CommitteeCache.ListKeys()
+	k := make([]string, 0, cache.CommitteeCache.Len())
+	for _, cacheKey := range cache.CommitteeCache.Keys() {
+		k = append(k, cacheKey.(string))
+	}
 	assert.Equal(t, maxCommitteesCacheSize, uint64(len(k)))
 
 	sort.Slice(k, func(i, j int) bool {
@@ -117,7 +120,7 @@ func TestCommitteeCache_CanRotate(t *testing.T) {
 func TestCommitteeCacheOutOfRange(t *testing.T) {
 	cache := NewCommitteesCache()
 	seed := bytesutil.ToBytes32([]byte("foo"))
-	err := cache.CommitteeCache.Add(&Committees{
+	err := cache.AddCommitteeShuffledList(&Committees{
 		CommitteeCount:  1,
 		Seed:            seed,
 		ShuffledIndices: []types.ValidatorIndex{0},
@@ -128,3 +131,32 @@ func TestCommitteeCacheOutOfRange(t *testing.T) {
 	_, err = cache.Committee(0, seed, math.MaxUint64) // Overflow!
 	require.NotNil(t, err, "Did not fail as expected")
 }
+
+func TestCommitteeCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCommitteesCacheWithSize(2)
+	items := []*Committees{
+		{Seed: [32]byte{'A'}, SortedIndices: []types.ValidatorIndex{1}},
+		{Seed: [32]byte{'B'}, SortedIndices: []types.ValidatorIndex{2}},
+		{Seed: [32]byte{'C'}, SortedIndices: []types.ValidatorIndex{3}},
+	}
+	require.NoError(t, cache.AddCommitteeShuffledList(items[0]))
+	require.NoError(t, cache.AddCommitteeShuffledList(items[1]))
+
+	// Looking up the first seed makes the second one the least recently used.
+	indices, err := cache.ActiveIndices(items[0].Seed)
+	require.NoError(t, err)
+	assert.DeepEqual(t, items[0].SortedIndices, indices)
+	require.NoError(t, cache.AddCommitteeShuffledList(items[2]))
+
+	assert.Equal(t, true, cache.HasEntry(key(items[0].Seed)))
+	assert.Equal(t, false, cache.HasEntry(key(items[1].Seed)))
+	assert.Equal(t, true, cache.HasEntry(key(items[2].Seed)))
+}
+
+func TestCommitteeCache_DefaultSize(t *testing.T) {
+	cache := NewCommitteesCacheWithSize(0)
+	for i := uint64(0); i < maxCommitteesCacheSize+1; i++ {
+		require.NoError(t, cache.AddCommitteeShuffledList(&Committees{Seed: bytesutil.ToBytes32(bytesutil.Bytes8(i))}))
+	}
+	assert.Equal(t, int(maxCommitteesCacheSize), cache.CommitteeCache.Len())
+}
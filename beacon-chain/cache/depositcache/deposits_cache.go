@@ -138,6 +138,45 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 	}
 }
 
+// FinalizedDepositsSnapshot returns the snapshot of the finalized deposits trie, along with the
+// height of the eth1 block of the last finalized deposit. It returns nil if no deposit is finalized.
+func (dc *DepositCache) FinalizedDepositsSnapshot(ctx context.Context) (*trieutil.DepositTreeSnapshot, error) {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.FinalizedDepositsSnapshot")
+	defer span.End()
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+
+	lastIndex := dc.finalizedDeposits.MerkleTrieIndex
+	if lastIndex < 0 {
+		return nil, nil
+	}
+	var blockHeight uint64
+	i := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= lastIndex })
+	if i < len(dc.deposits) && dc.deposits[i].Index == lastIndex {
+		blockHeight = dc.deposits[i].Eth1BlockHeight
+	}
+	return dc.finalizedDeposits.Deposits.Snapshot(uint64(lastIndex+1), blockHeight)
+}
+
+// RestoreFinalizedDeposits sets the finalized deposits trie to the one of a snapshot, whose finalized
+// leaves are pruned.
+func (dc *DepositCache) RestoreFinalizedDeposits(ctx context.Context, snapshot *trieutil.DepositTreeSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.RestoreFinalizedDeposits")
+	defer span.End()
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	depositTrie, err := trieutil.TrieFromSnapshot(snapshot, nil, params.BeaconConfig().DepositContractTreeDepth)
+	if err != nil {
+		return err
+	}
+	dc.finalizedDeposits = &FinalizedDeposits{
+		Deposits:        depositTrie,
+		MerkleTrieIndex: int64(snapshot.DepositCount) - 1,
+	}
+	return nil
+}
+
 // AllDepositContainers returns all historical deposit containers.
 func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*dbpb.DepositContainer {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.AllDepositContainers")
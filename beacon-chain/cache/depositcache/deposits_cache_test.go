@@ -750,3 +750,40 @@ func makeDepositProof() [][]byte {
 	}
 	return proof
 }
+
+func TestFinalizedDeposits_SnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dc, err := New()
+	require.NoError(t, err)
+	snapshot, err := dc.FinalizedDepositsSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*trieutil.DepositTreeSnapshot)(nil), snapshot)
+
+	for i := 0; i < 5; i++ {
+		dc.InsertDeposit(ctx, &ethpb.Deposit{
+			Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			},
+		}, uint64(10+i), int64(i), [32]byte{})
+	}
+	dc.InsertFinalizedDeposits(ctx, 2)
+	snapshot, err = dc.FinalizedDepositsSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), snapshot.DepositCount)
+	assert.Equal(t, uint64(12), snapshot.ExecutionBlockHeight)
+	assert.Equal(t, dc.FinalizedDeposits(ctx).Deposits.HashTreeRoot(), snapshot.DepositRoot)
+
+	restored, err := New()
+	require.NoError(t, err)
+	restored.InsertDepositContainers(ctx, dc.AllDepositContainers(ctx))
+	require.NoError(t, restored.RestoreFinalizedDeposits(ctx, snapshot))
+	assert.Equal(t, int64(2), restored.FinalizedDeposits(ctx).MerkleTrieIndex)
+	assert.Equal(t, snapshot.DepositRoot, restored.FinalizedDeposits(ctx).Deposits.HashTreeRoot())
+
+	// Deposits finalized after the restart extend the pruned trie.
+	dc.InsertFinalizedDeposits(ctx, 4)
+	restored.InsertFinalizedDeposits(ctx, 4)
+	assert.Equal(t, dc.FinalizedDeposits(ctx).Deposits.HashTreeRoot(), restored.FinalizedDeposits(ctx).Deposits.HashTreeRoot())
+}
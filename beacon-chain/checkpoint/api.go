@@ -0,0 +1,86 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	blockPath = "/eth/v1/beacon/blocks/"
+	statePath = "/eth/v1/debug/beacon/states/"
+	// sszMediaType is the media type requested for SSZ encoded responses.
+	sszMediaType = "application/octet-stream"
+	// maxErrorResponseSize bounds the size of the error messages read from the beacon API.
+	maxErrorResponseSize = 1 << 16
+)
+
+// FromURL fetches the latest finalized block of a trusted beacon node, along with its post state and
+// the genesis state, from the standard beacon API at the given URL.
+func FromURL(ctx context.Context, url string) (*Origin, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = "http://" + url
+	}
+	url = strings.TrimSuffix(url, "/")
+	client := &http.Client{Timeout: 5 * time.Minute}
+
+	data, err := fetchSSZ(ctx, client, url+blockPath+"finalized")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch finalized block")
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := blk.UnmarshalSSZ(data); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal finalized block")
+	}
+	// The state is requested by the state root of the block rather than as the finalized state, as
+	// finality may advance in between the requests.
+	data, err = fetchSSZ(ctx, client, fmt.Sprintf("%s%s%#x", url, statePath, blk.Block.StateRoot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch finalized state")
+	}
+	st, err := decodeState(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode finalized state")
+	}
+	data, err = fetchSSZ(ctx, client, url+statePath+"genesis")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch genesis state")
+	}
+	genesisState, err := decodeState(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode genesis state")
+	}
+	return &Origin{GenesisState: genesisState, State: st, Block: blk}, nil
+}
+
+func fetchSSZ(ctx context.Context, client *http.Client, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", sszMediaType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		msg, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorResponseSize))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read response with status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("request to %s failed with status %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,197 @@
+// Package checkpoint allows a beacon node with an empty database to start from a trusted finalized
+// checkpoint instead of replaying the chain from genesis.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// Origin is the finalized starting point of a node, along with the genesis state of its chain.
+type Origin struct {
+	GenesisState *stateTrie.BeaconState
+	State        *stateTrie.BeaconState
+	Block        *ethpb.SignedBeaconBlock
+}
+
+// FromFiles loads an origin from SSZ encoded genesis state, finalized state and finalized signed
+// block files. The finalized state must be the post state of the finalized block.
+func FromFiles(genesisStatePath, statePath, blockPath string) (*Origin, error) {
+	genesisState, err := readState(genesisStatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load genesis state")
+	}
+	st, err := readState(statePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load checkpoint state")
+	}
+	data, err := ioutil.ReadFile(blockPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read checkpoint block")
+	}
+	blk := &ethpb.SignedBeaconBlock{}
+	if err := blk.UnmarshalSSZ(data); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal checkpoint block")
+	}
+	return &Origin{GenesisState: genesisState, State: st, Block: blk}, nil
+}
+
+func readState(path string) (*stateTrie.BeaconState, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeState(data)
+}
+
+func decodeState(data []byte) (*stateTrie.BeaconState, error) {
+	st := &pb.BeaconState{}
+	if err := st.UnmarshalSSZ(data); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal state")
+	}
+	return stateTrie.InitializeFromProtoUnsafe(st)
+}
+
+// Verify checks that the origin state is the post state of the origin block, and that both
+// belong to the chain of the genesis state.
+func (o *Origin) Verify(ctx context.Context) error {
+	if o.GenesisState == nil || o.State == nil || o.Block == nil || o.Block.Block == nil {
+		return errors.New("incomplete checkpoint origin")
+	}
+	if o.GenesisState.Slot() != 0 {
+		return fmt.Errorf("genesis state has slot %d", o.GenesisState.Slot())
+	}
+	if !bytes.Equal(o.GenesisState.GenesisValidatorRoot(), o.State.GenesisValidatorRoot()) {
+		return errors.New("checkpoint state does not belong to the chain of the genesis state")
+	}
+	if o.State.Slot() != o.Block.Block.Slot {
+		return fmt.Errorf("checkpoint state slot %d does not match block slot %d", o.State.Slot(), o.Block.Block.Slot)
+	}
+	stateRoot, err := o.State.HashTreeRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not hash checkpoint state")
+	}
+	if !bytes.Equal(o.Block.Block.StateRoot, stateRoot[:]) {
+		return fmt.Errorf("checkpoint state root %#x does not match block state root %#x", stateRoot, o.Block.Block.StateRoot)
+	}
+	blockRoot, err := o.Block.Block.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash checkpoint block")
+	}
+	// The latest block header of a post state is the header of its block, with a zero state root.
+	header := o.State.LatestBlockHeader()
+	if header == nil {
+		return errors.New("checkpoint state has no latest block header")
+	}
+	header.StateRoot = stateRoot[:]
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash latest block header")
+	}
+	if headerRoot != blockRoot {
+		return fmt.Errorf("checkpoint state latest block header %#x does not match block root %#x", headerRoot, blockRoot)
+	}
+	return nil
+}
+
+// Save verifies the origin and writes it to an empty database. The genesis block and state are
+// saved as at chain start, while the origin block becomes the justified and finalized checkpoint
+// and the head of the chain, so that fork choice and sync resume from it.
+func (o *Origin) Save(ctx context.Context, beaconDB db.HeadAccessDatabase) error {
+	if err := o.Verify(ctx); err != nil {
+		return errors.Wrap(err, "could not verify checkpoint")
+	}
+	genesisBlock, err := beaconDB.GenesisBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block from db")
+	}
+	if genesisBlock != nil {
+		return errors.New("database is not empty")
+	}
+
+	genesisStateRoot, err := o.GenesisState.HashTreeRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not hash genesis state")
+	}
+	genesisBlk := blocks.NewGenesisBlock(genesisStateRoot[:])
+	genesisBlkRoot, err := genesisBlk.Block.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block root")
+	}
+	if err := beaconDB.SaveBlock(ctx, genesisBlk); err != nil {
+		return errors.Wrap(err, "could not save genesis block")
+	}
+	if err := beaconDB.SaveStateSummary(ctx, &pb.StateSummary{
+		Slot: 0,
+		Root: genesisBlkRoot[:],
+	}); err != nil {
+		return err
+	}
+	if err := beaconDB.SaveState(ctx, o.GenesisState, genesisBlkRoot); err != nil {
+		return errors.Wrap(err, "could not save genesis state")
+	}
+	if err := beaconDB.SaveGenesisBlockRoot(ctx, genesisBlkRoot); err != nil {
+		return errors.Wrap(err, "could not save genesis block root")
+	}
+
+	blockRoot, err := o.Block.Block.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash checkpoint block")
+	}
+	if err := beaconDB.SaveBlock(ctx, o.Block); err != nil {
+		return errors.Wrap(err, "could not save checkpoint block")
+	}
+	if err := beaconDB.SaveStateSummary(ctx, &pb.StateSummary{
+		Slot: o.Block.Block.Slot,
+		Root: blockRoot[:],
+	}); err != nil {
+		return err
+	}
+	if err := beaconDB.SaveState(ctx, o.State, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save checkpoint state")
+	}
+	if err := beaconDB.SaveOriginBlockRoot(ctx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save origin block root")
+	}
+	cp := &ethpb.Checkpoint{
+		Epoch: checkpointEpoch(o.Block.Block.Slot),
+		Root:  blockRoot[:],
+	}
+	if err := beaconDB.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := beaconDB.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	if err := beaconDB.SaveHeadBlockRoot(ctx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	log.WithFields(logrus.Fields{
+		"slot":  o.Block.Block.Slot,
+		"epoch": cp.Epoch,
+		"root":  fmt.Sprintf("%#x", blockRoot),
+	}).Info("Saved checkpoint to start syncing from")
+	return nil
+}
+
+// checkpointEpoch returns the first epoch whose checkpoint block can be the block of a slot.
+func checkpointEpoch(slot types.Slot) types.Epoch {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	epoch := types.Epoch(slot / slotsPerEpoch)
+	if slot%slotsPerEpoch != 0 {
+		epoch++
+	}
+	return epoch
+}
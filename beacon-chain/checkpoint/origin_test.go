@@ -0,0 +1,138 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func testOrigin(t *testing.T, slot types.Slot) *Origin {
+	genesisState, _ := testutil.DeterministicGenesisState(t, 64)
+	st := genesisState.Copy()
+	require.NoError(t, st.SetSlot(slot))
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	blk.Block.ParentRoot = bytesutil.PadTo([]byte("parent"), 32)
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: blk.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	return &Origin{GenesisState: genesisState, State: st, Block: blk}
+}
+
+func TestOrigin_Verify(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, testOrigin(t, 100).Verify(ctx))
+
+	o := testOrigin(t, 100)
+	o.Block.Block.StateRoot = make([]byte, 32)
+	assert.ErrorContains(t, "does not match block state root", o.Verify(ctx))
+
+	o = testOrigin(t, 100)
+	o.Block.Block.ProposerIndex = 1
+	assert.ErrorContains(t, "latest block header", o.Verify(ctx))
+
+	o = testOrigin(t, 100)
+	require.NoError(t, o.State.SetSlot(101))
+	assert.ErrorContains(t, "does not match block slot", o.Verify(ctx))
+
+	o = testOrigin(t, 100)
+	require.NoError(t, o.GenesisState.SetGenesisValidatorRoot(bytesutil.PadTo([]byte("other"), 32)))
+	assert.ErrorContains(t, "does not belong to the chain", o.Verify(ctx))
+}
+
+func TestOrigin_Save(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	o := testOrigin(t, 100)
+	require.NoError(t, o.Save(ctx, db))
+
+	blockRoot, err := o.Block.Block.HashTreeRoot()
+	require.NoError(t, err)
+	originRoot, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, originRoot)
+
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(100/params.BeaconConfig().SlotsPerEpoch+1), finalized.Epoch)
+	assert.DeepEqual(t, blockRoot[:], finalized.Root)
+	justified, err := db.JustifiedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, finalized, justified)
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, blockRoot))
+
+	head, err := db.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, o.Block, head)
+	st, err := db.State(ctx, blockRoot)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(100), st.Slot())
+	genesisState, err := db.GenesisState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(0), genesisState.Slot())
+
+	assert.ErrorContains(t, "database is not empty", o.Save(ctx, db))
+}
+
+func TestFromURL(t *testing.T) {
+	o := testOrigin(t, 64)
+	blockSSZ, err := o.Block.MarshalSSZ()
+	require.NoError(t, err)
+	stateSSZ, err := o.State.InnerStateUnsafe().MarshalSSZ()
+	require.NoError(t, err)
+	genesisSSZ, err := o.GenesisState.InnerStateUnsafe().MarshalSSZ()
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(blockPath+"finalized", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, sszMediaType, r.Header.Get("Accept"))
+		_, err := w.Write(blockSSZ)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc(fmt.Sprintf("%s%#x", statePath, o.Block.Block.StateRoot), func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(stateSSZ)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc(statePath+"genesis", func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(genesisSSZ)
+		require.NoError(t, err)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	fetched, err := FromURL(context.Background(), srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, fetched.Verify(context.Background()))
+	assert.DeepEqual(t, o.Block, fetched.Block)
+	assert.Equal(t, types.Slot(64), fetched.State.Slot())
+	assert.Equal(t, types.Slot(0), fetched.GenesisState.Slot())
+}
+
+func TestFromURL_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "block not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := FromURL(context.Background(), srv.URL)
+	assert.ErrorContains(t, "failed with status 404: block not found", err)
+}
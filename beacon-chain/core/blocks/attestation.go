@@ -3,6 +3,8 @@ package blocks
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
@@ -12,6 +14,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
@@ -26,15 +29,7 @@ func ProcessAttestations(
 	if err := helpers.VerifyNilBeaconBlock(b); err != nil {
 		return nil, err
 	}
-
-	var err error
-	for idx, attestation := range b.Block.Body.Attestations {
-		beaconState, err = ProcessAttestation(ctx, beaconState, attestation)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not verify attestation at index %d in block", idx)
-		}
-	}
-	return beaconState, nil
+	return processAttestations(ctx, beaconState, b.Block.Body.Attestations, true /* verifySignatures */)
 }
 
 // ProcessAttestation verifies an input attestation can pass through processing using the given beacon state.
@@ -88,12 +83,64 @@ func ProcessAttestationsNoVerifySignature(
 	if err := helpers.VerifyNilBeaconBlock(b); err != nil {
 		return nil, err
 	}
-	body := b.Block.Body
-	var err error
-	for idx, attestation := range body.Attestations {
-		beaconState, err = ProcessAttestationNoVerifySignature(ctx, beaconState, attestation)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not verify attestation at index %d in block", idx)
+	return processAttestations(ctx, beaconState, b.Block.Body.Attestations, false /* verifySignatures */)
+}
+
+// attestationsChunk is the outcome of validating a contiguous chunk of the attestations of a block.
+type attestationsChunk struct {
+	pendingAtts []*pb.PendingAttestation
+	err         error
+}
+
+// processAttestations validates the attestations of a block across CPU cores, as each attestation
+// is validated independently against the same read-only beacon state. The resulting pending
+// attestations are then appended to the state in block order.
+func processAttestations(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	atts []*ethpb.Attestation,
+	verifySignatures bool,
+) (*stateTrie.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "core.processAttestations")
+	defer span.End()
+
+	if len(atts) == 0 {
+		return beaconState, nil
+	}
+	// Errors are returned within the chunks, as the scatter returns as soon as a worker fails.
+	results, err := mputil.Scatter(len(atts), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		chunk := &attestationsChunk{pendingAtts: make([]*pb.PendingAttestation, 0, entries)}
+		for i := offset; i < offset+entries; i++ {
+			pendingAtt, err := validateAttestationNoVerifySignature(ctx, beaconState, atts[i])
+			if err == nil && verifySignatures {
+				err = VerifyAttestationSignature(ctx, beaconState, atts[i])
+			}
+			if err != nil {
+				chunk.err = errors.Wrapf(err, "could not verify attestation at index %d in block", i)
+				break
+			}
+			chunk.pendingAtts = append(chunk.pendingAtts, pendingAtt)
+		}
+		return chunk, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Offset < results[j].Offset
+	})
+	for _, result := range results {
+		chunk, ok := result.Extent.(*attestationsChunk)
+		if !ok {
+			return nil, errors.New("extent not of expected type")
+		}
+		if chunk.err != nil {
+			return nil, chunk.err
+		}
+		for _, pendingAtt := range chunk.pendingAtts {
+			if beaconState, err = appendPendingAttestation(beaconState, pendingAtt); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return beaconState, nil
@@ -109,6 +156,20 @@ func ProcessAttestationNoVerifySignature(
 	ctx, span := trace.StartSpan(ctx, "core.ProcessAttestationNoVerifySignature")
 	defer span.End()
 
+	pendingAtt, err := validateAttestationNoVerifySignature(ctx, beaconState, att)
+	if err != nil {
+		return nil, err
+	}
+	return appendPendingAttestation(beaconState, pendingAtt)
+}
+
+// validates the attestation against the beacon state without verifying its signature, and returns
+// the pending attestation to append to the state. The beacon state is only read.
+func validateAttestationNoVerifySignature(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	att *ethpb.Attestation,
+) (*pb.PendingAttestation, error) {
 	if err := helpers.ValidateNilAttestation(att); err != nil {
 		return nil, err
 	}
@@ -174,16 +235,10 @@ func ProcessAttestationNoVerifySignature(
 		if !beaconState.MatchCurrentJustifiedCheckpoint(data.Source) {
 			return nil, errors.New("source check point not equal to current justified checkpoint")
 		}
-		if err := beaconState.AppendCurrentEpochAttestations(pendingAtt); err != nil {
-			return nil, err
-		}
 	} else {
 		if !beaconState.MatchPreviousJustifiedCheckpoint(data.Source) {
 			return nil, errors.New("source check point not equal to previous justified checkpoint")
 		}
-		if err := beaconState.AppendPreviousEpochAttestations(pendingAtt); err != nil {
-			return nil, err
-		}
 	}
 
 	// Verify attesting indices are correct.
@@ -199,6 +254,20 @@ func ProcessAttestationNoVerifySignature(
 		return nil, err
 	}
 
+	return pendingAtt, nil
+}
+
+// appends the validated pending attestation to the attestations of its target epoch.
+func appendPendingAttestation(beaconState *stateTrie.BeaconState, pendingAtt *pb.PendingAttestation) (*stateTrie.BeaconState, error) {
+	if pendingAtt.Data.Target.Epoch == helpers.CurrentEpoch(beaconState) {
+		if err := beaconState.AppendCurrentEpochAttestations(pendingAtt); err != nil {
+			return nil, err
+		}
+		return beaconState, nil
+	}
+	if err := beaconState.AppendPreviousEpochAttestations(pendingAtt); err != nil {
+		return nil, err
+	}
 	return beaconState, nil
 }
 
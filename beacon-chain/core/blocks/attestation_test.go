@@ -402,6 +402,50 @@ func TestProcessAttestationsNoVerify_OK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestProcessAttestationsNoVerifySignature_KeepsBlockOrder(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 100)
+	require.NoError(t, beaconState.SetSlot(beaconState.Slot()+params.BeaconConfig().MinAttestationInclusionDelay))
+	ckp := beaconState.CurrentJustifiedCheckpoint()
+	copy(ckp.Root, "hello-world")
+	require.NoError(t, beaconState.SetCurrentJustifiedCheckpoint(ckp))
+	require.NoError(t, beaconState.SetCurrentEpochAttestations([]*pb.PendingAttestation{}))
+
+	var mockRoot [32]byte
+	copy(mockRoot[:], "hello-world")
+	atts := make([]*ethpb.Attestation, 20)
+	for i := range atts {
+		aggBits := bitfield.NewBitlist(3)
+		aggBits.SetBitAt(uint64(i%3), true)
+		atts[i] = &ethpb.Attestation{
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: bytesutil.PadTo([]byte{byte(i)}, 32),
+				Source:          &ethpb.Checkpoint{Epoch: 0, Root: mockRoot[:]},
+				Target:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+			},
+			AggregationBits: aggBits,
+			Signature:       make([]byte, 96),
+		}
+	}
+	b := testutil.NewBeaconBlock()
+	b.Block.Body.Attestations = atts
+
+	// Invalid attestations are reported in block order.
+	atts[12].Data.CommitteeIndex = 100
+	atts[5].Data.CommitteeIndex = 100
+	_, err := blocks.ProcessAttestationsNoVerifySignature(context.Background(), beaconState.Copy(), b)
+	assert.ErrorContains(t, "could not verify attestation at index 5 in block", err)
+
+	atts[12].Data.CommitteeIndex = 0
+	atts[5].Data.CommitteeIndex = 0
+	newState, err := blocks.ProcessAttestationsNoVerifySignature(context.Background(), beaconState, b)
+	require.NoError(t, err)
+	pendingAtts := newState.CurrentEpochAttestations()
+	require.Equal(t, len(atts), len(pendingAtts))
+	for i, pendingAtt := range pendingAtts {
+		assert.DeepEqual(t, atts[i].Data, pendingAtt.Data, "Pending attestation %d is out of order", i)
+	}
+}
+
 func TestProcessAttestationsNoVerify_BadAttIdx(t *testing.T) {
 	beaconState, _ := testutil.DeterministicGenesisState(t, 100)
 	aggBits := bitfield.NewBitlist(3)
@@ -38,6 +38,25 @@ func ProcessAttesterSlashings(
 	ctx context.Context,
 	beaconState *stateTrie.BeaconState,
 	b *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	return processAttesterSlashings(ctx, beaconState, b, VerifyAttesterSlashing)
+}
+
+// ProcessAttesterSlashingsNoVerifySignature processes the attester slashings of the block without
+// verifying the signatures of their attestations, which are retrieved with AttesterSlashingsSignatureSet.
+func ProcessAttesterSlashingsNoVerifySignature(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	b *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	return processAttesterSlashings(ctx, beaconState, b, verifyAttesterSlashingConditions)
+}
+
+func processAttesterSlashings(
+	ctx context.Context,
+	beaconState *stateTrie.BeaconState,
+	b *ethpb.SignedBeaconBlock,
+	verify func(context.Context, *stateTrie.BeaconState, *ethpb.AttesterSlashing) error,
 ) (*stateTrie.BeaconState, error) {
 	if err := helpers.VerifyNilBeaconBlock(b); err != nil {
 		return nil, err
@@ -45,7 +64,7 @@ func ProcessAttesterSlashings(
 
 	body := b.Block.Body
 	for idx, slashing := range body.AttesterSlashings {
-		if err := VerifyAttesterSlashing(ctx, beaconState, slashing); err != nil {
+		if err := verify(ctx, beaconState, slashing); err != nil {
 			return nil, errors.Wrapf(err, "could not verify attester slashing %d", idx)
 		}
 		slashableIndices := slashableAttesterIndices(slashing)
@@ -79,6 +98,20 @@ func ProcessAttesterSlashings(
 
 // VerifyAttesterSlashing validates the attestation data in both attestations in the slashing object.
 func VerifyAttesterSlashing(ctx context.Context, beaconState *stateTrie.BeaconState, slashing *ethpb.AttesterSlashing) error {
+	if err := verifyAttesterSlashingConditions(ctx, beaconState, slashing); err != nil {
+		return err
+	}
+	if err := VerifyIndexedAttestation(ctx, beaconState, slashing.Attestation_1); err != nil {
+		return errors.Wrap(err, "could not validate indexed attestation")
+	}
+	if err := VerifyIndexedAttestation(ctx, beaconState, slashing.Attestation_2); err != nil {
+		return errors.Wrap(err, "could not validate indexed attestation")
+	}
+	return nil
+}
+
+// verifies the attester slashing conditions, except for the signatures of the attestations.
+func verifyAttesterSlashingConditions(ctx context.Context, _ *stateTrie.BeaconState, slashing *ethpb.AttesterSlashing) error {
 	if slashing == nil {
 		return errors.New("nil slashing")
 	}
@@ -95,10 +128,10 @@ func VerifyAttesterSlashing(ctx context.Context, beaconState *stateTrie.BeaconSt
 	if !IsSlashableAttestationData(data1, data2) {
 		return errors.New("attestations are not slashable")
 	}
-	if err := VerifyIndexedAttestation(ctx, beaconState, att1); err != nil {
+	if err := attestationutil.IsValidAttestationIndices(ctx, att1); err != nil {
 		return errors.Wrap(err, "could not validate indexed attestation")
 	}
-	if err := VerifyIndexedAttestation(ctx, beaconState, att2); err != nil {
+	if err := attestationutil.IsValidAttestationIndices(ctx, att2); err != nil {
 		return errors.Wrap(err, "could not validate indexed attestation")
 	}
 	return nil
@@ -48,6 +48,24 @@ func ProcessVoluntaryExits(
 	_ context.Context,
 	beaconState *stateTrie.BeaconState,
 	b *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	return processVoluntaryExits(beaconState, b, true /* verifySignatures */)
+}
+
+// ProcessVoluntaryExitsNoVerifySignature processes the voluntary exits of the block without
+// verifying their signatures, which are retrieved with VoluntaryExitsSignatureSet.
+func ProcessVoluntaryExitsNoVerifySignature(
+	_ context.Context,
+	beaconState *stateTrie.BeaconState,
+	b *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	return processVoluntaryExits(beaconState, b, false /* verifySignatures */)
+}
+
+func processVoluntaryExits(
+	beaconState *stateTrie.BeaconState,
+	b *ethpb.SignedBeaconBlock,
+	verifySignatures bool,
 ) (*stateTrie.BeaconState, error) {
 	if err := helpers.VerifyNilBeaconBlock(b); err != nil {
 		return nil, err
@@ -63,7 +81,12 @@ func ProcessVoluntaryExits(
 		if err != nil {
 			return nil, err
 		}
-		if err := VerifyExitAndSignature(val, beaconState.Slot(), beaconState.Fork(), exit, beaconState.GenesisValidatorRoot()); err != nil {
+		if verifySignatures {
+			err = VerifyExitAndSignature(val, beaconState.Slot(), beaconState.Fork(), exit, beaconState.GenesisValidatorRoot())
+		} else {
+			err = verifyExitConditions(val, beaconState.Slot(), exit.Exit)
+		}
+		if err != nil {
 			return nil, errors.Wrapf(err, "could not verify exit %d", idx)
 		}
 		beaconState, err = v.InitiateValidatorExit(beaconState, exit.Exit.ValidatorIndex)
@@ -130,3 +130,54 @@ func TestProcessVoluntaryExits_AppliesCorrectStatus(t *testing.T) {
 			helpers.ActivationExitEpoch(types.Epoch(state.Slot()/params.BeaconConfig().SlotsPerEpoch)), newRegistry[0].ExitEpoch)
 	}
 }
+
+func TestProcessVoluntaryExitsNoVerifySignature_AppliesCorrectStatus(t *testing.T) {
+	exits := []*ethpb.SignedVoluntaryExit{
+		{
+			Exit: &ethpb.VoluntaryExit{
+				ValidatorIndex: 0,
+				Epoch:          0,
+			},
+			Signature: make([]byte, 96),
+		},
+	}
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	registry := []*ethpb.Validator{
+		{
+			PublicKey:       priv.PublicKey().Marshal(),
+			ExitEpoch:       params.BeaconConfig().FarFutureEpoch,
+			ActivationEpoch: 0,
+		},
+	}
+	state, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Validators: registry,
+		Fork: &pb.Fork{
+			CurrentVersion:  params.BeaconConfig().GenesisForkVersion,
+			PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+		},
+		Slot: params.BeaconConfig().SlotsPerEpoch * 5,
+	})
+	require.NoError(t, err)
+	err = state.SetSlot(state.Slot() + params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().ShardCommitteePeriod)))
+	require.NoError(t, err)
+
+	b := testutil.NewBeaconBlock()
+	b.Block = &ethpb.BeaconBlock{
+		Body: &ethpb.BeaconBlockBody{
+			VoluntaryExits: exits,
+		},
+	}
+
+	_, err = blocks.ProcessVoluntaryExits(context.Background(), state.Copy(), b)
+	require.ErrorContains(t, "could not verify exit 0", err)
+
+	// The invalid signature is only caught when verifying the signature set of the exits.
+	newState, err := blocks.ProcessVoluntaryExitsNoVerifySignature(context.Background(), state, b)
+	require.NoError(t, err, "Could not process exits")
+	assert.Equal(t, helpers.ActivationExitEpoch(helpers.CurrentEpoch(state)), newState.Validators()[0].ExitEpoch)
+	set, err := blocks.VoluntaryExitsSignatureSet(state, exits)
+	require.NoError(t, err)
+	verified, err := set.Verify()
+	assert.Equal(t, false, err == nil && verified, "Expected the exit signature set to fail verification")
+}
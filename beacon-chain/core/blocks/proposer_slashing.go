@@ -40,6 +40,24 @@ func ProcessProposerSlashings(
 	_ context.Context,
 	beaconState *stateTrie.BeaconState,
 	b *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	return processProposerSlashings(beaconState, b, VerifyProposerSlashing)
+}
+
+// ProcessProposerSlashingsNoVerifySignature processes the proposer slashings of the block without
+// verifying the signatures of their headers, which are retrieved with ProposerSlashingsSignatureSet.
+func ProcessProposerSlashingsNoVerifySignature(
+	_ context.Context,
+	beaconState *stateTrie.BeaconState,
+	b *ethpb.SignedBeaconBlock,
+) (*stateTrie.BeaconState, error) {
+	return processProposerSlashings(beaconState, b, verifyProposerSlashingConditions)
+}
+
+func processProposerSlashings(
+	beaconState *stateTrie.BeaconState,
+	b *ethpb.SignedBeaconBlock,
+	verify func(*stateTrie.BeaconState, *ethpb.ProposerSlashing) error,
 ) (*stateTrie.BeaconState, error) {
 	if err := helpers.VerifyNilBeaconBlock(b); err != nil {
 		return nil, err
@@ -51,7 +69,7 @@ func ProcessProposerSlashings(
 		if slashing == nil {
 			return nil, errors.New("nil proposer slashings in block body")
 		}
-		if err = VerifyProposerSlashing(beaconState, slashing); err != nil {
+		if err = verify(beaconState, slashing); err != nil {
 			return nil, errors.Wrapf(err, "could not verify proposer slashing %d", idx)
 		}
 		beaconState, err = v.SlashValidator(
@@ -68,6 +86,26 @@ func ProcessProposerSlashings(
 func VerifyProposerSlashing(
 	beaconState *stateTrie.BeaconState,
 	slashing *ethpb.ProposerSlashing,
+) error {
+	if err := verifyProposerSlashingConditions(beaconState, slashing); err != nil {
+		return err
+	}
+	hSlot := slashing.Header_1.Header.Slot
+	pIdx := slashing.Header_1.Header.ProposerIndex
+	headers := []*ethpb.SignedBeaconBlockHeader{slashing.Header_1, slashing.Header_2}
+	for _, header := range headers {
+		if err := helpers.ComputeDomainVerifySigningRoot(beaconState, pIdx, helpers.SlotToEpoch(hSlot),
+			header.Header, params.BeaconConfig().DomainBeaconProposer, header.Signature); err != nil {
+			return errors.Wrap(err, "could not verify beacon block header")
+		}
+	}
+	return nil
+}
+
+// verifies the proposer slashing conditions, except for the signatures of the headers.
+func verifyProposerSlashingConditions(
+	beaconState *stateTrie.BeaconState,
+	slashing *ethpb.ProposerSlashing,
 ) error {
 	if slashing.Header_1 == nil || slashing.Header_1.Header == nil || slashing.Header_2 == nil || slashing.Header_2.Header == nil {
 		return errors.New("nil header cannot be verified")
@@ -90,12 +128,5 @@ func VerifyProposerSlashing(
 	if !helpers.IsSlashableValidatorUsingTrie(proposer, helpers.CurrentEpoch(beaconState)) {
 		return fmt.Errorf("validator with key %#x is not slashable", proposer.PublicKey())
 	}
-	headers := []*ethpb.SignedBeaconBlockHeader{slashing.Header_1, slashing.Header_2}
-	for _, header := range headers {
-		if err := helpers.ComputeDomainVerifySigningRoot(beaconState, pIdx, helpers.SlotToEpoch(hSlot),
-			header.Header, params.BeaconConfig().DomainBeaconProposer, header.Signature); err != nil {
-			return errors.Wrap(err, "could not verify beacon block header")
-		}
-	}
 	return nil
 }
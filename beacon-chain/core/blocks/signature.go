@@ -3,6 +3,7 @@ package blocks
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
@@ -86,7 +87,12 @@ func BlockSignatureSet(beaconState *stateTrie.BeaconState, block *ethpb.SignedBe
 		return nil, err
 	}
 	proposerPubKey := proposer.PublicKey
-	return helpers.BlockSignatureSet(block.Block, proposerPubKey, block.Signature, domain)
+	set, err := helpers.BlockSignatureSet(block.Block, proposerPubKey, block.Signature, domain)
+	if err != nil {
+		return nil, err
+	}
+	set.Descriptions = []string{"block signature"}
+	return set, nil
 }
 
 // RandaoSignatureSet retrieves the relevant randao specific signature set object
@@ -102,6 +108,7 @@ func RandaoSignatureSet(beaconState *stateTrie.BeaconState,
 	if err != nil {
 		return nil, err
 	}
+	set.Descriptions = []string{"randao signature"}
 	return set, nil
 }
 
@@ -133,8 +140,10 @@ func createAttestationSignatureSet(ctx context.Context, beaconState *stateTrie.B
 	sigs := make([][]byte, len(atts))
 	pks := make([]bls.PublicKey, len(atts))
 	msgs := make([][32]byte, len(atts))
+	descs := make([]string, len(atts))
 	for i, a := range atts {
 		sigs[i] = a.Signature
+		descs[i] = fmt.Sprintf("attestation signature of slot %d committee %d", a.Data.Slot, a.Data.CommitteeIndex)
 		c, err := helpers.BeaconCommitteeFromState(beaconState, a.Data.Slot, a.Data.CommitteeIndex)
 		if err != nil {
 			return nil, err
@@ -165,9 +174,10 @@ func createAttestationSignatureSet(ctx context.Context, beaconState *stateTrie.B
 		msgs[i] = root
 	}
 	return &bls.SignatureSet{
-		Signatures: sigs,
-		PublicKeys: pks,
-		Messages:   msgs,
+		Signatures:   sigs,
+		PublicKeys:   pks,
+		Messages:     msgs,
+		Descriptions: descs,
 	}, nil
 }
 
@@ -223,3 +233,108 @@ func AttestationSignatureSet(ctx context.Context, beaconState *stateTrie.BeaconS
 	}
 	return set.Join(aSet), nil
 }
+
+// ProposerSlashingsSignatureSet retrieves the signature set of the block headers of the given
+// proposer slashings.
+func ProposerSlashingsSignatureSet(beaconState *stateTrie.BeaconState, slashings []*ethpb.ProposerSlashing) (*bls.SignatureSet, error) {
+	set := bls.NewSet()
+	for i, slashing := range slashings {
+		if slashing.Header_1 == nil || slashing.Header_1.Header == nil || slashing.Header_2 == nil || slashing.Header_2.Header == nil {
+			return nil, errors.New("nil header cannot be verified")
+		}
+		for j, header := range []*ethpb.SignedBeaconBlockHeader{slashing.Header_1, slashing.Header_2} {
+			domain, err := helpers.Domain(beaconState.Fork(), helpers.SlotToEpoch(header.Header.Slot),
+				params.BeaconConfig().DomainBeaconProposer, beaconState.GenesisValidatorRoot())
+			if err != nil {
+				return nil, err
+			}
+			root, err := header.Header.HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not hash block header")
+			}
+			proposerPub := beaconState.PubkeyAtIndex(header.Header.ProposerIndex)
+			hSet, err := signatureSet(root[:], proposerPub[:], header.Signature, domain)
+			if err != nil {
+				return nil, err
+			}
+			hSet.Descriptions = []string{fmt.Sprintf("proposer slashing %d header %d signature", i, j+1)}
+			set.Join(hSet)
+		}
+	}
+	return set, nil
+}
+
+// AttesterSlashingsSignatureSet retrieves the signature set of the indexed attestations of the
+// given attester slashings.
+func AttesterSlashingsSignatureSet(ctx context.Context, beaconState *stateTrie.BeaconState, slashings []*ethpb.AttesterSlashing) (*bls.SignatureSet, error) {
+	set := bls.NewSet()
+	for i, slashing := range slashings {
+		if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+			return nil, errors.New("nil attestation")
+		}
+		for j, att := range []*ethpb.IndexedAttestation{slashing.Attestation_1, slashing.Attestation_2} {
+			aSet, err := indexedAttestationSignatureSet(ctx, beaconState, att)
+			if err != nil {
+				return nil, err
+			}
+			aSet.Descriptions = []string{fmt.Sprintf("attester slashing %d attestation %d signature", i, j+1)}
+			set.Join(aSet)
+		}
+	}
+	return set, nil
+}
+
+// VoluntaryExitsSignatureSet retrieves the signature set of the given voluntary exits.
+func VoluntaryExitsSignatureSet(beaconState *stateTrie.BeaconState, exits []*ethpb.SignedVoluntaryExit) (*bls.SignatureSet, error) {
+	set := bls.NewSet()
+	for _, exit := range exits {
+		if exit == nil || exit.Exit == nil {
+			return nil, errors.New("nil exit")
+		}
+		domain, err := helpers.Domain(beaconState.Fork(), exit.Exit.Epoch, params.BeaconConfig().DomainVoluntaryExit, beaconState.GenesisValidatorRoot())
+		if err != nil {
+			return nil, err
+		}
+		root, err := exit.Exit.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash voluntary exit")
+		}
+		valPub := beaconState.PubkeyAtIndex(exit.Exit.ValidatorIndex)
+		eSet, err := signatureSet(root[:], valPub[:], exit.Signature, domain)
+		if err != nil {
+			return nil, err
+		}
+		eSet.Descriptions = []string{fmt.Sprintf("voluntary exit signature of validator %d", exit.Exit.ValidatorIndex)}
+		set.Join(eSet)
+	}
+	return set, nil
+}
+
+// retrieves the signature set of an indexed attestation, whose attesting public keys are aggregated.
+func indexedAttestationSignatureSet(ctx context.Context, beaconState *stateTrie.BeaconState, indexedAtt *ethpb.IndexedAttestation) (*bls.SignatureSet, error) {
+	if err := attestationutil.IsValidAttestationIndices(ctx, indexedAtt); err != nil {
+		return nil, err
+	}
+	domain, err := helpers.Domain(beaconState.Fork(), indexedAtt.Data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, beaconState.GenesisValidatorRoot())
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := make([][]byte, len(indexedAtt.AttestingIndices))
+	for i, idx := range indexedAtt.AttestingIndices {
+		pubkeyAtIdx := beaconState.PubkeyAtIndex(types.ValidatorIndex(idx))
+		pubkeys[i] = pubkeyAtIdx[:]
+	}
+	aggP, err := bls.AggregatePublicKeys(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	root, err := helpers.ComputeSigningRoot(indexedAtt.Data, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get signing root of object")
+	}
+	return &bls.SignatureSet{
+		Signatures: [][]byte{indexedAtt.Signature},
+		PublicKeys: []bls.PublicKey{aggP},
+		Messages:   [][32]byte{root},
+	}, nil
+}
@@ -69,36 +69,54 @@ func AttestationsDelta(state *stateTrie.BeaconState, pBal *Balance, vp []*Valida
 	return rewards, penalties, nil
 }
 
+// AttestationsDeltaBreakdown computes and returns the breakdown of the rewards and penalties for individual
+// validators based on the voting records. The totals of the breakdown are the deltas of AttestationsDelta.
+func AttestationsDeltaBreakdown(state *stateTrie.BeaconState, pBal *Balance, vp []*Validator) []*AttestationDelta {
+	prevEpoch := helpers.PrevEpoch(state)
+	finalizedEpoch := state.FinalizedCheckpointEpoch()
+
+	deltas := make([]*AttestationDelta, len(vp))
+	for i, v := range vp {
+		deltas[i] = attestationDeltaBreakdown(pBal, v, prevEpoch, finalizedEpoch)
+	}
+	return deltas
+}
+
 func attestationDelta(pBal *Balance, v *Validator, prevEpoch, finalizedEpoch types.Epoch) (uint64, uint64) {
+	d := attestationDeltaBreakdown(pBal, v, prevEpoch, finalizedEpoch)
+	return d.Reward(), d.Penalty()
+}
+
+func attestationDeltaBreakdown(pBal *Balance, v *Validator, prevEpoch, finalizedEpoch types.Epoch) *AttestationDelta {
+	d := &AttestationDelta{}
 	eligible := v.IsActivePrevEpoch || (v.IsSlashed && !v.IsWithdrawableCurrentEpoch)
 	if !eligible || pBal.ActiveCurrentEpoch == 0 {
-		return 0, 0
+		return d
 	}
 
 	baseRewardsPerEpoch := params.BeaconConfig().BaseRewardsPerEpoch
 	effectiveBalanceIncrement := params.BeaconConfig().EffectiveBalanceIncrement
 	vb := v.CurrentEpochEffectiveBalance
 	br := vb * params.BeaconConfig().BaseRewardFactor / mathutil.IntegerSquareRoot(pBal.ActiveCurrentEpoch) / baseRewardsPerEpoch
-	r, p := uint64(0), uint64(0)
 	currentEpochBalance := pBal.ActiveCurrentEpoch / effectiveBalanceIncrement
 
 	// Process source reward / penalty
 	if v.IsPrevEpochAttester && !v.IsSlashed {
 		proposerReward := br / params.BeaconConfig().ProposerRewardQuotient
 		maxAttesterReward := br - proposerReward
-		r += maxAttesterReward / uint64(v.InclusionDistance)
+		d.InclusionDelayReward = maxAttesterReward / uint64(v.InclusionDistance)
 
 		if isInInactivityLeak(prevEpoch, finalizedEpoch) {
 			// Since full base reward will be canceled out by inactivity penalty deltas,
 			// optimal participation receives full base reward compensation here.
-			r += br
+			d.SourceReward = br
 		} else {
 			rewardNumerator := br * (pBal.PrevEpochAttested / effectiveBalanceIncrement)
-			r += rewardNumerator / currentEpochBalance
+			d.SourceReward = rewardNumerator / currentEpochBalance
 
 		}
 	} else {
-		p += br
+		d.SourcePenalty = br
 	}
 
 	// Process target reward / penalty
@@ -106,13 +124,13 @@ func attestationDelta(pBal *Balance, v *Validator, prevEpoch, finalizedEpoch typ
 		if isInInactivityLeak(prevEpoch, finalizedEpoch) {
 			// Since full base reward will be canceled out by inactivity penalty deltas,
 			// optimal participation receives full base reward compensation here.
-			r += br
+			d.TargetReward = br
 		} else {
 			rewardNumerator := br * (pBal.PrevEpochTargetAttested / effectiveBalanceIncrement)
-			r += rewardNumerator / currentEpochBalance
+			d.TargetReward = rewardNumerator / currentEpochBalance
 		}
 	} else {
-		p += br
+		d.TargetPenalty = br
 	}
 
 	// Process head reward / penalty
@@ -120,13 +138,13 @@ func attestationDelta(pBal *Balance, v *Validator, prevEpoch, finalizedEpoch typ
 		if isInInactivityLeak(prevEpoch, finalizedEpoch) {
 			// Since full base reward will be canceled out by inactivity penalty deltas,
 			// optimal participation receives full base reward compensation here.
-			r += br
+			d.HeadReward = br
 		} else {
 			rewardNumerator := br * (pBal.PrevEpochHeadAttested / effectiveBalanceIncrement)
-			r += rewardNumerator / currentEpochBalance
+			d.HeadReward = rewardNumerator / currentEpochBalance
 		}
 	} else {
-		p += br
+		d.HeadPenalty = br
 	}
 
 	// Process finality delay penalty
@@ -135,15 +153,15 @@ func attestationDelta(pBal *Balance, v *Validator, prevEpoch, finalizedEpoch typ
 	if isInInactivityLeak(prevEpoch, finalizedEpoch) {
 		// If validator is performing optimally, this cancels all rewards for a neutral balance.
 		proposerReward := br / params.BeaconConfig().ProposerRewardQuotient
-		p += baseRewardsPerEpoch*br - proposerReward
+		d.InactivityPenalty = baseRewardsPerEpoch*br - proposerReward
 		// Apply an additional penalty to validators that did not vote on the correct target or has been slashed.
 		// Equivalent to the following condition from the spec:
 		// `index not in get_unslashed_attesting_indices(state, matching_target_attestations)`
 		if !v.IsPrevEpochTargetAttester || v.IsSlashed {
-			p += vb * uint64(finalityDelay) / params.BeaconConfig().InactivityPenaltyQuotient
+			d.InactivityPenalty += vb * uint64(finalityDelay) / params.BeaconConfig().InactivityPenaltyQuotient
 		}
 	}
-	return r, p
+	return d
 }
 
 // ProposersDelta computes and returns the rewards and penalties differences for individual validators based on the
@@ -138,6 +138,62 @@ func TestAttestationDeltaPrecompute(t *testing.T) {
 	}
 }
 
+func TestAttestationsDeltaBreakdown(t *testing.T) {
+	e := params.BeaconConfig().SlotsPerEpoch
+	validatorCount := uint64(2048)
+	base := buildState(e+2, validatorCount)
+	atts := make([]*pb.PendingAttestation, 3)
+	var emptyRoot [32]byte
+	for i := 0; i < len(atts); i++ {
+		atts[i] = &pb.PendingAttestation{
+			Data: &ethpb.AttestationData{
+				Target: &ethpb.Checkpoint{
+					Root: emptyRoot[:],
+				},
+				Source: &ethpb.Checkpoint{
+					Root: emptyRoot[:],
+				},
+				BeaconBlockRoot: emptyRoot[:],
+			},
+			AggregationBits: bitfield.Bitlist{0xC0, 0xC0, 0xC0, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x01},
+			InclusionDelay:  2,
+		}
+	}
+	base.PreviousEpochAttestations = atts
+	beaconState, err := state.InitializeFromProto(base)
+	require.NoError(t, err)
+
+	vp, bp, err := New(context.Background(), beaconState)
+	require.NoError(t, err)
+	vp, bp, err = ProcessAttestations(context.Background(), beaconState, vp, bp)
+	require.NoError(t, err)
+	bp.PrevEpochHeadAttested /= 2
+
+	rewards, penalties, err := AttestationsDelta(beaconState, bp, vp)
+	require.NoError(t, err)
+	deltas := AttestationsDeltaBreakdown(beaconState, bp, vp)
+	require.Equal(t, len(vp), len(deltas))
+	for i, d := range deltas {
+		assert.Equal(t, rewards[i], d.Reward(), "Unexpected reward for validator with index %d", i)
+		assert.Equal(t, penalties[i], d.Penalty(), "Unexpected penalty for validator with index %d", i)
+	}
+
+	attested, err := epoch.BaseReward(beaconState, 55)
+	require.NoError(t, err)
+	proposerReward := attested / params.BeaconConfig().ProposerRewardQuotient
+	assert.Equal(t, (attested-proposerReward)/2, deltas[55].InclusionDelayReward)
+	assert.Equal(t, true, deltas[55].HeadReward < deltas[55].TargetReward)
+	assert.Equal(t, uint64(0), deltas[55].Penalty())
+
+	missed, err := epoch.BaseReward(beaconState, 434)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), deltas[434].Reward())
+	assert.Equal(t, missed, deltas[434].SourcePenalty)
+	assert.Equal(t, missed, deltas[434].TargetPenalty)
+	assert.Equal(t, missed, deltas[434].HeadPenalty)
+	assert.Equal(t, uint64(0), deltas[434].InactivityPenalty)
+}
+
 func TestAttestationDeltas_ZeroEpoch(t *testing.T) {
 	e := params.BeaconConfig().SlotsPerEpoch
 	validatorCount := uint64(2048)
@@ -60,3 +60,34 @@ type Balance struct {
 	// correctly for head block during prev epoch.
 	PrevEpochHeadAttested uint64
 }
+
+// AttestationDelta stores the breakdown of the rewards and penalties of an individual validator for its
+// attestation of the previous epoch.
+type AttestationDelta struct {
+	// SourceReward is the reward for voting the correct source.
+	SourceReward uint64
+	// SourcePenalty is the penalty for not voting the correct source.
+	SourcePenalty uint64
+	// TargetReward is the reward for voting the correct target.
+	TargetReward uint64
+	// TargetPenalty is the penalty for not voting the correct target.
+	TargetPenalty uint64
+	// HeadReward is the reward for voting the correct head.
+	HeadReward uint64
+	// HeadPenalty is the penalty for not voting the correct head.
+	HeadPenalty uint64
+	// InclusionDelayReward is the reward for the inclusion delay of the attestation.
+	InclusionDelayReward uint64
+	// InactivityPenalty is the penalty applied during an inactivity leak.
+	InactivityPenalty uint64
+}
+
+// Reward is the total reward of the attestation.
+func (d *AttestationDelta) Reward() uint64 {
+	return d.SourceReward + d.TargetReward + d.HeadReward + d.InclusionDelayReward
+}
+
+// Penalty is the total penalty of the attestation.
+func (d *AttestationDelta) Penalty() uint64 {
+	return d.SourcePenalty + d.TargetPenalty + d.HeadPenalty + d.InactivityPenalty
+}
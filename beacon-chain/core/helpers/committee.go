@@ -22,6 +22,17 @@ import (
 var committeeCache = cache.NewCommitteesCache()
 var proposerIndicesCache = cache.NewProposerIndicesCache()
 
+// committeeCacheSize is the number of seeds the committee cache holds the shuffled committees of,
+// zero being the default size of the cache.
+var committeeCacheSize int
+
+// SetCommitteeCacheSize replaces the committee cache with an empty cache holding the shuffled
+// committees of up to size seeds. It is meant to be called once at start up.
+func SetCommitteeCacheSize(size int) {
+	committeeCacheSize = size
+	committeeCache = cache.NewCommitteesCacheWithSize(size)
+}
+
 // SlotCommitteeCount returns the number of crosslink committees of a slot. The
 // active validator count is provided as an argument rather than a imported implementation
 // from the spec definition. Having the active validator count as an argument allows for
@@ -298,7 +309,7 @@ func UpdateCommitteeCache(state *stateTrie.BeaconState, epoch types.Epoch) error
 		}
 
 		if committeeCache.HasEntry(string(seed[:])) {
-			return nil
+			continue
 		}
 
 		shuffledIndices, err := ShuffledIndices(state, e)
@@ -380,7 +391,7 @@ func UpdateProposerIndicesInCache(state *stateTrie.BeaconState) error {
 
 // ClearCache clears the committee cache
 func ClearCache() {
-	committeeCache = cache.NewCommitteesCache()
+	committeeCache = cache.NewCommitteesCacheWithSize(committeeCacheSize)
 	proposerIndicesCache = cache.NewProposerIndicesCache()
 }
 
@@ -9,6 +9,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -428,6 +429,42 @@ func TestUpdateCommitteeCache_CanUpdate(t *testing.T) {
 	assert.Equal(t, params.BeaconConfig().TargetCommitteeSize, uint64(len(indices)), "Did not save correct indices lengths")
 }
 
+func TestUpdateCommitteeCache_CachesNextEpochWhenCurrentIsCached(t *testing.T) {
+	ClearCache()
+	validators := make([]*ethpb.Validator, params.BeaconConfig().MinGenesisActiveValidatorCount)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	state, err := beaconstate.InitializeFromProto(&pb.BeaconState{
+		Validators:  validators,
+		RandaoMixes: make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+	})
+	require.NoError(t, err)
+	currentSeed, err := Seed(state, 0, params.BeaconConfig().DomainBeaconAttester)
+	require.NoError(t, err)
+	nextSeed, err := Seed(state, 1, params.BeaconConfig().DomainBeaconAttester)
+	require.NoError(t, err)
+	require.NoError(t, committeeCache.AddCommitteeShuffledList(&cache.Committees{Seed: currentSeed}))
+
+	require.NoError(t, UpdateCommitteeCache(state, 0))
+	assert.Equal(t, true, committeeCache.HasEntry(string(nextSeed[:])), "Next epoch committees were not cached")
+}
+
+func TestSetCommitteeCacheSize(t *testing.T) {
+	defer SetCommitteeCacheSize(0)
+	SetCommitteeCacheSize(1)
+	// The configured size is kept when the cache is cleared.
+	ClearCache()
+	for i := byte(0); i < 2; i++ {
+		require.NoError(t, committeeCache.AddCommitteeShuffledList(&cache.Committees{Seed: [32]byte{i}}))
+	}
+	assert.Equal(t, false, committeeCache.HasEntry(string(make([]byte, 32))), "Least recently used committees were not evicted")
+	seed := [32]byte{1}
+	assert.Equal(t, true, committeeCache.HasEntry(string(seed[:])))
+}
+
 func BenchmarkComputeCommittee300000_WithPreCache(b *testing.B) {
 	validators := make([]*ethpb.Validator, 300000)
 	for i := 0; i < len(validators); i++ {
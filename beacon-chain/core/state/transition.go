@@ -433,8 +433,9 @@ func ProcessBlock(
 
 // ProcessBlockNoVerifyAnySig creates a new, modified beacon state by applying block operation
 // transformations as defined in the Ethereum Serenity specification. It does not validate
-// any block signature except for deposit and slashing signatures. It also returns the relevant
-// signature set from all the respective methods.
+// any block signature except for deposit signatures. It also returns the relevant
+// signature set from all the respective methods, so that the block, randao, slashing,
+// attestation and exit signatures are verified in a single batch.
 //
 // Spec pseudocode definition:
 //
@@ -478,19 +479,31 @@ func ProcessBlockNoVerifyAnySig(
 		return nil, nil, errors.Wrap(err, "could not process eth1 data")
 	}
 
-	state, err = ProcessOperationsNoVerifyAttsSigs(ctx, state, signed)
+	state, err = processOperationsNoVerifySigs(ctx, state, signed)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, nil, errors.Wrap(err, "could not process block operation")
 	}
+	psSet, err := b.ProposerSlashingsSignatureSet(state, signed.Block.Body.ProposerSlashings)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not retrieve proposer slashing signature set")
+	}
+	asSet, err := b.AttesterSlashingsSignatureSet(ctx, state, signed.Block.Body.AttesterSlashings)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not retrieve attester slashing signature set")
+	}
 	aSet, err := b.AttestationSignatureSet(ctx, state, signed.Block.Body.Attestations)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not retrieve attestation signature set")
 	}
+	eSet, err := b.VoluntaryExitsSignatureSet(state, signed.Block.Body.VoluntaryExits)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not retrieve voluntary exit signature set")
+	}
 
-	// Merge beacon block, randao and attestations signatures into a set.
+	// Merge beacon block, randao, slashings, attestations and exits signatures into a set.
 	set := bls.NewSet()
-	set.Join(bSet).Join(rSet).Join(aSet)
+	set.Join(bSet).Join(rSet).Join(psSet).Join(asSet).Join(aSet).Join(eSet)
 
 	return set, state, nil
 }
@@ -555,6 +568,45 @@ func ProcessOperationsNoVerifyAttsSigs(
 	return state, nil
 }
 
+// processOperationsNoVerifySigs processes the operations in the beacon block like
+// ProcessOperationsNoVerifyAttsSigs, without verifying the slashing and exit signatures either.
+// Deposit signatures are still verified, as an invalid one skips the deposit instead of
+// invalidating the block.
+func processOperationsNoVerifySigs(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	signedBeaconBlock *ethpb.SignedBeaconBlock) (*stateTrie.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "core.state.processOperationsNoVerifySigs")
+	defer span.End()
+
+	if _, err := VerifyOperationLengths(ctx, state, signedBeaconBlock); err != nil {
+		return nil, errors.Wrap(err, "could not verify operation lengths")
+	}
+
+	state, err := b.ProcessProposerSlashingsNoVerifySignature(ctx, state, signedBeaconBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block proposer slashings")
+	}
+	state, err = b.ProcessAttesterSlashingsNoVerifySignature(ctx, state, signedBeaconBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block attester slashings")
+	}
+	state, err = b.ProcessAttestationsNoVerifySignature(ctx, state, signedBeaconBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block attestations")
+	}
+	state, err = b.ProcessDeposits(ctx, state, signedBeaconBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block validator deposits")
+	}
+	state, err = b.ProcessVoluntaryExitsNoVerifySignature(ctx, state, signedBeaconBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process validator exits")
+	}
+
+	return state, nil
+}
+
 // VerifyOperationLengths verifies that block operation lengths are valid.
 func VerifyOperationLengths(_ context.Context, state *stateTrie.BeaconState, b *ethpb.SignedBeaconBlock) (*stateTrie.BeaconState, error) {
 	if err := helpers.VerifyNilBeaconBlock(b); err != nil {
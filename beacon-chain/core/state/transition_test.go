@@ -501,6 +501,33 @@ func TestProcessBlockNoVerify_PassesProcessingConditions(t *testing.T) {
 	assert.Equal(t, true, verified, "Could not verify signature set.")
 }
 
+func TestProcessBlockNoVerify_BatchesAllSignatures(t *testing.T) {
+	beaconState, block, _, _, exits := createFullBlockWithOperations(t)
+	set, _, err := state.ProcessBlockNoVerifyAnySig(context.Background(), beaconState, block)
+	require.NoError(t, err)
+	exitDesc := fmt.Sprintf("voluntary exit signature of validator %d", exits[0].Exit.ValidatorIndex)
+	wanted := []string{
+		"block signature",
+		"randao signature",
+		"proposer slashing 0 header 1 signature",
+		"proposer slashing 0 header 2 signature",
+		"attester slashing 0 attestation 1 signature",
+		"attester slashing 0 attestation 2 signature",
+		fmt.Sprintf("attestation signature of slot %d committee %d", block.Block.Body.Attestations[0].Data.Slot, block.Block.Body.Attestations[0].Data.CommitteeIndex),
+		exitDesc,
+	}
+	require.DeepEqual(t, wanted, set.Descriptions)
+	verified, err := set.VerifyVerbosely()
+	require.NoError(t, err)
+	assert.Equal(t, true, verified)
+
+	// An invalid signature is pinpointed in the batch.
+	set.Signatures[len(set.Signatures)-1] = block.Signature
+	verified, err = set.VerifyVerbosely()
+	assert.Equal(t, false, verified)
+	assert.ErrorContains(t, exitDesc+" is invalid", err)
+}
+
 func TestProcessEpochPrecompute_CanProcess(t *testing.T) {
 	epoch := types.Epoch(1)
 
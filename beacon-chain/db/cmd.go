@@ -1,6 +1,7 @@
 package db
 
 import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/tos"
 	"github.com/urfave/cli/v2"
@@ -27,5 +28,66 @@ var DatabaseCommands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name: "prune",
+			Description: `removes the states and blocks below the finalized checkpoint which are not needed ` +
+				`to regenerate historical states, then compacts the database. The beacon node must be stopped`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.SlotsPerArchivedPoint,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := prune(cliCtx); err != nil {
+					log.Fatalf("Could not prune database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "migrate",
+			Description: `applies the pending schema migrations to the database, which the beacon node otherwise ` +
+				`does at startup. The beacon node must be stopped`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.MigrationDryRunFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := migrate(cliCtx); err != nil {
+					log.Fatalf("Could not migrate database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "convert",
+			Description: `converts the database to the storage engine given by --db-backend. The source database is ` +
+				`kept with a .bak suffix. The beacon node must be stopped`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.DatabaseBackend,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := convert(cliCtx); err != nil {
+					log.Fatalf("Could not convert database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "stats",
+			Description: `prints the number of keys and the size of each database bucket, the range of stored slots and the schema version`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := stats(cliCtx); err != nil {
+					log.Fatalf("Could not read database stats: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
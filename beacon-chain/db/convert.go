@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/urfave/cli/v2"
+)
+
+func convert(cliCtx *cli.Context) error {
+	dst, err := backend.ParseKind(cliCtx.String(flags.DatabaseBackend.Name))
+	if err != nil {
+		return err
+	}
+	dbDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	// The database files are locked by a running beacon node, so opening them fails in that case.
+	if err := kv.ConvertDatabase(context.Background(), dbDir, dst); err != nil {
+		return errors.Wrap(err, "make sure the beacon node is stopped")
+	}
+	return nil
+}
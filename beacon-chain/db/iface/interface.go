@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
 	ethereum_beacon_p2p_v1 "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/backuputil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 )
 
 // ReadOnlyDatabase defines a struct which only has read access to database methods.
@@ -27,6 +28,8 @@ type ReadOnlyDatabase interface {
 	BlockRootsBySlot(ctx context.Context, slot types.Slot) (bool, [][32]byte, error)
 	HasBlock(ctx context.Context, blockRoot [32]byte) bool
 	GenesisBlock(ctx context.Context) (*eth.SignedBeaconBlock, error)
+	OriginBlockRoot(ctx context.Context) ([32]byte, error)
+	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (*eth.SignedBeaconBlock, error)
 	HighestSlotBlocksBelow(ctx context.Context, slot types.Slot) ([]*eth.SignedBeaconBlock, error)
@@ -56,6 +59,14 @@ type ReadOnlyDatabase interface {
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// Powchain operations.
 	PowchainData(ctx context.Context) (*db.ETH1ChainData, error)
+	DepositSnapshot(ctx context.Context) (*trieutil.DepositTreeSnapshot, error)
+	// Operation pools related methods.
+	PoolAttestations(ctx context.Context) ([]*eth.Attestation, error)
+	PoolAttesterSlashings(ctx context.Context) ([]*eth.AttesterSlashing, error)
+	PoolProposerSlashings(ctx context.Context) ([]*eth.ProposerSlashing, error)
+	PoolVoluntaryExits(ctx context.Context) ([]*eth.SignedVoluntaryExit, error)
+	// Attestation inclusions archive related methods.
+	AttestationInclusion(ctx context.Context, epoch types.Epoch, validatorIndex types.ValidatorIndex) (*AttestationInclusion, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -66,6 +77,8 @@ type NoHeadAccessDatabase interface {
 	SaveBlock(ctx context.Context, block *eth.SignedBeaconBlock) error
 	SaveBlocks(ctx context.Context, blocks []*eth.SignedBeaconBlock) error
 	SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveBackfilledBlocks(ctx context.Context, blocks []*eth.SignedBeaconBlock) error
 	// State related methods.
 	SaveState(ctx context.Context, state *state.BeaconState, blockRoot [32]byte) error
 	SaveStates(ctx context.Context, states []*state.BeaconState, blockRoots [][32]byte) error
@@ -85,6 +98,14 @@ type NoHeadAccessDatabase interface {
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// Powchain operations.
 	SavePowchainData(ctx context.Context, data *db.ETH1ChainData) error
+	SaveDepositSnapshot(ctx context.Context, snapshot *trieutil.DepositTreeSnapshot) error
+	// Operation pools related methods.
+	SavePoolAttestations(ctx context.Context, atts []*eth.Attestation) error
+	SavePoolAttesterSlashings(ctx context.Context, slashings []*eth.AttesterSlashing) error
+	SavePoolProposerSlashings(ctx context.Context, slashings []*eth.ProposerSlashing) error
+	SavePoolVoluntaryExits(ctx context.Context, exits []*eth.SignedVoluntaryExit) error
+	// Attestation inclusions archive related methods.
+	SaveAttestationInclusions(ctx context.Context, inclusions []*AttestationInclusion) error
 
 	// Run any required database migrations.
 	RunMigrations(ctx context.Context) error
@@ -110,3 +131,11 @@ type Database interface {
 	DatabasePath() string
 	ClearDB() error
 }
+
+// AttestationInclusion records the inclusion of the attestation of a validator in the first block
+// including it, as archived by the beacon node.
+type AttestationInclusion struct {
+	ValidatorIndex  types.ValidatorIndex
+	AttestationSlot types.Slot
+	InclusionSlot   types.Slot
+}
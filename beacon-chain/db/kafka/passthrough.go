@@ -7,9 +7,11 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 )
 
 // DatabasePath -- passthrough.
@@ -137,6 +139,26 @@ func (e Exporter) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte)
 	return e.db.SaveGenesisBlockRoot(ctx, blockRoot)
 }
 
+// OriginBlockRoot -- passthrough.
+func (e Exporter) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.OriginBlockRoot(ctx)
+}
+
+// SaveOriginBlockRoot -- passthrough.
+func (e Exporter) SaveOriginBlockRoot(ctx context.Context, blockRoot [32]byte) error {
+	return e.db.SaveOriginBlockRoot(ctx, blockRoot)
+}
+
+// BackfillBlockRoot -- passthrough.
+func (e Exporter) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	return e.db.BackfillBlockRoot(ctx)
+}
+
+// SaveBackfilledBlocks -- passthrough.
+func (e Exporter) SaveBackfilledBlocks(ctx context.Context, blocks []*eth.SignedBeaconBlock) error {
+	return e.db.SaveBackfilledBlocks(ctx, blocks)
+}
+
 // SaveState -- passthrough.
 func (e Exporter) SaveState(ctx context.Context, st *state.BeaconState, blockRoot [32]byte) error {
 	return e.db.SaveState(ctx, st, blockRoot)
@@ -227,6 +249,66 @@ func (e Exporter) SavePowchainData(ctx context.Context, data *db.ETH1ChainData)
 	return e.db.SavePowchainData(ctx, data)
 }
 
+// DepositSnapshot -- passthrough
+func (e Exporter) DepositSnapshot(ctx context.Context) (*trieutil.DepositTreeSnapshot, error) {
+	return e.db.DepositSnapshot(ctx)
+}
+
+// SaveDepositSnapshot -- passthrough
+func (e Exporter) SaveDepositSnapshot(ctx context.Context, snapshot *trieutil.DepositTreeSnapshot) error {
+	return e.db.SaveDepositSnapshot(ctx, snapshot)
+}
+
+// PoolAttestations -- passthrough
+func (e Exporter) PoolAttestations(ctx context.Context) ([]*eth.Attestation, error) {
+	return e.db.PoolAttestations(ctx)
+}
+
+// SavePoolAttestations -- passthrough
+func (e Exporter) SavePoolAttestations(ctx context.Context, atts []*eth.Attestation) error {
+	return e.db.SavePoolAttestations(ctx, atts)
+}
+
+// PoolAttesterSlashings -- passthrough
+func (e Exporter) PoolAttesterSlashings(ctx context.Context) ([]*eth.AttesterSlashing, error) {
+	return e.db.PoolAttesterSlashings(ctx)
+}
+
+// SavePoolAttesterSlashings -- passthrough
+func (e Exporter) SavePoolAttesterSlashings(ctx context.Context, slashings []*eth.AttesterSlashing) error {
+	return e.db.SavePoolAttesterSlashings(ctx, slashings)
+}
+
+// PoolProposerSlashings -- passthrough
+func (e Exporter) PoolProposerSlashings(ctx context.Context) ([]*eth.ProposerSlashing, error) {
+	return e.db.PoolProposerSlashings(ctx)
+}
+
+// SavePoolProposerSlashings -- passthrough
+func (e Exporter) SavePoolProposerSlashings(ctx context.Context, slashings []*eth.ProposerSlashing) error {
+	return e.db.SavePoolProposerSlashings(ctx, slashings)
+}
+
+// PoolVoluntaryExits -- passthrough
+func (e Exporter) PoolVoluntaryExits(ctx context.Context) ([]*eth.SignedVoluntaryExit, error) {
+	return e.db.PoolVoluntaryExits(ctx)
+}
+
+// SavePoolVoluntaryExits -- passthrough
+func (e Exporter) SavePoolVoluntaryExits(ctx context.Context, exits []*eth.SignedVoluntaryExit) error {
+	return e.db.SavePoolVoluntaryExits(ctx, exits)
+}
+
+// AttestationInclusion -- passthrough
+func (e Exporter) AttestationInclusion(ctx context.Context, epoch types.Epoch, validatorIndex types.ValidatorIndex) (*iface.AttestationInclusion, error) {
+	return e.db.AttestationInclusion(ctx, epoch, validatorIndex)
+}
+
+// SaveAttestationInclusions -- passthrough
+func (e Exporter) SaveAttestationInclusions(ctx context.Context, inclusions []*iface.AttestationInclusion) error {
+	return e.db.SaveAttestationInclusions(ctx, inclusions)
+}
+
 // ArchivedPointRoot -- passthrough
 func (e Exporter) ArchivedPointRoot(ctx context.Context, index types.Slot) [32]byte {
 	return e.db.ArchivedPointRoot(ctx, index)
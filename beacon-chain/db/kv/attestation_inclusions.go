@@ -0,0 +1,98 @@
+package kv
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"go.opencensus.io/trace"
+)
+
+// attestationInclusionsChunkSize is the number of validators whose attestation inclusions of an
+// epoch are stored under the same key. Each validator takes two bytes: the offset of the slot of
+// its attestation in the epoch, and the inclusion delay of the attestation, which is zero if it
+// was not included.
+const attestationInclusionsChunkSize = 256
+
+// attestationInclusionsKey is the key of the chunk holding the attestation inclusions of a
+// validator in an epoch.
+func attestationInclusionsKey(epoch types.Epoch, validatorIndex types.ValidatorIndex) []byte {
+	chunk := uint64(validatorIndex) / attestationInclusionsChunkSize
+	return append(bytesutil.EpochToBytesBigEndian(epoch), bytesutil.Uint64ToBytesBigEndian(chunk)...)
+}
+
+// AttestationInclusion retrieves the archived inclusion of the attestation of a validator in an
+// epoch, or nil if none was archived.
+func (s *Store) AttestationInclusion(ctx context.Context, epoch types.Epoch, validatorIndex types.ValidatorIndex) (*iface.AttestationInclusion, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.AttestationInclusion")
+	defer span.End()
+	startSlot, err := helpers.StartSlot(epoch)
+	if err != nil {
+		return nil, err
+	}
+	var inclusion *iface.AttestationInclusion
+	err = s.db.View(func(tx backend.Tx) error {
+		chunk := tx.Bucket(attestationInclusionsBucket).Get(attestationInclusionsKey(epoch, validatorIndex))
+		pos := 2 * (uint64(validatorIndex) % attestationInclusionsChunkSize)
+		if uint64(len(chunk)) < pos+2 || chunk[pos+1] == 0 {
+			return nil
+		}
+		attSlot := startSlot + types.Slot(chunk[pos])
+		inclusion = &iface.AttestationInclusion{
+			ValidatorIndex:  validatorIndex,
+			AttestationSlot: attSlot,
+			InclusionSlot:   attSlot + types.Slot(chunk[pos+1]),
+		}
+		return nil
+	})
+	return inclusion, err
+}
+
+// SaveAttestationInclusions archives the inclusions of attestations in a block. The inclusion
+// already archived for the attestation of a validator in an epoch is kept, unless the new one
+// is earlier. Inclusions which do not fit the encoding of the archive are skipped.
+func (s *Store) SaveAttestationInclusions(ctx context.Context, inclusions []*iface.AttestationInclusion) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveAttestationInclusions")
+	defer span.End()
+	return s.db.Update(func(tx backend.Tx) error {
+		bkt := tx.Bucket(attestationInclusionsBucket)
+		chunks := make(map[string][]byte)
+		for _, inclusion := range inclusions {
+			epoch := helpers.SlotToEpoch(inclusion.AttestationSlot)
+			startSlot, err := helpers.StartSlot(epoch)
+			if err != nil {
+				return err
+			}
+			if inclusion.InclusionSlot <= inclusion.AttestationSlot {
+				continue
+			}
+			offset, delay := inclusion.AttestationSlot-startSlot, inclusion.InclusionSlot-inclusion.AttestationSlot
+			if offset > 255 || delay > 255 {
+				continue
+			}
+
+			key := string(attestationInclusionsKey(epoch, inclusion.ValidatorIndex))
+			chunk, ok := chunks[key]
+			if !ok {
+				chunk = make([]byte, 2*attestationInclusionsChunkSize)
+				copy(chunk, bkt.Get([]byte(key)))
+				chunks[key] = chunk
+			}
+			pos := 2 * (uint64(inclusion.ValidatorIndex) % attestationInclusionsChunkSize)
+			if chunk[pos+1] != 0 && types.Slot(chunk[pos])+types.Slot(chunk[pos+1]) <= offset+delay {
+				continue
+			}
+			chunk[pos] = byte(offset)
+			chunk[pos+1] = byte(delay)
+		}
+		for key, chunk := range chunks {
+			if err := bkt.Put([]byte(key), chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
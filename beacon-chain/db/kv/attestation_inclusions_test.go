@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_AttestationInclusions(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	inclusion, err := db.AttestationInclusion(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, (*iface.AttestationInclusion)(nil), inclusion)
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, db.SaveAttestationInclusions(ctx, []*iface.AttestationInclusion{
+		{ValidatorIndex: 3, AttestationSlot: slotsPerEpoch + 2, InclusionSlot: slotsPerEpoch + 5},
+		// Validators of another chunk.
+		{ValidatorIndex: 300, AttestationSlot: slotsPerEpoch + 1, InclusionSlot: slotsPerEpoch + 2},
+		{ValidatorIndex: 301, AttestationSlot: slotsPerEpoch, InclusionSlot: slotsPerEpoch + 3},
+		// Not an inclusion.
+		{ValidatorIndex: 4, AttestationSlot: slotsPerEpoch + 2, InclusionSlot: slotsPerEpoch + 2},
+	}))
+	// A later inclusion is ignored, while an earlier one replaces the archived one.
+	require.NoError(t, db.SaveAttestationInclusions(ctx, []*iface.AttestationInclusion{
+		{ValidatorIndex: 3, AttestationSlot: slotsPerEpoch + 2, InclusionSlot: slotsPerEpoch + 7},
+		{ValidatorIndex: 301, AttestationSlot: slotsPerEpoch, InclusionSlot: slotsPerEpoch + 1},
+	}))
+
+	tests := []struct {
+		index uint64
+		want  *iface.AttestationInclusion
+	}{
+		{index: 3, want: &iface.AttestationInclusion{ValidatorIndex: 3, AttestationSlot: slotsPerEpoch + 2, InclusionSlot: slotsPerEpoch + 5}},
+		{index: 4},
+		{index: 5},
+		{index: 300, want: &iface.AttestationInclusion{ValidatorIndex: 300, AttestationSlot: slotsPerEpoch + 1, InclusionSlot: slotsPerEpoch + 2}},
+		{index: 301, want: &iface.AttestationInclusion{ValidatorIndex: 301, AttestationSlot: slotsPerEpoch, InclusionSlot: slotsPerEpoch + 1}},
+	}
+	for _, tt := range tests {
+		inclusion, err := db.AttestationInclusion(ctx, 1, types.ValidatorIndex(tt.index))
+		require.NoError(t, err)
+		assert.DeepEqual(t, tt.want, inclusion, "Validator %d", tt.index)
+	}
+	// Inclusions are archived by epoch.
+	inclusion, err = db.AttestationInclusion(ctx, 0, 3)
+	require.NoError(t, err)
+	assert.Equal(t, (*iface.AttestationInclusion)(nil), inclusion)
+}
@@ -0,0 +1,107 @@
+// Package backend defines the key-value storage engines the beacon node database can be built on.
+// The interfaces follow the bucket and transaction model of bolt, which every engine implements so
+// that the beacon node database is independent of the engine storing it.
+package backend
+
+import (
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Kind identifies a storage engine.
+type Kind string
+
+const (
+	// Bolt stores the database in a single bolt file, which serializes all writes.
+	Bolt Kind = "bolt"
+	// LevelDB stores the database in a leveldb directory.
+	LevelDB Kind = "leveldb"
+)
+
+// Kinds lists the supported storage engines.
+var Kinds = []Kind{Bolt, LevelDB}
+
+// ErrBucketNotFound is returned when deleting a bucket which does not exist.
+var ErrBucketNotFound = bolt.ErrBucketNotFound
+
+// errTxNotWritable is returned when writing in a read only transaction.
+var errTxNotWritable = bolt.ErrTxNotWritable
+
+// DB is a key-value store made of buckets, read and written in transactions.
+type DB interface {
+	// View runs fn in a read only transaction, seeing a consistent snapshot of the database.
+	View(fn func(tx Tx) error) error
+	// Update runs fn in a read-write transaction, which is committed if fn returns no error and
+	// rolled back otherwise.
+	Update(fn func(tx Tx) error) error
+	Close() error
+}
+
+// Tx is a transaction of a DB. Slices returned by a transaction are only valid for its lifetime.
+type Tx interface {
+	// Bucket returns the bucket with the given name, or nil if it does not exist.
+	Bucket(name []byte) Bucket
+	CreateBucket(name []byte) (Bucket, error)
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+	DeleteBucket(name []byte) error
+	// ForEach calls fn for each bucket, in the byte order of their names.
+	ForEach(fn func(name []byte, b Bucket) error) error
+}
+
+// Bucket is a set of keys sorted in byte order.
+type Bucket interface {
+	// Get returns the value of key, or nil if the key does not exist.
+	Get(key []byte) []byte
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	Cursor() Cursor
+	// ForEach calls fn for each key in byte order. The bucket must not be written from fn.
+	ForEach(fn func(k, v []byte) error) error
+}
+
+// Cursor iterates over the keys of a bucket in byte order. The key returned by a cursor is nil
+// once it moves past the first or last key.
+type Cursor interface {
+	First() (key, value []byte)
+	Last() (key, value []byte)
+	Next() (key, value []byte)
+	Prev() (key, value []byte)
+	// Seek moves to the first key greater than or equal to seek.
+	Seek(seek []byte) (key, value []byte)
+}
+
+// Options for opening a database.
+type Options struct {
+	// InitialMMapSize is the initial size of the memory map of a bolt database.
+	InitialMMapSize int
+	// FreelistType is the type of the freelist of a bolt database, array if empty.
+	FreelistType string
+	// NoFreelistSync keeps the freelist of a bolt database from being written on each commit.
+	NoFreelistSync bool
+}
+
+// ParseKind returns the storage engine with the given name.
+func ParseKind(name string) (Kind, error) {
+	for _, k := range Kinds {
+		if string(k) == name {
+			return k, nil
+		}
+	}
+	return "", errors.Errorf("unknown database backend %q, expected one of %v", name, Kinds)
+}
+
+// Open opens the database of the given kind at dbPath, creating it if it does not exist. A bolt
+// database is stored in a single file, and a leveldb database in a directory.
+func Open(kind Kind, dbPath string, opts *Options) (DB, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	switch kind {
+	case Bolt:
+		return openBolt(dbPath, opts)
+	case LevelDB:
+		return openLevelDB(dbPath)
+	default:
+		return nil, errors.Errorf("unknown database backend %q", kind)
+	}
+}
@@ -0,0 +1,177 @@
+package backend
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+var testBucket = []byte("test")
+
+func setupDBs(t *testing.T) map[Kind]DB {
+	dbs := make(map[Kind]DB)
+	for _, kind := range Kinds {
+		db, err := Open(kind, path.Join(t.TempDir(), string(kind)), nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+		})
+		require.NoError(t, db.Update(func(tx Tx) error {
+			_, err := tx.CreateBucket(testBucket)
+			return err
+		}))
+		dbs[kind] = db
+	}
+	return dbs
+}
+
+func TestDB_Update(t *testing.T) {
+	for kind, db := range setupDBs(t) {
+		t.Run(string(kind), func(t *testing.T) {
+			require.NoError(t, db.Update(func(tx Tx) error {
+				bkt := tx.Bucket(testBucket)
+				require.NoError(t, bkt.Put([]byte("a"), []byte("1")))
+				// The writes of a transaction are visible in the transaction.
+				assert.DeepEqual(t, []byte("1"), bkt.Get([]byte("a")))
+				require.NoError(t, bkt.Put([]byte("b"), []byte{}))
+				return nil
+			}))
+
+			rollback := errors.New("rollback")
+			err := db.Update(func(tx Tx) error {
+				bkt := tx.Bucket(testBucket)
+				require.NoError(t, bkt.Put([]byte("a"), []byte("2")))
+				require.NoError(t, bkt.Delete([]byte("b")))
+				return rollback
+			})
+			assert.Equal(t, rollback, err)
+
+			require.NoError(t, db.View(func(tx Tx) error {
+				bkt := tx.Bucket(testBucket)
+				assert.DeepEqual(t, []byte("1"), bkt.Get([]byte("a")))
+				assert.NotNil(t, bkt.Get([]byte("b")), "Empty value read as a missing key")
+				assert.Equal(t, 0, len(bkt.Get([]byte("b"))))
+				assert.DeepEqual(t, []byte(nil), bkt.Get([]byte("c")))
+				assert.NotNil(t, bkt.Put([]byte("c"), []byte("3")), "Wrote in a read only transaction")
+				assert.Equal(t, nil, tx.Bucket([]byte("missing")))
+				return nil
+			}))
+		})
+	}
+}
+
+func TestDB_Buckets(t *testing.T) {
+	for kind, db := range setupDBs(t) {
+		t.Run(string(kind), func(t *testing.T) {
+			require.NoError(t, db.Update(func(tx Tx) error {
+				_, err := tx.CreateBucket(testBucket)
+				assert.NotNil(t, err, "Created an existing bucket")
+				bkt, err := tx.CreateBucketIfNotExists([]byte("other"))
+				require.NoError(t, err)
+				return bkt.Put([]byte("a"), []byte("1"))
+			}))
+
+			names := make([]string, 0)
+			require.NoError(t, db.View(func(tx Tx) error {
+				return tx.ForEach(func(name []byte, b Bucket) error {
+					names = append(names, string(name))
+					return nil
+				})
+			}))
+			assert.DeepEqual(t, []string{"other", "test"}, names)
+
+			require.NoError(t, db.Update(func(tx Tx) error {
+				require.NoError(t, tx.DeleteBucket([]byte("other")))
+				assert.Equal(t, ErrBucketNotFound, tx.DeleteBucket([]byte("other")))
+				bkt, err := tx.CreateBucket([]byte("other"))
+				require.NoError(t, err)
+				// The keys of a deleted bucket are deleted with it.
+				assert.DeepEqual(t, []byte(nil), bkt.Get([]byte("a")))
+				return nil
+			}))
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	for kind, db := range setupDBs(t) {
+		t.Run(string(kind), func(t *testing.T) {
+			require.NoError(t, db.Update(func(tx Tx) error {
+				bkt := tx.Bucket(testBucket)
+				for _, k := range []string{"b", "d", "f", "h"} {
+					require.NoError(t, bkt.Put([]byte(k), []byte(k)))
+				}
+				return nil
+			}))
+
+			// The cursor merges the writes of the transaction with the committed keys.
+			require.NoError(t, db.Update(func(tx Tx) error {
+				bkt := tx.Bucket(testBucket)
+				require.NoError(t, bkt.Put([]byte("a"), []byte("a")))
+				require.NoError(t, bkt.Put([]byte("e"), []byte("e")))
+				require.NoError(t, bkt.Put([]byte("f"), []byte("F")))
+				require.NoError(t, bkt.Delete([]byte("d")))
+				require.NoError(t, bkt.Delete([]byte("h")))
+				want := []string{"a:a", "b:b", "e:e", "f:F"}
+
+				c := bkt.Cursor()
+				got := make([]string, 0)
+				for k, v := c.First(); k != nil; k, v = c.Next() {
+					got = append(got, string(k)+":"+string(v))
+				}
+				assert.DeepEqual(t, want, got)
+
+				got = got[:0]
+				for k, v := c.Last(); k != nil; k, v = c.Prev() {
+					got = append([]string{string(k) + ":" + string(v)}, got...)
+				}
+				assert.DeepEqual(t, want, got)
+
+				k, _ := c.Seek([]byte("c"))
+				assert.DeepEqual(t, []byte("e"), k)
+				k, _ = c.Prev()
+				assert.DeepEqual(t, []byte("b"), k)
+				k, _ = c.Seek([]byte("g"))
+				assert.DeepEqual(t, []byte(nil), k)
+				return nil
+			}))
+
+			require.NoError(t, db.View(func(tx Tx) error {
+				got := make([]string, 0)
+				require.NoError(t, tx.Bucket(testBucket).ForEach(func(k, v []byte) error {
+					got = append(got, string(k)+":"+string(v))
+					return nil
+				}))
+				assert.DeepEqual(t, []string{"a:a", "b:b", "e:e", "f:F"}, got)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestOpen_BoltFreelist(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bolt.db")
+	db, err := Open(Bolt, dbPath, &Options{FreelistType: "hashmap", NoFreelistSync: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Update(func(tx Tx) error {
+		bkt, err := tx.CreateBucket(testBucket)
+		require.NoError(t, err)
+		return bkt.Put([]byte("a"), []byte("1"))
+	}))
+	require.NoError(t, db.Close())
+
+	// The freelist which was not written is rebuilt when opening the database.
+	db, err = Open(Bolt, dbPath, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.View(func(tx Tx) error {
+		assert.DeepEqual(t, []byte("1"), tx.Bucket(testBucket).Get([]byte("a")))
+		return nil
+	}))
+	require.NoError(t, db.Close())
+
+	_, err = Open(Bolt, dbPath, &Options{FreelistType: "list"})
+	assert.ErrorContains(t, "unknown bolt freelist type", err)
+}
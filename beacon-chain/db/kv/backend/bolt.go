@@ -0,0 +1,121 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+const boltAllocSize = 8 * 1024 * 1024
+
+type boltDB struct {
+	db *bolt.DB
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+type boltBucket struct {
+	*bolt.Bucket
+}
+
+func openBolt(dbPath string, opts *Options) (DB, error) {
+	freelistType := bolt.FreelistArrayType
+	switch opts.FreelistType {
+	case "", string(bolt.FreelistArrayType):
+	case string(bolt.FreelistMapType):
+		freelistType = bolt.FreelistMapType
+	default:
+		return nil, errors.Errorf("unknown bolt freelist type %q, expected %s or %s",
+			opts.FreelistType, bolt.FreelistArrayType, bolt.FreelistMapType)
+	}
+	db, err := bolt.Open(
+		dbPath,
+		params.BeaconIoConfig().ReadWritePermissions,
+		&bolt.Options{
+			Timeout:         1 * time.Second,
+			InitialMmapSize: opts.InitialMMapSize,
+			FreelistType:    freelistType,
+			NoFreelistSync:  opts.NoFreelistSync,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	db.AllocSize = boltAllocSize
+	return NewBolt(db), nil
+}
+
+// NewBolt returns a DB stored in the open bolt database.
+func NewBolt(db *bolt.DB) DB {
+	return &boltDB{db: db}
+}
+
+// BoltDB returns the bolt database storing db, for the operations which are specific to bolt.
+func BoltDB(db DB) (*bolt.DB, bool) {
+	b, ok := db.(*boltDB)
+	if !ok {
+		return nil, false
+	}
+	return b.db, true
+}
+
+func (b *boltDB) View(fn func(tx Tx) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltDB) Update(fn func(tx Tx) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltDB) Close() error {
+	return b.db.Close()
+}
+
+func (t *boltTx) Bucket(name []byte) Bucket {
+	// A nil bucket must be returned as a nil interface.
+	if b := t.tx.Bucket(name); b != nil {
+		return boltBucket{b}
+	}
+	return nil
+}
+
+func (t *boltTx) CreateBucket(name []byte) (Bucket, error) {
+	b, err := t.tx.CreateBucket(name)
+	if err != nil {
+		return nil, err
+	}
+	return boltBucket{b}, nil
+}
+
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	b, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return boltBucket{b}, nil
+}
+
+func (t *boltTx) DeleteBucket(name []byte) error {
+	return t.tx.DeleteBucket(name)
+}
+
+func (t *boltTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	return t.tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		return fn(name, boltBucket{b})
+	})
+}
+
+func (b boltBucket) Cursor() Cursor {
+	return b.Bucket.Cursor()
+}
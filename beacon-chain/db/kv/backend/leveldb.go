@@ -0,0 +1,410 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// The buckets of a leveldb database share its keyspace. The name of a bucket is stored at
+// bucketMarkerPrefix+name, and the keys of a bucket at bucketKeyPrefix+uvarint(len(name))+name+key,
+// so that the keys of a bucket are contiguous and sorted.
+const (
+	bucketMarkerPrefix byte = 0
+	bucketKeyPrefix    byte = 1
+)
+
+// The writes of a transaction are buffered in memory, with values tagged as written or deleted.
+const (
+	tagDeleted byte = 0
+	tagWritten byte = 1
+)
+
+var (
+	errBucketExists       = errors.New("bucket already exists")
+	errBucketNameRequired = errors.New("bucket name required")
+	errKeyRequired        = errors.New("key required")
+)
+
+// levelDB reads in transactions from snapshots, which never block, and buffers the writes of a
+// transaction until it is committed as a single atomic batch. Read-write transactions are
+// serialized, so that a transaction never overwrites the writes of another.
+type levelDB struct {
+	db      *leveldb.DB
+	writeMu sync.Mutex
+}
+
+type levelTx struct {
+	snapshot *leveldb.Snapshot
+	// writes is nil in a read only transaction.
+	writes    *memdb.DB
+	iterators []iterator.Iterator
+}
+
+type levelBucket struct {
+	tx     *levelTx
+	prefix []byte
+}
+
+type levelCursor struct {
+	bucket   *levelBucket
+	snapshot iterator.Iterator
+	writes   iterator.Iterator
+	key      []byte
+}
+
+func openLevelDB(dbPath string) (DB, error) {
+	db, err := leveldb.OpenFile(dbPath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open leveldb database, database may be in use by another process")
+	}
+	return &levelDB{db: db}, nil
+}
+
+func (l *levelDB) View(fn func(tx Tx) error) error {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	tx := &levelTx{snapshot: snapshot}
+	defer tx.release()
+	return fn(tx)
+}
+
+func (l *levelDB) Update(fn func(tx Tx) error) error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	tx := &levelTx{snapshot: snapshot, writes: memdb.New(comparer.DefaultComparer, 0)}
+	defer tx.release()
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	it := tx.writes.NewIterator(nil)
+	defer it.Release()
+	for it.Next() {
+		if it.Value()[0] == tagDeleted {
+			batch.Delete(it.Key())
+		} else {
+			batch.Put(it.Key(), it.Value()[1:])
+		}
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return l.db.Write(batch, nil)
+}
+
+func (l *levelDB) Close() error {
+	return l.db.Close()
+}
+
+func (t *levelTx) release() {
+	for _, it := range t.iterators {
+		it.Release()
+	}
+	t.snapshot.Release()
+}
+
+// get returns the value of key, reading the writes of the transaction first.
+func (t *levelTx) get(key []byte) []byte {
+	if t.writes != nil {
+		if v, err := t.writes.Get(key); err == nil {
+			if v[0] == tagDeleted {
+				return nil
+			}
+			// The full slice expression keeps appends to the value from overwriting the buffer.
+			return v[1:len(v):len(v)]
+		}
+	}
+	v, err := t.snapshot.Get(key, nil)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+func (t *levelTx) put(key, value []byte) error {
+	if t.writes == nil {
+		return errTxNotWritable
+	}
+	return t.writes.Put(key, append([]byte{tagWritten}, value...))
+}
+
+func (t *levelTx) delete(key []byte) error {
+	if t.writes == nil {
+		return errTxNotWritable
+	}
+	return t.writes.Put(key, []byte{tagDeleted})
+}
+
+// cursor returns a cursor over the keys in the range, without their prefix of prefixLen bytes.
+func (t *levelTx) cursor(r *util.Range, prefixLen int) *levelCursor {
+	c := &levelCursor{
+		bucket:   &levelBucket{tx: t, prefix: r.Start[:prefixLen]},
+		snapshot: t.snapshot.NewIterator(r, nil),
+	}
+	t.iterators = append(t.iterators, c.snapshot)
+	if t.writes != nil {
+		c.writes = t.writes.NewIterator(r)
+		t.iterators = append(t.iterators, c.writes)
+	}
+	return c
+}
+
+func (t *levelTx) Bucket(name []byte) Bucket {
+	if t.get(bucketMarkerKey(name)) == nil {
+		return nil
+	}
+	return &levelBucket{tx: t, prefix: bucketKeysPrefix(name)}
+}
+
+func (t *levelTx) CreateBucket(name []byte) (Bucket, error) {
+	if len(name) == 0 {
+		return nil, errBucketNameRequired
+	}
+	if t.Bucket(name) != nil {
+		return nil, errBucketExists
+	}
+	if err := t.put(bucketMarkerKey(name), []byte{}); err != nil {
+		return nil, err
+	}
+	return &levelBucket{tx: t, prefix: bucketKeysPrefix(name)}, nil
+}
+
+func (t *levelTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	if b := t.Bucket(name); b != nil {
+		return b, nil
+	}
+	return t.CreateBucket(name)
+}
+
+func (t *levelTx) DeleteBucket(name []byte) error {
+	b := t.Bucket(name)
+	if b == nil {
+		return ErrBucketNotFound
+	}
+	keys := make([][]byte, 0)
+	if err := b.ForEach(func(k, _ []byte) error {
+		keys = append(keys, k)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return t.delete(bucketMarkerKey(name))
+}
+
+func (t *levelTx) ForEach(fn func(name []byte, b Bucket) error) error {
+	c := t.cursor(util.BytesPrefix([]byte{bucketMarkerPrefix}), 1)
+	for name, _ := c.First(); name != nil; name, _ = c.Next() {
+		if err := fn(name, &levelBucket{tx: t, prefix: bucketKeysPrefix(name)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *levelBucket) Get(key []byte) []byte {
+	return b.tx.get(b.key(key))
+}
+
+func (b *levelBucket) Put(key, value []byte) error {
+	if len(key) == 0 {
+		return errKeyRequired
+	}
+	return b.tx.put(b.key(key), value)
+}
+
+func (b *levelBucket) Delete(key []byte) error {
+	return b.tx.delete(b.key(key))
+}
+
+func (b *levelBucket) Cursor() Cursor {
+	return b.tx.cursor(util.BytesPrefix(b.prefix), len(b.prefix))
+}
+
+func (b *levelBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *levelBucket) key(key []byte) []byte {
+	k := make([]byte, len(b.prefix)+len(key))
+	copy(k, b.prefix)
+	copy(k[len(b.prefix):], key)
+	return k
+}
+
+func (c *levelCursor) First() ([]byte, []byte) {
+	return c.forward(nil, true)
+}
+
+func (c *levelCursor) Last() ([]byte, []byte) {
+	return c.backward(nil, true)
+}
+
+func (c *levelCursor) Next() ([]byte, []byte) {
+	if c.key == nil {
+		return nil, nil
+	}
+	return c.forward(c.key, false)
+}
+
+func (c *levelCursor) Prev() ([]byte, []byte) {
+	if c.key == nil {
+		return nil, nil
+	}
+	return c.backward(c.key, false)
+}
+
+func (c *levelCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.forward(c.bucket.key(seek), true)
+}
+
+// forward moves to the first key after key, or from key if inclusive, which was not deleted in
+// the transaction. A nil key moves to the first key of the range.
+func (c *levelCursor) forward(key []byte, inclusive bool) ([]byte, []byte) {
+	for {
+		sk, sv := seekForward(c.snapshot, key, inclusive)
+		wk, wv := seekForward(c.writes, key, inclusive)
+		k, v, deleted := mergeEntries(sk, sv, wk, wv, func(a, b []byte) bool {
+			return bytes.Compare(a, b) < 0
+		})
+		if !deleted {
+			return c.moveTo(k, v)
+		}
+		key, inclusive = k, false
+	}
+}
+
+// backward moves to the last key before key, or from key if inclusive, which was not deleted in
+// the transaction. A nil key moves to the last key of the range.
+func (c *levelCursor) backward(key []byte, inclusive bool) ([]byte, []byte) {
+	for {
+		sk, sv := seekBackward(c.snapshot, key, inclusive)
+		wk, wv := seekBackward(c.writes, key, inclusive)
+		k, v, deleted := mergeEntries(sk, sv, wk, wv, func(a, b []byte) bool {
+			return bytes.Compare(a, b) > 0
+		})
+		if !deleted {
+			return c.moveTo(k, v)
+		}
+		key, inclusive = k, false
+	}
+}
+
+// moveTo sets the position of the cursor and returns the entry without the bucket prefix.
+func (c *levelCursor) moveTo(key, value []byte) ([]byte, []byte) {
+	c.key = key
+	if key == nil {
+		return nil, nil
+	}
+	return key[len(c.bucket.prefix):], value
+}
+
+// mergeEntries returns the entry of the snapshot or of the writes which comes first, the writes
+// taking precedence over the snapshot for the same key, and whether that entry was deleted.
+func mergeEntries(sk, sv, wk, wv []byte, before func(a, b []byte) bool) ([]byte, []byte, bool) {
+	switch {
+	case wk == nil && sk == nil:
+		return nil, nil, false
+	case wk == nil || (sk != nil && before(sk, wk)):
+		return sk, sv, false
+	case wv[0] == tagDeleted:
+		return wk, nil, true
+	default:
+		return wk, wv[1:], false
+	}
+}
+
+// seekForward returns a copy of the first entry of the iterator after key, or from key if
+// inclusive.
+func seekForward(it iterator.Iterator, key []byte, inclusive bool) ([]byte, []byte) {
+	if it == nil {
+		return nil, nil
+	}
+	var ok bool
+	switch {
+	case key == nil:
+		ok = it.First()
+	case !inclusive && it.Valid() && bytes.Equal(it.Key(), key):
+		// The iterator is still at the position of the cursor, which is the common case.
+		ok = it.Next()
+	default:
+		ok = it.Seek(key)
+		if ok && !inclusive && bytes.Equal(it.Key(), key) {
+			ok = it.Next()
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+	return copyEntry(it)
+}
+
+// seekBackward returns a copy of the last entry of the iterator before key, or from key if
+// inclusive.
+func seekBackward(it iterator.Iterator, key []byte, inclusive bool) ([]byte, []byte) {
+	if it == nil {
+		return nil, nil
+	}
+	var ok bool
+	switch {
+	case key == nil:
+		ok = it.Last()
+	case !inclusive && it.Valid() && bytes.Equal(it.Key(), key):
+		ok = it.Prev()
+	case !it.Seek(key):
+		// All the keys are before key.
+		ok = it.Last()
+	case inclusive && bytes.Equal(it.Key(), key):
+		ok = true
+	default:
+		ok = it.Prev()
+	}
+	if !ok {
+		return nil, nil
+	}
+	return copyEntry(it)
+}
+
+// copyEntry copies the entry of an iterator, which is only valid until the iterator moves.
+func copyEntry(it iterator.Iterator) ([]byte, []byte) {
+	k := append([]byte{}, it.Key()...)
+	v := append([]byte{}, it.Value()...)
+	return k, v
+}
+
+func bucketMarkerKey(name []byte) []byte {
+	return append([]byte{bucketMarkerPrefix}, name...)
+}
+
+func bucketKeysPrefix(name []byte) []byte {
+	prefix := make([]byte, 1+binary.MaxVarintLen64+len(name))
+	prefix[0] = bucketKeyPrefix
+	n := binary.PutUvarint(prefix[1:], uint64(len(name)))
+	copy(prefix[1+n:], name)
+	return prefix[:1+n+len(name)]
+}
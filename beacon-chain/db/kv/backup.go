@@ -3,9 +3,11 @@ package kv
 import (
 	"context"
 	"fmt"
+	"os"
 	"path"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"github.com/prysmaticlabs/prysm/shared/fileutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	bolt "go.etcd.io/bbolt"
@@ -37,6 +39,11 @@ func (s *Store) Backup(ctx context.Context, outputDir string) error {
 	if head == nil {
 		return errors.New("no head block")
 	}
+	// Backups are bolt files, which the restore command copies back in place.
+	boltDB, ok := backend.BoltDB(s.db)
+	if !ok {
+		return errors.Errorf("backups are not supported by the %s database backend", s.backendKind)
+	}
 	// Ensure the backups directory exists.
 	if err := fileutil.MkdirAll(backupsDir); err != nil {
 		return err
@@ -44,30 +51,25 @@ func (s *Store) Backup(ctx context.Context, outputDir string) error {
 	backupPath := path.Join(backupsDir, fmt.Sprintf("prysm_beacondb_at_slot_%07d.backup", head.Block.Slot))
 	log.WithField("backup", backupPath).Info("Writing backup database.")
 
-	copyDB, err := bolt.Open(
-		backupPath,
-		params.BeaconIoConfig().ReadWritePermissions,
-		&bolt.Options{Timeout: params.BeaconIoConfig().BoltTimeout},
-	)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err := copyDB.Close(); err != nil {
-			log.WithError(err).Error("Failed to close backup database")
-		}
-	}()
+	// Concurrent backups at the same head would write to the same file.
+	s.backupLock.Lock()
+	defer s.backupLock.Unlock()
 
-	return s.db.View(func(tx *bolt.Tx) error {
-		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
-			log.Debugf("Copying bucket %s\n", name)
-			return copyDB.Update(func(tx2 *bolt.Tx) error {
-				b2, err := tx2.CreateBucketIfNotExists(name)
-				if err != nil {
-					return err
-				}
-				return b.ForEach(b2.Put)
-			})
-		})
-	})
+	// The read transaction gives a consistent snapshot of the database while it keeps being
+	// written to. The snapshot is written to a temporary file, so that the backup file is never
+	// left partially written.
+	tmpPath := backupPath + ".tmp"
+	if err := boltDB.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(tmpPath, params.BeaconIoConfig().ReadWritePermissions)
+	}); err != nil {
+		if rmErr := os.Remove(tmpPath); rmErr != nil && !os.IsNotExist(rmErr) {
+			log.WithError(rmErr).Error("Failed to remove partial backup database")
+		}
+		return errors.Wrap(err, "could not write backup database")
+	}
+	if err := os.Rename(tmpPath, backupPath); err != nil {
+		return errors.Wrap(err, "could not move backup database")
+	}
+	log.WithField("backup", backupPath).Info("Backup database written.")
+	return nil
 }
@@ -5,9 +5,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
 
@@ -27,12 +29,21 @@ func TestStore_Backup(t *testing.T) {
 	require.NoError(t, db.SaveState(ctx, st, root))
 	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
 
-	require.NoError(t, db.Backup(ctx, ""))
+	// Concurrent backups do not corrupt each other.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, db.Backup(ctx, ""))
+		}()
+	}
+	wg.Wait()
 
 	backupsPath := filepath.Join(db.databasePath, backupsDirectoryName)
 	files, err := ioutil.ReadDir(backupsPath)
 	require.NoError(t, err)
-	require.NotEqual(t, 0, len(files), "No backups created")
+	require.Equal(t, 1, len(files), "Expected a single backup without temporary files")
 	require.NoError(t, db.Close(), "Failed to close database")
 
 	oldFilePath := filepath.Join(backupsPath, files[0].Name())
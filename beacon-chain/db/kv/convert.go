@@ -0,0 +1,139 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/sirupsen/logrus"
+)
+
+// convertTxSize is the number of bytes written in a single transaction when converting a database.
+const convertTxSize = 64 * 1024 * 1024
+
+// ConvertDatabase copies the beacon node database in dirPath into a new database stored by the dst
+// backend. The converted database is written next to the source database, which is renamed with a
+// .bak suffix once the conversion succeeds, so that an interrupted conversion leaves the source
+// database in place.
+func ConvertDatabase(ctx context.Context, dirPath string, dst backend.Kind) error {
+	var src backend.Kind
+	for _, kind := range backend.Kinds {
+		exists, err := databaseExists(dirPath, kind)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if kind == dst {
+			return errors.Errorf("a %s database already exists in %s", dst, dirPath)
+		}
+		src = kind
+	}
+	if src == "" {
+		return errors.Errorf("no database found in %s", dirPath)
+	}
+
+	srcPath := path.Join(dirPath, DatabaseFile(src))
+	dstPath := path.Join(dirPath, DatabaseFile(dst))
+	tmpPath := dstPath + ".tmp"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return errors.Wrap(err, "could not remove the database of a previous conversion")
+	}
+	srcDB, err := backend.Open(src, srcPath, nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s database", src)
+	}
+	dstDB, err := backend.Open(dst, tmpPath, nil)
+	if err != nil {
+		if closeErr := srcDB.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Failed to close database")
+		}
+		return errors.Wrapf(err, "could not create %s database", dst)
+	}
+
+	log.WithFields(logrus.Fields{
+		"from": src,
+		"to":   dst,
+	}).Info("Converting database, this may take a while")
+	copied, err := copyBuckets(ctx, dstDB, srcDB)
+	if closeErr := srcDB.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Failed to close database")
+	}
+	if closeErr := dstDB.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if rmErr := os.RemoveAll(tmpPath); rmErr != nil {
+			log.WithError(rmErr).Error("Failed to remove partially converted database")
+		}
+		return errors.Wrap(err, "could not convert database")
+	}
+
+	backupPath := srcPath + ".bak"
+	if err := os.Rename(srcPath, backupPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"keys":   copied,
+		"source": backupPath,
+	}).Info("Converted database, the source database can be removed once the beacon node runs on the new one")
+	return nil
+}
+
+// copyBuckets copies all the buckets of src to dst, committing the writes in transactions of
+// convertTxSize bytes to bound the memory used by a transaction, and returns the number of keys
+// copied.
+func copyBuckets(ctx context.Context, dst, src backend.DB) (int, error) {
+	type entry struct {
+		k, v []byte
+	}
+	var bucket []byte
+	batch := make([]entry, 0)
+	var batchSize, copied int
+	flush := func() error {
+		if err := dst.Update(func(tx backend.Tx) error {
+			bkt, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return err
+			}
+			for _, e := range batch {
+				if err := bkt.Put(e.k, e.v); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		copied += len(batch)
+		batch = batch[:0]
+		batchSize = 0
+		return ctx.Err()
+	}
+
+	err := src.View(func(tx backend.Tx) error {
+		return tx.ForEach(func(name []byte, b backend.Bucket) error {
+			bucket = append([]byte{}, name...)
+			if err := b.ForEach(func(k, v []byte) error {
+				// The slices of a transaction are only valid until it ends.
+				batch = append(batch, entry{k: append([]byte{}, k...), v: append([]byte{}, v...)})
+				batchSize += len(k) + len(v)
+				if batchSize < convertTxSize {
+					return nil
+				}
+				return flush()
+			}); err != nil {
+				return err
+			}
+			// The bucket is created even if it is empty.
+			return flush()
+		})
+	})
+	return copied, err
+}
@@ -0,0 +1,57 @@
+package kv
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestConvertDatabase(t *testing.T) {
+	ctx := context.Background()
+	dbDir := t.TempDir()
+	db, err := NewKVStore(ctx, dbDir, &Config{})
+	require.NoError(t, err)
+	b := testutil.NewBeaconBlock()
+	b.Block.Slot = 5
+	require.NoError(t, db.SaveBlock(ctx, b))
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
+	require.NoError(t, db.Close())
+
+	require.NoError(t, ConvertDatabase(ctx, dbDir, backend.LevelDB))
+	assert.Equal(t, false, fileutil.FileExists(path.Join(dbDir, DatabaseFileName)))
+	assert.Equal(t, true, fileutil.FileExists(path.Join(dbDir, DatabaseFileName+".bak")))
+	assert.ErrorContains(t, "a leveldb database already exists", ConvertDatabase(ctx, dbDir, backend.LevelDB))
+
+	db, err = NewKVStore(ctx, dbDir, &Config{Backend: backend.LevelDB})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	head, err := db.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, b, head)
+	assert.Equal(t, true, db.HasState(ctx, root))
+	assert.ErrorContains(t, "backups are not supported by the leveldb database backend", db.Backup(ctx, ""))
+}
+
+func TestNewKVStore_OtherBackend(t *testing.T) {
+	ctx := context.Background()
+	dbDir := t.TempDir()
+	db, err := NewKVStore(ctx, dbDir, &Config{Backend: backend.LevelDB})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = NewKVStore(ctx, dbDir, &Config{})
+	assert.ErrorContains(t, "found a leveldb database", err)
+}
@@ -71,6 +71,8 @@ func isSSZStorageFormat(obj interface{}) bool {
 		return true
 	case *ethpb.VoluntaryExit:
 		return true
+	case *ethpb.SignedVoluntaryExit:
+		return true
 	default:
 		return false
 	}
@@ -3,14 +3,15 @@ package kv
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -32,12 +33,13 @@ var containerFinalizedButNotCanonical = []byte("recent block needs reindexing to
 //   - De-index all finalized beacon block roots from previous_finalized_epoch to
 //     new_finalized_epoch. (I.e. delete these roots from the index, to be re-indexed.)
 //   - Build the canonical finalized chain by walking up the ancestry chain from the finalized block
-//     root until a parent is found in the index or the parent is genesis.
+//     root until a parent is found in the index, the parent is genesis or the block is the
+//     checkpoint sync origin.
 //   - Add all block roots in the database where epoch(block.slot) == checkpoint.epoch.
 //
 // This method ensures that all blocks from the current finalized epoch are considered "final" while
 // maintaining only canonical and finalized blocks older than the current finalized epoch.
-func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, checkpoint *ethpb.Checkpoint) error {
+func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx backend.Tx, checkpoint *ethpb.Checkpoint) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.updateFinalizedBlockRoots")
 	defer span.End()
 
@@ -46,6 +48,7 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	root := checkpoint.Root
 	var previousRoot []byte
 	genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+	originRoot := tx.Bucket(blocksBucket).Get(originBlockRootKey)
 
 	// De-index recent finalized block roots, to be re-indexed.
 	previousFinalizedCheckpoint := &ethpb.Checkpoint{}
@@ -105,6 +108,11 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 			return err
 		}
 
+		// The ancestors of a checkpoint sync origin block are not in the database.
+		if originRoot != nil && bytes.Equal(root, originRoot) {
+			break
+		}
+
 		// Found parent, loop exit condition.
 		if parentBytes := bkt.Get(block.ParentRoot); parentBytes != nil {
 			parent := &dbpb.FinalizedBlockRootContainer{}
@@ -155,6 +163,65 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	return bkt.Put(previousFinalizedCheckpointKey, enc)
 }
 
+// BackfillBlockRoot returns the root of the lowest block backfilled below the checkpoint sync
+// origin, or a zero root if no block was backfilled yet.
+func (s *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.BackfillBlockRoot")
+	defer span.End()
+	var root [32]byte
+	err := s.db.View(func(tx backend.Tx) error {
+		root = bytesutil.ToBytes32(tx.Bucket(finalizedBlockRootsIndexBucket).Get(backfillBlockRootKey))
+		return nil
+	})
+	return root, err
+}
+
+// SaveBackfilledBlocks saves blocks downloaded backwards from the checkpoint sync origin, ordered by
+// descending slot, where each block is the parent of the block before it and the first block is the
+// parent of the lowest block backfilled so far. As ancestors of the finalized origin, the blocks are
+// indexed as finalized and canonical, and the last block becomes the lowest backfilled block.
+func (s *Store) SaveBackfilledBlocks(ctx context.Context, blocks []*ethpb.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfilledBlocks")
+	defer span.End()
+	if len(blocks) == 0 {
+		return nil
+	}
+	if err := s.SaveBlocks(ctx, blocks); err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	err := s.db.Update(func(tx backend.Tx) error {
+		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+		childRoot := bkt.Get(backfillBlockRootKey)
+		if childRoot == nil {
+			childRoot = tx.Bucket(blocksBucket).Get(originBlockRootKey)
+		}
+		if childRoot == nil {
+			return errors.New("no checkpoint sync origin in database")
+		}
+		for _, block := range blocks {
+			root, err := block.Block.HashTreeRoot()
+			if err != nil {
+				return err
+			}
+			enc, err := encode(ctx, &dbpb.FinalizedBlockRootContainer{
+				ParentRoot: block.Block.ParentRoot,
+				ChildRoot:  childRoot,
+			})
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(root[:], enc); err != nil {
+				return err
+			}
+			childRoot = root[:]
+		}
+		return bkt.Put(backfillBlockRootKey, childRoot)
+	})
+	traceutil.AnnotateError(span, err)
+	return err
+}
+
 // IsFinalizedBlock returns true if the block root is present in the finalized block root index.
 // A beacon block root contained exists in this index if it is considered finalized and canonical.
 // Note: beacon blocks from the latest finalized epoch return true, whether or not they are
@@ -164,7 +231,7 @@ func (s *Store) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool {
 	defer span.End()
 
 	var exists bool
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		exists = tx.Bucket(finalizedBlockRootsIndexBucket).Get(blockRoot[:]) != nil
 		// Check genesis block root.
 		if !exists {
@@ -187,7 +254,7 @@ func (s *Store) FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (*e
 	defer span.End()
 
 	var blk *ethpb.SignedBeaconBlock
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		blkBytes := tx.Bucket(finalizedBlockRootsIndexBucket).Get(blockRoot[:])
 		if blkBytes == nil {
 			return nil
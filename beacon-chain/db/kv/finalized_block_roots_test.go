@@ -131,6 +131,85 @@ func TestStore_IsFinalized_ForkEdgeCase(t *testing.T) {
 	}
 }
 
+func TestStore_IsFinalizedBlock_FromOrigin(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	// The origin block's parent is not in the db.
+	blks := makeBlocks(t, slotsPerEpoch*2-1, slotsPerEpoch*3, bytesutil.ToBytes32([]byte("missing parent")))
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	originRoot, err := blks[0].Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, originRoot))
+	savedRoot, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, originRoot, savedRoot)
+
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, originRoot))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: originRoot[:]}))
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, originRoot))
+
+	root, err := blks[slotsPerEpoch].Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: root[:]}))
+	for i := uint64(0); i <= slotsPerEpoch; i++ {
+		root, err := blks[i].Block.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Block at index %d was not considered finalized in the index", i)
+	}
+}
+
+func TestStore_SaveBackfilledBlocks(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 1, 10, genesisBlockRoot)
+	require.NoError(t, db.SaveBlock(ctx, blks[9]))
+	originRoot, err := blks[9].Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveOriginBlockRoot(ctx, originRoot))
+
+	reversed := func(blks []*ethpb.SignedBeaconBlock) []*ethpb.SignedBeaconBlock {
+		r := make([]*ethpb.SignedBeaconBlock, len(blks))
+		for i, b := range blks {
+			r[len(blks)-1-i] = b
+		}
+		return r
+	}
+	require.NoError(t, db.SaveBackfilledBlocks(ctx, reversed(blks[5:9])))
+	lowestRoot, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	wantedRoot, err := blks[5].Block.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantedRoot, lowestRoot)
+	child, err := db.FinalizedChildBlock(ctx, wantedRoot)
+	require.NoError(t, err)
+	assert.DeepEqual(t, blks[6], child)
+	child, err = db.FinalizedChildBlock(ctx, bytesutil.ToBytes32(blks[9].Block.ParentRoot))
+	require.NoError(t, err)
+	assert.DeepEqual(t, blks[9], child)
+
+	require.NoError(t, db.SaveBackfilledBlocks(ctx, reversed(blks[:5])))
+	for i := 0; i < 9; i++ {
+		root, err := blks[i].Block.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Block at index %d was not considered finalized in the index", i)
+		assert.Equal(t, true, db.HasBlock(ctx, root))
+	}
+	lowestRoot, err = db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	wantedRoot, err = blks[0].Block.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantedRoot, lowestRoot)
+}
+
 func TestStore_IsFinalizedChildBlock(t *testing.T) {
 	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
 	db := setupDB(t)
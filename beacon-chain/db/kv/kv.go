@@ -1,20 +1,20 @@
-// Package kv defines a bolt-db, key-value store implementation
-// of the Database interface defined by a Prysm beacon node.
+// Package kv defines a key-value store implementation of the Database
+// interface defined by a Prysm beacon node, stored by bolt-db by default.
 package kv
 
 import (
 	"context"
 	"os"
 	"path"
-	"time"
+	"sync"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	prombolt "github.com/prysmaticlabs/prombbolt"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"github.com/prysmaticlabs/prysm/shared/fileutil"
-	"github.com/prysmaticlabs/prysm/shared/params"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -29,8 +29,8 @@ const (
 	BeaconNodeDbDirName = "beaconchaindata"
 	// DatabaseFileName is the name of the beacon node database.
 	DatabaseFileName = "beaconchain.db"
-
-	boltAllocSize = 8 * 1024 * 1024
+	// LevelDBDirName is the name of the directory of the beacon node database stored by leveldb.
+	LevelDBDirName = "beaconchain.leveldb"
 )
 
 // BlockCacheSize specifies 1000 slots worth of blocks cached, which
@@ -48,23 +48,30 @@ var blockedBuckets = [][]byte{
 	finalizedBlockRootsIndexBucket,
 }
 
-// Config for the bolt db kv store.
+// Config for the kv store.
 type Config struct {
 	InitialMMapSize int
+	// FreelistType and NoFreelistSync tune the freelist of a bolt database.
+	FreelistType   string
+	NoFreelistSync bool
+	// Backend is the storage engine of the database, bolt if empty.
+	Backend backend.Kind
 }
 
 // Store defines an implementation of the Prysm Database interface
-// using BoltDB as the underlying persistent kv-store for eth2.
+// using a key-value storage engine, BoltDB by default, as the underlying persistent kv-store for eth2.
 type Store struct {
-	db                  *bolt.DB
+	db                  backend.DB
+	backendKind         backend.Kind
 	databasePath        string
 	blockCache          *ristretto.Cache
 	validatorIndexCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
 	ctx                 context.Context
+	backupLock          sync.Mutex
 }
 
-// NewKVStore initializes a new boltDB key-value store at the directory
+// NewKVStore initializes a new key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
 func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, error) {
@@ -77,22 +84,33 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			return nil, err
 		}
 	}
-	datafile := path.Join(dirPath, DatabaseFileName)
-	boltDB, err := bolt.Open(
-		datafile,
-		params.BeaconIoConfig().ReadWritePermissions,
-		&bolt.Options{
-			Timeout:         1 * time.Second,
-			InitialMmapSize: config.InitialMMapSize,
-		},
-	)
-	if err != nil {
-		if errors.Is(err, bolt.ErrTimeout) {
-			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+	kind := config.Backend
+	if kind == "" {
+		kind = backend.Bolt
+	}
+	// A database stored by another backend is not opened, as the beacon node would otherwise sync
+	// from scratch in a new database next to it.
+	for _, other := range backend.Kinds {
+		if other == kind {
+			continue
+		}
+		exists, err := databaseExists(dirPath, other)
+		if err != nil {
+			return nil, err
 		}
+		if exists {
+			return nil, errors.Errorf("found a %s database in %s, convert it to %s with the db convert command "+
+				"or remove it", other, dirPath, kind)
+		}
+	}
+	kvDB, err := backend.Open(kind, path.Join(dirPath, DatabaseFile(kind)), &backend.Options{
+		InitialMMapSize: config.InitialMMapSize,
+		FreelistType:    config.FreelistType,
+		NoFreelistSync:  config.NoFreelistSync,
+	})
+	if err != nil {
 		return nil, err
 	}
-	boltDB.AllocSize = boltAllocSize
 	blockCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,           // number of keys to track frequency of (1000).
 		MaxCost:     BlockCacheSize, // maximum cost of cache (1000 Blocks).
@@ -112,7 +130,8 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	}
 
 	kv := &Store{
-		db:                  boltDB,
+		db:                  kvDB,
+		backendKind:         kind,
 		databasePath:        dirPath,
 		blockCache:          blockCache,
 		validatorIndexCache: validatorCache,
@@ -120,7 +139,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		ctx:                 ctx,
 	}
 
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
+	if err := kv.db.Update(func(tx backend.Tx) error {
 		return createBuckets(
 			tx,
 			attestationsBucket,
@@ -133,6 +152,13 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			checkpointBucket,
 			powchainBucket,
 			stateSummaryBucket,
+			// Operation pools buckets.
+			poolAttestationsBucket,
+			poolAttesterSlashingsBucket,
+			poolProposerSlashingsBucket,
+			poolVoluntaryExitsBucket,
+			// Attestation inclusions archive bucket.
+			attestationInclusionsBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -152,26 +178,49 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		return nil, err
 	}
 
-	err = prometheus.Register(createBoltCollector(kv.db))
+	if boltDB, ok := backend.BoltDB(kv.db); ok {
+		err = prometheus.Register(createBoltCollector(boltDB))
+	}
 
 	return kv, err
 }
 
+// DatabaseFile returns the name of the file, or directory, of the beacon node database stored by
+// the given backend.
+func DatabaseFile(kind backend.Kind) string {
+	if kind == backend.LevelDB {
+		return LevelDBDirName
+	}
+	return DatabaseFileName
+}
+
+func databaseExists(dirPath string, kind backend.Kind) (bool, error) {
+	dbPath := path.Join(dirPath, DatabaseFile(kind))
+	if kind == backend.LevelDB {
+		return fileutil.HasDir(dbPath)
+	}
+	return fileutil.FileExists(dbPath), nil
+}
+
 // ClearDB removes the previously stored database in the data directory.
 func (s *Store) ClearDB() error {
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(s.db))
-	if err := os.Remove(path.Join(s.databasePath, DatabaseFileName)); err != nil {
+	if boltDB, ok := backend.BoltDB(s.db); ok {
+		prometheus.Unregister(createBoltCollector(boltDB))
+	}
+	if err := os.RemoveAll(path.Join(s.databasePath, DatabaseFile(s.backendKind))); err != nil {
 		return errors.Wrap(err, "could not remove database file")
 	}
 	return nil
 }
 
-// Close closes the underlying BoltDB database.
+// Close closes the underlying database.
 func (s *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(s.db))
+	if boltDB, ok := backend.BoltDB(s.db); ok {
+		prometheus.Unregister(createBoltCollector(boltDB))
+	}
 
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
@@ -186,7 +235,7 @@ func (s *Store) DatabasePath() string {
 	return s.databasePath
 }
 
-func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+func createBuckets(tx backend.Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 			return err
@@ -2,29 +2,134 @@ package kv
 
 import (
 	"context"
+	"time"
 
-	bolt "go.etcd.io/bbolt"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
 )
 
 var migrationCompleted = []byte("done")
 
-type migration func(*bolt.Tx) error
+// schemaVersionKey stores the version of the database schema, which is the version of the last
+// migration applied to the database.
+var schemaVersionKey = []byte("schema-version")
 
-var migrations = []migration{
-	migrateArchivedIndex,
-	migrateBlockSlotIndex,
+// errDryRun rolls back the transaction of a migration run as a dry run.
+var errDryRun = errors.New("migration dry run")
+
+type migration func(backend.Tx) error
+
+// versionedMigration upgrades the database schema to its version.
+type versionedMigration struct {
+	version uint64
+	name    string
+	migrate migration
+}
+
+// migrations in the order of their versions. A new migration is appended with the next version,
+// and the versions of released migrations must never change.
+var migrations = []*versionedMigration{
+	{version: 1, name: "archived index", migrate: migrateArchivedIndex},
+	{version: 2, name: "block slot index", migrate: migrateBlockSlotIndex},
+}
+
+// LatestSchemaVersion is the version of the database schema once all migrations are applied.
+func LatestSchemaVersion() uint64 {
+	return migrations[len(migrations)-1].version
+}
+
+// SchemaVersion returns the version of the database schema. Databases created before schema
+// versions were recorded are at version 0.
+func (s *Store) SchemaVersion(ctx context.Context) (uint64, error) {
+	var version uint64
+	err := s.db.View(func(tx backend.Tx) error {
+		version = schemaVersion(tx)
+		return nil
+	})
+	return version, err
 }
 
 // RunMigrations defined in the migrations array.
 func (s *Store) RunMigrations(ctx context.Context) error {
-	for _, m := range migrations {
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
+	return s.MigrateSchema(ctx, false /* dryRun */)
+}
 
-		if err := s.db.Update(m); err != nil {
+// MigrateSchema applies the migrations above the schema version of the database in order. Each
+// migration is applied in its own transaction along with the update of the schema version, so
+// that an interrupted upgrade resumes from the first migration which was not applied. On a dry
+// run, all the migrations are applied in a single transaction which is then rolled back, leaving
+// the database unchanged.
+func (s *Store) MigrateSchema(ctx context.Context, dryRun bool) error {
+	version, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	latest := LatestSchemaVersion()
+	if version > latest {
+		return errors.Errorf("database schema version %d is newer than the latest supported version %d, "+
+			"the database was used by a more recent version of the beacon node", version, latest)
+	}
+	if version == latest {
+		return nil
+	}
+
+	log.WithFields(logrus.Fields{
+		"schemaVersion": version,
+		"latestVersion": latest,
+		"dryRun":        dryRun,
+	}).Info("Migrating database schema")
+	pending := migrations[len(migrations)-int(latest-version):]
+	if dryRun {
+		err := s.db.Update(func(tx backend.Tx) error {
+			for _, m := range pending {
+				if err := applyMigration(ctx, tx, m); err != nil {
+					return err
+				}
+			}
+			return errDryRun
+		})
+		if errors.Is(err, errDryRun) {
+			log.Info("Database schema migration dry run succeeded, no changes were written")
+			return nil
+		}
+		return err
+	}
+	for _, m := range pending {
+		if err := s.db.Update(func(tx backend.Tx) error {
+			return applyMigration(ctx, tx, m)
+		}); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// This applies a migration and updates the schema version to its version in the transaction.
+func applyMigration(ctx context.Context, tx backend.Tx, m *versionedMigration) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	start := time.Now()
+	if err := m.migrate(tx); err != nil {
+		return errors.Wrapf(err, "could not apply migration to schema version %d (%s)", m.version, m.name)
+	}
+	if err := tx.Bucket(migrationsBucket).Put(schemaVersionKey, bytesutil.Uint64ToBytesBigEndian(m.version)); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"version":  m.version,
+		"name":     m.name,
+		"duration": time.Since(start),
+	}).Info("Applied database migration")
+	return nil
+}
+
+func schemaVersion(tx backend.Tx) uint64 {
+	bkt := tx.Bucket(migrationsBucket)
+	if bkt == nil {
+		return 0
+	}
+	return bytesutil.BytesToUint64BigEndian(bkt.Get(schemaVersionKey))
+}
@@ -6,13 +6,13 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	bolt "go.etcd.io/bbolt"
 )
 
 var migrationArchivedIndex0Key = []byte("archive_index_0")
 
-func migrateArchivedIndex(tx *bolt.Tx) error {
+func migrateArchivedIndex(tx backend.Tx) error {
 	mb := tx.Bucket(migrationsBucket)
 	if b := mb.Get(migrationArchivedIndex0Key); bytes.Equal(b, migrationCompleted) {
 		return nil // Migration already completed.
@@ -3,21 +3,21 @@ package kv
 import (
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
-	"go.etcd.io/bbolt"
 )
 
 func Test_migrateBlockSlotIndex(t *testing.T) {
 	tests := []struct {
 		name  string
-		setup func(t *testing.T, db *bbolt.DB)
-		eval  func(t *testing.T, db *bbolt.DB)
+		setup func(t *testing.T, db backend.DB)
+		eval  func(t *testing.T, db backend.DB)
 	}{
 		{
 			name: "only runs once",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					if err := tx.Bucket(blockSlotIndicesBucket).Put([]byte("2048"), []byte("foo")); err != nil {
 						return err
 					}
@@ -25,8 +25,8 @@ func Test_migrateBlockSlotIndex(t *testing.T) {
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
 					v := tx.Bucket(blockSlotIndicesBucket).Get([]byte("2048"))
 					assert.DeepEqual(t, []byte("foo"), v, "Did not receive correct data for key 2048")
 					return nil
@@ -36,14 +36,14 @@ func Test_migrateBlockSlotIndex(t *testing.T) {
 		},
 		{
 			name: "migrates and deletes entries",
-			setup: func(t *testing.T, db *bbolt.DB) {
-				err := db.Update(func(tx *bbolt.Tx) error {
+			setup: func(t *testing.T, db backend.DB) {
+				err := db.Update(func(tx backend.Tx) error {
 					return tx.Bucket(blockSlotIndicesBucket).Put([]byte("2048"), []byte("foo"))
 				})
 				assert.NoError(t, err)
 			},
-			eval: func(t *testing.T, db *bbolt.DB) {
-				err := db.View(func(tx *bbolt.Tx) error {
+			eval: func(t *testing.T, db backend.DB) {
+				err := db.View(func(tx backend.Tx) error {
 					k := uint64(2048)
 					v := tx.Bucket(blockSlotIndicesBucket).Get(bytesutil.Uint64ToBytesBigEndian(k))
 					assert.DeepEqual(t, []byte("foo"), v, "Did not receive correct data for key %d", k)
@@ -0,0 +1,98 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_MigrateSchema(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	version, err := db.SchemaVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), version)
+
+	require.NoError(t, db.RunMigrations(ctx))
+	version, err = db.SchemaVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LatestSchemaVersion(), version)
+
+	// Running the migrations again is a no-op.
+	require.NoError(t, db.RunMigrations(ctx))
+}
+
+func TestStore_MigrateSchema_Resumes(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	testKey := []byte("test-migration")
+	applied := make([]uint64, 0)
+	testMigration := func(version uint64, fail bool) *versionedMigration {
+		return &versionedMigration{
+			version: version,
+			name:    "test",
+			migrate: func(tx backend.Tx) error {
+				if err := tx.Bucket(migrationsBucket).Put(testKey, bytesutil.Uint64ToBytesBigEndian(version)); err != nil {
+					return err
+				}
+				if fail {
+					return errors.New("migration failed")
+				}
+				applied = append(applied, version)
+				return nil
+			},
+		}
+	}
+	defer func(m []*versionedMigration) {
+		migrations = m
+	}(migrations)
+
+	migrations = []*versionedMigration{testMigration(1, false), testMigration(2, false), testMigration(3, true)}
+	assert.ErrorContains(t, "could not apply migration to schema version 3", db.RunMigrations(ctx))
+	version, err := db.SchemaVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+	// The failed migration is rolled back.
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.DeepEqual(t, bytesutil.Uint64ToBytesBigEndian(2), tx.Bucket(migrationsBucket).Get(testKey))
+		return nil
+	}))
+
+	migrations[2] = testMigration(3, false)
+	require.NoError(t, db.RunMigrations(ctx))
+	version, err = db.SchemaVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), version)
+	assert.DeepEqual(t, []uint64{1, 2, 3}, applied)
+}
+
+func TestStore_MigrateSchema_DryRun(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.MigrateSchema(ctx, true /* dryRun */))
+	version, err := db.SchemaVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), version)
+	require.NoError(t, db.db.View(func(tx backend.Tx) error {
+		assert.DeepEqual(t, []byte(nil), tx.Bucket(migrationsBucket).Get(migrationArchivedIndex0Key))
+		return nil
+	}))
+}
+
+func TestStore_MigrateSchema_NewerVersion(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.db.Update(func(tx backend.Tx) error {
+		return tx.Bucket(migrationsBucket).Put(schemaVersionKey, bytesutil.Uint64ToBytesBigEndian(LatestSchemaVersion()+1))
+	}))
+	assert.ErrorContains(t, "is newer than the latest supported version", db.RunMigrations(ctx))
+}
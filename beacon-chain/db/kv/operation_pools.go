@@ -0,0 +1,145 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"go.opencensus.io/trace"
+)
+
+// PoolAttestations retrieves the attestations of the attestation pool saved in the db.
+func (s *Store) PoolAttestations(ctx context.Context) ([]*ethpb.Attestation, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PoolAttestations")
+	defer span.End()
+	var atts []*ethpb.Attestation
+	err := s.poolOperations(ctx, poolAttestationsBucket, func() proto.Message {
+		att := &ethpb.Attestation{}
+		atts = append(atts, att)
+		return att
+	})
+	return atts, err
+}
+
+// SavePoolAttestations replaces the attestations of the attestation pool saved in the db.
+func (s *Store) SavePoolAttestations(ctx context.Context, atts []*ethpb.Attestation) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePoolAttestations")
+	defer span.End()
+	msgs := make([]proto.Message, len(atts))
+	for i, att := range atts {
+		msgs[i] = att
+	}
+	return s.savePoolOperations(ctx, poolAttestationsBucket, msgs)
+}
+
+// PoolAttesterSlashings retrieves the attester slashings of the slashings pool saved in the db.
+func (s *Store) PoolAttesterSlashings(ctx context.Context) ([]*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PoolAttesterSlashings")
+	defer span.End()
+	var slashings []*ethpb.AttesterSlashing
+	err := s.poolOperations(ctx, poolAttesterSlashingsBucket, func() proto.Message {
+		slashing := &ethpb.AttesterSlashing{}
+		slashings = append(slashings, slashing)
+		return slashing
+	})
+	return slashings, err
+}
+
+// SavePoolAttesterSlashings replaces the attester slashings of the slashings pool saved in the db.
+func (s *Store) SavePoolAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePoolAttesterSlashings")
+	defer span.End()
+	msgs := make([]proto.Message, len(slashings))
+	for i, slashing := range slashings {
+		msgs[i] = slashing
+	}
+	return s.savePoolOperations(ctx, poolAttesterSlashingsBucket, msgs)
+}
+
+// PoolProposerSlashings retrieves the proposer slashings of the slashings pool saved in the db.
+func (s *Store) PoolProposerSlashings(ctx context.Context) ([]*ethpb.ProposerSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PoolProposerSlashings")
+	defer span.End()
+	var slashings []*ethpb.ProposerSlashing
+	err := s.poolOperations(ctx, poolProposerSlashingsBucket, func() proto.Message {
+		slashing := &ethpb.ProposerSlashing{}
+		slashings = append(slashings, slashing)
+		return slashing
+	})
+	return slashings, err
+}
+
+// SavePoolProposerSlashings replaces the proposer slashings of the slashings pool saved in the db.
+func (s *Store) SavePoolProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePoolProposerSlashings")
+	defer span.End()
+	msgs := make([]proto.Message, len(slashings))
+	for i, slashing := range slashings {
+		msgs[i] = slashing
+	}
+	return s.savePoolOperations(ctx, poolProposerSlashingsBucket, msgs)
+}
+
+// PoolVoluntaryExits retrieves the voluntary exits of the exit pool saved in the db.
+func (s *Store) PoolVoluntaryExits(ctx context.Context) ([]*ethpb.SignedVoluntaryExit, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PoolVoluntaryExits")
+	defer span.End()
+	var exits []*ethpb.SignedVoluntaryExit
+	err := s.poolOperations(ctx, poolVoluntaryExitsBucket, func() proto.Message {
+		exit := &ethpb.SignedVoluntaryExit{}
+		exits = append(exits, exit)
+		return exit
+	})
+	return exits, err
+}
+
+// SavePoolVoluntaryExits replaces the voluntary exits of the exit pool saved in the db.
+func (s *Store) SavePoolVoluntaryExits(ctx context.Context, exits []*ethpb.SignedVoluntaryExit) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePoolVoluntaryExits")
+	defer span.End()
+	msgs := make([]proto.Message, len(exits))
+	for i, exit := range exits {
+		msgs[i] = exit
+	}
+	return s.savePoolOperations(ctx, poolVoluntaryExitsBucket, msgs)
+}
+
+// poolOperations decodes the operations of a pool bucket, in the order they were saved, into the
+// messages returned by newMsg.
+func (s *Store) poolOperations(ctx context.Context, bucketName []byte, newMsg func() proto.Message) error {
+	return s.db.View(func(tx backend.Tx) error {
+		return tx.Bucket(bucketName).ForEach(func(_, enc []byte) error {
+			return decode(ctx, enc, newMsg())
+		})
+	})
+}
+
+// savePoolOperations replaces the operations of a pool bucket, keyed by their big endian encoded
+// position so that they are retrieved in the same order.
+func (s *Store) savePoolOperations(ctx context.Context, bucketName []byte, msgs []proto.Message) error {
+	encs := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		enc, err := encode(ctx, msg)
+		if err != nil {
+			return err
+		}
+		encs[i] = enc
+	}
+	return s.db.Update(func(tx backend.Tx) error {
+		if err := tx.DeleteBucket(bucketName); err != nil && err != backend.ErrBucketNotFound {
+			return err
+		}
+		bucket, err := tx.CreateBucket(bucketName)
+		if err != nil {
+			return err
+		}
+		for i, enc := range encs {
+			if err := bucket.Put(bytesutil.Uint64ToBytesBigEndian(uint64(i)), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
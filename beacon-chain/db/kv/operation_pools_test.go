@@ -0,0 +1,89 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_PoolAttestations(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	atts, err := db.PoolAttestations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(atts))
+
+	var want []*ethpb.Attestation
+	// More than 256 attestations check that the order of the keys is kept.
+	for i := 0; i < 300; i++ {
+		att := testutil.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.Bitlist{0x0b}})
+		att.Data.Slot = types.Slot(300 - i)
+		want = append(want, att)
+	}
+	require.NoError(t, db.SavePoolAttestations(ctx, want))
+	atts, err = db.PoolAttestations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(want), len(atts))
+	for i := range want {
+		wantEnc, err := want[i].MarshalSSZ()
+		require.NoError(t, err)
+		enc, err := atts[i].MarshalSSZ()
+		require.NoError(t, err)
+		assert.DeepEqual(t, wantEnc, enc, "Wrong attestation %d", i)
+	}
+
+	// Saving the pool replaces the previous attestations.
+	require.NoError(t, db.SavePoolAttestations(ctx, want[:1]))
+	atts, err = db.PoolAttestations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(atts))
+	require.NoError(t, db.SavePoolAttestations(ctx, nil))
+	atts, err = db.PoolAttestations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(atts))
+}
+
+func TestStore_PoolSlashingsAndExits(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	attSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: testutil.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}}),
+		Attestation_2: testutil.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2}}),
+	}
+	require.NoError(t, db.SavePoolAttesterSlashings(ctx, []*ethpb.AttesterSlashing{attSlashing}))
+	attSlashings, err := db.PoolAttesterSlashings(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(attSlashings))
+	assert.Equal(t, true, proto.Equal(attSlashing, attSlashings[0]))
+
+	propSlashing := &ethpb.ProposerSlashing{
+		Header_1: testutil.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: testutil.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	propSlashing.Header_2.Header.StateRoot[0] = 1
+	require.NoError(t, db.SavePoolProposerSlashings(ctx, []*ethpb.ProposerSlashing{propSlashing}))
+	propSlashings, err := db.PoolProposerSlashings(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(propSlashings))
+	assert.Equal(t, true, proto.Equal(propSlashing, propSlashings[0]))
+
+	exits := []*ethpb.SignedVoluntaryExit{
+		{Exit: &ethpb.VoluntaryExit{Epoch: 3, ValidatorIndex: 5}, Signature: make([]byte, 96)},
+		{Exit: &ethpb.VoluntaryExit{Epoch: 2, ValidatorIndex: 1}, Signature: make([]byte, 96)},
+	}
+	require.NoError(t, db.SavePoolVoluntaryExits(ctx, exits))
+	retrieved, err := db.PoolVoluntaryExits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(exits), len(retrieved))
+	for i := range exits {
+		assert.Equal(t, true, proto.Equal(exits[i], retrieved[i]), "Wrong exit %d", i)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
-	bolt "go.etcd.io/bbolt"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	"go.opencensus.io/trace"
 )
 
@@ -22,7 +24,7 @@ func (s *Store) SavePowchainData(ctx context.Context, data *db.ETH1ChainData) er
 		return err
 	}
 
-	err := s.db.Update(func(tx *bolt.Tx) error {
+	err := s.db.Update(func(tx backend.Tx) error {
 		bkt := tx.Bucket(powchainBucket)
 		enc, err := proto.Marshal(data)
 		if err != nil {
@@ -40,7 +42,7 @@ func (s *Store) PowchainData(ctx context.Context) (*db.ETH1ChainData, error) {
 	defer span.End()
 
 	var data *db.ETH1ChainData
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(powchainBucket)
 		enc := bkt.Get(powchainDataKey)
 		if len(enc) == 0 {
@@ -51,3 +53,41 @@ func (s *Store) PowchainData(ctx context.Context) (*db.ETH1ChainData, error) {
 	})
 	return data, err
 }
+
+// SaveDepositSnapshot saves the snapshot of the finalized deposit tree.
+func (s *Store) SaveDepositSnapshot(ctx context.Context, snapshot *trieutil.DepositTreeSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveDepositSnapshot")
+	defer span.End()
+
+	if snapshot == nil {
+		err := errors.New("cannot save nil deposit snapshot")
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+
+	err := s.db.Update(func(tx backend.Tx) error {
+		bkt := tx.Bucket(powchainBucket)
+		return bkt.Put(depositSnapshotKey, snapshot.Marshal())
+	})
+	traceutil.AnnotateError(span, err)
+	return err
+}
+
+// DepositSnapshot retrieves the snapshot of the finalized deposit tree, or nil if none was saved.
+func (s *Store) DepositSnapshot(ctx context.Context) (*trieutil.DepositTreeSnapshot, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DepositSnapshot")
+	defer span.End()
+
+	var snapshot *trieutil.DepositTreeSnapshot
+	err := s.db.View(func(tx backend.Tx) error {
+		bkt := tx.Bucket(powchainBucket)
+		enc := bkt.Get(depositSnapshotKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		var err error
+		snapshot, err = trieutil.UnmarshalDepositTreeSnapshot(enc, params.BeaconConfig().DepositContractTreeDepth)
+		return err
+	})
+	return snapshot, err
+}
@@ -5,6 +5,10 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 )
 
 func TestStore_SavePowchainData(t *testing.T) {
@@ -33,3 +37,23 @@ func TestStore_SavePowchainData(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_DepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := setupDB(t)
+	snapshot, err := store.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*trieutil.DepositTreeSnapshot)(nil), snapshot)
+
+	items := [][]byte{[]byte("A"), []byte("B"), []byte("C")}
+	trie, err := trieutil.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	want, err := trie.Snapshot(3, 100)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveDepositSnapshot(ctx, want))
+	snapshot, err = store.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, snapshot)
+
+	assert.ErrorContains(t, "cannot save nil deposit snapshot", store.SaveDepositSnapshot(ctx, nil))
+}
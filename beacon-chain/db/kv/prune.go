@@ -0,0 +1,169 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"go.opencensus.io/trace"
+)
+
+// PruneStats reports the number of objects removed from the database by Prune.
+type PruneStats struct {
+	DeletedStates int
+	DeletedBlocks int
+}
+
+// Prune removes the objects below the finalized checkpoint which the node no longer needs:
+// 1.) blocks which are not part of the finalized chain, along with their states and state summaries.
+// 2.) states of the finalized chain, except the highest one of each archived point interval
+//   (e.g. archived_interval=2048, the states with the highest slots in (0, 2048], (2048, 4096]... etc).
+// The genesis, finalized and head states are always kept. Prune is meant to run on a database which
+// is not in use by a beacon node, and does not shrink the database file.
+func (s *Store) Prune(ctx context.Context, slotsPerArchivedPoint types.Slot) (*PruneStats, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Prune")
+	defer span.End()
+
+	if slotsPerArchivedPoint == 0 {
+		return nil, errors.New("slots per archived point must be greater than 0")
+	}
+	stats := &PruneStats{}
+	f, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var genesisRoot, headRoot [32]byte
+	if err := s.db.View(func(tx backend.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		genesisRoot = bytesutil.ToBytes32(bkt.Get(genesisBlockRootKey))
+		headRoot = bytesutil.ToBytes32(bkt.Get(headBlockRootKey))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	fRoot := bytesutil.ToBytes32(f.Root)
+	if fRoot == [32]byte{} || fRoot == genesisRoot {
+		// Nothing is finalized beyond genesis.
+		return stats, nil
+	}
+	fBlock, err := s.Block(ctx, fRoot)
+	if err != nil {
+		return nil, err
+	}
+	if fBlock == nil || fBlock.Block == nil {
+		return nil, errors.New("finalized block is not in the database")
+	}
+	if !s.IsFinalizedBlock(ctx, fRoot) {
+		return nil, errors.New("finalized block is not in the finalized block roots index")
+	}
+	fSlot := fBlock.Block.Slot
+
+	staleBlocks, keptStates, err := s.pruneCandidates(ctx, fSlot, slotsPerArchivedPoint)
+	if err != nil {
+		return nil, err
+	}
+	keptStates[genesisRoot] = true
+	keptStates[fRoot] = true
+	keptStates[headRoot] = true
+
+	// States are deleted before the blocks, as the slot of a state is looked up from its block.
+	deletedStates := make([][32]byte, 0)
+	if err := s.db.View(func(tx backend.Tx) error {
+		return tx.Bucket(stateBucket).ForEach(func(k, _ []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			root := bytesutil.ToBytes32(k)
+			if keptStates[root] {
+				return nil
+			}
+			slot, err := slotByBlockRoot(ctx, tx, k)
+			if err != nil {
+				return err
+			}
+			if slot < fSlot {
+				deletedStates = append(deletedStates, root)
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	log.WithField("count", len(deletedStates)).Info("Deleting pre-finalized states")
+	if err := s.DeleteStates(ctx, deletedStates); err != nil {
+		return nil, err
+	}
+	stats.DeletedStates = len(deletedStates)
+
+	log.WithField("count", len(staleBlocks)).Info("Deleting blocks not in the finalized chain")
+	if err := s.deleteBlocks(ctx, staleBlocks); err != nil {
+		return nil, err
+	}
+	if err := s.db.Update(func(tx backend.Tx) error {
+		bkt := tx.Bucket(stateSummaryBucket)
+		for _, root := range staleBlocks {
+			if err := bkt.Delete(root[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	stats.DeletedBlocks = len(staleBlocks)
+	return stats, nil
+}
+
+// This returns the roots of the blocks below the finalized slot which are not part of the finalized
+// chain, and the roots of the highest finalized state of each archived point interval below it.
+func (s *Store) pruneCandidates(
+	ctx context.Context,
+	fSlot, slotsPerArchivedPoint types.Slot,
+) ([][32]byte, map[[32]byte]bool, error) {
+	staleBlocks := make([][32]byte, 0)
+	keptStates := make(map[[32]byte]bool)
+	err := s.db.View(func(tx backend.Tx) error {
+		finalizedIndex := tx.Bucket(finalizedBlockRootsIndexBucket)
+		genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+		isFinalized := func(root []byte) bool {
+			return finalizedIndex.Get(root) != nil || bytes.Equal(root, genesisRoot)
+		}
+
+		c := tx.Bucket(blockSlotIndicesBucket).Cursor()
+		for k, v := c.First(); k != nil && bytesutil.BytesToSlotBigEndian(k) < fSlot; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			for i := 0; i+32 <= len(v); i += 32 {
+				if !isFinalized(v[i : i+32]) {
+					staleBlocks = append(staleBlocks, bytesutil.ToBytes32(v[i:i+32]))
+				}
+			}
+		}
+
+		// The state slot indices are sorted by slot, so the last finalized state seen in an
+		// interval is its highest one.
+		highestInInterval := make(map[types.Slot][32]byte)
+		c = tx.Bucket(stateSlotIndicesBucket).Cursor()
+		for k, v := c.First(); k != nil && bytesutil.BytesToSlotBigEndian(k) < fSlot; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			interval := (slot + slotsPerArchivedPoint - 1) / slotsPerArchivedPoint
+			for i := 0; i+32 <= len(v); i += 32 {
+				if isFinalized(v[i : i+32]) {
+					highestInInterval[interval] = bytesutil.ToBytes32(v[i : i+32])
+				}
+			}
+		}
+		for _, root := range highestInInterval {
+			keptStates[root] = true
+		}
+		return nil
+	})
+	return staleBlocks, keptStates, err
+}
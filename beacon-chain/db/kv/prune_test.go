@@ -0,0 +1,76 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStore_Prune(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	saveBlock := func(slot types.Slot, parentRoot [32]byte, proposer types.ValidatorIndex) [32]byte {
+		b := testutil.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parentRoot[:]
+		b.Block.ProposerIndex = proposer
+		require.NoError(t, db.SaveBlock(ctx, b))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		st, err := testutil.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, db.SaveState(ctx, st, root))
+		return root
+	}
+
+	// The canonical chain has a block at every slot up to 10, and a fork forms at slot 3.
+	roots := make([][32]byte, 11)
+	roots[0] = saveBlock(0, [32]byte{}, 0)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, roots[0]))
+	for i := 1; i < len(roots); i++ {
+		roots[i] = saveBlock(types.Slot(i), roots[i-1], 0)
+	}
+	forkRoot := saveBlock(3, roots[2], 1)
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, roots[10]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[8][:]}))
+
+	stats, err := db.Prune(ctx, 4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &PruneStats{DeletedStates: 6, DeletedBlocks: 1}, stats)
+
+	assert.Equal(t, false, db.HasBlock(ctx, forkRoot))
+	assert.Equal(t, false, db.HasState(ctx, forkRoot))
+	assert.Equal(t, false, db.HasStateSummary(ctx, forkRoot))
+	// The states of the highest slots of each archived point interval below the finalized slot,
+	// and the states from the finalized slot on are kept.
+	for i, root := range roots {
+		assert.Equal(t, true, db.HasBlock(ctx, root), "Missing block at slot %d", i)
+		kept := i == 0 || i == 4 || i >= 7
+		assert.Equal(t, kept, db.HasState(ctx, root), "Unexpected state at slot %d", i)
+	}
+}
+
+func TestStore_Prune_NothingFinalized(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	genesis := testutil.NewBeaconBlock()
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	root, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, root))
+
+	stats, err := db.Prune(ctx, 4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &PruneStats{}, stats)
+
+	_, err = db.Prune(ctx, 0)
+	assert.ErrorContains(t, "slots per archived point must be greater than 0", err)
+}
@@ -18,6 +18,15 @@ var (
 	checkpointBucket        = []byte("check-point")
 	powchainBucket          = []byte("powchain")
 
+	// Operation pools buckets, saved on shutdown and reloaded on startup.
+	poolAttestationsBucket      = []byte("pool-attestations")
+	poolAttesterSlashingsBucket = []byte("pool-attester-slashings")
+	poolProposerSlashingsBucket = []byte("pool-proposer-slashings")
+	poolVoluntaryExitsBucket    = []byte("pool-voluntary-exits")
+
+	// Archive of the inclusions of attestations in blocks, by epoch and validator index.
+	attestationInclusionsBucket = []byte("attestation-inclusions")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
@@ -37,10 +46,13 @@ var (
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
 	genesisBlockRootKey       = []byte("genesis-root")
+	originBlockRootKey        = []byte("origin-checkpoint-root")
+	backfillBlockRootKey      = []byte("backfill-block-root")
 	depositContractAddressKey = []byte("deposit-contract")
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
 	powchainDataKey           = []byte("powchain-data")
+	depositSnapshotKey        = []byte("deposit-snapshot")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
-	bolt "go.etcd.io/bbolt"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"go.opencensus.io/trace"
 )
 
@@ -49,7 +49,7 @@ func (s *Store) SaveProposerSlashing(ctx context.Context, slashing *ethpb.Propos
 	if err != nil {
 		return err
 	}
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(proposerSlashingsBucket)
 		return bucket.Put(slashingRoot[:], enc)
 	})
@@ -59,7 +59,7 @@ func (s *Store) proposerSlashingBytes(ctx context.Context, slashingRoot [32]byte
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.proposerSlashingBytes")
 	defer span.End()
 	var dst []byte
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(proposerSlashingsBucket)
 		dst = bkt.Get(slashingRoot[:])
 		return nil
@@ -71,7 +71,7 @@ func (s *Store) proposerSlashingBytes(ctx context.Context, slashingRoot [32]byte
 func (s *Store) deleteProposerSlashing(ctx context.Context, slashingRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.deleteProposerSlashing")
 	defer span.End()
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(proposerSlashingsBucket)
 		return bucket.Delete(slashingRoot[:])
 	})
@@ -118,7 +118,7 @@ func (s *Store) SaveAttesterSlashing(ctx context.Context, slashing *ethpb.Attest
 	if err != nil {
 		return err
 	}
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(attesterSlashingsBucket)
 		return bucket.Put(slashingRoot[:], enc)
 	})
@@ -128,7 +128,7 @@ func (s *Store) attesterSlashingBytes(ctx context.Context, slashingRoot [32]byte
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.attesterSlashingBytes")
 	defer span.End()
 	var dst []byte
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bkt := tx.Bucket(attesterSlashingsBucket)
 		dst = bkt.Get(slashingRoot[:])
 		return nil
@@ -140,7 +140,7 @@ func (s *Store) attesterSlashingBytes(ctx context.Context, slashingRoot [32]byte
 func (s *Store) deleteAttesterSlashing(ctx context.Context, slashingRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.deleteAttesterSlashing")
 	defer span.End()
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(attesterSlashingsBucket)
 		return bucket.Delete(slashingRoot[:])
 	})
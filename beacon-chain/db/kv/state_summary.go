@@ -3,9 +3,9 @@ package kv
 import (
 	"context"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -81,7 +81,7 @@ func (s *Store) stateSummaryBytes(ctx context.Context, blockRoot [32]byte) ([]by
 	defer span.End()
 
 	var enc []byte
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx backend.Tx) error {
 		bucket := tx.Bucket(stateSummaryBucket)
 		enc = bucket.Get(blockRoot[:])
 		return nil
@@ -101,7 +101,7 @@ func (s *Store) saveCachedStateSummariesDB(ctx context.Context) error {
 		}
 		encs[i] = enc
 	}
-	if err := s.db.Update(func(tx *bolt.Tx) error {
+	if err := s.db.Update(func(tx backend.Tx) error {
 		bucket := tx.Bucket(stateSummaryBucket)
 		for i, s := range summaries {
 			if err := bucket.Put(s.Root, encs[i]); err != nil {
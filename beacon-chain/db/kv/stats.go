@@ -0,0 +1,113 @@
+package kv
+
+import (
+	"os"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BucketStats describes the content of a database bucket.
+type BucketStats struct {
+	Name     string
+	KeyCount int
+	// InUse is the number of bytes used by the keys and values of the bucket.
+	InUse int
+	// Allocated is the number of bytes of the pages allocated to the bucket.
+	Allocated int
+}
+
+// DatabaseStats describes the content of a database file.
+type DatabaseStats struct {
+	FileSize            int64
+	PageSize            int
+	FreePages           int
+	PendingPages        int
+	FreelistSize        int
+	Buckets             []*BucketStats
+	HasBlocks           bool
+	EarliestBlockSlot   types.Slot
+	LatestBlockSlot     types.Slot
+	HasStates           bool
+	EarliestStateSlot   types.Slot
+	LatestStateSlot     types.Slot
+	SchemaVersion       uint64
+	LatestSchemaVersion uint64
+}
+
+// Stats returns statistics about the content of a database file. The file is opened read only,
+// so that a database which fails to open as a store can still be inspected.
+func Stats(dbFile string) (*DatabaseStats, error) {
+	info, err := os.Stat(dbFile)
+	if err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(dbFile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  params.BeaconIoConfig().BoltTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	dbStats := db.Stats()
+	stats := &DatabaseStats{
+		FileSize:            info.Size(),
+		PageSize:            db.Info().PageSize,
+		FreePages:           dbStats.FreePageN,
+		PendingPages:        dbStats.PendingPageN,
+		FreelistSize:        dbStats.FreelistInuse,
+		Buckets:             make([]*BucketStats, 0),
+		LatestSchemaVersion: LatestSchemaVersion(),
+	}
+	if err := db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			bStats := b.Stats()
+			pages := bStats.BranchPageN + bStats.BranchOverflowN + bStats.LeafPageN + bStats.LeafOverflowN
+			stats.Buckets = append(stats.Buckets, &BucketStats{
+				Name:      string(name),
+				KeyCount:  bStats.KeyN,
+				InUse:     bStats.BranchInuse + bStats.LeafInuse + bStats.InlineBucketInuse,
+				Allocated: pages * stats.PageSize,
+			})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	err = backend.NewBolt(db).View(func(tx backend.Tx) error {
+		if bkt := tx.Bucket(blockSlotIndicesBucket); bkt != nil {
+			c := bkt.Cursor()
+			if first, _ := c.First(); first != nil {
+				last, _ := c.Last()
+				stats.HasBlocks = true
+				stats.EarliestBlockSlot = bytesutil.BytesToSlotBigEndian(first)
+				stats.LatestBlockSlot = bytesutil.BytesToSlotBigEndian(last)
+			}
+		}
+		if bkt := tx.Bucket(stateSlotIndicesBucket); bkt != nil {
+			c := bkt.Cursor()
+			if first, _ := c.First(); first != nil {
+				last, _ := c.Last()
+				stats.HasStates = true
+				stats.EarliestStateSlot = bytesutil.BytesToSlotBigEndian(first)
+				stats.LatestStateSlot = bytesutil.BytesToSlotBigEndian(last)
+			}
+		}
+		stats.SchemaVersion = schemaVersion(tx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,52 @@
+package kv
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewKVStore(ctx, t.TempDir(), &Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.RunMigrations(ctx))
+	for _, slot := range []types.Slot{3, 7} {
+		b := testutil.NewBeaconBlock()
+		b.Block.Slot = slot
+		require.NoError(t, db.SaveBlock(ctx, b))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		st, err := testutil.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot+1))
+		require.NoError(t, db.SaveState(ctx, st, root))
+	}
+	require.NoError(t, db.Close())
+
+	stats, err := Stats(path.Join(db.databasePath, DatabaseFileName))
+	require.NoError(t, err)
+	assert.Equal(t, true, stats.HasBlocks)
+	assert.Equal(t, types.Slot(3), stats.EarliestBlockSlot)
+	assert.Equal(t, types.Slot(7), stats.LatestBlockSlot)
+	assert.Equal(t, true, stats.HasStates)
+	assert.Equal(t, types.Slot(4), stats.EarliestStateSlot)
+	assert.Equal(t, types.Slot(8), stats.LatestStateSlot)
+	assert.Equal(t, LatestSchemaVersion(), stats.SchemaVersion)
+	assert.Equal(t, LatestSchemaVersion(), stats.LatestSchemaVersion)
+
+	var stateStats *BucketStats
+	for _, b := range stats.Buckets {
+		if b.Name == string(stateBucket) {
+			stateStats = b
+		}
+	}
+	require.NotNil(t, stateStats, "Missing state bucket")
+	assert.Equal(t, 2, stateStats.KeyCount)
+	assert.Equal(t, true, stateStats.InUse > 0 && stateStats.InUse <= stateStats.Allocated)
+}
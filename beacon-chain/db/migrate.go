@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/urfave/cli/v2"
+)
+
+func migrate(cliCtx *cli.Context) error {
+	dbDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	dbFile := path.Join(dbDir, kv.DatabaseFileName)
+	if !fileutil.FileExists(dbFile) {
+		return errors.Errorf("no database found at %s", dbFile)
+	}
+	ctx := context.Background()
+	store, err := kv.NewKVStore(ctx, dbDir, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database, make sure the beacon node is stopped")
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+	return store.MigrateSchema(ctx, cliCtx.Bool(cmd.MigrationDryRunFlag.Name))
+}
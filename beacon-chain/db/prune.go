@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// compactTxSize is the number of bytes copied in a single transaction when compacting the database.
+const compactTxSize = 64 * 1024 * 1024
+
+func prune(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	slotsPerArchivedPoint := cliCtx.Int(flags.SlotsPerArchivedPoint.Name)
+	if slotsPerArchivedPoint <= 0 {
+		return errors.Errorf("%s must be greater than 0", flags.SlotsPerArchivedPoint.Name)
+	}
+
+	dbDir := path.Join(dataDir, kv.BeaconNodeDbDirName)
+	dbFile := path.Join(dbDir, kv.DatabaseFileName)
+	if !fileutil.FileExists(dbFile) {
+		return errors.Errorf("no database found at %s", dbFile)
+	}
+	ctx := context.Background()
+	// The database file is locked by a running beacon node, so opening it fails in that case.
+	store, err := kv.NewKVStore(ctx, dbDir, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database, make sure the beacon node is stopped")
+	}
+	stats, err := store.Prune(ctx, types.Slot(slotsPerArchivedPoint))
+	if closeErr := store.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Failed to close database")
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not prune database")
+	}
+
+	log.Info("Compacting database, this may take a while")
+	sizeBefore, sizeAfter, err := compactDB(dbFile)
+	if err != nil {
+		return errors.Wrap(err, "could not compact database")
+	}
+	log.WithFields(logrus.Fields{
+		"deletedStates":  stats.DeletedStates,
+		"deletedBlocks":  stats.DeletedBlocks,
+		"sizeBefore":     sizeBefore,
+		"sizeAfter":      sizeAfter,
+		"reclaimedBytes": sizeBefore - sizeAfter,
+	}).Info("Prune completed successfully")
+	return nil
+}
+
+// compactDB rewrites the database file without the pages freed by deletions, which bolt never
+// returns to the file system, and returns the sizes of the file before and after compaction.
+func compactDB(dbFile string) (int64, int64, error) {
+	info, err := os.Stat(dbFile)
+	if err != nil {
+		return 0, 0, err
+	}
+	sizeBefore := info.Size()
+
+	src, err := bolt.Open(dbFile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  params.BeaconIoConfig().BoltTimeout,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+	// The database is rewritten to a temporary file, so that an interrupted compaction leaves the
+	// database untouched.
+	tmpFile := dbFile + ".compact"
+	dst, err := bolt.Open(tmpFile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout: params.BeaconIoConfig().BoltTimeout,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := copyDB(dst, src); err != nil {
+		if closeErr := dst.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Failed to close compacted database")
+		}
+		if rmErr := os.Remove(tmpFile); rmErr != nil {
+			log.WithError(rmErr).Error("Failed to remove compacted database")
+		}
+		return 0, 0, err
+	}
+	if err := dst.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err := os.Rename(tmpFile, dbFile); err != nil {
+		return 0, 0, err
+	}
+
+	info, err = os.Stat(dbFile)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sizeBefore, info.Size(), nil
+}
+
+// copyDB copies all the buckets of src to dst, committing the writes in batches of compactTxSize
+// bytes to bound the memory used by a transaction.
+func copyDB(dst, src *bolt.DB) error {
+	dstTx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Rolling back a committed transaction is a no-op.
+		_ = dstTx.Rollback()
+	}()
+	var txSize int
+
+	// put writes a key to the bucket at the given path in the current transaction, or creates a
+	// nested bucket if the value is nil.
+	put := func(bucketPath [][]byte, k, v []byte) error {
+		if txSize+len(k)+len(v) > compactTxSize {
+			if err := dstTx.Commit(); err != nil {
+				return err
+			}
+			if dstTx, err = dst.Begin(true); err != nil {
+				return err
+			}
+			txSize = 0
+		}
+		txSize += len(k) + len(v)
+
+		if len(bucketPath) == 0 {
+			_, err := dstTx.CreateBucketIfNotExists(k)
+			return err
+		}
+		bkt := dstTx.Bucket(bucketPath[0])
+		for _, name := range bucketPath[1:] {
+			bkt = bkt.Bucket(name)
+		}
+		if v == nil {
+			_, err := bkt.CreateBucketIfNotExists(k)
+			return err
+		}
+		return bkt.Put(k, v)
+	}
+
+	var walk func(bucketPath [][]byte, b *bolt.Bucket) error
+	walk = func(bucketPath [][]byte, b *bolt.Bucket) error {
+		return b.ForEach(func(k, v []byte) error {
+			if err := put(bucketPath, k, v); err != nil {
+				return err
+			}
+			if v == nil {
+				return walk(append(bucketPath[:len(bucketPath):len(bucketPath)], k), b.Bucket(k))
+			}
+			return nil
+		})
+	}
+
+	if err := src.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if err := put(nil, name, nil); err != nil {
+				return err
+			}
+			return walk([][]byte{name}, b)
+		})
+	}); err != nil {
+		return err
+	}
+	return dstTx.Commit()
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestCompactDB(t *testing.T) {
+	ctx := context.Background()
+	dbDir := t.TempDir()
+	store, err := kv.NewKVStore(ctx, dbDir, &kv.Config{})
+	require.NoError(t, err)
+
+	st, _ := testutil.DeterministicGenesisState(t, 1024)
+	roots := make([][32]byte, 0)
+	for i := byte(0); i < 8; i++ {
+		b := testutil.NewBeaconBlock()
+		b.Block.ProposerIndex = types.ValidatorIndex(i)
+		require.NoError(t, store.SaveBlock(ctx, b))
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, store.SaveState(ctx, st, root))
+		roots = append(roots, root)
+	}
+	require.NoError(t, store.DeleteStates(ctx, roots[1:]))
+	require.NoError(t, store.Close())
+
+	sizeBefore, sizeAfter, err := compactDB(path.Join(dbDir, kv.DatabaseFileName))
+	require.NoError(t, err)
+	assert.Equal(t, true, sizeAfter < sizeBefore, "Database was not compacted, size before %d, after %d", sizeBefore, sizeAfter)
+
+	store, err = kv.NewKVStore(ctx, dbDir, &kv.Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	assert.Equal(t, true, store.HasState(ctx, roots[0]))
+	assert.Equal(t, false, store.HasState(ctx, roots[1]))
+	for _, root := range roots {
+		assert.Equal(t, true, store.HasBlock(ctx, root))
+	}
+}
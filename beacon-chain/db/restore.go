@@ -9,8 +9,10 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
 )
 
 const dbExistsYesNoPrompt = "A database file already exists in the target directory. " +
@@ -33,13 +35,51 @@ func restore(cliCtx *cli.Context) error {
 			return nil
 		}
 	}
+	if err := verifyBackup(sourceFile); err != nil {
+		return errors.Wrap(err, "could not verify backup file")
+	}
 	if err := fileutil.MkdirAll(restoreDir); err != nil {
 		return err
 	}
-	if err := fileutil.CopyFile(sourceFile, path.Join(restoreDir, kv.DatabaseFileName)); err != nil {
+	// The backup is copied to a temporary file first, so that an interrupted restore does not
+	// leave a partially written database behind.
+	tmpFile := path.Join(restoreDir, kv.DatabaseFileName+".tmp")
+	if err := fileutil.CopyFile(sourceFile, tmpFile); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, path.Join(restoreDir, kv.DatabaseFileName)); err != nil {
 		return err
 	}
 
 	log.Info("Restore completed successfully")
 	return nil
 }
+
+// verifyBackup checks that the backup file is a readable bolt database.
+func verifyBackup(backupFile string) error {
+	if !fileutil.FileExists(backupFile) {
+		return errors.Errorf("backup file %s does not exist", backupFile)
+	}
+	backupDB, err := bolt.Open(backupFile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  params.BeaconIoConfig().BoltTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := backupDB.Close(); err != nil {
+			log.WithError(err).Error("Failed to close backup database")
+		}
+	}()
+	return backupDB.View(func(tx *bolt.Tx) error {
+		// The channel is drained for the check to complete before the transaction is closed.
+		var checkErr error
+		for err := range tx.Check() {
+			if checkErr == nil {
+				checkErr = errors.Wrap(err, "backup database is corrupted")
+			}
+		}
+		return checkErr
+	})
+}
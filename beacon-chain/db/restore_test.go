@@ -70,3 +70,21 @@ func TestRestore(t *testing.T) {
 	assert.LogsContain(t, logHook, "Restore completed successfully")
 
 }
+
+func TestRestore_InvalidBackup(t *testing.T) {
+	backupFile := path.Join(t.TempDir(), "backup.db")
+	require.NoError(t, ioutil.WriteFile(backupFile, []byte("not a database"), 0600))
+
+	restoreDir := t.TempDir()
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.RestoreSourceFileFlag.Name, "", "")
+	set.String(cmd.RestoreTargetDirFlag.Name, "", "")
+	require.NoError(t, set.Set(cmd.RestoreSourceFileFlag.Name, backupFile))
+	require.NoError(t, set.Set(cmd.RestoreTargetDirFlag.Name, restoreDir))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	assert.ErrorContains(t, "could not verify backup file", restore(cliCtx))
+	_, err := os.Stat(path.Join(restoreDir, kv.BeaconNodeDbDirName))
+	assert.Equal(t, true, os.IsNotExist(err), "Restore directory was created")
+}
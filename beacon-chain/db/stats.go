@@ -0,0 +1,54 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/urfave/cli/v2"
+)
+
+func stats(cliCtx *cli.Context) error {
+	dbFile := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName, kv.DatabaseFileName)
+	if !fileutil.FileExists(dbFile) {
+		return errors.Errorf("no database found at %s", dbFile)
+	}
+	dbStats, err := kv.Stats(dbFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read database, make sure the beacon node is stopped")
+	}
+	return printStats(os.Stdout, dbFile, dbStats)
+}
+
+// printStats writes the statistics of a database in a human readable format.
+func printStats(out io.Writer, dbFile string, dbStats *kv.DatabaseStats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Database:\t%s\n", dbFile)
+	fmt.Fprintf(w, "File size:\t%d bytes\n", dbStats.FileSize)
+	fmt.Fprintf(w, "Page size:\t%d bytes\n", dbStats.PageSize)
+	fmt.Fprintf(w, "Free pages:\t%d (%d pending)\n", dbStats.FreePages, dbStats.PendingPages)
+	fmt.Fprintf(w, "Freelist size:\t%d bytes\n", dbStats.FreelistSize)
+	if dbStats.HasBlocks {
+		fmt.Fprintf(w, "Block slots:\t%d - %d\n", dbStats.EarliestBlockSlot, dbStats.LatestBlockSlot)
+	} else {
+		fmt.Fprintf(w, "Block slots:\tnone\n")
+	}
+	if dbStats.HasStates {
+		fmt.Fprintf(w, "State slots:\t%d - %d\n", dbStats.EarliestStateSlot, dbStats.LatestStateSlot)
+	} else {
+		fmt.Fprintf(w, "State slots:\tnone\n")
+	}
+	fmt.Fprintf(w, "Schema version:\t%d (latest %d)\n", dbStats.SchemaVersion, dbStats.LatestSchemaVersion)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Bucket\tKeys\tIn use (bytes)\tAllocated (bytes)\n")
+	for _, b := range dbStats.Buckets {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", b.Name, b.KeyCount, b.InUse, b.Allocated)
+	}
+	return w.Flush()
+}
@@ -3,19 +3,23 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	// HTTPWeb3ProviderFlag provides an HTTP access endpoint to an ETH 1.0 RPC.
+	// HTTPWeb3ProviderFlag provides an HTTP or websocket access endpoint to an ETH 1.0 RPC.
 	HTTPWeb3ProviderFlag = &cli.StringFlag{
 		Name:  "http-web3provider",
-		Usage: "A mainchain web3 provider string http endpoint. This is our primary web3 provider",
+		Usage: "A mainchain web3 provider string http or websocket endpoint. This is our primary web3 provider",
 	}
+	// FallbackWeb3ProviderFlag provides endpoints to fail over to when the primary web3 provider is unhealthy.
 	FallbackWeb3ProviderFlag = &cli.StringSliceFlag{
-		Name:  "fallback-web3provider",
-		Usage: "A mainchain web3 provider string http endpoint. This is our fallback web3 provider, this flag maybe used multiple times.",
+		Name: "fallback-web3provider",
+		Usage: "A mainchain web3 provider string http or websocket endpoint. This is our fallback web3 provider, " +
+			"used in order whenever the active provider is unreachable, syncing or on the wrong chain. This flag may be used multiple times.",
 	}
 	// DepositContractFlag defines a flag for the deposit contract address.
 	DepositContractFlag = &cli.StringFlag{
@@ -51,6 +55,13 @@ var (
 		Name:  "tls-key",
 		Usage: "Key for secure gRPC. Pass this and the tls-cert flag in order to use gRPC securely.",
 	}
+	// ClientCAFlag defines a flag for the certificate authority of the validator clients' TLS certificates.
+	ClientCAFlag = &cli.StringFlag{
+		Name: "tls-client-ca",
+		Usage: "Certificate authority of the client certificates for mutual TLS. When set with the tls-cert and " +
+			"tls-key flags, gRPC clients must present a certificate signed by this authority. The certificates are " +
+			"reloaded when their files change.",
+	}
 	// DisableGRPCGateway for JSON-HTTP requests to the beacon node.
 	DisableGRPCGateway = &cli.BoolFlag{
 		Name:  "disable-grpc-gateway",
@@ -75,6 +86,55 @@ var (
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 		Value: "http://localhost:4200,http://localhost:7500,http://127.0.0.1:4200,http://127.0.0.1:7500,http://0.0.0.0:4200,http://0.0.0.0:7500",
 	}
+	// GRPCGatewayMaxBodySize defines the maximum size of the request bodies accepted by the gRPC gateway.
+	GRPCGatewayMaxBodySize = &cli.Int64Flag{
+		Name: "grpc-gateway-max-body-size",
+		Usage: "The maximum size in bytes of the request bodies accepted by the gRPC gateway, which bounds " +
+			"SSZ encoded block and state uploads. 0 disables the limit.",
+		Value: 32 << 20,
+	}
+	// GRPCGatewayRequestTimeout defines the time after which the gRPC gateway cancels a request.
+	GRPCGatewayRequestTimeout = &cli.DurationFlag{
+		Name:  "grpc-gateway-request-timeout",
+		Usage: "The time after which the gRPC gateway cancels a request, except for event streams. 0 disables the timeout.",
+	}
+	// GRPCGatewayClientCertFlag defines a flag for the certificate the gRPC gateway presents to the node.
+	GRPCGatewayClientCertFlag = &cli.StringFlag{
+		Name: "grpc-gateway-tls-client-cert",
+		Usage: "Client certificate the gRPC gateway presents to the node's gRPC server. Required with the " +
+			"grpc-gateway-tls-client-key flag when the tls-client-ca flag is set, signed by that authority.",
+	}
+	// GRPCGatewayClientKeyFlag defines a flag for the key of the gRPC gateway's client certificate.
+	GRPCGatewayClientKeyFlag = &cli.StringFlag{
+		Name:  "grpc-gateway-tls-client-key",
+		Usage: "Key of the client certificate the gRPC gateway presents to the node's gRPC server.",
+	}
+	// GRPCGatewayAuthTokenFile enables bearer token authentication of the gRPC gateway with static tokens.
+	GRPCGatewayAuthTokenFile = &cli.StringFlag{
+		Name: "grpc-gateway-auth-token-file",
+		Usage: "File listing the API tokens accepted by the gRPC gateway, one per line. When set, requests must " +
+			"carry one of the tokens in an 'Authorization: Bearer {token}' header.",
+	}
+	// GRPCGatewayJWTSecretFile enables bearer token authentication of the gRPC gateway with JWTs.
+	GRPCGatewayJWTSecretFile = &cli.StringFlag{
+		Name: "grpc-gateway-jwt-secret-file",
+		Usage: "File containing the hex encoded HMAC secret of the JWTs accepted by the gRPC gateway. When set, " +
+			"requests must carry a JWT signed with this secret in an 'Authorization: Bearer {token}' header.",
+	}
+	// GRPCGatewayAuthExemptPaths lists the gRPC gateway paths served without authentication.
+	GRPCGatewayAuthExemptPaths = &cli.StringSliceFlag{
+		Name: "grpc-gateway-auth-exempt",
+		Usage: "Paths of the gRPC gateway served without authentication, such as health checks. A path ending " +
+			"with a slash exempts all the paths under it. This flag may be used multiple times.",
+		Value: cli.NewStringSlice("/eth/v1/node/health", "/eth/v1alpha1/node/syncing"),
+	}
+	// EnableAdminEndpoints serves the admin endpoints of the gRPC gateway, such as runtime feature flag toggles.
+	EnableAdminEndpoints = &cli.BoolFlag{
+		Name: "enable-admin-endpoints",
+		Usage: "Serves the admin endpoints of the gRPC gateway, which change feature flags such as " +
+			"--disable-broadcast-slashings and the log levels of modules at runtime, and revalidate the operation pools. Requires gRPC gateway authentication, which applies to " +
+			"these endpoints even under exempt paths.",
+	}
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -102,10 +162,18 @@ var (
 	// SlotsPerArchivedPoint specifies the number of slots between the archived points, to save beacon state in the cold
 	// section of DB.
 	SlotsPerArchivedPoint = &cli.IntFlag{
-		Name:  "slots-per-archive-point",
-		Usage: "The slot durations of when an archived state gets saved in the DB.",
+		Name: "slots-per-archive-point",
+		Usage: "The slot durations of when an archived state gets saved in the DB. Lower values use more disk space " +
+			"for faster historical state queries. On restart, archived states are pruned or regenerated to match this value.",
 		Value: 2048,
 	}
+	// DatabaseBackend specifies the storage engine of the beacon node database.
+	DatabaseBackend = &cli.StringFlag{
+		Name: "db-backend",
+		Usage: "The storage engine of the beacon node database, one of bolt or leveldb. Bolt serializes all writes, " +
+			"leveldb does not block reads on writes. An existing database is converted with the db convert command.",
+		Value: "bolt",
+	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
@@ -143,6 +211,38 @@ var (
 		Name:  "historical-slasher-node",
 		Usage: "Enables required flags for serving historical data to a slasher client. Results in additional storage usage",
 	}
+	// SlasherFlag runs slashing detection inside the beacon node.
+	SlasherFlag = &cli.BoolFlag{
+		Name: "slasher",
+		Usage: "Runs slashing detection on the blocks and attestations received by the beacon node, and includes " +
+			"the slashings found in proposed blocks. Uses a slasher database in the data directory, instead of a " +
+			"separate slasher process",
+	}
+	// MonitorIndicesFlag tracks the duties of validators in the beacon node.
+	MonitorIndicesFlag = &cli.IntSliceFlag{
+		Name: "monitor-indices",
+		Usage: "Validator indices to monitor. The beacon node logs and exports as metrics the proposals, " +
+			"attestation inclusions and misses, and balance changes of these validators. This flag may be used " +
+			"multiple times",
+	}
+	// ArchiveAttestationInclusionsFlag archives the attestation inclusions of processed blocks.
+	ArchiveAttestationInclusionsFlag = &cli.BoolFlag{
+		Name: "archive-attestation-inclusions",
+		Usage: "Archives the inclusions of the attestations in processed blocks, so that attestation inclusion " +
+			"lookups are answered without searching blocks. Results in additional storage usage",
+	}
+	// GossipSlashingDetectionFlag checks the attestations received over gossip for slashable offences.
+	GossipSlashingDetectionFlag = &cli.BoolFlag{
+		Name: "gossip-slashing-detection",
+		Usage: "Checks the attestations received over gossip for double and surround votes against an in-memory " +
+			"span cache, and immediately submits and broadcasts the attester slashings found",
+	}
+	// GossipSlashingDetectionEpochsFlag sets the number of recent epochs covered by gossip slashing detection.
+	GossipSlashingDetectionEpochsFlag = &cli.Uint64Flag{
+		Name:  "gossip-slashing-detection-epochs",
+		Usage: "The number of recent epochs for which attestations are kept for gossip slashing detection",
+		Value: 32,
+	}
 	// ChainID defines a flag to set the chain id. If none is set, it derives this value from NetworkConfig
 	ChainID = &cli.Uint64Flag{
 		Name:  "chain-id",
@@ -166,4 +266,113 @@ var (
 		Usage: "Sets the maximum number of headers that a deposit log query can fetch.",
 		Value: uint64(1000),
 	}
+	// SlashingWebhookURL specifies an HTTP endpoint to notify when a slashing is inserted into the operations pool.
+	SlashingWebhookURL = &cli.StringFlag{
+		Name:  "slashing-webhook-url",
+		Usage: "Optional URL to which a JSON summary of every slashing inserted into the operations pool is POSTed",
+	}
+	// RPCMaxBatchSize defines the maximum number of items accepted by a single bulk submission RPC.
+	RPCMaxBatchSize = &cli.Uint64Flag{
+		Name:  "rpc-max-batch-size",
+		Usage: "The maximum number of objects accepted in a single bulk submission request",
+		Value: 256,
+	}
+	// RPCRateLimit defines the sustained number of requests per second per client served by the gRPC server and the gateway.
+	RPCRateLimit = &cli.Float64Flag{
+		Name: "rpc-rate-limit",
+		Usage: "The number of requests per second of a client host served by the gRPC server and the gRPC gateway, each, " +
+			"beyond which requests are rejected. 0 disables the limit.",
+	}
+	// RPCRateLimitBurst defines the number of requests served at once above the rate limit.
+	RPCRateLimitBurst = &cli.IntFlag{
+		Name:  "rpc-rate-limit-burst",
+		Usage: "The number of requests allowed at once by the rate limit. Defaults to the rate limit.",
+	}
+	// RPCMaxConcurrentRequests defines the number of requests per client served at the same time by the gRPC server and the gateway.
+	RPCMaxConcurrentRequests = &cli.IntFlag{
+		Name: "rpc-max-concurrent-requests",
+		Usage: "The number of requests of a client host served at the same time by the gRPC server and the gRPC gateway, each, " +
+			"beyond which requests are rejected. Streams are not counted. 0 disables the limit.",
+	}
+	// RPCRateLimitOverrides defines limits for the requests to given gRPC methods or gateway paths.
+	RPCRateLimitOverrides = &cli.StringSliceFlag{
+		Name: "rpc-rate-limit-override",
+		Usage: "Limits of the requests to the gRPC methods or gateway paths starting with a prefix, as " +
+			"prefix=rate,burst,max-concurrent, for example /ethereum.eth.v1alpha1.BeaconChain/GetBeaconState=1,2,1. " +
+			"Requests matching an override are limited separately from the others. This flag may be used multiple times.",
+	}
+	// ExitInclusionOrdering defines the priority used to pick pending voluntary exits for inclusion in proposed blocks.
+	ExitInclusionOrdering = &cli.StringFlag{
+		Name:  "exit-inclusion-ordering",
+		Usage: "The order in which pending voluntary exits are packed into proposed blocks: lowest-index or earliest-submitted",
+		Value: "lowest-index",
+	}
+	// MaxPoolAggregatedAttestations defines the capacity of the aggregated attestation pool.
+	MaxPoolAggregatedAttestations = &cli.IntFlag{
+		Name:  "max-pool-aggregated-attestations",
+		Usage: "The maximum number of distinct attestation data held in the aggregated attestation pool, the oldest being evicted first (0 for no limit)",
+		Value: 16384,
+	}
+	// MaxPoolUnaggregatedAttestations defines the capacity of the unaggregated attestation pool.
+	MaxPoolUnaggregatedAttestations = &cli.IntFlag{
+		Name:  "max-pool-unaggregated-attestations",
+		Usage: "The maximum number of attestations held in the unaggregated attestation pool, the oldest being evicted first (0 for no limit)",
+		Value: 262144,
+	}
+	// MaxPoolSlashings defines the capacity of the attester and proposer slashing pools.
+	MaxPoolSlashings = &cli.IntFlag{
+		Name:  "max-pool-slashings",
+		Usage: "The maximum number of pending attester slashings, and of pending proposer slashings, held in the slashing pool, the highest validator index being evicted first (0 for no limit)",
+		Value: 4096,
+	}
+	// MaxPoolVoluntaryExits defines the capacity of the voluntary exit pool.
+	MaxPoolVoluntaryExits = &cli.IntFlag{
+		Name:  "max-pool-voluntary-exits",
+		Usage: "The maximum number of pending voluntary exits held in the exit pool, the exit packed last under --exit-inclusion-ordering being evicted first (0 for no limit)",
+		Value: 16384,
+	}
+	// SlashingReplayCacheSize defines the number of recently broadcast slashing roots remembered to suppress re-broadcasts.
+	SlashingReplayCacheSize = &cli.IntFlag{
+		Name:  "slashing-replay-cache-size",
+		Usage: "The number of recently broadcast slashings remembered so that resubmissions are not broadcast again",
+		Value: 1024,
+	}
+	// SlashingReplayCacheTTL defines how long a broadcast slashing is remembered for re-broadcast suppression.
+	SlashingReplayCacheTTL = &cli.DurationFlag{
+		Name:  "slashing-replay-cache-ttl",
+		Usage: "How long a broadcast slashing is remembered so that resubmissions are not broadcast again",
+		Value: 10 * time.Minute,
+	}
+	// CommitteeCacheSize defines the number of epoch shufflings held in the committee cache.
+	CommitteeCacheSize = &cli.IntFlag{
+		Name:  "committee-cache-size",
+		Usage: "The number of shuffled committees, one per epoch and seed, held in the committee cache, the least recently used being evicted first",
+		Value: 32,
+	}
+	// VerifyExitsAgainstPool rejects submitted voluntary exits that exceed the churn limit together with the exits already pooled.
+	VerifyExitsAgainstPool = &cli.BoolFlag{
+		Name:  "verify-exits-against-pool",
+		Usage: "Reject submitted voluntary exits that, together with the exits already in the pool, exceed the validator churn limit",
+	}
+	// CheckpointSyncURL defines the beacon API of a trusted beacon node a fresh node fetches its finalized starting point from.
+	CheckpointSyncURL = &cli.StringFlag{
+		Name: "checkpoint-sync-url",
+		Usage: "URL of the beacon API of a trusted beacon node to fetch the latest finalized state and block from. " +
+			"A node with an empty database starts syncing from this checkpoint instead of from genesis",
+	}
+	// CheckpointGenesisState defines the SSZ file of the genesis state used to start from a checkpoint.
+	CheckpointGenesisState = &cli.StringFlag{
+		Name:  "checkpoint-genesis-state",
+		Usage: "The genesis state file (.SSZ) of the chain, used with --checkpoint-state and --checkpoint-block",
+	}
+	// CheckpointState defines the SSZ file of the finalized state a fresh node starts from.
+	CheckpointState = &cli.StringFlag{
+		Name:  "checkpoint-state",
+		Usage: "The finalized state file (.SSZ) to start syncing from, the post state of --checkpoint-block",
+	}
+	// CheckpointBlock defines the SSZ file of the finalized signed block a fresh node starts from.
+	CheckpointBlock = &cli.StringFlag{
+		Name:  "checkpoint-block",
+		Usage: "The finalized signed block file (.SSZ) to start syncing from",
+	}
 )
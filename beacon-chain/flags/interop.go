@@ -27,4 +27,12 @@ var (
 		Name:  "interop-num-validators",
 		Usage: "Specify number of genesis validators to generate for interop. Must be used with --interop-genesis-time",
 	}
+	// DevModeFlag runs a single node local chain for development.
+	DevModeFlag = &cli.BoolFlag{
+		Name: "dev",
+		Usage: "Run a single node local chain for development, which uses the minimal config, disables discovery, " +
+			"mocks eth1 and starts from an interop genesis state of 64 validators right away. Unless --datadir is set, " +
+			"the chain is stored in a temporary directory. Run a validator with --minimal-config " +
+			"--interop-num-validators=64 to produce blocks",
+	}
 )
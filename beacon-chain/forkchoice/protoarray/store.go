@@ -230,6 +230,27 @@ func (s *Store) NodesIndices() map[[32]byte]uint64 {
 	return s.nodesIndices
 }
 
+// Leaves returns copies of the nodes of fork choice store without children, which are the heads of
+// all the chains the store knows of.
+func (s *Store) Leaves() []*Node {
+	s.nodesLock.RLock()
+	defer s.nodesLock.RUnlock()
+
+	hasChild := make([]bool, len(s.nodes))
+	for _, n := range s.nodes {
+		if n.parent != NonExistentNode && n.parent < uint64(len(s.nodes)) {
+			hasChild[n.parent] = true
+		}
+	}
+	leaves := make([]*Node, 0)
+	for i, n := range s.nodes {
+		if !hasChild[i] {
+			leaves = append(leaves, copyNode(n))
+		}
+	}
+	return leaves
+}
+
 // head starts from justified root and then follows the best descendant links
 // to find the best block for head.
 func (s *Store) head(ctx context.Context, justifiedRoot [32]byte) ([32]byte, error) {
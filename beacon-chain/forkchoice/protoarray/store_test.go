@@ -61,6 +61,22 @@ func TestStore_NodesIndices(t *testing.T) {
 	require.DeepEqual(t, nodeIndices, s.NodesIndices())
 }
 
+func TestStore_Leaves(t *testing.T) {
+	s := &Store{
+		nodes: []*Node{
+			{slot: 100, root: [32]byte{'a'}, parent: NonExistentNode},
+			{slot: 101, root: [32]byte{'b'}, parent: 0},
+			{slot: 102, root: [32]byte{'c'}, parent: 1},
+			{slot: 102, root: [32]byte{'d'}, parent: 0},
+		},
+	}
+	leaves := s.Leaves()
+	require.Equal(t, 2, len(leaves))
+	assert.Equal(t, [32]byte{'c'}, leaves[0].Root())
+	assert.Equal(t, types.Slot(102), leaves[0].Slot())
+	assert.Equal(t, [32]byte{'d'}, leaves[1].Root())
+}
+
 func TestForkChoice_HasNode(t *testing.T) {
 	nodeIndices := map[[32]byte]uint64{
 		{'a'}: 1,
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconv1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+// FeatureTogglesPath is the path of the admin endpoint changing feature flags at runtime.
+const FeatureTogglesPath = "/prysm/admin/v1/features"
+
+// LoggingPath is the path of the admin endpoint changing the log levels of modules at runtime.
+const LoggingPath = "/prysm/admin/v1/logging"
+
+// PoolsRevalidationPath is the path of the admin endpoint dropping the pooled operations which are
+// no longer valid against the head state.
+const PoolsRevalidationPath = "/prysm/admin/v1/pools/revalidate"
+
+// PoolsRevalidator revalidates the operation pools of the node.
+type PoolsRevalidator interface {
+	RevalidatePools(ctx context.Context, _ *ptypes.Empty) (*beaconv1.RevalidatePoolsResponse, error)
+}
+
+// maxToggleRequestSize bounds the size of a feature toggle request.
+const maxToggleRequestSize = 1024
+
+type toggleChangeJSON struct {
+	Flag     string `json:"flag"`
+	Enabled  bool   `json:"enabled"`
+	Previous bool   `json:"previous"`
+	Source   string `json:"source"`
+	Time     string `json:"time"`
+}
+
+type featureTogglesJSON struct {
+	Toggles map[string]bool     `json:"toggles"`
+	Changes []*toggleChangeJSON `json:"changes"`
+}
+
+type toggleRequestJSON struct {
+	Flag    string `json:"flag"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// FeatureTogglesHandler serves the feature flags which can be changed at runtime, along with the
+// audit history of their changes, on GET requests. It enables or disables a flag on POST requests
+// with a body such as {"flag": "disable-broadcast-slashings", "enabled": true}. It must only be
+// served behind authentication.
+func FeatureTogglesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req toggleRequestJSON
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxToggleRequestSize)).Decode(&req); err != nil {
+			writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: fmt.Sprintf("Could not decode request: %v", err)})
+			return
+		}
+		if req.Enabled == nil {
+			writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: "Missing enabled field"})
+			return
+		}
+		if _, err := featureconfig.SetToggle(req.Flag, *req.Enabled, r.RemoteAddr); err != nil {
+			writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+	default:
+		writeSpecError(w, &specError{Code: http.StatusMethodNotAllowed, Message: "Method not allowed"})
+		return
+	}
+
+	changes := featureconfig.ToggleChanges()
+	resp := &featureTogglesJSON{
+		Toggles: featureconfig.Toggles(),
+		Changes: make([]*toggleChangeJSON, len(changes)),
+	}
+	for i, c := range changes {
+		resp.Changes[i] = &toggleChangeJSON{
+			Flag:     c.Flag,
+			Enabled:  c.Enabled,
+			Previous: c.Previous,
+			Source:   c.Source,
+			Time:     c.Time.UTC().Format(time.RFC3339),
+		}
+	}
+	enc, err := json.Marshal(resp)
+	if err != nil {
+		writeSpecError(w, &specError{Code: http.StatusInternalServerError, Message: fmt.Sprintf("Could not encode response: %v", err)})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(enc); err != nil {
+		log.WithError(err).Debug("Could not write feature toggles response")
+	}
+}
+
+// PoolsRevalidationHandler revalidates the operation pools on POST requests, responding with the
+// number of operations kept and dropped. As it mutates the pools, it must only be served behind
+// authentication.
+type PoolsRevalidationHandler struct {
+	Pools PoolsRevalidator
+}
+
+func (h *PoolsRevalidationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeSpecError(w, &specError{Code: http.StatusMethodNotAllowed, Message: "Method not allowed"})
+		return
+	}
+	resp, err := h.Pools.RevalidatePools(r.Context(), &ptypes.Empty{})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	enc, err := marshalSpecJSON(resp)
+	if err != nil {
+		writeSpecError(w, &specError{Code: http.StatusInternalServerError, Message: fmt.Sprintf("Could not encode response: %v", err)})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(enc); err != nil {
+		log.WithError(err).Debug("Could not write pools revalidation response")
+	}
+}
@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconv1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFeatureTogglesHandler(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{})
+	defer resetCfg()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, FeatureTogglesPath, strings.NewReader(body))
+		FeatureTogglesHandler(rec, req)
+		return rec
+	}
+	rec := post(`{"flag": "disable-broadcast-slashings", "enabled": true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, true, featureconfig.Get().DisableBroadcastSlashings)
+	resp := &featureTogglesJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	assert.Equal(t, true, resp.Toggles["disable-broadcast-slashings"])
+	require.NotEqual(t, 0, len(resp.Changes))
+	last := resp.Changes[len(resp.Changes)-1]
+	assert.Equal(t, "disable-broadcast-slashings", last.Flag)
+	assert.Equal(t, true, last.Enabled)
+	assert.Equal(t, false, last.Previous)
+	// The source is the address of the requester.
+	assert.Equal(t, "192.0.2.1:1234", last.Source)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"flag": "disable-broadcast-slashings"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"flag": "enable-blst", "enabled": true}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`not json`).Code)
+
+	rec = httptest.NewRecorder()
+	FeatureTogglesHandler(rec, httptest.NewRequest(http.MethodDelete, FeatureTogglesPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+type mockPoolsRevalidator struct {
+	resp *beaconv1.RevalidatePoolsResponse
+	err  error
+}
+
+func (m *mockPoolsRevalidator) RevalidatePools(_ context.Context, _ *ptypes.Empty) (*beaconv1.RevalidatePoolsResponse, error) {
+	return m.resp, m.err
+}
+
+func TestPoolsRevalidationHandler(t *testing.T) {
+	pools := &mockPoolsRevalidator{resp: &beaconv1.RevalidatePoolsResponse{AttesterSlashingsKept: 2, VoluntaryExitsDropped: 1}}
+	h := &PoolsRevalidationHandler{Pools: pools}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PoolsRevalidationPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"attester_slashings_kept":"2","attester_slashings_dropped":"0",`+
+		`"proposer_slashings_kept":"0","proposer_slashings_dropped":"0",`+
+		`"voluntary_exits_kept":"0","voluntary_exits_dropped":"1"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PoolsRevalidationPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	pools.err = status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PoolsRevalidationPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestPoolsRevalidationHandler_RequiresAuthentication(t *testing.T) {
+	a := &Authenticator{}
+	h := a.Require(&PoolsRevalidationHandler{Pools: &mockPoolsRevalidator{resp: &beaconv1.RevalidatePoolsResponse{}}})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PoolsRevalidationPath, nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
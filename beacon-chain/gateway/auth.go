@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+// minJWTSecretLength is the minimum length in bytes of the HMAC secret of JWT tokens.
+const minJWTSecretLength = 32
+
+// Authenticator requires HTTP requests to carry a bearer token in their Authorization header.
+// A token is accepted if it is one of the static tokens, or a JWT signed with the HMAC secret.
+type Authenticator struct {
+	tokens      [][]byte
+	jwtSecret   []byte
+	exemptPaths []string
+}
+
+// NewAuthenticator returns an authenticator accepting the tokens listed in tokenFile, one per
+// line, and the JWTs signed with the hex encoded HMAC secret in jwtSecretFile. Either file may be
+// empty, but not both. Requests to a path in exemptPaths, or under one ending with a slash, are
+// not authenticated.
+func NewAuthenticator(tokenFile, jwtSecretFile string, exemptPaths []string) (*Authenticator, error) {
+	if tokenFile == "" && jwtSecretFile == "" {
+		return nil, errors.New("a token file or a JWT secret file is required")
+	}
+	a := &Authenticator{exemptPaths: exemptPaths}
+	if tokenFile != "" {
+		content, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read token file")
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if token := strings.TrimSpace(line); token != "" {
+				a.tokens = append(a.tokens, []byte(token))
+			}
+		}
+		if len(a.tokens) == 0 {
+			return nil, errors.Errorf("no token found in %s", tokenFile)
+		}
+	}
+	if jwtSecretFile != "" {
+		content, err := ioutil.ReadFile(jwtSecretFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read JWT secret file")
+		}
+		secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(content)), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode JWT secret, expected a hex string")
+		}
+		if len(secret) < minJWTSecretLength {
+			return nil, errors.Errorf("JWT secret must be at least %d bytes, got %d", minJWTSecretLength, len(secret))
+		}
+		a.jwtSecret = secret
+	}
+	return a, nil
+}
+
+// Handler returns a handler authenticating requests before passing them to next.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	required := a.Require(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.exempt(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		required.ServeHTTP(w, r)
+	})
+}
+
+// Require returns a handler authenticating every request before passing it to next, regardless
+// of the exempt paths.
+func (a *Authenticator) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := a.authenticate(r); err != nil {
+			log.WithError(err).WithField("path", r.URL.Path).Debug("Rejected unauthenticated request")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeSpecError(w, &specError{Code: http.StatusUnauthorized, Message: err.Error()})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *Authenticator) exempt(path string) bool {
+	for _, p := range a.exemptPaths {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Authenticator) authenticate(r *http.Request) error {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return errors.New("missing authorization header")
+	}
+	const prefix = "Bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return errors.New("invalid authorization header, expected Bearer {token}")
+	}
+	token := []byte(strings.TrimSpace(header[len(prefix):]))
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(t, token) == 1 {
+			return nil
+		}
+	}
+	if a.jwtSecret != nil {
+		if _, err := jwt.Parse(string(token), a.validateJWT); err != nil {
+			return errors.Wrap(err, "invalid token")
+		}
+		return nil
+	}
+	return errors.New("invalid token")
+}
+
+func (a *Authenticator) validateJWT(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, errors.Errorf("unexpected JWT signing method: %v", token.Header["alg"])
+	}
+	return a.jwtSecret, nil
+}
@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/prysmaticlabs/prysm/shared/ratelimit"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "tokens")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("first-token\n\nsecond-token\n"), 0600))
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+	secretFile := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("0x"+hex.EncodeToString(secret)+"\n"), 0600))
+
+	auth, err := NewAuthenticator(tokenFile, secretFile, []string{"/eth/v1/node/health", "/public/"})
+	require.NoError(t, err)
+	srv := httptest.NewServer(auth.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	defer srv.Close()
+
+	sign := func(key []byte, expiry time.Duration) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(expiry).Unix(),
+		}).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		code   int
+	}{
+		{name: "no header", path: "/eth/v1/node/version", code: http.StatusUnauthorized},
+		{name: "not a bearer token", path: "/eth/v1/node/version", header: "Basic first-token", code: http.StatusUnauthorized},
+		{name: "static token", path: "/eth/v1/node/version", header: "Bearer first-token", code: http.StatusOK},
+		{name: "second static token", path: "/eth/v1/node/version", header: "bearer second-token", code: http.StatusOK},
+		{name: "unknown token", path: "/eth/v1/node/version", header: "Bearer third-token", code: http.StatusUnauthorized},
+		{name: "jwt", path: "/eth/v1/node/version", header: "Bearer " + sign(secret, time.Minute), code: http.StatusOK},
+		{name: "expired jwt", path: "/eth/v1/node/version", header: "Bearer " + sign(secret, -time.Minute), code: http.StatusUnauthorized},
+		{name: "jwt with another secret", path: "/eth/v1/node/version", header: "Bearer " + sign([]byte("another secret"), time.Minute), code: http.StatusUnauthorized},
+		{name: "exempt path", path: "/eth/v1/node/health", code: http.StatusOK},
+		{name: "exempt prefix", path: "/public/anything", code: http.StatusOK},
+		{name: "not exempt prefix", path: "/eth/v1/node/health/more", code: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
+
+func TestAuthenticator_Require(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("token"), 0600))
+	auth, err := NewAuthenticator(tokenFile, "", []string{"/public/"})
+	require.NoError(t, err)
+	handler := auth.Require(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Exempt paths are authenticated as well.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/public/admin", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/public/admin", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNewAuthenticator_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewAuthenticator("", "", nil)
+	assert.ErrorContains(t, "a token file or a JWT secret file is required", err)
+
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, ioutil.WriteFile(emptyFile, []byte("\n"), 0600))
+	_, err = NewAuthenticator(emptyFile, "", nil)
+	assert.ErrorContains(t, "no token found", err)
+
+	shortSecret := filepath.Join(dir, "short")
+	require.NoError(t, ioutil.WriteFile(shortSecret, []byte("abcd"), 0600))
+	_, err = NewAuthenticator("", shortSecret, nil)
+	assert.ErrorContains(t, "JWT secret must be at least 32 bytes", err)
+}
+
+func TestGateway_AuthenticatesBeforeRateLimit(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("token\n"), 0600))
+	auth, err := NewAuthenticator(tokenFile, "", nil)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	g := &Gateway{
+		mux:           mux,
+		authenticator: auth,
+		rateLimiter:   ratelimit.New("test", ratelimit.Limit{Rate: 0.001, Burst: 1}, nil),
+	}
+	srv := httptest.NewServer(g.handler())
+	defer srv.Close()
+
+	get := func(token string) int {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/eth/v1/node/version", nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+	// Unauthenticated requests do not consume the rate limit of the client.
+	assert.Equal(t, http.StatusUnauthorized, get(""))
+	assert.Equal(t, http.StatusUnauthorized, get("unknown"))
+	assert.Equal(t, http.StatusOK, get("token"))
+	assert.Equal(t, http.StatusTooManyRequests, get("token"))
+}
@@ -0,0 +1,155 @@
+package gateway
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} {
+		return gzip.NewWriter(nil)
+	}}
+	deflateWriters = sync.Pool{New: func() interface{} {
+		w, err := flate.NewWriter(nil, flate.DefaultCompression)
+		if err != nil {
+			panic(err) // The compression level is valid.
+		}
+		return w
+	}}
+)
+
+// newCompressionHandler compresses the JSON responses of next with gzip or deflate, as negotiated
+// by the Accept-Encoding header of the request. Streams are not compressed, so that their events
+// are delivered as soon as they are written.
+func newCompressionHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || isStreamPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressingWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding returns the preferred encoding of an Accept-Encoding header among gzip and
+// deflate, or an empty string if the response must not be compressed.
+func negotiateEncoding(header string) string {
+	var best string
+	var bestQ float64
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				v, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					v = 0
+				}
+				q = v
+			}
+		}
+		if coding == "*" {
+			coding = encodingGzip
+		}
+		if coding != encodingGzip && coding != encodingDeflate {
+			continue
+		}
+		// gzip is preferred over deflate at equal quality, as it is more widely supported.
+		if q > bestQ || (q == bestQ && q > 0 && coding == encodingGzip) {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressingWriter compresses the response body if it is JSON, which is decided from the content
+// type once the handler writes the response header.
+type compressingWriter struct {
+	http.ResponseWriter
+	encoding    string
+	wroteHeader bool
+	writer      io.WriteCloser
+}
+
+func (w *compressingWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	h := w.Header()
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err == nil && mediaType == "application/json" && h.Get("Content-Encoding") == "" &&
+		code != http.StatusNoContent && code != http.StatusNotModified {
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", w.encoding)
+		switch w.encoding {
+		case encodingGzip:
+			gw := gzipWriters.Get().(*gzip.Writer)
+			gw.Reset(w.ResponseWriter)
+			w.writer = gw
+		case encodingDeflate:
+			fw := deflateWriters.Get().(*flate.Writer)
+			fw.Reset(w.ResponseWriter)
+			w.writer = fw
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *compressingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.writer == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.writer.Write(b)
+}
+
+// Flush flushes the compressed data written so far to the client.
+func (w *compressingWriter) Flush() {
+	if f, ok := w.writer.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			log.WithError(err).Debug("Could not flush compressed response")
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close completes the compressed body and returns the compressor to its pool.
+func (w *compressingWriter) close() {
+	if w.writer == nil {
+		return
+	}
+	if err := w.writer.Close(); err != nil {
+		log.WithError(err).Debug("Could not complete compressed response")
+	}
+	switch cw := w.writer.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(cw)
+	case *flate.Writer:
+		deflateWriters.Put(cw)
+	}
+}
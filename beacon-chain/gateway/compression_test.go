@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                            "",
+		"identity":                    "",
+		"gzip":                        encodingGzip,
+		"deflate":                     encodingDeflate,
+		"deflate, gzip":               encodingGzip,
+		"gzip;q=0.5, deflate":         encodingDeflate,
+		"gzip;q=0, deflate;q=0":       "",
+		"br, GZIP;q=0.8":              encodingGzip,
+		"*":                           encodingGzip,
+		"gzip;q=invalid, deflate;q=0": "",
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, negotiateEncoding(header), "Accept-Encoding: %s", header)
+	}
+}
+
+func TestCompressionHandler(t *testing.T) {
+	payload := strings.Repeat(`{"index":"1","status":"active_ongoing"}`, 100)
+	srv := httptest.NewServer(newCompressionHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ssz" {
+			w.Header().Set("Content-Type", sszMediaType)
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		_, err := w.Write([]byte(payload))
+		require.NoError(t, err)
+	})))
+	defer srv.Close()
+
+	get := func(path, acceptEncoding string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		// Setting the header keeps the client from decompressing the response.
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		var body io.Reader = resp.Body
+		switch resp.Header.Get("Content-Encoding") {
+		case encodingGzip:
+			body, err = gzip.NewReader(resp.Body)
+			require.NoError(t, err)
+		case encodingDeflate:
+			body = flate.NewReader(resp.Body)
+		}
+		content, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		return resp, string(content)
+	}
+
+	for _, encoding := range []string{encodingGzip, encodingDeflate} {
+		resp, body := get("/json", encoding)
+		assert.Equal(t, encoding, resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", resp.Header.Get("Vary"))
+		assert.Equal(t, payload, body)
+	}
+	resp, body := get("/json", "identity")
+	assert.Equal(t, "", resp.Header.Get("Content-Encoding"))
+	assert.Equal(t, payload, body)
+	// Only JSON responses and non streaming paths are compressed.
+	resp, _ = get("/ssz", encodingGzip)
+	assert.Equal(t, "", resp.Header.Get("Content-Encoding"))
+	resp, _ = get(EventsPath, encodingGzip)
+	assert.Equal(t, "", resp.Header.Get("Content-Encoding"))
+}
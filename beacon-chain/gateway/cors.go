@@ -2,16 +2,23 @@ package gateway
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/rs/cors"
 )
 
 func newCorsHandler(srv http.Handler, allowedOrigins []string) http.Handler {
-	if len(allowedOrigins) == 0 {
+	origins := make([]string, 0, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		if o = strings.TrimSpace(o); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	if len(origins) == 0 {
 		return srv
 	}
 	c := cors.New(cors.Options{
-		AllowedOrigins: allowedOrigins,
+		AllowedOrigins: origins,
 		AllowedMethods: []string{http.MethodPost, http.MethodGet},
 		MaxAge:         600,
 		AllowedHeaders: []string{"*"},
@@ -0,0 +1,423 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+// EventsPath is the path of the server-sent events endpoint of the standard API.
+const EventsPath = "/eth/v1/events"
+
+const (
+	// eventQueueSize is the number of events a client may fall behind before being disconnected.
+	eventQueueSize = 256
+	// eventWriteTimeout is the time a client may take to receive the events of a single write.
+	eventWriteTimeout = 10 * time.Second
+)
+
+// Topics of the server-sent events stream.
+const (
+	headTopic                = "head"
+	blockTopic               = "block"
+	attestationTopic         = "attestation"
+	voluntaryExitTopic       = "voluntary_exit"
+	finalizedCheckpointTopic = "finalized_checkpoint"
+	chainReorgTopic          = "chain_reorg"
+)
+
+var eventTopics = map[string]bool{
+	headTopic:                true,
+	blockTopic:               true,
+	attestationTopic:         true,
+	voluntaryExitTopic:       true,
+	finalizedCheckpointTopic: true,
+	chainReorgTopic:          true,
+}
+
+// HeadRootFetcher returns the root of the current head block.
+type HeadRootFetcher interface {
+	HeadRoot(ctx context.Context) ([]byte, error)
+}
+
+// FinalizedCheckpointFetcher returns the latest finalized checkpoint.
+type FinalizedCheckpointFetcher interface {
+	FinalizedCheckpt() *ethpb.Checkpoint
+}
+
+// EventsHandler serves the standard API server-sent events stream, translating events of the
+// beacon node's state and operation feeds into the topics requested by the client.
+type EventsHandler struct {
+	StateNotifier     statefeed.Notifier
+	OperationNotifier opfeed.Notifier
+	HeadFetcher       HeadRootFetcher
+	FinalizedFetcher  FinalizedCheckpointFetcher
+}
+
+// ServeHTTP streams the events of the topics given by the topics query parameter until the
+// client disconnects. Topics may be repeated or comma separated, e.g. ?topics=head,block.
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	topics, err := parseEventTopics(r.URL.Query()["topics"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	stateChannel := make(chan *feed.Event, 1)
+	opChannel := make(chan *feed.Event, 1)
+	var subs []event.Subscription
+	if h.StateNotifier != nil && (topics[headTopic] || topics[blockTopic] || topics[finalizedCheckpointTopic] || topics[chainReorgTopic]) {
+		subs = append(subs, h.StateNotifier.StateFeed().Subscribe(stateChannel))
+	}
+	if h.OperationNotifier != nil && (topics[attestationTopic] || topics[voluntaryExitTopic]) {
+		subs = append(subs, h.OperationNotifier.OperationFeed().Subscribe(opChannel))
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	queue := make(chan *queuedEvent, eventQueueSize)
+	go forwardEvents(ctx, cancel, subs, stateChannel, opChannel, queue)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	conn, ok := r.Context().Value(connContextKey{}).(net.Conn)
+	if ok {
+		defer func() {
+			if err := conn.SetWriteDeadline(time.Time{}); err != nil {
+				log.WithError(err).Debug("Could not reset write deadline of stream")
+			}
+		}()
+	}
+	// setWriteDeadline bounds the time a client may take to receive the next writes.
+	setWriteDeadline := func() error {
+		if conn == nil {
+			return nil
+		}
+		return conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+	}
+	if err := setWriteDeadline(); err != nil {
+		log.WithError(err).Debug("Could not set write deadline of stream")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var finalizedEpoch types.Epoch
+	if h.FinalizedFetcher != nil {
+		finalizedEpoch = h.FinalizedFetcher.FinalizedCheckpt().Epoch
+	}
+	for {
+		var events []*streamedEvent
+		select {
+		case ev := <-queue:
+			if ev.state {
+				events = h.stateEvents(ctx, topics, ev.event, &finalizedEpoch)
+			} else {
+				events = opEvents(topics, ev.event)
+			}
+		case <-ctx.Done():
+			return
+		}
+		if len(events) == 0 {
+			continue
+		}
+		if err := setWriteDeadline(); err != nil {
+			log.WithError(err).Debug("Could not set write deadline of stream")
+			return
+		}
+		for _, ev := range events {
+			if err := ev.write(w); err != nil {
+				log.WithError(err).Debug("Could not write event to stream")
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// queuedEvent is an event of the state or operation feed waiting to be streamed.
+type queuedEvent struct {
+	event *feed.Event
+	state bool
+}
+
+// forwardEvents moves the events received by the subscriptions into the queue of the stream, so
+// that the feeds are never blocked by a slow client. The stream is cancelled, and the
+// subscriptions closed, once the client falls more than eventQueueSize events behind.
+func forwardEvents(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	subs []event.Subscription,
+	stateChannel, opChannel <-chan *feed.Event,
+	queue chan<- *queuedEvent,
+) {
+	defer func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}()
+	for {
+		var ev *queuedEvent
+		select {
+		case e := <-stateChannel:
+			ev = &queuedEvent{event: e, state: true}
+		case e := <-opChannel:
+			ev = &queuedEvent{event: e}
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case queue <- ev:
+		default:
+			log.Debug("Disconnecting events client falling behind the stream")
+			cancel()
+			return
+		}
+	}
+}
+
+// withConn stores the connection in the context of its requests, so that the events stream can
+// bound the time taken to write to it.
+func withConn(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, c)
+}
+
+type connContextKey struct{}
+
+// parseEventTopics validates the requested topics, accepting both repeated and comma separated values.
+func parseEventTopics(values []string) (map[string]bool, error) {
+	topics := make(map[string]bool)
+	for _, value := range values {
+		for _, topic := range strings.Split(value, ",") {
+			topic = strings.TrimSpace(topic)
+			if topic == "" {
+				continue
+			}
+			if !eventTopics[topic] {
+				return nil, fmt.Errorf("invalid topic: %s", topic)
+			}
+			topics[topic] = true
+		}
+	}
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("no topics specified")
+	}
+	return topics, nil
+}
+
+// streamedEvent is a single event of the server-sent events stream.
+type streamedEvent struct {
+	topic string
+	data  interface{}
+}
+
+func (e *streamedEvent) write(w http.ResponseWriter) error {
+	data, err := json.Marshal(e.data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.topic, data)
+	return err
+}
+
+// stateEvents translates a state feed event into the events of the requested topics. The finalized
+// epoch last streamed is updated in place, so a finalized checkpoint is only streamed once.
+func (h *EventsHandler) stateEvents(ctx context.Context, topics map[string]bool, ev *feed.Event, finalizedEpoch *types.Epoch) []*streamedEvent {
+	var events []*streamedEvent
+	switch ev.Type {
+	case statefeed.BlockProcessed:
+		data, ok := ev.Data.(*statefeed.BlockProcessedData)
+		if !ok || data.SignedBlock == nil || data.SignedBlock.Block == nil {
+			return nil
+		}
+		if topics[blockTopic] {
+			events = append(events, &streamedEvent{topic: blockTopic, data: &blockEvent{
+				Slot:  uint64String(uint64(data.Slot)),
+				Block: hexString(data.BlockRoot[:]),
+			}})
+		}
+		if topics[headTopic] && h.isHead(ctx, data.BlockRoot) {
+			events = append(events, &streamedEvent{topic: headTopic, data: &headEvent{
+				Slot:            uint64String(uint64(data.Slot)),
+				Block:           hexString(data.BlockRoot[:]),
+				State:           hexString(data.SignedBlock.Block.StateRoot),
+				EpochTransition: helpers.IsEpochStart(data.Slot),
+			}})
+		}
+		if topics[finalizedCheckpointTopic] && h.FinalizedFetcher != nil {
+			finalized := h.FinalizedFetcher.FinalizedCheckpt()
+			if finalized.Epoch > *finalizedEpoch {
+				*finalizedEpoch = finalized.Epoch
+				events = append(events, &streamedEvent{topic: finalizedCheckpointTopic, data: &finalizedCheckpointEvent{
+					Block: hexString(finalized.Root),
+					Epoch: uint64String(uint64(finalized.Epoch)),
+				}})
+			}
+		}
+	case statefeed.Reorg:
+		data, ok := ev.Data.(*statefeed.ReorgData)
+		if !ok || !topics[chainReorgTopic] {
+			return nil
+		}
+		events = append(events, &streamedEvent{topic: chainReorgTopic, data: &chainReorgEvent{
+			Slot:    uint64String(uint64(data.NewSlot)),
+			OldSlot: uint64String(uint64(data.OldSlot)),
+			Epoch:   uint64String(uint64(helpers.SlotToEpoch(data.NewSlot))),
+		}})
+	}
+	return events
+}
+
+// isHead returns true if the block root is the current head, or if no head fetcher is configured.
+func (h *EventsHandler) isHead(ctx context.Context, root [32]byte) bool {
+	if h.HeadFetcher == nil {
+		return true
+	}
+	headRoot, err := h.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not get head root for head event")
+		return false
+	}
+	return bytes.Equal(headRoot, root[:])
+}
+
+// opEvents translates an operation feed event into the events of the requested topics.
+func opEvents(topics map[string]bool, ev *feed.Event) []*streamedEvent {
+	switch ev.Type {
+	case opfeed.UnaggregatedAttReceived:
+		data, ok := ev.Data.(*opfeed.UnAggregatedAttReceivedData)
+		if !ok || data.Attestation == nil || !topics[attestationTopic] {
+			return nil
+		}
+		return []*streamedEvent{{topic: attestationTopic, data: newAttestationEvent(data.Attestation)}}
+	case opfeed.AggregatedAttReceived:
+		data, ok := ev.Data.(*opfeed.AggregatedAttReceivedData)
+		if !ok || data.Attestation == nil || data.Attestation.Aggregate == nil || !topics[attestationTopic] {
+			return nil
+		}
+		return []*streamedEvent{{topic: attestationTopic, data: newAttestationEvent(data.Attestation.Aggregate)}}
+	case opfeed.ExitReceived:
+		data, ok := ev.Data.(*opfeed.ExitReceivedData)
+		if !ok || data.Exit == nil || data.Exit.Exit == nil || !topics[voluntaryExitTopic] {
+			return nil
+		}
+		return []*streamedEvent{{topic: voluntaryExitTopic, data: &voluntaryExitEvent{
+			Message: &voluntaryExitMessage{
+				Epoch:          uint64String(uint64(data.Exit.Exit.Epoch)),
+				ValidatorIndex: uint64String(uint64(data.Exit.Exit.ValidatorIndex)),
+			},
+			Signature: hexString(data.Exit.Signature),
+		}}}
+	}
+	return nil
+}
+
+type headEvent struct {
+	Slot            string `json:"slot"`
+	Block           string `json:"block"`
+	State           string `json:"state"`
+	EpochTransition bool   `json:"epoch_transition"`
+}
+
+type blockEvent struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block"`
+}
+
+type finalizedCheckpointEvent struct {
+	Block string `json:"block"`
+	Epoch string `json:"epoch"`
+}
+
+type chainReorgEvent struct {
+	Slot    string `json:"slot"`
+	OldSlot string `json:"old_slot"`
+	Epoch   string `json:"epoch"`
+}
+
+type voluntaryExitEvent struct {
+	Message   *voluntaryExitMessage `json:"message"`
+	Signature string                `json:"signature"`
+}
+
+type voluntaryExitMessage struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+type attestationEvent struct {
+	AggregationBits string                `json:"aggregation_bits"`
+	Data            *attestationDataEvent `json:"data"`
+	Signature       string                `json:"signature"`
+}
+
+type attestationDataEvent struct {
+	Slot            string           `json:"slot"`
+	Index           string           `json:"index"`
+	BeaconBlockRoot string           `json:"beacon_block_root"`
+	Source          *checkpointEvent `json:"source"`
+	Target          *checkpointEvent `json:"target"`
+}
+
+type checkpointEvent struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+func newAttestationEvent(att *ethpb.Attestation) *attestationEvent {
+	event := &attestationEvent{
+		AggregationBits: hexString(att.AggregationBits),
+		Signature:       hexString(att.Signature),
+	}
+	if att.Data != nil {
+		event.Data = &attestationDataEvent{
+			Slot:            uint64String(uint64(att.Data.Slot)),
+			Index:           uint64String(uint64(att.Data.CommitteeIndex)),
+			BeaconBlockRoot: hexString(att.Data.BeaconBlockRoot),
+			Source:          newCheckpointEvent(att.Data.Source),
+			Target:          newCheckpointEvent(att.Data.Target),
+		}
+	}
+	return event
+}
+
+func newCheckpointEvent(c *ethpb.Checkpoint) *checkpointEvent {
+	if c == nil {
+		return nil
+	}
+	return &checkpointEvent{
+		Epoch: uint64String(uint64(c.Epoch)),
+		Root:  hexString(c.Root),
+	}
+}
+
+func hexString(b []byte) string {
+	return fmt.Sprintf("%#x", b)
+}
+
+func uint64String(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
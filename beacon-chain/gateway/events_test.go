@@ -0,0 +1,156 @@
+package gateway
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// mockFinalizedFetcher returns a checkpoint that may be updated while the handler streams.
+type mockFinalizedFetcher struct {
+	lock       sync.Mutex
+	checkpoint *ethpb.Checkpoint
+}
+
+func (m *mockFinalizedFetcher) FinalizedCheckpt() *ethpb.Checkpoint {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.checkpoint
+}
+
+func (m *mockFinalizedFetcher) set(checkpoint *ethpb.Checkpoint) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.checkpoint = checkpoint
+}
+
+func TestEventsHandler_InvalidTopics(t *testing.T) {
+	srv := httptest.NewServer(&EventsHandler{})
+	defer srv.Close()
+
+	for _, query := range []string{"", "?topics=", "?topics=head,unknown"} {
+		resp, err := http.Get(srv.URL + EventsPath + query)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestEventsHandler_StreamsRequestedTopics(t *testing.T) {
+	headRoot := bytesutil.PadTo([]byte("head"), 32)
+	chain := &chainMock.ChainService{Root: headRoot}
+	finalized := &mockFinalizedFetcher{checkpoint: &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)}}
+	srv := httptest.NewServer(&EventsHandler{
+		StateNotifier:     chain.StateNotifier(),
+		OperationNotifier: chain.OperationNotifier(),
+		HeadFetcher:       chain,
+		FinalizedFetcher:  finalized,
+	})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + EventsPath + "?topics=head,voluntary_exit&topics=finalized_checkpoint")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	finalized.set(&ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("finalized"), 32)})
+	chain.StateNotifier().StateFeed().Send(&feed.Event{
+		Type: statefeed.BlockProcessed,
+		Data: &statefeed.BlockProcessedData{
+			Slot:        32,
+			BlockRoot:   bytesutil.ToBytes32(headRoot),
+			SignedBlock: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 32, StateRoot: make([]byte, 32)}},
+		},
+	})
+	// Reorgs were not requested and are not streamed.
+	chain.StateNotifier().StateFeed().Send(&feed.Event{
+		Type: statefeed.Reorg,
+		Data: &statefeed.ReorgData{NewSlot: 30, OldSlot: 31},
+	})
+	chain.OperationNotifier().OperationFeed().Send(&feed.Event{
+		Type: opfeed.ExitReceived,
+		Data: &opfeed.ExitReceivedData{
+			Exit: &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 2, ValidatorIndex: 5}, Signature: []byte{0x01}},
+		},
+	})
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() (string, string) {
+		event, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		data, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		_, err = reader.ReadString('\n')
+		require.NoError(t, err)
+		return strings.TrimSpace(strings.TrimPrefix(event, "event:")), strings.TrimSpace(strings.TrimPrefix(data, "data:"))
+	}
+
+	topic, data := readEvent()
+	assert.Equal(t, headTopic, topic)
+	assert.Equal(t, true, strings.Contains(data, `"slot":"32"`), data)
+	assert.Equal(t, true, strings.Contains(data, `"epoch_transition":true`), data)
+	topic, data = readEvent()
+	assert.Equal(t, finalizedCheckpointTopic, topic)
+	assert.Equal(t, true, strings.Contains(data, `"epoch":"1"`), data)
+	topic, data = readEvent()
+	assert.Equal(t, voluntaryExitTopic, topic)
+	assert.Equal(t, `{"message":{"epoch":"2","validator_index":"5"},"signature":"0x01"}`, data)
+}
+
+func TestEventsHandler_SlowClientDoesNotBlockFeed(t *testing.T) {
+	chain := &chainMock.ChainService{}
+	srv := httptest.NewUnstartedServer(&EventsHandler{OperationNotifier: chain.OperationNotifier()})
+	srv.Config.ConnContext = withConn
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + EventsPath + "?topics=voluntary_exit")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The client never reads the stream, so its connection fills up with the large exits.
+	exit := &feed.Event{
+		Type: opfeed.ExitReceived,
+		Data: &opfeed.ExitReceivedData{
+			Exit: &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{}, Signature: make([]byte, 1<<16)},
+		},
+	}
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 4*eventQueueSize; i++ {
+			chain.OperationNotifier().OperationFeed().Send(exit)
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Feed blocked by a slow client")
+	}
+	// The client fell behind and is unsubscribed from the feed.
+	subscribers := chain.OperationNotifier().OperationFeed().Send(exit)
+	for i := 0; i < 100 && subscribers > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		subscribers = chain.OperationNotifier().OperationFeed().Send(exit)
+	}
+	assert.Equal(t, 0, subscribers)
+}
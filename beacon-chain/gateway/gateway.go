@@ -7,19 +7,25 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1_gateway"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1_gateway"
 	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/ratelimit"
+	"github.com/prysmaticlabs/prysm/shared/tlsutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 )
 
 var _ shared.Service = (*Gateway)(nil)
 
+// readHeaderTimeout is the time allowed to read the headers of a request.
+const readHeaderTimeout = 10 * time.Second
+
 // Gateway is the gRPC gateway to serve HTTP JSON traffic as a proxy and forward
 // it to the beacon-chain gRPC server.
 type Gateway struct {
@@ -29,12 +35,19 @@ type Gateway struct {
 	gatewayAddr             string
 	remoteAddr              string
 	remoteCert              string
+	clientCert              string
+	clientKey               string
 	server                  *http.Server
 	mux                     *http.ServeMux
 	allowedOrigins          []string
+	authenticator           *Authenticator
+	rateLimiter             *ratelimit.Limiter
+	rateLimitExemption      string
 	startFailure            error
 	enableDebugRPCEndpoints bool
 	maxCallRecvMsgSize      uint64
+	maxBodySize             int64
+	requestTimeout          time.Duration
 }
 
 // Start the gateway service. This serves the HTTP JSON traffic on the specified
@@ -78,10 +91,15 @@ func (g *Gateway) Start() {
 	}
 
 	g.mux.Handle("/", gwmux)
+	standardAPI := newStandardAPIHandler(conn)
+	g.mux.Handle(StandardAPIPrefix, standardAPI)
+	g.mux.Handle(PrysmAPIPrefix, standardAPI)
 
 	g.server = &http.Server{
-		Addr:    g.gatewayAddr,
-		Handler: newCorsHandler(g.mux, g.allowedOrigins),
+		Addr:              g.gatewayAddr,
+		Handler:           g.handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ConnContext:       withConn,
 	}
 	go func() {
 		if err := g.server.ListenAndServe(); err != http.ErrServerClosed {
@@ -92,6 +110,24 @@ func (g *Gateway) Start() {
 	}()
 }
 
+// handler wraps the mux of the gateway with its middlewares. Unauthenticated requests are rejected
+// before they count against the rate limits of their client.
+func (g *Gateway) handler() http.Handler {
+	handler := newLimitsHandler(newCompressionHandler(g.mux), g.maxBodySize, g.requestTimeout)
+	if g.rateLimiter != nil {
+		handler = g.rateLimiter.HTTPHandler(handler, isStreamPath)
+	}
+	if g.authenticator != nil {
+		handler = g.authenticator.Handler(handler)
+	}
+	return newCorsHandler(handler, g.allowedOrigins)
+}
+
+// isStreamPath returns whether the requests to path are long lived streams.
+func isStreamPath(path string) bool {
+	return path == EventsPath || strings.HasSuffix(path, "/stream")
+}
+
 // Status of grpc gateway. Returns an error if this service is unhealthy.
 func (g *Gateway) Status() error {
 	if g.startFailure != nil {
@@ -121,16 +157,28 @@ func (g *Gateway) Stop() error {
 }
 
 // New returns a new gateway server which translates HTTP into gRPC.
-// Accepts a context and optional http.ServeMux.
+// Accepts a context and optional http.ServeMux. The client certificate and
+// key are presented to a gRPC server requiring mutual TLS. Requests are not
+// authenticated if the authenticator is nil, nor limited if the rate limiter
+// is nil. As its HTTP clients are limited by the gateway, its gRPC calls carry
+// the rate limit exemption token of the gRPC server, unless empty. Request bodies larger than maxBodySize bytes are rejected and
+// requests running longer than requestTimeout are cancelled, unless zero.
 func New(
 	ctx context.Context,
 	remoteAddress,
 	remoteCert,
+	clientCert,
+	clientKey,
 	gatewayAddress string,
 	mux *http.ServeMux,
 	allowedOrigins []string,
+	authenticator *Authenticator,
+	rateLimiter *ratelimit.Limiter,
+	rateLimitExemption string,
 	enableDebugRPCEndpoints bool,
 	maxCallRecvMsgSize uint64,
+	maxBodySize int64,
+	requestTimeout time.Duration,
 ) *Gateway {
 	if mux == nil {
 		mux = http.NewServeMux()
@@ -139,12 +187,19 @@ func New(
 	return &Gateway{
 		remoteAddr:              remoteAddress,
 		remoteCert:              remoteCert,
+		clientCert:              clientCert,
+		clientKey:               clientKey,
 		gatewayAddr:             gatewayAddress,
 		ctx:                     ctx,
 		mux:                     mux,
 		allowedOrigins:          allowedOrigins,
+		authenticator:           authenticator,
+		rateLimiter:             rateLimiter,
+		rateLimitExemption:      rateLimitExemption,
 		enableDebugRPCEndpoints: enableDebugRPCEndpoints,
 		maxCallRecvMsgSize:      maxCallRecvMsgSize,
+		maxBodySize:             maxBodySize,
+		requestTimeout:          requestTimeout,
 	}
 }
 
@@ -165,7 +220,7 @@ func (g *Gateway) dial(ctx context.Context, network, addr string) (*grpc.ClientC
 func (g *Gateway) dialTCP(ctx context.Context, addr string) (*grpc.ClientConn, error) {
 	security := grpc.WithInsecure()
 	if len(g.remoteCert) > 0 {
-		creds, err := credentials.NewClientTLSFromFile(g.remoteCert, "")
+		creds, err := tlsutil.ClientCredentials(g.remoteCert, g.clientCert, g.clientKey)
 		if err != nil {
 			return nil, err
 		}
@@ -175,6 +230,7 @@ func (g *Gateway) dialTCP(ctx context.Context, addr string) (*grpc.ClientConn, e
 		security,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(g.maxCallRecvMsgSize))),
 	}
+	opts = append(opts, g.exemptionDialOptions()...)
 
 	return grpc.DialContext(
 		ctx,
@@ -194,5 +250,24 @@ func (g *Gateway) dialUnix(ctx context.Context, addr string) (*grpc.ClientConn,
 		grpc.WithDialer(d),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(g.maxCallRecvMsgSize))),
 	}
+	opts = append(opts, g.exemptionDialOptions()...)
 	return grpc.DialContext(ctx, addr, opts...)
 }
+
+// exemptionDialOptions attach the rate limit exemption token to the outgoing calls, if any.
+func (g *Gateway) exemptionDialOptions() []grpc.DialOption {
+	if g.rateLimitExemption == "" {
+		return nil
+	}
+	withToken := func(ctx context.Context) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, ratelimit.ExemptionMetadataKey, g.rateLimitExemption)
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(withToken(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(withToken(ctx), desc, cc, method, opts...)
+		}),
+	}
+}
@@ -0,0 +1,62 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// errBodyTooLarge is returned when reading a request body larger than the maximum body size.
+var errBodyTooLarge = errors.New("request body too large")
+
+// newLimitsHandler rejects the requests with a body larger than maxBodySize bytes, and cancels the
+// requests still running after timeout, except for streams. A zero value disables the limit.
+func newLimitsHandler(next http.Handler, maxBodySize int64, timeout time.Duration) http.Handler {
+	if maxBodySize <= 0 && timeout <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if maxBodySize > 0 {
+			if r.ContentLength > maxBodySize {
+				writeSpecError(w, &specError{
+					Code:    http.StatusRequestEntityTooLarge,
+					Message: fmt.Sprintf("Request body larger than the maximum of %d bytes", maxBodySize),
+				})
+				return
+			}
+			r.Body = &limitedBody{ReadCloser: r.Body, remaining: maxBodySize}
+		}
+		if timeout > 0 && !isStreamPath(r.URL.Path) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitedBody is a request body returning errBodyTooLarge once more than remaining bytes are read,
+// for bodies whose length is not known in advance.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	// One more byte than remaining is read to tell a body of exactly the maximum size from a larger one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		return n, errBodyTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestLimitsHandler_MaxBodySize(t *testing.T) {
+	srv := httptest.NewServer(newLimitsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err == errBodyTooLarge {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}), 8, 0))
+	defer srv.Close()
+
+	post := func(body io.Reader) (int, string) {
+		resp, err := http.Post(srv.URL, "application/octet-stream", body)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		content, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(content)
+	}
+	code, body := post(strings.NewReader("12345678"))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "12345678", body)
+	// A body of known length is rejected before being read.
+	code, body = post(strings.NewReader("123456789"))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	assert.Equal(t, true, strings.Contains(body, "larger than the maximum of 8 bytes"), "Unexpected body %s", body)
+	// A chunked body is rejected once it is read past the limit.
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := pw.Write([]byte("123456789"))
+		require.NoError(t, pw.CloseWithError(err))
+	}()
+	code, _ = post(pr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+}
+
+func TestLimitsHandler_RequestTimeout(t *testing.T) {
+	deadlines := make(chan bool, 2)
+	srv := httptest.NewServer(newLimitsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Deadline()
+		deadlines <- ok
+	}), 0, time.Minute))
+	defer srv.Close()
+
+	for _, path := range []string{"/eth/v1/node/version", EventsPath} {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.Equal(t, true, <-deadlines, "Request has no deadline")
+	assert.Equal(t, false, <-deadlines, "Event stream has a deadline")
+}
@@ -23,6 +23,8 @@ var (
 	allowedOrigins          = flag.String("corsdomain", "localhost:4242", "A comma separated list of CORS domains to allow")
 	enableDebugRPCEndpoints = flag.Bool("enable-debug-rpc-endpoints", false, "Enable debug rpc endpoints such as /eth/v1alpha1/beacon/state")
 	grpcMaxMsgSize          = flag.Int("grpc-max-msg-size", 1<<22, "Integer to define max recieve message call size")
+	maxBodySize             = flag.Int64("max-body-size", 32<<20, "Maximum size in bytes of request bodies, 0 for no limit")
+	requestTimeout          = flag.Duration("request-timeout", 0, "Time after which requests are cancelled, 0 for no timeout")
 )
 
 func init() {
@@ -40,11 +42,18 @@ func main() {
 		context.Background(),
 		*beaconRPC,
 		"", // remoteCert
+		"", // clientCert
+		"", // clientKey
 		fmt.Sprintf("%s:%d", *host, *port),
 		mux,
 		strings.Split(*allowedOrigins, ","),
+		nil, // authenticator
+		nil, // rateLimiter
+		"",  // rateLimitExemption
 		*enableDebugRPCEndpoints,
 		uint64(*grpcMaxMsgSize),
+		*maxBodySize,
+		*requestTimeout,
 	)
 	mux.HandleFunc("/swagger/", gateway.SwaggerServer())
 	mux.HandleFunc("/healthz", healthzServer(gw))
@@ -0,0 +1,291 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	ptypes "github.com/gogo/protobuf/types"
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// StandardAPIPrefix is the path prefix of the standard API served by the gateway.
+const StandardAPIPrefix = "/eth/v1/"
+
+// PrysmAPIPrefix is the path prefix of the Prysm specific extensions of the standard API, which are
+// kept out of the standard namespace.
+const PrysmAPIPrefix = "/prysm/v1/"
+
+// standardAPIHandler serves the standard API paths, and the Prysm specific paths extending them, by
+// forwarding them to the v1 gRPC services.
+type standardAPIHandler struct {
+	conn   grpc.ClientConnInterface
+	routes []*standardRoute
+}
+
+func newStandardAPIHandler(conn grpc.ClientConnInterface) *standardAPIHandler {
+	return &standardAPIHandler{conn: conn, routes: standardRoutes}
+}
+
+// specError is the error body of the standard API. Failures lists the items of a batch request
+// that failed, by their index in the batch.
+type specError struct {
+	Code     int                         `json:"code"`
+	Message  string                      `json:"message"`
+	Failures []*grpcutils.IndexedFailure `json:"failures,omitempty"`
+}
+
+// ServeHTTP matches the request against the standard API routes and forwards it to the gRPC server.
+func (h *standardAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, params, pathMatched := h.match(r.Method, r.URL.Path)
+	if route == nil {
+		if pathMatched {
+			writeSpecError(w, &specError{Code: http.StatusMethodNotAllowed, Message: "Method not allowed"})
+			return
+		}
+		writeSpecError(w, &specError{Code: http.StatusNotFound, Message: "Not found"})
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err == errBodyTooLarge {
+		writeSpecError(w, &specError{Code: http.StatusRequestEntityTooLarge, Message: "Request body too large"})
+		return
+	}
+	if err != nil {
+		writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: fmt.Sprintf("Could not read request body: %v", err)})
+		return
+	}
+	if isSSZBody(r) && route.decodeSSZ == nil {
+		writeSpecError(w, &specError{Code: http.StatusUnsupportedMediaType, Message: "SSZ request bodies are not supported by this endpoint"})
+		return
+	}
+
+	ctx := r.Context()
+	req := route.request()
+	if err := populateRequest(req, params, r); err != nil {
+		writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	ctx, err = appendRequestParams(ctx, route.params, params, r)
+	if err != nil {
+		writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	switch {
+	case isSSZBody(r):
+		err = route.decodeSSZ(body, req)
+	case route.body == messageBody:
+		err = unmarshalSpecJSON(body, req)
+	case route.body == dataBody:
+		err = unmarshalSpecJSON(append(append([]byte(`{"data":`), body...), '}'), req)
+	case route.body == indexBody:
+		err = unmarshalSpecJSON(append(append([]byte(`{"index":`), body...), '}'), req)
+	case route.body == signedDataBody:
+		var messages []byte
+		var signatures []string
+		messages, signatures, err = splitSignedItems(body)
+		if err == nil {
+			err = unmarshalSpecJSON(append(append([]byte(`{"data":`), messages...), '}'), req)
+			ctx = grpcutils.AppendSignatures(ctx, signatures...)
+		}
+	}
+	if err != nil {
+		writeSpecError(w, &specError{Code: http.StatusBadRequest, Message: fmt.Sprintf("Could not decode request body: %v", err)})
+		return
+	}
+
+	resp := route.response()
+	var header, trailer metadata.MD
+	opts := []grpc.CallOption{grpc.Header(&header), grpc.Trailer(&trailer)}
+	if route.jsonCodec {
+		opts = append(opts, grpc.CallContentSubtype(grpcutils.JSONCodecName))
+	}
+	if err := h.conn.Invoke(ctx, route.rpc, req, resp, opts...); err != nil {
+		writeGRPCError(w, err, grpcutils.Failures(trailer)...)
+		return
+	}
+	code, ok := grpcutils.HTTPCode(header)
+	if !ok {
+		code = http.StatusOK
+	}
+	if _, ok := resp.(*ptypes.Empty); ok {
+		w.WriteHeader(code)
+		return
+	}
+	contentType, encode := "application/json", marshalSpecJSON
+	if route.encodeSSZ != nil && prefersSSZ(r) {
+		contentType, encode = sszMediaType, route.encodeSSZ
+	}
+	enc, err := encode(resp)
+	if err == nil && contentType == "application/json" {
+		enc, err = addSpecFields(enc, grpcutils.ResponseFields(header))
+	}
+	if err != nil {
+		writeSpecError(w, &specError{Code: http.StatusInternalServerError, Message: fmt.Sprintf("Could not encode response: %v", err)})
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	if _, err := w.Write(enc); err != nil {
+		log.WithError(err).Debug("Could not write response")
+	}
+}
+
+// match returns the route of the method and path with the values of its path parameters. If no
+// route matches, it reports whether the path matched a route of another method.
+func (h *standardAPIHandler) match(method, path string) (*standardRoute, map[string]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	pathMatched := false
+	for _, route := range h.routes {
+		params, ok := matchPath(route.path, segments)
+		if !ok {
+			continue
+		}
+		if route.method == method {
+			return route, params, true
+		}
+		pathMatched = true
+	}
+	return nil, nil, pathMatched
+}
+
+// matchPath matches the path segments against a template such as /eth/v1/beacon/headers/{block_id}.
+func matchPath(template string, segments []string) (map[string]string, bool) {
+	tmplSegments := strings.Split(strings.Trim(template, "/"), "/")
+	if len(tmplSegments) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, tmpl := range tmplSegments {
+		if strings.HasPrefix(tmpl, "{") && strings.HasSuffix(tmpl, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(tmpl, "{}")] = segments[i]
+			continue
+		}
+		if tmpl != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// populateRequest sets the top level fields of the request message from the path and query
+// parameters. Unknown query parameters are ignored.
+func populateRequest(req interface{}, params map[string]string, r *http.Request) error {
+	v := reflect.ValueOf(req).Elem()
+	fields := make(map[string]int)
+	for _, f := range specFields(v.Type()) {
+		fields[f.name] = f.index
+	}
+	for name, value := range params {
+		index, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown path parameter %s", name)
+		}
+		if err := setParam(v.Field(index), []string{value}); err != nil {
+			return fmt.Errorf("invalid path parameter %s: %v", name, err)
+		}
+	}
+	for name, values := range r.URL.Query() {
+		index, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if err := setParam(v.Field(index), values); err != nil {
+			return fmt.Errorf("invalid query parameter %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// appendRequestParams attaches the path and query parameters of the route's params to the outgoing
+// context. Their values must be printable ASCII to be passed as gRPC metadata.
+func appendRequestParams(ctx context.Context, names []string, pathParams map[string]string, r *http.Request) (context.Context, error) {
+	query := r.URL.Query()
+	for _, name := range names {
+		value, ok := pathParams[name]
+		if !ok {
+			values, ok := query[name]
+			if !ok || len(values) == 0 {
+				continue
+			}
+			value = values[len(values)-1]
+		}
+		for _, c := range value {
+			if c < 0x20 || c > 0x7e {
+				return nil, fmt.Errorf("invalid parameter %s: unsupported character %q", name, c)
+			}
+		}
+		ctx = grpcutils.AppendRequestParam(ctx, name, value)
+	}
+	return ctx, nil
+}
+
+// setParam sets a field from parameter values. Bytes fields take the raw value, as identifiers
+// such as "head" or "finalized" are resolved by the gRPC server, and repeated fields accept both
+// repeated and comma separated values.
+func setParam(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				elem := reflect.New(v.Type().Elem()).Elem()
+				if err := setParam(elem, []string{item}); err != nil {
+					return err
+				}
+				v.Set(reflect.Append(v, elem))
+			}
+		}
+		return nil
+	}
+	value := values[len(values)-1]
+	switch v.Kind() {
+	case reflect.Slice:
+		v.SetBytes([]byte(value))
+	case reflect.Bool:
+		switch value {
+		case "true":
+			v.SetBool(true)
+		case "false":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("expected boolean")
+		}
+	default:
+		return setSpecScalar(v, value)
+	}
+	return nil
+}
+
+// writeGRPCError writes the error of a gRPC call, along with the failures of the items of a batch
+// request the server reported.
+func writeGRPCError(w http.ResponseWriter, err error, failures ...*grpcutils.IndexedFailure) {
+	st := status.Convert(err)
+	writeSpecError(w, &specError{
+		Code:     gwruntime.HTTPStatusFromCode(st.Code()),
+		Message:  st.Message(),
+		Failures: failures,
+	})
+}
+
+func writeSpecError(w http.ResponseWriter, e *specError) {
+	enc, err := json.Marshal(e)
+	if err != nil {
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.Code)
+	if _, err := w.Write(enc); err != nil {
+		log.WithError(err).Debug("Could not write error response")
+	}
+}
@@ -0,0 +1,397 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+)
+
+// specFieldNames renames the proto fields whose JSON names differ from the standard API.
+var specFieldNames = map[reflect.Type]map[string]string{
+	reflect.TypeOf(ethpb.SignedBeaconBlock{}):       {"block": "message"},
+	reflect.TypeOf(ethpb.SignedBeaconBlockHeader{}): {"header": "message"},
+	reflect.TypeOf(ethpb.SignedVoluntaryExit{}):     {"exit": "message"},
+	reflect.TypeOf(ethpb.ProposerSlashing{}):        {"header_1": "signed_header_1", "header_2": "signed_header_2"},
+}
+
+var timestampType = reflect.TypeOf(ptypes.Timestamp{})
+
+// maxEnumValue bounds the search for an enum value by name, as enum types carry no value map.
+const maxEnumValue = 256
+
+// specField is a proto message field with its standard API JSON name.
+type specField struct {
+	name  string
+	index int
+}
+
+// specFields returns the exported fields of a proto or plain Go message struct type.
+func specFields(t reflect.Type) []specField {
+	renames := specFieldNames[t]
+	fields := make([]specField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") {
+			continue
+		}
+		name := protoFieldName(f)
+		if name == "" {
+			continue
+		}
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+		fields = append(fields, specField{name: name, index: i})
+	}
+	return fields
+}
+
+// protoFieldName returns the original proto name of a field from its protobuf struct tag. Fields of
+// plain Go messages, which have no protobuf tag, are named by their json struct tag.
+func protoFieldName(f reflect.StructField) string {
+	tag, ok := f.Tag.Lookup("protobuf")
+	if !ok {
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
+
+// isEnum returns true for the int32 based proto enum types, and for the uint8 based enum types of
+// the Prysm specific messages.
+func isEnum(t reflect.Type) bool {
+	_, ok := reflect.Zero(t).Interface().(fmt.Stringer)
+	return (t.Kind() == reflect.Int32 || t.Kind() == reflect.Uint8) && ok
+}
+
+// marshalSpecJSON encodes a proto message as standard API JSON: bytes are 0x-prefixed hex,
+// integers are decimal strings and enums are lowercase names.
+func marshalSpecJSON(msg interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := encodeSpecValue(buf, reflect.ValueOf(msg)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// splitSignedItems splits a JSON array of signed items into the JSON array of their messages and
+// their signatures.
+func splitSignedItems(body []byte) ([]byte, []string, error) {
+	var items []struct {
+		Message   json.RawMessage `json:"message"`
+		Signature string          `json:"signature"`
+	}
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, nil, err
+	}
+	messages := make([]json.RawMessage, len(items))
+	signatures := make([]string, len(items))
+	for i, item := range items {
+		if item.Message == nil {
+			return nil, nil, fmt.Errorf("item %d has no message", i)
+		}
+		messages[i] = item.Message
+		signatures[i] = item.Signature
+	}
+	enc, err := json.Marshal(messages)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, signatures, nil
+}
+
+// addSpecFields adds top level string fields to an encoded JSON object, in the order of their names.
+func addSpecFields(enc []byte, fields map[string]string) ([]byte, error) {
+	if len(fields) == 0 {
+		return enc, nil
+	}
+	if !bytes.HasSuffix(enc, []byte("}")) {
+		return nil, fmt.Errorf("response is not a JSON object")
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buf := new(bytes.Buffer)
+	buf.Write(enc[:len(enc)-1])
+	for _, name := range names {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSONString(buf, name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := writeJSONString(buf, fields[name]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func encodeSpecValue(buf *bytes.Buffer, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeSpecValue(buf, v.Elem())
+	case reflect.Struct:
+		if v.Type() == timestampType {
+			return writeJSONString(buf, strconv.FormatInt(v.Interface().(ptypes.Timestamp).Seconds, 10))
+		}
+		buf.WriteByte('{')
+		for i, f := range specFields(v.Type()) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONString(buf, f.name); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeSpecValue(buf, v.Field(f.index)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return writeJSONString(buf, hexutil.Encode(v.Bytes()))
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeSpecValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONString(buf, fmt.Sprint(k.Interface())); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeSpecValue(buf, v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isEnum(v.Type()) {
+			return writeJSONString(buf, strings.ToLower(v.Interface().(fmt.Stringer).String()))
+		}
+		return writeJSONString(buf, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Int32:
+		if isEnum(v.Type()) {
+			return writeJSONString(buf, strings.ToLower(v.Interface().(fmt.Stringer).String()))
+		}
+		return writeJSONString(buf, strconv.FormatInt(v.Int(), 10))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		return writeJSONString(buf, strconv.FormatInt(v.Int(), 10))
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.String:
+		return writeJSONString(buf, v.String())
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) error {
+	enc, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	buf.Write(enc)
+	return nil
+}
+
+// unmarshalSpecJSON decodes standard API JSON into a proto message, the inverse of marshalSpecJSON.
+func unmarshalSpecJSON(data []byte, msg interface{}) error {
+	var decoded interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return err
+	}
+	return decodeSpecValue(decoded, reflect.ValueOf(msg).Elem(), "")
+}
+
+func decodeSpecValue(in interface{}, v reflect.Value, path string) error {
+	if in == nil {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeSpecValue(in, v.Elem(), path)
+	case reflect.Struct:
+		obj, ok := in.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object", fieldPath(path))
+		}
+		for _, f := range specFields(v.Type()) {
+			if fieldIn, ok := obj[f.name]; ok {
+				if err := decodeSpecValue(fieldIn, v.Field(f.index), joinPath(path, f.name)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			s, ok := in.(string)
+			if !ok {
+				return fmt.Errorf("%s: expected hex string", fieldPath(path))
+			}
+			b, err := hexutil.Decode(s)
+			if err != nil {
+				return fmt.Errorf("%s: %v", fieldPath(path), err)
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		arr, ok := in.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array", fieldPath(path))
+		}
+		slice := reflect.MakeSlice(v.Type(), len(arr), len(arr))
+		for i, elem := range arr {
+			if err := decodeSpecValue(elem, slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case reflect.Map:
+		obj, ok := in.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("%s: expected object", fieldPath(path))
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(obj))
+		for k, elemIn := range obj {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeSpecValue(elemIn, elem, joinPath(path, k)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Bool:
+		b, ok := in.(bool)
+		if !ok {
+			return fmt.Errorf("%s: expected boolean", fieldPath(path))
+		}
+		v.SetBool(b)
+		return nil
+	default:
+		var s string
+		switch value := in.(type) {
+		case string:
+			s = value
+		case json.Number:
+			s = value.String()
+		default:
+			return fmt.Errorf("%s: expected string", fieldPath(path))
+		}
+		if err := setSpecScalar(v, s); err != nil {
+			return fmt.Errorf("%s: %v", fieldPath(path), err)
+		}
+		return nil
+	}
+}
+
+// setSpecScalar sets a string, integer or enum field from its standard API string form.
+func setSpecScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isEnum(v.Type()) {
+			return setSpecEnum(v, s)
+		}
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Int32:
+		if isEnum(v.Type()) {
+			return setSpecEnum(v, s)
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// setSpecEnum sets an enum field from its case insensitive name.
+func setSpecEnum(v reflect.Value, name string) error {
+	for i := int64(0); i < maxEnumValue; i++ {
+		enum := reflect.ValueOf(i).Convert(v.Type())
+		if strings.EqualFold(enum.Interface().(fmt.Stringer).String(), name) {
+			v.Set(enum)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown value %q", name)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "request body"
+	}
+	return path
+}
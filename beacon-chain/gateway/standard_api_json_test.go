@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestMarshalSpecJSON(t *testing.T) {
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 2, ValidatorIndex: 5},
+		Signature: []byte{0x01, 0x02},
+	}
+	enc, err := marshalSpecJSON(exit)
+	require.NoError(t, err)
+	assert.Equal(t, `{"message":{"epoch":"2","validator_index":"5"},"signature":"0x0102"}`, string(enc))
+
+	peer := &ethpb.PeerResponse{Data: &ethpb.Peer{
+		PeerId:    "peer",
+		State:     ethpb.ConnectionState_CONNECTED,
+		Direction: ethpb.PeerDirection_INBOUND,
+	}}
+	enc, err = marshalSpecJSON(peer)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"peer_id":"peer","enr":"","address":"","state":"connected","direction":"inbound"}}`, string(enc))
+}
+
+func TestAddSpecFields(t *testing.T) {
+	enc, err := addSpecFields([]byte(`{"data":[]}`), map[string]string{"dependent_root": "0x01", "b": "2"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":[],"b":"2","dependent_root":"0x01"}`, string(enc))
+
+	enc, err = addSpecFields([]byte(`{}`), map[string]string{"dependent_root": "0x01"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"dependent_root":"0x01"}`, string(enc))
+
+	enc, err = addSpecFields([]byte(`{"data":[]}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":[]}`, string(enc))
+
+	_, err = addSpecFields([]byte(`[]`), map[string]string{"dependent_root": "0x01"})
+	assert.ErrorContains(t, "not a JSON object", err)
+}
+
+func TestUnmarshalSpecJSON_RoundTrip(t *testing.T) {
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 2, ParentRoot: []byte{0xaa}},
+			Signature: []byte{0x01},
+		},
+		Header_2: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 2, ParentRoot: []byte{0xbb}},
+			Signature: []byte{0x02},
+		},
+	}
+	enc, err := marshalSpecJSON(slashing)
+	require.NoError(t, err)
+	assert.Equal(t, true, len(enc) > 0)
+
+	decoded := &ethpb.ProposerSlashing{}
+	require.NoError(t, unmarshalSpecJSON(enc, decoded))
+	assert.DeepEqual(t, slashing.Header_1.Header.ParentRoot, decoded.Header_1.Header.ParentRoot)
+	assert.DeepEqual(t, slashing.Header_2.Signature, decoded.Header_2.Signature)
+	assert.Equal(t, slashing.Header_1.Header.ProposerIndex, decoded.Header_1.Header.ProposerIndex)
+
+	peer := &ethpb.Peer{}
+	require.NoError(t, unmarshalSpecJSON([]byte(`{"peer_id":"peer","state":"disconnecting"}`), peer))
+	assert.Equal(t, ethpb.ConnectionState_DISCONNECTING, peer.State)
+}
+
+func TestUnmarshalSpecJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{input: `[]`, err: "request body: expected object"},
+		{input: `{"message":{"epoch":"two"}}`, err: "message.epoch"},
+		{input: `{"signature":"0102"}`, err: "signature"},
+		{input: `{"message":{"epoch":"1"`, err: "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		err := unmarshalSpecJSON([]byte(tt.input), &ethpb.SignedVoluntaryExit{})
+		assert.ErrorContains(t, tt.err, err, tt.input)
+	}
+}
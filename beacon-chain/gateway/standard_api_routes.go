@@ -0,0 +1,474 @@
+package gateway
+
+import (
+	"net/http"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconv1"
+)
+
+// routeBody describes how the body of a standard API request maps onto its gRPC request message.
+type routeBody int
+
+const (
+	// noBody requests are populated from their path and query parameters only.
+	noBody routeBody = iota
+	// messageBody requests are decoded from the whole request body.
+	messageBody
+	// dataBody requests take the JSON array of the request body as their data field.
+	dataBody
+	// indexBody requests take the JSON array of the request body as their index field.
+	indexBody
+	// signedDataBody requests take the messages of the JSON array of signed items of the request
+	// body as their data field, with the signatures of the items passed as request metadata.
+	signedDataBody
+)
+
+// standardRoute maps a standard API path onto a method of the v1 gRPC services. Path templates
+// and request bodies follow the google.api.http annotations of the ethereumapis v1 services.
+type standardRoute struct {
+	method   string
+	path     string
+	rpc      string
+	request  func() interface{}
+	response func() interface{}
+	body     routeBody
+	// encodeSSZ, if set, encodes the response for clients accepting application/octet-stream.
+	encodeSSZ func(resp interface{}) ([]byte, error)
+	// decodeSSZ, if set, decodes an application/octet-stream request body.
+	decodeSSZ func(data []byte, req interface{}) error
+	// jsonCodec is set for the Prysm specific methods, whose messages are plain Go structs. Unless
+	// they serve a standard path, their paths are under PrysmAPIPrefix.
+	jsonCodec bool
+	// params names the path and query parameters passed to the server as request metadata, either
+	// because the request message has no field for them or because the presence of a zero value
+	// matters.
+	params []string
+}
+
+// pageParams are the query parameters of the paginated listings.
+var pageParams = []string{"page_size", "page_token"}
+
+// committeeParams are the filters of the committees listing, whose zero values select the first
+// epoch, slot or committee index.
+var committeeParams = []string{"epoch", "index", "slot"}
+
+var standardRoutes = []*standardRoute{
+	// BeaconChain service.
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/genesis",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetGenesis",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.GenesisResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/root",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetStateRoot",
+		request:  func() interface{} { return &ethpb.StateRequest{} },
+		response: func() interface{} { return &ethpb.StateRootResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/fork",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetStateFork",
+		request:  func() interface{} { return &ethpb.StateRequest{} },
+		response: func() interface{} { return &ethpb.StateForkResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/finality_checkpoints",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetFinalityCheckpoints",
+		request:  func() interface{} { return &ethpb.StateRequest{} },
+		response: func() interface{} { return &ethpb.StateFinalityCheckpointResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/validators/{validator_id}",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetValidator",
+		request:  func() interface{} { return &ethpb.StateValidatorRequest{} },
+		response: func() interface{} { return &ethpb.StateValidatorResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/validators",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListValidators",
+		request:  func() interface{} { return &ethpb.StateValidatorsRequest{} },
+		response: func() interface{} { return &ethpb.StateValidatorsResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/validator_balances",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListValidatorBalances",
+		request:  func() interface{} { return &ethpb.ValidatorBalancesRequest{} },
+		response: func() interface{} { return &ethpb.ValidatorBalancesResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/committees/{epoch}",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListCommittees",
+		request:  func() interface{} { return &ethpb.StateCommitteesRequest{} },
+		response: func() interface{} { return &ethpb.StateCommitteesResponse{} },
+		params:   committeeParams,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/states/{state_id}/committees",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListCommittees",
+		request:  func() interface{} { return &ethpb.StateCommitteesRequest{} },
+		response: func() interface{} { return &ethpb.StateCommitteesResponse{} },
+		params:   committeeParams,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/headers/{block_id}",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetBlockHeader",
+		request:  func() interface{} { return &ethpb.BlockRequest{} },
+		response: func() interface{} { return &ethpb.BlockHeaderResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/headers",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListBlockHeaders",
+		request:  func() interface{} { return &ethpb.BlockHeadersRequest{} },
+		response: func() interface{} { return &ethpb.BlockHeadersResponse{} },
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/eth/v1/beacon/blocks",
+		rpc:       "/ethereum.eth.v1.BeaconChain/SubmitBlock",
+		request:   func() interface{} { return &ethpb.BeaconBlockContainer{} },
+		response:  func() interface{} { return &ptypes.Empty{} },
+		body:      messageBody,
+		decodeSSZ: decodeBlockContainerSSZ,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/eth/v1/beacon/blocks/{block_id}",
+		rpc:       "/ethereum.eth.v1.BeaconChain/GetBlock",
+		request:   func() interface{} { return &ethpb.BlockRequest{} },
+		response:  func() interface{} { return &ethpb.BlockResponse{} },
+		encodeSSZ: encodeBlockResponseSSZ,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/blocks/{block_id}/root",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetBlockRoot",
+		request:  func() interface{} { return &ethpb.BlockRequest{} },
+		response: func() interface{} { return &ethpb.BlockRootResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/blocks/{block_id}/attestations",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListBlockAttestations",
+		request:  func() interface{} { return &ethpb.BlockRequest{} },
+		response: func() interface{} { return &ethpb.BlockAttestationsResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/pool/attestations",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListPoolAttestations",
+		request:  func() interface{} { return &ethpb.AttestationsPoolRequest{} },
+		response: func() interface{} { return &ethpb.AttestationsPoolResponse{} },
+		params:   append([]string{"slot", "committee_index"}, pageParams...),
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/eth/v1/beacon/pool/attestations",
+		rpc:       "/prysm.eth.v1.BeaconChain/SubmitAttestations",
+		request:   func() interface{} { return &beaconv1.SubmitAttestationsRequest{} },
+		response:  func() interface{} { return &ptypes.Empty{} },
+		body:      dataBody,
+		jsonCodec: true,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/pool/attester_slashings",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListPoolAttesterSlashings",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.AttesterSlashingsPoolResponse{} },
+		params:   pageParams,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/pool/attester_slashings/count",
+		rpc:       "/prysm.eth.v1.BeaconChain/CountPoolAttesterSlashings",
+		request:   func() interface{} { return &ptypes.Empty{} },
+		response:  func() interface{} { return &ptypes.UInt64Value{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/eth/v1/beacon/pool/attester_slashings",
+		rpc:       "/ethereum.eth.v1.BeaconChain/SubmitAttesterSlashing",
+		request:   func() interface{} { return &ethpb.AttesterSlashing{} },
+		response:  func() interface{} { return &ptypes.Empty{} },
+		body:      messageBody,
+		decodeSSZ: decodeMessageSSZ,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/pool/proposer_slashings",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListPoolProposerSlashings",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.ProposerSlashingPoolResponse{} },
+		params:   pageParams,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/pool/proposer_slashings/count",
+		rpc:       "/prysm.eth.v1.BeaconChain/CountPoolProposerSlashings",
+		request:   func() interface{} { return &ptypes.Empty{} },
+		response:  func() interface{} { return &ptypes.UInt64Value{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/eth/v1/beacon/pool/proposer_slashings",
+		rpc:       "/ethereum.eth.v1.BeaconChain/SubmitProposerSlashing",
+		request:   func() interface{} { return &ethpb.ProposerSlashing{} },
+		response:  func() interface{} { return &ptypes.Empty{} },
+		body:      messageBody,
+		decodeSSZ: decodeMessageSSZ,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/beacon/pool/voluntary_exits",
+		rpc:      "/ethereum.eth.v1.BeaconChain/ListPoolVoluntaryExits",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.VoluntaryExitsPoolResponse{} },
+		params:   append([]string{"include_expired"}, pageParams...),
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/pool/voluntary_exits/count",
+		rpc:       "/prysm.eth.v1.BeaconChain/CountPoolVoluntaryExits",
+		request:   func() interface{} { return &ptypes.Empty{} },
+		response:  func() interface{} { return &ptypes.UInt64Value{} },
+		jsonCodec: true,
+	},
+	{
+		method:   http.MethodPost,
+		path:     "/eth/v1/beacon/pool/voluntary_exits",
+		rpc:      "/ethereum.eth.v1.BeaconChain/SubmitVoluntaryExit",
+		request:  func() interface{} { return &ethpb.SignedVoluntaryExit{} },
+		response: func() interface{} { return &ptypes.Empty{} },
+		body:     messageBody,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/pool/voluntary_exits/ordered",
+		rpc:       "/prysm.eth.v1.BeaconChain/GetVoluntaryExitsOrdered",
+		request:   func() interface{} { return &beaconv1.VoluntaryExitsOrderedRequest{} },
+		response:  func() interface{} { return &ethpb.VoluntaryExitsPoolResponse{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/pool/voluntary_exits/validators",
+		rpc:       "/prysm.eth.v1.BeaconChain/ListExitingValidators",
+		request:   func() interface{} { return &ptypes.Empty{} },
+		response:  func() interface{} { return &beaconv1.ExitingValidatorsResponse{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/prysm/v1/beacon/pool/voluntary_exits/batch",
+		rpc:       "/prysm.eth.v1.BeaconChain/SubmitVoluntaryExits",
+		request:   func() interface{} { return &beaconv1.SubmitVoluntaryExitsRequest{} },
+		response:  func() interface{} { return &ptypes.Empty{} },
+		body:      dataBody,
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/pool/last_broadcast_times",
+		rpc:       "/prysm.eth.v1.BeaconChain/GetLastBroadcastTimes",
+		request:   func() interface{} { return &ptypes.Empty{} },
+		response:  func() interface{} { return &beaconv1.LastBroadcastTimesResponse{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/attestation_inclusion/{epoch}/{validator_index}",
+		rpc:       "/prysm.eth.v1.BeaconChain/GetAttestationInclusion",
+		request:   func() interface{} { return &beaconv1.AttestationInclusionRequest{} },
+		response:  func() interface{} { return &beaconv1.AttestationInclusionResponse{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/rewards/blocks/{block_id}",
+		rpc:       "/prysm.eth.v1.BeaconChain/GetBlockRewards",
+		request:   func() interface{} { return &ethpb.BlockRequest{} },
+		response:  func() interface{} { return &beaconv1.BlockRewardsResponse{} },
+		jsonCodec: true,
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/prysm/v1/beacon/rewards/attestations/{epoch}",
+		rpc:       "/prysm.eth.v1.BeaconChain/GetAttestationRewards",
+		request:   func() interface{} { return &beaconv1.AttestationRewardsRequest{} },
+		response:  func() interface{} { return &beaconv1.AttestationRewardsResponse{} },
+		jsonCodec: true,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/config/fork_schedule",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetForkSchedule",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.ForkScheduleResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/config/spec",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetSpec",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.SpecResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/config/deposit_contract",
+		rpc:      "/ethereum.eth.v1.BeaconChain/GetDepositContract",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.DepositContractResponse{} },
+	},
+	// BeaconDebug service.
+	{
+		method:    http.MethodGet,
+		path:      "/eth/v1/debug/beacon/states/{state_id}",
+		rpc:       "/ethereum.eth.v1.BeaconDebug/GetBeaconState",
+		request:   func() interface{} { return &ethpb.StateRequest{} },
+		response:  func() interface{} { return &ethpb.BeaconStateResponse{} },
+		encodeSSZ: encodeBeaconStateResponseSSZ,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/debug/beacon/heads",
+		rpc:      "/ethereum.eth.v1.BeaconDebug/ListForkChoiceHeads",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.ForkChoiceHeadsResponse{} },
+	},
+	// BeaconNode service.
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/identity",
+		rpc:      "/ethereum.eth.v1.BeaconNode/GetIdentity",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.IdentityResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/peers/{peer_id}",
+		rpc:      "/ethereum.eth.v1.BeaconNode/GetPeer",
+		request:  func() interface{} { return &ethpb.PeerRequest{} },
+		response: func() interface{} { return &ethpb.PeerResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/peers",
+		rpc:      "/ethereum.eth.v1.BeaconNode/ListPeers",
+		request:  func() interface{} { return &ethpb.PeersRequest{} },
+		response: func() interface{} { return &ethpb.PeersResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/peer_count",
+		rpc:      "/ethereum.eth.v1.BeaconNode/PeerCount",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.PeerCountResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/version",
+		rpc:      "/ethereum.eth.v1.BeaconNode/GetVersion",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.VersionResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/syncing",
+		rpc:      "/ethereum.eth.v1.BeaconNode/GetSyncStatus",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ethpb.SyncingResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/node/health",
+		rpc:      "/ethereum.eth.v1.BeaconNode/GetHealth",
+		request:  func() interface{} { return &ptypes.Empty{} },
+		response: func() interface{} { return &ptypes.Empty{} },
+	},
+	// BeaconValidator service.
+	{
+		method:   http.MethodPost,
+		path:     "/eth/v1/validator/duties/attester/{epoch}",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/GetAttesterDuties",
+		request:  func() interface{} { return &ethpb.AttesterDutiesRequest{} },
+		response: func() interface{} { return &ethpb.AttesterDutiesResponse{} },
+		body:     indexBody,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/validator/duties/attester/{epoch}",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/GetAttesterDuties",
+		request:  func() interface{} { return &ethpb.AttesterDutiesRequest{} },
+		response: func() interface{} { return &ethpb.AttesterDutiesResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/validator/duties/proposer/{epoch}",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/GetProposerDuties",
+		request:  func() interface{} { return &ethpb.ProposerDutiesRequest{} },
+		response: func() interface{} { return &ethpb.ProposerDutiesResponse{} },
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/eth/v1/validator/blocks/{slot}",
+		rpc:       "/ethereum.eth.v1.BeaconValidator/GetBlock",
+		request:   func() interface{} { return &ethpb.ProposerBlockRequest{} },
+		response:  func() interface{} { return &ethpb.ProposerBlockResponse{} },
+		encodeSSZ: encodeProposerBlockResponseSSZ,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/validator/attestation_data",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/GetAttestationData",
+		request:  func() interface{} { return &ethpb.AttestationDataRequest{} },
+		response: func() interface{} { return &ethpb.AttestationDataResponse{} },
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/eth/v1/validator/aggregate_attestation",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/GetAggregateAttestation",
+		request:  func() interface{} { return &ethpb.AggregateAttestationRequest{} },
+		response: func() interface{} { return &ethpb.AttestationResponse{} },
+	},
+	{
+		method:   http.MethodPost,
+		path:     "/eth/v1/validator/aggregate_and_proofs",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/SubmitAggregateAndProofs",
+		request:  func() interface{} { return &ethpb.AggregateAndProofsSubmit{} },
+		response: func() interface{} { return &ptypes.Empty{} },
+		body:     signedDataBody,
+	},
+	{
+		method:   http.MethodPost,
+		path:     "/eth/v1/validator/beacon_committee_subscriptions",
+		rpc:      "/ethereum.eth.v1.BeaconValidator/SubmitBeaconCommitteeSubscription",
+		request:  func() interface{} { return &ethpb.BeaconCommitteeSubscribeSubmit{} },
+		response: func() interface{} { return &ptypes.Empty{} },
+		body:     dataBody,
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/eth/v1/validator/liveness/{epoch}",
+		rpc:       "/prysm.eth.v1.BeaconChain/GetLiveness",
+		request:   func() interface{} { return &beaconv1.LivenessRequest{} },
+		response:  func() interface{} { return &beaconv1.LivenessResponse{} },
+		body:      indexBody,
+		jsonCodec: true,
+	},
+}
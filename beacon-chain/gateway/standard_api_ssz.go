@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+)
+
+// sszMediaType is the media type of SSZ encoded request and response bodies.
+const sszMediaType = "application/octet-stream"
+
+var errNoData = errors.New("response has no data")
+
+// prefersSSZ returns true if the first media type of the Accept header is application/octet-stream.
+func prefersSSZ(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(strings.Split(accept, ",")[0])
+	return err == nil && mediaType == sszMediaType
+}
+
+// isSSZBody returns true if the request body is declared as application/octet-stream.
+func isSSZBody(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == sszMediaType
+}
+
+// encodeBlockResponseSSZ encodes the block of a block response as a SSZ signed beacon block.
+func encodeBlockResponseSSZ(resp interface{}) ([]byte, error) {
+	container := resp.(*ethpb.BlockResponse).Data
+	if container == nil {
+		return nil, errNoData
+	}
+	return (&ethpb.SignedBeaconBlock{Block: container.Message, Signature: container.Signature}).MarshalSSZ()
+}
+
+// decodeBlockContainerSSZ decodes a SSZ signed beacon block into a block container.
+func decodeBlockContainerSSZ(data []byte, req interface{}) error {
+	block := &ethpb.SignedBeaconBlock{}
+	if err := block.UnmarshalSSZ(data); err != nil {
+		return err
+	}
+	container := req.(*ethpb.BeaconBlockContainer)
+	container.Message = block.Block
+	container.Signature = block.Signature
+	return nil
+}
+
+// sszUnmarshaler is implemented by the v1 messages with generated SSZ decoders.
+type sszUnmarshaler interface {
+	UnmarshalSSZ(data []byte) error
+}
+
+// decodeMessageSSZ decodes a SSZ request body into the request message itself, such as a slashing.
+func decodeMessageSSZ(data []byte, req interface{}) error {
+	return req.(sszUnmarshaler).UnmarshalSSZ(data)
+}
+
+// encodeProposerBlockResponseSSZ encodes the produced block of a proposer block response.
+func encodeProposerBlockResponseSSZ(resp interface{}) ([]byte, error) {
+	block := resp.(*ethpb.ProposerBlockResponse).Data
+	if block == nil {
+		return nil, errNoData
+	}
+	return block.MarshalSSZ()
+}
+
+// encodeBeaconStateResponseSSZ encodes the state of a beacon state response. The v1 state carries
+// no SSZ encoding of its own, so it is converted to the beacon node's state proto first.
+func encodeBeaconStateResponseSSZ(resp interface{}) ([]byte, error) {
+	state := resp.(*ethpb.BeaconStateResponse).Data
+	if state == nil {
+		return nil, errNoData
+	}
+	return migration.V1ToPbp2pState(state).MarshalSSZ()
+}
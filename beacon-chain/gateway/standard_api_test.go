@@ -0,0 +1,627 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconv1"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockNodeServer struct {
+	ethpb.UnimplementedBeaconNodeServer
+}
+
+func (*mockNodeServer) GetVersion(_ context.Context, _ *ptypes.Empty) (*ethpb.VersionResponse, error) {
+	return &ethpb.VersionResponse{Data: &ethpb.Version{Version: "Prysm/v1.0.0"}}, nil
+}
+
+func (*mockNodeServer) GetPeer(_ context.Context, req *ethpb.PeerRequest) (*ethpb.PeerResponse, error) {
+	if req.PeerId != "peer" {
+		return nil, status.Errorf(codes.NotFound, "Peer not found: %s", req.PeerId)
+	}
+	return &ethpb.PeerResponse{Data: &ethpb.Peer{PeerId: req.PeerId, State: ethpb.ConnectionState_CONNECTED}}, nil
+}
+
+func (*mockNodeServer) ListPeers(_ context.Context, req *ethpb.PeersRequest) (*ethpb.PeersResponse, error) {
+	resp := &ethpb.PeersResponse{}
+	for _, state := range req.State {
+		resp.Data = append(resp.Data, &ethpb.Peer{PeerId: state})
+	}
+	return resp, nil
+}
+
+func (*mockNodeServer) GetHealth(ctx context.Context, _ *ptypes.Empty) (*ptypes.Empty, error) {
+	if err := grpcutils.SetHTTPCode(ctx, http.StatusPartialContent); err != nil {
+		return nil, err
+	}
+	return &ptypes.Empty{}, nil
+}
+
+type mockChainServer struct {
+	ethpb.UnimplementedBeaconChainServer
+}
+
+func (*mockChainServer) GetGenesis(_ context.Context, _ *ptypes.Empty) (*ethpb.GenesisResponse, error) {
+	return &ethpb.GenesisResponse{Data: &ethpb.GenesisResponse_Genesis{
+		GenesisTime:           &ptypes.Timestamp{Seconds: 1606824023},
+		GenesisValidatorsRoot: []byte{0x4b, 0x36},
+		GenesisForkVersion:    []byte{0, 0, 0, 0},
+	}}, nil
+}
+
+func (*mockChainServer) GetBlock(_ context.Context, _ *ethpb.BlockRequest) (*ethpb.BlockResponse, error) {
+	block := testutil.HydrateV1SignedBeaconBlock(&ethpb.SignedBeaconBlock{})
+	block.Block.Slot = 7
+	return &ethpb.BlockResponse{Data: &ethpb.BeaconBlockContainer{Message: block.Block, Signature: block.Signature}}, nil
+}
+
+func (*mockChainServer) SubmitBlock(_ context.Context, block *ethpb.BeaconBlockContainer) (*ptypes.Empty, error) {
+	if block.Message == nil || block.Message.Slot != 7 {
+		return nil, status.Error(codes.InvalidArgument, "Unexpected block")
+	}
+	return &ptypes.Empty{}, nil
+}
+
+func (*mockChainServer) SubmitVoluntaryExit(_ context.Context, exit *ethpb.SignedVoluntaryExit) (*ptypes.Empty, error) {
+	if exit.Exit == nil || exit.Exit.ValidatorIndex != 5 {
+		return nil, status.Error(codes.InvalidArgument, "Unexpected exit")
+	}
+	return &ptypes.Empty{}, nil
+}
+
+func (*mockChainServer) SubmitProposerSlashing(_ context.Context, slashing *ethpb.ProposerSlashing) (*ptypes.Empty, error) {
+	if slashing.Header_1 == nil || slashing.Header_1.Header.ProposerIndex != 3 {
+		return nil, status.Error(codes.InvalidArgument, "Unexpected slashing")
+	}
+	return &ptypes.Empty{}, nil
+}
+
+// mockPrysmChainServer serves the Prysm specific beacon chain methods with the JSON codec.
+type mockPrysmChainServer struct{}
+
+func (*mockPrysmChainServer) SubmitAttestations(ctx context.Context, req *beaconv1.SubmitAttestationsRequest) (*ptypes.Empty, error) {
+	var failures []*grpcutils.IndexedFailure
+	for i, att := range req.Data {
+		if att.Data == nil || att.Data.Slot > 10 {
+			failures = append(failures, &grpcutils.IndexedFailure{Index: uint64(i), Message: "Invalid attestation slot"})
+		}
+	}
+	return mockBatchResponse(ctx, "attestations", failures)
+}
+
+func (*mockPrysmChainServer) SubmitVoluntaryExits(ctx context.Context, req *beaconv1.SubmitVoluntaryExitsRequest) (*ptypes.Empty, error) {
+	var failures []*grpcutils.IndexedFailure
+	for i, exit := range req.Data {
+		if exit.Exit == nil || exit.Exit.ValidatorIndex != 5 {
+			failures = append(failures, &grpcutils.IndexedFailure{Index: uint64(i), Message: "Unexpected exit"})
+		}
+	}
+	return mockBatchResponse(ctx, "voluntary exits", failures)
+}
+
+func (*mockPrysmChainServer) GetAttestationRewards(_ context.Context, req *beaconv1.AttestationRewardsRequest) (*beaconv1.AttestationRewardsResponse, error) {
+	resp := &beaconv1.AttestationRewardsResponse{Epoch: req.Epoch}
+	for _, id := range req.Id {
+		idx, err := strconv.ParseUint(string(id), 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator ID: %s", id)
+		}
+		resp.Data = append(resp.Data, &beaconv1.AttestationRewards{ValidatorIndex: types.ValidatorIndex(idx), Head: -1})
+	}
+	return resp, nil
+}
+
+func (*mockPrysmChainServer) CountPoolVoluntaryExits(_ context.Context, _ *ptypes.Empty) (*ptypes.UInt64Value, error) {
+	return &ptypes.UInt64Value{Value: 3}, nil
+}
+
+func (*mockPrysmChainServer) ListExitingValidators(_ context.Context, _ *ptypes.Empty) (*beaconv1.ExitingValidatorsResponse, error) {
+	return &beaconv1.ExitingValidatorsResponse{Data: []types.ValidatorIndex{1, 4}}, nil
+}
+
+func (*mockPrysmChainServer) GetVoluntaryExitsOrdered(_ context.Context, req *beaconv1.VoluntaryExitsOrderedRequest) (*ethpb.VoluntaryExitsPoolResponse, error) {
+	return &ethpb.VoluntaryExitsPoolResponse{
+		Data: []*ethpb.SignedVoluntaryExit{
+			{Exit: &ethpb.VoluntaryExit{ValidatorIndex: types.ValidatorIndex(req.Ordering)}},
+		},
+	}, nil
+}
+
+func (*mockPrysmChainServer) GetLastBroadcastTimes(_ context.Context, _ *ptypes.Empty) (*beaconv1.LastBroadcastTimesResponse, error) {
+	return &beaconv1.LastBroadcastTimesResponse{VoluntaryExit: 1606824023}, nil
+}
+
+func (*mockPrysmChainServer) GetLiveness(_ context.Context, req *beaconv1.LivenessRequest) (*beaconv1.LivenessResponse, error) {
+	resp := &beaconv1.LivenessResponse{}
+	for _, idx := range req.Index {
+		resp.Data = append(resp.Data, &beaconv1.ValidatorLiveness{Index: idx, Epoch: req.Epoch, IsLive: idx%2 == 1})
+	}
+	return resp, nil
+}
+
+func (*mockPrysmChainServer) GetAttestationInclusion(_ context.Context, req *beaconv1.AttestationInclusionRequest) (*beaconv1.AttestationInclusionResponse, error) {
+	return &beaconv1.AttestationInclusionResponse{
+		Included:        true,
+		AttestationSlot: types.Slot(req.Epoch) * 32,
+		BlockRoot:       []byte{0xab},
+		InclusionSlot:   types.Slot(req.Epoch)*32 + types.Slot(req.ValidatorIndex),
+		InclusionDelay:  types.Slot(req.ValidatorIndex),
+	}, nil
+}
+
+func mockBatchResponse(ctx context.Context, items string, failures []*grpcutils.IndexedFailure) (*ptypes.Empty, error) {
+	if len(failures) > 0 {
+		if err := grpcutils.SetFailures(ctx, failures); err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "One or more %s failed to be processed", items)
+	}
+	return &ptypes.Empty{}, nil
+}
+
+var mockPrysmChainServiceDesc = grpc.ServiceDesc{
+	ServiceName: beaconv1.PrysmBeaconChainServiceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CountPoolVoluntaryExits",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &ptypes.Empty{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).CountPoolVoluntaryExits(ctx, req)
+			},
+		},
+		{
+			MethodName: "ListExitingValidators",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &ptypes.Empty{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).ListExitingValidators(ctx, req)
+			},
+		},
+		{
+			MethodName: "GetVoluntaryExitsOrdered",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &beaconv1.VoluntaryExitsOrderedRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).GetVoluntaryExitsOrdered(ctx, req)
+			},
+		},
+		{
+			MethodName: "GetLiveness",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &beaconv1.LivenessRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).GetLiveness(ctx, req)
+			},
+		},
+		{
+			MethodName: "GetLastBroadcastTimes",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &ptypes.Empty{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).GetLastBroadcastTimes(ctx, req)
+			},
+		},
+		{
+			MethodName: "GetAttestationInclusion",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &beaconv1.AttestationInclusionRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).GetAttestationInclusion(ctx, req)
+			},
+		},
+		{
+			MethodName: "GetAttestationRewards",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &beaconv1.AttestationRewardsRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).GetAttestationRewards(ctx, req)
+			},
+		},
+		{
+			MethodName: "SubmitAttestations",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &beaconv1.SubmitAttestationsRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).SubmitAttestations(ctx, req)
+			},
+		},
+		{
+			MethodName: "SubmitVoluntaryExits",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &beaconv1.SubmitVoluntaryExitsRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(*mockPrysmChainServer).SubmitVoluntaryExits(ctx, req)
+			},
+		},
+	},
+}
+
+func (*mockChainServer) ListPoolVoluntaryExits(ctx context.Context, _ *ptypes.Empty) (*ethpb.VoluntaryExitsPoolResponse, error) {
+	pageToken, ok := grpcutils.RequestParam(ctx, "page_token")
+	if !ok {
+		return &ethpb.VoluntaryExitsPoolResponse{}, nil
+	}
+	if err := grpcutils.SetResponseField(ctx, "next_page_token", pageToken+"1"); err != nil {
+		return nil, err
+	}
+	return &ethpb.VoluntaryExitsPoolResponse{}, nil
+}
+
+func (*mockChainServer) ListCommittees(ctx context.Context, req *ethpb.StateCommitteesRequest) (*ethpb.StateCommitteesResponse, error) {
+	committee := &ethpb.Committee{Index: req.Index, Slot: req.Slot}
+	if _, ok := grpcutils.RequestParam(ctx, "index"); ok {
+		committee.Validators = append(committee.Validators, 1)
+	}
+	if epoch, ok := grpcutils.RequestParam(ctx, "epoch"); ok && epoch == fmt.Sprint(req.Epoch) {
+		committee.Validators = append(committee.Validators, 2)
+	}
+	return &ethpb.StateCommitteesResponse{Data: []*ethpb.Committee{committee}}, nil
+}
+
+type mockValidatorServer struct {
+	ethpb.UnimplementedBeaconValidatorServer
+}
+
+func (*mockValidatorServer) GetAttesterDuties(ctx context.Context, req *ethpb.AttesterDutiesRequest) (*ethpb.AttesterDutiesResponse, error) {
+	if err := grpcutils.SetResponseField(ctx, "dependent_root", "0xcf8e"); err != nil {
+		return nil, err
+	}
+	resp := &ethpb.AttesterDutiesResponse{}
+	for _, index := range req.Index {
+		resp.Data = append(resp.Data, &ethpb.AttesterDuty{ValidatorIndex: index, Slot: types.Slot(req.Epoch) * 32})
+	}
+	return resp, nil
+}
+
+func (*mockValidatorServer) SubmitAggregateAndProofs(ctx context.Context, req *ethpb.AggregateAndProofsSubmit) (*ptypes.Empty, error) {
+	signatures := grpcutils.Signatures(ctx)
+	if len(signatures) != len(req.Data) {
+		return nil, status.Error(codes.InvalidArgument, "Missing signatures")
+	}
+	for i, item := range req.Data {
+		if signatures[i] != fmt.Sprintf("0x%02x", item.AggregatorIndex) {
+			return nil, status.Errorf(codes.InvalidArgument, "Unexpected signature %s", signatures[i])
+		}
+	}
+	return &ptypes.Empty{}, nil
+}
+
+func setupStandardAPI(t *testing.T) *httptest.Server {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	ethpb.RegisterBeaconNodeServer(server, &mockNodeServer{})
+	ethpb.RegisterBeaconChainServer(server, &mockChainServer{})
+	ethpb.RegisterBeaconValidatorServer(server, &mockValidatorServer{})
+	server.RegisterService(&mockPrysmChainServiceDesc, &mockPrysmChainServer{})
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	srv := httptest.NewServer(newStandardAPIHandler(conn))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func doRequest(t *testing.T, method, url, body string, headers ...string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(respBody)
+}
+
+func TestStandardAPI_Get(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/version", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":{"version":"Prysm/v1.0.0"}}`, body)
+
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/health", "")
+	assert.Equal(t, http.StatusPartialContent, code)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/genesis", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x4b36","genesis_fork_version":"0x00000000"}}`, body)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/peers/peer", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, strings.Contains(body, `"peer_id":"peer"`), body)
+	assert.Equal(t, true, strings.Contains(body, `"state":"connected"`), body)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/peers?state=connected,disconnected&state=connecting", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, strings.Contains(body, `"peer_id":"connected"`), body)
+	assert.Equal(t, true, strings.Contains(body, `"peer_id":"disconnected"`), body)
+	assert.Equal(t, true, strings.Contains(body, `"peer_id":"connecting"`), body)
+}
+
+func TestStandardAPI_Errors(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/peers/unknown", "")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, `{"code":404,"message":"Peer not found: unknown"}`, body)
+
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/unknown", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/node/version", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/node/identity", "")
+	assert.Equal(t, http.StatusNotImplemented, code)
+
+	code, body = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/voluntary_exits", `{"message":{"epoch":"x"}}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, true, strings.Contains(body, "message.epoch"), body)
+}
+
+func TestStandardAPI_SubmitBody(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/voluntary_exits",
+		`{"message":{"epoch":"1","validator_index":"5"},"signature":"0x01"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "", body)
+}
+
+func TestStandardAPI_IndexBody(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/duties/attester/2", `["3","5"]`)
+	assert.Equal(t, http.StatusOK, code)
+	resp := &struct {
+		DependentRoot string `json:"dependent_root"`
+		Data          []struct {
+			ValidatorIndex string `json:"validator_index"`
+			Slot           string `json:"slot"`
+		} `json:"data"`
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(body), resp), body)
+	assert.Equal(t, "0xcf8e", resp.DependentRoot)
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, "3", resp.Data[0].ValidatorIndex)
+	assert.Equal(t, "64", resp.Data[0].Slot)
+	assert.Equal(t, "5", resp.Data[1].ValidatorIndex)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/duties/attester/2", `{"index":["3"]}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestStandardAPI_SignedDataBody(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/aggregate_and_proofs",
+		`[{"message":{"aggregator_index":"1"},"signature":"0x01"},{"message":{"aggregator_index":"2"},"signature":"0x02"}]`)
+	assert.Equal(t, http.StatusOK, code, body)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/aggregate_and_proofs",
+		`[{"message":{"aggregator_index":"1"},"signature":"0x02"}]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/aggregate_and_proofs", `[{"signature":"0x01"}]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestStandardAPI_SubmitBatch(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, _ := doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/attestations", `[{"data":{"slot":"1"}},{"data":{"slot":"2"}}]`)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, body := doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/attestations",
+		`[{"data":{"slot":"1"}},{"data":{"slot":"11"}},{"data":{"slot":"12"}}]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	resp := &specError{}
+	require.NoError(t, json.Unmarshal([]byte(body), resp))
+	require.Equal(t, 2, len(resp.Failures))
+	assert.Equal(t, uint64(1), resp.Failures[0].Index)
+	assert.Equal(t, "Invalid attestation slot", resp.Failures[0].Message)
+	assert.Equal(t, uint64(2), resp.Failures[1].Index)
+
+	code, body = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/attestations", `[{"data":{"slot":"bad"}}]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, true, strings.Contains(body, "data[0].data.slot"), body)
+}
+
+func TestStandardAPI_JSONCodec(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodPost, srv.URL+"/prysm/v1/beacon/pool/voluntary_exits/batch",
+		`[{"message":{"epoch":"1","validator_index":"5"},"signature":"0x01"}]`)
+	assert.Equal(t, http.StatusOK, code, body)
+
+	code, body = doRequest(t, http.MethodPost, srv.URL+"/prysm/v1/beacon/pool/voluntary_exits/batch",
+		`[{"message":{"epoch":"1","validator_index":"5"},"signature":"0x01"},{"message":{"epoch":"1","validator_index":"6"},"signature":"0x01"}]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	resp := &specError{}
+	require.NoError(t, json.Unmarshal([]byte(body), resp))
+	assert.Equal(t, "One or more voluntary exits failed to be processed", resp.Message)
+	require.Equal(t, 1, len(resp.Failures))
+	assert.Equal(t, uint64(1), resp.Failures[0].Index)
+	assert.Equal(t, "Unexpected exit", resp.Failures[0].Message)
+}
+
+func TestStandardAPI_RequestParams(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/pool/voluntary_exits?page_token=2", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":[],"next_page_token":"21"}`, body)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/pool/voluntary_exits", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":[]}`, body)
+
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/pool/voluntary_exits?page_token=%0A", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Path parameters and explicit zero filters are passed as well.
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/states/head/committees/0?index=0", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, strings.Contains(body, `"validators":["1","2"]`), body)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/states/head/committees", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, strings.Contains(body, `"validators":[]`), body)
+}
+
+func TestStandardAPI_JSONCodecParams(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/rewards/attestations/3?id=1,2", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"epoch":"3","data":[`+
+		`{"validator_index":"1","head":"-1","target":"0","source":"0","inclusion_delay":"0","inactivity":"0"},`+
+		`{"validator_index":"2","head":"-1","target":"0","source":"0","inclusion_delay":"0","inactivity":"0"}]}`, body)
+
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/rewards/attestations/3?id=0xab", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/pool/voluntary_exits/count", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"value":"3"}`, body)
+	// The Prysm specific paths are not served under the standard namespace.
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/pool/voluntary_exits/count", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// The ordering is selected by name and echoed back as the validator index of the exit.
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/pool/voluntary_exits/ordered?ordering=earliest-submitted", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":[{"message":{"epoch":"0","validator_index":"2"},"signature":"0x"}]}`, body)
+
+	code, _ = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/pool/voluntary_exits/ordered?ordering=random", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/pool/voluntary_exits/validators", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":["1","4"]}`, body)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/pool/last_broadcast_times", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"attestation":"0","attester_slashing":"0","proposer_slashing":"0","voluntary_exit":"1606824023"}`, body)
+
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/prysm/v1/beacon/attestation_inclusion/2/5", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"included":true,"attestation_slot":"64","block_root":"0xab","inclusion_slot":"69","inclusion_delay":"5"}`, body)
+
+	code, body = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/liveness/7", `["1","2"]`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"data":[{"index":"1","epoch":"7","is_live":true},{"index":"2","epoch":"7","is_live":false}]}`, body)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/validator/liveness/7", `["bar"]`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestStandardAPI_SSZ(t *testing.T) {
+	srv := setupStandardAPI(t)
+
+	code, body := doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/blocks/head", "", "Accept", "application/octet-stream")
+	require.Equal(t, http.StatusOK, code)
+	block := &ethpb.SignedBeaconBlock{}
+	require.NoError(t, block.UnmarshalSSZ([]byte(body)))
+	assert.Equal(t, types.Slot(7), block.Block.Slot)
+
+	// JSON remains the default.
+	code, body = doRequest(t, http.MethodGet, srv.URL+"/eth/v1/beacon/blocks/head", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, strings.Contains(body, `"slot":"7"`), body)
+
+	enc, err := block.MarshalSSZ()
+	require.NoError(t, err)
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/blocks", string(enc), "Content-Type", "application/octet-stream")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/blocks", "corrupt", "Content-Type", "application/octet-stream")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{ProposerIndex: 3, ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), BodyRoot: make([]byte, 32)},
+			Signature: make([]byte, 96),
+		},
+		Header_2: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{ProposerIndex: 3, ParentRoot: make([]byte, 32), StateRoot: make([]byte, 32), BodyRoot: make([]byte, 32)},
+			Signature: make([]byte, 96),
+		},
+	}
+	enc, err = slashing.MarshalSSZ()
+	require.NoError(t, err)
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/proposer_slashings", string(enc), "Content-Type", "application/octet-stream")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/proposer_slashings", string(enc[1:]), "Content-Type", "application/octet-stream")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, http.MethodPost, srv.URL+"/eth/v1/beacon/pool/voluntary_exits", string(enc), "Content-Type", "application/octet-stream")
+	assert.Equal(t, http.StatusUnsupportedMediaType, code)
+}
+
+func TestMatchPath(t *testing.T) {
+	h := newStandardAPIHandler(nil)
+	route, params, _ := h.match(http.MethodGet, "/eth/v1/beacon/states/head/validators/12")
+	require.NotNil(t, route)
+	assert.Equal(t, "/ethereum.eth.v1.BeaconChain/GetValidator", route.rpc)
+	assert.Equal(t, "head", params["state_id"])
+	assert.Equal(t, "12", params["validator_id"])
+
+	route, _, pathMatched := h.match(http.MethodDelete, "/eth/v1/beacon/pool/attestations")
+	assert.Equal(t, true, route == nil)
+	assert.Equal(t, true, pathMatched)
+}
@@ -0,0 +1,94 @@
+package interopcoldstart
+
+import (
+	"github.com/pkg/errors"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	genesisNumValidatorsFlag = &cli.Uint64Flag{
+		Name:  "num-validators",
+		Usage: "The number of genesis validators",
+	}
+	genesisTimeFlag = &cli.Uint64Flag{
+		Name:  "genesis-time",
+		Usage: "The unix timestamp of the genesis, defaults to now",
+	}
+	genesisMnemonicFlag = &cli.StringFlag{
+		Name: "mnemonic",
+		Usage: "A mnemonic to derive the validator keys from, along the paths of a derived wallet. " +
+			"The interop deterministic keys are used if not set",
+	}
+	genesisOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "The path to write the SSZ encoded genesis state to",
+		Value: "genesis.ssz",
+	}
+)
+
+// GenesisCommand generates the genesis state of a local devnet, which can then be passed to every
+// client of the devnet, e.g. with --interop-genesis-state for Prysm.
+var GenesisCommand = &cli.Command{
+	Name:     "genesis",
+	Category: "interop",
+	Usage:    "generates a genesis.ssz from interop deterministic keys or a mnemonic, for local devnets",
+	Flags: cmd.WrapFlags([]cli.Flag{
+		genesisNumValidatorsFlag,
+		genesisTimeFlag,
+		genesisMnemonicFlag,
+		genesisOutputFlag,
+		cmd.ChainConfigFileFlag,
+		cmd.MinimalConfigFlag,
+	}),
+	Action: func(cliCtx *cli.Context) error {
+		if err := generateGenesis(cliCtx); err != nil {
+			return errors.Wrap(err, "could not generate genesis state")
+		}
+		return nil
+	},
+}
+
+func generateGenesis(cliCtx *cli.Context) error {
+	numValidators := cliCtx.Uint64(genesisNumValidatorsFlag.Name)
+	if numValidators == 0 {
+		return errors.New("--num-validators must be greater than 0")
+	}
+	if cliCtx.Bool(cmd.MinimalConfigFlag.Name) {
+		params.UseMinimalConfig()
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name))
+	}
+	genesisTime := cliCtx.Uint64(genesisTimeFlag.Name)
+
+	var genesisState *pb.BeaconState
+	var err error
+	if mnemonic := cliCtx.String(genesisMnemonicFlag.Name); mnemonic != "" {
+		genesisState, _, err = interop.GenerateGenesisStateFromMnemonic(genesisTime, mnemonic, numValidators)
+	} else {
+		genesisState, _, err = interop.GenerateGenesisState(genesisTime, numValidators)
+	}
+	if err != nil {
+		return err
+	}
+	enc, err := genesisState.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal genesis state")
+	}
+	output := cliCtx.String(genesisOutputFlag.Name)
+	if err := fileutil.WriteFile(output, enc); err != nil {
+		return errors.Wrap(err, "could not write genesis state")
+	}
+	log.WithFields(logrus.Fields{
+		"numValidators": numValidators,
+		"genesisTime":   genesisState.GenesisTime,
+		"output":        output,
+	}).Info("Generated genesis state")
+	return nil
+}
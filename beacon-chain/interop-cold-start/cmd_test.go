@@ -0,0 +1,43 @@
+package interopcoldstart
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestGenerateGenesis(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "genesis.ssz")
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Uint64(genesisNumValidatorsFlag.Name, 0, "")
+	set.Uint64(genesisTimeFlag.Name, 0, "")
+	set.String(genesisMnemonicFlag.Name, "", "")
+	set.String(genesisOutputFlag.Name, "", "")
+	cliCtx := cli.NewContext(&app, set, nil)
+	assert.ErrorContains(t, "--num-validators must be greater than 0", generateGenesis(cliCtx))
+
+	require.NoError(t, set.Set(genesisNumValidatorsFlag.Name, "16"))
+	require.NoError(t, set.Set(genesisTimeFlag.Name, "1606824000"))
+	require.NoError(t, set.Set(genesisOutputFlag.Name, output))
+	require.NoError(t, generateGenesis(cliCtx))
+
+	enc, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+	genesisState := &pb.BeaconState{}
+	require.NoError(t, genesisState.UnmarshalSSZ(enc))
+	assert.Equal(t, uint64(1606824000), genesisState.GenesisTime)
+	assert.Equal(t, 16, len(genesisState.Validators))
+	wanted, _, err := interop.GenerateGenesisState(1606824000, 16)
+	require.NoError(t, err)
+	wantedEnc, err := wanted.MarshalSSZ()
+	require.NoError(t, err)
+	assert.DeepEqual(t, wantedEnc, enc)
+}
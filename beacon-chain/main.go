@@ -13,7 +13,9 @@ import (
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/interop-cold-start"
 	"github.com/prysmaticlabs/prysm/beacon-chain/node"
+	rpcdebug "github.com/prysmaticlabs/prysm/beacon-chain/rpc/debug"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
@@ -36,10 +38,19 @@ var appFlags = []cli.Flag{
 	flags.RPCPort,
 	flags.CertFlag,
 	flags.KeyFlag,
+	flags.ClientCAFlag,
 	flags.DisableGRPCGateway,
 	flags.GRPCGatewayHost,
 	flags.GRPCGatewayPort,
 	flags.GPRCGatewayCorsDomain,
+	flags.GRPCGatewayMaxBodySize,
+	flags.GRPCGatewayRequestTimeout,
+	flags.GRPCGatewayClientCertFlag,
+	flags.GRPCGatewayClientKeyFlag,
+	flags.GRPCGatewayAuthTokenFile,
+	flags.GRPCGatewayJWTSecretFile,
+	flags.GRPCGatewayAuthExemptPaths,
+	flags.EnableAdminEndpoints,
 	flags.MinSyncPeers,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
@@ -52,14 +63,40 @@ var appFlags = []cli.Flag{
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
+	flags.DevModeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.DatabaseBackend,
 	flags.EnableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
+	flags.SlasherFlag,
+	flags.MonitorIndicesFlag,
+	flags.ArchiveAttestationInclusionsFlag,
+	flags.GossipSlashingDetectionFlag,
+	flags.GossipSlashingDetectionEpochsFlag,
 	flags.ChainID,
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpt,
 	flags.Eth1HeaderReqLimit,
+	flags.SlashingWebhookURL,
+	flags.RPCMaxBatchSize,
+	flags.RPCRateLimit,
+	flags.RPCRateLimitBurst,
+	flags.RPCMaxConcurrentRequests,
+	flags.RPCRateLimitOverrides,
+	flags.ExitInclusionOrdering,
+	flags.MaxPoolAggregatedAttestations,
+	flags.MaxPoolUnaggregatedAttestations,
+	flags.MaxPoolSlashings,
+	flags.MaxPoolVoluntaryExits,
+	flags.SlashingReplayCacheSize,
+	flags.SlashingReplayCacheTTL,
+	flags.CommitteeCacheSize,
+	flags.VerifyExitsAgainstPool,
+	flags.CheckpointSyncURL,
+	flags.CheckpointGenesisState,
+	flags.CheckpointState,
+	flags.CheckpointBlock,
 	cmd.EnableBackupWebhookFlag,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
@@ -81,6 +118,7 @@ var appFlags = []cli.Flag{
 	cmd.P2PDenyList,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
+	cmd.ModuleLogLevelsFlag,
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
 	cmd.TracingEndpointFlag,
@@ -101,6 +139,9 @@ var appFlags = []cli.Flag{
 	debug.BlockProfileRateFlag,
 	debug.MutexProfileFractionFlag,
 	cmd.LogFileName,
+	cmd.LogMaxSize,
+	cmd.LogMaxAge,
+	cmd.LogMaxBackups,
 	cmd.EnableUPnPFlag,
 	cmd.ConfigFileFlag,
 	cmd.ChainConfigFileFlag,
@@ -109,6 +150,8 @@ var appFlags = []cli.Flag{
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.BoltMMapInitialSizeFlag,
+	cmd.BoltFreelistTypeFlag,
+	cmd.BoltNoFreelistSyncFlag,
 }
 
 func init() {
@@ -123,6 +166,8 @@ func main() {
 	app.Version = version.Version()
 	app.Commands = []*cli.Command{
 		db.DatabaseCommands,
+		rpcdebug.DumpForkChoiceCommand,
+		interopcoldstart.GenesisCommand,
 	}
 
 	app.Flags = appFlags
@@ -161,7 +206,12 @@ func main() {
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
-			if err := logutil.ConfigurePersistentLogging(logFileName); err != nil {
+			rotation := logutil.Rotation{
+				MaxSize:    int64(ctx.Int(cmd.LogMaxSize.Name)) << 20,
+				MaxAge:     ctx.Duration(cmd.LogMaxAge.Name),
+				MaxBackups: ctx.Int(cmd.LogMaxBackups.Name),
+			}
+			if err := logutil.ConfigureRotatingLogging(logFileName, rotation); err != nil {
 				log.WithError(err).Error("Failed to configuring logging to disk.")
 			}
 		}
@@ -208,7 +258,11 @@ func startNode(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	logrus.SetLevel(level)
+	moduleLevels, err := logutil.ParseModuleLevels(ctx.String(cmd.ModuleLogLevelsFlag.Name))
+	if err != nil {
+		return err
+	}
+	logutil.ConfigureLevels(level, moduleLevels)
 	if level == logrus.TraceLevel {
 		// libp2p specific logging.
 		golog.SetAllLoggers(golog.LevelDebug)
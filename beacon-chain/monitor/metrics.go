@@ -0,0 +1,51 @@
+package monitor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	balanceGwei = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "monitor_balance_gwei",
+			Help: "The balance of the tracked validator at the start of the epoch.",
+		},
+		[]string{"validator_index"},
+	)
+	balanceDeltaGwei = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "monitor_balance_delta_gwei",
+			Help: "The change of balance of the tracked validator over the last epoch.",
+		},
+		[]string{"validator_index"},
+	)
+	attestationInclusionDistance = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "monitor_attestation_inclusion_distance_slots",
+			Help: "The number of slots between the last attestation of the tracked validator and its inclusion in a block.",
+		},
+		[]string{"validator_index"},
+	)
+	includedAttestations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "monitor_included_attestations_total",
+			Help: "The number of attestations of the tracked validator included in processed blocks.",
+		},
+		[]string{"validator_index"},
+	)
+	missedAttestations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "monitor_missed_attestations_total",
+			Help: "The number of epochs in which no attestation of the active tracked validator was included.",
+		},
+		[]string{"validator_index"},
+	)
+	proposedBlocks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "monitor_proposed_blocks_total",
+			Help: "The number of processed blocks proposed by the tracked validator.",
+		},
+		[]string{"validator_index"},
+	)
+)
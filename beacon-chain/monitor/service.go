@@ -0,0 +1,244 @@
+// Package monitor tracks the duties of a set of validators chosen by the operator from the blocks
+// processed by the beacon node. It logs and exports as metrics the proposals, attestation
+// inclusions and misses, and balance changes of the tracked validators, without requiring a
+// validator client or external tooling. Sync committee participation is not tracked, as there are
+// no sync committees before the Altair fork.
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/sirupsen/logrus"
+)
+
+var _ shared.Service = (*Service)(nil)
+
+// Config to set up the validator monitoring service.
+type Config struct {
+	TrackedIndices []types.ValidatorIndex
+	StateNotifier  statefeed.Notifier
+	HeadFetcher    blockchain.HeadFetcher
+}
+
+// Service monitors the tracked validators from the processed blocks.
+type Service struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	cfg          *Config
+	tracked      map[types.ValidatorIndex]bool
+	stateChannel chan *feed.Event
+	stateSub     event.Subscription
+
+	started bool
+	// lastEpoch is the epoch of the last processed block.
+	lastEpoch types.Epoch
+	// missCheckEpoch is the next epoch to check for missed attestations. The attestations of the
+	// epoch of the first processed block, and earlier, may have been included in blocks which were
+	// not monitored.
+	missCheckEpoch types.Epoch
+	// included holds the tracked validators with an included attestation, by target epoch.
+	included map[types.Epoch]map[types.ValidatorIndex]bool
+	// balances are the balances of the tracked validators at the start of the last epoch.
+	balances map[types.ValidatorIndex]uint64
+}
+
+// NewService configures the validator monitoring service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	tracked := make(map[types.ValidatorIndex]bool, len(cfg.TrackedIndices))
+	for _, idx := range cfg.TrackedIndices {
+		tracked[idx] = true
+	}
+	return &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		cfg:          cfg,
+		tracked:      tracked,
+		stateChannel: make(chan *feed.Event, 1),
+		included:     make(map[types.Epoch]map[types.ValidatorIndex]bool),
+		balances:     make(map[types.ValidatorIndex]uint64),
+	}
+}
+
+// Start monitoring the processed blocks.
+func (s *Service) Start() {
+	log.WithField("validatorIndices", s.cfg.TrackedIndices).Info("Monitoring validators")
+	s.stateSub = s.cfg.StateNotifier.StateFeed().Subscribe(s.stateChannel)
+	go s.run()
+}
+
+// Stop monitoring.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status always returns nil.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	defer s.stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-s.stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.BlockProcessedData)
+			if !ok {
+				log.Error("Event feed data is not type *statefeed.BlockProcessedData")
+				continue
+			}
+			headState, err := s.cfg.HeadFetcher.HeadState(s.ctx)
+			if err != nil || headState == nil {
+				log.WithError(err).Debug("Could not get head state")
+				continue
+			}
+			s.processBlock(headState, data.SignedBlock)
+		case err := <-s.stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// processBlock records the proposal and the attestations of the tracked validators in a processed
+// block, and reports the tracked validators' last epoch once the block starts a new one. The
+// committees of the attestations are computed from the head state.
+func (s *Service) processBlock(headState *state.BeaconState, blk *ethpb.SignedBeaconBlock) {
+	if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+		return
+	}
+	b := blk.Block
+	epoch := helpers.SlotToEpoch(b.Slot)
+	if !s.started {
+		s.started = true
+		s.lastEpoch = epoch
+		s.missCheckEpoch = epoch + 1
+		s.updateBalances(headState, epoch)
+	} else if epoch > s.lastEpoch {
+		s.processEpoch(headState, epoch)
+	}
+
+	if s.tracked[b.ProposerIndex] {
+		proposedBlocks.WithLabelValues(indexLabel(b.ProposerIndex)).Inc()
+		log.WithFields(logrus.Fields{
+			"validatorIndex": b.ProposerIndex,
+			"slot":           b.Slot,
+		}).Info("Tracked validator proposed a block")
+	}
+
+	for _, att := range b.Body.Attestations {
+		if att == nil || att.Data == nil || att.Data.Target == nil {
+			continue
+		}
+		committee, err := helpers.BeaconCommitteeFromState(headState, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			log.WithError(err).Debug("Could not get attestation committee")
+			continue
+		}
+		attesting, err := attestationutil.AttestingIndices(att.AggregationBits, committee)
+		if err != nil {
+			log.WithError(err).Debug("Could not get attesting indices")
+			continue
+		}
+		for _, i := range attesting {
+			idx := types.ValidatorIndex(i)
+			if !s.tracked[idx] {
+				continue
+			}
+			target := att.Data.Target.Epoch
+			if s.included[target] == nil {
+				s.included[target] = make(map[types.ValidatorIndex]bool)
+			}
+			// Only the first inclusion of an attestation counts, the later ones are redundant.
+			if s.included[target][idx] {
+				continue
+			}
+			s.included[target][idx] = true
+			distance := b.Slot - att.Data.Slot
+			includedAttestations.WithLabelValues(indexLabel(idx)).Inc()
+			attestationInclusionDistance.WithLabelValues(indexLabel(idx)).Set(float64(distance))
+			log.WithFields(logrus.Fields{
+				"validatorIndex":    idx,
+				"attestationSlot":   att.Data.Slot,
+				"inclusionSlot":     b.Slot,
+				"inclusionDistance": distance,
+			}).Info("Tracked validator attestation included")
+		}
+	}
+}
+
+// processEpoch reports the balance changes of the tracked validators over the epochs ended before
+// epoch, and the attestations missed in the epochs whose inclusion window ended.
+func (s *Service) processEpoch(headState *state.BeaconState, epoch types.Epoch) {
+	// An attestation can be included up to an epoch after its own epoch.
+	for ; s.missCheckEpoch+1 < epoch; s.missCheckEpoch++ {
+		e := s.missCheckEpoch
+		for idx := range s.tracked {
+			if s.included[e][idx] {
+				continue
+			}
+			v, err := headState.ValidatorAtIndexReadOnly(idx)
+			if err != nil || !helpers.IsActiveValidatorUsingTrie(v, e) {
+				continue
+			}
+			missedAttestations.WithLabelValues(indexLabel(idx)).Inc()
+			log.WithFields(logrus.Fields{
+				"validatorIndex": idx,
+				"epoch":          e,
+			}).Warn("Tracked validator missed an attestation")
+		}
+	}
+	for e := range s.included {
+		if e+1 < epoch {
+			delete(s.included, e)
+		}
+	}
+	s.updateBalances(headState, epoch)
+	s.lastEpoch = epoch
+}
+
+// updateBalances records the balances of the tracked validators at the start of epoch.
+func (s *Service) updateBalances(headState *state.BeaconState, epoch types.Epoch) {
+	for idx := range s.tracked {
+		balance, err := headState.BalanceAtIndex(idx)
+		if err != nil {
+			// The validator is not known yet.
+			continue
+		}
+		balanceGwei.WithLabelValues(indexLabel(idx)).Set(float64(balance))
+		prev, ok := s.balances[idx]
+		s.balances[idx] = balance
+		if !ok {
+			continue
+		}
+		delta := int64(balance) - int64(prev)
+		balanceDeltaGwei.WithLabelValues(indexLabel(idx)).Set(float64(delta))
+		log.WithFields(logrus.Fields{
+			"validatorIndex": idx,
+			"epoch":          epoch,
+			"balance":        balance,
+			"balanceChange":  delta,
+		}).Info("Tracked validator balance")
+	}
+}
+
+func indexLabel(idx types.ValidatorIndex) string {
+	return fmt.Sprintf("%d", idx)
+}
@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_ProcessBlock(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 1)
+	attester, absent := committee[0], committee[1]
+	chain := &mock.ChainService{State: beaconState}
+	s := NewService(context.Background(), &Config{
+		TrackedIndices: []types.ValidatorIndex{attester, absent},
+		StateNotifier:  chain.StateNotifier(),
+		HeadFetcher:    chain,
+	})
+
+	// Only the first validator of the committee attested.
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	att := testutil.HydrateAttestation(&ethpb.Attestation{AggregationBits: bits})
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = 2
+	blk.Block.ProposerIndex = absent
+	blk.Block.Body.Attestations = []*ethpb.Attestation{att}
+
+	included := includedAttestations.WithLabelValues(indexLabel(attester))
+	proposed := proposedBlocks.WithLabelValues(indexLabel(absent))
+	includedBefore, proposedBefore := promtestutil.ToFloat64(included), promtestutil.ToFloat64(proposed)
+	s.processBlock(beaconState, blk)
+	// A redundant inclusion of the attestation is not counted.
+	s.processBlock(beaconState, blk)
+	assert.Equal(t, includedBefore+1, promtestutil.ToFloat64(included))
+	assert.Equal(t, proposedBefore+2, promtestutil.ToFloat64(proposed))
+	assert.Equal(t, float64(2), promtestutil.ToFloat64(attestationInclusionDistance.WithLabelValues(indexLabel(attester))))
+	assert.Equal(t, float64(params.BeaconConfig().MaxEffectiveBalance), promtestutil.ToFloat64(balanceGwei.WithLabelValues(indexLabel(attester))))
+
+	// Attestations of the first epoch are not checked, as they may have been included before the
+	// monitoring started, and those of an epoch are only checked once its inclusion window ended.
+	attesterMissed := missedAttestations.WithLabelValues(indexLabel(attester))
+	absentMissed := missedAttestations.WithLabelValues(indexLabel(absent))
+	attesterBefore, absentBefore := promtestutil.ToFloat64(attesterMissed), promtestutil.ToFloat64(absentMissed)
+	next := testutil.NewBeaconBlock()
+	next.Block.Slot = 2 * params.BeaconConfig().SlotsPerEpoch
+	s.processBlock(beaconState, next)
+	assert.Equal(t, attesterBefore, promtestutil.ToFloat64(attesterMissed))
+	assert.Equal(t, absentBefore, promtestutil.ToFloat64(absentMissed))
+
+	next = testutil.NewBeaconBlock()
+	next.Block.Slot = 4 * params.BeaconConfig().SlotsPerEpoch
+	s.processBlock(beaconState, next)
+	// Epochs 1 and 2 are checked, in which neither validator attested.
+	assert.Equal(t, attesterBefore+2, promtestutil.ToFloat64(attesterMissed))
+	assert.Equal(t, absentBefore+2, promtestutil.ToFloat64(absentMissed))
+	assert.Equal(t, float64(0), promtestutil.ToFloat64(balanceDeltaGwei.WithLabelValues(indexLabel(attester))))
+	assert.Equal(t, 0, len(s.included))
+}
@@ -0,0 +1,50 @@
+package node
+
+import (
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/urfave/cli/v2"
+)
+
+// devNumValidators is the number of interop validators of the genesis state in development mode.
+const devNumValidators = 64
+
+// configureDevMode sets the flags of a single node local chain, which starts right away from an
+// interop genesis state and mocks eth1 data. Flags set explicitly are left untouched, so that
+// e.g. a different number of validators or a persistent data directory can be used.
+func configureDevMode(cliCtx *cli.Context) error {
+	log.Warn("Running in development mode, on a local chain which is not connected to any network")
+	devFlags := []struct {
+		name  string
+		value string
+	}{
+		{cmd.MinimalConfigFlag.Name, "true"},
+		{cmd.NoDiscovery.Name, "true"},
+		{flags.DisableSync.Name, "true"},
+		{flags.InteropMockEth1DataVotesFlag.Name, "true"},
+		{flags.InteropNumValidatorsFlag.Name, strconv.Itoa(devNumValidators)},
+	}
+	for _, f := range devFlags {
+		if cliCtx.IsSet(f.name) {
+			continue
+		}
+		if err := cliCtx.Set(f.name, f.value); err != nil {
+			return errors.Wrapf(err, "could not set --%s", f.name)
+		}
+	}
+	if !cliCtx.IsSet(cmd.DataDirFlag.Name) {
+		dataDir, err := ioutil.TempDir("", "prysm-dev")
+		if err != nil {
+			return errors.Wrap(err, "could not create temporary data directory")
+		}
+		if err := cliCtx.Set(cmd.DataDirFlag.Name, dataDir); err != nil {
+			return errors.Wrapf(err, "could not set --%s", cmd.DataDirFlag.Name)
+		}
+		log.WithField("dataDir", dataDir).Info("Storing the development chain in a temporary directory")
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -18,23 +19,33 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/archiver"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/checkpoint"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv/backend"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/gateway"
 	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/interop-cold-start"
+	"github.com/prysmaticlabs/prysm/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/persistence"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconv1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher/spans"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	regularsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/backfill"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/backuputil"
@@ -42,12 +53,15 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/prereq"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
+	"github.com/prysmaticlabs/prysm/shared/ratelimit"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
 	"github.com/prysmaticlabs/prysm/shared/version"
+	slasherflags "github.com/prysmaticlabs/prysm/slasher/flags"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	"gopkg.in/yaml.v2"
@@ -75,6 +89,9 @@ type BeaconNode struct {
 	opFeed          *event.Feed
 	forkChoiceStore forkchoice.ForkChoicer
 	stateGen        *stategen.State
+	// rateLimitExemption is the token exempting the calls of the gateway from the limits of the
+	// gRPC server, or empty if the gRPC server is not limited.
+	rateLimitExemption string
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -93,9 +110,16 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 	// Warn if user's platform is not supported
 	prereq.WarnIfNotSupported(cliCtx.Context)
 
+	if cliCtx.Bool(flags.DevModeFlag.Name) {
+		if err := configureDevMode(cliCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	featureconfig.ConfigureBeaconChain(cliCtx)
 	cmd.ConfigureBeaconChain(cliCtx)
 	flags.ConfigureGlobalFlags(cliCtx)
+	helpers.SetCommitteeCacheSize(cliCtx.Int(flags.CommitteeCacheSize.Name))
 
 	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
 		chainConfigFileName := cliCtx.String(cmd.ChainConfigFileFlag.Name)
@@ -119,6 +143,9 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 	}
 
 	if cliCtx.IsSet(flags.SlotsPerArchivedPoint.Name) {
+		if cliCtx.Int(flags.SlotsPerArchivedPoint.Name) <= 0 {
+			return nil, fmt.Errorf("--%s must be greater than 0", flags.SlotsPerArchivedPoint.Name)
+		}
 		c := params.BeaconConfig()
 		c.SlotsPerArchivedPoint = types.Slot(cliCtx.Int(flags.SlotsPerArchivedPoint.Name))
 		params.OverrideBeaconConfig(c)
@@ -153,6 +180,20 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 		params.OverrideBeaconNetworkConfig(networkCfg)
 	}
 
+	exitOrdering := voluntaryexits.LowestIndexFirst
+	if cliCtx.IsSet(flags.ExitInclusionOrdering.Name) {
+		var err error
+		exitOrdering, err = voluntaryexits.ParseExitOrdering(cliCtx.String(flags.ExitInclusionOrdering.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attPool := attestations.NewPoolWithCapacity(
+		cliCtx.Int(flags.MaxPoolAggregatedAttestations.Name),
+		cliCtx.Int(flags.MaxPoolUnaggregatedAttestations.Name),
+	)
+
 	registry := shared.NewServiceRegistry()
 
 	ctx, cancel := context.WithCancel(cliCtx.Context)
@@ -165,15 +206,19 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 		stateFeed:       new(event.Feed),
 		blockFeed:       new(event.Feed),
 		opFeed:          new(event.Feed),
-		attestationPool: attestations.NewPool(),
-		exitPool:        voluntaryexits.NewPool(),
-		slashingsPool:   slashings.NewPool(),
+		attestationPool: attPool,
+		exitPool:        voluntaryexits.NewPoolWithCapacity(exitOrdering, cliCtx.Int(flags.MaxPoolVoluntaryExits.Name)),
+		slashingsPool:   slashings.NewPoolWithCapacity(cliCtx.Int(flags.MaxPoolSlashings.Name)),
 	}
 
 	if err := beacon.startDB(cliCtx); err != nil {
 		return nil, err
 	}
 
+	if err := beacon.startFromCheckpoint(cliCtx); err != nil {
+		return nil, err
+	}
+
 	beacon.startStateGen()
 
 	if err := beacon.registerP2P(cliCtx); err != nil {
@@ -206,6 +251,32 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerBackfillService(); err != nil {
+		return nil, err
+	}
+
+	if cliCtx.Bool(flags.SlasherFlag.Name) {
+		if err := beacon.registerSlasherService(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := beacon.registerPoolPersistenceService(); err != nil {
+		return nil, err
+	}
+
+	if len(cliCtx.IntSlice(flags.MonitorIndicesFlag.Name)) > 0 {
+		if err := beacon.registerValidatorMonitorService(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cliCtx.Bool(flags.ArchiveAttestationInclusionsFlag.Name) {
+		if err := beacon.registerAttestationInclusionsArchiver(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
 	}
@@ -299,9 +370,17 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 
 	log.WithField("database-path", dbPath).Info("Checking DB")
 
-	d, err := db.NewDB(b.ctx, dbPath, &kv.Config{
+	dbBackend, err := backend.ParseKind(cliCtx.String(flags.DatabaseBackend.Name))
+	if err != nil {
+		return err
+	}
+	dbConfig := &kv.Config{
 		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
-	})
+		FreelistType:    cliCtx.String(cmd.BoltFreelistTypeFlag.Name),
+		NoFreelistSync:  cliCtx.Bool(cmd.BoltNoFreelistSyncFlag.Name),
+		Backend:         dbBackend,
+	}
+	d, err := db.NewDB(b.ctx, dbPath, dbConfig)
 	if err != nil {
 		return err
 	}
@@ -323,9 +402,7 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 		if err := d.ClearDB(); err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
-		d, err = db.NewDB(b.ctx, dbPath, &kv.Config{
-			InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
-		})
+		d, err = db.NewDB(b.ctx, dbPath, dbConfig)
 		if err != nil {
 			return errors.Wrap(err, "could not create new database")
 		}
@@ -346,6 +423,46 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 	return nil
 }
 
+// startFromCheckpoint saves the configured finalized checkpoint to an empty database, so that the
+// node syncs from the checkpoint instead of from genesis.
+func (b *BeaconNode) startFromCheckpoint(cliCtx *cli.Context) error {
+	url := cliCtx.String(flags.CheckpointSyncURL.Name)
+	genesisStatePath := cliCtx.String(flags.CheckpointGenesisState.Name)
+	statePath := cliCtx.String(flags.CheckpointState.Name)
+	blockPath := cliCtx.String(flags.CheckpointBlock.Name)
+	fromFiles := genesisStatePath != "" || statePath != "" || blockPath != ""
+	if url == "" && !fromFiles {
+		return nil
+	}
+	if url != "" && fromFiles {
+		return fmt.Errorf("--%s cannot be used together with checkpoint files", flags.CheckpointSyncURL.Name)
+	}
+	if fromFiles && (genesisStatePath == "" || statePath == "" || blockPath == "") {
+		return fmt.Errorf("--%s, --%s and --%s must all be set to start from checkpoint files",
+			flags.CheckpointGenesisState.Name, flags.CheckpointState.Name, flags.CheckpointBlock.Name)
+	}
+	genesisBlock, err := b.db.GenesisBlock(b.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block from db")
+	}
+	if genesisBlock != nil {
+		log.Info("Database is already initialized, ignoring the checkpoint to sync from")
+		return nil
+	}
+
+	var origin *checkpoint.Origin
+	if url != "" {
+		log.WithField("url", url).Info("Fetching the latest finalized checkpoint to sync from")
+		origin, err = checkpoint.FromURL(b.ctx, url)
+	} else {
+		origin, err = checkpoint.FromFiles(genesisStatePath, statePath, blockPath)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not load checkpoint")
+	}
+	return origin.Save(b.ctx, b.db)
+}
+
 func (b *BeaconNode) startStateGen() {
 	b.stateGen = stategen.New(b.db)
 }
@@ -487,7 +604,7 @@ func (b *BeaconNode) registerPOWChainService() error {
 		log.Fatalf("Invalid deposit contract address given: %s", depAddress)
 	}
 
-	if b.cliCtx.String(flags.HTTPWeb3ProviderFlag.Name) == "" {
+	if b.cliCtx.String(flags.HTTPWeb3ProviderFlag.Name) == "" && !b.cliCtx.Bool(flags.DevModeFlag.Name) {
 		log.Error("No ETH1 node specified to run with the beacon node. Please consider running your own ETH1 node for better uptime, security, and decentralization of ETH2. Visit https://docs.prylabs.network/docs/prysm-usage/setup-eth1 for more information.")
 		log.Error("You will need to specify --http-web3provider to attach an eth1 node to the prysm node. Without an eth1 node block proposals for your validator will be affected and the beacon node will not be able to initialize the genesis state.")
 	}
@@ -543,6 +660,16 @@ func (b *BeaconNode) registerSyncService() error {
 		return err
 	}
 
+	var spanDetector *spans.Detector
+	if b.cliCtx.Bool(flags.GossipSlashingDetectionFlag.Name) {
+		historyLength := types.Epoch(b.cliCtx.Uint64(flags.GossipSlashingDetectionEpochsFlag.Name))
+		detector, err := spans.NewDetector(historyLength)
+		if err != nil {
+			return errors.Wrap(err, "could not create gossip slashing detector")
+		}
+		spanDetector = detector
+	}
+
 	rs := regularsync.NewService(b.ctx, &regularsync.Config{
 		DB:                  b.db,
 		P2P:                 b.fetchP2P(),
@@ -555,6 +682,7 @@ func (b *BeaconNode) registerSyncService() error {
 		ExitPool:            b.exitPool,
 		SlashingPool:        b.slashingsPool,
 		StateGen:            b.stateGen,
+		SpanDetector:        spanDetector,
 	})
 
 	return b.services.RegisterService(rs)
@@ -576,6 +704,94 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService() error {
+	var initSync *initialsync.Service
+	if err := b.services.FetchService(&initSync); err != nil {
+		return err
+	}
+
+	bs := backfill.NewService(b.ctx, &backfill.Config{
+		DB:          b.db,
+		P2P:         b.fetchP2P(),
+		InitialSync: initSync,
+	})
+	return b.services.RegisterService(bs)
+}
+
+func (b *BeaconNode) registerSlasherService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	svc, err := slasher.NewService(b.ctx, &slasher.Config{
+		DataDir:                     b.cliCtx.String(cmd.DataDirFlag.Name),
+		SpanCacheSize:               slasherflags.SpanCacheSize.Value,
+		HighestAttestationCacheSize: slasherflags.HighestAttCacheSize.Value,
+		StateNotifier:               b,
+		OperationNotifier:           b,
+		AttestationReceiver:         chainService,
+		HeadFetcher:                 chainService,
+		SlashingPool:                b.slashingsPool,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not register slasher service")
+	}
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerPoolPersistenceService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	svc := persistence.NewService(b.ctx, &persistence.Config{
+		BeaconDB:      b.db,
+		HeadFetcher:   chainService,
+		StateNotifier: b,
+		AttPool:       b.attestationPool,
+		ExitPool:      b.exitPool,
+		SlashingPool:  b.slashingsPool,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerValidatorMonitorService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	var indices []types.ValidatorIndex
+	for _, idx := range b.cliCtx.IntSlice(flags.MonitorIndicesFlag.Name) {
+		if idx < 0 {
+			return fmt.Errorf("invalid validator index to monitor: %d", idx)
+		}
+		indices = append(indices, types.ValidatorIndex(idx))
+	}
+	svc := monitor.NewService(b.ctx, &monitor.Config{
+		TrackedIndices: indices,
+		StateNotifier:  b,
+		HeadFetcher:    chainService,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerAttestationInclusionsArchiver() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	svc := archiver.NewService(b.ctx, &archiver.Config{
+		BeaconDB:      b.db,
+		StateNotifier: b,
+		HeadFetcher:   chainService,
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerRPCService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -614,9 +830,32 @@ func (b *BeaconNode) registerRPCService() error {
 	beaconMonitoringPort := b.cliCtx.Int(flags.MonitoringPortFlag.Name)
 	cert := b.cliCtx.String(flags.CertFlag.Name)
 	key := b.cliCtx.String(flags.KeyFlag.Name)
+	clientCA := b.cliCtx.String(flags.ClientCAFlag.Name)
 	mockEth1DataVotes := b.cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
+	slashingWebhookURL := b.cliCtx.String(flags.SlashingWebhookURL.Name)
+	maxBatchSize := b.cliCtx.Uint64(flags.RPCMaxBatchSize.Name)
+	slashingReplayCache, err := beaconv1.NewSlashingReplayCache(
+		b.cliCtx.Int(flags.SlashingReplayCacheSize.Name),
+		b.cliCtx.Duration(flags.SlashingReplayCacheTTL.Name),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create slashing replay cache")
+	}
+	verifyExitsAgainstPool := b.cliCtx.Bool(flags.VerifyExitsAgainstPool.Name)
+	rateLimiter, err := b.rateLimiter("grpc")
+	if err != nil {
+		return err
+	}
+	if rateLimiter != nil {
+		// The HTTP clients of the gateway are limited by the gateway rather than sharing the limits
+		// of its host with the gRPC clients of that host.
+		b.rateLimitExemption, err = rateLimiter.NewExemptionToken()
+		if err != nil {
+			return err
+		}
+	}
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		Host:                    host,
@@ -625,6 +864,7 @@ func (b *BeaconNode) registerRPCService() error {
 		BeaconMonitoringPort:    beaconMonitoringPort,
 		CertFlag:                cert,
 		KeyFlag:                 key,
+		ClientCAFlag:            clientCA,
 		BeaconDB:                b.db,
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
@@ -654,6 +894,11 @@ func (b *BeaconNode) registerRPCService() error {
 		StateGen:                b.stateGen,
 		EnableDebugRPCEndpoints: enableDebugRPCEndpoints,
 		MaxMsgSize:              maxMsgSize,
+		SlashingWebhookURL:      slashingWebhookURL,
+		MaxBatchSize:            maxBatchSize,
+		RateLimiter:             rateLimiter,
+		SlashingReplayCache:     slashingReplayCache,
+		VerifyExitsAgainstPool:  verifyExitsAgainstPool,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -694,6 +939,28 @@ func (b *BeaconNode) registerPrometheusService(cliCtx *cli.Context) error {
 	return b.services.RegisterService(service)
 }
 
+// rateLimiter returns the limiter of the requests to the named RPC server, or nil if no limit is
+// configured.
+func (b *BeaconNode) rateLimiter(server string) (*ratelimit.Limiter, error) {
+	fallback := ratelimit.Limit{
+		Rate:          b.cliCtx.Float64(flags.RPCRateLimit.Name),
+		Burst:         b.cliCtx.Int(flags.RPCRateLimitBurst.Name),
+		MaxConcurrent: b.cliCtx.Int(flags.RPCMaxConcurrentRequests.Name),
+	}
+	overrides := make(map[string]ratelimit.Limit)
+	for _, o := range b.cliCtx.StringSlice(flags.RPCRateLimitOverrides.Name) {
+		prefix, limit, err := ratelimit.ParseOverride(o)
+		if err != nil {
+			return nil, err
+		}
+		overrides[prefix] = limit
+	}
+	if fallback.Rate == 0 && fallback.MaxConcurrent == 0 && len(overrides) == 0 {
+		return nil, nil
+	}
+	return ratelimit.New(server, fallback, overrides), nil
+}
+
 func (b *BeaconNode) registerGRPCGateway() error {
 	if b.cliCtx.Bool(flags.DisableGRPCGateway.Name) {
 		return nil
@@ -706,16 +973,82 @@ func (b *BeaconNode) registerGRPCGateway() error {
 	allowedOrigins := strings.Split(b.cliCtx.String(flags.GPRCGatewayCorsDomain.Name), ",")
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
 	selfCert := b.cliCtx.String(flags.CertFlag.Name)
+	clientCert := b.cliCtx.String(flags.GRPCGatewayClientCertFlag.Name)
+	clientKey := b.cliCtx.String(flags.GRPCGatewayClientKeyFlag.Name)
+	if selfCert != "" && b.cliCtx.String(flags.ClientCAFlag.Name) != "" && (clientCert == "" || clientKey == "") {
+		return fmt.Errorf(
+			"the gRPC gateway requires the %s and %s flags when the %s flag is set",
+			flags.GRPCGatewayClientCertFlag.Name,
+			flags.GRPCGatewayClientKeyFlag.Name,
+			flags.ClientCAFlag.Name,
+		)
+	}
+
+	var authenticator *gateway.Authenticator
+	tokenFile := b.cliCtx.String(flags.GRPCGatewayAuthTokenFile.Name)
+	jwtSecretFile := b.cliCtx.String(flags.GRPCGatewayJWTSecretFile.Name)
+	if tokenFile != "" || jwtSecretFile != "" {
+		var err error
+		authenticator, err = gateway.NewAuthenticator(
+			tokenFile,
+			jwtSecretFile,
+			b.cliCtx.StringSlice(flags.GRPCGatewayAuthExemptPaths.Name),
+		)
+		if err != nil {
+			return errors.Wrap(err, "could not set up gateway authentication")
+		}
+	}
+	if b.cliCtx.Bool(flags.EnableAdminEndpoints.Name) && authenticator == nil {
+		return errors.New("admin endpoints require gRPC gateway authentication")
+	}
+	rateLimiter, err := b.rateLimiter("gateway")
+	if err != nil {
+		return err
+	}
+
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(gateway.EventsPath, &gateway.EventsHandler{
+		StateNotifier:     b,
+		OperationNotifier: b,
+		HeadFetcher:       chainService,
+		FinalizedFetcher:  chainService,
+	})
+	if b.cliCtx.Bool(flags.EnableAdminEndpoints.Name) {
+		var syncService *initialsync.Service
+		if err := b.services.FetchService(&syncService); err != nil {
+			return err
+		}
+		pools := &beaconv1.Server{
+			ChainInfoFetcher:   chainService,
+			SyncChecker:        syncService,
+			SlashingsPool:      b.slashingsPool,
+			VoluntaryExitsPool: b.exitPool,
+		}
+		mux.Handle(gateway.FeatureTogglesPath, authenticator.Require(http.HandlerFunc(gateway.FeatureTogglesHandler)))
+		mux.Handle(gateway.LoggingPath, authenticator.Require(http.HandlerFunc(logutil.LevelsHandler)))
+		mux.Handle(gateway.PoolsRevalidationPath, authenticator.Require(&gateway.PoolsRevalidationHandler{Pools: pools}))
+	}
 	return b.services.RegisterService(
 		gateway.New(
 			b.ctx,
 			selfAddress,
 			selfCert,
+			clientCert,
+			clientKey,
 			gatewayAddress,
-			nil, /*optional mux*/
+			mux,
 			allowedOrigins,
+			authenticator,
+			rateLimiter,
+			b.rateLimitExemption,
 			enableDebugRPCEndpoints,
 			b.cliCtx.Uint64(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+			b.cliCtx.Int64(flags.GRPCGatewayMaxBodySize.Name),
+			b.cliCtx.Duration(flags.GRPCGatewayRequestTimeout.Name),
 		),
 	)
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
@@ -81,3 +82,27 @@ func TestClearDB(t *testing.T) {
 	require.LogsContain(t, hook, "Removing database")
 	require.NoError(t, os.RemoveAll(tmp))
 }
+
+func TestConfigureDevMode(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Bool(cmd.MinimalConfigFlag.Name, false, "")
+	set.Bool(cmd.NoDiscovery.Name, false, "")
+	set.Bool(flags.DisableSync.Name, false, "")
+	set.Bool(flags.InteropMockEth1DataVotesFlag.Name, false, "")
+	set.Uint64(flags.InteropNumValidatorsFlag.Name, 0, "")
+	set.String(cmd.DataDirFlag.Name, "", "")
+	require.NoError(t, set.Set(flags.InteropNumValidatorsFlag.Name, "8"))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, configureDevMode(cliCtx))
+	assert.Equal(t, true, cliCtx.Bool(cmd.MinimalConfigFlag.Name))
+	assert.Equal(t, true, cliCtx.Bool(cmd.NoDiscovery.Name))
+	assert.Equal(t, true, cliCtx.Bool(flags.DisableSync.Name))
+	assert.Equal(t, true, cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name))
+	// Explicitly set flags are kept.
+	assert.Equal(t, uint64(8), cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name))
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	assert.NotEqual(t, "", dataDir)
+	require.NoError(t, os.RemoveAll(dataDir))
+}
@@ -114,10 +114,15 @@ func (c *AttCaches) SaveAggregatedAttestation(att *ethpb.Attestation) error {
 	defer c.aggregatedAttLock.Unlock()
 	atts, ok := c.aggregatedAtt[r]
 	if !ok {
+		if !c.evictAggregatedAttestations(copiedAtt.Data, r) {
+			return nil
+		}
 		atts := []*ethpb.Attestation{copiedAtt}
 		c.aggregatedAtt[r] = atts
+		aggregatedAttsInserted.Inc()
 		return nil
 	}
+	aggregatedAttsInserted.Inc()
 
 	atts, err = attaggregation.Aggregate(append(atts, copiedAtt))
 	if err != nil {
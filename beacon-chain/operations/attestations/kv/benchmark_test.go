@@ -1,11 +1,19 @@
 package kv_test
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations/kv"
+	attaggregation "github.com/prysmaticlabs/prysm/shared/aggregation/attestations"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
 
 func BenchmarkAttCaches(b *testing.B) {
@@ -18,3 +26,57 @@ func BenchmarkAttCaches(b *testing.B) {
 		assert.NoError(b, ac.DeleteAggregatedAttestation(att))
 	}
 }
+
+// BenchmarkAttCaches_Aggregation saves the attestations of a committee to the pool, as overlapping
+// aggregates received from several aggregators and the unaggregated attestations of a subnet,
+// and aggregates them. It reports the number of aggregates left in the pool, the fewer the better
+// for both the memory used by the pool and the attestations fitting in a proposed block.
+func BenchmarkAttCaches_Aggregation(b *testing.B) {
+	const committeeSize = 128
+	priv, err := bls.RandKey()
+	require.NoError(b, err)
+	sig := priv.Sign([]byte("attestation")).Marshal()
+	r := rand.New(rand.NewSource(1))
+	newAtt := func(bits ...uint64) *ethpb.Attestation {
+		aggregationBits := bitfield.NewBitlist(committeeSize)
+		for _, bit := range bits {
+			aggregationBits.SetBitAt(bit, true)
+		}
+		return testutil.HydrateAttestation(&ethpb.Attestation{AggregationBits: aggregationBits, Signature: sig})
+	}
+	var aggregates []*ethpb.Attestation
+	for i := 0; i < 16; i++ {
+		bits := make([]uint64, 8)
+		for j := range bits {
+			bits[j] = uint64(r.Intn(committeeSize))
+		}
+		aggregates = append(aggregates, newAtt(bits...))
+	}
+	var unaggregated []*ethpb.Attestation
+	for i := uint64(0); i < committeeSize; i += 2 {
+		unaggregated = append(unaggregated, newAtt(i))
+	}
+
+	strategies := []attaggregation.AttestationAggregationStrategy{
+		attaggregation.NaiveAggregation,
+		attaggregation.MaxCoverAggregation,
+		attaggregation.OptMaxCoverAggregation,
+	}
+	for _, strategy := range strategies {
+		b.Run(fmt.Sprintf("%s_%d_aggregates_%d_unaggregated", strategy, len(aggregates), len(unaggregated)), func(b *testing.B) {
+			resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{
+				AttestationAggregationStrategy: string(strategy),
+			})
+			defer resetCfg()
+			var count int
+			for i := 0; i < b.N; i++ {
+				ac := kv.NewAttCaches()
+				require.NoError(b, ac.SaveAggregatedAttestations(aggregates))
+				require.NoError(b, ac.SaveUnaggregatedAttestations(unaggregated))
+				require.NoError(b, ac.AggregateUnaggregatedAttestations())
+				count = len(ac.AggregatedAttestations())
+			}
+			b.ReportMetric(float64(count), "aggregates")
+		})
+	}
+}
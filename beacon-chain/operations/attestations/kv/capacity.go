@@ -0,0 +1,72 @@
+package kv
+
+import (
+	"bytes"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// evictUnaggregatedAttestation makes room in a full unaggregated cache for the attestation of
+// the given root, by evicting the cached attestation of the oldest slot. It returns false if the
+// new attestation is itself older than all the cached ones and should be dropped instead.
+// The caller must hold the unaggregated lock.
+func (c *AttCaches) evictUnaggregatedAttestation(att *ethpb.Attestation, r [32]byte) bool {
+	if c.maxUnaggregated <= 0 || len(c.unAggregatedAtt) < c.maxUnaggregated {
+		return true
+	}
+	if _, ok := c.unAggregatedAtt[r]; ok {
+		return true
+	}
+	var oldestRoot [32]byte
+	var oldest *ethpb.Attestation
+	for root, a := range c.unAggregatedAtt {
+		if oldest == nil || isOlder(a.Data, root, oldest.Data, oldestRoot) {
+			oldestRoot, oldest = root, a
+		}
+	}
+	unaggregatedAttsEvicted.Inc()
+	if isOlder(att.Data, r, oldest.Data, oldestRoot) {
+		return false
+	}
+	delete(c.unAggregatedAtt, oldestRoot)
+	return true
+}
+
+// evictAggregatedAttestations makes room in a full aggregated cache for the attestations of the
+// given data root, by evicting the cached attestations of the oldest slot. It returns false if
+// the new attestation data is itself older than all the cached ones and should be dropped
+// instead. The caller must hold the aggregated lock.
+func (c *AttCaches) evictAggregatedAttestations(data *ethpb.AttestationData, r [32]byte) bool {
+	if c.maxAggregated <= 0 || len(c.aggregatedAtt) < c.maxAggregated {
+		return true
+	}
+	if _, ok := c.aggregatedAtt[r]; ok {
+		return true
+	}
+	var oldestRoot [32]byte
+	var oldest *ethpb.AttestationData
+	for root, atts := range c.aggregatedAtt {
+		if oldest == nil || isOlder(atts[0].Data, root, oldest, oldestRoot) {
+			oldestRoot, oldest = root, atts[0].Data
+		}
+	}
+	if isOlder(data, r, oldest, oldestRoot) {
+		aggregatedAttsEvicted.Inc()
+		return false
+	}
+	aggregatedAttsEvicted.Add(float64(len(c.aggregatedAtt[oldestRoot])))
+	delete(c.aggregatedAtt, oldestRoot)
+	return true
+}
+
+// isOlder orders attestations by slot, then committee index, then root, so that evictions do not
+// depend on the iteration order of the caches.
+func isOlder(a *ethpb.AttestationData, aRoot [32]byte, b *ethpb.AttestationData, bRoot [32]byte) bool {
+	if a.Slot != b.Slot {
+		return a.Slot < b.Slot
+	}
+	if a.CommitteeIndex != b.CommitteeIndex {
+		return a.CommitteeIndex < b.CommitteeIndex
+	}
+	return bytes.Compare(aRoot[:], bRoot[:]) < 0
+}
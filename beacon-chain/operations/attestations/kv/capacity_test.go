@@ -0,0 +1,81 @@
+package kv
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestKV_Unaggregated_Capacity(t *testing.T) {
+	cache := NewAttCachesWithCapacity(0, 2)
+	newAtt := func(slot types.Slot) *ethpb.Attestation {
+		return testutil.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot},
+			AggregationBits: bitfield.Bitlist{0b101},
+		})
+	}
+	slots := func() []types.Slot {
+		atts, err := cache.UnaggregatedAttestations()
+		require.NoError(t, err)
+		res := make([]types.Slot, 0, len(atts))
+		for _, att := range atts {
+			res = append(res, att.Data.Slot)
+		}
+		if len(res) == 2 && res[0] > res[1] {
+			res[0], res[1] = res[1], res[0]
+		}
+		return res
+	}
+
+	require.NoError(t, cache.SaveUnaggregatedAttestations([]*ethpb.Attestation{newAtt(2), newAtt(3)}))
+	assert.DeepEqual(t, []types.Slot{2, 3}, slots())
+
+	// The attestation of the oldest slot is evicted.
+	require.NoError(t, cache.SaveUnaggregatedAttestation(newAtt(4)))
+	assert.DeepEqual(t, []types.Slot{3, 4}, slots())
+
+	// An attestation older than the whole pool is dropped.
+	require.NoError(t, cache.SaveUnaggregatedAttestation(newAtt(1)))
+	assert.DeepEqual(t, []types.Slot{3, 4}, slots())
+
+	// Saving an attestation already in the pool does not evict anything.
+	require.NoError(t, cache.SaveUnaggregatedAttestation(newAtt(3)))
+	assert.DeepEqual(t, []types.Slot{3, 4}, slots())
+}
+
+func TestKV_Aggregated_Capacity(t *testing.T) {
+	cache := NewAttCachesWithCapacity(2, 0)
+	sig := testutil.HydrateAttestation(&ethpb.Attestation{}).Signature
+	newAtt := func(slot types.Slot, bits bitfield.Bitlist) *ethpb.Attestation {
+		return testutil.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot},
+			AggregationBits: bits,
+			Signature:       sig,
+		})
+	}
+
+	require.NoError(t, cache.SaveAggregatedAttestations([]*ethpb.Attestation{
+		newAtt(3, bitfield.Bitlist{0b1011}),
+		newAtt(2, bitfield.Bitlist{0b1011}),
+		newAtt(2, bitfield.Bitlist{0b1110}),
+	}))
+	require.Equal(t, 2, cache.AggregatedAttestationCount())
+	require.Equal(t, 2, len(cache.AggregatedAttestationsBySlotIndex(2, 0)))
+
+	// All the attestations of the oldest data are evicted.
+	require.NoError(t, cache.SaveAggregatedAttestation(newAtt(4, bitfield.Bitlist{0b1011})))
+	require.Equal(t, 2, cache.AggregatedAttestationCount())
+	assert.Equal(t, 0, len(cache.AggregatedAttestationsBySlotIndex(2, 0)))
+	assert.Equal(t, 1, len(cache.AggregatedAttestationsBySlotIndex(3, 0)))
+	assert.Equal(t, 1, len(cache.AggregatedAttestationsBySlotIndex(4, 0)))
+
+	// Data older than the whole pool is dropped.
+	require.NoError(t, cache.SaveAggregatedAttestation(newAtt(1, bitfield.Bitlist{0b1011})))
+	require.Equal(t, 2, cache.AggregatedAttestationCount())
+	assert.Equal(t, 0, len(cache.AggregatedAttestationsBySlotIndex(1, 0)))
+}
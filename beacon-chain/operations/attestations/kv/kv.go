@@ -28,11 +28,21 @@ type AttCaches struct {
 	blockAttLock       sync.RWMutex
 	blockAtt           map[[32]byte][]*ethpb.Attestation
 	seenAtt            *cache.Cache
+	maxAggregated      int
+	maxUnaggregated    int
 }
 
 // NewAttCaches initializes a new attestation pool consists of multiple KV store in cache for
 // various kind of attestations.
 func NewAttCaches() *AttCaches {
+	return NewAttCachesWithCapacity(0, 0)
+}
+
+// NewAttCachesWithCapacity initializes a new attestation pool which holds at most maxAggregated
+// distinct attestation data in its aggregated cache, and maxUnaggregated attestations in its
+// unaggregated cache. Once a cache is full, the attestations of the oldest slot are evicted to
+// make room for new ones. A capacity of 0 leaves the cache unbounded.
+func NewAttCachesWithCapacity(maxAggregated, maxUnaggregated int) *AttCaches {
 	secsInEpoch := time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))
 	c := cache.New(secsInEpoch*time.Second, 2*secsInEpoch*time.Second)
 	pool := &AttCaches{
@@ -41,6 +51,8 @@ func NewAttCaches() *AttCaches {
 		forkchoiceAtt:   make(map[[32]byte]*ethpb.Attestation),
 		blockAtt:        make(map[[32]byte][]*ethpb.Attestation),
 		seenAtt:         c,
+		maxAggregated:   maxAggregated,
+		maxUnaggregated: maxUnaggregated,
 	}
 
 	return pool
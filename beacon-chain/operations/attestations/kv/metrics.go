@@ -0,0 +1,25 @@
+package kv
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	aggregatedAttsInserted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inserted_aggregated_atts_total",
+		Help: "The number of aggregated attestations inserted in the pool.",
+	})
+	unaggregatedAttsInserted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "inserted_unaggregated_atts_total",
+		Help: "The number of unaggregated attestations inserted in the pool.",
+	})
+	aggregatedAttsEvicted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "evicted_aggregated_atts_total",
+		Help: "The number of aggregated attestations evicted or dropped because the pool was full.",
+	})
+	unaggregatedAttsEvicted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "evicted_unaggregated_atts_total",
+		Help: "The number of unaggregated attestations evicted or dropped because the pool was full.",
+	})
+)
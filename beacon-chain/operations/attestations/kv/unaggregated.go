@@ -32,7 +32,11 @@ func (c *AttCaches) SaveUnaggregatedAttestation(att *ethpb.Attestation) error {
 	att = stateTrie.CopyAttestation(att) // Copied.
 	c.unAggregateAttLock.Lock()
 	defer c.unAggregateAttLock.Unlock()
+	if !c.evictUnaggregatedAttestation(att, r) {
+		return nil
+	}
 	c.unAggregatedAtt[r] = att
+	unaggregatedAttsInserted.Inc()
 
 	return nil
 }
@@ -46,3 +46,10 @@ type Pool interface {
 func NewPool() *kv.AttCaches {
 	return kv.NewAttCaches()
 }
+
+// NewPoolWithCapacity initializes a new attestation pool which holds at most maxAggregated
+// distinct attestation data in its aggregated cache, and maxUnaggregated unaggregated
+// attestations. A capacity of 0 leaves the cache unbounded.
+func NewPoolWithCapacity(maxAggregated, maxUnaggregated int) *kv.AttCaches {
+	return kv.NewAttCachesWithCapacity(maxAggregated, maxUnaggregated)
+}
@@ -0,0 +1,226 @@
+// Package persistence saves the operation pools of the beacon node to its database on shutdown,
+// and reloads them once the chain is initialized on startup, so that the attestations, slashings
+// and voluntary exits waiting for inclusion survive restarts.
+package persistence
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var _ shared.Service = (*Service)(nil)
+
+// Config to set up the operation pools persistence service.
+type Config struct {
+	BeaconDB      db.NoHeadAccessDatabase
+	HeadFetcher   blockchain.HeadFetcher
+	StateNotifier statefeed.Notifier
+	AttPool       attestations.Pool
+	ExitPool      voluntaryexits.PoolManager
+	SlashingPool  slashings.PoolManager
+}
+
+// Service saves and reloads the operation pools.
+type Service struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	cfg          *Config
+	stateChannel chan *feed.Event
+	stateSub     event.Subscription
+	lock         sync.Mutex
+	initialized  bool
+}
+
+// NewService configures the operation pools persistence service. It subscribes to the state
+// feed right away, so that the initialization of the chain is seen even when it happens as the
+// blockchain service starts.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	stateChannel := make(chan *feed.Event, 1)
+	return &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		cfg:          cfg,
+		stateChannel: stateChannel,
+		stateSub:     cfg.StateNotifier.StateFeed().Subscribe(stateChannel),
+	}
+}
+
+// Start waits for the chain to be initialized to reload the saved operation pools.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop saves the operation pools to the database. The saved pools are left untouched if the
+// chain was never initialized, as they were not reloaded.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.initialized {
+		return nil
+	}
+	return s.savePools(context.Background())
+}
+
+// Status always returns nil.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	defer s.stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-s.stateChannel:
+			if event.Type != statefeed.Initialized {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.InitializedData)
+			if !ok {
+				log.Error("Event feed data is not type *statefeed.InitializedData")
+				return
+			}
+			s.lock.Lock()
+			if err := s.loadPools(s.ctx, data.StartTime); err != nil {
+				log.WithError(err).Error("Could not reload operation pools")
+			}
+			s.initialized = true
+			s.lock.Unlock()
+			return
+		case err := <-s.stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// loadPools inserts the saved operations into the pools, dropping the attestations too old to be
+// included in a block, and the slashings and exits of validators already slashed or exited.
+func (s *Service) loadPools(ctx context.Context, genesisTime time.Time) error {
+	headState, err := s.cfg.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return errors.New("head state is nil")
+	}
+
+	atts, err := s.cfg.BeaconDB.PoolAttestations(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get saved attestations")
+	}
+	currentSlot := helpers.SlotsSince(genesisTime)
+	var numAtts int
+	for _, att := range atts {
+		if att.Data.Slot+params.BeaconConfig().SlotsPerEpoch < currentSlot {
+			continue
+		}
+		if helpers.IsAggregated(att) {
+			err = s.cfg.AttPool.SaveAggregatedAttestation(att)
+		} else {
+			err = s.cfg.AttPool.SaveUnaggregatedAttestation(att)
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not save attestation to pool")
+		}
+		numAtts++
+	}
+
+	attSlashings, err := s.cfg.BeaconDB.PoolAttesterSlashings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get saved attester slashings")
+	}
+	for _, slashing := range attSlashings {
+		if err := s.cfg.SlashingPool.InsertAttesterSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Dropping saved attester slashing")
+		}
+	}
+	propSlashings, err := s.cfg.BeaconDB.PoolProposerSlashings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get saved proposer slashings")
+	}
+	for _, slashing := range propSlashings {
+		if err := s.cfg.SlashingPool.InsertProposerSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Dropping saved proposer slashing")
+		}
+	}
+
+	exits, err := s.cfg.BeaconDB.PoolVoluntaryExits(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get saved voluntary exits")
+	}
+	for _, exit := range exits {
+		s.cfg.ExitPool.InsertVoluntaryExit(ctx, headState, exit)
+	}
+
+	log.WithFields(logrus.Fields{
+		"attestations":      numAtts,
+		"attesterSlashings": len(attSlashings),
+		"proposerSlashings": len(propSlashings),
+		"voluntaryExits":    len(exits),
+	}).Info("Reloaded operation pools")
+	return nil
+}
+
+// savePools replaces the saved operations with the contents of the pools.
+func (s *Service) savePools(ctx context.Context) error {
+	headState, err := s.cfg.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return errors.New("head state is nil")
+	}
+
+	unaggregated, err := s.cfg.AttPool.UnaggregatedAttestations()
+	if err != nil {
+		return errors.Wrap(err, "could not get unaggregated attestations")
+	}
+	atts := append([]*ethpb.Attestation{}, s.cfg.AttPool.AggregatedAttestations()...)
+	atts = append(atts, unaggregated...)
+	if err := s.cfg.BeaconDB.SavePoolAttestations(ctx, atts); err != nil {
+		return errors.Wrap(err, "could not save attestations")
+	}
+	attSlashings := s.cfg.SlashingPool.PendingAttesterSlashings(ctx, headState, true /* noLimit */)
+	if err := s.cfg.BeaconDB.SavePoolAttesterSlashings(ctx, attSlashings); err != nil {
+		return errors.Wrap(err, "could not save attester slashings")
+	}
+	propSlashings := s.cfg.SlashingPool.PendingProposerSlashings(ctx, headState, true /* noLimit */)
+	if err := s.cfg.BeaconDB.SavePoolProposerSlashings(ctx, propSlashings); err != nil {
+		return errors.Wrap(err, "could not save proposer slashings")
+	}
+	// The exits of all epochs are saved, not only those ready for inclusion at the head slot.
+	exits := s.cfg.ExitPool.PendingExits(headState, types.Slot(math.MaxUint64), true /* noLimit */)
+	if err := s.cfg.BeaconDB.SavePoolVoluntaryExits(ctx, exits); err != nil {
+		return errors.Wrap(err, "could not save voluntary exits")
+	}
+
+	log.WithFields(logrus.Fields{
+		"attestations":      len(atts),
+		"attesterSlashings": len(attSlashings),
+		"proposerSlashings": len(propSlashings),
+		"voluntaryExits":    len(exits),
+	}).Info("Saved operation pools")
+	return nil
+}
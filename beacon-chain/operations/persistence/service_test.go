@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/timeutils"
+)
+
+func setupService(t *testing.T, beaconDB db.Database) (*Service, *mock.ChainService) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	chain := &mock.ChainService{State: beaconState}
+	return NewService(context.Background(), &Config{
+		BeaconDB:      beaconDB,
+		HeadFetcher:   chain,
+		StateNotifier: chain.StateNotifier(),
+		AttPool:       attestations.NewPool(),
+		ExitPool:      voluntaryexits.NewPool(),
+		SlashingPool:  &slashings.PoolMock{},
+	}), chain
+}
+
+func TestService_SaveAndReloadPools(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	s, _ := setupService(t, beaconDB)
+	att := testutil.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.Bitlist{0x0b}})
+	require.NoError(t, s.cfg.AttPool.SaveAggregatedAttestation(att))
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: testutil.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+		Attestation_2: testutil.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+	}
+	s.cfg.SlashingPool.(*slashings.PoolMock).PendingAttSlashings = []*ethpb.AttesterSlashing{slashing}
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 1000, ValidatorIndex: 3},
+		Signature: make([]byte, 96),
+	}
+	s.cfg.ExitPool.InsertVoluntaryExit(ctx, s.cfg.HeadFetcher.(*mock.ChainService).State, exit)
+
+	// Pools are not saved before the chain is initialized.
+	require.NoError(t, s.Stop())
+	savedAtts, err := s.cfg.BeaconDB.PoolAttestations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(savedAtts))
+
+	s.initialized = true
+	require.NoError(t, s.Stop())
+
+	// A restarted node reloads the saved pools.
+	restarted, _ := setupService(t, beaconDB)
+	require.NoError(t, restarted.loadPools(ctx, timeutils.Now()))
+	assert.Equal(t, 1, restarted.cfg.AttPool.AggregatedAttestationCount())
+	assert.Equal(t, 1, len(restarted.cfg.SlashingPool.(*slashings.PoolMock).PendingAttSlashings))
+	exits := restarted.cfg.ExitPool.PendingExits(restarted.cfg.HeadFetcher.(*mock.ChainService).State, params.BeaconConfig().SlotsPerEpoch.Mul(1000), true)
+	require.Equal(t, 1, len(exits))
+	assert.DeepEqual(t, exit, exits[0])
+
+	// Attestations too old to be included in a block are dropped.
+	restarted, _ = setupService(t, beaconDB)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	genesisTime := timeutils.Now().Add(-slotDuration * time.Duration(2*params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(t, restarted.loadPools(ctx, genesisTime))
+	assert.Equal(t, 0, restarted.cfg.AttPool.AggregatedAttestationCount())
+}
+
+func TestService_ReloadsPoolsOnInitialized(t *testing.T) {
+	ctx := context.Background()
+	s, chain := setupService(t, testDB.SetupDB(t))
+	att := testutil.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.Bitlist{0x09}})
+	att.Data.Slot = types.Slot(1)
+	require.NoError(t, s.cfg.BeaconDB.SavePoolAttestations(ctx, []*ethpb.Attestation{att}))
+
+	s.Start()
+	chain.StateNotifier().StateFeed().Send(&feed.Event{
+		Type: statefeed.Initialized,
+		Data: &statefeed.InitializedData{StartTime: timeutils.Now()},
+	})
+	for i := 0; i < 100 && s.cfg.AttPool.UnaggregatedAttestationCount() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 1, s.cfg.AttPool.UnaggregatedAttestationCount())
+	require.NoError(t, s.Stop())
+	assert.Equal(t, true, s.initialized)
+}
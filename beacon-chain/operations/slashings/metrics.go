@@ -18,6 +18,18 @@ var (
 			Help: "Number of attester slashings included in blocks",
 		},
 	)
+	numAttesterSlashingsInserted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "attester_slashings_inserted_total",
+			Help: "Number of attester slashings inserted in the pool",
+		},
+	)
+	numAttesterSlashingsEvicted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "attester_slashings_evicted_total",
+			Help: "Number of attester slashings evicted because the pool was full",
+		},
+	)
 	numPendingProposerSlashings = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "num_pending_proposer_slashings",
@@ -30,4 +42,16 @@ var (
 			Help: "Number of proposer slashings included in blocks",
 		},
 	)
+	numProposerSlashingsInserted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "proposer_slashings_inserted_total",
+			Help: "Number of proposer slashings inserted in the pool",
+		},
+	)
+	numProposerSlashingsEvicted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "proposer_slashings_evicted_total",
+			Help: "Number of proposer slashings evicted because the pool was full",
+		},
+	)
 )
@@ -44,3 +44,23 @@ func (m *PoolMock) MarkIncludedAttesterSlashing(_ *ethpb.AttesterSlashing) {
 func (m *PoolMock) MarkIncludedProposerSlashing(_ *ethpb.ProposerSlashing) {
 	panic("implement me")
 }
+
+// RemoveAttesterSlashing --
+func (m *PoolMock) RemoveAttesterSlashing(as *ethpb.AttesterSlashing) {
+	for i, s := range m.PendingAttSlashings {
+		if s == as {
+			m.PendingAttSlashings = append(m.PendingAttSlashings[:i], m.PendingAttSlashings[i+1:]...)
+			return
+		}
+	}
+}
+
+// RemoveProposerSlashing --
+func (m *PoolMock) RemoveProposerSlashing(ps *ethpb.ProposerSlashing) {
+	for i, s := range m.PendingPropSlashings {
+		if s == ps {
+			m.PendingPropSlashings = append(m.PendingPropSlashings[:i], m.PendingPropSlashings[i+1:]...)
+			return
+		}
+	}
+}
@@ -19,10 +19,19 @@ import (
 
 // NewPool returns an initialized attester slashing and proposer slashing pool.
 func NewPool() *Pool {
+	return NewPoolWithCapacity(0)
+}
+
+// NewPoolWithCapacity returns an initialized attester slashing and proposer slashing pool which
+// holds at most maxPending slashings of each kind. Once full, the slashing of the highest
+// validator index, which is the last one packed into blocks, is evicted to make room for new
+// ones. A capacity of 0 leaves the pool unbounded.
+func NewPoolWithCapacity(maxPending int) *Pool {
 	return &Pool{
 		pendingProposerSlashing: make([]*ethpb.ProposerSlashing, 0),
 		pendingAttesterSlashing: make([]*PendingAttesterSlashing, 0),
 		included:                make(map[types.ValidatorIndex]bool),
+		maxPending:              maxPending,
 	}
 }
 
@@ -162,6 +171,13 @@ func (p *Pool) InsertAttesterSlashing(
 		sort.Slice(p.pendingAttesterSlashing, func(i, j int) bool {
 			return p.pendingAttesterSlashing[i].validatorToSlash < p.pendingAttesterSlashing[j].validatorToSlash
 		})
+		numAttesterSlashingsInserted.Inc()
+		// Evict the slashing of the highest validator index once the pool is over capacity,
+		// which may be the one just inserted.
+		if p.maxPending > 0 && len(p.pendingAttesterSlashing) > p.maxPending {
+			p.pendingAttesterSlashing = p.pendingAttesterSlashing[:p.maxPending]
+			numAttesterSlashingsEvicted.Inc()
+		}
 		numPendingAttesterSlashings.Set(float64(len(p.pendingAttesterSlashing)))
 	}
 	if len(cantSlash) == len(slashedVal) {
@@ -213,6 +229,13 @@ func (p *Pool) InsertProposerSlashing(
 	sort.Slice(p.pendingProposerSlashing, func(i, j int) bool {
 		return p.pendingProposerSlashing[i].Header_1.Header.ProposerIndex < p.pendingProposerSlashing[j].Header_1.Header.ProposerIndex
 	})
+	numProposerSlashingsInserted.Inc()
+	// Evict the slashing of the highest validator index once the pool is over capacity,
+	// which may be the one just inserted.
+	if p.maxPending > 0 && len(p.pendingProposerSlashing) > p.maxPending {
+		p.pendingProposerSlashing = p.pendingProposerSlashing[:p.maxPending]
+		numProposerSlashingsEvicted.Inc()
+	}
 	numPendingProposerSlashings.Set(float64(len(p.pendingProposerSlashing)))
 
 	return nil
@@ -253,6 +276,37 @@ func (p *Pool) MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
 	numProposerSlashingsIncluded.Inc()
 }
 
+// RemoveAttesterSlashing removes an attester slashing from the pending pool, e.g. when it no longer
+// verifies against the head state. Unlike MarkIncludedAttesterSlashing, the slashed validators are not
+// marked as included, so a valid slashing of them can still be inserted later.
+func (p *Pool) RemoveAttesterSlashing(as *ethpb.AttesterSlashing) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	slashedVal := sliceutil.IntersectionUint64(as.Attestation_1.AttestingIndices, as.Attestation_2.AttestingIndices)
+	for _, val := range slashedVal {
+		i := sort.Search(len(p.pendingAttesterSlashing), func(i int) bool {
+			return uint64(p.pendingAttesterSlashing[i].validatorToSlash) >= val
+		})
+		if i != len(p.pendingAttesterSlashing) && uint64(p.pendingAttesterSlashing[i].validatorToSlash) == val {
+			p.pendingAttesterSlashing = append(p.pendingAttesterSlashing[:i], p.pendingAttesterSlashing[i+1:]...)
+		}
+	}
+}
+
+// RemoveProposerSlashing removes a proposer slashing from the pending pool, e.g. when it no longer
+// verifies against the head state. Unlike MarkIncludedProposerSlashing, the proposer is not marked
+// as included, so a valid slashing of it can still be inserted later.
+func (p *Pool) RemoveProposerSlashing(ps *ethpb.ProposerSlashing) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	i := sort.Search(len(p.pendingProposerSlashing), func(i int) bool {
+		return p.pendingProposerSlashing[i].Header_1.Header.ProposerIndex >= ps.Header_1.Header.ProposerIndex
+	})
+	if i != len(p.pendingProposerSlashing) && p.pendingProposerSlashing[i].Header_1.Header.ProposerIndex == ps.Header_1.Header.ProposerIndex {
+		p.pendingProposerSlashing = append(p.pendingProposerSlashing[:i], p.pendingProposerSlashing[i+1:]...)
+	}
+}
+
 // this function checks a few items about a validator before proceeding with inserting
 // a proposer/attester slashing into the pool. First, it checks if the validator
 // has been recently included in the pool, then it checks if the validator is slashable.
@@ -325,6 +325,22 @@ func TestPool_InsertAttesterSlashing_SigFailsVerify_ClearPool(t *testing.T) {
 	assert.Equal(t, 1, len(p.pendingAttesterSlashing))
 }
 
+func TestPool_RemoveAttesterSlashing(t *testing.T) {
+	p := &Pool{
+		pendingAttesterSlashing: []*PendingAttesterSlashing{
+			pendingSlashingForValIdx(1),
+			pendingSlashingForValIdx(2),
+			pendingSlashingForValIdx(3),
+		},
+		included: make(map[types.ValidatorIndex]bool),
+	}
+	p.RemoveAttesterSlashing(attesterSlashingForValIdx(2))
+	require.Equal(t, 2, len(p.pendingAttesterSlashing))
+	assert.DeepEqual(t, pendingSlashingForValIdx(1), p.pendingAttesterSlashing[0])
+	assert.DeepEqual(t, pendingSlashingForValIdx(3), p.pendingAttesterSlashing[1])
+	assert.Equal(t, false, p.included[2], "Removed slashing must not be marked as included")
+}
+
 func TestPool_MarkIncludedAttesterSlashing(t *testing.T) {
 	type fields struct {
 		pending  []*PendingAttesterSlashing
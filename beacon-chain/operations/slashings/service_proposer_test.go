@@ -178,6 +178,20 @@ func TestPool_InsertProposerSlashing(t *testing.T) {
 	}
 }
 
+func TestPool_InsertProposerSlashing_Capacity(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	p := NewPoolWithCapacity(2)
+	for _, idx := range []types.ValidatorIndex{3, 1, 4, 2} {
+		sl, err := testutil.GenerateProposerSlashingForValidator(beaconState, privKeys[idx], idx)
+		require.NoError(t, err)
+		require.NoError(t, p.InsertProposerSlashing(context.Background(), beaconState, sl))
+	}
+	// The slashings of the highest validator indices are evicted.
+	require.Equal(t, 2, len(p.pendingProposerSlashing))
+	assert.Equal(t, types.ValidatorIndex(1), p.pendingProposerSlashing[0].Header_1.Header.ProposerIndex)
+	assert.Equal(t, types.ValidatorIndex(2), p.pendingProposerSlashing[1].Header_1.Header.ProposerIndex)
+}
+
 func TestPool_InsertProposerSlashing_SigFailsVerify_ClearPool(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	conf := params.BeaconConfig()
@@ -204,6 +218,22 @@ func TestPool_InsertProposerSlashing_SigFailsVerify_ClearPool(t *testing.T) {
 	assert.Equal(t, 1, len(p.pendingProposerSlashing))
 }
 
+func TestPool_RemoveProposerSlashing(t *testing.T) {
+	p := &Pool{
+		pendingProposerSlashing: []*ethpb.ProposerSlashing{
+			proposerSlashingForValIdx(1),
+			proposerSlashingForValIdx(2),
+			proposerSlashingForValIdx(3),
+		},
+		included: make(map[types.ValidatorIndex]bool),
+	}
+	p.RemoveProposerSlashing(proposerSlashingForValIdx(2))
+	require.Equal(t, 2, len(p.pendingProposerSlashing))
+	assert.DeepEqual(t, proposerSlashingForValIdx(1), p.pendingProposerSlashing[0])
+	assert.DeepEqual(t, proposerSlashingForValIdx(3), p.pendingProposerSlashing[1])
+	assert.Equal(t, false, p.included[2], "Removed slashing must not be marked as included")
+}
+
 func TestPool_MarkIncludedProposerSlashing(t *testing.T) {
 	type fields struct {
 		pending  []*ethpb.ProposerSlashing
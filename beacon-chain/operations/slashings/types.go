@@ -26,6 +26,8 @@ type PoolManager interface {
 	) error
 	MarkIncludedAttesterSlashing(as *ethpb.AttesterSlashing)
 	MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing)
+	RemoveAttesterSlashing(as *ethpb.AttesterSlashing)
+	RemoveProposerSlashing(ps *ethpb.ProposerSlashing)
 }
 
 // Pool is a concrete implementation of PoolManager.
@@ -34,6 +36,7 @@ type Pool struct {
 	pendingProposerSlashing []*ethpb.ProposerSlashing
 	pendingAttesterSlashing []*PendingAttesterSlashing
 	included                map[types.ValidatorIndex]bool
+	maxPending              int
 }
 
 // PendingAttesterSlashing represents an attester slashing in the operation pool.
@@ -0,0 +1,27 @@
+package voluntaryexits
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	numPendingExits = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "num_pending_voluntary_exits",
+			Help: "Number of pending voluntary exits in the pool",
+		},
+	)
+	numExitsInserted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "voluntary_exits_inserted_total",
+			Help: "Number of voluntary exits inserted in the pool",
+		},
+	)
+	numExitsEvicted = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "voluntary_exits_evicted_total",
+			Help: "Number of voluntary exits evicted because the pool was full",
+		},
+	)
+)
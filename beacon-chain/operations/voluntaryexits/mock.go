@@ -18,12 +18,22 @@ func (m *PoolMock) PendingExits(_ *beaconstate.BeaconState, _ types.Slot, _ bool
 	return m.Exits
 }
 
+// PendingExitsOrdered --
+func (m *PoolMock) PendingExitsOrdered(_ *beaconstate.BeaconState, _ types.Slot, _ bool, _ ExitOrdering) []*eth.SignedVoluntaryExit {
+	return m.Exits
+}
+
 // InsertVoluntaryExit --
 func (m *PoolMock) InsertVoluntaryExit(_ context.Context, _ *beaconstate.BeaconState, exit *eth.SignedVoluntaryExit) {
 	m.Exits = append(m.Exits, exit)
 }
 
 // MarkIncluded --
-func (*PoolMock) MarkIncluded(_ *eth.SignedVoluntaryExit) {
-	panic("implement me")
+func (m *PoolMock) MarkIncluded(exit *eth.SignedVoluntaryExit) {
+	for i, e := range m.Exits {
+		if e == exit {
+			m.Exits = append(m.Exits[:i], m.Exits[i+1:]...)
+			return
+		}
+	}
 }
@@ -2,6 +2,7 @@ package voluntaryexits
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 
@@ -17,30 +18,118 @@ import (
 // This pool is used by proposers to insert voluntary exits into new blocks.
 type PoolManager interface {
 	PendingExits(state *beaconstate.BeaconState, slot types.Slot, noLimit bool) []*ethpb.SignedVoluntaryExit
+	PendingExitsOrdered(state *beaconstate.BeaconState, slot types.Slot, noLimit bool, ordering ExitOrdering) []*ethpb.SignedVoluntaryExit
 	InsertVoluntaryExit(ctx context.Context, state *beaconstate.BeaconState, exit *ethpb.SignedVoluntaryExit)
 	MarkIncluded(exit *ethpb.SignedVoluntaryExit)
 }
 
+// ExitOrdering is the priority used to order pending exits when they are packed into a block.
+type ExitOrdering uint8
+
+const (
+	// DefaultOrdering defers to the ordering the pool was configured with.
+	DefaultOrdering ExitOrdering = iota
+	// LowestIndexFirst prioritizes exits of the validators with the lowest indices.
+	LowestIndexFirst
+	// EarliestSubmittedFirst prioritizes exits in the order they were first inserted into the pool.
+	EarliestSubmittedFirst
+)
+
+// String returns the flag value corresponding to the ordering.
+func (o ExitOrdering) String() string {
+	switch o {
+	case DefaultOrdering:
+		return "default"
+	case LowestIndexFirst:
+		return "lowest-index"
+	case EarliestSubmittedFirst:
+		return "earliest-submitted"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(o))
+	}
+}
+
+// ParseExitOrdering converts a flag value such as "lowest-index" or "earliest-submitted"
+// into an ExitOrdering.
+func ParseExitOrdering(s string) (ExitOrdering, error) {
+	switch s {
+	case LowestIndexFirst.String():
+		return LowestIndexFirst, nil
+	case EarliestSubmittedFirst.String():
+		return EarliestSubmittedFirst, nil
+	default:
+		return DefaultOrdering, fmt.Errorf("unknown exit ordering %q", s)
+	}
+}
+
 // Pool is a concrete implementation of PoolManager.
 type Pool struct {
-	lock    sync.RWMutex
-	pending []*ethpb.SignedVoluntaryExit
+	lock     sync.RWMutex
+	pending  []*ethpb.SignedVoluntaryExit
+	ordering ExitOrdering
+	// insertedAt records the insertion sequence number of each pending exit, keyed by validator index.
+	insertedAt map[types.ValidatorIndex]uint64
+	seq        uint64
+	maxPending int
 }
 
 // NewPool accepts a head fetcher (for reading the validator set) and returns an initialized
 // voluntary exit pool.
 func NewPool() *Pool {
+	return NewPoolWithOrdering(LowestIndexFirst)
+}
+
+// NewPoolWithOrdering returns an initialized voluntary exit pool which orders pending exits
+// for block inclusion using the given ordering.
+func NewPoolWithOrdering(ordering ExitOrdering) *Pool {
+	return NewPoolWithCapacity(ordering, 0)
+}
+
+// NewPoolWithCapacity returns an initialized voluntary exit pool which orders pending exits for
+// block inclusion using the given ordering, and holds at most maxPending exits. Once full, the
+// exit packed last under that ordering is evicted to make room for new ones. A capacity of 0
+// leaves the pool unbounded.
+func NewPoolWithCapacity(ordering ExitOrdering, maxPending int) *Pool {
 	return &Pool{
-		pending: make([]*ethpb.SignedVoluntaryExit, 0),
+		pending:    make([]*ethpb.SignedVoluntaryExit, 0),
+		ordering:   ordering,
+		insertedAt: make(map[types.ValidatorIndex]uint64),
+		maxPending: maxPending,
 	}
 }
 
-// PendingExits returns exits that are ready for inclusion at the given slot. This method will not
-// return more than the block enforced MaxVoluntaryExits.
+// PendingExits returns exits that are ready for inclusion at the given slot, ordered by the
+// pool's configured ordering. This method will not return more than the block enforced MaxVoluntaryExits.
 func (p *Pool) PendingExits(state *beaconstate.BeaconState, slot types.Slot, noLimit bool) []*ethpb.SignedVoluntaryExit {
+	return p.PendingExitsOrdered(state, slot, noLimit, DefaultOrdering)
+}
+
+// PendingExitsOrdered returns exits that are ready for inclusion at the given slot in the given
+// ordering. DefaultOrdering uses the pool's configured ordering. This method will not return more
+// than the block enforced MaxVoluntaryExits.
+func (p *Pool) PendingExitsOrdered(
+	state *beaconstate.BeaconState,
+	slot types.Slot,
+	noLimit bool,
+	ordering ExitOrdering,
+) []*ethpb.SignedVoluntaryExit {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
+	if ordering == DefaultOrdering {
+		ordering = p.ordering
+	}
+	candidates := p.pending
+	if ordering == EarliestSubmittedFirst {
+		candidates = make([]*ethpb.SignedVoluntaryExit, len(p.pending))
+		copy(candidates, p.pending)
+		// Exits with the same sequence number, which only happens for exits not inserted through
+		// InsertVoluntaryExit, keep their validator index order.
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return p.insertedAt[candidates[i].Exit.ValidatorIndex] < p.insertedAt[candidates[j].Exit.ValidatorIndex]
+		})
+	}
+
 	// Allocate pending slice with a capacity of min(len(p.pending), maxVoluntaryExits) since the
 	// array cannot exceed the max and is typically less than the max value.
 	maxExits := params.BeaconConfig().MaxVoluntaryExits
@@ -48,7 +137,7 @@ func (p *Pool) PendingExits(state *beaconstate.BeaconState, slot types.Slot, noL
 		maxExits = uint64(len(p.pending))
 	}
 	pending := make([]*ethpb.SignedVoluntaryExit, 0, maxExits)
-	for _, e := range p.pending {
+	for _, e := range candidates {
 		if e.Exit.Epoch > helpers.SlotToEpoch(slot) {
 			continue
 		}
@@ -94,10 +183,36 @@ func (p *Pool) InsertVoluntaryExit(ctx context.Context, state *beaconstate.Beaco
 	}
 
 	// Insert into pending list and sort.
+	if p.insertedAt == nil {
+		p.insertedAt = make(map[types.ValidatorIndex]uint64)
+	}
+	p.seq++
+	p.insertedAt[exit.Exit.ValidatorIndex] = p.seq
 	p.pending = append(p.pending, exit)
 	sort.Slice(p.pending, func(i, j int) bool {
 		return p.pending[i].Exit.ValidatorIndex < p.pending[j].Exit.ValidatorIndex
 	})
+	numExitsInserted.Inc()
+	if p.maxPending > 0 && len(p.pending) > p.maxPending {
+		p.evictLast()
+	}
+	numPendingExits.Set(float64(len(p.pending)))
+}
+
+// evictLast removes the pending exit which would be packed last into blocks under the pool's
+// ordering: the exit of the highest validator index, or the latest inserted one.
+func (p *Pool) evictLast() {
+	index := len(p.pending) - 1
+	if p.ordering == EarliestSubmittedFirst {
+		for i, e := range p.pending {
+			if p.insertedAt[e.Exit.ValidatorIndex] > p.insertedAt[p.pending[index].Exit.ValidatorIndex] {
+				index = i
+			}
+		}
+	}
+	delete(p.insertedAt, p.pending[index].Exit.ValidatorIndex)
+	p.pending = append(p.pending[:index], p.pending[index+1:]...)
+	numExitsEvicted.Inc()
 }
 
 // MarkIncluded is used when an exit has been included in a beacon block. Every block seen by this
@@ -110,6 +225,8 @@ func (p *Pool) MarkIncluded(exit *ethpb.SignedVoluntaryExit) {
 	if exists {
 		// Exit we want is present at p.pending[index], so we remove it.
 		p.pending = append(p.pending[:index], p.pending[index+1:]...)
+		delete(p.insertedAt, exit.Exit.ValidatorIndex)
+		numPendingExits.Set(float64(len(p.pending)))
 	}
 }
 
@@ -529,3 +529,111 @@ func TestPool_PendingExits(t *testing.T) {
 		})
 	}
 }
+
+func TestPool_PendingExitsOrdered(t *testing.T) {
+	validators := make([]*ethpb.Validator, 4)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{ExitEpoch: params.BeaconConfig().FarFutureEpoch}
+	}
+	s, err := beaconstate.InitializeFromProtoUnsafe(&p2ppb.BeaconState{Validators: validators})
+	require.NoError(t, err)
+
+	insert := func(p *Pool) {
+		for _, idx := range []types.ValidatorIndex{3, 1, 2, 0} {
+			p.InsertVoluntaryExit(context.Background(), s, &ethpb.SignedVoluntaryExit{
+				Exit: &ethpb.VoluntaryExit{ValidatorIndex: idx},
+			})
+		}
+	}
+	indices := func(exits []*ethpb.SignedVoluntaryExit) []types.ValidatorIndex {
+		res := make([]types.ValidatorIndex, len(exits))
+		for i, e := range exits {
+			res[i] = e.Exit.ValidatorIndex
+		}
+		return res
+	}
+
+	t.Run("Lowest index first", func(t *testing.T) {
+		p := NewPoolWithOrdering(LowestIndexFirst)
+		insert(p)
+		want := []types.ValidatorIndex{0, 1, 2, 3}
+		require.DeepEqual(t, want, indices(p.PendingExits(s, 0, false)))
+		require.DeepEqual(t, want, indices(p.PendingExitsOrdered(s, 0, false, LowestIndexFirst)))
+	})
+	t.Run("Earliest submitted first", func(t *testing.T) {
+		p := NewPoolWithOrdering(EarliestSubmittedFirst)
+		insert(p)
+		want := []types.ValidatorIndex{3, 1, 2, 0}
+		require.DeepEqual(t, want, indices(p.PendingExits(s, 0, false)))
+		require.DeepEqual(t, want, indices(p.PendingExitsOrdered(s, 0, false, EarliestSubmittedFirst)))
+	})
+	t.Run("Explicit ordering overrides pool default", func(t *testing.T) {
+		p := NewPool()
+		insert(p)
+		require.DeepEqual(t, []types.ValidatorIndex{3, 1, 2, 0}, indices(p.PendingExitsOrdered(s, 0, false, EarliestSubmittedFirst)))
+	})
+	t.Run("Replacing an exit keeps its submission priority", func(t *testing.T) {
+		p := NewPoolWithOrdering(EarliestSubmittedFirst)
+		p.InsertVoluntaryExit(context.Background(), s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 2, Epoch: 5}})
+		p.InsertVoluntaryExit(context.Background(), s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1}})
+		p.InsertVoluntaryExit(context.Background(), s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 2, Epoch: 0}})
+		require.DeepEqual(t, []types.ValidatorIndex{2, 1}, indices(p.PendingExits(s, 0, false)))
+	})
+	t.Run("Block limit applies after ordering", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconConfig()
+		cfg.MaxVoluntaryExits = 2
+		params.OverrideBeaconConfig(cfg)
+		p := NewPoolWithOrdering(EarliestSubmittedFirst)
+		insert(p)
+		require.DeepEqual(t, []types.ValidatorIndex{3, 1}, indices(p.PendingExits(s, 0, false)))
+	})
+}
+
+func TestPool_InsertVoluntaryExit_Capacity(t *testing.T) {
+	validators := make([]*ethpb.Validator, 4)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{ExitEpoch: params.BeaconConfig().FarFutureEpoch}
+	}
+	s, err := beaconstate.InitializeFromProtoUnsafe(&p2ppb.BeaconState{Validators: validators})
+	require.NoError(t, err)
+
+	insert := func(p *Pool) []types.ValidatorIndex {
+		for _, idx := range []types.ValidatorIndex{2, 0, 3, 1} {
+			p.InsertVoluntaryExit(context.Background(), s, &ethpb.SignedVoluntaryExit{
+				Exit: &ethpb.VoluntaryExit{ValidatorIndex: idx},
+			})
+		}
+		res := make([]types.ValidatorIndex, len(p.pending))
+		for i, e := range p.pending {
+			res[i] = e.Exit.ValidatorIndex
+		}
+		return res
+	}
+
+	t.Run("Lowest index first evicts the highest index", func(t *testing.T) {
+		p := NewPoolWithCapacity(LowestIndexFirst, 2)
+		require.DeepEqual(t, []types.ValidatorIndex{0, 1}, insert(p))
+		require.Equal(t, 2, len(p.insertedAt))
+	})
+	t.Run("Earliest submitted first evicts the latest inserted", func(t *testing.T) {
+		p := NewPoolWithCapacity(EarliestSubmittedFirst, 2)
+		require.DeepEqual(t, []types.ValidatorIndex{0, 2}, insert(p))
+		require.Equal(t, 2, len(p.insertedAt))
+	})
+	t.Run("No limit", func(t *testing.T) {
+		p := NewPoolWithCapacity(LowestIndexFirst, 0)
+		require.DeepEqual(t, []types.ValidatorIndex{0, 1, 2, 3}, insert(p))
+	})
+}
+
+func TestParseExitOrdering(t *testing.T) {
+	o, err := ParseExitOrdering("lowest-index")
+	require.NoError(t, err)
+	require.Equal(t, LowestIndexFirst, o)
+	o, err = ParseExitOrdering("earliest-submitted")
+	require.NoError(t, err)
+	require.Equal(t, EarliestSubmittedFirst, o)
+	_, err = ParseExitOrdering("random")
+	require.ErrorContains(t, "unknown exit ordering", err)
+}
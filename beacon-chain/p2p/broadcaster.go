@@ -20,6 +20,11 @@ import (
 // GossipTypeMapping.
 var ErrMessageNotMapped = errors.New("message type is not mapped to a PubSub topic")
 
+// ErrTopicNotSubscribed occurs on a Broadcast attempt to a topic the node is not subscribed to yet
+// when no peer of the topic is found before the deadline, which is common while gossip
+// subscriptions are set up at startup.
+var ErrTopicNotSubscribed = errors.New("not subscribed to gossip topic")
+
 // Broadcast a message to the p2p network.
 func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 	ctx, span := trace.StartSpan(ctx, "p2p.Broadcast")
@@ -7,6 +7,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
@@ -60,13 +61,26 @@ func (s *Service) PublishToTopic(ctx context.Context, topic string, data []byte,
 		}
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			if !s.isSubscribed(topic) {
+				return errors.Wrapf(ErrTopicNotSubscribed, "no peers for topic %s", topic)
+			}
+			return errors.Wrapf(ctx.Err(), "no peers for topic %s", topic)
 		default:
 			time.Sleep(100 * time.Millisecond)
 		}
 	}
 }
 
+// isSubscribed returns whether the node is subscribed to a PubSub topic.
+func (s *Service) isSubscribed(topic string) bool {
+	for _, t := range s.pubsub.GetTopics() {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
 // SubscribeToTopic joins (if necessary) and subscribes to PubSub topic.
 func (s *Service) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub.Subscription, error) {
 	s.awaitStateInitialized() // Genesis time and genesis validator root are required to subscribe.
@@ -2,14 +2,17 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	testp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
@@ -57,6 +60,48 @@ func TestService_PublishToTopicConcurrentMapWrite(t *testing.T) {
 	wg.Wait()
 }
 
+func TestService_PublishToTopic_NoTopicPeers(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{MinimumSyncPeers: 1})
+	defer flags.Init(resetFlags)
+
+	p0 := testp2p.NewTestP2P(t)
+	s := &Service{
+		pubsub:       p0.PubSub(),
+		joinedTopics: map[string]*pubsub.Topic{},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := s.PublishToTopic(ctx, "/eth2/testing/ssz_snappy", []byte{})
+	assert.ErrorContains(t, ErrTopicNotSubscribed.Error(), err)
+	assert.Equal(t, true, errors.Is(err, ErrTopicNotSubscribed))
+}
+
+func TestService_PublishToTopic_SubscribedNoTopicPeers(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{MinimumSyncPeers: 1})
+	defer flags.Init(resetFlags)
+
+	p0 := testp2p.NewTestP2P(t)
+	s := &Service{
+		pubsub:       p0.PubSub(),
+		joinedTopics: map[string]*pubsub.Topic{},
+	}
+	topic := "/eth2/testing/ssz_snappy"
+	topicHandle, err := s.JoinTopic(topic)
+	require.NoError(t, err)
+	sub, err := topicHandle.Subscribe()
+	require.NoError(t, err)
+	defer sub.Cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err = s.PublishToTopic(ctx, topic, []byte{})
+	assert.Equal(t, true, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, false, errors.Is(err, ErrTopicNotSubscribed))
+}
+
 func TestMessageIDFunction_HashesCorrectly(t *testing.T) {
 	invalidSnappy := [32]byte{'J', 'U', 'N', 'K'}
 	pMsg := &pubsubpb.Message{Data: invalidSnappy[:]}
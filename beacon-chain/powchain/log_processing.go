@@ -31,6 +31,7 @@ var (
 
 const eth1LookBackPeriod = 100
 const eth1DataSavingInterval = 100
+const eth1BlockSavingInterval = 1000
 const maxTolerableDifference = 50
 const defaultEth1HeaderReqLimit = uint64(1000)
 const depositlogRequestLimit = 10000
@@ -255,6 +256,8 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 	// block of the deployment of the deposit contract.
 	if deploymentBlock > currentBlockNum {
 		currentBlockNum = deploymentBlock
+	} else {
+		log.WithField("block", currentBlockNum).Info("Resuming processing of deposit logs from the last requested block")
 	}
 	// To store all blocks.
 	headersMap := make(map[uint64]*gethTypes.Header)
@@ -348,6 +351,10 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 			return err
 		}
 		currentBlockNum = end
+		s.latestEth1Data.LastRequestedBlock = currentBlockNum
+		if err := s.saveProgress(ctx); err != nil {
+			return err
+		}
 
 		if batchSize < s.eth1HeaderReqLimit {
 			// update the batchSize with additive increase
@@ -359,6 +366,9 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 	}
 
 	s.latestEth1Data.LastRequestedBlock = currentBlockNum
+	if err := s.saveProgress(ctx); err != nil {
+		return err
+	}
 
 	c, err := s.beaconDB.FinalizedCheckpoint(ctx)
 	if err != nil {
@@ -407,7 +417,7 @@ func (s *Service) requestBatchedHeadersAndLogs(ctx context.Context) error {
 		s.latestEth1Data.LastRequestedBlock = i
 	}
 
-	return nil
+	return s.saveProgress(ctx)
 }
 
 // requestMissingLogs requests any logs that were missed by requesting from previous blocks
@@ -528,14 +538,44 @@ func (s *Service) checkForChainstart(blockHash [32]byte, blockNumber *big.Int, b
 	}
 }
 
-// save all powchain related metadata to disk.
+// saveProgress saves the powchain data once the last requested block is far enough past the one
+// of the previous save, so that log processing resumes close to where it stopped after a restart,
+// even when no deposits were made in the meantime.
+func (s *Service) saveProgress(ctx context.Context) error {
+	if s.latestEth1Data.LastRequestedBlock < s.lastSavedBlock+eth1BlockSavingInterval {
+		return nil
+	}
+	return s.savePowchainData(ctx)
+}
+
+// save all powchain related metadata to disk. The deposit trie is not saved, as it is rebuilt from
+// the deposits and the snapshot of the finalized deposits on startup.
 func (s *Service) savePowchainData(ctx context.Context) error {
+	// The last requested block and the snapshot are read before the deposits are listed, so that
+	// neither of them is ever ahead of the saved deposits. Log processing resumes from that block
+	// on restart, skipping the deposits it already has.
+	latestEth1Data := &protodb.LatestETH1Data{
+		BlockHeight:        s.latestEth1Data.GetBlockHeight(),
+		BlockTime:          s.latestEth1Data.GetBlockTime(),
+		BlockHash:          bytesutil.SafeCopyBytes(s.latestEth1Data.GetBlockHash()),
+		LastRequestedBlock: s.latestEth1Data.GetLastRequestedBlock(),
+	}
+	snapshot, err := s.depositCache.FinalizedDepositsSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not snapshot finalized deposits")
+	}
 	eth1Data := &protodb.ETH1ChainData{
-		CurrentEth1Data:   s.latestEth1Data,
+		CurrentEth1Data:   latestEth1Data,
 		ChainstartData:    s.chainStartData,
 		BeaconState:       s.preGenesisState.InnerStateUnsafe(), // I promise not to mutate it!
-		Trie:              s.depositTrie.ToProto(),
 		DepositContainers: s.depositCache.AllDepositContainers(ctx),
 	}
-	return s.beaconDB.SavePowchainData(ctx, eth1Data)
+	if err := s.beaconDB.SavePowchainData(ctx, eth1Data); err != nil {
+		return err
+	}
+	s.lastSavedBlock = latestEth1Data.LastRequestedBlock
+	if snapshot == nil {
+		return nil
+	}
+	return s.beaconDB.SaveDepositSnapshot(ctx, snapshot)
 }
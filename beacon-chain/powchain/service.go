@@ -4,6 +4,7 @@
 package powchain
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
@@ -54,6 +55,18 @@ var (
 		Name: "powchain_missed_deposit_logs",
 		Help: "The number of times a missed deposit log is detected",
 	})
+	activeEndpointGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "powchain_active_endpoint",
+		Help: "Set to 1 for the eth1 endpoint currently in use and to 0 for the other configured endpoints",
+	}, []string{"endpoint"})
+	endpointFailoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_endpoint_failovers_total",
+		Help: "The number of times the service switched to another eth1 endpoint",
+	})
+	unhealthyEndpointCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_unhealthy_endpoint_total",
+		Help: "The number of times the active eth1 endpoint failed a health check",
+	})
 )
 
 // time to wait before trying to reconnect with the eth1 node.
@@ -65,6 +78,9 @@ var logThreshold = 8
 // period to log chainstart related information
 var logPeriod = 1 * time.Minute
 
+// period to check the sync status and chain id of the active eth1 endpoint.
+var endpointHealthCheckPeriod = 1 * time.Minute
+
 // error when eth1 node is not synced.
 var errNotSynced = errors.New("eth1 node is still syncing")
 
@@ -113,6 +129,7 @@ type RPCDataFetcher interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error)
 	HeaderByHash(ctx context.Context, hash common.Hash) (*gethTypes.Header, error)
 	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+	ChainID(ctx context.Context) (*big.Int, error)
 }
 
 // RPCClient defines the rpc methods required to interact with the eth1 node.
@@ -148,7 +165,8 @@ type Service struct {
 	chainStartData          *protodb.ChainStartData
 	beaconDB                db.HeadAccessDatabase // Circular dep if using HeadFetcher.
 	depositCache            *depositcache.DepositCache
-	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
+	lastReceivedMerkleIndex int64  // Keeps track of the last received index to prevent log spam.
+	lastSavedBlock          uint64 // Last requested eth1 block at the time of the last save of the powchain data.
 	runError                error
 	preGenesisState         *stateTrie.BeaconState
 	stateGen                *stategen.State
@@ -186,17 +204,10 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 		eth1HeaderReqLimit = defaultEth1HeaderReqLimit
 	}
 
-	endpoints := dedupEndpoints(config.HTTPEndpoints)
-	// Select first http endpoint in the provided list.
-	currEndpoint := ""
-	if len(endpoints) > 0 {
-		currEndpoint = endpoints[0]
-	}
 	s := &Service{
-		ctx:              ctx,
-		cancel:           cancel,
-		httpEndpoints:    endpoints,
-		currHttpEndpoint: currEndpoint,
+		ctx:           ctx,
+		cancel:        cancel,
+		httpEndpoints: dedupEndpoints(config.HTTPEndpoints),
 		latestEth1Data: &protodb.LatestETH1Data{
 			BlockHeight:        0,
 			BlockTime:          0,
@@ -219,13 +230,29 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 		stateGen:                config.StateGen,
 		eth1HeaderReqLimit:      eth1HeaderReqLimit,
 	}
+	// Select first http endpoint in the provided list.
+	if len(s.httpEndpoints) > 0 {
+		s.setActiveEndpoint(s.httpEndpoints[0])
+	}
 
 	eth1Data, err := config.BeaconDB.PowchainData(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to retrieve eth1 data")
 	}
 	if eth1Data != nil {
-		s.depositTrie = trieutil.CreateTrieFromProto(eth1Data.Trie)
+		snapshot, err := config.BeaconDB.DepositSnapshot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to retrieve deposit snapshot")
+		}
+		if eth1Data.Trie != nil {
+			// Older databases persist the whole deposit trie.
+			s.depositTrie = trieutil.CreateTrieFromProto(eth1Data.Trie)
+		} else {
+			s.depositTrie, err = depositTrieFromContainers(snapshot, eth1Data.DepositContainers)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not rebuild deposit trie")
+			}
+		}
 		s.chainStartData = eth1Data.ChainstartData
 		if !reflect.ValueOf(eth1Data.BeaconState).IsZero() {
 			s.preGenesisState, err = stateTrie.InitializeFromProto(eth1Data.BeaconState)
@@ -234,10 +261,16 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 			}
 		}
 		s.latestEth1Data = eth1Data.CurrentEth1Data
+		s.lastSavedBlock = s.latestEth1Data.GetLastRequestedBlock()
 		s.lastReceivedMerkleIndex = int64(len(s.depositTrie.Items()) - 1)
 		if err := s.initDepositCaches(ctx, eth1Data.DepositContainers); err != nil {
 			return nil, errors.Wrap(err, "could not initialize caches")
 		}
+		if snapshot != nil {
+			if err := s.depositCache.RestoreFinalizedDeposits(ctx, snapshot); err != nil {
+				return nil, errors.Wrap(err, "could not restore finalized deposits")
+			}
+		}
 	}
 	return s, nil
 }
@@ -279,6 +312,12 @@ func (s *Service) Stop() error {
 		defer s.cancel()
 	}
 	s.closeClients()
+	// Save the progress of log processing, so that it resumes from there on restart.
+	if s.beaconDB != nil && s.depositCache != nil {
+		if err := s.savePowchainData(context.Background()); err != nil {
+			return errors.Wrap(err, "could not save powchain data")
+		}
+	}
 	return nil
 }
 
@@ -480,6 +519,8 @@ func (s *Service) waitForConnection() {
 		s.runError = errConnect
 		log.WithError(errConnect).Error("Could not connect to powchain endpoint")
 	}
+	// Try the next endpoint on the following attempt, rather than waiting on the same one.
+	s.fallbackToNextEndpoint()
 	// Use a custom logger to only log errors
 	// once in  a while.
 	logCounter := 0
@@ -521,6 +562,7 @@ func (s *Service) waitForConnection() {
 			}
 			s.runError = errNotSynced
 			log.Debug("Eth1 node is currently syncing")
+			s.fallbackToNextEndpoint()
 		case <-s.ctx.Done():
 			log.Debug("Received cancelled context,closing existing powchain service")
 			return
@@ -538,6 +580,34 @@ func (s *Service) isEth1NodeSynced() (bool, error) {
 	return syncProg == nil, nil
 }
 
+// checkActiveEndpoint returns an error if the active eth1 node started syncing again or is no
+// longer on the deposit chain, so that the service fails over to another endpoint.
+func (s *Service) checkActiveEndpoint() error {
+	synced, err := s.isEth1NodeSynced()
+	if err != nil {
+		return errors.Wrap(err, "could not check sync status of eth1 chain")
+	}
+	if !synced {
+		return errNotSynced
+	}
+	cID, err := s.eth1DataFetcher.ChainID(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get chain id of eth1 chain")
+	}
+	if cID.Uint64() != params.BeaconConfig().DepositChainID {
+		return fmt.Errorf("eth1 node using incorrect chain id, %d != %d", cID.Uint64(), params.BeaconConfig().DepositChainID)
+	}
+	return nil
+}
+
+// failover closes the clients of the failed eth1 endpoint and reconnects through the next
+// configured endpoint.
+func (s *Service) failover(err error) {
+	s.closeClients()
+	s.fallbackToNextEndpoint()
+	s.retryETH1Node(err)
+}
+
 // Reconnect to eth1 node in case of any failure.
 func (s *Service) retryETH1Node(err error) {
 	s.runError = err
@@ -595,6 +665,38 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*protodb.Deposit
 	return nil
 }
 
+// depositTrieFromContainers rebuilds the deposit trie from the persisted deposits. The deposits
+// covered by the finalized deposit snapshot are pruned from the trie.
+func depositTrieFromContainers(snapshot *trieutil.DepositTreeSnapshot, ctrs []*protodb.DepositContainer) (*trieutil.SparseMerkleTrie, error) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	if len(ctrs) == 0 {
+		return trieutil.NewTrie(depth)
+	}
+	if snapshot == nil || snapshot.DepositCount > uint64(len(ctrs)) {
+		snapshot = &trieutil.DepositTreeSnapshot{}
+	}
+	leaves := make([][]byte, 0, uint64(len(ctrs))-snapshot.DepositCount)
+	for i := snapshot.DepositCount; i < uint64(len(ctrs)); i++ {
+		if ctrs[i].Index != int64(i) {
+			return nil, fmt.Errorf("deposit at position %d has index %d", i, ctrs[i].Index)
+		}
+		depositHash, err := ctrs[i].Deposit.Data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash deposit data")
+		}
+		leaves = append(leaves, depositHash[:])
+	}
+	depositTrie, err := trieutil.TrieFromSnapshot(snapshot, leaves, depth)
+	if err != nil {
+		return nil, err
+	}
+	root := depositTrie.Root()
+	if !bytes.Equal(root[:], ctrs[len(ctrs)-1].DepositRoot) {
+		return nil, fmt.Errorf("deposit trie root %#x does not match root %#x of the last deposit", root, ctrs[len(ctrs)-1].DepositRoot)
+	}
+	return depositTrie, nil
+}
+
 // processBlockHeader adds a newly observed eth1 block to the block cache and
 // updates the latest blockHeight, blockHash, and blockTime properties of the service.
 func (s *Service) processBlockHeader(header *gethTypes.Header) {
@@ -747,6 +849,8 @@ func (s *Service) run(done <-chan struct{}) {
 
 	chainstartTicker := time.NewTicker(logPeriod)
 	defer chainstartTicker.Stop()
+	healthTicker := time.NewTicker(endpointHealthCheckPeriod)
+	defer healthTicker.Stop()
 
 	for {
 		select {
@@ -760,12 +864,21 @@ func (s *Service) run(done <-chan struct{}) {
 			head, err := s.eth1DataFetcher.HeaderByNumber(s.ctx, nil)
 			if err != nil {
 				log.WithError(err).Debug("Could not fetch latest eth1 header")
-				s.retryETH1Node(err)
+				unhealthyEndpointCount.Inc()
+				s.failover(err)
 				continue
 			}
 			s.processBlockHeader(head)
 			s.handleETH1FollowDistance()
 			s.checkDefaultEndpoint()
+		case <-healthTicker.C:
+			if err := s.checkActiveEndpoint(); err != nil {
+				log.WithError(err).WithField(
+					"endpoint", logutil.MaskCredentialsLogging(s.currHttpEndpoint),
+				).Warn("Eth1 endpoint is unhealthy")
+				unhealthyEndpointCount.Inc()
+				s.failover(err)
+			}
 		case <-chainstartTicker.C:
 			if s.chainStartData.Chainstarted {
 				chainstartTicker.Stop()
@@ -878,7 +991,7 @@ func (s *Service) checkDefaultEndpoint() {
 
 	// Switch back to primary endpoint and try connecting
 	// to it again.
-	s.currHttpEndpoint = primaryEndpoint
+	s.setActiveEndpoint(primaryEndpoint)
 	s.retryETH1Node(nil)
 }
 
@@ -903,8 +1016,21 @@ func (s *Service) fallbackToNextEndpoint() {
 	if nextIndex == currIndex {
 		return
 	}
-	s.currHttpEndpoint = s.httpEndpoints[nextIndex]
-	log.Infof("Falling back to alternative endpoint: %s", s.currHttpEndpoint)
+	s.setActiveEndpoint(s.httpEndpoints[nextIndex])
+	endpointFailoverCount.Inc()
+	log.Infof("Falling back to alternative endpoint: %s", logutil.MaskCredentialsLogging(s.currHttpEndpoint))
+}
+
+// setActiveEndpoint switches the endpoint used to connect to the eth1 chain, and reports it
+// through the active endpoint metric.
+func (s *Service) setActiveEndpoint(endpoint string) {
+	s.currHttpEndpoint = endpoint
+	for _, e := range s.httpEndpoints {
+		if e != endpoint {
+			activeEndpointGauge.WithLabelValues(logutil.MaskCredentialsLogging(e)).Set(0)
+		}
+	}
+	activeEndpointGauge.WithLabelValues(logutil.MaskCredentialsLogging(endpoint)).Set(1)
 }
 
 func dedupEndpoints(endpoints []string) []string {
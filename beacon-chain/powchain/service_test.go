@@ -15,17 +15,20 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
 	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
 	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -116,6 +119,26 @@ func (g *goodFetcher) SyncProgress(_ context.Context) (*ethereum.SyncProgress, e
 	return nil, nil
 }
 
+func (g *goodFetcher) ChainID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(params.BeaconConfig().DepositChainID), nil
+}
+
+type syncingFetcher struct {
+	goodFetcher
+}
+
+func (s *syncingFetcher) SyncProgress(_ context.Context) (*ethereum.SyncProgress, error) {
+	return &ethereum.SyncProgress{CurrentBlock: 10, HighestBlock: 100}, nil
+}
+
+type wrongChainFetcher struct {
+	goodFetcher
+}
+
+func (w *wrongChainFetcher) ChainID(_ context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
 var depositsReqForChainStart = 64
 
 func TestStart_OK(t *testing.T) {
@@ -452,6 +475,137 @@ func TestInitDepositCache_OK(t *testing.T) {
 	require.Equal(t, 3, len(s.depositCache.PendingContainers(context.Background(), nil)))
 }
 
+// depositContainers returns the containers of n deposits along with the deposit trie holding them.
+func depositContainers(t *testing.T, n int) ([]*protodb.DepositContainer, *trieutil.SparseMerkleTrie) {
+	depositTrie, err := trieutil.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	ctrs := make([]*protodb.DepositContainer, n)
+	for i := range ctrs {
+		data := &ethpb.Deposit_Data{
+			PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+			WithdrawalCredentials: make([]byte, 32),
+			Signature:             make([]byte, 96),
+		}
+		depositHash, err := data.HashTreeRoot()
+		require.NoError(t, err)
+		depositTrie.Insert(depositHash[:], i)
+		root := depositTrie.Root()
+		ctrs[i] = &protodb.DepositContainer{
+			Index:           int64(i),
+			Eth1BlockHeight: uint64(10 + i),
+			Deposit:         &ethpb.Deposit{Data: data},
+			DepositRoot:     root[:],
+		}
+	}
+	return ctrs, depositTrie
+}
+
+func TestDepositTrieFromContainers(t *testing.T) {
+	ctrs, depositTrie := depositContainers(t, 5)
+	rebuilt, err := depositTrieFromContainers(nil, ctrs)
+	require.NoError(t, err)
+	assert.Equal(t, depositTrie.Root(), rebuilt.Root())
+
+	snapshot, err := depositTrie.Snapshot(3, 12)
+	require.NoError(t, err)
+	pruned, err := depositTrieFromContainers(snapshot, ctrs)
+	require.NoError(t, err)
+	assert.Equal(t, depositTrie.Root(), pruned.Root())
+	assert.Equal(t, 5, len(pruned.Items()))
+	want, err := depositTrie.MerkleProof(4)
+	require.NoError(t, err)
+	proof, err := pruned.MerkleProof(4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, proof)
+
+	ctrs[4].DepositRoot = make([]byte, 32)
+	_, err = depositTrieFromContainers(snapshot, ctrs)
+	assert.ErrorContains(t, "does not match root", err)
+}
+
+func TestSavePowchainData_RestoresFinalizedDeposits(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	s, err := NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:     beaconDB,
+		DepositCache: depositCache,
+	})
+	require.NoError(t, err)
+	ctrs, depositTrie := depositContainers(t, 5)
+	depositCache.InsertDepositContainers(ctx, ctrs)
+	depositCache.InsertFinalizedDeposits(ctx, 2)
+	s.depositTrie = depositTrie
+	require.NoError(t, s.savePowchainData(ctx))
+
+	eth1Data, err := beaconDB.PowchainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*protodb.SparseMerkleTrie)(nil), eth1Data.Trie, "Deposit trie was persisted")
+	snapshot, err := beaconDB.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), snapshot.DepositCount)
+
+	restartedCache, err := depositcache.New()
+	require.NoError(t, err)
+	restarted, err := NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:     beaconDB,
+		DepositCache: restartedCache,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, depositTrie.Root(), restarted.DepositRoot())
+	assert.Equal(t, int64(4), restarted.lastReceivedMerkleIndex)
+	finalized := restartedCache.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(2), finalized.MerkleTrieIndex)
+	assert.Equal(t, snapshot.DepositRoot, finalized.Deposits.HashTreeRoot())
+	assert.Equal(t, 5, len(restartedCache.AllDepositContainers(ctx)))
+}
+
+func TestSaveProgress_ResumesFromLastRequestedBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	s, err := NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:     beaconDB,
+		DepositCache: depositCache,
+	})
+	require.NoError(t, err)
+	ctrs, depositTrie := depositContainers(t, 3)
+	depositCache.InsertDepositContainers(ctx, ctrs)
+	s.depositTrie = depositTrie
+
+	// Progress is not saved until the saving interval is reached.
+	s.latestEth1Data.LastRequestedBlock = eth1BlockSavingInterval - 1
+	require.NoError(t, s.saveProgress(ctx))
+	eth1Data, err := beaconDB.PowchainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*protodb.ETH1ChainData)(nil), eth1Data)
+
+	s.latestEth1Data.LastRequestedBlock = eth1BlockSavingInterval + 5
+	require.NoError(t, s.saveProgress(ctx))
+	assert.Equal(t, uint64(eth1BlockSavingInterval+5), s.lastSavedBlock)
+	s.latestEth1Data.LastRequestedBlock = eth1BlockSavingInterval + 10
+	require.NoError(t, s.saveProgress(ctx))
+
+	restarted, err := NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:     beaconDB,
+		DepositCache: depositCache,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(eth1BlockSavingInterval+5), restarted.latestEth1Data.LastRequestedBlock)
+	assert.Equal(t, int64(2), restarted.lastReceivedMerkleIndex)
+
+	// Stopping the service saves its progress.
+	require.NoError(t, s.Stop())
+	restarted, err = NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:     beaconDB,
+		DepositCache: depositCache,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(eth1BlockSavingInterval+10), restarted.latestEth1Data.LastRequestedBlock)
+}
+
 func TestNewService_EarliestVotingBlock(t *testing.T) {
 	testAcc, err := contracts.Setup()
 	require.NoError(t, err, "Unable to set up simulated backend")
@@ -566,6 +720,32 @@ func TestServiceFallbackCorrectly(t *testing.T) {
 	assert.Equal(t, firstEndpoint, s1.currHttpEndpoint, "Unexpected http endpoint")
 }
 
+func TestService_CheckActiveEndpoint(t *testing.T) {
+	s := &Service{ctx: context.Background(), eth1DataFetcher: &goodFetcher{}}
+	require.NoError(t, s.checkActiveEndpoint())
+
+	s.eth1DataFetcher = &syncingFetcher{}
+	assert.ErrorContains(t, errNotSynced.Error(), s.checkActiveEndpoint())
+
+	s.eth1DataFetcher = &wrongChainFetcher{}
+	assert.ErrorContains(t, "incorrect chain id", s.checkActiveEndpoint())
+}
+
+func TestService_SetActiveEndpoint(t *testing.T) {
+	s := &Service{httpEndpoints: []string{"http://primary:8545", "ws://backup:8546"}}
+	s.setActiveEndpoint("ws://backup:8546")
+	assert.Equal(t, "ws://backup:8546", s.currHttpEndpoint)
+	assert.Equal(t, float64(0), promtestutil.ToFloat64(activeEndpointGauge.WithLabelValues("http://primary:8545")))
+	assert.Equal(t, float64(1), promtestutil.ToFloat64(activeEndpointGauge.WithLabelValues("ws://backup:8546")))
+
+	failovers := promtestutil.ToFloat64(endpointFailoverCount)
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "http://primary:8545", s.currHttpEndpoint)
+	assert.Equal(t, failovers+1, promtestutil.ToFloat64(endpointFailoverCount))
+	assert.Equal(t, float64(1), promtestutil.ToFloat64(activeEndpointGauge.WithLabelValues("http://primary:8545")))
+	assert.Equal(t, float64(0), promtestutil.ToFloat64(activeEndpointGauge.WithLabelValues("ws://backup:8546")))
+}
+
 func TestDedupEndpoints(t *testing.T) {
 	assert.DeepEqual(t, []string{"A"}, dedupEndpoints([]string{"A"}), "did not dedup correctly")
 	assert.DeepEqual(t, []string{"A", "B"}, dedupEndpoints([]string{"A", "B"}), "did not dedup correctly")
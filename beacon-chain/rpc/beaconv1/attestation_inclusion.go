@@ -0,0 +1,157 @@
+package beaconv1
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttestationInclusionRequest identifies the attestation of a validator in an epoch.
+type AttestationInclusionRequest struct {
+	ValidatorIndex types.ValidatorIndex `json:"validator_index"`
+	Epoch          types.Epoch          `json:"epoch"`
+}
+
+// AttestationInclusionResponse reports whether the attestation of a validator was included in the
+// canonical chain, and in which block.
+type AttestationInclusionResponse struct {
+	Included        bool       `json:"included"`
+	AttestationSlot types.Slot `json:"attestation_slot"`
+	BlockRoot       []byte     `json:"block_root,omitempty"`
+	InclusionSlot   types.Slot `json:"inclusion_slot,omitempty"`
+	InclusionDelay  types.Slot `json:"inclusion_delay,omitempty"`
+}
+
+// GetAttestationInclusion looks up the first canonical block including the attestation of a
+// validator in an epoch. The attestation inclusions archive is used if it covers the epoch,
+// otherwise the blocks of the inclusion window of the attestation are searched, which requires the
+// state of the epoch to compute the committee of the validator.
+func (bs *Server) GetAttestationInclusion(ctx context.Context, req *AttestationInclusionRequest) (*AttestationInclusionResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.GetAttestationInclusion")
+	defer span.End()
+
+	currentEpoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+	if req.Epoch > currentEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Epoch %d is later than the current epoch %d", req.Epoch, currentEpoch)
+	}
+
+	archived, err := bs.BeaconDB.AttestationInclusion(ctx, req.Epoch, req.ValidatorIndex)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get archived attestation inclusion: %v", err)
+	}
+	if archived != nil {
+		blk, root, err := bs.canonicalBlockAtSlot(ctx, archived.InclusionSlot)
+		if err != nil {
+			return nil, err
+		}
+		// The archived inclusion may belong to a block which is no longer canonical, in which case
+		// the canonical blocks are searched.
+		if blk != nil && includesAttestationAtSlot(blk, archived.AttestationSlot) {
+			return &AttestationInclusionResponse{
+				Included:        true,
+				AttestationSlot: archived.AttestationSlot,
+				BlockRoot:       root[:],
+				InclusionSlot:   archived.InclusionSlot,
+				InclusionDelay:  archived.InclusionSlot - archived.AttestationSlot,
+			}, nil
+		}
+	}
+
+	startSlot, err := helpers.StartSlot(req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid epoch: %v", err)
+	}
+	epochState, err := bs.StateGenService.StateBySlot(ctx, startSlot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get state of epoch %d: %v", req.Epoch, err)
+	}
+	assignments, _, err := helpers.CommitteeAssignments(epochState, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
+	}
+	assignment, ok := assignments[req.ValidatorIndex]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Validator %d has no attestation duty in epoch %d", req.ValidatorIndex, req.Epoch)
+	}
+	position := -1
+	for i, idx := range assignment.Committee {
+		if idx == req.ValidatorIndex {
+			position = i
+			break
+		}
+	}
+
+	resp := &AttestationInclusionResponse{AttestationSlot: assignment.AttesterSlot}
+	lastSlot := assignment.AttesterSlot + params.BeaconConfig().SlotsPerEpoch
+	if headSlot := bs.ChainInfoFetcher.HeadSlot(); lastSlot > headSlot {
+		lastSlot = headSlot
+	}
+	for slot := assignment.AttesterSlot + params.BeaconConfig().MinAttestationInclusionDelay; slot <= lastSlot; slot++ {
+		if err := ctx.Err(); err != nil {
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while searching blocks: %v", err)
+		}
+		blk, root, err := bs.canonicalBlockAtSlot(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if blk == nil {
+			continue
+		}
+		for _, att := range blk.Block.Body.Attestations {
+			if att.Data.Slot != assignment.AttesterSlot || att.Data.CommitteeIndex != assignment.CommitteeIndex {
+				continue
+			}
+			if uint64(position) < att.AggregationBits.Len() && att.AggregationBits.BitAt(uint64(position)) {
+				resp.Included = true
+				resp.BlockRoot = root[:]
+				resp.InclusionSlot = slot
+				resp.InclusionDelay = slot - assignment.AttesterSlot
+				return resp, nil
+			}
+		}
+	}
+	return resp, nil
+}
+
+// canonicalBlockAtSlot returns the canonical block at a slot along with its root, or nil if the
+// slot is empty in the canonical chain.
+func (bs *Server) canonicalBlockAtSlot(ctx context.Context, slot types.Slot) (*eth.SignedBeaconBlock, [32]byte, error) {
+	_, roots, err := bs.BeaconDB.BlockRootsBySlot(ctx, slot)
+	if err != nil {
+		return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not retrieve block roots for slot %d: %v", slot, err)
+	}
+	for _, root := range roots {
+		canonical, err := bs.ChainInfoFetcher.IsCanonical(ctx, root)
+		if err != nil {
+			return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not determine if block root is canonical: %v", err)
+		}
+		if !canonical {
+			continue
+		}
+		blk, err := bs.BeaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, [32]byte{}, status.Errorf(codes.Internal, "Could not retrieve block: %v", err)
+		}
+		if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+			return nil, [32]byte{}, nil
+		}
+		return blk, root, nil
+	}
+	return nil, [32]byte{}, nil
+}
+
+// includesAttestationAtSlot returns whether a block includes an attestation for a slot.
+func includesAttestationAtSlot(blk *eth.SignedBeaconBlock, slot types.Slot) bool {
+	for _, att := range blk.Block.Body.Attestations {
+		if att.Data != nil && att.Data.Slot == slot {
+			return true
+		}
+	}
+	return false
+}
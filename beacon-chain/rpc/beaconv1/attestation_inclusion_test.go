@@ -0,0 +1,115 @@
+package beaconv1
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb_alpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestGetAttestationInclusion(t *testing.T) {
+	ctx := context.Background()
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	assignments, _, err := helpers.CommitteeAssignments(beaconState, 0)
+	require.NoError(t, err)
+	included, missed := types.ValidatorIndex(0), types.ValidatorIndex(1)
+	assignment := assignments[included]
+	require.NotNil(t, assignment)
+
+	bits := bitfield.NewBitlist(uint64(len(assignment.Committee)))
+	for i, idx := range assignment.Committee {
+		if idx == included {
+			bits.SetBitAt(uint64(i), true)
+		}
+	}
+	att := testutil.HydrateAttestation(&ethpb_alpha.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb_alpha.AttestationData{
+			Slot:           assignment.AttesterSlot,
+			CommitteeIndex: assignment.CommitteeIndex,
+		},
+	})
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = assignment.AttesterSlot + 2
+	blk.Block.Body.Attestations = []*ethpb_alpha.Attestation{att}
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	newServer := func(t *testing.T) (*Server, db.Database) {
+		beaconDB := dbTest.SetupDB(t)
+		require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+		stateGen := stategen.NewMockService()
+		stateGen.StatesBySlot[0] = beaconState
+		headState := beaconState.Copy()
+		require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch*2))
+		currentSlot := headState.Slot()
+		c := &mock.ChainService{State: headState, Slot: &currentSlot}
+		return &Server{
+			BeaconDB:           beaconDB,
+			ChainInfoFetcher:   c,
+			GenesisTimeFetcher: c,
+			StateGenService:    stateGen,
+		}, beaconDB
+	}
+
+	t.Run("searched blocks", func(t *testing.T) {
+		s, _ := newServer(t)
+		resp, err := s.GetAttestationInclusion(ctx, &AttestationInclusionRequest{ValidatorIndex: included})
+		require.NoError(t, err)
+		assert.Equal(t, true, resp.Included)
+		assert.Equal(t, assignment.AttesterSlot, resp.AttestationSlot)
+		assert.Equal(t, blk.Block.Slot, resp.InclusionSlot)
+		assert.Equal(t, types.Slot(2), resp.InclusionDelay)
+		assert.DeepEqual(t, root[:], resp.BlockRoot)
+
+		resp, err = s.GetAttestationInclusion(ctx, &AttestationInclusionRequest{ValidatorIndex: missed})
+		require.NoError(t, err)
+		assert.Equal(t, false, resp.Included)
+		assert.Equal(t, assignments[missed].AttesterSlot, resp.AttestationSlot)
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		s, beaconDB := newServer(t)
+		// The archive is used without computing committees.
+		s.StateGenService = stategen.NewMockService()
+		require.NoError(t, beaconDB.SaveAttestationInclusions(ctx, []*db.AttestationInclusion{
+			{ValidatorIndex: included, AttestationSlot: assignment.AttesterSlot, InclusionSlot: blk.Block.Slot},
+		}))
+		resp, err := s.GetAttestationInclusion(ctx, &AttestationInclusionRequest{ValidatorIndex: included})
+		require.NoError(t, err)
+		assert.Equal(t, true, resp.Included)
+		assert.Equal(t, blk.Block.Slot, resp.InclusionSlot)
+		assert.DeepEqual(t, root[:], resp.BlockRoot)
+	})
+
+	t.Run("archived block not canonical", func(t *testing.T) {
+		s, beaconDB := newServer(t)
+		s.ChainInfoFetcher.(*mock.ChainService).CanonicalRoots = map[[32]byte]bool{}
+		require.NoError(t, beaconDB.SaveAttestationInclusions(ctx, []*db.AttestationInclusion{
+			{ValidatorIndex: included, AttestationSlot: assignment.AttesterSlot, InclusionSlot: blk.Block.Slot},
+		}))
+		resp, err := s.GetAttestationInclusion(ctx, &AttestationInclusionRequest{ValidatorIndex: included})
+		require.NoError(t, err)
+		assert.Equal(t, false, resp.Included)
+	})
+
+	t.Run("future epoch", func(t *testing.T) {
+		s, _ := newServer(t)
+		_, err := s.GetAttestationInclusion(ctx, &AttestationInclusionRequest{ValidatorIndex: included, Epoch: 3})
+		assert.ErrorContains(t, "later than the current epoch", err)
+	})
+}
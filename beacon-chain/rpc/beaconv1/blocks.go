@@ -1,20 +1,28 @@
 package beaconv1
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb_alpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/proto/migration"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -23,7 +31,7 @@ import (
 func (bs *Server) GetBlockHeader(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.BlockHeaderResponse, error) {
 	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get block from block ID: %v", err)
+		return nil, statusError("Could not get block from block ID", err)
 	}
 	if blk == nil {
 		return nil, status.Errorf(codes.NotFound, "Could not find requested block header")
@@ -42,14 +50,10 @@ func (bs *Server) GetBlockHeader(ctx context.Context, req *ethpb.BlockRequest) (
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not determine if block root is canonical: %v", err)
 	}
-	root, err := v1BlockHdr.HashTreeRoot()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not hash block header: %v", err)
-	}
 
 	return &ethpb.BlockHeaderResponse{
 		Data: &ethpb.BlockHeaderContainer{
-			Root:      root[:],
+			Root:      blkRoot[:],
 			Canonical: canonical,
 			Header: &ethpb.BeaconBlockHeaderContainer{
 				Message:   v1BlockHdr.Header,
@@ -60,23 +64,32 @@ func (bs *Server) GetBlockHeader(ctx context.Context, req *ethpb.BlockRequest) (
 }
 
 // ListBlockHeaders retrieves block headers matching given query. By default it will fetch current head slot blocks.
+// As the request cannot tell an unset slot from slot 0, a zero slot without a parent root selects the head slot.
 func (bs *Server) ListBlockHeaders(ctx context.Context, req *ethpb.BlockHeadersRequest) (*ethpb.BlockHeadersResponse, error) {
 	var err error
 	var blks []*ethpb_alpha.SignedBeaconBlock
 	var blkRoots [][32]byte
-	if len(req.ParentRoot) == 32 {
-		blks, blkRoots, err = bs.BeaconDB.Blocks(ctx, filters.NewFilter().SetParentRoot(req.ParentRoot))
+	if len(req.ParentRoot) != 0 {
+		parentRoot, ok := decodeRootID(req.ParentRoot)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid parent root: %s", req.ParentRoot)
+		}
+		blks, blkRoots, err = bs.BeaconDB.Blocks(ctx, filters.NewFilter().SetParentRoot(parentRoot[:]))
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not retrieve blocks: %v", err)
 		}
 	} else {
-		_, blks, err = bs.BeaconDB.BlocksBySlot(ctx, req.Slot)
+		slot := req.Slot
+		if slot == 0 {
+			slot = bs.ChainInfoFetcher.HeadSlot()
+		}
+		_, blks, err = bs.BeaconDB.BlocksBySlot(ctx, slot)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not retrieve blocks for slot %d: %v", req.Slot, err)
+			return nil, status.Errorf(codes.Internal, "Could not retrieve blocks for slot %d: %v", slot, err)
 		}
-		_, blkRoots, err = bs.BeaconDB.BlockRootsBySlot(ctx, req.Slot)
+		_, blkRoots, err = bs.BeaconDB.BlockRootsBySlot(ctx, slot)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not retrieve block roots for slot %d: %v", req.Slot, err)
+			return nil, status.Errorf(codes.Internal, "Could not retrieve block roots for slot %d: %v", slot, err)
 		}
 	}
 	if blks == nil {
@@ -111,10 +124,10 @@ func (bs *Server) ListBlockHeaders(ctx context.Context, req *ethpb.BlockHeadersR
 }
 
 // SubmitBlock instructs the beacon node to broadcast a newly signed beacon block to the beacon network, to be
-// included in the beacon chain. The beacon node is not required to validate the signed BeaconBlock, and a successful
-// response (20X) only indicates that the broadcast has been successful. The beacon node is expected to integrate the
-// new block into its state, and therefore validate the block internally, however blocks which fail the validation are
-// still broadcast but a different status code is returned (202).
+// included in the beacon chain. The block must pass the same validation as a block received over gossip before
+// it is broadcast; a block failing it is rejected as invalid and not broadcast. The beacon node then integrates
+// the new block into its state, and blocks which fail this later validation have still been broadcast, so a
+// successful response with a different status code is returned (202).
 func (bs *Server) SubmitBlock(ctx context.Context, req *ethpb.BeaconBlockContainer) (*ptypes.Empty, error) {
 	blk := req.Message
 
@@ -128,6 +141,10 @@ func (bs *Server) SubmitBlock(ctx context.Context, req *ethpb.BeaconBlockContain
 		return nil, status.Errorf(codes.Internal, "Could not tree hash block: %v", err)
 	}
 
+	if err := bs.validateBlockGossip(ctx, v1alpha1Block); err != nil {
+		return nil, err
+	}
+
 	// Do not block proposal critical path with debug logging or block feed updates.
 	defer func() {
 		log.WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debugf(
@@ -144,7 +161,11 @@ func (bs *Server) SubmitBlock(ctx context.Context, req *ethpb.BeaconBlockContain
 	}
 
 	if err := bs.BlockReceiver.ReceiveBlock(ctx, v1alpha1Block, root); err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not process beacon block: %v", err)
+		log.WithError(err).WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Warn(
+			"Broadcast block failed to be processed")
+		if err := grpcutils.SetHTTPCode(ctx, http.StatusAccepted); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not set HTTP code: %v", err)
+		}
 	}
 
 	return &ptypes.Empty{}, nil
@@ -154,7 +175,7 @@ func (bs *Server) SubmitBlock(ctx context.Context, req *ethpb.BeaconBlockContain
 func (bs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.BlockResponse, error) {
 	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get block from block ID: %v", err)
+		return nil, statusError("Could not get block from block ID", err)
 	}
 	if blk == nil {
 		return nil, status.Errorf(codes.NotFound, "Could not find requested block")
@@ -203,20 +224,20 @@ func (bs *Server) GetBlockRoot(ctx context.Context, req *ethpb.BlockRequest) (*e
 		}
 		root = blkRoot[:]
 	default:
-		if len(req.BlockId) == 32 {
-			block, err := bs.BeaconDB.Block(ctx, bytesutil.ToBytes32(req.BlockId))
+		if blkRoot, ok := decodeRootID(req.BlockId); ok {
+			block, err := bs.BeaconDB.Block(ctx, blkRoot)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "Could not retrieve block for block root %#x: %v", req.BlockId, err)
+				return nil, status.Errorf(codes.Internal, "Could not retrieve block for block root %#x: %v", blkRoot, err)
 			}
 			if block == nil {
 				return nil, status.Error(codes.NotFound, "Could not find any blocks with given root")
 			}
 
-			root = req.BlockId
+			root = blkRoot[:]
 		} else {
 			slot, err := strconv.ParseUint(string(req.BlockId), 10, 64)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "could not decode block id: %v", err)
+				return nil, status.Errorf(codes.InvalidArgument, "Could not decode block ID: %v", err)
 			}
 			hasRoots, roots, err := bs.BeaconDB.BlockRootsBySlot(ctx, types.Slot(slot))
 			if err != nil {
@@ -254,7 +275,7 @@ func (bs *Server) GetBlockRoot(ctx context.Context, req *ethpb.BlockRequest) (*e
 func (bs *Server) ListBlockAttestations(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.BlockAttestationsResponse, error) {
 	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get block from block ID: %v", err)
+		return nil, statusError("Could not get block from block ID", err)
 	}
 	if blk == nil {
 		return nil, status.Errorf(codes.NotFound, "Could not find requested block")
@@ -283,7 +304,7 @@ func (bs *Server) blockFromBlockID(ctx context.Context, blockId []byte) (*ethpb_
 		finalizedRoot := bytesutil.ToBytes32(finalized.Root)
 		blk, err = bs.BeaconDB.Block(ctx, finalizedRoot)
 		if err != nil {
-			return nil, errors.New("could not get finalized block from db")
+			return nil, errors.Wrap(err, "could not get finalized block from db")
 		}
 	case "genesis":
 		blk, err = bs.BeaconDB.GenesisBlock(ctx)
@@ -291,15 +312,15 @@ func (bs *Server) blockFromBlockID(ctx context.Context, blockId []byte) (*ethpb_
 			return nil, errors.Wrap(err, "could not retrieve blocks for genesis slot")
 		}
 	default:
-		if len(blockId) == 32 {
-			blk, err = bs.BeaconDB.Block(ctx, bytesutil.ToBytes32(blockId))
+		if root, ok := decodeRootID(blockId); ok {
+			blk, err = bs.BeaconDB.Block(ctx, root)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not retrieve block")
 			}
 		} else {
 			slot, err := strconv.ParseUint(string(blockId), 10, 64)
 			if err != nil {
-				return nil, errors.Wrap(err, "could not decode block id")
+				return nil, newRequestError(errors.Wrap(err, "could not decode block id"))
 			}
 			_, blks, err := bs.BeaconDB.BlocksBySlot(ctx, types.Slot(slot))
 			if err != nil {
@@ -332,3 +353,66 @@ func (bs *Server) blockFromBlockID(ctx context.Context, blockId []byte) (*ethpb_
 	}
 	return blk, nil
 }
+
+// decodeRootID decodes a root given either as raw bytes, as sent by gRPC clients, or as a
+// 0x-prefixed hex string, as sent through the REST gateway.
+func decodeRootID(id []byte) ([32]byte, bool) {
+	if len(id) == 32 {
+		return bytesutil.ToBytes32(id), true
+	}
+	if len(id) == 66 && bytes.HasPrefix(id, []byte("0x")) {
+		root, err := hexutil.Decode(string(id))
+		if err == nil {
+			return bytesutil.ToBytes32(root), true
+		}
+	}
+	return [32]byte{}, false
+}
+
+// validateBlockGossip runs the gossip validation of the block against its parent state: the block
+// must not be from a future slot, must be newer than the finalized checkpoint, must build on a known
+// parent and must be signed by the expected proposer.
+func (bs *Server) validateBlockGossip(ctx context.Context, blk *ethpb_alpha.SignedBeaconBlock) error {
+	genesisTime := uint64(bs.GenesisTimeFetcher.GenesisTime().Unix())
+	if err := helpers.VerifySlotTime(genesisTime, blk.Block.Slot, params.BeaconNetworkConfig().MaximumGossipClockDisparity); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid block slot: %v", err)
+	}
+	var finalizedEpoch types.Epoch
+	if finalized := bs.ChainInfoFetcher.FinalizedCheckpt(); finalized != nil {
+		finalizedEpoch = finalized.Epoch
+	}
+	finalizedSlot, err := helpers.StartSlot(finalizedEpoch)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get finalized slot: %v", err)
+	}
+	if blk.Block.Slot <= finalizedSlot {
+		return status.Errorf(codes.InvalidArgument, "Block slot %d is not after finalized slot %d", blk.Block.Slot, finalizedSlot)
+	}
+
+	parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+	if !bs.BeaconDB.HasBlock(ctx, parentRoot) {
+		return status.Errorf(codes.InvalidArgument, "Parent block %#x not found", parentRoot)
+	}
+	parentState, err := bs.StateGenService.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get parent state: %v", err)
+	}
+	if blk.Block.Slot <= parentState.Slot() {
+		return status.Errorf(codes.InvalidArgument, "Block slot %d is not after parent slot %d", blk.Block.Slot, parentState.Slot())
+	}
+	if err := blocks.VerifyBlockSignature(parentState, blk); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid block signature: %v", err)
+	}
+	parentState, err = state.ProcessSlots(ctx, parentState, blk.Block.Slot)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not process slots up to %d: %v", blk.Block.Slot, err)
+	}
+	proposerIndex, err := helpers.BeaconProposerIndex(parentState)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get proposer index: %v", err)
+	}
+	if blk.Block.ProposerIndex != proposerIndex {
+		return status.Errorf(codes.InvalidArgument, "Incorrect proposer index %d, expected %d", blk.Block.ProposerIndex, proposerIndex)
+	}
+	return nil
+}
@@ -2,24 +2,35 @@ package beaconv1
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gogo/protobuf/proto"
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb_alpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
-	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/proto/migration"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func fillDBTestBlocks(ctx context.Context, t *testing.T, beaconDB db.Database) (*ethpb_alpha.SignedBeaconBlock, []*ethpb_alpha.BeaconBlockContainer) {
@@ -105,6 +116,11 @@ func TestServer_GetBlockHeader(t *testing.T) {
 			blockID: blkContainers[20].BlockRoot,
 			want:    blkContainers[20].Block,
 		},
+		{
+			name:    "hex root",
+			blockID: []byte(hexutil.Encode(blkContainers[20].BlockRoot)),
+			want:    blkContainers[20].Block,
+		},
 		{
 			name:    "canonical",
 			blockID: []byte("30"),
@@ -154,6 +170,9 @@ func TestServer_GetBlockHeader(t *testing.T) {
 			if !reflect.DeepEqual(header.Data.Header.Message, blkHdr.Header) {
 				t.Error("Expected blocks to equal")
 			}
+			wantRoot, err := tt.want.Block.HashTreeRoot()
+			require.NoError(t, err)
+			assert.DeepEqual(t, wantRoot[:], header.Data.Root)
 		})
 	}
 }
@@ -164,6 +183,9 @@ func TestServer_ListBlockHeaders(t *testing.T) {
 
 	_, blkContainers := fillDBTestBlocks(ctx, t, beaconDB)
 	headBlock := blkContainers[len(blkContainers)-1]
+	headState, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(headBlock.Block.Block.Slot))
 	bs := &Server{
 		BeaconDB: beaconDB,
 		ChainInfoFetcher: &mock.ChainService{
@@ -171,6 +193,7 @@ func TestServer_ListBlockHeaders(t *testing.T) {
 			Block:               headBlock.Block,
 			Root:                headBlock.BlockRoot,
 			FinalizedCheckPoint: &ethpb_alpha.Checkpoint{Root: blkContainers[64].BlockRoot},
+			State:               headState,
 		},
 	}
 
@@ -217,6 +240,22 @@ func TestServer_ListBlockHeaders(t *testing.T) {
 				b5,
 			},
 		},
+		{
+			name:       "hex parent root",
+			parentRoot: []byte(hexutil.Encode(b2.Block.ParentRoot)),
+			want: []*ethpb_alpha.SignedBeaconBlock{
+				blkContainers[1].Block,
+				b2,
+				b4,
+				b5,
+			},
+		},
+		{
+			name: "head slot",
+			want: []*ethpb_alpha.SignedBeaconBlock{
+				headBlock.Block,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -239,44 +278,126 @@ func TestServer_ListBlockHeaders(t *testing.T) {
 	}
 }
 
-func TestServer_ProposeBlock_OK(t *testing.T) {
-	beaconDB := dbTest.SetupDB(t)
+// headerStream records the response header and trailer set by a server method.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestServer_SubmitBlock(t *testing.T) {
 	ctx := context.Background()
 	params.SetupTestConfigCleanup(t)
 	params.OverrideBeaconConfig(params.MainnetConfig())
 
-	genesis := testutil.NewBeaconBlock()
-	require.NoError(t, beaconDB.SaveBlock(context.Background(), genesis), "Could not save genesis block")
-
-	numDeposits := uint64(64)
-	beaconState, _ := testutil.DeterministicGenesisState(t, numDeposits)
-	bsRoot, err := beaconState.HashTreeRoot(ctx)
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := beaconState.HashTreeRoot(ctx)
 	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
 	genesisRoot, err := genesis.Block.HashTreeRoot()
 	require.NoError(t, err)
-	require.NoError(t, beaconDB.SaveState(ctx, beaconState, genesisRoot), "Could not save genesis state")
-
-	c := &mock.ChainService{Root: bsRoot[:], State: beaconState}
-	beaconChainServer := &Server{
-		BeaconDB:          beaconDB,
-		ChainStartFetcher: &mockPOW.POWChain{},
-		BlockReceiver:     c,
-		ChainInfoFetcher:  c,
-		BlockNotifier:     c.BlockNotifier(),
-		Broadcaster:       mockp2p.NewTestP2P(t),
-	}
-	req := testutil.NewBeaconBlock()
-	req.Block.Slot = 5
-	req.Block.ParentRoot = bsRoot[:]
-	v1Block, err := migration.V1Alpha1ToV1Block(req)
+	signedBlock, err := testutil.GenerateFullBlock(beaconState, privKeys, testutil.DefaultBlockGenConfig(), 1)
 	require.NoError(t, err)
-	require.NoError(t, beaconDB.SaveBlock(ctx, req))
-	blockReq := &ethpb.BeaconBlockContainer{
-		Message:   v1Block.Block,
-		Signature: v1Block.Signature,
+	require.DeepEqual(t, genesisRoot[:], signedBlock.Block.ParentRoot)
+
+	newServer := func(t *testing.T) (*Server, *mockp2p.TestP2P) {
+		beaconDB := dbTest.SetupDB(t)
+		require.NoError(t, beaconDB.SaveBlock(ctx, genesis))
+		stateGen := stategen.NewMockService()
+		stateGen.AddStateForRoot(beaconState.Copy(), genesisRoot)
+		genesisTime := time.Now().Add(-time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+		c := &mock.ChainService{
+			Root:                genesisRoot[:],
+			State:               beaconState.Copy(),
+			Genesis:             genesisTime,
+			FinalizedCheckPoint: &ethpb_alpha.Checkpoint{Epoch: 0},
+		}
+		broadcaster := mockp2p.NewTestP2P(t)
+		return &Server{
+			BeaconDB:           beaconDB,
+			BlockReceiver:      c,
+			ChainInfoFetcher:   c,
+			GenesisTimeFetcher: c,
+			BlockNotifier:      c.BlockNotifier(),
+			StateGenService:    stateGen,
+			Broadcaster:        broadcaster,
+		}, broadcaster
 	}
-	_, err = beaconChainServer.SubmitBlock(context.Background(), blockReq)
-	assert.NoError(t, err, "Could not propose block correctly")
+	container := func(t *testing.T, blk *ethpb_alpha.SignedBeaconBlock) *ethpb.BeaconBlockContainer {
+		v1Block, err := migration.V1Alpha1ToV1Block(blk)
+		require.NoError(t, err)
+		return &ethpb.BeaconBlockContainer{Message: v1Block.Block, Signature: v1Block.Signature}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		server, broadcaster := newServer(t)
+		events := make(chan *feed.Event, 1)
+		sub := server.BlockNotifier.BlockFeed().Subscribe(events)
+		defer sub.Unsubscribe()
+		stream := &headerStream{}
+		_, err := server.SubmitBlock(grpc.NewContextWithServerTransportStream(ctx, stream), container(t, signedBlock))
+		require.NoError(t, err)
+		assert.Equal(t, true, broadcaster.BroadcastCalled)
+		assert.Equal(t, 1, len(events))
+		_, ok := grpcutils.HTTPCode(stream.header)
+		assert.Equal(t, false, ok)
+	})
+
+	t.Run("Failed late validation", func(t *testing.T) {
+		server, broadcaster := newServer(t)
+		server.BlockReceiver = &mock.ChainService{Root: []byte("other")}
+		stream := &headerStream{}
+		_, err := server.SubmitBlock(grpc.NewContextWithServerTransportStream(ctx, stream), container(t, signedBlock))
+		require.NoError(t, err)
+		assert.Equal(t, true, broadcaster.BroadcastCalled)
+		code, ok := grpcutils.HTTPCode(stream.header)
+		require.Equal(t, true, ok)
+		assert.Equal(t, http.StatusAccepted, code)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		server, broadcaster := newServer(t)
+		events := make(chan *feed.Event, 1)
+		sub := server.BlockNotifier.BlockFeed().Subscribe(events)
+		defer sub.Unsubscribe()
+		blk := proto.Clone(signedBlock).(*ethpb_alpha.SignedBeaconBlock)
+		blk.Signature = make([]byte, 96)
+		_, err := server.SubmitBlock(ctx, container(t, blk))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid block signature", err)
+		assert.Equal(t, false, broadcaster.BroadcastCalled)
+		assert.Equal(t, 0, len(events), "Invalid block was sent to the block feed")
+	})
+
+	t.Run("Unknown parent", func(t *testing.T) {
+		server, broadcaster := newServer(t)
+		blk := proto.Clone(signedBlock).(*ethpb_alpha.SignedBeaconBlock)
+		blk.Block.ParentRoot = bytesutil.PadTo([]byte("unknown"), 32)
+		_, err := server.SubmitBlock(ctx, container(t, blk))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "not found", err)
+		assert.Equal(t, false, broadcaster.BroadcastCalled)
+	})
+
+	t.Run("Future slot", func(t *testing.T) {
+		server, broadcaster := newServer(t)
+		blk := proto.Clone(signedBlock).(*ethpb_alpha.SignedBeaconBlock)
+		blk.Block.Slot = 100
+		_, err := server.SubmitBlock(ctx, container(t, blk))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid block slot", err)
+		assert.Equal(t, false, broadcaster.BroadcastCalled)
+	})
 }
 
 func TestServer_GetBlock(t *testing.T) {
@@ -461,6 +582,11 @@ func TestServer_GetBlockRoot(t *testing.T) {
 			blockID: blkContainers[20].BlockRoot,
 			want:    blkContainers[20].BlockRoot,
 		},
+		{
+			name:    "hex root",
+			blockID: []byte(hexutil.Encode(blkContainers[20].BlockRoot)),
+			want:    blkContainers[20].BlockRoot,
+		},
 		{
 			name:    "non-existent root",
 			blockID: bytesutil.PadTo([]byte("hi there"), 32),
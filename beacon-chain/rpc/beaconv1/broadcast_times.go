@@ -0,0 +1,62 @@
+package beaconv1
+
+import (
+	"context"
+	gosync "sync"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"go.opencensus.io/trace"
+)
+
+// lastBroadcastTimes records when the server last broadcast each type of pool object.
+type lastBroadcastTimes struct {
+	lock  gosync.RWMutex
+	times map[string]time.Time
+}
+
+// record marks the object type as broadcast now.
+func (l *lastBroadcastTimes) record(object string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.times == nil {
+		l.times = make(map[string]time.Time)
+	}
+	l.times[object] = time.Now()
+}
+
+// get returns the last broadcast time of the object type as Unix seconds, or zero if it was never
+// broadcast.
+func (l *lastBroadcastTimes) get(object string) uint64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	t, ok := l.times[object]
+	if !ok {
+		return 0
+	}
+	return uint64(t.Unix())
+}
+
+// LastBroadcastTimesResponse holds the time each type of pool object was last broadcast by the node,
+// in Unix seconds like the genesis time. Zero means the node has not broadcast an object of that
+// type since it started.
+type LastBroadcastTimesResponse struct {
+	Attestation      uint64 `json:"attestation"`
+	AttesterSlashing uint64 `json:"attester_slashing"`
+	ProposerSlashing uint64 `json:"proposer_slashing"`
+	VoluntaryExit    uint64 `json:"voluntary_exit"`
+}
+
+// GetLastBroadcastTimes returns when the node last broadcast an attestation, slashing or voluntary exit
+// submitted through the pool endpoints. A long-stale time can indicate a broken gossip path.
+func (bs *Server) GetLastBroadcastTimes(ctx context.Context, _ *ptypes.Empty) (*LastBroadcastTimesResponse, error) {
+	_, span := trace.StartSpan(ctx, "beaconv1.GetLastBroadcastTimes")
+	defer span.End()
+
+	return &LastBroadcastTimesResponse{
+		Attestation:      bs.lastBroadcasts.get(attestationObject),
+		AttesterSlashing: bs.lastBroadcasts.get(attesterSlashingObject),
+		ProposerSlashing: bs.lastBroadcasts.get(proposerSlashingObject),
+		VoluntaryExit:    bs.lastBroadcasts.get(voluntaryExitObject),
+	}, nil
+}
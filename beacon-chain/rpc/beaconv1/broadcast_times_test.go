@@ -0,0 +1,71 @@
+package beaconv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	p2pMock "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestGetLastBroadcastTimes(t *testing.T) {
+	ctx := context.Background()
+	attState, attSlashing := signedAttesterSlashing(t)
+	exitState, exits := signedVoluntaryExits(t, 1)
+
+	s := &Server{
+		SlashingsPool:      &slashings.PoolMock{},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        &p2pMock.MockBroadcaster{},
+	}
+
+	resp, err := s.GetLastBroadcastTimes(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.AttesterSlashing == 0)
+	assert.Equal(t, true, resp.VoluntaryExit == 0)
+
+	before := time.Now()
+	s.ChainInfoFetcher = &chainMock.ChainService{State: attState}
+	_, err = s.SubmitAttesterSlashing(ctx, attSlashing)
+	require.NoError(t, err)
+
+	resp, err = s.GetLastBroadcastTimes(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.AttesterSlashing >= uint64(before.Unix()))
+	assert.Equal(t, true, resp.ProposerSlashing == 0)
+	assert.Equal(t, true, resp.VoluntaryExit == 0)
+	assert.Equal(t, true, resp.Attestation == 0)
+	slashingBroadcast := resp.AttesterSlashing
+
+	s.ChainInfoFetcher = &chainMock.ChainService{State: exitState}
+	_, err = s.SubmitVoluntaryExit(ctx, exits[0])
+	require.NoError(t, err)
+
+	resp, err = s.GetLastBroadcastTimes(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.VoluntaryExit >= slashingBroadcast)
+	assert.Equal(t, slashingBroadcast, resp.AttesterSlashing)
+}
+
+func TestGetLastBroadcastTimes_FailedBroadcastNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 1)
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        &erroringBroadcaster{err: context.DeadlineExceeded},
+	}
+
+	_, err := s.SubmitVoluntaryExit(ctx, exits[0])
+	require.NotNil(t, err)
+
+	resp, err := s.GetLastBroadcastTimes(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.VoluntaryExit == 0)
+}
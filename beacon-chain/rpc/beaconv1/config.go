@@ -6,7 +6,6 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ptypes "github.com/gogo/protobuf/types"
@@ -53,6 +52,8 @@ func (bs *Server) GetForkSchedule(ctx context.Context, _ *ptypes.Empty) (*ethpb.
 }
 
 // GetSpec retrieves specification configuration (without Phase 1 params) used on this node. Specification params list
+// the constants, presets and configuration values of the network the node runs.
+// Params are keyed by their upper case name in the config file, such as SECONDS_PER_SLOT.
 // Values are returned with following format:
 // - any value starting with 0x in the spec is returned as a hex string.
 // - all other values are returned as number.
@@ -105,7 +106,7 @@ func prepareConfigSpec() (map[string]string, error) {
 			continue
 		}
 
-		tagValue := strings.ToLower(tField.Tag.Get("yaml"))
+		tagValue := tField.Tag.Get("yaml")
 		vField := v.Field(i)
 		switch vField.Kind() {
 		case reflect.Uint64:
@@ -100,125 +100,125 @@ func TestGetSpec(t *testing.T) {
 	assert.Equal(t, 60, len(resp.Data))
 	for k, v := range resp.Data {
 		switch k {
-		case "config_name":
+		case "CONFIG_NAME":
 			assert.Equal(t, "ConfigName", v)
-		case "max_committees_per_slot":
+		case "MAX_COMMITTEES_PER_SLOT":
 			assert.Equal(t, "1", v)
-		case "target_committee_size":
+		case "TARGET_COMMITTEE_SIZE":
 			assert.Equal(t, "2", v)
-		case "max_validators_per_committee":
+		case "MAX_VALIDATORS_PER_COMMITTEE":
 			assert.Equal(t, "3", v)
-		case "min_per_epoch_churn_limit":
+		case "MIN_PER_EPOCH_CHURN_LIMIT":
 			assert.Equal(t, "4", v)
-		case "churn_limit_quotient":
+		case "CHURN_LIMIT_QUOTIENT":
 			assert.Equal(t, "5", v)
-		case "shuffle_round_count":
+		case "SHUFFLE_ROUND_COUNT":
 			assert.Equal(t, "6", v)
-		case "min_genesis_active_validator_count":
+		case "MIN_GENESIS_ACTIVE_VALIDATOR_COUNT":
 			assert.Equal(t, "7", v)
-		case "min_genesis_time":
+		case "MIN_GENESIS_TIME":
 			assert.Equal(t, "8", v)
-		case "hysteresis_quotient":
+		case "HYSTERESIS_QUOTIENT":
 			assert.Equal(t, "9", v)
-		case "hysteresis_downward_multiplier":
+		case "HYSTERESIS_DOWNWARD_MULTIPLIER":
 			assert.Equal(t, "10", v)
-		case "hysteresis_upward_multiplier":
+		case "HYSTERESIS_UPWARD_MULTIPLIER":
 			assert.Equal(t, "11", v)
-		case "safe_slots_to_update_justified":
+		case "SAFE_SLOTS_TO_UPDATE_JUSTIFIED":
 			assert.Equal(t, "12", v)
-		case "eth1_follow_distance":
+		case "ETH1_FOLLOW_DISTANCE":
 			assert.Equal(t, "13", v)
-		case "target_aggregators_per_committee":
+		case "TARGET_AGGREGATORS_PER_COMMITTEE":
 			assert.Equal(t, "14", v)
-		case "random_subnets_per_validator":
+		case "RANDOM_SUBNETS_PER_VALIDATOR":
 			assert.Equal(t, "15", v)
-		case "epochs_per_random_subnet_subscription":
+		case "EPOCHS_PER_RANDOM_SUBNET_SUBSCRIPTION":
 			assert.Equal(t, "16", v)
-		case "seconds_per_eth1_block":
+		case "SECONDS_PER_ETH1_BLOCK":
 			assert.Equal(t, "17", v)
-		case "deposit_chain_id":
+		case "DEPOSIT_CHAIN_ID":
 			assert.Equal(t, "18", v)
-		case "deposit_network_id":
+		case "DEPOSIT_NETWORK_ID":
 			assert.Equal(t, "19", v)
-		case "deposit_contract_address":
+		case "DEPOSIT_CONTRACT_ADDRESS":
 			assert.Equal(t, "DepositContractAddress", v)
-		case "min_deposit_amount":
+		case "MIN_DEPOSIT_AMOUNT":
 			assert.Equal(t, "20", v)
-		case "max_effective_balance":
+		case "MAX_EFFECTIVE_BALANCE":
 			assert.Equal(t, "21", v)
-		case "ejection_balance":
+		case "EJECTION_BALANCE":
 			assert.Equal(t, "22", v)
-		case "effective_balance_increment":
+		case "EFFECTIVE_BALANCE_INCREMENT":
 			assert.Equal(t, "23", v)
-		case "genesis_fork_version":
+		case "GENESIS_FORK_VERSION":
 			assert.Equal(t, "0x47656e65736973466f726b56657273696f6e", v)
-		case "bls_withdrawal_prefix":
+		case "BLS_WITHDRAWAL_PREFIX":
 			assert.Equal(t, "0x62", v)
-		case "genesis_delay":
+		case "GENESIS_DELAY":
 			assert.Equal(t, "24", v)
-		case "seconds_per_slot":
+		case "SECONDS_PER_SLOT":
 			assert.Equal(t, "25", v)
-		case "min_attestation_inclusion_delay":
+		case "MIN_ATTESTATION_INCLUSION_DELAY":
 			assert.Equal(t, "26", v)
-		case "slots_per_epoch":
+		case "SLOTS_PER_EPOCH":
 			assert.Equal(t, "27", v)
-		case "min_seed_lookahead":
+		case "MIN_SEED_LOOKAHEAD":
 			assert.Equal(t, "28", v)
-		case "max_seed_lookahead":
+		case "MAX_SEED_LOOKAHEAD":
 			assert.Equal(t, "29", v)
-		case "epochs_per_eth1_voting_period":
+		case "EPOCHS_PER_ETH1_VOTING_PERIOD":
 			assert.Equal(t, "30", v)
-		case "slots_per_historical_root":
+		case "SLOTS_PER_HISTORICAL_ROOT":
 			assert.Equal(t, "31", v)
-		case "min_validator_withdrawability_delay":
+		case "MIN_VALIDATOR_WITHDRAWABILITY_DELAY":
 			assert.Equal(t, "32", v)
-		case "shard_committee_period":
+		case "SHARD_COMMITTEE_PERIOD":
 			assert.Equal(t, "33", v)
-		case "min_epochs_to_inactivity_penalty":
+		case "MIN_EPOCHS_TO_INACTIVITY_PENALTY":
 			assert.Equal(t, "34", v)
-		case "epochs_per_historical_vector":
+		case "EPOCHS_PER_HISTORICAL_VECTOR":
 			assert.Equal(t, "35", v)
-		case "epochs_per_slashings_vector":
+		case "EPOCHS_PER_SLASHINGS_VECTOR":
 			assert.Equal(t, "36", v)
-		case "historical_roots_limit":
+		case "HISTORICAL_ROOTS_LIMIT":
 			assert.Equal(t, "37", v)
-		case "validator_registry_limit":
+		case "VALIDATOR_REGISTRY_LIMIT":
 			assert.Equal(t, "38", v)
-		case "base_reward_factor":
+		case "BASE_REWARD_FACTOR":
 			assert.Equal(t, "39", v)
-		case "whistleblower_reward_quotient":
+		case "WHISTLEBLOWER_REWARD_QUOTIENT":
 			assert.Equal(t, "40", v)
-		case "proposer_reward_quotient":
+		case "PROPOSER_REWARD_QUOTIENT":
 			assert.Equal(t, "41", v)
-		case "inactivity_penalty_quotient":
+		case "INACTIVITY_PENALTY_QUOTIENT":
 			assert.Equal(t, "42", v)
-		case "min_slashing_penalty_quotient":
+		case "MIN_SLASHING_PENALTY_QUOTIENT":
 			assert.Equal(t, "43", v)
-		case "proportional_slashing_multiplier":
+		case "PROPORTIONAL_SLASHING_MULTIPLIER":
 			assert.Equal(t, "44", v)
-		case "max_proposer_slashings":
+		case "MAX_PROPOSER_SLASHINGS":
 			assert.Equal(t, "45", v)
-		case "max_attester_slashings":
+		case "MAX_ATTESTER_SLASHINGS":
 			assert.Equal(t, "46", v)
-		case "max_attestations":
+		case "MAX_ATTESTATIONS":
 			assert.Equal(t, "47", v)
-		case "max_deposits":
+		case "MAX_DEPOSITS":
 			assert.Equal(t, "48", v)
-		case "max_voluntary_exits":
+		case "MAX_VOLUNTARY_EXITS":
 			assert.Equal(t, "49", v)
-		case "domain_beacon_proposer":
+		case "DOMAIN_BEACON_PROPOSER":
 			assert.Equal(t, "0x30303031", v)
-		case "domain_beacon_attester":
+		case "DOMAIN_BEACON_ATTESTER":
 			assert.Equal(t, "0x30303032", v)
-		case "domain_randao":
+		case "DOMAIN_RANDAO":
 			assert.Equal(t, "0x30303033", v)
-		case "domain_deposit":
+		case "DOMAIN_DEPOSIT":
 			assert.Equal(t, "0x30303034", v)
-		case "domain_voluntary_exit":
+		case "DOMAIN_VOLUNTARY_EXIT":
 			assert.Equal(t, "0x30303035", v)
-		case "domain_selection_proof":
+		case "DOMAIN_SELECTION_PROOF":
 			assert.Equal(t, "0x30303036", v)
-		case "domain_aggregate_and_proof":
+		case "DOMAIN_AGGREGATE_AND_PROOF":
 			assert.Equal(t, "0x30303037", v)
 		default:
 			t.Errorf("Incorrect key: %s", k)
@@ -0,0 +1,72 @@
+package beaconv1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requestError is an error caused by invalid request input rather than by a failure of the node.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// newRequestError marks err as caused by invalid request input.
+func newRequestError(err error) error {
+	return &requestError{err: err}
+}
+
+// statusError translates an error into a gRPC status error with the message prefix msg. Errors
+// caused by invalid request input map to InvalidArgument, which the gateway serves as 400, and
+// errors that already carry a status code keep it. All other errors are failures of the node and
+// map to Internal, served as 500.
+func statusError(msg string, err error) error {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	}
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return status.Errorf(st.Code(), "%s: %s", msg, st.Message())
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// batchError returns the error of a batch request whose items failed with errs, or nil if none
+// failed. The failures are passed to the gateway by their index in the batch as trailer metadata,
+// which it reports in the body of its 400 response.
+func batchError(ctx context.Context, items string, errs []error) error {
+	failures := make([]*grpcutils.IndexedFailure, 0)
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, &grpcutils.IndexedFailure{
+				Index:   uint64(i),
+				Message: status.Convert(err).Message(),
+			})
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	if err := grpcutils.SetFailures(ctx, failures); err != nil {
+		log.WithError(err).Debug("Could not set batch failures")
+	}
+	first := failures[0]
+	return status.Errorf(
+		codes.InvalidArgument,
+		"One or more %s failed to be processed, first failure at index %d: %s",
+		items,
+		first.Index,
+		first.Message,
+	)
+}
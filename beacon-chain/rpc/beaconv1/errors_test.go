@@ -0,0 +1,63 @@
+package beaconv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{
+			name: "request error",
+			err:  newRequestError(errors.New("bad slot 5")),
+			code: codes.InvalidArgument,
+			msg:  "Could not get block: bad slot 5",
+		},
+		{
+			name: "wrapped request error",
+			err:  fmt.Errorf("lookup: %w", newRequestError(errors.New("bad slot"))),
+			code: codes.InvalidArgument,
+			msg:  "Could not get block: lookup: bad slot",
+		},
+		{
+			name: "status error",
+			err:  status.Error(codes.NotFound, "no block"),
+			code: codes.NotFound,
+			msg:  "Could not get block: no block",
+		},
+		{
+			name: "node failure",
+			err:  errors.New("database closed"),
+			code: codes.Internal,
+			msg:  "Could not get block: database closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := statusError("Could not get block", tt.err)
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.msg, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestGetBlock_InvalidBlockID(t *testing.T) {
+	bs := &Server{BeaconDB: dbTest.SetupDB(t)}
+
+	_, err := bs.GetBlock(context.Background(), &ethpb.BlockRequest{BlockId: []byte("3bad0")})
+	assert.ErrorContains(t, "could not decode block id", err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,143 @@
+package beaconv1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LivenessRequest selects the validators whose liveness in an epoch is requested.
+type LivenessRequest struct {
+	Epoch types.Epoch            `json:"epoch"`
+	Index []types.ValidatorIndex `json:"index"`
+}
+
+// ValidatorLiveness is the liveness of a single validator in an epoch.
+type ValidatorLiveness struct {
+	Index  types.ValidatorIndex `json:"index"`
+	Epoch  types.Epoch          `json:"epoch"`
+	IsLive bool                 `json:"is_live"`
+}
+
+// LivenessResponse lists the liveness of the requested validators, in the order of the request.
+type LivenessResponse struct {
+	Data []*ValidatorLiveness `json:"data"`
+}
+
+// GetLiveness reports whether validators were seen active in the current or previous epoch, either
+// attesting, by attestations included in the head state or received over gossip, or proposing, by
+// blocks received by the node in the epoch. Validator clients use it to detect their keys being run
+// elsewhere before starting, and monitoring systems to check validators are online.
+func (bs *Server) GetLiveness(ctx context.Context, req *LivenessRequest) (*LivenessResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.GetLiveness")
+	defer span.End()
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range req.Index {
+		if uint64(idx) >= uint64(headState.NumValidators()) {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown validator index %d", idx)
+		}
+	}
+	if currentEpoch := helpers.CurrentEpoch(headState); req.Epoch != currentEpoch && req.Epoch+1 != currentEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Epoch %d is not the current or previous epoch of the head state", req.Epoch)
+	}
+	live, err := bs.liveIndices(ctx, headState, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not determine liveness: %v", err)
+	}
+	resp := &LivenessResponse{Data: make([]*ValidatorLiveness, len(req.Index))}
+	for i, idx := range req.Index {
+		resp.Data[i] = &ValidatorLiveness{
+			Index:  idx,
+			Epoch:  req.Epoch,
+			IsLive: live[idx],
+		}
+	}
+	return resp, nil
+}
+
+// liveIndices collects the indices of the validators attesting or proposing in an epoch. Only the
+// current and previous epochs of the head state can be checked, as older attestations are pruned.
+func (bs *Server) liveIndices(ctx context.Context, headState *statetrie.BeaconState, epoch types.Epoch) (map[types.ValidatorIndex]bool, error) {
+	var aggregationBits []bitfield.Bitlist
+	var data []*eth.AttestationData
+	currentEpoch := helpers.CurrentEpoch(headState)
+	switch {
+	case epoch == currentEpoch:
+		for _, att := range headState.CurrentEpochAttestations() {
+			aggregationBits = append(aggregationBits, att.AggregationBits)
+			data = append(data, att.Data)
+		}
+	case epoch+1 == currentEpoch:
+		for _, att := range headState.PreviousEpochAttestations() {
+			aggregationBits = append(aggregationBits, att.AggregationBits)
+			data = append(data, att.Data)
+		}
+	default:
+		return nil, errors.Errorf("epoch %d is not the current or previous epoch of the head state", epoch)
+	}
+	if bs.AttestationsPool != nil {
+		unaggregated, err := bs.AttestationsPool.UnaggregatedAttestations()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get unaggregated attestations")
+		}
+		for _, att := range append(bs.AttestationsPool.AggregatedAttestations(), unaggregated...) {
+			if att.Data == nil || att.Data.Target == nil || att.Data.Target.Epoch != epoch {
+				continue
+			}
+			aggregationBits = append(aggregationBits, att.AggregationBits)
+			data = append(data, att.Data)
+		}
+	}
+
+	live := make(map[types.ValidatorIndex]bool)
+	for i, d := range data {
+		// Attestations received over gossip are not guaranteed to match a committee of the
+		// head state, in which case they are skipped.
+		committee, err := helpers.BeaconCommitteeFromState(headState, d.Slot, d.CommitteeIndex)
+		if err != nil {
+			continue
+		}
+		attesting, err := attestationutil.AttestingIndices(aggregationBits[i], committee)
+		if err != nil {
+			continue
+		}
+		for _, idx := range attesting {
+			live[types.ValidatorIndex(idx)] = true
+		}
+	}
+
+	if bs.BeaconDB != nil {
+		startSlot, err := helpers.StartSlot(epoch)
+		if err != nil {
+			return nil, err
+		}
+		filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(startSlot + params.BeaconConfig().SlotsPerEpoch - 1)
+		blks, _, err := bs.BeaconDB.Blocks(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get blocks of epoch %d", epoch)
+		}
+		// Blocks which are not canonical still show the proposer is live.
+		for _, blk := range blks {
+			if blk == nil || blk.Block == nil {
+				continue
+			}
+			live[blk.Block.ProposerIndex] = true
+		}
+	}
+	return live, nil
+}
@@ -0,0 +1,109 @@
+package beaconv1
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetLiveness(t *testing.T) {
+	ctx := context.Background()
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch+1))
+
+	// A validator attesting in the previous epoch, included in the head state.
+	committee, err := helpers.BeaconCommitteeFromState(headState, 1, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	require.NoError(t, headState.AppendPreviousEpochAttestations(&pbp2p.PendingAttestation{
+		AggregationBits: bits,
+		Data:            &eth.AttestationData{Slot: 1, Target: &eth.Checkpoint{Epoch: 0}},
+	}))
+	previousLive := committee[0]
+
+	// A validator attesting in the current epoch, only seen over gossip.
+	slot := params.BeaconConfig().SlotsPerEpoch
+	gossipCommittee, err := helpers.BeaconCommitteeFromState(headState, slot, 0)
+	require.NoError(t, err)
+	gossipBits := bitfield.NewBitlist(uint64(len(gossipCommittee)))
+	gossipBits.SetBitAt(1, true)
+	pool := attestations.NewPool()
+	require.NoError(t, pool.SaveUnaggregatedAttestation(testutil.HydrateAttestation(&eth.Attestation{
+		AggregationBits: gossipBits,
+		Data:            &eth.AttestationData{Slot: slot, Target: &eth.Checkpoint{Epoch: 1}},
+	})))
+	currentLive := gossipCommittee[1]
+
+	// A validator proposing in the current epoch, without attesting.
+	proposerLive := types.ValidatorIndex(0)
+	for proposerLive == previousLive || proposerLive == currentLive {
+		proposerLive++
+	}
+	beaconDB := dbTest.SetupDB(t)
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot + 1
+	blk.Block.ProposerIndex = proposerLive
+	require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: headState},
+		AttestationsPool: pool,
+		BeaconDB:         beaconDB,
+	}
+
+	t.Run("previous epoch", func(t *testing.T) {
+		resp, err := s.GetLiveness(ctx, &LivenessRequest{Epoch: 0, Index: []types.ValidatorIndex{previousLive, currentLive}})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, true, resp.Data[0].IsLive)
+		assert.Equal(t, currentLive == previousLive, resp.Data[1].IsLive)
+		assert.Equal(t, types.Epoch(0), resp.Data[0].Epoch)
+	})
+	t.Run("current epoch", func(t *testing.T) {
+		resp, err := s.GetLiveness(ctx, &LivenessRequest{Epoch: 1, Index: []types.ValidatorIndex{currentLive}})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, true, resp.Data[0].IsLive)
+		assert.Equal(t, currentLive, resp.Data[0].Index)
+	})
+	t.Run("proposer", func(t *testing.T) {
+		resp, err := s.GetLiveness(ctx, &LivenessRequest{Epoch: 1, Index: []types.ValidatorIndex{proposerLive}})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, true, resp.Data[0].IsLive)
+
+		resp, err = s.GetLiveness(ctx, &LivenessRequest{Epoch: 0, Index: []types.ValidatorIndex{proposerLive}})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, false, resp.Data[0].IsLive)
+	})
+	t.Run("epoch too old or in the future", func(t *testing.T) {
+		require.NoError(t, headState.SetSlot(3*params.BeaconConfig().SlotsPerEpoch))
+		defer func() {
+			require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch+1))
+		}()
+		_, err := s.GetLiveness(ctx, &LivenessRequest{Epoch: 0, Index: []types.ValidatorIndex{0}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = s.GetLiveness(ctx, &LivenessRequest{Epoch: 4, Index: []types.ValidatorIndex{0}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		_, err := s.GetLiveness(ctx, &LivenessRequest{Epoch: 1, Index: []types.ValidatorIndex{64}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
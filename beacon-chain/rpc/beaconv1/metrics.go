@@ -0,0 +1,90 @@
+package beaconv1
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Object labels of pool submission metrics.
+const (
+	attestationObject      = "attestation"
+	attesterSlashingObject = "attester_slashing"
+	proposerSlashingObject = "proposer_slashing"
+	voluntaryExitObject    = "voluntary_exit"
+)
+
+// Reason labels of pool submission rejection metrics.
+const (
+	syncingReason             = "syncing"
+	malformedReason           = "malformed"
+	badSignatureReason        = "bad_signature"
+	notSlashableReason        = "not_slashable"
+	alreadyExitedReason       = "already_exited"
+	outOfRangeReason          = "out_of_range"
+	notActiveLongEnoughReason = "not_active_long_enough"
+	churnLimitReason          = "churn_limit"
+	notSubscribedReason       = "not_subscribed"
+	verificationFailedReason  = "verification_failed"
+	internalReason            = "internal"
+)
+
+var poolSubmissionRejections = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "pool_submission_rejections_total",
+		Help: "Count of pool object submissions rejected by the node, by object type and rejection reason.",
+	},
+	[]string{"object", "reason"},
+)
+
+var poolSubmissionsAccepted = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "pool_submissions_accepted_total",
+		Help: "Count of pool object submissions accepted by the node, by object type.",
+	},
+	[]string{"object"},
+)
+
+var poolBroadcastFailures = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "pool_broadcast_failures_total",
+		Help: "Count of pool objects inserted into the pool which could not be broadcast, by object type.",
+	},
+	[]string{"object"},
+)
+
+var poolRPCLatency = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "pool_rpc_latency_seconds",
+		Help:    "Latency of the pool submission and listing RPCs, by method.",
+		Buckets: []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5},
+	},
+	[]string{"method"},
+)
+
+// acceptSubmission records an accepted submission of the given object.
+func acceptSubmission(object string) {
+	poolSubmissionsAccepted.WithLabelValues(object).Inc()
+}
+
+// observePoolRPC records the latency of a pool RPC started at start. It is meant to be deferred.
+func observePoolRPC(method string, start time.Time) {
+	poolRPCLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// rejectSubmission records a rejected submission of the given object for the given reason and returns err as is.
+func rejectSubmission(object, reason string, err error) error {
+	poolSubmissionRejections.WithLabelValues(object, reason).Inc()
+	return err
+}
+
+// rejectHeadState records a submission rejected because the head state was unavailable and returns err as is.
+func rejectHeadState(object string, err error) error {
+	if status.Code(err) == codes.Unavailable {
+		return rejectSubmission(object, syncingReason, err)
+	}
+	return rejectSubmission(object, internalReason, err)
+}
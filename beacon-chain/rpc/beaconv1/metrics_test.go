@@ -0,0 +1,143 @@
+package beaconv1
+
+import (
+	"context"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	p2pMock "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	syncMock "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestPoolSubmissionRejections(t *testing.T) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 2)
+	exitedState := state.Copy()
+	require.NoError(t, exitedState.UpdateValidatorAtIndex(0, &eth.Validator{
+		ExitEpoch:         10,
+		WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+	}))
+	_, attSlashing := signedAttesterSlashing(t)
+	attSlashing.Attestation_2 = attSlashing.Attestation_1
+
+	newServer := func() *Server {
+		return &Server{
+			ChainInfoFetcher:   &chainMock.ChainService{State: state},
+			SlashingsPool:      &slashings.PoolMock{},
+			VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+			Broadcaster:        &p2pMock.MockBroadcaster{},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		object string
+		reason string
+		submit func(s *Server) error
+	}{
+		{
+			name:   "syncing",
+			object: voluntaryExitObject,
+			reason: syncingReason,
+			submit: func(s *Server) error {
+				s.SyncChecker = &syncMock.Sync{IsSyncing: true}
+				_, err := s.SubmitVoluntaryExit(ctx, exits[0])
+				return err
+			},
+		},
+		{
+			name:   "malformed proposer slashing",
+			object: proposerSlashingObject,
+			reason: malformedReason,
+			submit: func(s *Server) error {
+				_, err := s.SubmitProposerSlashing(ctx, &ethpb.ProposerSlashing{})
+				return err
+			},
+		},
+		{
+			name:   "non-conflicting attester slashing",
+			object: attesterSlashingObject,
+			reason: notSlashableReason,
+			submit: func(s *Server) error {
+				_, err := s.SubmitAttesterSlashing(ctx, attSlashing)
+				return err
+			},
+		},
+		{
+			name:   "exit with bad signature",
+			object: voluntaryExitObject,
+			reason: badSignatureReason,
+			submit: func(s *Server) error {
+				_, err := s.SubmitVoluntaryExit(ctx, &ethpb.SignedVoluntaryExit{
+					Exit:      exits[0].Exit,
+					Signature: exits[1].Signature,
+				})
+				return err
+			},
+		},
+		{
+			name:   "exit of already exited validator",
+			object: voluntaryExitObject,
+			reason: alreadyExitedReason,
+			submit: func(s *Server) error {
+				s.ChainInfoFetcher = &chainMock.ChainService{State: exitedState}
+				_, err := s.SubmitVoluntaryExit(ctx, exits[0])
+				return err
+			},
+		},
+		{
+			name:   "exit of unknown validator",
+			object: voluntaryExitObject,
+			reason: outOfRangeReason,
+			submit: func(s *Server) error {
+				_, err := s.SubmitVoluntaryExit(ctx, &ethpb.SignedVoluntaryExit{
+					Exit:      &ethpb.VoluntaryExit{ValidatorIndex: 100},
+					Signature: exits[0].Signature,
+				})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := poolSubmissionRejections.WithLabelValues(tt.object, tt.reason)
+			before := promtestutil.ToFloat64(counter)
+			require.NotNil(t, tt.submit(newServer()))
+			assert.Equal(t, before+1, promtestutil.ToFloat64(counter))
+		})
+	}
+}
+
+func TestPoolSubmissionsAccepted(t *testing.T) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 2)
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        &p2pMock.MockBroadcaster{},
+	}
+	accepted := poolSubmissionsAccepted.WithLabelValues(voluntaryExitObject)
+	before := promtestutil.ToFloat64(accepted)
+	_, err := s.SubmitVoluntaryExit(ctx, exits[0])
+	require.NoError(t, err)
+	assert.Equal(t, before+1, promtestutil.ToFloat64(accepted))
+	// Each exit of a batch is counted.
+	_, err = s.SubmitVoluntaryExits(ctx, &SubmitVoluntaryExitsRequest{Data: exits})
+	require.NoError(t, err)
+	assert.Equal(t, before+3, promtestutil.ToFloat64(accepted))
+
+	s.Broadcaster = &erroringBroadcaster{err: context.DeadlineExceeded}
+	failures := poolBroadcastFailures.WithLabelValues(voluntaryExitObject)
+	before = promtestutil.ToFloat64(failures)
+	_, err = s.SubmitVoluntaryExit(ctx, exits[0])
+	require.NotNil(t, err)
+	assert.Equal(t, before+1, promtestutil.ToFloat64(failures))
+}
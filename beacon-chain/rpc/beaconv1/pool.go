@@ -1,29 +1,225 @@
 package beaconv1
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	gosync "sync"
+	"time"
 
 	ptypes "github.com/gogo/protobuf/types"
+	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/proto/migration"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// defaultMaxBatchSize is the bulk submission limit used when the server has none configured.
+const defaultMaxBatchSize = 256
+
+// includeExpiredParam is the query parameter with which ListPoolVoluntaryExits also returns the exits
+// whose epoch is behind the current epoch.
+const includeExpiredParam = "include_expired"
+
 // ListPoolAttestations retrieves attestations known by the node but
-// not necessarily incorporated into any block.
+// not necessarily incorporated into any block, ordered by slot and committee index.
+// The listing is paginated when a page size or page token is requested.
 func (bs *Server) ListPoolAttestations(ctx context.Context, req *ethpb.AttestationsPoolRequest) (*ethpb.AttestationsPoolResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "beaconv1.ListPoolAttestations")
+	defer span.End()
+	defer observePoolRPC("ListPoolAttestations", time.Now())
+
+	unaggregated, err := bs.AttestationsPool.UnaggregatedAttestations()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get unaggregated attestations: %v", err)
+	}
+	filterSlot := requestFilterSet(ctx, "slot", uint64(req.Slot))
+	filterCommittee := requestFilterSet(ctx, "committee_index", uint64(req.CommitteeIndex))
+	var sourceAtts []*eth.Attestation
+	for _, a := range append(bs.AttestationsPool.AggregatedAttestations(), unaggregated...) {
+		if filterSlot && a.Data.Slot != req.Slot {
+			continue
+		}
+		if filterCommittee && a.Data.CommitteeIndex != req.CommitteeIndex {
+			continue
+		}
+		sourceAtts = append(sourceAtts, a)
+	}
+	sort.SliceStable(sourceAtts, func(i, j int) bool {
+		if sourceAtts[i].Data.Slot != sourceAtts[j].Data.Slot {
+			return sourceAtts[i].Data.Slot < sourceAtts[j].Data.Slot
+		}
+		return sourceAtts[i].Data.CommitteeIndex < sourceAtts[j].Data.CommitteeIndex
+	})
+
+	start, end, err := poolPageBounds(ctx, len(sourceAtts))
+	if err != nil {
+		return nil, err
+	}
+	atts := make([]*ethpb.Attestation, 0, end-start)
+	for _, a := range sourceAtts[start:end] {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while converting attestations: %v", err)
+		}
+		atts = append(atts, migration.V1Alpha1AttestationToV1(a))
+	}
+
+	return &ethpb.AttestationsPoolResponse{
+		Data: atts,
+	}, nil
 }
 
 // SubmitAttestation submits Attestation object to node. If attestation passes all validation
 // constraints, node MUST publish attestation on appropriate subnet.
 func (bs *Server) SubmitAttestation(ctx context.Context, req *ethpb.Attestation) (*ptypes.Empty, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "beaconv1.SubmitAttestation")
+	defer span.End()
+	defer observePoolRPC("SubmitAttestation", time.Now())
+
+	alphaAtt, err := convertAttestation(req)
+	if err != nil {
+		return nil, err
+	}
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, rejectHeadState(attestationObject, err)
+	}
+	if err := verifyAttestationSignature(ctx, headState, alphaAtt); err != nil {
+		return nil, err
+	}
+
+	// A valid aggregate whose bits are already covered by a pooled aggregate carries no new
+	// information, so we acknowledge it without inserting or broadcasting it again.
+	seen, err := bs.attestationSeen(alphaAtt)
+	if err != nil {
+		return nil, err
+	}
+	if seen {
+		return &ptypes.Empty{}, nil
+	}
+	if err := bs.poolAttestation(ctx, headState, alphaAtt); err != nil {
+		return nil, err
+	}
+
+	return &ptypes.Empty{}, nil
+}
+
+// convertAttestation converts a submitted attestation for the pool, rejecting it if required fields are missing.
+func convertAttestation(req *ethpb.Attestation) (*eth.Attestation, error) {
+	alphaAtt := migration.V1AttestationToV1Alpha1(req)
+	if err := helpers.ValidateNilAttestation(alphaAtt); err != nil {
+		return nil, rejectSubmission(attestationObject, malformedReason, status.Errorf(codes.InvalidArgument, "Invalid attestation: %v", err))
+	}
+	return alphaAtt, nil
+}
+
+// attestationSeen returns true if the attestation is an aggregate already covered by a pooled aggregate.
+func (bs *Server) attestationSeen(alphaAtt *eth.Attestation) (bool, error) {
+	if !helpers.IsAggregated(alphaAtt) {
+		return false, nil
+	}
+	seen, err := bs.AttestationsPool.HasAggregatedAttestation(alphaAtt)
+	if err != nil {
+		return false, rejectSubmission(attestationObject, internalReason, status.Errorf(codes.Internal, "Could not check attestation pool: %v", err))
+	}
+	return seen, nil
+}
+
+// verifyAttestationSignature checks the attestation signature against the head state.
+func verifyAttestationSignature(ctx context.Context, headState *statetrie.BeaconState, alphaAtt *eth.Attestation) error {
+	if err := blocks.VerifyAttestationSignature(ctx, headState, alphaAtt); err != nil {
+		return rejectSubmission(attestationObject, badSignatureReason, status.Errorf(codes.InvalidArgument, "Invalid attestation: %v", err))
+	}
+	return nil
+}
+
+// poolAttestation inserts a verified attestation into the pool and broadcasts it on its subnet.
+func (bs *Server) poolAttestation(ctx context.Context, headState *statetrie.BeaconState, alphaAtt *eth.Attestation) error {
+	var err error
+	if helpers.IsAggregated(alphaAtt) {
+		err = bs.AttestationsPool.SaveAggregatedAttestation(alphaAtt)
+	} else {
+		err = bs.AttestationsPool.SaveUnaggregatedAttestation(alphaAtt)
+	}
+	if err != nil {
+		return rejectSubmission(attestationObject, internalReason, status.Errorf(codes.Internal, "Could not insert attestation into pool: %v", err))
+	}
+
+	activeValCount, err := helpers.ActiveValidatorCount(headState, helpers.SlotToEpoch(alphaAtt.Data.Slot))
+	if err != nil {
+		return rejectSubmission(attestationObject, internalReason, status.Errorf(codes.Internal, "Could not get active validator count: %v", err))
+	}
+	subnet := helpers.ComputeSubnetFromCommitteeAndSlot(activeValCount, alphaAtt.Data.CommitteeIndex, alphaAtt.Data.Slot)
+	if err := bs.Broadcaster.BroadcastAttestation(ctx, subnet, alphaAtt); err != nil {
+		return pooledBroadcastError(attestationObject, "Could not broadcast attestation: %v", err)
+	}
+	bs.lastBroadcasts.record(attestationObject)
+	return nil
+}
+
+// SubmitAttestationsRequest is a batch of attestations submitted in a single call.
+type SubmitAttestationsRequest struct {
+	Data []*ethpb.Attestation `json:"data"`
+}
+
+// SubmitAttestations submits a batch of Attestation objects to the node, applying the same
+// validation, pooling and broadcasting as SubmitAttestation to each of them. Signatures are
+// verified concurrently, and none is pooled unless all of them are valid. The attestations are
+// then pooled and broadcast in order. The failures are reported by their index in the batch.
+func (bs *Server) SubmitAttestations(ctx context.Context, req *SubmitAttestationsRequest) (*ptypes.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.SubmitAttestations")
+	defer span.End()
+
+	if err := bs.validateBatchSize(len(req.Data)); err != nil {
+		return nil, err
+	}
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, rejectHeadState(attestationObject, err)
+	}
+
+	alphaAtts := make([]*eth.Attestation, len(req.Data))
+	errs := verifyBatch(ctx, len(req.Data), func(i int) error {
+		alphaAtt, err := convertAttestation(req.Data[i])
+		if err != nil {
+			return err
+		}
+		alphaAtts[i] = alphaAtt
+		return verifyAttestationSignature(ctx, headState, alphaAtt)
+	})
+	if err := batchError(ctx, "attestations", errs); err != nil {
+		return nil, err
+	}
+	for i, alphaAtt := range alphaAtts {
+		seen, err := bs.attestationSeen(alphaAtt)
+		if err == nil && !seen {
+			err = bs.poolAttestation(ctx, headState, alphaAtt)
+		}
+		errs[i] = err
+	}
+	if err := batchError(ctx, "attestations", errs); err != nil {
+		return nil, err
+	}
+
+	return &ptypes.Empty{}, nil
 }
 
 // ListPoolAttesterSlashings retrieves attester slashings known by the node but
@@ -31,16 +227,25 @@ func (bs *Server) SubmitAttestation(ctx context.Context, req *ethpb.Attestation)
 func (bs *Server) ListPoolAttesterSlashings(ctx context.Context, req *ptypes.Empty) (*ethpb.AttesterSlashingsPoolResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "beaconv1.ListPoolAttesterSlashings")
 	defer span.End()
+	defer observePoolRPC("ListPoolAttesterSlashings", time.Now())
 
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	headState, err := bs.requireHeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		return nil, err
 	}
-	sourceSlashings := bs.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */)
+	sourceSlashings := sortedAttesterSlashings(bs.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */))
 
-	slashings := make([]*ethpb.AttesterSlashing, len(sourceSlashings))
-	for i, s := range sourceSlashings {
-		slashings[i] = migration.V1Alpha1AttSlashingToV1(s)
+	start, end, err := poolPageBounds(ctx, len(sourceSlashings))
+	if err != nil {
+		return nil, err
+	}
+	slashings := make([]*ethpb.AttesterSlashing, 0, end-start)
+	for _, s := range sourceSlashings[start:end] {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while converting attester slashings: %v", err)
+		}
+		slashings = append(slashings, migration.V1Alpha1AttSlashingToV1(s))
 	}
 
 	return &ethpb.AttesterSlashingsPoolResponse{
@@ -48,32 +253,71 @@ func (bs *Server) ListPoolAttesterSlashings(ctx context.Context, req *ptypes.Emp
 	}, nil
 }
 
+// CountPoolAttesterSlashings returns the number of attester slashings known by the node
+// but not necessarily incorporated into any block, without converting or returning the slashings.
+func (bs *Server) CountPoolAttesterSlashings(ctx context.Context, _ *ptypes.Empty) (*ptypes.UInt64Value, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.CountPoolAttesterSlashings")
+	defer span.End()
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sourceSlashings := bs.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */)
+
+	return &ptypes.UInt64Value{
+		Value: uint64(len(sourceSlashings)),
+	}, nil
+}
+
 // SubmitAttesterSlashing submits AttesterSlashing object to node's pool and
 // if passes validation node MUST broadcast it to network.
 func (bs *Server) SubmitAttesterSlashing(ctx context.Context, req *ethpb.AttesterSlashing) (*ptypes.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "beaconv1.SubmitAttesterSlashing")
 	defer span.End()
+	defer observePoolRPC("SubmitAttesterSlashing", time.Now())
 
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	headState, err := bs.requireHeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		return nil, rejectHeadState(attesterSlashingObject, err)
 	}
 
 	alphaSlashing := migration.V1AttSlashingToV1Alpha1(req)
+	if err := validateAttesterSlashingFields(alphaSlashing); err != nil {
+		log.WithError(err).Debug("Received malformed attester slashing")
+		return nil, rejectSubmission(attesterSlashingObject, malformedReason, status.Errorf(codes.InvalidArgument, "Malformed request object: %v", err))
+	}
+	if err := validateAttesterSlashingConflict(alphaSlashing); err != nil {
+		return nil, rejectSubmission(attesterSlashingObject, notSlashableReason, status.Errorf(codes.InvalidArgument, "Invalid attester slashing: %v", err))
+	}
+	root, err := alphaSlashing.HashTreeRoot()
+	if err != nil {
+		return nil, rejectSubmission(attesterSlashingObject, internalReason, status.Errorf(codes.Internal, "Could not compute attester slashing root: %v", err))
+	}
+	// A slashing broadcast recently has already been verified and pooled, so a resubmission
+	// (e.g. gossiped back by a peer) is acknowledged without broadcasting it again.
+	if bs.SlashingReplayCache.seen(root) {
+		acceptSubmission(attesterSlashingObject)
+		return &ptypes.Empty{}, nil
+	}
 	err = blocks.VerifyAttesterSlashing(ctx, headState, alphaSlashing)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Invalid attester slashing: %v", err)
+		return nil, rejectSubmission(attesterSlashingObject, verificationFailedReason, status.Errorf(codes.InvalidArgument, "Invalid attester slashing: %v", err))
 	}
 
 	err = bs.SlashingsPool.InsertAttesterSlashing(ctx, headState, alphaSlashing)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not insert attester slashing into pool: %v", err)
+		return nil, rejectSubmission(attesterSlashingObject, internalReason, status.Errorf(codes.Internal, "Could not insert attester slashing into pool: %v", err))
 	}
+	bs.notifyAttesterSlashing(alphaSlashing)
 	if !featureconfig.Get().DisableBroadcastSlashings {
 		if err := bs.Broadcaster.Broadcast(ctx, req); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not broadcast slashing object: %v", err)
+			return nil, pooledBroadcastError(attesterSlashingObject, "Could not broadcast slashing object: %v", err)
 		}
+		bs.lastBroadcasts.record(attesterSlashingObject)
+		bs.SlashingReplayCache.add(root)
 	}
+	acceptSubmission(attesterSlashingObject)
 
 	return &ptypes.Empty{}, nil
 }
@@ -83,16 +327,25 @@ func (bs *Server) SubmitAttesterSlashing(ctx context.Context, req *ethpb.Atteste
 func (bs *Server) ListPoolProposerSlashings(ctx context.Context, req *ptypes.Empty) (*ethpb.ProposerSlashingPoolResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "beaconv1.ListPoolProposerSlashings")
 	defer span.End()
+	defer observePoolRPC("ListPoolProposerSlashings", time.Now())
 
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	headState, err := bs.requireHeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		return nil, err
 	}
-	sourceSlashings := bs.SlashingsPool.PendingProposerSlashings(ctx, headState, true /* return unlimited slashings */)
+	sourceSlashings := sortedProposerSlashings(bs.SlashingsPool.PendingProposerSlashings(ctx, headState, true /* return unlimited slashings */))
 
-	slashings := make([]*ethpb.ProposerSlashing, len(sourceSlashings))
-	for i, s := range sourceSlashings {
-		slashings[i] = migration.V1Alpha1ProposerSlashingToV1(s)
+	start, end, err := poolPageBounds(ctx, len(sourceSlashings))
+	if err != nil {
+		return nil, err
+	}
+	slashings := make([]*ethpb.ProposerSlashing, 0, end-start)
+	for _, s := range sourceSlashings[start:end] {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while converting proposer slashings: %v", err)
+		}
+		slashings = append(slashings, migration.V1Alpha1ProposerSlashingToV1(s))
 	}
 
 	return &ethpb.ProposerSlashingPoolResponse{
@@ -100,51 +353,161 @@ func (bs *Server) ListPoolProposerSlashings(ctx context.Context, req *ptypes.Emp
 	}, nil
 }
 
+// CountPoolProposerSlashings returns the number of proposer slashings known by the node
+// but not necessarily incorporated into any block, without converting or returning the slashings.
+func (bs *Server) CountPoolProposerSlashings(ctx context.Context, _ *ptypes.Empty) (*ptypes.UInt64Value, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.CountPoolProposerSlashings")
+	defer span.End()
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sourceSlashings := bs.SlashingsPool.PendingProposerSlashings(ctx, headState, true /* return unlimited slashings */)
+
+	return &ptypes.UInt64Value{
+		Value: uint64(len(sourceSlashings)),
+	}, nil
+}
+
 // SubmitProposerSlashing submits AttesterSlashing object to node's pool and if
 // passes validation node MUST broadcast it to network.
 func (bs *Server) SubmitProposerSlashing(ctx context.Context, req *ethpb.ProposerSlashing) (*ptypes.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "beaconv1.SubmitProposerSlashing")
 	defer span.End()
+	defer observePoolRPC("SubmitProposerSlashing", time.Now())
 
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	headState, err := bs.requireHeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		return nil, rejectHeadState(proposerSlashingObject, err)
 	}
 
 	alphaSlashing := migration.V1ProposerSlashingToV1Alpha1(req)
+	if err := validateProposerSlashingFields(alphaSlashing); err != nil {
+		log.WithError(err).Debug("Received malformed proposer slashing")
+		return nil, rejectSubmission(proposerSlashingObject, malformedReason, status.Errorf(codes.InvalidArgument, "Malformed request object: %v", err))
+	}
+	root, err := alphaSlashing.HashTreeRoot()
+	if err != nil {
+		return nil, rejectSubmission(proposerSlashingObject, internalReason, status.Errorf(codes.Internal, "Could not compute proposer slashing root: %v", err))
+	}
+	// A slashing broadcast recently has already been verified and pooled, so a resubmission
+	// (e.g. gossiped back by a peer) is acknowledged without broadcasting it again.
+	if bs.SlashingReplayCache.seen(root) {
+		acceptSubmission(proposerSlashingObject)
+		return &ptypes.Empty{}, nil
+	}
+	// Confirm the slashed proposer is known before the comparatively expensive signature
+	// verification, which would otherwise fail with a confusing error on an unknown index.
+	proposerIndex := alphaSlashing.Header_1.Header.ProposerIndex
+	if pubkey := headState.PubkeyAtIndex(proposerIndex); pubkey == [48]byte{} {
+		return nil, rejectSubmission(proposerSlashingObject, outOfRangeReason, status.Errorf(codes.InvalidArgument, "Invalid proposer slashing: proposer index %d has no public key in the head state", proposerIndex))
+	}
 	err = blocks.VerifyProposerSlashing(headState, alphaSlashing)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Invalid proposer slashing: %v", err)
+		return nil, rejectSubmission(proposerSlashingObject, verificationFailedReason, status.Errorf(codes.InvalidArgument, "Invalid proposer slashing: %v", err))
 	}
 
 	err = bs.SlashingsPool.InsertProposerSlashing(ctx, headState, alphaSlashing)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not insert proposer slashing into pool: %v", err)
+		return nil, rejectSubmission(proposerSlashingObject, internalReason, status.Errorf(codes.Internal, "Could not insert proposer slashing into pool: %v", err))
 	}
+	bs.notifyProposerSlashing(alphaSlashing)
 	if !featureconfig.Get().DisableBroadcastSlashings {
 		if err := bs.Broadcaster.Broadcast(ctx, req); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not broadcast slashing object: %v", err)
+			return nil, pooledBroadcastError(proposerSlashingObject, "Could not broadcast slashing object: %v", err)
 		}
+		bs.lastBroadcasts.record(proposerSlashingObject)
+		bs.SlashingReplayCache.add(root)
 	}
+	acceptSubmission(proposerSlashingObject)
 
 	return &ptypes.Empty{}, nil
 }
 
 // ListPoolVoluntaryExits retrieves voluntary exits known by the node but
-// not necessarily incorporated into any block.
-func (bs *Server) ListPoolVoluntaryExits(ctx context.Context, req *ptypes.Empty) (*ethpb.VoluntaryExitsPoolResponse, error) {
+// not necessarily incorporated into any block. Exits whose epoch is behind
+// the current epoch are considered expired and are only returned when the
+// include_expired parameter is true.
+// The listing is paginated when a page size or page token is requested.
+func (bs *Server) ListPoolVoluntaryExits(ctx context.Context, _ *ptypes.Empty) (*ethpb.VoluntaryExitsPoolResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "beaconv1.ListPoolVoluntaryExits")
 	defer span.End()
+	defer observePoolRPC("ListPoolVoluntaryExits", time.Now())
 
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	headState, err := bs.requireHeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		return nil, err
+	}
+	currentEpoch := helpers.CurrentEpoch(headState)
+	includeExpired := false
+	if value, ok := grpcutils.RequestParam(ctx, includeExpiredParam); ok {
+		includeExpired, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid %s value %q", includeExpiredParam, value)
+		}
 	}
 
-	sourceExits := bs.VoluntaryExitsPool.PendingExits(headState, headState.Slot(), true /* return unlimited exits */)
+	var sourceExits []*eth.SignedVoluntaryExit
+	for _, e := range sortedExits(bs.VoluntaryExitsPool.PendingExits(headState, headState.Slot(), true /* return unlimited exits */)) {
+		if includeExpired || e.Exit.Epoch >= currentEpoch {
+			sourceExits = append(sourceExits, e)
+		}
+	}
+
+	start, end, err := poolPageBounds(ctx, len(sourceExits))
+	if err != nil {
+		return nil, err
+	}
+	exits := make([]*ethpb.SignedVoluntaryExit, 0, end-start)
+	for _, s := range sourceExits[start:end] {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while converting voluntary exits: %v", err)
+		}
+		exits = append(exits, migration.V1Alpha1ExitToV1(s))
+	}
+
+	return &ethpb.VoluntaryExitsPoolResponse{
+		Data: exits,
+	}, nil
+}
+
+// VoluntaryExitsOrderedRequest selects the priority in which GetVoluntaryExitsOrdered returns exits.
+// The zero value uses the ordering the exit pool was configured with.
+type VoluntaryExitsOrderedRequest struct {
+	Ordering voluntaryexits.ExitOrdering `json:"ordering"`
+}
+
+// GetVoluntaryExitsOrdered retrieves voluntary exits known by the node but not necessarily
+// incorporated into any block, in the order they would be considered for block inclusion.
+func (bs *Server) GetVoluntaryExitsOrdered(ctx context.Context, req *VoluntaryExitsOrderedRequest) (*ethpb.VoluntaryExitsPoolResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.GetVoluntaryExitsOrdered")
+	defer span.End()
+
+	ordering := voluntaryexits.DefaultOrdering
+	if req != nil {
+		ordering = req.Ordering
+	}
+	switch ordering {
+	case voluntaryexits.DefaultOrdering, voluntaryexits.LowestIndexFirst, voluntaryexits.EarliestSubmittedFirst:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown exit ordering %s", ordering)
+	}
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceExits := bs.VoluntaryExitsPool.PendingExitsOrdered(headState, headState.Slot(), true /* return unlimited exits */, ordering)
 
 	exits := make([]*ethpb.SignedVoluntaryExit, len(sourceExits))
 	for i, s := range sourceExits {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while converting voluntary exits: %v", err)
+		}
 		exits[i] = migration.V1Alpha1ExitToV1(s)
 	}
 
@@ -153,31 +516,554 @@ func (bs *Server) ListPoolVoluntaryExits(ctx context.Context, req *ptypes.Empty)
 	}, nil
 }
 
+// CountPoolVoluntaryExits returns the number of voluntary exits known by the node
+// but not necessarily incorporated into any block, without converting or returning the exits.
+func (bs *Server) CountPoolVoluntaryExits(ctx context.Context, _ *ptypes.Empty) (*ptypes.UInt64Value, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.CountPoolVoluntaryExits")
+	defer span.End()
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sourceExits := bs.VoluntaryExitsPool.PendingExits(headState, headState.Slot(), true /* return unlimited exits */)
+
+	return &ptypes.UInt64Value{
+		Value: uint64(len(sourceExits)),
+	}, nil
+}
+
+// ExitingValidatorsResponse lists the indices of validators that have a voluntary exit pending in the pool.
+type ExitingValidatorsResponse struct {
+	Data []types.ValidatorIndex `json:"data"`
+}
+
+// ListExitingValidators retrieves the sorted, deduplicated indices of validators whose voluntary
+// exits are known by the node but not necessarily incorporated into any block.
+func (bs *Server) ListExitingValidators(ctx context.Context, _ *ptypes.Empty) (*ExitingValidatorsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.ListExitingValidators")
+	defer span.End()
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sourceExits := bs.VoluntaryExitsPool.PendingExits(headState, headState.Slot(), true /* return unlimited exits */)
+
+	seen := make(map[types.ValidatorIndex]bool, len(sourceExits))
+	indices := make([]types.ValidatorIndex, 0, len(sourceExits))
+	for _, e := range sourceExits {
+		if e == nil || e.Exit == nil || seen[e.Exit.ValidatorIndex] {
+			continue
+		}
+		seen[e.Exit.ValidatorIndex] = true
+		indices = append(indices, e.Exit.ValidatorIndex)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	return &ExitingValidatorsResponse{
+		Data: indices,
+	}, nil
+}
+
 // SubmitVoluntaryExit submits SignedVoluntaryExit object to node's pool
 // and if passes validation node MUST broadcast it to network.
 func (bs *Server) SubmitVoluntaryExit(ctx context.Context, req *ethpb.SignedVoluntaryExit) (*ptypes.Empty, error) {
 	ctx, span := trace.StartSpan(ctx, "beaconv1.SubmitVoluntaryExit")
 	defer span.End()
+	defer observePoolRPC("SubmitVoluntaryExit", time.Now())
 
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	headState, err := bs.requireHeadState(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		return nil, rejectHeadState(voluntaryExitObject, err)
 	}
 
-	validator, err := headState.ValidatorAtIndexReadOnly(req.Exit.ValidatorIndex)
+	alphaExit, err := verifyVoluntaryExit(headState, req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get exiting validator: %v", err)
+		return nil, err
 	}
+	if err := bs.poolVoluntaryExit(ctx, headState, req, alphaExit); err != nil {
+		return nil, err
+	}
+
+	return &ptypes.Empty{}, nil
+}
+
+// verifyVoluntaryExit checks a submitted exit against the head state, returning it converted
+// for the pool. It does not depend on the pool, so exits of a batch may be verified concurrently.
+func verifyVoluntaryExit(headState *statetrie.BeaconState, req *ethpb.SignedVoluntaryExit) (*eth.SignedVoluntaryExit, error) {
 	alphaExit := migration.V1ExitToV1Alpha1(req)
-	err = blocks.VerifyExitAndSignature(validator, headState.Slot(), headState.Fork(), alphaExit, headState.GenesisValidatorRoot())
+	if err := validateExitFields(alphaExit); err != nil {
+		log.WithError(err).Debug("Received malformed voluntary exit")
+		return nil, rejectSubmission(voluntaryExitObject, malformedReason, status.Errorf(codes.InvalidArgument, "Malformed request object: %v", err))
+	}
+
+	validator, err := headState.ValidatorAtIndexReadOnly(alphaExit.Exit.ValidatorIndex)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Invalid voluntary exit: %v", err)
+		return nil, rejectSubmission(voluntaryExitObject, outOfRangeReason, status.Errorf(codes.InvalidArgument, "Could not get exiting validator: %v", err))
+	}
+	// Per spec, a validator must have been active for at least SHARD_COMMITTEE_PERIOD epochs before it can exit.
+	currentEpoch := helpers.SlotToEpoch(headState.Slot())
+	earliestExitEpoch, err := validator.ActivationEpoch().SafeAddEpoch(params.BeaconConfig().ShardCommitteePeriod)
+	if err != nil || currentEpoch < earliestExitEpoch {
+		return nil, rejectSubmission(voluntaryExitObject, notActiveLongEnoughReason, status.Errorf(
+			codes.InvalidArgument,
+			"Validator %d has not been active long enough to exit: activation epoch %d, current epoch %d, required active period %d epochs",
+			alphaExit.Exit.ValidatorIndex,
+			validator.ActivationEpoch(),
+			currentEpoch,
+			params.BeaconConfig().ShardCommitteePeriod,
+		))
+	}
+
+	if err := blocks.VerifyExitAndSignature(validator, headState.Slot(), headState.Fork(), alphaExit, headState.GenesisValidatorRoot()); err != nil {
+		reason := exitRejectionReason(validator, currentEpoch, alphaExit)
+		return nil, rejectSubmission(voluntaryExitObject, reason, status.Errorf(codes.InvalidArgument, "Invalid voluntary exit: %v", err))
+	}
+	return alphaExit, nil
+}
+
+// poolVoluntaryExit inserts a verified exit into the pool and broadcasts it.
+func (bs *Server) poolVoluntaryExit(ctx context.Context, headState *statetrie.BeaconState, req *ethpb.SignedVoluntaryExit, alphaExit *eth.SignedVoluntaryExit) error {
+	if bs.VerifyExitsAgainstPool {
+		if err := bs.checkPendingExitChurn(headState, alphaExit.Exit.ValidatorIndex); err != nil {
+			if status.Code(err) == codes.ResourceExhausted {
+				return rejectSubmission(voluntaryExitObject, churnLimitReason, err)
+			}
+			return rejectSubmission(voluntaryExitObject, internalReason, err)
+		}
 	}
 
 	bs.VoluntaryExitsPool.InsertVoluntaryExit(ctx, headState, alphaExit)
 	if err := bs.Broadcaster.Broadcast(ctx, req); err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not broadcast voluntary exit object: %v", err)
+		return pooledBroadcastError(voluntaryExitObject, "Could not broadcast voluntary exit object: %v", err)
+	}
+	bs.lastBroadcasts.record(voluntaryExitObject)
+	acceptSubmission(voluntaryExitObject)
+
+	return nil
+}
+
+// SubmitVoluntaryExitsRequest is a batch of signed voluntary exits submitted in a single call.
+type SubmitVoluntaryExitsRequest struct {
+	Data []*ethpb.SignedVoluntaryExit `json:"data"`
+}
+
+// SubmitVoluntaryExits submits a batch of SignedVoluntaryExit objects to the node's pool,
+// applying the same validation and broadcasting as SubmitVoluntaryExit to each of them. Exits
+// are verified concurrently, and none is pooled unless all of them are valid. The exits are then
+// pooled and broadcast in order, so that the churn check against the pool sees the earlier exits
+// of the batch. The failures are reported by their index in the batch.
+func (bs *Server) SubmitVoluntaryExits(ctx context.Context, req *SubmitVoluntaryExitsRequest) (*ptypes.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.SubmitVoluntaryExits")
+	defer span.End()
+	defer observePoolRPC("SubmitVoluntaryExits", time.Now())
+
+	if err := bs.validateBatchSize(len(req.Data)); err != nil {
+		return nil, err
+	}
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, rejectHeadState(voluntaryExitObject, err)
+	}
+
+	alphaExits := make([]*eth.SignedVoluntaryExit, len(req.Data))
+	errs := verifyBatch(ctx, len(req.Data), func(i int) error {
+		alphaExit, err := verifyVoluntaryExit(headState, req.Data[i])
+		alphaExits[i] = alphaExit
+		return err
+	})
+	if err := batchError(ctx, "voluntary exits", errs); err != nil {
+		return nil, err
+	}
+	for i := range req.Data {
+		errs[i] = bs.poolVoluntaryExit(ctx, headState, req.Data[i], alphaExits[i])
+	}
+	if err := batchError(ctx, "voluntary exits", errs); err != nil {
+		return nil, err
 	}
 
 	return &ptypes.Empty{}, nil
 }
+
+// RevalidatePoolsResponse reports how many pooled operations RevalidatePools kept and dropped.
+type RevalidatePoolsResponse struct {
+	AttesterSlashingsKept    uint64 `json:"attester_slashings_kept"`
+	AttesterSlashingsDropped uint64 `json:"attester_slashings_dropped"`
+	ProposerSlashingsKept    uint64 `json:"proposer_slashings_kept"`
+	ProposerSlashingsDropped uint64 `json:"proposer_slashings_dropped"`
+	VoluntaryExitsKept       uint64 `json:"voluntary_exits_kept"`
+	VoluntaryExitsDropped    uint64 `json:"voluntary_exits_dropped"`
+}
+
+// RevalidatePools re-runs verification of every pooled slashing and voluntary exit against the
+// current head state, e.g. after a reorg, and drops the ones that are no longer valid. As it mutates
+// the pools, it is not a method of the gRPC services but served as an authenticated admin endpoint
+// of the gateway.
+func (bs *Server) RevalidatePools(ctx context.Context, _ *ptypes.Empty) (*RevalidatePoolsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.RevalidatePools")
+	defer span.End()
+
+	headState, err := bs.requireHeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &RevalidatePoolsResponse{}
+
+	for _, slashing := range bs.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */) {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while revalidating attester slashings: %v", err)
+		}
+		if err := verifyPooledAttesterSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Dropping attester slashing that is no longer valid")
+			bs.SlashingsPool.RemoveAttesterSlashing(slashing)
+			resp.AttesterSlashingsDropped++
+			continue
+		}
+		resp.AttesterSlashingsKept++
+	}
+
+	for _, slashing := range bs.SlashingsPool.PendingProposerSlashings(ctx, headState, true /* return unlimited slashings */) {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while revalidating proposer slashings: %v", err)
+		}
+		if err := blocks.VerifyProposerSlashing(headState, slashing); err != nil {
+			log.WithError(err).Debug("Dropping proposer slashing that is no longer valid")
+			bs.SlashingsPool.RemoveProposerSlashing(slashing)
+			resp.ProposerSlashingsDropped++
+			continue
+		}
+		resp.ProposerSlashingsKept++
+	}
+
+	for _, exit := range bs.VoluntaryExitsPool.PendingExits(headState, headState.Slot(), true /* return unlimited exits */) {
+		if err := ctx.Err(); err != nil {
+			traceutil.AnnotateError(span, err)
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while revalidating voluntary exits: %v", err)
+		}
+		if err := verifyPooledExit(headState, exit); err != nil {
+			log.WithError(err).Debug("Dropping voluntary exit that is no longer valid")
+			// Removing an exit from the pool carries no other bookkeeping, so MarkIncluded is safe here.
+			bs.VoluntaryExitsPool.MarkIncluded(exit)
+			resp.VoluntaryExitsDropped++
+			continue
+		}
+		resp.VoluntaryExitsKept++
+	}
+
+	return resp, nil
+}
+
+// verifyPooledAttesterSlashing checks that a pooled attester slashing verifies against the state
+// and still slashes at least one slashable validator.
+func verifyPooledAttesterSlashing(ctx context.Context, headState *statetrie.BeaconState, slashing *eth.AttesterSlashing) error {
+	if err := blocks.VerifyAttesterSlashing(ctx, headState, slashing); err != nil {
+		return err
+	}
+	currentEpoch := helpers.CurrentEpoch(headState)
+	for _, idx := range sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices) {
+		validator, err := headState.ValidatorAtIndexReadOnly(types.ValidatorIndex(idx))
+		if err != nil {
+			return err
+		}
+		if helpers.IsSlashableValidatorUsingTrie(validator, currentEpoch) {
+			return nil
+		}
+	}
+	return errors.New("no slashable validators in attester slashing")
+}
+
+// verifyPooledExit checks that a pooled voluntary exit verifies against the state.
+func verifyPooledExit(headState *statetrie.BeaconState, exit *eth.SignedVoluntaryExit) error {
+	validator, err := headState.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
+	if err != nil {
+		return err
+	}
+	return blocks.VerifyExitAndSignature(validator, headState.Slot(), headState.Fork(), exit, headState.GenesisValidatorRoot())
+}
+
+// validateBatchSize rejects bulk submissions that are empty or larger than the configured
+// maximum, before any per-item work is done.
+func (bs *Server) validateBatchSize(size int) error {
+	maxSize := bs.MaxBatchSize
+	if maxSize == 0 {
+		maxSize = defaultMaxBatchSize
+	}
+	if size == 0 {
+		return status.Error(codes.InvalidArgument, "No items provided in batch")
+	}
+	if uint64(size) > maxSize {
+		return status.Errorf(codes.InvalidArgument, "Batch of %d items exceeds the maximum batch size of %d", size, maxSize)
+	}
+	return nil
+}
+
+// verifyBatch calls verify for every index of a batch of the given size, running at most
+// featureconfig.Get().BulkVerificationWorkers verifications at a time (GOMAXPROCS if unset).
+// The returned errors are collated by index, regardless of the order verifications complete in.
+func verifyBatch(ctx context.Context, size int, verify func(i int) error) []error {
+	workers := featureconfig.Get().BulkVerificationWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > size {
+		workers = size
+	}
+
+	errs := make([]error, size)
+	indices := make(chan int)
+	var wg gosync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = verify(i)
+			}
+		}()
+	}
+	for i := 0; i < size; i++ {
+		if err := ctx.Err(); err != nil {
+			errs[i] = status.Errorf(codes.Canceled, "Request cancelled while verifying batch: %v", err)
+			continue
+		}
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return errs
+}
+
+// checkPendingExitChurn rejects an exit for the given validator when the exits already in the pool
+// fill the validator churn limit of the head state, as the exits could not be processed together.
+func (bs *Server) checkPendingExitChurn(headState *statetrie.BeaconState, validatorIndex types.ValidatorIndex) error {
+	activeCount, err := helpers.ActiveValidatorCount(headState, helpers.CurrentEpoch(headState))
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get active validator count: %v", err)
+	}
+	churnLimit, err := helpers.ValidatorChurnLimit(activeCount)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get validator churn limit: %v", err)
+	}
+	pooled := uint64(0)
+	for _, e := range bs.VoluntaryExitsPool.PendingExits(headState, headState.Slot(), true /* return unlimited exits */) {
+		// A resubmission for the same validator replaces its pooled exit rather than adding to the churn.
+		if e.Exit.ValidatorIndex != validatorIndex {
+			pooled++
+		}
+	}
+	if pooled >= churnLimit {
+		return status.Errorf(
+			codes.ResourceExhausted,
+			"Pending voluntary exits already reach the validator churn limit of %d, validator %d cannot exit together with them",
+			churnLimit,
+			validatorIndex,
+		)
+	}
+	return nil
+}
+
+// sortedAttesterSlashings returns a copy of the slashings ordered by the lowest attesting
+// index, the slots of the two attestations and the hash tree root, so that list responses are
+// reproducible.
+func sortedAttesterSlashings(slashings []*eth.AttesterSlashing) []*eth.AttesterSlashing {
+	sorted := make([]*eth.AttesterSlashing, len(slashings))
+	copy(sorted, slashings)
+	roots := newTiebreakRoots(func(op hashTreeRooter) error {
+		return validateAttesterSlashingFields(op.(*eth.AttesterSlashing))
+	})
+	lowestIndex := func(att *eth.IndexedAttestation) uint64 {
+		indices := att.GetAttestingIndices()
+		if len(indices) == 0 {
+			return 0
+		}
+		lowest := indices[0]
+		for _, idx := range indices[1:] {
+			if idx < lowest {
+				lowest = idx
+			}
+		}
+		return lowest
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		si, sj := sorted[i], sorted[j]
+		if li, lj := lowestIndex(si.GetAttestation_1()), lowestIndex(sj.GetAttestation_1()); li != lj {
+			return li < lj
+		}
+		if ai, aj := si.GetAttestation_1().GetData().GetSlot(), sj.GetAttestation_1().GetData().GetSlot(); ai != aj {
+			return ai < aj
+		}
+		if ai, aj := si.GetAttestation_2().GetData().GetSlot(), sj.GetAttestation_2().GetData().GetSlot(); ai != aj {
+			return ai < aj
+		}
+		return roots.less(si, sj)
+	})
+	return sorted
+}
+
+// sortedProposerSlashings returns a copy of the slashings ordered by proposer index, slot and
+// hash tree root, so that list responses are reproducible.
+func sortedProposerSlashings(slashings []*eth.ProposerSlashing) []*eth.ProposerSlashing {
+	sorted := make([]*eth.ProposerSlashing, len(slashings))
+	copy(sorted, slashings)
+	roots := newTiebreakRoots(func(op hashTreeRooter) error {
+		return validateProposerSlashingFields(op.(*eth.ProposerSlashing))
+	})
+	sort.SliceStable(sorted, func(i, j int) bool {
+		hi, hj := sorted[i].GetHeader_1().GetHeader(), sorted[j].GetHeader_1().GetHeader()
+		if hi.GetProposerIndex() != hj.GetProposerIndex() {
+			return hi.GetProposerIndex() < hj.GetProposerIndex()
+		}
+		if hi.GetSlot() != hj.GetSlot() {
+			return hi.GetSlot() < hj.GetSlot()
+		}
+		return roots.less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// sortedExits returns a copy of the exits ordered by validator index, epoch and hash tree root,
+// so that list responses are reproducible.
+func sortedExits(exits []*eth.SignedVoluntaryExit) []*eth.SignedVoluntaryExit {
+	sorted := make([]*eth.SignedVoluntaryExit, len(exits))
+	copy(sorted, exits)
+	roots := newTiebreakRoots(func(op hashTreeRooter) error {
+		return validateExitFields(op.(*eth.SignedVoluntaryExit))
+	})
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ei, ej := sorted[i].GetExit(), sorted[j].GetExit()
+		if ei.GetValidatorIndex() != ej.GetValidatorIndex() {
+			return ei.GetValidatorIndex() < ej.GetValidatorIndex()
+		}
+		if ei.GetEpoch() != ej.GetEpoch() {
+			return ei.GetEpoch() < ej.GetEpoch()
+		}
+		return roots.less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// hashTreeRooter is an operation with a hash tree root.
+type hashTreeRooter interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// tiebreakRoots orders operations by hash tree root to break ties when sorting. Roots are only
+// computed for the operations that tie on the other sort keys, and cached across comparisons.
+type tiebreakRoots struct {
+	roots map[hashTreeRooter][32]byte
+	// validate rejects the operations missing fields, whose root cannot be computed.
+	validate func(op hashTreeRooter) error
+}
+
+func newTiebreakRoots(validate func(op hashTreeRooter) error) *tiebreakRoots {
+	return &tiebreakRoots{roots: make(map[hashTreeRooter][32]byte), validate: validate}
+}
+
+// less reports whether the root of a sorts before the root of b. An operation whose root cannot
+// be computed sorts as the zero root.
+func (r *tiebreakRoots) less(a, b hashTreeRooter) bool {
+	ra, rb := r.root(a), r.root(b)
+	return bytes.Compare(ra[:], rb[:]) < 0
+}
+
+func (r *tiebreakRoots) root(op hashTreeRooter) [32]byte {
+	if root, ok := r.roots[op]; ok {
+		return root
+	}
+	var root [32]byte
+	if r.validate(op) == nil {
+		if htr, err := op.HashTreeRoot(); err == nil {
+			root = htr
+		}
+	}
+	r.roots[op] = root
+	return root
+}
+
+// pooledBroadcastError maps the failure to broadcast an item that has already been inserted into the pool.
+// A node that has not joined the gossip topic yet still holds the item, so that case is reported as
+// FailedPrecondition rather than Internal.
+func pooledBroadcastError(object, format string, err error) error {
+	poolBroadcastFailures.WithLabelValues(object).Inc()
+	if errors.Is(err, p2p.ErrTopicNotSubscribed) {
+		return rejectSubmission(object, notSubscribedReason, status.Error(
+			codes.FailedPrecondition,
+			"Node not yet subscribed to topic; item pooled and will broadcast shortly",
+		))
+	}
+	return rejectSubmission(object, internalReason, status.Errorf(codes.Internal, format, err))
+}
+
+// exitRejectionReason classifies why a voluntary exit failed verification against the head state.
+func exitRejectionReason(validator statetrie.ReadOnlyValidator, currentEpoch types.Epoch, exit *eth.SignedVoluntaryExit) string {
+	switch {
+	case validator.ExitEpoch() != params.BeaconConfig().FarFutureEpoch:
+		return alreadyExitedReason
+	case !helpers.IsActiveValidatorUsingTrie(validator, currentEpoch) || currentEpoch < exit.Exit.Epoch:
+		return verificationFailedReason
+	default:
+		return badSignatureReason
+	}
+}
+
+// validateAttesterSlashingFields checks that a converted attester slashing carries every field
+// required for verification, so that malformed requests are not reported as verification failures.
+func validateAttesterSlashingFields(slashing *eth.AttesterSlashing) error {
+	for i, att := range []*eth.IndexedAttestation{slashing.Attestation_1, slashing.Attestation_2} {
+		if att == nil {
+			return fmt.Errorf("attestation_%d is missing", i+1)
+		}
+		if att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+			return fmt.Errorf("attestation_%d is missing attestation data, source or target", i+1)
+		}
+		if len(att.Signature) == 0 {
+			return fmt.Errorf("attestation_%d is missing signature", i+1)
+		}
+	}
+	return nil
+}
+
+// validateAttesterSlashingConflict checks that the two attestations of a well-formed attester slashing
+// are slashable, i.e. a double vote or attestation_1 surrounding attestation_2, so that slasher
+// false positives are reported distinctly from signature or index verification failures.
+func validateAttesterSlashingConflict(slashing *eth.AttesterSlashing) error {
+	data1, data2 := slashing.Attestation_1.Data, slashing.Attestation_2.Data
+	if blocks.IsSlashableAttestationData(data1, data2) {
+		return nil
+	}
+	if blocks.IsSlashableAttestationData(data2, data1) {
+		return errors.New("attestation_2 surrounds attestation_1, the surrounding attestation must be attestation_1")
+	}
+	return errors.New("attestations do not conflict, they are neither a double vote nor a surround vote")
+}
+
+// validateProposerSlashingFields checks that a converted proposer slashing carries every field
+// required for verification, so that malformed requests are not reported as verification failures.
+func validateProposerSlashingFields(slashing *eth.ProposerSlashing) error {
+	for i, h := range []*eth.SignedBeaconBlockHeader{slashing.Header_1, slashing.Header_2} {
+		if h == nil || h.Header == nil {
+			return fmt.Errorf("header_%d is missing", i+1)
+		}
+		if len(h.Signature) == 0 {
+			return fmt.Errorf("header_%d is missing signature", i+1)
+		}
+	}
+	return nil
+}
+
+// validateExitFields checks that a converted voluntary exit carries every field
+// required for verification, so that malformed requests are not reported as verification failures.
+func validateExitFields(exit *eth.SignedVoluntaryExit) error {
+	if exit.Exit == nil {
+		return errors.New("exit message is missing")
+	}
+	if len(exit.Signature) == 0 {
+		return errors.New("exit signature is missing")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package beaconv1
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/pagination"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Query parameters and response fields of the paginated pool listings.
+const (
+	pageSizeParam      = "page_size"
+	pageTokenParam     = "page_token"
+	nextPageTokenField = "next_page_token"
+	totalSizeField     = "total_size"
+)
+
+// poolPageBounds returns the bounds of the page of a pool listing of the given size selected by
+// the page_size and page_token request parameters, capping the page size at the server's maximum
+// RPC page size. A zero page size uses the default page size, and the page token of the first page
+// is empty. Without either parameter the whole listing is returned, as in the standard API.
+// Otherwise the next page token and the size of the listing are set as response fields.
+func poolPageBounds(ctx context.Context, totalSize int) (int, int, error) {
+	sizeParam, sizeSet := grpcutils.RequestParam(ctx, pageSizeParam)
+	pageToken, tokenSet := grpcutils.RequestParam(ctx, pageTokenParam)
+	if !sizeSet && !tokenSet {
+		return 0, totalSize, nil
+	}
+	var pageSize int
+	if sizeSet {
+		size, err := strconv.ParseInt(sizeParam, 10, 32)
+		if err != nil {
+			return 0, 0, status.Errorf(codes.InvalidArgument, "Invalid page size %q: %v", sizeParam, err)
+		}
+		pageSize = int(size)
+	}
+	if pageSize < 0 {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "Requested page size %d can not be negative", pageSize)
+	}
+	if pageSize > cmd.Get().MaxRPCPageSize {
+		pageSize = cmd.Get().MaxRPCPageSize
+	}
+
+	var start, end int
+	var nextPageToken string
+	if totalSize > 0 || (pageToken != "" && pageToken != "0") {
+		var err error
+		start, end, nextPageToken, err = pagination.StartAndEndPage(pageToken, pageSize, totalSize)
+		if err != nil {
+			return 0, 0, status.Errorf(codes.InvalidArgument, "Could not paginate pool: %v", err)
+		}
+	}
+	if err := grpcutils.SetResponseField(ctx, nextPageTokenField, nextPageToken); err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "Could not set next page token: %v", err)
+	}
+	if err := grpcutils.SetResponseField(ctx, totalSizeField, strconv.Itoa(totalSize)); err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "Could not set total size: %v", err)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,209 @@
+package beaconv1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+	eth2types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// pageContext returns an incoming context carrying the request parameters, whose response
+// fields are recorded by the returned stream.
+func pageContext(params ...string) (context.Context, *headerStream) {
+	md := metadata.MD{}
+	for i := 0; i+1 < len(params); i += 2 {
+		md.Append(grpcutils.RequestParamMetadataPrefix+params[i], params[i+1])
+	}
+	stream := &headerStream{}
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	return grpc.NewContextWithServerTransportStream(ctx, stream), stream
+}
+
+func TestListPoolVoluntaryExits_Pages(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	exits := make([]*eth.SignedVoluntaryExit, 5)
+	for i := range exits {
+		exits[i] = &eth.SignedVoluntaryExit{Exit: &eth.VoluntaryExit{ValidatorIndex: eth2types.ValidatorIndex(len(exits) - i)}}
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
+
+	var indices []eth2types.ValidatorIndex
+	pageToken := ""
+	for pages := 0; ; pages++ {
+		require.Equal(t, true, pages < 3, "too many pages")
+		ctx, stream := pageContext(pageSizeParam, "2", pageTokenParam, pageToken)
+		resp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+		require.NoError(t, err)
+		fields := grpcutils.ResponseFields(stream.header)
+		assert.Equal(t, "5", fields[totalSizeField])
+		for _, e := range resp.Data {
+			indices = append(indices, e.Exit.ValidatorIndex)
+		}
+		pageToken = fields[nextPageTokenField]
+		if pageToken == "" {
+			break
+		}
+	}
+	assert.DeepEqual(t, []eth2types.ValidatorIndex{1, 2, 3, 4, 5}, indices)
+
+	// Without page parameters, the whole listing is returned.
+	ctx, stream := pageContext()
+	resp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(resp.Data))
+	assert.Equal(t, 0, len(grpcutils.ResponseFields(stream.header)))
+}
+
+func TestListPoolSlashings_Pages(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	attSlashings := []*eth.AttesterSlashing{
+		{
+			Attestation_1: &eth.IndexedAttestation{AttestingIndices: []uint64{2}, Data: &eth.AttestationData{}},
+			Attestation_2: &eth.IndexedAttestation{AttestingIndices: []uint64{2}, Data: &eth.AttestationData{}},
+		},
+		{
+			Attestation_1: &eth.IndexedAttestation{AttestingIndices: []uint64{1}, Data: &eth.AttestationData{}},
+			Attestation_2: &eth.IndexedAttestation{AttestingIndices: []uint64{1}, Data: &eth.AttestationData{}},
+		},
+	}
+	propSlashings := []*eth.ProposerSlashing{
+		{
+			Header_1: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 2}},
+			Header_2: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 2}},
+		},
+		{
+			Header_1: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 1}},
+			Header_2: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 1}},
+		},
+	}
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: state},
+		SlashingsPool:    &slashings.PoolMock{PendingAttSlashings: attSlashings, PendingPropSlashings: propSlashings},
+	}
+
+	ctx, stream := pageContext(pageSizeParam, "1", pageTokenParam, "1")
+	attPage, err := s.ListPoolAttesterSlashings(ctx, &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(attPage.Data))
+	assert.Equal(t, uint64(2), attPage.Data[0].Attestation_1.AttestingIndices[0])
+	fields := grpcutils.ResponseFields(stream.header)
+	assert.Equal(t, "", fields[nextPageTokenField])
+	assert.Equal(t, "2", fields[totalSizeField])
+
+	ctx, stream = pageContext(pageSizeParam, "1")
+	propPage, err := s.ListPoolProposerSlashings(ctx, &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(propPage.Data))
+	assert.Equal(t, eth2types.ValidatorIndex(1), propPage.Data[0].Header_1.Header.ProposerIndex)
+	assert.Equal(t, "1", grpcutils.ResponseFields(stream.header)[nextPageTokenField])
+}
+
+func TestListPoolAttestations(t *testing.T) {
+	pool := attestations.NewPool()
+	for _, slot := range []eth2types.Slot{3, 1, 2} {
+		att := testutil.HydrateAttestation(&eth.Attestation{AggregationBits: bitfield.Bitlist{0b1101}})
+		att.Data.Slot = slot
+		require.NoError(t, pool.SaveAggregatedAttestation(att))
+	}
+	att := testutil.HydrateAttestation(&eth.Attestation{AggregationBits: bitfield.Bitlist{0b101}})
+	att.Data.Slot = 0
+	require.NoError(t, pool.SaveUnaggregatedAttestation(att))
+	s := &Server{AttestationsPool: pool}
+
+	resp, err := s.ListPoolAttestations(context.Background(), &ethpb.AttestationsPoolRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(resp.Data))
+	for i, a := range resp.Data {
+		assert.Equal(t, eth2types.Slot(i), a.Data.Slot)
+	}
+
+	resp, err = s.ListPoolAttestations(context.Background(), &ethpb.AttestationsPoolRequest{Slot: 2})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, eth2types.Slot(2), resp.Data[0].Data.Slot)
+
+	// The gateway passes the presence of the slot filter, so that slot 0 can be selected.
+	ctx, stream := pageContext("slot", "0", pageSizeParam, "1")
+	resp, err = s.ListPoolAttestations(ctx, &ethpb.AttestationsPoolRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, eth2types.Slot(0), resp.Data[0].Data.Slot)
+	assert.Equal(t, "1", grpcutils.ResponseFields(stream.header)[totalSizeField])
+}
+
+func TestListPoolPages_CapsPageSize(t *testing.T) {
+	resetCfg := cmd.InitWithReset(&cmd.Flags{MaxRPCPageSize: 2})
+	defer resetCfg()
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	exits := make([]*eth.SignedVoluntaryExit, 3)
+	for i := range exits {
+		exits[i] = &eth.SignedVoluntaryExit{Exit: &eth.VoluntaryExit{ValidatorIndex: eth2types.ValidatorIndex(i)}}
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
+
+	// A page size above the maximum is capped rather than rejected.
+	ctx, stream := pageContext(pageSizeParam, "3")
+	resp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, "1", grpcutils.ResponseFields(stream.header)[nextPageTokenField])
+}
+
+func TestListPoolPages_InvalidRequest(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: []*eth.SignedVoluntaryExit{{Exit: &eth.VoluntaryExit{}}}},
+	}
+
+	ctx, _ := pageContext(pageSizeParam, "-1")
+	_, err = s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	assert.ErrorContains(t, "can not be negative", err)
+
+	ctx, _ = pageContext(pageSizeParam, "abc")
+	_, err = s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	assert.ErrorContains(t, "Invalid page size", err)
+
+	ctx, _ = pageContext(pageTokenParam, "5")
+	_, err = s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	assert.ErrorContains(t, "Could not paginate pool", err)
+
+	ctx, _ = pageContext(pageTokenParam, "abc")
+	_, err = s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	assert.ErrorContains(t, "Could not paginate pool", err)
+
+	// An empty pool has a single, empty first page.
+	s.VoluntaryExitsPool = &voluntaryexits.PoolMock{}
+	ctx, stream := pageContext(pageTokenParam, "")
+	resp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(resp.Data))
+	fields := grpcutils.ResponseFields(stream.header)
+	assert.Equal(t, "", fields[nextPageTokenField])
+	assert.Equal(t, "0", fields[totalSizeField])
+}
@@ -2,25 +2,40 @@ package beaconv1
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
 	eth2types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
 	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	p2pMock "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/proto/migration"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestListPoolAttesterSlashings(t *testing.T) {
@@ -201,9 +216,94 @@ func TestListPoolVoluntaryExits(t *testing.T) {
 	assert.DeepEqual(t, migration.V1Alpha1ExitToV1(exit2), resp.Data[1])
 }
 
-func TestSubmitAttesterSlashing_Ok(t *testing.T) {
+func TestListPoolOperations_DeterministicOrder(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+
+	attSlashing := func(index uint64) *eth.AttesterSlashing {
+		return &eth.AttesterSlashing{
+			Attestation_1: &eth.IndexedAttestation{AttestingIndices: []uint64{index + 1, index}, Data: &eth.AttestationData{Slot: 1}},
+			Attestation_2: &eth.IndexedAttestation{AttestingIndices: []uint64{index}, Data: &eth.AttestationData{Slot: 1}},
+		}
+	}
+	propSlashing := func(index eth2types.ValidatorIndex) *eth.ProposerSlashing {
+		return &eth.ProposerSlashing{
+			Header_1: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: index}},
+			Header_2: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: index}},
+		}
+	}
+	exit := func(index eth2types.ValidatorIndex) *eth.SignedVoluntaryExit {
+		return &eth.SignedVoluntaryExit{Exit: &eth.VoluntaryExit{ValidatorIndex: index}}
+	}
+	attSlashings := []*eth.AttesterSlashing{attSlashing(3), attSlashing(1), attSlashing(2)}
+	propSlashings := []*eth.ProposerSlashing{propSlashing(3), propSlashing(1), propSlashing(2)}
+	exits := []*eth.SignedVoluntaryExit{exit(3), exit(1), exit(2)}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		SlashingsPool:      &slashings.PoolMock{PendingAttSlashings: attSlashings, PendingPropSlashings: propSlashings},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
 	ctx := context.Background()
 
+	attResp, err := s.ListPoolAttesterSlashings(ctx, &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(attResp.Data))
+	for i, slashing := range attResp.Data {
+		assert.Equal(t, uint64(i+1), slashing.Attestation_2.AttestingIndices[0])
+	}
+	attAgain, err := s.ListPoolAttesterSlashings(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, attResp, attAgain)
+
+	propResp, err := s.ListPoolProposerSlashings(ctx, &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(propResp.Data))
+	for i, slashing := range propResp.Data {
+		assert.Equal(t, eth2types.ValidatorIndex(i+1), slashing.Header_1.Header.ProposerIndex)
+	}
+	propAgain, err := s.ListPoolProposerSlashings(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, propResp, propAgain)
+
+	exitResp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(exitResp.Data))
+	for i, e := range exitResp.Data {
+		assert.Equal(t, eth2types.ValidatorIndex(i+1), e.Exit.ValidatorIndex)
+	}
+	exitAgain, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, exitResp, exitAgain)
+
+	// The pool contents themselves are left untouched.
+	assert.Equal(t, eth2types.ValidatorIndex(3), exits[0].Exit.ValidatorIndex)
+}
+
+func TestSortedProposerSlashings_RootTiebreak(t *testing.T) {
+	propSlashing := func(slot2 eth2types.Slot) *eth.ProposerSlashing {
+		header := func(slot eth2types.Slot) *eth.SignedBeaconBlockHeader {
+			return &eth.SignedBeaconBlockHeader{
+				Header: &eth.BeaconBlockHeader{
+					Slot:       slot,
+					ParentRoot: make([]byte, 32),
+					StateRoot:  make([]byte, 32),
+					BodyRoot:   make([]byte, 32),
+				},
+				Signature: make([]byte, 96),
+			}
+		}
+		return &eth.ProposerSlashing{Header_1: header(1), Header_2: header(slot2)}
+	}
+	a, b := propSlashing(2), propSlashing(3)
+	forward := sortedProposerSlashings([]*eth.ProposerSlashing{a, b})
+	backward := sortedProposerSlashings([]*eth.ProposerSlashing{b, a})
+	assert.DeepEqual(t, forward, backward)
+	roots := newTiebreakRoots(func(hashTreeRooter) error { return nil })
+	assert.NotEqual(t, roots.root(a), roots.root(b))
+}
+
+// signedAttesterSlashing returns a state with a single validator and a valid attester slashing of it.
+func signedAttesterSlashing(t *testing.T) (*stateTrie.BeaconState, *ethpb.AttesterSlashing) {
 	_, keys, err := testutil.DeterministicDepositsAndKeys(1)
 	require.NoError(t, err)
 	validator := &eth.Validator{
@@ -260,6 +360,12 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 		require.NoError(t, err)
 		att.Signature = sig.Marshal()
 	}
+	return state, slashing
+}
+
+func TestSubmitAttesterSlashing_Ok(t *testing.T) {
+	ctx := context.Background()
+	state, slashing := signedAttesterSlashing(t)
 
 	broadcaster := &p2pMock.MockBroadcaster{}
 	s := &Server{
@@ -268,7 +374,7 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 		Broadcaster:      broadcaster,
 	}
 
-	_, err = s.SubmitAttesterSlashing(ctx, slashing)
+	_, err := s.SubmitAttesterSlashing(ctx, slashing)
 	require.NoError(t, err)
 	pendingSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, state, true)
 	require.Equal(t, 1, len(pendingSlashings))
@@ -276,6 +382,31 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 	assert.Equal(t, true, broadcaster.BroadcastCalled)
 }
 
+func TestSubmitAttesterSlashing_ReplayNotRebroadcast(t *testing.T) {
+	ctx := context.Background()
+	state, slashing := signedAttesterSlashing(t)
+
+	replayCache, err := NewSlashingReplayCache(0, time.Minute)
+	require.NoError(t, err)
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher:    &chainMock.ChainService{State: state},
+		SlashingsPool:       &slashings.PoolMock{},
+		Broadcaster:         broadcaster,
+		SlashingReplayCache: replayCache,
+	}
+
+	_, err = s.SubmitAttesterSlashing(ctx, slashing)
+	require.NoError(t, err)
+	require.Equal(t, true, broadcaster.BroadcastCalled)
+
+	broadcaster.BroadcastCalled = false
+	_, err = s.SubmitAttesterSlashing(ctx, slashing)
+	require.NoError(t, err)
+	assert.Equal(t, false, broadcaster.BroadcastCalled, "Slashing was broadcast again within the replay TTL")
+	assert.Equal(t, 1, len(s.SlashingsPool.PendingAttesterSlashings(ctx, state, true)))
+}
+
 func TestSubmitAttesterSlashing_InvalidSlashing(t *testing.T) {
 	ctx := context.Background()
 	state, err := testutil.NewBeaconState()
@@ -313,12 +444,92 @@ func TestSubmitAttesterSlashing_InvalidSlashing(t *testing.T) {
 
 	_, err = s.SubmitAttesterSlashing(ctx, slashing)
 	require.ErrorContains(t, "Invalid attester slashing", err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, "attestations do not conflict", err)
 	assert.Equal(t, false, broadcaster.BroadcastCalled)
 }
 
-func TestSubmitProposerSlashing_Ok(t *testing.T) {
+func TestValidateAttesterSlashingConflict(t *testing.T) {
+	data := func(source, target eth2types.Epoch, root string) *eth.AttestationData {
+		return &eth.AttestationData{
+			BeaconBlockRoot: bytesutil.PadTo([]byte(root), 32),
+			Source:          &eth.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+			Target:          &eth.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+		}
+	}
+	tests := []struct {
+		name   string
+		data1  *eth.AttestationData
+		data2  *eth.AttestationData
+		errMsg string
+	}{
+		{
+			name:   "non-conflicting",
+			data1:  data(1, 2, "root1"),
+			data2:  data(2, 3, "root2"),
+			errMsg: "attestations do not conflict",
+		},
+		{
+			name:   "identical",
+			data1:  data(1, 2, "root1"),
+			data2:  data(1, 2, "root1"),
+			errMsg: "attestations do not conflict",
+		},
+		{
+			name:  "double vote",
+			data1: data(1, 2, "root1"),
+			data2: data(1, 2, "root2"),
+		},
+		{
+			name:  "surround vote",
+			data1: data(1, 5, "root1"),
+			data2: data(2, 4, "root2"),
+		},
+		{
+			name:   "surround vote in reverse order",
+			data1:  data(2, 4, "root1"),
+			data2:  data(1, 5, "root2"),
+			errMsg: "attestation_2 surrounds attestation_1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAttesterSlashingConflict(&eth.AttesterSlashing{
+				Attestation_1: &eth.IndexedAttestation{Data: tt.data1},
+				Attestation_2: &eth.IndexedAttestation{Data: tt.data2},
+			})
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestSubmitAttesterSlashing_NonConflicting(t *testing.T) {
 	ctx := context.Background()
+	state, slashing := signedAttesterSlashing(t)
+	slashing.Attestation_2.Data.Source.Epoch = 11
+	slashing.Attestation_2.Data.Target.Epoch = 12
 
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: state},
+		SlashingsPool:    &slashings.PoolMock{},
+		Broadcaster:      broadcaster,
+	}
+
+	_, err := s.SubmitAttesterSlashing(ctx, slashing)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, "attestations do not conflict", err)
+	assert.Equal(t, 0, len(s.SlashingsPool.PendingAttesterSlashings(ctx, state, true)))
+	assert.Equal(t, false, broadcaster.BroadcastCalled)
+}
+
+// signedProposerSlashing returns a state with a single validator and a valid proposer slashing of it.
+func signedProposerSlashing(t *testing.T) (*stateTrie.BeaconState, *ethpb.ProposerSlashing) {
 	_, keys, err := testutil.DeterministicDepositsAndKeys(1)
 	require.NoError(t, err)
 	validator := &eth.Validator{
@@ -368,6 +579,12 @@ func TestSubmitProposerSlashing_Ok(t *testing.T) {
 		require.NoError(t, err)
 		h.Signature = sig.Marshal()
 	}
+	return state, slashing
+}
+
+func TestSubmitProposerSlashing_Ok(t *testing.T) {
+	ctx := context.Background()
+	state, slashing := signedProposerSlashing(t)
 
 	broadcaster := &p2pMock.MockBroadcaster{}
 	s := &Server{
@@ -376,7 +593,7 @@ func TestSubmitProposerSlashing_Ok(t *testing.T) {
 		Broadcaster:      broadcaster,
 	}
 
-	_, err = s.SubmitProposerSlashing(ctx, slashing)
+	_, err := s.SubmitProposerSlashing(ctx, slashing)
 	require.NoError(t, err)
 	pendingSlashings := s.SlashingsPool.PendingProposerSlashings(ctx, state, true)
 	require.Equal(t, 1, len(pendingSlashings))
@@ -414,6 +631,27 @@ func TestSubmitProposerSlashing_InvalidSlashing(t *testing.T) {
 
 	_, err = s.SubmitProposerSlashing(ctx, slashing)
 	require.ErrorContains(t, "Invalid proposer slashing", err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, false, broadcaster.BroadcastCalled)
+}
+
+func TestSubmitProposerSlashing_UnknownProposerIndex(t *testing.T) {
+	ctx := context.Background()
+	state, slashing := signedProposerSlashing(t)
+	slashing.Header_1.Header.ProposerIndex = eth2types.ValidatorIndex(state.NumValidators())
+	slashing.Header_2.Header.ProposerIndex = slashing.Header_1.Header.ProposerIndex
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: state},
+		SlashingsPool:    &slashings.PoolMock{},
+		Broadcaster:      broadcaster,
+	}
+
+	_, err := s.SubmitProposerSlashing(ctx, slashing)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, "proposer index 1 has no public key in the head state", err)
+	assert.Equal(t, 0, len(s.SlashingsPool.PendingProposerSlashings(ctx, state, true)))
 	assert.Equal(t, false, broadcaster.BroadcastCalled)
 }
 
@@ -495,7 +733,56 @@ func TestSubmitVoluntaryExit_InvalidValidatorIndex(t *testing.T) {
 
 	_, err = s.SubmitVoluntaryExit(ctx, exit)
 	require.ErrorContains(t, "Could not get exiting validator", err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, false, broadcaster.BroadcastCalled)
+}
+
+func TestSubmitVoluntaryExit_ValidatorNotActiveLongEnough(t *testing.T) {
+	ctx := context.Background()
+
+	_, keys, err := testutil.DeterministicDepositsAndKeys(1)
+	require.NoError(t, err)
+	validator := &eth.Validator{
+		ActivationEpoch:       1,
+		ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+		PublicKey:             keys[0].PublicKey().Marshal(),
+		WithdrawalCredentials: make([]byte, 32),
+	}
+	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
+		state.Validators = []*eth.Validator{validator}
+		// One epoch short of the activity time required before exiting.
+		state.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().ShardCommitteePeriod))
+	})
+	require.NoError(t, err)
+
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit: &ethpb.VoluntaryExit{
+			Epoch:          0,
+			ValidatorIndex: 0,
+		},
+		Signature: make([]byte, 96),
+	}
+
+	sb, err := helpers.ComputeDomainAndSign(state, exit.Exit.Epoch, exit.Exit, params.BeaconConfig().DomainVoluntaryExit, keys[0])
+	require.NoError(t, err)
+	sig, err := bls.SignatureFromBytes(sb)
+	require.NoError(t, err)
+	exit.Signature = sig.Marshal()
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        broadcaster,
+	}
+
+	_, err = s.SubmitVoluntaryExit(ctx, exit)
+	require.ErrorContains(t, "has not been active long enough to exit", err)
+	st, ok := status.FromError(err)
+	require.Equal(t, true, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
 	assert.Equal(t, false, broadcaster.BroadcastCalled)
+	assert.Equal(t, 0, len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)))
 }
 
 func TestSubmitVoluntaryExit_InvalidExit(t *testing.T) {
@@ -510,6 +797,8 @@ func TestSubmitVoluntaryExit_InvalidExit(t *testing.T) {
 	}
 	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
 		state.Validators = []*eth.Validator{validator}
+		// Satisfy activity time required before exiting.
+		state.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().ShardCommitteePeriod))
 	})
 	require.NoError(t, err)
 
@@ -530,5 +819,929 @@ func TestSubmitVoluntaryExit_InvalidExit(t *testing.T) {
 
 	_, err = s.SubmitVoluntaryExit(ctx, exit)
 	require.ErrorContains(t, "Invalid voluntary exit", err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	assert.Equal(t, false, broadcaster.BroadcastCalled)
 }
+
+func TestSubmitAttestation_Ok(t *testing.T) {
+	ctx := context.Background()
+	state, keys := testutil.DeterministicGenesisState(t, 64)
+	atts, err := testutil.GenerateAttestations(state, keys, 1, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: state},
+		AttestationsPool: attestations.NewPool(),
+		Broadcaster:      broadcaster,
+	}
+
+	_, err = s.SubmitAttestation(ctx, migration.V1Alpha1AttestationToV1(atts[0]))
+	require.NoError(t, err)
+	pooled := s.AttestationsPool.AggregatedAttestations()
+	require.Equal(t, 1, len(pooled))
+	assert.DeepSSZEqual(t, atts[0], pooled[0])
+	assert.Equal(t, true, broadcaster.BroadcastCalled)
+}
+
+func TestSubmitAttestations_IndexedFailures(t *testing.T) {
+	ctx := context.Background()
+	state, keys := testutil.DeterministicGenesisState(t, 64)
+	atts, err := testutil.GenerateAttestations(state, keys, 1, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	valid := migration.V1Alpha1AttestationToV1(atts[0])
+	badSignature := migration.V1Alpha1AttestationToV1(atts[0])
+	badSignature.Signature = make([]byte, 96)
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: state},
+		AttestationsPool: attestations.NewPool(),
+		Broadcaster:      broadcaster,
+	}
+
+	stream := &headerStream{}
+	_, err = s.SubmitAttestations(grpc.NewContextWithServerTransportStream(ctx, stream), &SubmitAttestationsRequest{
+		Data: []*ethpb.Attestation{badSignature, {}, valid},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, "first failure at index 0", err)
+	failures := grpcutils.Failures(stream.trailer)
+	require.Equal(t, 2, len(failures))
+	assert.Equal(t, uint64(0), failures[0].Index)
+	assert.Equal(t, true, strings.Contains(failures[0].Message, "Invalid attestation"), failures[0].Message)
+	assert.Equal(t, uint64(1), failures[1].Index)
+	assert.Equal(t, true, strings.Contains(failures[1].Message, "Invalid attestation"), failures[1].Message)
+
+	// No attestation of the batch is pooled when one of them is invalid.
+	assert.Equal(t, 0, len(s.AttestationsPool.AggregatedAttestations()))
+	assert.Equal(t, false, broadcaster.BroadcastCalled)
+
+	_, err = s.SubmitAttestations(ctx, &SubmitAttestationsRequest{Data: []*ethpb.Attestation{valid}})
+	require.NoError(t, err)
+	pooled := s.AttestationsPool.AggregatedAttestations()
+	require.Equal(t, 1, len(pooled))
+	assert.DeepSSZEqual(t, atts[0], pooled[0])
+	assert.Equal(t, true, broadcaster.BroadcastCalled)
+}
+
+func TestSubmitAttestation_SubsetOfPooledAggregate(t *testing.T) {
+	ctx := context.Background()
+	state, keys := testutil.DeterministicGenesisState(t, 128)
+	atts, err := testutil.GenerateAttestations(state, keys, 1, 0, false)
+	require.NoError(t, err)
+	superset := atts[0]
+	require.Equal(t, true, superset.AggregationBits.Count() > 2)
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher: &chainMock.ChainService{State: state},
+		AttestationsPool: attestations.NewPool(),
+		Broadcaster:      broadcaster,
+	}
+	_, err = s.SubmitAttestation(ctx, migration.V1Alpha1AttestationToV1(superset))
+	require.NoError(t, err)
+	require.Equal(t, true, broadcaster.BroadcastCalled)
+
+	// Drop one of the participants so the new aggregate is a strict subset of the pooled one, signed
+	// by the remaining participants.
+	committee, err := helpers.BeaconCommitteeFromState(state, superset.Data.Slot, superset.Data.CommitteeIndex)
+	require.NoError(t, err)
+	subset := stateTrie.CopyAttestation(superset)
+	subset.AggregationBits = bitfield.NewBitlist(superset.AggregationBits.Len())
+	var sigs []bls.Signature
+	for _, i := range superset.AggregationBits.BitIndices()[1:] {
+		subset.AggregationBits.SetBitAt(uint64(i), true)
+		sb, err := helpers.ComputeDomainAndSign(state, subset.Data.Target.Epoch, subset.Data, params.BeaconConfig().DomainBeaconAttester, keys[committee[i]])
+		require.NoError(t, err)
+		sig, err := bls.SignatureFromBytes(sb)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+	}
+	subset.Signature = bls.AggregateSignatures(sigs).Marshal()
+	require.Equal(t, uint64(1), superset.AggregationBits.Count()-subset.AggregationBits.Count())
+
+	// A subset with an invalid signature is rejected rather than acknowledged.
+	badSignature := stateTrie.CopyAttestation(subset)
+	badSignature.Signature = superset.Signature
+	broadcaster.BroadcastCalled = false
+	_, err = s.SubmitAttestation(ctx, migration.V1Alpha1AttestationToV1(badSignature))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.SubmitAttestation(ctx, migration.V1Alpha1AttestationToV1(subset))
+	require.NoError(t, err)
+	assert.Equal(t, false, broadcaster.BroadcastCalled)
+	pooled := s.AttestationsPool.AggregatedAttestations()
+	require.Equal(t, 1, len(pooled))
+	assert.DeepSSZEqual(t, superset, pooled[0])
+}
+
+func TestCountPoolOperations(t *testing.T) {
+	ctx := context.Background()
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+
+	attSlashings := []*eth.AttesterSlashing{
+		{Attestation_1: &eth.IndexedAttestation{AttestingIndices: []uint64{1}}},
+		{Attestation_1: &eth.IndexedAttestation{AttestingIndices: []uint64{2}}},
+		{Attestation_1: &eth.IndexedAttestation{AttestingIndices: []uint64{3}}},
+	}
+	propSlashings := []*eth.ProposerSlashing{
+		{Header_1: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 1}}},
+		{Header_1: &eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 2}}},
+	}
+	exits := []*eth.SignedVoluntaryExit{
+		{Exit: &eth.VoluntaryExit{ValidatorIndex: 1}},
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		SlashingsPool:      &slashings.PoolMock{PendingAttSlashings: attSlashings, PendingPropSlashings: propSlashings},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
+
+	t.Run("Attester slashings", func(t *testing.T) {
+		count, err := s.CountPoolAttesterSlashings(ctx, &types.Empty{})
+		require.NoError(t, err)
+		list, err := s.ListPoolAttesterSlashings(ctx, &types.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(attSlashings)), count.Value)
+		assert.Equal(t, uint64(len(list.Data)), count.Value)
+	})
+	t.Run("Proposer slashings", func(t *testing.T) {
+		count, err := s.CountPoolProposerSlashings(ctx, &types.Empty{})
+		require.NoError(t, err)
+		list, err := s.ListPoolProposerSlashings(ctx, &types.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(propSlashings)), count.Value)
+		assert.Equal(t, uint64(len(list.Data)), count.Value)
+	})
+	t.Run("Voluntary exits", func(t *testing.T) {
+		count, err := s.CountPoolVoluntaryExits(ctx, &types.Empty{})
+		require.NoError(t, err)
+		list, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(exits)), count.Value)
+		assert.Equal(t, uint64(len(list.Data)), count.Value)
+	})
+
+	// Listing a pool converts every object and checks the context while doing so, whereas counting
+	// it must not convert anything, so only counting succeeds with a cancelled context.
+	t.Run("No conversion", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		count, err := s.CountPoolAttesterSlashings(cancelledCtx, &types.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(attSlashings)), count.Value)
+		_, err = s.ListPoolAttesterSlashings(cancelledCtx, &types.Empty{})
+		assert.ErrorContains(t, "Request cancelled", err)
+
+		count, err = s.CountPoolProposerSlashings(cancelledCtx, &types.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(propSlashings)), count.Value)
+		_, err = s.ListPoolProposerSlashings(cancelledCtx, &types.Empty{})
+		assert.ErrorContains(t, "Request cancelled", err)
+
+		count, err = s.CountPoolVoluntaryExits(cancelledCtx, &types.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(exits)), count.Value)
+		_, err = s.ListPoolVoluntaryExits(cancelledCtx, &types.Empty{})
+		assert.ErrorContains(t, "Request cancelled", err)
+	})
+}
+
+func TestSubmitPoolOperations_MalformedRequest(t *testing.T) {
+	ctx := context.Background()
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+
+	validAttestation := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{0},
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  1,
+			BeaconBlockRoot: bytesutil.PadTo([]byte("blockroot1"), 32),
+			Source: &ethpb.Checkpoint{
+				Epoch: 1,
+				Root:  bytesutil.PadTo([]byte("sourceroot1"), 32),
+			},
+			Target: &ethpb.Checkpoint{
+				Epoch: 10,
+				Root:  bytesutil.PadTo([]byte("targetroot1"), 32),
+			},
+		},
+		Signature: make([]byte, 96),
+	}
+	validHeader := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          1,
+			ProposerIndex: 0,
+			ParentRoot:    bytesutil.PadTo([]byte("parentroot1"), 32),
+			StateRoot:     bytesutil.PadTo([]byte("stateroot1"), 32),
+			BodyRoot:      bytesutil.PadTo([]byte("bodyroot1"), 32),
+		},
+		Signature: make([]byte, 96),
+	}
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		SlashingsPool:      &slashings.PoolMock{},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        broadcaster,
+	}
+
+	tests := []struct {
+		name    string
+		submit  func() error
+		wantErr string
+	}{
+		{
+			name: "attester slashing missing attestation",
+			submit: func() error {
+				_, err := s.SubmitAttesterSlashing(ctx, &ethpb.AttesterSlashing{Attestation_1: validAttestation})
+				return err
+			},
+			wantErr: "attestation_2 is missing",
+		},
+		{
+			name: "attester slashing missing target",
+			submit: func() error {
+				att := &ethpb.IndexedAttestation{
+					AttestingIndices: []uint64{0},
+					Data: &ethpb.AttestationData{
+						Slot:   1,
+						Source: &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+					},
+					Signature: make([]byte, 96),
+				}
+				_, err := s.SubmitAttesterSlashing(ctx, &ethpb.AttesterSlashing{Attestation_1: att, Attestation_2: validAttestation})
+				return err
+			},
+			wantErr: "attestation_1 is missing attestation data, source or target",
+		},
+		{
+			name: "proposer slashing missing header",
+			submit: func() error {
+				_, err := s.SubmitProposerSlashing(ctx, &ethpb.ProposerSlashing{Header_1: validHeader, Header_2: &ethpb.SignedBeaconBlockHeader{}})
+				return err
+			},
+			wantErr: "header_2 is missing",
+		},
+		{
+			name: "voluntary exit missing exit message",
+			submit: func() error {
+				_, err := s.SubmitVoluntaryExit(ctx, &ethpb.SignedVoluntaryExit{Signature: make([]byte, 96)})
+				return err
+			},
+			wantErr: "exit message is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.submit()
+			require.ErrorContains(t, "Malformed request object", err)
+			assert.ErrorContains(t, tt.wantErr, err)
+			st, ok := status.FromError(err)
+			require.Equal(t, true, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Equal(t, false, broadcaster.BroadcastCalled)
+		})
+	}
+}
+
+// The head state fork is enough to verify exits on both sides of a fork boundary, as its domain
+// uses the previous version for exits of epochs before the fork epoch.
+func TestSubmitVoluntaryExit_AcrossForkBoundary(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	genesisVersion := []byte{0, 0, 0, 0}
+	previousVersion := []byte{1, 0, 0, 0}
+	currentVersion := []byte{2, 0, 0, 0}
+	forkEpoch := params.BeaconConfig().ShardCommitteePeriod + 10
+	cfg := params.BeaconConfig().Copy()
+	cfg.GenesisForkVersion = genesisVersion
+	cfg.ForkVersionSchedule = map[eth2types.Epoch][]byte{
+		0:         genesisVersion,
+		100:       previousVersion,
+		forkEpoch: currentVersion,
+	}
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	_, keys, err := testutil.DeterministicDepositsAndKeys(1)
+	require.NoError(t, err)
+	validator := &eth.Validator{
+		ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+		PublicKey:             keys[0].PublicKey().Marshal(),
+		WithdrawalCredentials: make([]byte, 32),
+	}
+	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
+		state.Validators = []*eth.Validator{validator}
+		state.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(uint64(forkEpoch))
+		state.Fork = &pb.Fork{
+			PreviousVersion: previousVersion,
+			CurrentVersion:  currentVersion,
+			Epoch:           forkEpoch,
+		}
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		epoch   eth2types.Epoch
+		version []byte
+	}{
+		{
+			name:    "previous fork just before boundary",
+			epoch:   forkEpoch - 1,
+			version: previousVersion,
+		},
+		{
+			name:    "current fork at boundary",
+			epoch:   forkEpoch,
+			version: currentVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exit := &ethpb.SignedVoluntaryExit{
+				Exit: &ethpb.VoluntaryExit{
+					Epoch:          tt.epoch,
+					ValidatorIndex: 0,
+				},
+			}
+			domain, err := helpers.ComputeDomain(params.BeaconConfig().DomainVoluntaryExit, tt.version, state.GenesisValidatorRoot())
+			require.NoError(t, err)
+			signingRoot, err := helpers.ComputeSigningRoot(exit.Exit, domain)
+			require.NoError(t, err)
+			exit.Signature = keys[0].Sign(signingRoot[:]).Marshal()
+
+			broadcaster := &p2pMock.MockBroadcaster{}
+			s := &Server{
+				ChainInfoFetcher:   &chainMock.ChainService{State: state},
+				VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+				Broadcaster:        broadcaster,
+			}
+			_, err = s.SubmitVoluntaryExit(ctx, exit)
+			require.NoError(t, err)
+			assert.Equal(t, true, broadcaster.BroadcastCalled)
+		})
+	}
+}
+
+func TestListPoolVoluntaryExits_IncludeExpired(t *testing.T) {
+	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
+		state.Slot = params.BeaconConfig().SlotsPerEpoch * 5
+	})
+	require.NoError(t, err)
+	exits := []*eth.SignedVoluntaryExit{
+		{Exit: &eth.VoluntaryExit{Epoch: 3, ValidatorIndex: 0}},
+		{Exit: &eth.VoluntaryExit{Epoch: 5, ValidatorIndex: 1}},
+		{Exit: &eth.VoluntaryExit{Epoch: 4, ValidatorIndex: 2}},
+		{Exit: &eth.VoluntaryExit{Epoch: 6, ValidatorIndex: 3}},
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
+	indices := func(resp *ethpb.VoluntaryExitsPoolResponse) []eth2types.ValidatorIndex {
+		res := make([]eth2types.ValidatorIndex, len(resp.Data))
+		for i, e := range resp.Data {
+			res[i] = e.Exit.ValidatorIndex
+		}
+		return res
+	}
+
+	t.Run("default hides expired", func(t *testing.T) {
+		resp, err := s.ListPoolVoluntaryExits(context.Background(), &types.Empty{})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []eth2types.ValidatorIndex{1, 3}, indices(resp))
+	})
+	t.Run("include_expired false", func(t *testing.T) {
+		ctx, _ := pageContext(includeExpiredParam, "false")
+		resp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []eth2types.ValidatorIndex{1, 3}, indices(resp))
+	})
+	t.Run("include_expired true", func(t *testing.T) {
+		ctx, _ := pageContext(includeExpiredParam, "true")
+		resp, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []eth2types.ValidatorIndex{0, 1, 2, 3}, indices(resp))
+	})
+	t.Run("invalid include_expired", func(t *testing.T) {
+		ctx, _ := pageContext(includeExpiredParam, "maybe")
+		_, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+		assert.ErrorContains(t, "Invalid include_expired value", err)
+	})
+}
+
+func TestListExitingValidators(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	exits := []*eth.SignedVoluntaryExit{
+		{Exit: &eth.VoluntaryExit{Epoch: 1, ValidatorIndex: 7}},
+		{Exit: &eth.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}},
+		{Exit: &eth.VoluntaryExit{Epoch: 2, ValidatorIndex: 7}},
+		{Exit: &eth.VoluntaryExit{Epoch: 1, ValidatorIndex: 5}},
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
+
+	resp, err := s.ListExitingValidators(context.Background(), &types.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []eth2types.ValidatorIndex{2, 5, 7}, resp.Data)
+}
+
+func TestListExitingValidators_EmptyPool(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+	}
+
+	resp, err := s.ListExitingValidators(context.Background(), &types.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(resp.Data))
+}
+
+func TestGetVoluntaryExitsOrdered(t *testing.T) {
+	state, _ := testutil.DeterministicGenesisState(t, 4)
+	pool := voluntaryexits.NewPoolWithOrdering(voluntaryexits.EarliestSubmittedFirst)
+	for _, idx := range []eth2types.ValidatorIndex{2, 0, 3} {
+		pool.InsertVoluntaryExit(context.Background(), state, &eth.SignedVoluntaryExit{
+			Exit:      &eth.VoluntaryExit{ValidatorIndex: idx},
+			Signature: make([]byte, 96),
+		})
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: pool,
+	}
+
+	tests := []struct {
+		name     string
+		req      *VoluntaryExitsOrderedRequest
+		expected []eth2types.ValidatorIndex
+	}{
+		{
+			name:     "pool default",
+			req:      &VoluntaryExitsOrderedRequest{},
+			expected: []eth2types.ValidatorIndex{2, 0, 3},
+		},
+		{
+			name:     "nil request",
+			req:      nil,
+			expected: []eth2types.ValidatorIndex{2, 0, 3},
+		},
+		{
+			name:     "lowest index first",
+			req:      &VoluntaryExitsOrderedRequest{Ordering: voluntaryexits.LowestIndexFirst},
+			expected: []eth2types.ValidatorIndex{0, 2, 3},
+		},
+		{
+			name:     "earliest submitted first",
+			req:      &VoluntaryExitsOrderedRequest{Ordering: voluntaryexits.EarliestSubmittedFirst},
+			expected: []eth2types.ValidatorIndex{2, 0, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetVoluntaryExitsOrdered(context.Background(), tt.req)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.expected), len(resp.Data))
+			for i, e := range resp.Data {
+				assert.Equal(t, tt.expected[i], e.Exit.ValidatorIndex)
+			}
+		})
+	}
+
+	t.Run("unknown ordering", func(t *testing.T) {
+		_, err := s.GetVoluntaryExitsOrdered(context.Background(), &VoluntaryExitsOrderedRequest{Ordering: 42})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Unknown exit ordering", err)
+	})
+}
+
+func TestListPoolOperations_ContextCancelled(t *testing.T) {
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+
+	const poolSize = 10000
+	attSlashings := make([]*eth.AttesterSlashing, poolSize)
+	propSlashings := make([]*eth.ProposerSlashing, poolSize)
+	exits := make([]*eth.SignedVoluntaryExit, poolSize)
+	for i := 0; i < poolSize; i++ {
+		attSlashings[i] = &eth.AttesterSlashing{}
+		propSlashings[i] = &eth.ProposerSlashing{}
+		exits[i] = &eth.SignedVoluntaryExit{Exit: &eth.VoluntaryExit{ValidatorIndex: eth2types.ValidatorIndex(i)}}
+	}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		SlashingsPool:      &slashings.PoolMock{PendingAttSlashings: attSlashings, PendingPropSlashings: propSlashings},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: exits},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		list func() error
+	}{
+		{
+			name: "attester slashings",
+			list: func() error {
+				_, err := s.ListPoolAttesterSlashings(ctx, &types.Empty{})
+				return err
+			},
+		},
+		{
+			name: "proposer slashings",
+			list: func() error {
+				_, err := s.ListPoolProposerSlashings(ctx, &types.Empty{})
+				return err
+			},
+		},
+		{
+			name: "voluntary exits",
+			list: func() error {
+				_, err := s.ListPoolVoluntaryExits(ctx, &types.Empty{})
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list()
+			require.ErrorContains(t, "Request cancelled while converting "+tt.name, err)
+			st, ok := status.FromError(err)
+			require.Equal(t, true, ok)
+			assert.Equal(t, codes.Canceled, st.Code())
+		})
+	}
+}
+
+func TestSubmitBatch_SizeLimits(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &p2pMock.MockBroadcaster{}
+	// No chain info fetcher is set: any per-item verification would need the head state and panic.
+	s := &Server{
+		AttestationsPool:   attestations.NewPool(),
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        broadcaster,
+		MaxBatchSize:       4,
+	}
+
+	atts := make([]*ethpb.Attestation, 5)
+	exits := make([]*ethpb.SignedVoluntaryExit, 5)
+	for i := range atts {
+		atts[i] = &ethpb.Attestation{}
+		exits[i] = &ethpb.SignedVoluntaryExit{}
+	}
+
+	tests := []struct {
+		name    string
+		submit  func() error
+		wantErr string
+	}{
+		{
+			name: "oversize attestation batch",
+			submit: func() error {
+				_, err := s.SubmitAttestations(ctx, &SubmitAttestationsRequest{Data: atts})
+				return err
+			},
+			wantErr: "Batch of 5 items exceeds the maximum batch size of 4",
+		},
+		{
+			name: "oversize exit batch",
+			submit: func() error {
+				_, err := s.SubmitVoluntaryExits(ctx, &SubmitVoluntaryExitsRequest{Data: exits})
+				return err
+			},
+			wantErr: "Batch of 5 items exceeds the maximum batch size of 4",
+		},
+		{
+			name: "empty attestation batch",
+			submit: func() error {
+				_, err := s.SubmitAttestations(ctx, &SubmitAttestationsRequest{})
+				return err
+			},
+			wantErr: "No items provided in batch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.submit()
+			require.ErrorContains(t, tt.wantErr, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, false, broadcaster.BroadcastCalled)
+		})
+	}
+}
+
+func TestSubmitBatch_DefaultMaxSize(t *testing.T) {
+	s := &Server{}
+	require.NoError(t, s.validateBatchSize(defaultMaxBatchSize))
+	assert.ErrorContains(t, "exceeds the maximum batch size", s.validateBatchSize(defaultMaxBatchSize+1))
+}
+
+// signedVoluntaryExits returns a state with n validators eligible to exit and a signed exit for each of them.
+func signedVoluntaryExits(t testing.TB, n uint64) (*stateTrie.BeaconState, []*ethpb.SignedVoluntaryExit) {
+	_, keys, err := testutil.DeterministicDepositsAndKeys(n)
+	require.NoError(t, err)
+	validators := make([]*eth.Validator, len(keys))
+	for i, key := range keys {
+		validators[i] = &eth.Validator{
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+			PublicKey:             key.PublicKey().Marshal(),
+			WithdrawalCredentials: make([]byte, 32),
+		}
+	}
+	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
+		state.Validators = validators
+		// Satisfy activity time required before exiting.
+		state.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().ShardCommitteePeriod))
+	})
+	require.NoError(t, err)
+
+	exits := make([]*ethpb.SignedVoluntaryExit, len(keys))
+	for i, key := range keys {
+		exits[i] = &ethpb.SignedVoluntaryExit{
+			Exit: &ethpb.VoluntaryExit{
+				Epoch:          0,
+				ValidatorIndex: eth2types.ValidatorIndex(i),
+			},
+		}
+		sb, err := helpers.ComputeDomainAndSign(state, exits[i].Exit.Epoch, exits[i].Exit, params.BeaconConfig().DomainVoluntaryExit, key)
+		require.NoError(t, err)
+		sig, err := bls.SignatureFromBytes(sb)
+		require.NoError(t, err)
+		exits[i].Signature = sig.Marshal()
+	}
+	return state, exits
+}
+
+func TestSubmitVoluntaryExits_Ok(t *testing.T) {
+	ctx := context.Background()
+
+	state, exits := signedVoluntaryExits(t, 2)
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+		Broadcaster:        broadcaster,
+	}
+
+	_, err := s.SubmitVoluntaryExits(ctx, &SubmitVoluntaryExitsRequest{Data: exits})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)))
+	assert.Equal(t, true, broadcaster.BroadcastCalled)
+}
+
+func TestVerifyBatch_CollatesByIndex(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{BulkVerificationWorkers: 4})
+	defer resetCfg()
+
+	const size = 16
+	var inFlight, maxInFlight int32
+	errs := verifyBatch(context.Background(), size, func(i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		// Later items complete first.
+		time.Sleep(time.Duration(size-i) * time.Millisecond)
+		if i%3 == 0 {
+			return fmt.Errorf("item %d", i)
+		}
+		return nil
+	})
+	require.Equal(t, size, len(errs))
+	for i, err := range errs {
+		if i%3 == 0 {
+			assert.ErrorContains(t, fmt.Sprintf("item %d", i), err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+	assert.Equal(t, true, maxInFlight <= 4, "More verifications in flight than workers")
+}
+
+func TestSubmitVoluntaryExits_Parallel(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{BulkVerificationWorkers: 4})
+	defer resetCfg()
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 8)
+	signature := exits[5].Signature
+	exits[5].Signature = exits[4].Signature
+
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: voluntaryexits.NewPool(),
+		Broadcaster:        &p2pMock.MockBroadcaster{},
+	}
+	stream := &headerStream{}
+	_, err := s.SubmitVoluntaryExits(grpc.NewContextWithServerTransportStream(ctx, stream), &SubmitVoluntaryExitsRequest{Data: exits})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, "first failure at index 5", err)
+	failures := grpcutils.Failures(stream.trailer)
+	require.Equal(t, 1, len(failures))
+	assert.Equal(t, uint64(5), failures[0].Index)
+	// No exit of the batch is pooled when one of them is invalid.
+	assert.Equal(t, 0, len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)))
+
+	exits[5].Signature = signature
+	_, err = s.SubmitVoluntaryExits(ctx, &SubmitVoluntaryExitsRequest{Data: exits})
+	require.NoError(t, err)
+	pending := s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)
+	require.Equal(t, 8, len(pending))
+	for i, exit := range pending {
+		assert.Equal(t, eth2types.ValidatorIndex(i), exit.Exit.ValidatorIndex)
+	}
+}
+
+func BenchmarkSubmitVoluntaryExits(b *testing.B) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(b, 64)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("%d workers", workers), func(b *testing.B) {
+			resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{BulkVerificationWorkers: workers})
+			defer resetCfg()
+			for i := 0; i < b.N; i++ {
+				s := &Server{
+					ChainInfoFetcher:   &chainMock.ChainService{State: state},
+					VoluntaryExitsPool: voluntaryexits.NewPool(),
+					Broadcaster:        &p2pMock.MockBroadcaster{},
+				}
+				_, err := s.SubmitVoluntaryExits(ctx, &SubmitVoluntaryExitsRequest{Data: exits})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestSubmitVoluntaryExits_PastChurnLimit(t *testing.T) {
+	ctx := context.Background()
+	// The churn limit of a small validator set is MinPerEpochChurnLimit.
+	churnLimit := params.BeaconConfig().MinPerEpochChurnLimit
+	state, exits := signedVoluntaryExits(t, churnLimit+2)
+
+	t.Run("verified against pool", func(t *testing.T) {
+		s := &Server{
+			ChainInfoFetcher:       &chainMock.ChainService{State: state},
+			VoluntaryExitsPool:     voluntaryexits.NewPool(),
+			Broadcaster:            &p2pMock.MockBroadcaster{},
+			VerifyExitsAgainstPool: true,
+		}
+		stream := &headerStream{}
+		_, err := s.SubmitVoluntaryExits(grpc.NewContextWithServerTransportStream(ctx, stream), &SubmitVoluntaryExitsRequest{Data: exits})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, fmt.Sprintf("first failure at index %d", churnLimit), err)
+		assert.ErrorContains(t, "validator churn limit", err)
+		failures := grpcutils.Failures(stream.trailer)
+		require.Equal(t, 2, len(failures))
+		assert.Equal(t, churnLimit, failures[0].Index)
+		assert.Equal(t, churnLimit+1, failures[1].Index)
+		assert.Equal(t, churnLimit, uint64(len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true))))
+
+		// Resubmitting an already pooled exit does not count against the churn limit.
+		_, err = s.SubmitVoluntaryExit(ctx, exits[0])
+		require.NoError(t, err)
+	})
+	t.Run("verified against head state only", func(t *testing.T) {
+		s := &Server{
+			ChainInfoFetcher:   &chainMock.ChainService{State: state},
+			VoluntaryExitsPool: voluntaryexits.NewPool(),
+			Broadcaster:        &p2pMock.MockBroadcaster{},
+		}
+		_, err := s.SubmitVoluntaryExits(ctx, &SubmitVoluntaryExitsRequest{Data: exits})
+		require.NoError(t, err)
+		assert.Equal(t, len(exits), len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)))
+	})
+}
+
+func TestRevalidatePools(t *testing.T) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 2)
+	_, attSlashing := signedAttesterSlashing(t)
+	unsignedPropSlashing := &eth.ProposerSlashing{
+		Header_1: &eth.SignedBeaconBlockHeader{
+			Header:    &eth.BeaconBlockHeader{Slot: 1, ProposerIndex: 1, BodyRoot: bytesutil.PadTo([]byte("body1"), 32)},
+			Signature: make([]byte, 96),
+		},
+		Header_2: &eth.SignedBeaconBlockHeader{
+			Header:    &eth.BeaconBlockHeader{Slot: 1, ProposerIndex: 1, BodyRoot: bytesutil.PadTo([]byte("body2"), 32)},
+			Signature: make([]byte, 96),
+		},
+	}
+
+	chainService := &chainMock.ChainService{State: state}
+	s := &Server{
+		ChainInfoFetcher: chainService,
+		SlashingsPool: &slashings.PoolMock{
+			PendingAttSlashings:  []*eth.AttesterSlashing{migration.V1AttSlashingToV1Alpha1(attSlashing)},
+			PendingPropSlashings: []*eth.ProposerSlashing{unsignedPropSlashing},
+		},
+		VoluntaryExitsPool: &voluntaryexits.PoolMock{Exits: []*eth.SignedVoluntaryExit{
+			migration.V1ExitToV1Alpha1(exits[0]),
+			migration.V1ExitToV1Alpha1(exits[1]),
+		}},
+	}
+
+	resp, err := s.RevalidatePools(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &RevalidatePoolsResponse{
+		AttesterSlashingsKept:    1,
+		ProposerSlashingsDropped: 1,
+		VoluntaryExitsKept:       2,
+	}, resp)
+
+	// The new head has validator 0 slashed and exiting, invalidating its slashing and its exit.
+	newHead := state.Copy()
+	validator, err := newHead.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	validator.Slashed = true
+	validator.ExitEpoch = helpers.CurrentEpoch(newHead) + 1
+	require.NoError(t, newHead.UpdateValidatorAtIndex(0, validator))
+	chainService.State = newHead
+
+	resp, err = s.RevalidatePools(ctx, &types.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &RevalidatePoolsResponse{
+		AttesterSlashingsDropped: 1,
+		VoluntaryExitsKept:       1,
+		VoluntaryExitsDropped:    1,
+	}, resp)
+	assert.Equal(t, 0, len(s.SlashingsPool.PendingAttesterSlashings(ctx, newHead, true)))
+	assert.Equal(t, 0, len(s.SlashingsPool.PendingProposerSlashings(ctx, newHead, true)))
+	pendingExits := s.VoluntaryExitsPool.PendingExits(newHead, newHead.Slot(), true)
+	require.Equal(t, 1, len(pendingExits))
+	assert.Equal(t, eth2types.ValidatorIndex(1), pendingExits[0].Exit.ValidatorIndex)
+}
+
+// erroringBroadcaster fails every Broadcast call with the given error.
+type erroringBroadcaster struct {
+	p2pMock.MockBroadcaster
+	err error
+}
+
+func (b *erroringBroadcaster) Broadcast(_ context.Context, _ proto.Message) error {
+	return b.err
+}
+
+func TestSubmitPoolOperations_TopicNotSubscribed(t *testing.T) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 1)
+	_, attSlashing := signedAttesterSlashing(t)
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{
+			name: "not subscribed",
+			err:  errors.Wrap(p2p.ErrTopicNotSubscribed, "could not publish message"),
+			code: codes.FailedPrecondition,
+		},
+		{
+			name: "other broadcast failure",
+			err:  errors.New("could not encode message"),
+			code: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{
+				ChainInfoFetcher:   &chainMock.ChainService{State: state},
+				SlashingsPool:      &slashings.PoolMock{},
+				VoluntaryExitsPool: &voluntaryexits.PoolMock{},
+				Broadcaster:        &erroringBroadcaster{err: tt.err},
+			}
+
+			_, err := s.SubmitVoluntaryExit(ctx, exits[0])
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, 1, len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)), "Exit was not pooled")
+
+			_, err = s.SubmitAttesterSlashing(ctx, attSlashing)
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, 1, len(s.SlashingsPool.PendingAttesterSlashings(ctx, state, true)), "Slashing was not pooled")
+
+			if tt.code == codes.FailedPrecondition {
+				assert.ErrorContains(t, "Node not yet subscribed to topic; item pooled and will broadcast shortly", err)
+			}
+		})
+	}
+}
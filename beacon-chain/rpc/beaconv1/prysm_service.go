@@ -0,0 +1,157 @@
+package beaconv1
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"google.golang.org/grpc"
+)
+
+// PrysmBeaconChainServiceName is the gRPC service of the Prysm specific methods of the v1 beacon
+// chain server, which are not part of the ethereumapis v1 protos. Their messages are plain Go
+// structs, so clients call them with the grpcutils.JSONCodecName content subtype.
+const PrysmBeaconChainServiceName = "prysm.eth.v1.BeaconChain"
+
+// prysmBeaconChainServer is the interface of the Prysm specific methods of the server.
+type prysmBeaconChainServer interface {
+	CountPoolAttesterSlashings(context.Context, *ptypes.Empty) (*ptypes.UInt64Value, error)
+	CountPoolProposerSlashings(context.Context, *ptypes.Empty) (*ptypes.UInt64Value, error)
+	CountPoolVoluntaryExits(context.Context, *ptypes.Empty) (*ptypes.UInt64Value, error)
+	GetAttestationInclusion(context.Context, *AttestationInclusionRequest) (*AttestationInclusionResponse, error)
+	GetAttestationRewards(context.Context, *AttestationRewardsRequest) (*AttestationRewardsResponse, error)
+	GetBlockRewards(context.Context, *ethpb.BlockRequest) (*BlockRewardsResponse, error)
+	GetLastBroadcastTimes(context.Context, *ptypes.Empty) (*LastBroadcastTimesResponse, error)
+	GetLiveness(context.Context, *LivenessRequest) (*LivenessResponse, error)
+	GetVoluntaryExitsOrdered(context.Context, *VoluntaryExitsOrderedRequest) (*ethpb.VoluntaryExitsPoolResponse, error)
+	ListExitingValidators(context.Context, *ptypes.Empty) (*ExitingValidatorsResponse, error)
+	SubmitAttestations(context.Context, *SubmitAttestationsRequest) (*ptypes.Empty, error)
+	SubmitVoluntaryExits(context.Context, *SubmitVoluntaryExitsRequest) (*ptypes.Empty, error)
+}
+
+var prysmBeaconChainServiceDesc = grpc.ServiceDesc{
+	ServiceName: PrysmBeaconChainServiceName,
+	HandlerType: (*prysmBeaconChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryMethod(
+			"CountPoolAttesterSlashings",
+			func() interface{} { return &ptypes.Empty{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.CountPoolAttesterSlashings(ctx, req.(*ptypes.Empty))
+			},
+		),
+		unaryMethod(
+			"CountPoolProposerSlashings",
+			func() interface{} { return &ptypes.Empty{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.CountPoolProposerSlashings(ctx, req.(*ptypes.Empty))
+			},
+		),
+		unaryMethod(
+			"CountPoolVoluntaryExits",
+			func() interface{} { return &ptypes.Empty{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.CountPoolVoluntaryExits(ctx, req.(*ptypes.Empty))
+			},
+		),
+		unaryMethod(
+			"GetAttestationInclusion",
+			func() interface{} { return &AttestationInclusionRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetAttestationInclusion(ctx, req.(*AttestationInclusionRequest))
+			},
+		),
+		unaryMethod(
+			"GetAttestationRewards",
+			func() interface{} { return &AttestationRewardsRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetAttestationRewards(ctx, req.(*AttestationRewardsRequest))
+			},
+		),
+		unaryMethod(
+			"GetBlockRewards",
+			func() interface{} { return &ethpb.BlockRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetBlockRewards(ctx, req.(*ethpb.BlockRequest))
+			},
+		),
+		unaryMethod(
+			"GetLastBroadcastTimes",
+			func() interface{} { return &ptypes.Empty{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetLastBroadcastTimes(ctx, req.(*ptypes.Empty))
+			},
+		),
+		unaryMethod(
+			"GetLiveness",
+			func() interface{} { return &LivenessRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetLiveness(ctx, req.(*LivenessRequest))
+			},
+		),
+		unaryMethod(
+			"GetVoluntaryExitsOrdered",
+			func() interface{} { return &VoluntaryExitsOrderedRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetVoluntaryExitsOrdered(ctx, req.(*VoluntaryExitsOrderedRequest))
+			},
+		),
+		unaryMethod(
+			"ListExitingValidators",
+			func() interface{} { return &ptypes.Empty{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.ListExitingValidators(ctx, req.(*ptypes.Empty))
+			},
+		),
+		unaryMethod(
+			"SubmitAttestations",
+			func() interface{} { return &SubmitAttestationsRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.SubmitAttestations(ctx, req.(*SubmitAttestationsRequest))
+			},
+		),
+		unaryMethod(
+			"SubmitVoluntaryExits",
+			func() interface{} { return &SubmitVoluntaryExitsRequest{} },
+			func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error) {
+				return s.SubmitVoluntaryExits(ctx, req.(*SubmitVoluntaryExitsRequest))
+			},
+		),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterPrysmBeaconChainServer registers the Prysm specific methods of the v1 beacon chain
+// server on the gRPC server.
+func RegisterPrysmBeaconChainServer(s *grpc.Server, srv *Server) {
+	s.RegisterService(&prysmBeaconChainServiceDesc, srv)
+}
+
+// unaryMethod describes a unary method of the Prysm beacon chain service, which decodes its request
+// into the message returned by newRequest and passes it to call through the server interceptors.
+func unaryMethod(
+	name string,
+	newRequest func() interface{},
+	call func(s prysmBeaconChainServer, ctx context.Context, req interface{}) (interface{}, error),
+) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := newRequest()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(srv.(prysmBeaconChainServer), ctx, req)
+			}
+			if interceptor == nil {
+				return handler(ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + PrysmBeaconChainServiceName + "/" + name,
+			}
+			return interceptor(ctx, req, info, handler)
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package beaconv1
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
+	p2pMock "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterPrysmBeaconChainServer(t *testing.T) {
+	ctx := context.Background()
+	state, exits := signedVoluntaryExits(t, 2)
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		VoluntaryExitsPool: voluntaryexits.NewPool(),
+		Broadcaster:        &p2pMock.MockBroadcaster{},
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterPrysmBeaconChainServer(server, s)
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer server.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	method := "/" + PrysmBeaconChainServiceName + "/SubmitVoluntaryExits"
+	var trailer metadata.MD
+	err = conn.Invoke(ctx, method, &SubmitVoluntaryExitsRequest{Data: exits[:1]}, &ptypes.Empty{},
+		grpc.CallContentSubtype(grpcutils.JSONCodecName))
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(s.VoluntaryExitsPool.PendingExits(state, state.Slot(), true)))
+
+	exits[1].Signature = exits[0].Signature
+	err = conn.Invoke(ctx, method, &SubmitVoluntaryExitsRequest{Data: exits}, &ptypes.Empty{},
+		grpc.CallContentSubtype(grpcutils.JSONCodecName), grpc.Trailer(&trailer))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	failures := grpcutils.Failures(trailer)
+	require.Equal(t, 1, len(failures))
+	assert.Equal(t, uint64(1), failures[0].Index)
+}
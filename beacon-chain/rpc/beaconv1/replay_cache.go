@@ -0,0 +1,65 @@
+package beaconv1
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// defaultSlashingReplayCacheSize is the number of broadcast slashings remembered when the server has none configured.
+	defaultSlashingReplayCacheSize = 1024
+	// defaultSlashingReplayCacheTTL is how long a broadcast slashing is remembered when the server has none configured.
+	defaultSlashingReplayCacheTTL = 10 * time.Minute
+)
+
+// SlashingReplayCache remembers the hash tree roots of recently broadcast slashings, so that
+// the same slashing gossiped back to a client and resubmitted is not broadcast again. A nil cache
+// remembers nothing.
+type SlashingReplayCache struct {
+	cache *lru.Cache
+	ttl   time.Duration
+}
+
+// NewSlashingReplayCache returns a cache remembering up to size slashing roots for ttl. The
+// defaults are used for a size or TTL which is not positive.
+func NewSlashingReplayCache(size int, ttl time.Duration) (*SlashingReplayCache, error) {
+	if size <= 0 {
+		size = defaultSlashingReplayCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defaultSlashingReplayCacheTTL
+	}
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &SlashingReplayCache{
+		cache: cache,
+		ttl:   ttl,
+	}, nil
+}
+
+// seen returns true if the root was broadcast within the cache TTL. Expired entries are evicted.
+func (c *SlashingReplayCache) seen(root [32]byte) bool {
+	if c == nil {
+		return false
+	}
+	item, ok := c.cache.Get(root)
+	if !ok {
+		return false
+	}
+	if time.Since(item.(time.Time)) > c.ttl {
+		c.cache.Remove(root)
+		return false
+	}
+	return true
+}
+
+// add records the root as broadcast now.
+func (c *SlashingReplayCache) add(root [32]byte) {
+	if c == nil {
+		return
+	}
+	c.cache.Add(root, time.Now())
+}
@@ -0,0 +1,44 @@
+package beaconv1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSlashingReplayCache_Expiry(t *testing.T) {
+	c, err := NewSlashingReplayCache(2, time.Millisecond)
+	require.NoError(t, err)
+	root := [32]byte{'a'}
+	assert.Equal(t, false, c.seen(root))
+	c.add(root)
+	assert.Equal(t, true, c.seen(root))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, false, c.seen(root))
+	assert.Equal(t, 0, c.cache.Len())
+}
+
+func TestSlashingReplayCache_Eviction(t *testing.T) {
+	c, err := NewSlashingReplayCache(2, time.Minute)
+	require.NoError(t, err)
+	c.add([32]byte{'a'})
+	c.add([32]byte{'b'})
+	c.add([32]byte{'c'})
+	assert.Equal(t, false, c.seen([32]byte{'a'}))
+	assert.Equal(t, true, c.seen([32]byte{'b'}))
+	assert.Equal(t, true, c.seen([32]byte{'c'}))
+}
+
+func TestNewSlashingReplayCache_Defaults(t *testing.T) {
+	c, err := NewSlashingReplayCache(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaultSlashingReplayCacheTTL, c.ttl)
+}
+
+func TestSlashingReplayCache_Nil(t *testing.T) {
+	var c *SlashingReplayCache
+	c.add([32]byte{'a'})
+	assert.Equal(t, false, c.seen([32]byte{'a'}))
+}
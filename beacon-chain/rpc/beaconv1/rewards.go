@@ -0,0 +1,263 @@
+package beaconv1
+
+import (
+	"context"
+	"sort"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockRewardsResponse is the breakdown of the rewards of the proposer of a block, in Gwei.
+type BlockRewardsResponse struct {
+	ProposerIndex     types.ValidatorIndex `json:"proposer_index"`
+	Total             uint64               `json:"total"`
+	Attestations      uint64               `json:"attestations"`
+	ProposerSlashings uint64               `json:"proposer_slashings"`
+	AttesterSlashings uint64               `json:"attester_slashings"`
+}
+
+// AttestationRewardsRequest selects the validators whose attestation rewards of an epoch are
+// computed. Validators are identified by index or by 0x-prefixed hex encoded public key, and all
+// validators are selected when none is given.
+type AttestationRewardsRequest struct {
+	Epoch types.Epoch `json:"epoch"`
+	Id    [][]byte    `json:"id"`
+}
+
+// AttestationRewards is the breakdown of the rewards of a validator for its attestation of an
+// epoch, in Gwei. Penalties are negative.
+type AttestationRewards struct {
+	ValidatorIndex types.ValidatorIndex `json:"validator_index"`
+	Head           int64                `json:"head"`
+	Target         int64                `json:"target"`
+	Source         int64                `json:"source"`
+	InclusionDelay int64                `json:"inclusion_delay"`
+	Inactivity     int64                `json:"inactivity"`
+}
+
+// AttestationRewardsResponse holds the attestation rewards of the requested validators.
+type AttestationRewardsResponse struct {
+	Epoch types.Epoch           `json:"epoch"`
+	Data  []*AttestationRewards `json:"data"`
+}
+
+// GetBlockRewards computes the rewards of the proposer of a block for the attestations and the
+// slashings it includes. Slashing rewards are paid when the block is processed, whereas
+// attestation rewards are paid at the epoch transition following the epoch of the attestations:
+// they are computed from the state the block was processed on, and only count the attesters whose
+// attestations were not included by an earlier block.
+func (bs *Server) GetBlockRewards(ctx context.Context, req *ethpb.BlockRequest) (*BlockRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.GetBlockRewards")
+	defer span.End()
+
+	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
+	if err != nil {
+		return nil, statusError("Could not get block from block ID", err)
+	}
+	if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+		return nil, status.Errorf(codes.NotFound, "Could not find requested block")
+	}
+	resp := &BlockRewardsResponse{ProposerIndex: blk.Block.ProposerIndex}
+	if blk.Block.Slot == 0 {
+		return resp, nil
+	}
+
+	parentState, err := bs.StateGenService.StateByRoot(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get parent state: %v", err)
+	}
+	if parentState == nil {
+		return nil, status.Errorf(codes.NotFound, "Could not find parent state of requested block")
+	}
+	preState, err := state.ProcessSlots(ctx, parentState.Copy(), blk.Block.Slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process slots up to block: %v", err)
+	}
+
+	resp.Attestations, err = attestationsProposerReward(preState, blk.Block.Body)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute attestations reward: %v", err)
+	}
+
+	// Validators can only be slashed once, so the slashings of the block are counted in the order
+	// they are processed.
+	currentEpoch := helpers.CurrentEpoch(preState)
+	slashed := make(map[types.ValidatorIndex]bool)
+	whistleblowerReward := func(idx types.ValidatorIndex) (uint64, error) {
+		val, err := preState.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return 0, err
+		}
+		if slashed[idx] || !helpers.IsSlashableValidatorUsingTrie(val, currentEpoch) {
+			return 0, nil
+		}
+		slashed[idx] = true
+		// In phase 0, the proposer is the whistleblower and receives the whole whistleblower reward.
+		return val.EffectiveBalance() / params.BeaconConfig().WhistleBlowerRewardQuotient, nil
+	}
+	for _, slashing := range blk.Block.Body.ProposerSlashings {
+		reward, err := whistleblowerReward(slashing.Header_1.Header.ProposerIndex)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not compute proposer slashing reward: %v", err)
+		}
+		resp.ProposerSlashings += reward
+	}
+	for _, slashing := range blk.Block.Body.AttesterSlashings {
+		indices := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+		sort.Slice(indices, func(i, j int) bool {
+			return indices[i] < indices[j]
+		})
+		for _, idx := range indices {
+			reward, err := whistleblowerReward(types.ValidatorIndex(idx))
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not compute attester slashing reward: %v", err)
+			}
+			resp.AttesterSlashings += reward
+		}
+	}
+
+	resp.Total = resp.Attestations + resp.ProposerSlashings + resp.AttesterSlashings
+	return resp, nil
+}
+
+// GetAttestationRewards computes the head, target, source, inclusion delay and inactivity
+// components of the rewards of validators for their attestations of an epoch. These rewards are
+// applied at the transition to the second epoch after it, so the epoch must have ended at least one
+// epoch before the current one.
+func (bs *Server) GetAttestationRewards(ctx context.Context, req *AttestationRewardsRequest) (*AttestationRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconv1.GetAttestationRewards")
+	defer span.End()
+
+	currentEpoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+	if req.Epoch+1 >= currentEpoch {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Rewards of epoch %d are applied at the start of epoch %d, the current epoch is %d",
+			req.Epoch,
+			req.Epoch+2,
+			currentEpoch,
+		)
+	}
+
+	// The rewards are computed on the state right before the epoch transition applying them.
+	startSlot, err := helpers.StartSlot(req.Epoch + 2)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid epoch: %v", err)
+	}
+	st, err := bs.StateGenService.StateBySlot(ctx, startSlot-1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get state: %v", err)
+	}
+	vp, bp, err := precompute.New(ctx, st)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not precompute validators: %v", err)
+	}
+	vp, bp, err = precompute.ProcessAttestations(ctx, st, vp, bp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process attestations: %v", err)
+	}
+	deltas := precompute.AttestationsDeltaBreakdown(st, bp, vp)
+
+	indices, err := validatorIndices(st, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AttestationRewardsResponse{
+		Epoch: req.Epoch,
+		Data:  make([]*AttestationRewards, len(indices)),
+	}
+	for i, idx := range indices {
+		d := deltas[idx]
+		resp.Data[i] = &AttestationRewards{
+			ValidatorIndex: idx,
+			Head:           int64(d.HeadReward) - int64(d.HeadPenalty),
+			Target:         int64(d.TargetReward) - int64(d.TargetPenalty),
+			Source:         int64(d.SourceReward) - int64(d.SourcePenalty),
+			InclusionDelay: int64(d.InclusionDelayReward),
+			Inactivity:     -int64(d.InactivityPenalty),
+		}
+	}
+	return resp, nil
+}
+
+// attestationsProposerReward computes the proposer reward for the attestations of a block
+// processed on preState. Only the first inclusion of the attestation of a validator is rewarded.
+func attestationsProposerReward(preState *statetrie.BeaconState, body *eth.BeaconBlockBody) (uint64, error) {
+	totalBalance, err := helpers.TotalActiveBalance(preState)
+	if err != nil {
+		return 0, err
+	}
+	balanceSqrt := mathutil.IntegerSquareRoot(totalBalance)
+	// Balance square root cannot be 0, this prevents division by 0.
+	if balanceSqrt == 0 {
+		balanceSqrt = 1
+	}
+
+	included := make(map[types.Epoch]map[types.ValidatorIndex]bool)
+	attesters := func(data *eth.AttestationData, bits bitfield.Bitlist) ([]uint64, error) {
+		committee, err := helpers.BeaconCommitteeFromState(preState, data.Slot, data.CommitteeIndex)
+		if err != nil {
+			return nil, err
+		}
+		return attestationutil.AttestingIndices(bits, committee)
+	}
+	markIncluded := func(epoch types.Epoch, idx types.ValidatorIndex) bool {
+		if included[epoch] == nil {
+			included[epoch] = make(map[types.ValidatorIndex]bool)
+		}
+		if included[epoch][idx] {
+			return false
+		}
+		included[epoch][idx] = true
+		return true
+	}
+	for _, a := range append(preState.PreviousEpochAttestations(), preState.CurrentEpochAttestations()...) {
+		indices, err := attesters(a.Data, a.AggregationBits)
+		if err != nil {
+			return 0, err
+		}
+		for _, idx := range indices {
+			markIncluded(a.Data.Target.Epoch, types.ValidatorIndex(idx))
+		}
+	}
+
+	var reward uint64
+	for _, att := range body.Attestations {
+		indices, err := attesters(att.Data, att.AggregationBits)
+		if err != nil {
+			return 0, err
+		}
+		for _, i := range indices {
+			idx := types.ValidatorIndex(i)
+			if !markIncluded(att.Data.Target.Epoch, idx) {
+				continue
+			}
+			val, err := preState.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				return 0, err
+			}
+			if val.Slashed() {
+				continue
+			}
+			baseReward := val.EffectiveBalance() * params.BeaconConfig().BaseRewardFactor / balanceSqrt /
+				params.BeaconConfig().BaseRewardsPerEpoch
+			reward += baseReward / params.BeaconConfig().ProposerRewardQuotient
+		}
+	}
+	return reward, nil
+}
@@ -0,0 +1,142 @@
+package beaconv1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	ethpb_alpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestGetBlockRewards(t *testing.T) {
+	ctx := context.Background()
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	conf := testutil.DefaultBlockGenConfig()
+	conf.NumProposerSlashings = 1
+	blk, err := testutil.GenerateFullBlock(beaconState, privKeys, conf, 1)
+	require.NoError(t, err)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	beaconDB := dbTest.SetupDB(t)
+	require.NoError(t, beaconDB.SaveBlock(ctx, genesis))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+	stateGen := stategen.NewMockService()
+	stateGen.AddStateForRoot(beaconState.Copy(), genesisRoot)
+	s := &Server{
+		BeaconDB:         beaconDB,
+		ChainInfoFetcher: &mock.ChainService{},
+		StateGenService:  stateGen,
+	}
+
+	preState, err := state.ProcessSlots(ctx, beaconState.Copy(), 1)
+	require.NoError(t, err)
+	var wantAttestations uint64
+	for _, att := range blk.Block.Body.Attestations {
+		committee, err := helpers.BeaconCommitteeFromState(preState, att.Data.Slot, att.Data.CommitteeIndex)
+		require.NoError(t, err)
+		for i, idx := range committee {
+			if !att.AggregationBits.BitAt(uint64(i)) {
+				continue
+			}
+			baseReward, err := epoch.BaseReward(preState, idx)
+			require.NoError(t, err)
+			wantAttestations += baseReward / params.BeaconConfig().ProposerRewardQuotient
+		}
+	}
+	require.Equal(t, true, wantAttestations > 0)
+	wantSlashings := params.BeaconConfig().MaxEffectiveBalance / params.BeaconConfig().WhistleBlowerRewardQuotient
+
+	resp, err := s.GetBlockRewards(ctx, &ethpb.BlockRequest{BlockId: root[:]})
+	require.NoError(t, err)
+	assert.Equal(t, blk.Block.ProposerIndex, resp.ProposerIndex)
+	assert.Equal(t, wantAttestations, resp.Attestations)
+	assert.Equal(t, wantSlashings, resp.ProposerSlashings)
+	assert.Equal(t, uint64(0), resp.AttesterSlashings)
+	assert.Equal(t, wantAttestations+wantSlashings, resp.Total)
+
+	resp, err = s.GetBlockRewards(ctx, &ethpb.BlockRequest{BlockId: []byte("genesis")})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), resp.Total)
+}
+
+func TestGetAttestationRewards(t *testing.T) {
+	ctx := context.Background()
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	// The rewards of epoch 0 are applied at the transition to epoch 2.
+	require.NoError(t, beaconState.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, 0, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	require.NoError(t, beaconState.AppendPreviousEpochAttestations(&pb.PendingAttestation{
+		AggregationBits: bits,
+		Data:            testutil.HydrateAttestationData(&ethpb_alpha.AttestationData{}),
+		InclusionDelay:  1,
+	}))
+	attester, missed := committee[0], committee[1]
+
+	stateGen := stategen.NewMockService()
+	stateGen.StatesBySlot[beaconState.Slot()] = beaconState
+	currentSlot := 2 * params.BeaconConfig().SlotsPerEpoch
+	s := &Server{
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+		StateGenService:    stateGen,
+	}
+
+	resp, err := s.GetAttestationRewards(ctx, &AttestationRewardsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 64, len(resp.Data))
+
+	resp, err = s.GetAttestationRewards(ctx, &AttestationRewardsRequest{
+		Id: [][]byte{[]byte(fmt.Sprintf("%d", attester)), []byte(fmt.Sprintf("%d", missed))},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Data))
+	rewards := resp.Data[0]
+	assert.Equal(t, attester, rewards.ValidatorIndex)
+	assert.Equal(t, true, rewards.Head > 0)
+	assert.Equal(t, true, rewards.Target > 0)
+	assert.Equal(t, true, rewards.Source > 0)
+	assert.Equal(t, true, rewards.InclusionDelay > 0)
+	assert.Equal(t, int64(0), rewards.Inactivity)
+
+	baseReward, err := epoch.BaseReward(beaconState, missed)
+	require.NoError(t, err)
+	penalties := resp.Data[1]
+	assert.Equal(t, missed, penalties.ValidatorIndex)
+	assert.Equal(t, -int64(baseReward), penalties.Head)
+	assert.Equal(t, -int64(baseReward), penalties.Target)
+	assert.Equal(t, -int64(baseReward), penalties.Source)
+	assert.Equal(t, int64(0), penalties.InclusionDelay)
+
+	_, err = s.GetAttestationRewards(ctx, &AttestationRewardsRequest{Epoch: types.Epoch(1)})
+	assert.ErrorContains(t, "applied at the start of epoch 3", err)
+}
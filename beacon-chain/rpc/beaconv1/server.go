@@ -18,32 +18,65 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Server defines a server implementation of the gRPC Beacon Chain service,
 // providing RPC endpoints to access data relevant to the Ethereum 2.0 phase 0
 // beacon chain.
 type Server struct {
-	BeaconDB            db.ReadOnlyDatabase
-	Ctx                 context.Context
-	ChainStartFetcher   powchain.ChainStartFetcher
-	ChainInfoFetcher    blockchain.ChainInfoFetcher
-	DepositFetcher      depositcache.DepositFetcher
-	BlockFetcher        powchain.POWBlockFetcher
-	GenesisTimeFetcher  blockchain.TimeFetcher
-	BlockReceiver       blockchain.BlockReceiver
-	StateNotifier       statefeed.Notifier
-	BlockNotifier       blockfeed.Notifier
-	AttestationNotifier operation.Notifier
-	Broadcaster         p2p.Broadcaster
-	AttestationsPool    attestations.Pool
-	SlashingsPool       slashings.PoolManager
-	VoluntaryExitsPool  voluntaryexits.PoolManager
-	CanonicalStateChan  chan *pbp2p.BeaconState
-	ChainStartChan      chan time.Time
-	StateGenService     stategen.StateManager
-	SyncChecker         sync.Checker
+	BeaconDB               db.ReadOnlyDatabase
+	Ctx                    context.Context
+	ChainStartFetcher      powchain.ChainStartFetcher
+	ChainInfoFetcher       blockchain.ChainInfoFetcher
+	DepositFetcher         depositcache.DepositFetcher
+	BlockFetcher           powchain.POWBlockFetcher
+	GenesisTimeFetcher     blockchain.TimeFetcher
+	BlockReceiver          blockchain.BlockReceiver
+	StateNotifier          statefeed.Notifier
+	BlockNotifier          blockfeed.Notifier
+	AttestationNotifier    operation.Notifier
+	Broadcaster            p2p.Broadcaster
+	AttestationsPool       attestations.Pool
+	SlashingsPool          slashings.PoolManager
+	VoluntaryExitsPool     voluntaryexits.PoolManager
+	CanonicalStateChan     chan *pbp2p.BeaconState
+	ChainStartChan         chan time.Time
+	StateGenService        stategen.StateManager
+	SyncChecker            sync.Checker
+	SlashingWebhookURL     string
+	MaxBatchSize           uint64
+	SlashingReplayCache    *SlashingReplayCache
+	VerifyExitsAgainstPool bool
+	lastBroadcasts         lastBroadcastTimes
+}
+
+// requireHeadState returns the head state for handlers that validate against it. A node that is
+// still syncing is reported as Unavailable, while a failure to retrieve the state is Internal.
+func (bs *Server) requireHeadState(ctx context.Context) (*statetrie.BeaconState, error) {
+	if bs.SyncChecker != nil && bs.SyncChecker.Syncing() {
+		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if headState == nil {
+		return nil, status.Error(codes.Internal, "Could not get head state: head state is nil")
+	}
+	return headState, nil
+}
+
+// requestFilterSet returns whether a filter of a request is set. The gateway passes the presence of
+// the query parameters of the filters, so that zero values filter too. Calls made without it,
+// which cannot express a zero filter, filter on non-zero values only.
+func requestFilterSet(ctx context.Context, name string, value uint64) bool {
+	_, ok := grpcutils.RequestParam(ctx, name)
+	return ok || value != 0
 }
@@ -1,5 +1,69 @@
 package beaconv1
 
-import ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	syncMock "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
 
 var _ ethpb.BeaconChainServer = (*Server)(nil)
+
+type erroringHeadStateFetcher struct {
+	*chainMock.ChainService
+}
+
+func (*erroringHeadStateFetcher) HeadState(context.Context) (*statetrie.BeaconState, error) {
+	return nil, errors.New("could not read state")
+}
+
+func TestRequireHeadState(t *testing.T) {
+	ctx := context.Background()
+	state, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+
+	t.Run("OK", func(t *testing.T) {
+		s := &Server{
+			ChainInfoFetcher: &chainMock.ChainService{State: state},
+			SyncChecker:      &syncMock.Sync{IsSyncing: false},
+		}
+		headState, err := s.requireHeadState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, state, headState)
+	})
+	t.Run("Syncing", func(t *testing.T) {
+		s := &Server{
+			ChainInfoFetcher: &chainMock.ChainService{State: state},
+			SyncChecker:      &syncMock.Sync{IsSyncing: true},
+		}
+		_, err := s.requireHeadState(ctx)
+		assert.ErrorContains(t, "Syncing to latest head", err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("Fetcher error", func(t *testing.T) {
+		s := &Server{
+			ChainInfoFetcher: &erroringHeadStateFetcher{},
+			SyncChecker:      &syncMock.Sync{IsSyncing: false},
+		}
+		_, err := s.requireHeadState(ctx)
+		assert.ErrorContains(t, "Could not get head state: could not read state", err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+	t.Run("Nil head state", func(t *testing.T) {
+		s := &Server{
+			ChainInfoFetcher: &chainMock.ChainService{},
+		}
+		_, err := s.requireHeadState(ctx)
+		assert.ErrorContains(t, "head state is nil", err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -78,7 +78,7 @@ func (bs *Server) GetStateFork(ctx context.Context, req *ethpb.StateRequest) (*e
 		err   error
 	)
 
-	state, err = bs.state(ctx, req.StateId)
+	state, err = bs.State(ctx, req.StateId)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +104,7 @@ func (bs *Server) GetFinalityCheckpoints(ctx context.Context, req *ethpb.StateRe
 		err   error
 	)
 
-	state, err = bs.state(ctx, req.StateId)
+	state, err = bs.State(ctx, req.StateId)
 	if err != nil {
 		return nil, err
 	}
@@ -135,17 +135,13 @@ func (bs *Server) stateRoot(ctx context.Context, stateId []byte) ([]byte, error)
 	case "justified":
 		root, err = bs.justifiedStateRoot(ctx)
 	default:
-		ok, matchErr := bytesutil.IsBytes32Hex(stateId)
-		if matchErr != nil {
-			return nil, status.Errorf(codes.Internal, "Could not parse ID: %v", err)
-		}
-		if ok {
-			root, err = bs.stateRootByHex(ctx, stateId)
+		if stateRoot, ok := decodeRootID(stateId); ok {
+			root, err = bs.stateRootByHex(ctx, stateRoot[:])
 		} else {
 			slotNumber, parseErr := strconv.ParseUint(stateIdString, 10, 64)
 			if parseErr != nil {
 				// ID format does not match any valid options.
-				return nil, status.Errorf(codes.Internal, "Invalid state ID: "+stateIdString)
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid state ID: %s", stateIdString)
 			}
 			root, err = bs.stateRootBySlot(ctx, types.Slot(slotNumber))
 		}
@@ -154,7 +150,10 @@ func (bs *Server) stateRoot(ctx context.Context, stateId []byte) ([]byte, error)
 	return root, err
 }
 
-func (bs *Server) state(ctx context.Context, stateId []byte) (*statetrie.BeaconState, error) {
+// State returns the beacon state for a state id of the standard API: head, genesis, finalized,
+// justified, a hex encoded state root or a slot. Historical states are regenerated by the state
+// gen service.
+func (bs *Server) State(ctx context.Context, stateId []byte) (*statetrie.BeaconState, error) {
 	var (
 		s   *statetrie.BeaconState
 		err error
@@ -185,17 +184,13 @@ func (bs *Server) state(ctx context.Context, stateId []byte) (*statetrie.BeaconS
 			return nil, status.Errorf(codes.Internal, "Could not get justified state: %v", err)
 		}
 	default:
-		ok, matchErr := bytesutil.IsBytes32Hex(stateId)
-		if matchErr != nil {
-			return nil, status.Errorf(codes.Internal, "Could not parse ID: %v", err)
-		}
-		if ok {
-			s, err = bs.stateByHex(ctx, stateId)
+		if stateRoot, ok := decodeRootID(stateId); ok {
+			s, err = bs.stateByHex(ctx, stateRoot[:])
 		} else {
 			slotNumber, parseErr := strconv.ParseUint(stateIdString, 10, 64)
 			if parseErr != nil {
 				// ID format does not match any valid options.
-				return nil, status.Errorf(codes.Internal, "Invalid state ID: "+stateIdString)
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid state ID: %s", stateIdString)
 			}
 			s, err = bs.stateBySlot(ctx, types.Slot(slotNumber))
 		}
@@ -259,24 +254,16 @@ func (bs *Server) justifiedStateRoot(ctx context.Context) ([]byte, error) {
 func (bs *Server) stateRootByHex(ctx context.Context, stateId []byte) ([]byte, error) {
 	var stateRoot [32]byte
 	copy(stateRoot[:], stateId)
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
-	}
-	for _, root := range headState.StateRoots() {
-		if bytes.Equal(root, stateRoot[:]) {
-			return stateRoot[:], nil
-		}
+	if _, err := bs.blockRootByStateRoot(ctx, stateRoot[:]); err != nil {
+		return nil, err
 	}
-	return nil, status.Errorf(
-		codes.NotFound,
-		"State not found in the last %d state roots in head state", len(headState.StateRoots()))
+	return stateRoot[:], nil
 }
 
 func (bs *Server) stateRootBySlot(ctx context.Context, slot types.Slot) ([]byte, error) {
 	currentSlot := bs.GenesisTimeFetcher.CurrentSlot()
 	if slot > currentSlot {
-		return nil, status.Errorf(codes.Internal, "Slot cannot be in the future")
+		return nil, status.Errorf(codes.InvalidArgument, "Slot cannot be in the future")
 	}
 	found, blks, err := bs.BeaconDB.BlocksBySlot(ctx, slot)
 	if err != nil {
@@ -295,25 +282,63 @@ func (bs *Server) stateRootBySlot(ctx context.Context, slot types.Slot) ([]byte,
 }
 
 func (bs *Server) stateByHex(ctx context.Context, stateId []byte) (*statetrie.BeaconState, error) {
-	headState, err := bs.ChainInfoFetcher.HeadState(ctx)
+	blockRoot, err := bs.blockRootByStateRoot(ctx, stateId)
+	if err != nil {
+		return nil, err
+	}
+	state, err := bs.StateGenService.StateByRoot(ctx, bytesutil.ToBytes32(blockRoot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get state: %v", err)
+	}
+	return state, nil
+}
+
+// maxArchivedStateRootLookups bounds the number of archived states searched for a state root, as
+// every lookup may replay blocks on top of a state of the cold store.
+const maxArchivedStateRootLookups = 4
+
+// blockRootByStateRoot returns the block root recorded alongside the given state root. The state
+// roots of the head state are searched first. Older roots are searched in the state roots of states
+// from the cold store, each one historical root vector further back, up to
+// maxArchivedStateRootLookups states or genesis, whichever comes first.
+func (bs *Server) blockRootByStateRoot(ctx context.Context, stateRoot []byte) ([]byte, error) {
+	st, err := bs.ChainInfoFetcher.HeadState(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
 	}
-	for i, root := range headState.StateRoots() {
-		if bytes.Equal(root, stateId) {
-			blockRoot := headState.BlockRoots()[i]
-			return bs.StateGenService.StateByRoot(ctx, bytesutil.ToBytes32(blockRoot))
+	historyLen := types.Slot(len(st.StateRoots()))
+	for lookups := 0; ; lookups++ {
+		for i, root := range st.StateRoots() {
+			if bytes.Equal(root, stateRoot) {
+				return st.BlockRoots()[i], nil
+			}
+		}
+		if st.Slot() <= historyLen || lookups == maxArchivedStateRootLookups {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, status.Errorf(codes.Canceled, "Request cancelled while searching archived states: %v", err)
+		}
+		st, err = bs.StateGenService.StateBySlot(ctx, st.Slot()-historyLen)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get archived state: %v", err)
+		}
+		if st == nil {
+			break
 		}
 	}
 	return nil, status.Errorf(
 		codes.NotFound,
-		"State not found in the last %d state roots in head state", len(headState.StateRoots()))
+		"State not found in the last %d state roots in head state or in up to %d archived states",
+		historyLen,
+		maxArchivedStateRootLookups,
+	)
 }
 
 func (bs *Server) stateBySlot(ctx context.Context, slot types.Slot) (*statetrie.BeaconState, error) {
 	currentSlot := bs.GenesisTimeFetcher.CurrentSlot()
 	if slot > currentSlot {
-		return nil, status.Errorf(codes.Internal, "Slot cannot be in the future")
+		return nil, status.Errorf(codes.InvalidArgument, "Slot cannot be in the future")
 	}
 	state, err := bs.StateGenService.StateBySlot(ctx, slot)
 	if err != nil {
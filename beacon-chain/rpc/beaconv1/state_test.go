@@ -22,6 +22,8 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestGetGenesis(t *testing.T) {
@@ -272,6 +274,7 @@ func TestGetStateRoot(t *testing.T) {
 			StateId: []byte("foo"),
 		})
 		require.ErrorContains(t, "Invalid state ID: foo", err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 }
 
@@ -661,6 +664,72 @@ func TestGetFinalityCheckpoints(t *testing.T) {
 		require.ErrorContains(t, "State not found in the last 8192 state roots in head state", err)
 	})
 
+	t.Run("Hex root in archived state", func(t *testing.T) {
+		historyLen := params.MainnetConfig().SlotsPerHistoricalRoot
+		archivedRoot := bytesutil.PadTo([]byte("archived state"), 32)
+		archivedBlockRoot := bytesutil.PadTo([]byte("archived block"), 32)
+		archivedState, err := testutil.NewBeaconState(fillCheckpoints, func(state *pb.BeaconState) {
+			state.Slot = headSlot
+			state.StateRoots[3] = archivedRoot
+			state.BlockRoots[3] = archivedBlockRoot
+		})
+		require.NoError(t, err)
+		recentState, err := testutil.NewBeaconState(fillRoots, func(state *pb.BeaconState) {
+			state.Slot = headSlot + historyLen
+		})
+		require.NoError(t, err)
+		stateGen := stategen.NewMockService()
+		stateGen.StatesBySlot[headSlot] = archivedState
+		stateGen.StatesByRoot[bytesutil.ToBytes32(archivedBlockRoot)] = archivedState
+
+		s := Server{
+			ChainInfoFetcher: &chainMock.ChainService{State: recentState},
+			StateGenService:  stateGen,
+		}
+
+		resp, err := s.GetFinalityCheckpoints(ctx, &ethpb.StateRequest{
+			StateId: archivedRoot,
+		})
+		require.NoError(t, err)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte("finalized"), 32), resp.Data.Finalized.Root)
+		assert.Equal(t, types.Epoch(103), resp.Data.Finalized.Epoch)
+	})
+
+	t.Run("Hex root beyond archived states", func(t *testing.T) {
+		historyLen := params.MainnetConfig().SlotsPerHistoricalRoot
+		archivedRoot := bytesutil.PadTo([]byte("archived state"), 32)
+		stateGen := stategen.NewMockService()
+		oldestSlot := headSlot
+		oldestState, err := testutil.NewBeaconState(fillCheckpoints, func(state *pb.BeaconState) {
+			state.Slot = oldestSlot
+			state.StateRoots[3] = archivedRoot
+			state.BlockRoots[3] = bytesutil.PadTo([]byte("archived block"), 32)
+		})
+		require.NoError(t, err)
+		stateGen.StatesBySlot[oldestSlot] = oldestState
+		// The root is in the state one lookup beyond the bound, which must not be searched.
+		for i := 1; i <= maxArchivedStateRootLookups+1; i++ {
+			slot := oldestSlot + types.Slot(i)*historyLen
+			st, err := testutil.NewBeaconState(fillRoots, func(state *pb.BeaconState) {
+				state.Slot = slot
+			})
+			require.NoError(t, err)
+			stateGen.StatesBySlot[slot] = st
+		}
+		headState := stateGen.StatesBySlot[oldestSlot+types.Slot(maxArchivedStateRootLookups+1)*historyLen]
+
+		s := Server{
+			ChainInfoFetcher: &chainMock.ChainService{State: headState},
+			StateGenService:  stateGen,
+		}
+
+		_, err = s.GetFinalityCheckpoints(ctx, &ethpb.StateRequest{
+			StateId: archivedRoot,
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.ErrorContains(t, "or in up to 4 archived states", err)
+	})
+
 	t.Run("Slot", func(t *testing.T) {
 		stateGen := stategen.NewMockService()
 		stateGen.StatesBySlot[headSlot] = state
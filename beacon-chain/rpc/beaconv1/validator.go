@@ -2,27 +2,356 @@ package beaconv1
 
 import (
 	"context"
-	"errors"
+	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// Validator statuses as defined by the standard beacon node API.
+const (
+	statusPendingInitialized = "pending_initialized"
+	statusPendingQueued      = "pending_queued"
+	statusActiveOngoing      = "active_ongoing"
+	statusActiveExiting      = "active_exiting"
+	statusActiveSlashed      = "active_slashed"
+	statusExitedUnslashed    = "exited_unslashed"
+	statusExitedSlashed      = "exited_slashed"
+	statusWithdrawalPossible = "withdrawal_possible"
+	statusWithdrawalDone     = "withdrawal_done"
+)
+
+// validatorStatuses lists every status a validator can have, followed by the top level statuses
+// that each cover all statuses sharing their prefix.
+var validatorStatuses = []string{
+	statusPendingInitialized,
+	statusPendingQueued,
+	statusActiveOngoing,
+	statusActiveExiting,
+	statusActiveSlashed,
+	statusExitedUnslashed,
+	statusExitedSlashed,
+	statusWithdrawalPossible,
+	statusWithdrawalDone,
+	"pending",
+	"active",
+	"exited",
+	"withdrawal",
+}
+
 // GetValidator returns a validator specified by state and id or public key along with status and balance.
 func (bs *Server) GetValidator(ctx context.Context, req *ethpb.StateValidatorRequest) (*ethpb.StateValidatorResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "beaconv1.GetValidator")
+	defer span.End()
+
+	state, err := bs.State(ctx, req.StateId)
+	if err != nil {
+		return nil, err
+	}
+	idx, ok, err := validatorIndex(state, req.ValidatorId)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Could not find validator with ID %s", req.ValidatorId)
+	}
+	container, err := validatorContainer(state, idx, helpers.CurrentEpoch(state))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get validator: %v", err)
+	}
+
+	return &ethpb.StateValidatorResponse{Data: container}, nil
 }
 
 // ListValidators returns filterable list of validators with their balance, status and index.
+// Validators can be filtered by index or public key, and by a comma separated list of statuses.
+// IDs of validators that are not in the state are ignored.
 func (bs *Server) ListValidators(ctx context.Context, req *ethpb.StateValidatorsRequest) (*ethpb.StateValidatorsResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "beaconv1.ListValidators")
+	defer span.End()
+
+	state, err := bs.State(ctx, req.StateId)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := statusFilter(req.Status)
+	if err != nil {
+		return nil, err
+	}
+	indices, err := validatorIndices(state, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch := helpers.CurrentEpoch(state)
+	containers := make([]*ethpb.ValidatorContainer, 0, len(indices))
+	for _, idx := range indices {
+		container, err := validatorContainer(state, idx, epoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator: %v", err)
+		}
+		if matchesStatus(container.Status, statuses) {
+			containers = append(containers, container)
+		}
+	}
+
+	return &ethpb.StateValidatorsResponse{Data: containers}, nil
 }
 
-// ListValidatorBalances returns a filterable list of validator balances.
+// ListValidatorBalances returns a filterable list of validator balances. Only the balances and, for
+// public key IDs, the public key index of the state are read, so the validator registry is never copied.
 func (bs *Server) ListValidatorBalances(ctx context.Context, req *ethpb.ValidatorBalancesRequest) (*ethpb.ValidatorBalancesResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "beaconv1.ListValidatorBalances")
+	defer span.End()
+
+	state, err := bs.State(ctx, req.StateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Id) == 0 {
+		balances := state.Balances()
+		data := make([]*ethpb.ValidatorBalance, len(balances))
+		for i, balance := range balances {
+			data[i] = &ethpb.ValidatorBalance{Index: uint64(i), Balance: balance}
+		}
+		return &ethpb.ValidatorBalancesResponse{Data: data}, nil
+	}
+
+	ids := make([][]byte, len(req.Id))
+	for i, id := range req.Id {
+		ids[i] = []byte(id)
+	}
+	indices, err := validatorIndices(state, ids)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*ethpb.ValidatorBalance, len(indices))
+	for i, idx := range indices {
+		balance, err := state.BalanceAtIndex(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator balance: %v", err)
+		}
+		data[i] = &ethpb.ValidatorBalance{Index: uint64(idx), Balance: balance}
+	}
+
+	return &ethpb.ValidatorBalancesResponse{Data: data}, nil
 }
 
-// ListCommittees retrieves the committees for the given state at the given epoch.
+// ListCommittees retrieves the committees for the given state at the given epoch, optionally
+// filtered by committee index and slot. Without an epoch, the epoch of the requested slot or,
+// without one, the epoch of the state is used. The gateway passes which filters are set, so that
+// zero values filter too; as other callers cannot tell an unset field from a zero value, their zero
+// fields are treated as unset. Committees are read from the committee cache, which is filled for
+// the epoch on first request.
 func (bs *Server) ListCommittees(ctx context.Context, req *ethpb.StateCommitteesRequest) (*ethpb.StateCommitteesResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "beaconv1.ListCommittees")
+	defer span.End()
+
+	state, err := bs.State(ctx, req.StateId)
+	if err != nil {
+		return nil, err
+	}
+
+	filterSlot := requestFilterSet(ctx, "slot", uint64(req.Slot))
+	filterIndex := requestFilterSet(ctx, "index", req.Index)
+	epoch := req.Epoch
+	if !requestFilterSet(ctx, "epoch", uint64(req.Epoch)) {
+		if filterSlot {
+			epoch = helpers.SlotToEpoch(req.Slot)
+		} else {
+			epoch = helpers.CurrentEpoch(state)
+		}
+	}
+	if filterSlot && helpers.SlotToEpoch(req.Slot) != epoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Slot %d is not in epoch %d", req.Slot, epoch)
+	}
+	if maxEpoch := helpers.CurrentEpoch(state) + params.BeaconConfig().MinSeedLookahead; epoch > maxEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Epoch %d can not be greater than %d for state at slot %d",
+			epoch, maxEpoch, state.Slot())
+	}
+
+	activeCount, err := helpers.ActiveValidatorCount(state, epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get active validator count: %v", err)
+	}
+	committeesPerSlot := helpers.SlotCommitteeCount(activeCount)
+	startSlot, err := helpers.StartSlot(epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Could not get start slot of epoch %d: %v", epoch, err)
+	}
+
+	var committees []*ethpb.Committee
+	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch; slot++ {
+		if filterSlot && slot != req.Slot {
+			continue
+		}
+		for index := uint64(0); index < committeesPerSlot; index++ {
+			if filterIndex && index != req.Index {
+				continue
+			}
+			committee, err := helpers.BeaconCommitteeFromState(state, slot, types.CommitteeIndex(index))
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not get committee: %v", err)
+			}
+			validators := make([]uint64, len(committee))
+			for i, v := range committee {
+				validators[i] = uint64(v)
+			}
+			committees = append(committees, &ethpb.Committee{
+				Index:      index,
+				Slot:       slot,
+				Validators: validators,
+			})
+		}
+	}
+
+	return &ethpb.StateCommitteesResponse{Data: committees}, nil
+}
+
+// validatorIndices returns the indices of the validators with the given IDs in the order they were
+// requested, or the indices of all validators in the state if no IDs are given. Duplicate IDs and
+// IDs of validators that are not in the state are skipped.
+func validatorIndices(state *statetrie.BeaconState, ids [][]byte) ([]types.ValidatorIndex, error) {
+	if len(ids) == 0 {
+		indices := make([]types.ValidatorIndex, state.NumValidators())
+		for i := range indices {
+			indices[i] = types.ValidatorIndex(i)
+		}
+		return indices, nil
+	}
+
+	indices := make([]types.ValidatorIndex, 0, len(ids))
+	seen := make(map[types.ValidatorIndex]bool, len(ids))
+	for _, id := range ids {
+		idx, ok, err := validatorIndex(state, id)
+		if err != nil {
+			return nil, err
+		}
+		if ok && !seen[idx] {
+			seen[idx] = true
+			indices = append(indices, idx)
+		}
+	}
+	return indices, nil
+}
+
+// validatorIndex resolves a validator ID, which is either a decimal validator index or a 0x-prefixed
+// hex encoded public key, to the index of the validator in the state. It reports false when the
+// validator is not in the state.
+func validatorIndex(state *statetrie.BeaconState, id []byte) (types.ValidatorIndex, bool, error) {
+	idString := string(id)
+	if strings.HasPrefix(idString, "0x") {
+		pubKey, err := hexutil.Decode(idString)
+		if err != nil || len(pubKey) != params.BeaconConfig().BLSPubkeyLength {
+			return 0, false, status.Errorf(codes.InvalidArgument, "Invalid validator ID: %s", idString)
+		}
+		idx, ok := state.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
+		return idx, ok, nil
+	}
+	index, err := strconv.ParseUint(idString, 10, 64)
+	if err != nil {
+		return 0, false, status.Errorf(codes.InvalidArgument, "Invalid validator ID: %s", idString)
+	}
+	if index >= uint64(state.NumValidators()) {
+		return 0, false, nil
+	}
+	return types.ValidatorIndex(index), true, nil
+}
+
+// statusFilter parses a comma separated list of validator statuses. An empty list matches every
+// status.
+func statusFilter(filter string) ([]string, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	statuses := strings.Split(strings.ToLower(filter), ",")
+	for i, s := range statuses {
+		s = strings.TrimSpace(s)
+		if !isValidatorStatus(s) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator status: %s", s)
+		}
+		statuses[i] = s
+	}
+	return statuses, nil
+}
+
+func isValidatorStatus(s string) bool {
+	for _, vs := range validatorStatuses {
+		if s == vs {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesStatus reports whether a validator status is matched by any of the filtered statuses,
+// where a top level status such as "active" matches every status it covers.
+func matchesStatus(validatorStatus string, statuses []string) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if validatorStatus == s || strings.HasPrefix(validatorStatus, s+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func validatorContainer(state *statetrie.BeaconState, idx types.ValidatorIndex, epoch types.Epoch) (*ethpb.ValidatorContainer, error) {
+	val, err := state.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := state.BalanceAtIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.ValidatorContainer{
+		Index:     uint64(idx),
+		Balance:   balance,
+		Status:    validatorStatus(val, balance, epoch),
+		Validator: migration.V1Alpha1ValidatorToV1(val.CopyValidator()),
+	}, nil
+}
+
+// validatorStatus derives the status of a validator at the given epoch as defined by the standard
+// beacon node API.
+func validatorStatus(val statetrie.ReadOnlyValidator, balance uint64, epoch types.Epoch) string {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	switch {
+	case val.ActivationEpoch() > epoch:
+		if val.ActivationEligibilityEpoch() == farFutureEpoch {
+			return statusPendingInitialized
+		}
+		return statusPendingQueued
+	case val.ExitEpoch() > epoch:
+		if val.ExitEpoch() == farFutureEpoch {
+			return statusActiveOngoing
+		}
+		if val.Slashed() {
+			return statusActiveSlashed
+		}
+		return statusActiveExiting
+	case val.WithdrawableEpoch() > epoch:
+		if val.Slashed() {
+			return statusExitedSlashed
+		}
+		return statusExitedUnslashed
+	case balance != 0:
+		return statusWithdrawalPossible
+	default:
+		return statusWithdrawalDone
+	}
 }
@@ -0,0 +1,234 @@
+package beaconv1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// validatorStatusServer returns a server whose head state at epoch 10 holds one validator of every
+// status, in the order of validatorStatuses.
+func validatorStatusServer(t *testing.T) *Server {
+	far := params.BeaconConfig().FarFutureEpoch
+	validators := []*eth.Validator{
+		{ActivationEligibilityEpoch: far, ActivationEpoch: far, ExitEpoch: far, WithdrawableEpoch: far},
+		{ActivationEligibilityEpoch: 9, ActivationEpoch: 11, ExitEpoch: far, WithdrawableEpoch: far},
+		{ActivationEpoch: 1, ExitEpoch: far, WithdrawableEpoch: far},
+		{ActivationEpoch: 1, ExitEpoch: 12, WithdrawableEpoch: 20},
+		{ActivationEpoch: 1, ExitEpoch: 12, WithdrawableEpoch: 20, Slashed: true},
+		{ActivationEpoch: 1, ExitEpoch: 5, WithdrawableEpoch: 20},
+		{ActivationEpoch: 1, ExitEpoch: 5, WithdrawableEpoch: 20, Slashed: true},
+		{ActivationEpoch: 1, ExitEpoch: 5, WithdrawableEpoch: 8},
+		{ActivationEpoch: 1, ExitEpoch: 5, WithdrawableEpoch: 8},
+	}
+	balances := make([]uint64, len(validators))
+	for i, v := range validators {
+		v.PublicKey = bytesutil.PadTo([]byte(fmt.Sprintf("pubkey%d", i)), 48)
+		v.WithdrawalCredentials = make([]byte, 32)
+		balances[i] = uint64(i + 1)
+	}
+	balances[len(balances)-1] = 0
+
+	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
+		state.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(10)
+		state.Validators = validators
+		state.Balances = balances
+	})
+	require.NoError(t, err)
+	return &Server{ChainInfoFetcher: &chainMock.ChainService{State: state}}
+}
+
+func TestListValidators(t *testing.T) {
+	s := validatorStatusServer(t)
+	ctx := context.Background()
+
+	t.Run("All", func(t *testing.T) {
+		resp, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head")})
+		require.NoError(t, err)
+		require.Equal(t, 9, len(resp.Data))
+		for i, v := range resp.Data {
+			assert.Equal(t, uint64(i), v.Index)
+			assert.Equal(t, validatorStatuses[i], v.Status)
+			assert.DeepEqual(t, bytesutil.PadTo([]byte(fmt.Sprintf("pubkey%d", i)), 48), v.Validator.PublicKey)
+		}
+		assert.Equal(t, uint64(1), resp.Data[0].Balance)
+	})
+
+	t.Run("By ID", func(t *testing.T) {
+		pubKey := fmt.Sprintf("%#x", bytesutil.PadTo([]byte("pubkey4"), 48))
+		unknownKey := fmt.Sprintf("%#x", bytesutil.PadTo([]byte("unknown"), 48))
+		resp, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{
+			StateId: []byte("head"),
+			Id:      [][]byte{[]byte("2"), []byte(pubKey), []byte("2"), []byte("100"), []byte(unknownKey)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, uint64(2), resp.Data[0].Index)
+		assert.Equal(t, uint64(4), resp.Data[1].Index)
+	})
+
+	t.Run("By status", func(t *testing.T) {
+		resp, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{
+			StateId: []byte("head"),
+			Status:  "active,withdrawal_done",
+		})
+		require.NoError(t, err)
+		var indices []uint64
+		for _, v := range resp.Data {
+			indices = append(indices, v.Index)
+		}
+		assert.DeepEqual(t, []uint64{2, 3, 4, 8}, indices)
+	})
+
+	t.Run("By ID and status", func(t *testing.T) {
+		resp, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{
+			StateId: []byte("head"),
+			Id:      [][]byte{[]byte("0"), []byte("5")},
+			Status:  "exited_unslashed",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, uint64(5), resp.Data[0].Index)
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		_, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head"), Status: "active_foo"})
+		assert.ErrorContains(t, "Invalid validator status: active_foo", err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := s.ListValidators(ctx, &ethpb.StateValidatorsRequest{StateId: []byte("head"), Id: [][]byte{[]byte("0x1234")}})
+		assert.ErrorContains(t, "Invalid validator ID: 0x1234", err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGetValidator(t *testing.T) {
+	s := validatorStatusServer(t)
+	ctx := context.Background()
+
+	resp, err := s.GetValidator(ctx, &ethpb.StateValidatorRequest{StateId: []byte("head"), ValidatorId: []byte("6")})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), resp.Data.Index)
+	assert.Equal(t, uint64(7), resp.Data.Balance)
+	assert.Equal(t, statusExitedSlashed, resp.Data.Status)
+	assert.Equal(t, types.Epoch(5), resp.Data.Validator.ExitEpoch)
+
+	_, err = s.GetValidator(ctx, &ethpb.StateValidatorRequest{StateId: []byte("head"), ValidatorId: []byte("9")})
+	assert.ErrorContains(t, "Could not find validator with ID 9", err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListValidatorBalances(t *testing.T) {
+	s := validatorStatusServer(t)
+	ctx := context.Background()
+
+	resp, err := s.ListValidatorBalances(ctx, &ethpb.ValidatorBalancesRequest{StateId: []byte("head")})
+	require.NoError(t, err)
+	require.Equal(t, 9, len(resp.Data))
+	for i, b := range resp.Data {
+		assert.Equal(t, uint64(i), b.Index)
+	}
+	assert.Equal(t, uint64(3), resp.Data[2].Balance)
+	assert.Equal(t, uint64(0), resp.Data[8].Balance)
+
+	pubKey := fmt.Sprintf("%#x", bytesutil.PadTo([]byte("pubkey1"), 48))
+	resp, err = s.ListValidatorBalances(ctx, &ethpb.ValidatorBalancesRequest{
+		StateId: []byte("head"),
+		Id:      []string{"5", pubKey, "100"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Data))
+	assert.DeepEqual(t, &ethpb.ValidatorBalance{Index: 5, Balance: 6}, resp.Data[0])
+	assert.DeepEqual(t, &ethpb.ValidatorBalance{Index: 1, Balance: 2}, resp.Data[1])
+
+	_, err = s.ListValidatorBalances(ctx, &ethpb.ValidatorBalancesRequest{StateId: []byte("head"), Id: []string{"foo"}})
+	assert.ErrorContains(t, "Invalid validator ID: foo", err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListCommittees(t *testing.T) {
+	helpers.ClearCache()
+	state, _ := testutil.DeterministicGenesisState(t, 128)
+	s := &Server{ChainInfoFetcher: &chainMock.ChainService{State: state}}
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	t.Run("All", func(t *testing.T) {
+		resp, err := s.ListCommittees(ctx, &ethpb.StateCommitteesRequest{StateId: []byte("head")})
+		require.NoError(t, err)
+		require.Equal(t, int(slotsPerEpoch), len(resp.Data))
+		seen := make(map[uint64]bool)
+		for i, c := range resp.Data {
+			assert.Equal(t, types.Slot(i), c.Slot)
+			assert.Equal(t, uint64(0), c.Index)
+			want, err := helpers.BeaconCommitteeFromState(state, c.Slot, 0)
+			require.NoError(t, err)
+			require.Equal(t, len(want), len(c.Validators))
+			for j, v := range c.Validators {
+				assert.Equal(t, uint64(want[j]), v)
+				seen[v] = true
+			}
+		}
+		assert.Equal(t, 128, len(seen))
+	})
+
+	t.Run("By slot", func(t *testing.T) {
+		resp, err := s.ListCommittees(ctx, &ethpb.StateCommitteesRequest{StateId: []byte("head"), Slot: slotsPerEpoch + 3})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, slotsPerEpoch+3, resp.Data[0].Slot)
+	})
+
+	t.Run("Unknown index", func(t *testing.T) {
+		resp, err := s.ListCommittees(ctx, &ethpb.StateCommitteesRequest{StateId: []byte("head"), Index: 5})
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+
+	t.Run("Explicit zero filters", func(t *testing.T) {
+		md := metadata.MD{}
+		for _, name := range []string{"epoch", "slot", "index"} {
+			md.Set(grpcutils.RequestParamMetadataPrefix+name, "0")
+		}
+		resp, err := s.ListCommittees(metadata.NewIncomingContext(ctx, md), &ethpb.StateCommitteesRequest{StateId: []byte("head")})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, types.Slot(0), resp.Data[0].Slot)
+		assert.Equal(t, uint64(0), resp.Data[0].Index)
+
+		// An explicit zero epoch is not replaced by the epoch of the slot.
+		md = metadata.Pairs(grpcutils.RequestParamMetadataPrefix+"epoch", "0")
+		_, err = s.ListCommittees(metadata.NewIncomingContext(ctx, md), &ethpb.StateCommitteesRequest{StateId: []byte("head"), Slot: slotsPerEpoch})
+		assert.ErrorContains(t, fmt.Sprintf("Slot %d is not in epoch 0", slotsPerEpoch), err)
+	})
+
+	t.Run("Slot outside epoch", func(t *testing.T) {
+		_, err := s.ListCommittees(ctx, &ethpb.StateCommitteesRequest{StateId: []byte("head"), Epoch: 1, Slot: 1})
+		assert.ErrorContains(t, "Slot 1 is not in epoch 1", err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Epoch too far ahead", func(t *testing.T) {
+		_, err := s.ListCommittees(ctx, &ethpb.StateCommitteesRequest{StateId: []byte("head"), Epoch: 2})
+		assert.ErrorContains(t, "Epoch 2 can not be greater than 1", err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
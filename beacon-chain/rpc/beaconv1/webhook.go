@@ -0,0 +1,102 @@
+package beaconv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+)
+
+const (
+	attesterSlashingWebhookType = "attester_slashing"
+	proposerSlashingWebhookType = "proposer_slashing"
+)
+
+// slashingWebhookTimeout bounds how long a single webhook delivery may take.
+var slashingWebhookTimeout = 5 * time.Second
+
+// slashingWebhookPayload is the JSON summary posted to the slashing webhook URL.
+type slashingWebhookPayload struct {
+	Type           string                 `json:"type"`
+	Root           string                 `json:"root"`
+	SlashedIndices []types.ValidatorIndex `json:"slashed_indices"`
+}
+
+// notifyAttesterSlashing posts a summary of an attester slashing inserted into the pool
+// to the configured webhook, if any.
+func (bs *Server) notifyAttesterSlashing(slashing *eth.AttesterSlashing) {
+	if bs.SlashingWebhookURL == "" {
+		return
+	}
+	root, err := slashing.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Debug("Could not compute attester slashing root for webhook")
+		return
+	}
+	intersection := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+	indices := make([]types.ValidatorIndex, len(intersection))
+	for i, idx := range intersection {
+		indices[i] = types.ValidatorIndex(idx)
+	}
+	bs.postSlashingWebhook(&slashingWebhookPayload{
+		Type:           attesterSlashingWebhookType,
+		Root:           fmt.Sprintf("%#x", root),
+		SlashedIndices: indices,
+	})
+}
+
+// notifyProposerSlashing posts a summary of a proposer slashing inserted into the pool
+// to the configured webhook, if any.
+func (bs *Server) notifyProposerSlashing(slashing *eth.ProposerSlashing) {
+	if bs.SlashingWebhookURL == "" {
+		return
+	}
+	root, err := slashing.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Debug("Could not compute proposer slashing root for webhook")
+		return
+	}
+	bs.postSlashingWebhook(&slashingWebhookPayload{
+		Type:           proposerSlashingWebhookType,
+		Root:           fmt.Sprintf("%#x", root),
+		SlashedIndices: []types.ValidatorIndex{slashing.Header_1.Header.ProposerIndex},
+	})
+}
+
+// postSlashingWebhook delivers the payload in the background. Delivery is best effort:
+// failures are only logged and never propagate to the RPC caller.
+func (bs *Server) postSlashingWebhook(payload *slashingWebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.WithError(err).Debug("Could not marshal slashing webhook payload")
+		return
+	}
+	url := bs.SlashingWebhookURL
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), slashingWebhookTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			log.WithError(err).Debug("Could not create slashing webhook request")
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			log.WithError(err).Warn("Could not deliver slashing webhook")
+			return
+		}
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close slashing webhook response body")
+		}
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			log.WithField("statusCode", resp.StatusCode).Warn("Slashing webhook returned a non-success status")
+		}
+	}()
+}
@@ -0,0 +1,143 @@
+package beaconv1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	chainMock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	p2pMock "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func webhookStub(t *testing.T) (*httptest.Server, chan *slashingWebhookPayload) {
+	received := make(chan *slashingWebhookPayload, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		payload := &slashingWebhookPayload{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(payload))
+		received <- payload
+	}))
+	return srv, received
+}
+
+func TestSubmitProposerSlashing_WebhookNotified(t *testing.T) {
+	ctx := context.Background()
+
+	_, keys, err := testutil.DeterministicDepositsAndKeys(1)
+	require.NoError(t, err)
+	validator := &eth.Validator{
+		ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+		PublicKey:             keys[0].PublicKey().Marshal(),
+		WithdrawalCredentials: make([]byte, 32),
+		WithdrawableEpoch:     types.Epoch(1),
+	}
+	state, err := testutil.NewBeaconState(func(state *pb.BeaconState) {
+		state.Validators = []*eth.Validator{validator}
+	})
+	require.NoError(t, err)
+
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:       1,
+				ParentRoot: bytesutil.PadTo([]byte("parentroot1"), 32),
+				StateRoot:  bytesutil.PadTo([]byte("stateroot1"), 32),
+				BodyRoot:   bytesutil.PadTo([]byte("bodyroot1"), 32),
+			},
+		},
+		Header_2: &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:       1,
+				ParentRoot: bytesutil.PadTo([]byte("parentroot2"), 32),
+				StateRoot:  bytesutil.PadTo([]byte("stateroot2"), 32),
+				BodyRoot:   bytesutil.PadTo([]byte("bodyroot2"), 32),
+			},
+		},
+	}
+	for _, h := range []*ethpb.SignedBeaconBlockHeader{slashing.Header_1, slashing.Header_2} {
+		sb, err := helpers.ComputeDomainAndSign(state, helpers.SlotToEpoch(h.Header.Slot), h.Header, params.BeaconConfig().DomainBeaconProposer, keys[0])
+		require.NoError(t, err)
+		sig, err := bls.SignatureFromBytes(sb)
+		require.NoError(t, err)
+		h.Signature = sig.Marshal()
+	}
+
+	srv, received := webhookStub(t)
+	defer srv.Close()
+	s := &Server{
+		ChainInfoFetcher:   &chainMock.ChainService{State: state},
+		SlashingsPool:      &slashings.PoolMock{},
+		Broadcaster:        &p2pMock.MockBroadcaster{},
+		SlashingWebhookURL: srv.URL,
+	}
+
+	_, err = s.SubmitProposerSlashing(ctx, slashing)
+	require.NoError(t, err)
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, proposerSlashingWebhookType, payload.Type)
+		assert.DeepEqual(t, []types.ValidatorIndex{0}, payload.SlashedIndices)
+		assert.NotEqual(t, "", payload.Root)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook was not notified")
+	}
+}
+
+func TestNotifyAttesterSlashing_SlashedIndices(t *testing.T) {
+	srv, received := webhookStub(t)
+	defer srv.Close()
+	s := &Server{SlashingWebhookURL: srv.URL}
+
+	slashing := &eth.AttesterSlashing{
+		Attestation_1: testutil.HydrateIndexedAttestation(&eth.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3}}),
+		Attestation_2: testutil.HydrateIndexedAttestation(&eth.IndexedAttestation{AttestingIndices: []uint64{2, 3, 4}}),
+	}
+	s.notifyAttesterSlashing(slashing)
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, attesterSlashingWebhookType, payload.Type)
+		assert.DeepEqual(t, []types.ValidatorIndex{2, 3}, payload.SlashedIndices)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook was not notified")
+	}
+}
+
+func TestNotifyProposerSlashing_UnreachableWebhookDoesNotBlock(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+	s := &Server{SlashingWebhookURL: url}
+
+	slashing := &eth.ProposerSlashing{
+		Header_1: testutil.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{}),
+		Header_2: testutil.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{}),
+	}
+	done := make(chan struct{})
+	go func() {
+		s.notifyProposerSlashing(slashing)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Webhook notification blocked the caller")
+	}
+}
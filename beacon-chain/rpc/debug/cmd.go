@@ -0,0 +1,109 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DumpForkChoiceCommand prints the proto array fork choice store of a running beacon node as JSON.
+var DumpForkChoiceCommand = &cli.Command{
+	Name:     "dump-forkchoice",
+	Category: "debug",
+	Usage:    "prints the proto array fork choice store of a running beacon node, which must have the debug gRPC endpoints enabled",
+	Flags: []cli.Flag{
+		flags.RPCHost,
+		flags.RPCPort,
+		flags.CertFlag,
+	},
+	Action: func(cliCtx *cli.Context) error {
+		if err := dumpForkChoice(cliCtx, os.Stdout); err != nil {
+			return errors.Wrap(err, "could not dump fork choice store")
+		}
+		return nil
+	},
+}
+
+type forkChoiceDump struct {
+	PruneThreshold uint64           `json:"prune_threshold"`
+	JustifiedEpoch types.Epoch      `json:"justified_epoch"`
+	FinalizedEpoch types.Epoch      `json:"finalized_epoch"`
+	Nodes          []forkChoiceNode `json:"nodes"`
+}
+
+type forkChoiceNode struct {
+	Index          uint64        `json:"index"`
+	Slot           types.Slot    `json:"slot"`
+	Root           hexutil.Bytes `json:"root"`
+	Parent         uint64        `json:"parent"`
+	JustifiedEpoch types.Epoch   `json:"justified_epoch"`
+	FinalizedEpoch types.Epoch   `json:"finalized_epoch"`
+	Weight         uint64        `json:"weight"`
+	BestChild      uint64        `json:"best_child"`
+	BestDescendant uint64        `json:"best_descendant"`
+}
+
+func dumpForkChoice(cliCtx *cli.Context, w io.Writer) error {
+	dialOpt := grpc.WithInsecure()
+	if cert := cliCtx.String(flags.CertFlag.Name); cert != "" {
+		creds, err := credentials.NewClientTLSFromFile(cert, "")
+		if err != nil {
+			return errors.Wrap(err, "could not load TLS certificate")
+		}
+		dialOpt = grpc.WithTransportCredentials(creds)
+	}
+	endpoint := fmt.Sprintf("%s:%d", cliCtx.String(flags.RPCHost.Name), cliCtx.Int(flags.RPCPort.Name))
+	conn, err := grpc.DialContext(cliCtx.Context, endpoint, dialOpt)
+	if err != nil {
+		return errors.Wrapf(err, "could not dial endpoint: %s", endpoint)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close connection")
+		}
+	}()
+
+	resp, err := pbrpc.NewDebugClient(conn).GetProtoArrayForkChoice(cliCtx.Context, &ptypes.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not get fork choice store")
+	}
+	return writeForkChoice(w, resp)
+}
+
+// writeForkChoice writes the fork choice store as indented JSON, with the nodes in proto array
+// order so that the parent, best child and best descendant indices can be followed.
+func writeForkChoice(w io.Writer, resp *pbrpc.ProtoArrayForkChoiceResponse) error {
+	dump := forkChoiceDump{
+		PruneThreshold: resp.PruneThreshold,
+		JustifiedEpoch: resp.JustifiedEpoch,
+		FinalizedEpoch: resp.FinalizedEpoch,
+		Nodes:          make([]forkChoiceNode, len(resp.ProtoArrayNodes)),
+	}
+	for i, n := range resp.ProtoArrayNodes {
+		dump.Nodes[i] = forkChoiceNode{
+			Index:          uint64(i),
+			Slot:           n.Slot,
+			Root:           n.Root,
+			Parent:         n.Parent,
+			JustifiedEpoch: n.JustifiedEpoch,
+			FinalizedEpoch: n.FinalizedEpoch,
+			Weight:         n.Weight,
+			BestChild:      n.BestChild,
+			BestDescendant: n.BestDescendant,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dump)
+}
@@ -0,0 +1,39 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestWriteForkChoice(t *testing.T) {
+	resp := &pbrpc.ProtoArrayForkChoiceResponse{
+		PruneThreshold: 256,
+		JustifiedEpoch: 1,
+		FinalizedEpoch: 0,
+		ProtoArrayNodes: []*pbrpc.ProtoArrayNode{
+			{Slot: 1, Root: []byte{0x0a}, Parent: ^uint64(0), Weight: 64, BestChild: 1, BestDescendant: 1},
+			{Slot: 2, Root: []byte{0x0b}, Parent: 0, JustifiedEpoch: 1, Weight: 32, BestChild: ^uint64(0), BestDescendant: ^uint64(0)},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeForkChoice(&buf, resp))
+
+	dump := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &dump))
+	assert.Equal(t, float64(256), dump["prune_threshold"])
+	assert.Equal(t, float64(1), dump["justified_epoch"])
+	nodes, ok := dump["nodes"].([]interface{})
+	require.Equal(t, true, ok)
+	require.Equal(t, 2, len(nodes))
+	node := nodes[1].(map[string]interface{})
+	assert.Equal(t, float64(1), node["index"])
+	assert.Equal(t, "0x0b", node["root"])
+	assert.Equal(t, float64(0), node["parent"])
+	assert.Equal(t, float64(32), node["weight"])
+	assert.Equal(t, float64(1), node["justified_epoch"])
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -50,7 +51,7 @@ func (ds *Server) SetLoggingLevel(_ context.Context, req *pbrpc.LoggingLevelRequ
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Could not parse verbosity level")
 	}
-	logrus.SetLevel(level)
+	logutil.SetDefaultLevel(level)
 	if level == logrus.TraceLevel {
 		// Libp2p specific logging.
 		golog.SetAllLoggers(golog.LevelDebug)
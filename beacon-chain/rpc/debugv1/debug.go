@@ -4,16 +4,47 @@ import (
 	"context"
 
 	ptypes "github.com/gogo/protobuf/types"
-	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// GetState returns the full beacon state for a given state id.
-func (bs *Server) GetState(ctx context.Context, req *ethpb.StateRequest) (*ethpb.BeaconStateResponse, error) {
-	return nil, errors.New("unimplemented")
+// GetBeaconState returns the full beacon state for a given state id. States before the head are
+// regenerated by the state gen service, so any state back to genesis can be requested.
+func (bs *Server) GetBeaconState(ctx context.Context, req *ethpb.StateRequest) (*ethpb.BeaconStateResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "debugv1.GetBeaconState")
+	defer span.End()
+
+	state, err := bs.StateFetcher.State(ctx, req.StateId)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, status.Error(codes.NotFound, "State not found")
+	}
+
+	return &ethpb.BeaconStateResponse{
+		Data: migration.Pbp2pToV1State(state.CloneInnerState()),
+	}, nil
 }
 
-// GetForkChoiceHeads retrieves the fork choice leaves for the current head.
-func (bs *Server) GetForkChoiceHeads(ctx context.Context, _ *ptypes.Empty) (*ethpb.ForkChoiceHeadsResponse, error) {
-	return nil, errors.New("unimplemented")
+// ListForkChoiceHeads retrieves the leaves of the fork choice store, which are the heads of all the
+// chains known to the node. The canonical head is one of them.
+func (bs *Server) ListForkChoiceHeads(ctx context.Context, _ *ptypes.Empty) (*ethpb.ForkChoiceHeadsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "debugv1.ListForkChoiceHeads")
+	defer span.End()
+
+	leaves := bs.HeadFetcher.ProtoArrayStore().Leaves()
+	heads := make([]*ethpb.ForkChoiceHead, len(leaves))
+	for i, leaf := range leaves {
+		root := leaf.Root()
+		heads[i] = &ethpb.ForkChoiceHead{
+			Root: root[:],
+			Slot: leaf.Slot(),
+		}
+	}
+
+	return &ethpb.ForkChoiceHeadsResponse{Data: heads}, nil
 }
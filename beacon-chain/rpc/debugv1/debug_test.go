@@ -0,0 +1,79 @@
+package debugv1
+
+import (
+	"context"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockStateFetcher struct {
+	states map[string]*statetrie.BeaconState
+}
+
+func (m *mockStateFetcher) State(_ context.Context, stateId []byte) (*statetrie.BeaconState, error) {
+	st, ok := m.states[string(stateId)]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid state ID: %s", stateId)
+	}
+	return st, nil
+}
+
+func TestGetBeaconState(t *testing.T) {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisState(t, 8)
+	require.NoError(t, st.SetSlot(5))
+	bs := &Server{
+		StateFetcher: &mockStateFetcher{states: map[string]*statetrie.BeaconState{
+			"head":    st,
+			"genesis": nil,
+		}},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		resp, err := bs.GetBeaconState(ctx, &ethpb.StateRequest{StateId: []byte("head")})
+		require.NoError(t, err)
+		assert.Equal(t, st.Slot(), resp.Data.Slot)
+		assert.Equal(t, st.NumValidators(), len(resp.Data.Validators))
+		assert.DeepEqual(t, st.Balances(), resp.Data.Balances)
+	})
+
+	t.Run("Invalid state ID", func(t *testing.T) {
+		_, err := bs.GetBeaconState(ctx, &ethpb.StateRequest{StateId: []byte("foo")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("State not found", func(t *testing.T) {
+		_, err := bs.GetBeaconState(ctx, &ethpb.StateRequest{StateId: []byte("genesis")})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestListForkChoiceHeads(t *testing.T) {
+	ctx := context.Background()
+	fc := protoarray.New(0, 0, [32]byte{'a'})
+	require.NoError(t, fc.ProcessBlock(ctx, 0, [32]byte{'a'}, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, [32]byte{'b'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 2, [32]byte{'c'}, [32]byte{'b'}, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 2, [32]byte{'d'}, [32]byte{'a'}, [32]byte{}, 0, 0))
+	bs := &Server{HeadFetcher: &mock.ChainService{ForkChoiceStore: fc.Store()}}
+
+	resp, err := bs.ListForkChoiceHeads(ctx, &ptypes.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Data))
+	c, d := [32]byte{'c'}, [32]byte{'d'}
+	assert.DeepEqual(t, c[:], resp.Data[0].Root)
+	assert.Equal(t, types.Slot(2), resp.Data[0].Slot)
+	assert.DeepEqual(t, d[:], resp.Data[1].Root)
+	assert.Equal(t, types.Slot(2), resp.Data[1].Slot)
+}
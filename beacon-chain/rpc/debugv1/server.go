@@ -1,18 +1,27 @@
-// Package beaconv1 defines a gRPC beacon service implementation,
+// Package debugv1 defines a gRPC beacon debug service implementation,
 // following the official API standards https://ethereum.github.io/eth2.0-APIs/#/.
-// This package includes the beacon and config endpoints.
+// This package includes the debug endpoints.
 package debugv1
 
 import (
 	"context"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 )
 
-// Server defines a server implementation of the gRPC Beacon Chain service,
-// providing RPC endpoints to access data relevant to the Ethereum 2.0 phase 0
-// beacon chain.
+// StateFetcher resolves the state IDs accepted by the standard API to beacon states.
+type StateFetcher interface {
+	State(ctx context.Context, stateId []byte) (*statetrie.BeaconState, error)
+}
+
+// Server defines a server implementation of the gRPC Beacon Debug service,
+// providing RPC endpoints to access data relevant to debugging the Ethereum 2.0
+// phase 0 beacon chain.
 type Server struct {
-	Ctx      context.Context
-	BeaconDB db.ReadOnlyDatabase
+	Ctx          context.Context
+	BeaconDB     db.ReadOnlyDatabase
+	HeadFetcher  blockchain.HeadFetcher
+	StateFetcher StateFetcher
 }
@@ -3,6 +3,7 @@ package nodev1
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
@@ -72,49 +74,32 @@ func (ns *Server) GetIdentity(ctx context.Context, _ *ptypes.Empty) (*ethpb.Iden
 	}, nil
 }
 
-// GetPeer retrieves data about the given peer.
+// GetPeer retrieves data about the given peer. The peer ID is accepted in its base58 text form, as
+// returned by ListPeers and GetIdentity, as well as in its binary form.
 func (ns *Server) GetPeer(ctx context.Context, req *ethpb.PeerRequest) (*ethpb.PeerResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "nodev1.GetPeer")
 	defer span.End()
 
 	peerStatus := ns.PeersFetcher.Peers()
-	id, err := peer.IDFromString(req.PeerId)
+	id, err := peer.Decode(req.PeerId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "Invalid peer ID: "+req.PeerId)
+		id, err = peer.IDFromString(req.PeerId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid peer ID: "+req.PeerId)
+		}
 	}
-	enr, err := peerStatus.ENR(id)
-	if err != nil {
+	if _, err := peerStatus.ConnectionState(id); err != nil {
 		if errors.Is(err, peerdata.ErrPeerUnknown) {
 			return nil, status.Error(codes.NotFound, "Peer not found")
 		}
-		return nil, status.Errorf(codes.Internal, "Could not obtain ENR: %v", err)
-	}
-	serializedEnr, err := p2p.SerializeENR(enr)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not obtain ENR: %v", err)
-	}
-	p2pAddress, err := peerStatus.Address(id)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not obtain address: %v", err)
-	}
-	state, err := peerStatus.ConnectionState(id)
-	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not obtain connection state: %v", err)
 	}
-	direction, err := peerStatus.Direction(id)
+	p, err := peerInfo(peerStatus, id)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not obtain direction: %v", err)
+		return nil, err
 	}
 
-	return &ethpb.PeerResponse{
-		Data: &ethpb.Peer{
-			PeerId:    req.PeerId,
-			Enr:       "enr:" + serializedEnr,
-			Address:   p2pAddress.String(),
-			State:     ethpb.ConnectionState(state),
-			Direction: ethpb.PeerDirection(direction),
-		},
-	}, nil
+	return &ethpb.PeerResponse{Data: p}, nil
 }
 
 // ListPeers retrieves data about the node's network peers.
@@ -187,9 +172,14 @@ func (ns *Server) ListPeers(ctx context.Context, req *ethpb.PeersRequest) (*ethp
 	}
 
 	var filteredIds []peer.ID
+	seen := make(map[peer.ID]bool, len(stateIds))
 	for _, stateId := range stateIds {
+		if seen[stateId] {
+			continue
+		}
 		for _, directionId := range directionIds {
-			if stateId.Pretty() == directionId.Pretty() {
+			if stateId == directionId {
+				seen[stateId] = true
 				filteredIds = append(filteredIds, stateId)
 				break
 			}
@@ -264,10 +254,16 @@ func (ns *Server) GetHealth(ctx context.Context, _ *ptypes.Empty) (*ptypes.Empty
 	ctx, span := trace.StartSpan(ctx, "nodev1.GetHealth")
 	defer span.End()
 
-	if ns.SyncChecker.Syncing() || ns.SyncChecker.Initialized() {
+	if ns.SyncChecker.Syncing() {
+		if err := grpcutils.SetHTTPCode(ctx, http.StatusPartialContent); err != nil {
+			return &ptypes.Empty{}, status.Errorf(codes.Internal, "Could not set HTTP code: %v", err)
+		}
 		return &ptypes.Empty{}, nil
 	}
-	return &ptypes.Empty{}, status.Error(codes.Internal, "Node not initialized or having issues")
+	if ns.SyncChecker.Initialized() {
+		return &ptypes.Empty{}, nil
+	}
+	return &ptypes.Empty{}, status.Error(codes.Unavailable, "Node not initialized or having issues")
 }
 
 func (ns *Server) handleEmptyFilters(req *ethpb.PeersRequest, peerStatus *peers.Status) (emptyState, emptyDirection bool) {
@@ -295,19 +291,30 @@ func (ns *Server) handleEmptyFilters(req *ethpb.PeersRequest, peerStatus *peers.
 	return emptyState, emptyDirection
 }
 
+// peerInfo returns the data of a known peer. Peers that connected to the node without being
+// discovered have no ENR, and peers that were discovered but never connected have no address; the
+// respective fields are left empty for them.
 func peerInfo(peerStatus *peers.Status, id peer.ID) (*ethpb.Peer, error) {
 	enr, err := peerStatus.ENR(id)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not obtain ENR: %v", err)
 	}
-	serializedEnr, err := p2p.SerializeENR(enr)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not serialize ENR: %v", err)
+	var serializedEnr string
+	if enr != nil {
+		serializedEnr, err = p2p.SerializeENR(enr)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not serialize ENR: %v", err)
+		}
+		serializedEnr = "enr:" + serializedEnr
 	}
 	address, err := peerStatus.Address(id)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not obtain address: %v", err)
 	}
+	var p2pAddress string
+	if address != nil {
+		p2pAddress = address.String()
+	}
 	connectionState, err := peerStatus.ConnectionState(id)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not obtain connection state: %v", err)
@@ -318,8 +325,8 @@ func peerInfo(peerStatus *peers.Status, id peer.ID) (*ethpb.Peer, error) {
 	}
 	p := ethpb.Peer{
 		PeerId:    id.Pretty(),
-		Enr:       "enr:" + serializedEnr,
-		Address:   address.String(),
+		Enr:       serializedEnr,
+		Address:   p2pAddress,
 		State:     ethpb.ConnectionState(connectionState),
 		Direction: ethpb.PeerDirection(direction),
 	}
@@ -3,6 +3,7 @@ package nodev1
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
 	"strconv"
 	"strings"
@@ -24,10 +25,15 @@ import (
 	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	syncmock "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	"github.com/prysmaticlabs/prysm/shared/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type dummyIdentity enode.ID
@@ -56,12 +62,31 @@ func TestGetHealth(t *testing.T) {
 
 	_, err := s.GetHealth(ctx, &ptypes.Empty{})
 	require.ErrorContains(t, "Node not initialized or having issues", err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
 	checker.IsInitialized = true
-	_, err = s.GetHealth(ctx, &ptypes.Empty{})
+	stream := &headerStream{}
+	_, err = s.GetHealth(grpc.NewContextWithServerTransportStream(ctx, stream), &ptypes.Empty{})
 	require.NoError(t, err)
+	_, ok := grpcutils.HTTPCode(stream.header)
+	assert.Equal(t, false, ok)
 	checker.IsInitialized = false
 	checker.IsSyncing = true
+	_, err = s.GetHealth(grpc.NewContextWithServerTransportStream(ctx, stream), &ptypes.Empty{})
 	require.NoError(t, err)
+	code, ok := grpcutils.HTTPCode(stream.header)
+	require.Equal(t, true, ok)
+	assert.Equal(t, http.StatusPartialContent, code)
+}
+
+// headerStream records the response header set by a server method.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
 }
 
 func TestGetIdentity(t *testing.T) {
@@ -175,7 +200,7 @@ func TestGetPeer(t *testing.T) {
 	ctx := context.Background()
 	decodedId, err := peer.Decode("16Uiu2HAkvyYtoQXZNTsthjgLHjEnv7kvwzEmjvsJjWXpbhtqpSUN")
 	require.NoError(t, err)
-	peerId := string(decodedId)
+	peerId := decodedId.Pretty()
 	enrRecord := &enr.Record{}
 	err = enrRecord.SetSig(dummyIdentity{1}, []byte{42})
 	require.NoError(t, err)
@@ -199,6 +224,22 @@ func TestGetPeer(t *testing.T) {
 		assert.Equal(t, ethpb.PeerDirection_INBOUND, resp.Data.Direction)
 	})
 
+	t.Run("Binary ID", func(t *testing.T) {
+		resp, err := s.GetPeer(ctx, &ethpb.PeerRequest{PeerId: string(decodedId)})
+		require.NoError(t, err)
+		assert.Equal(t, peerId, resp.Data.PeerId)
+	})
+
+	t.Run("No ENR", func(t *testing.T) {
+		id := libp2ptest.GeneratePeerIDs(1)[0]
+		peerFetcher.Peers().Add(nil, id, p2pMultiAddr, network.DirOutbound)
+		resp, err := s.GetPeer(ctx, &ethpb.PeerRequest{PeerId: id.Pretty()})
+		require.NoError(t, err)
+		assert.Equal(t, "", resp.Data.Enr)
+		assert.Equal(t, p2pAddr, resp.Data.Address)
+		assert.Equal(t, ethpb.PeerDirection_OUTBOUND, resp.Data.Direction)
+	})
+
 	t.Run("Invalid ID", func(t *testing.T) {
 		_, err = s.GetPeer(ctx, &ethpb.PeerRequest{PeerId: "foo"})
 		assert.ErrorContains(t, "Invalid peer ID: foo", err)
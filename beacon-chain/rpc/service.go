@@ -30,9 +30,11 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beacon"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beaconv1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/debug"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/debugv1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/node"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/nodev1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validatorv1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -40,11 +42,12 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/ratelimit"
+	"github.com/prysmaticlabs/prysm/shared/tlsutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
 )
@@ -80,6 +83,7 @@ type Service struct {
 	listener                net.Listener
 	withCert                string
 	withKey                 string
+	withClientCA            string
 	grpcServer              *grpc.Server
 	canonicalStateChan      chan *pbp2p.BeaconState
 	incomingAttestation     chan *ethpb.Attestation
@@ -97,6 +101,11 @@ type Service struct {
 	connectedRPCClients     map[net.Addr]bool
 	clientConnectionLock    sync.Mutex
 	maxMsgSize              int
+	slashingWebhookURL      string
+	maxBatchSize            uint64
+	rateLimiter             *ratelimit.Limiter
+	slashingReplayCache     *beaconv1.SlashingReplayCache
+	verifyExitsAgainstPool  bool
 }
 
 // Config options for the beacon node RPC server.
@@ -105,6 +114,7 @@ type Config struct {
 	Port                    string
 	CertFlag                string
 	KeyFlag                 string
+	ClientCAFlag            string
 	BeaconMonitoringHost    string
 	BeaconMonitoringPort    int
 	BeaconDB                db.HeadAccessDatabase
@@ -136,6 +146,11 @@ type Config struct {
 	OperationNotifier       opfeed.Notifier
 	StateGen                *stategen.State
 	MaxMsgSize              int
+	SlashingWebhookURL      string
+	MaxBatchSize            uint64
+	RateLimiter             *ratelimit.Limiter
+	SlashingReplayCache     *beaconv1.SlashingReplayCache
+	VerifyExitsAgainstPool  bool
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -172,6 +187,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		beaconMonitoringPort:    cfg.BeaconMonitoringPort,
 		withCert:                cfg.CertFlag,
 		withKey:                 cfg.KeyFlag,
+		withClientCA:            cfg.ClientCAFlag,
 		depositFetcher:          cfg.DepositFetcher,
 		pendingDepositFetcher:   cfg.PendingDepositFetcher,
 		canonicalStateChan:      make(chan *pbp2p.BeaconState, params.BeaconConfig().DefaultBufferSize),
@@ -183,6 +199,11 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		enableDebugRPCEndpoints: cfg.EnableDebugRPCEndpoints,
 		connectedRPCClients:     make(map[net.Addr]bool),
 		maxMsgSize:              cfg.MaxMsgSize,
+		slashingWebhookURL:      cfg.SlashingWebhookURL,
+		maxBatchSize:            cfg.MaxBatchSize,
+		rateLimiter:             cfg.RateLimiter,
+		slashingReplayCache:     cfg.SlashingReplayCache,
+		verifyExitsAgainstPool:  cfg.VerifyExitsAgainstPool,
 	}
 }
 
@@ -196,34 +217,46 @@ func (s *Service) Start() {
 	s.listener = lis
 	log.WithField("address", address).Info("gRPC server listening on port")
 
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		recovery.StreamServerInterceptor(
+			recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
+		),
+		grpc_prometheus.StreamServerInterceptor,
+		grpc_opentracing.StreamServerInterceptor(),
+		s.validatorStreamConnectionInterceptor,
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		recovery.UnaryServerInterceptor(
+			recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
+		),
+		grpc_prometheus.UnaryServerInterceptor,
+		grpc_opentracing.UnaryServerInterceptor(),
+		s.validatorUnaryConnectionInterceptor,
+	}
+	if s.rateLimiter != nil {
+		streamInterceptors = append(streamInterceptors, s.rateLimiter.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, s.rateLimiter.UnaryServerInterceptor())
+	}
 	opts := []grpc.ServerOption{
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
-		grpc.StreamInterceptor(middleware.ChainStreamServer(
-			recovery.StreamServerInterceptor(
-				recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
-			),
-			grpc_prometheus.StreamServerInterceptor,
-			grpc_opentracing.StreamServerInterceptor(),
-			s.validatorStreamConnectionInterceptor,
-		)),
-		grpc.UnaryInterceptor(middleware.ChainUnaryServer(
-			recovery.UnaryServerInterceptor(
-				recovery.WithRecoveryHandlerContext(traceutil.RecoveryHandlerFunc),
-			),
-			grpc_prometheus.UnaryServerInterceptor,
-			grpc_opentracing.UnaryServerInterceptor(),
-			s.validatorUnaryConnectionInterceptor,
-		)),
+		grpc.StreamInterceptor(middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(middleware.ChainUnaryServer(unaryInterceptors...)),
 		grpc.MaxRecvMsgSize(s.maxMsgSize),
 	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	if s.withCert != "" && s.withKey != "" {
-		creds, err := credentials.NewServerTLSFromFile(s.withCert, s.withKey)
+		creds, err := tlsutil.ServerCredentials(s.withCert, s.withKey, s.withClientCA)
 		if err != nil {
 			log.WithError(err).Fatal("Could not load TLS keys")
 		}
 		opts = append(opts, grpc.Creds(creds))
+		if s.withClientCA != "" {
+			log.Info("Requiring gRPC clients to present a TLS certificate")
+		}
 	} else {
+		if s.withClientCA != "" {
+			log.Fatal("A TLS certificate and key are required to verify client certificates")
+		}
 		log.Warn("You are using an insecure gRPC server. If you are running your beacon node and " +
 			"validator on the same machines, you can ignore this message. If you want to know " +
 			"how to enable secure connections, see: https://docs.prylabs.network/docs/prysm-usage/secure-grpc")
@@ -305,28 +338,45 @@ func (s *Service) Start() {
 		CollectedAttestationsBuffer: make(chan []*ethpb.Attestation, attestationBufferSize),
 	}
 	beaconChainServerV1 := &beaconv1.Server{
-		Ctx:                 s.ctx,
-		BeaconDB:            s.beaconDB,
-		AttestationsPool:    s.attestationsPool,
-		SlashingsPool:       s.slashingsPool,
-		ChainInfoFetcher:    s.chainInfoFetcher,
-		ChainStartFetcher:   s.chainStartFetcher,
-		DepositFetcher:      s.depositFetcher,
-		BlockFetcher:        s.powChainService,
-		CanonicalStateChan:  s.canonicalStateChan,
-		GenesisTimeFetcher:  s.timeFetcher,
-		StateNotifier:       s.stateNotifier,
-		BlockNotifier:       s.blockNotifier,
-		AttestationNotifier: s.operationNotifier,
-		Broadcaster:         s.p2p,
-		StateGenService:     s.stateGen,
-		SyncChecker:         s.syncService,
+		Ctx:                    s.ctx,
+		BeaconDB:               s.beaconDB,
+		AttestationsPool:       s.attestationsPool,
+		SlashingsPool:          s.slashingsPool,
+		VoluntaryExitsPool:     s.exitPool,
+		ChainInfoFetcher:       s.chainInfoFetcher,
+		ChainStartFetcher:      s.chainStartFetcher,
+		DepositFetcher:         s.depositFetcher,
+		BlockFetcher:           s.powChainService,
+		CanonicalStateChan:     s.canonicalStateChan,
+		GenesisTimeFetcher:     s.timeFetcher,
+		StateNotifier:          s.stateNotifier,
+		BlockNotifier:          s.blockNotifier,
+		AttestationNotifier:    s.operationNotifier,
+		Broadcaster:            s.p2p,
+		StateGenService:        s.stateGen,
+		SyncChecker:            s.syncService,
+		SlashingWebhookURL:     s.slashingWebhookURL,
+		MaxBatchSize:           s.maxBatchSize,
+		SlashingReplayCache:    s.slashingReplayCache,
+		VerifyExitsAgainstPool: s.verifyExitsAgainstPool,
+	}
+	validatorServerV1 := &validatorv1.Server{
+		Ctx:                     s.ctx,
+		BeaconDB:                s.beaconDB,
+		HeadFetcher:             s.headFetcher,
+		TimeFetcher:             s.timeFetcher,
+		SyncChecker:             s.syncService,
+		AttestationsPool:        s.attestationsPool,
+		Broadcaster:             s.p2p,
+		BlockProducer:           validatorServer,
+		AttestationDataProducer: validatorServer,
 	}
 	ethpb.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbv1.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
 	pbrpc.RegisterHealthServer(s.grpcServer, nodeServer)
 	ethpb.RegisterBeaconChainServer(s.grpcServer, beaconChainServer)
 	ethpbv1.RegisterBeaconChainServer(s.grpcServer, beaconChainServerV1)
+	beaconv1.RegisterPrysmBeaconChainServer(s.grpcServer, beaconChainServerV1)
 	if s.enableDebugRPCEndpoints {
 		log.Info("Enabled debug gRPC endpoints")
 		debugServer := &debug.Server{
@@ -338,8 +388,16 @@ func (s *Service) Start() {
 			PeersFetcher:       s.peersFetcher,
 		}
 		pbrpc.RegisterDebugServer(s.grpcServer, debugServer)
+		debugServerV1 := &debugv1.Server{
+			Ctx:          s.ctx,
+			BeaconDB:     s.beaconDB,
+			HeadFetcher:  s.headFetcher,
+			StateFetcher: beaconChainServerV1,
+		}
+		ethpbv1.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
 	}
 	ethpb.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+	ethpbv1.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
@@ -0,0 +1,40 @@
+// Package validatorv1 defines a gRPC validator service implementation,
+// following the official API standards https://ethereum.github.io/eth2.0-APIs/#/.
+// This package includes the duties and validator client endpoints.
+package validatorv1
+
+import (
+	"context"
+
+	ethpbalpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+)
+
+// Server defines a server implementation of the gRPC Beacon Validator service,
+// providing RPC endpoints for validator clients to obtain their duties and to
+// produce and submit blocks and attestations.
+type Server struct {
+	Ctx                     context.Context
+	BeaconDB                db.ReadOnlyDatabase
+	HeadFetcher             blockchain.HeadFetcher
+	TimeFetcher             blockchain.TimeFetcher
+	SyncChecker             sync.Checker
+	AttestationsPool        attestations.Pool
+	Broadcaster             p2p.Broadcaster
+	BlockProducer           BlockProducer
+	AttestationDataProducer AttestationDataProducer
+}
+
+// BlockProducer produces unsigned blocks through the v1alpha1 proposer implementation.
+type BlockProducer interface {
+	GetBlock(ctx context.Context, req *ethpbalpha.BlockRequest) (*ethpbalpha.BeaconBlock, error)
+}
+
+// AttestationDataProducer produces attestation data through the v1alpha1 attester implementation.
+type AttestationDataProducer interface {
+	GetAttestationData(ctx context.Context, req *ethpbalpha.AttestationDataRequest) (*ethpbalpha.AttestationData, error)
+}
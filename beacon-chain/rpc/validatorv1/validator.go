@@ -0,0 +1,529 @@
+package validatorv1
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	ethpbalpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	statetrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dependentRootField is the top level response field of the duties endpoints which identifies the
+// block the duties were computed from.
+const dependentRootField = "dependent_root"
+
+// GetAttesterDuties requests the beacon node to provide a set of attestation duties, which should be
+// performed by validators, for a particular epoch. Duties can be requested up to the next epoch.
+// Validators without a committee assignment in the epoch are left out of the response. The
+// dependent root of the duties is the block root at the last slot of the epoch before the previous
+// epoch, or the genesis block root for the first two epochs; when it changes, the duties need to be
+// requested again.
+func (vs *Server) GetAttesterDuties(ctx context.Context, req *ethpb.AttesterDutiesRequest) (*ethpb.AttesterDutiesResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.GetAttesterDuties")
+	defer span.End()
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	currentEpoch := helpers.SlotToEpoch(vs.TimeFetcher.CurrentSlot())
+	if req.Epoch > currentEpoch+1 {
+		return nil, status.Errorf(codes.InvalidArgument, "Request epoch %d can not be greater than next epoch %d", req.Epoch, currentEpoch+1)
+	}
+
+	s, err := vs.dutiesState(ctx, req.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range req.Index {
+		if uint64(index) >= uint64(s.NumValidators()) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator index %d", index)
+		}
+	}
+
+	activeCount, err := helpers.ActiveValidatorCount(s, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get active validator count: %v", err)
+	}
+	committeesAtSlot := helpers.SlotCommitteeCount(activeCount)
+	assignments, _, err := helpers.CommitteeAssignments(s, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
+	}
+
+	duties := make([]*ethpb.AttesterDuty, 0, len(req.Index))
+	for _, index := range req.Index {
+		assignment, ok := assignments[index]
+		if !ok {
+			continue
+		}
+		pubkey := s.PubkeyAtIndex(index)
+		duty := &ethpb.AttesterDuty{
+			Pubkey:           pubkey[:],
+			ValidatorIndex:   index,
+			CommitteeIndex:   assignment.CommitteeIndex,
+			CommitteeLength:  uint64(len(assignment.Committee)),
+			CommitteesAtSlot: committeesAtSlot,
+			Slot:             assignment.AttesterSlot,
+		}
+		for i, member := range assignment.Committee {
+			if member == index {
+				duty.ValidatorCommitteeIndex = types.CommitteeIndex(i)
+				break
+			}
+		}
+		duties = append(duties, duty)
+	}
+
+	var dependentSlot types.Slot
+	if req.Epoch > 1 {
+		startSlot, err := helpers.StartSlot(req.Epoch - 1)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get start slot of epoch %d: %v", req.Epoch-1, err)
+		}
+		dependentSlot = startSlot - 1
+	}
+	if err := vs.setDependentRoot(ctx, s, dependentSlot); err != nil {
+		return nil, err
+	}
+
+	return &ethpb.AttesterDutiesResponse{Data: duties}, nil
+}
+
+// GetProposerDuties requests beacon node to provide all validators that are scheduled to propose a block in the given epoch.
+// Proposers are only known for the current epoch, as they depend on the effective balances at its
+// start. The proposer indices of an epoch are precomputed into the proposer indices cache on the first
+// lookup, so the schedule is not recomputed per request. The dependent root of the duties is the block
+// root at the last slot of the previous epoch, or the genesis block root for the genesis epoch.
+func (vs *Server) GetProposerDuties(ctx context.Context, req *ethpb.ProposerDutiesRequest) (*ethpb.ProposerDutiesResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.GetProposerDuties")
+	defer span.End()
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	currentEpoch := helpers.SlotToEpoch(vs.TimeFetcher.CurrentSlot())
+	if req.Epoch > currentEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Request epoch %d can not be greater than current epoch %d", req.Epoch, currentEpoch)
+	}
+
+	s, err := vs.dutiesState(ctx, req.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if headEpoch := helpers.CurrentEpoch(s); req.Epoch < headEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Request epoch %d can not be before head epoch %d", req.Epoch, headEpoch)
+	}
+
+	startSlot, err := helpers.StartSlot(req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get start slot of epoch %d: %v", req.Epoch, err)
+	}
+	var dependentSlot types.Slot
+	if startSlot > 0 {
+		dependentSlot = startSlot - 1
+	}
+	if err := vs.setDependentRoot(ctx, s, dependentSlot); err != nil {
+		return nil, err
+	}
+
+	duties := make([]*ethpb.ProposerDuty, 0, params.BeaconConfig().SlotsPerEpoch)
+	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch; slot++ {
+		// The genesis block has no proposer.
+		if slot == 0 {
+			continue
+		}
+		if err := s.SetSlot(slot); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not set slot: %v", err)
+		}
+		index, err := helpers.BeaconProposerIndex(s)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get proposer at slot %d: %v", slot, err)
+		}
+		pubkey := s.PubkeyAtIndex(index)
+		duties = append(duties, &ethpb.ProposerDuty{
+			Pubkey:         pubkey[:],
+			ValidatorIndex: index,
+			Slot:           slot,
+		})
+	}
+
+	return &ethpb.ProposerDutiesResponse{Data: duties}, nil
+}
+
+// GetBlock requests the beacon node to produce a valid unsigned beacon block, which can then be signed by a proposer and submitted.
+// The block is produced by the v1alpha1 proposer, and the graffiti defaults to zero bytes when omitted.
+func (vs *Server) GetBlock(ctx context.Context, req *ethpb.ProposerBlockRequest) (*ethpb.ProposerBlockResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.GetBlock")
+	defer span.End()
+
+	randaoReveal, ok := decodeBytesParam(req.RandaoReveal, params.BeaconConfig().BLSSignatureLength)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid randao reveal")
+	}
+	var graffiti []byte
+	if len(req.Graffiti) > 0 {
+		graffiti, ok = decodeBytesParam(req.Graffiti, 32)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "Invalid graffiti")
+		}
+	}
+
+	v1alpha1Block, err := vs.BlockProducer.GetBlock(ctx, &ethpbalpha.BlockRequest{
+		Slot:         req.Slot,
+		RandaoReveal: randaoReveal,
+		Graffiti:     graffiti,
+	})
+	if err != nil {
+		return nil, err
+	}
+	v1Block, err := migration.V1Alpha1ToV1Block(&ethpbalpha.SignedBeaconBlock{Block: v1alpha1Block})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not convert block: %v", err)
+	}
+	return &ethpb.ProposerBlockResponse{Data: v1Block.Block}, nil
+}
+
+// GetAttestationData requests that the beacon node produces attestation data for
+// the requested committee index and slot based on the nodes current head.
+// The data is produced by the v1alpha1 attester from the head, which is rewound to the requested slot
+// when it is newer, so the target and source checkpoints match the chain the attestation votes for.
+func (vs *Server) GetAttestationData(ctx context.Context, req *ethpb.AttestationDataRequest) (*ethpb.AttestationDataResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.GetAttestationData")
+	defer span.End()
+
+	v1alpha1Data, err := vs.AttestationDataProducer.GetAttestationData(ctx, &ethpbalpha.AttestationDataRequest{
+		Slot:           req.Slot,
+		CommitteeIndex: req.CommitteeIndex,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.AttestationDataResponse{Data: migration.V1Alpha1AttDataToV1(v1alpha1Data)}, nil
+}
+
+// GetAggregateAttestation aggregates all attestations matching the given attestation data root and slot, returning the aggregated result.
+// The unaggregated attestations of the committee are aggregated in the pool first, and the aggregate with the
+// most attesters is returned.
+func (vs *Server) GetAggregateAttestation(ctx context.Context, req *ethpb.AggregateAttestationRequest) (*ethpb.AttestationResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.GetAggregateAttestation")
+	defer span.End()
+
+	dataRoot, ok := decodeBytesParam(req.AttestationDataRoot, 32)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid attestation data root")
+	}
+	unaggregated, err := vs.AttestationsPool.UnaggregatedAttestations()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get unaggregated attestations: %v", err)
+	}
+	match, err := bestAttestation(append(vs.AttestationsPool.AggregatedAttestations(), unaggregated...), req.Slot, dataRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not match attestations: %v", err)
+	}
+	if match == nil {
+		return nil, status.Error(codes.NotFound, "No matching attestation found")
+	}
+
+	committeeIndex := match.Data.CommitteeIndex
+	if err := vs.AttestationsPool.AggregateUnaggregatedAttestationsBySlotIndex(req.Slot, committeeIndex); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not aggregate unaggregated attestations: %v", err)
+	}
+	best, err := bestAttestation(vs.AttestationsPool.AggregatedAttestationsBySlotIndex(req.Slot, committeeIndex), req.Slot, dataRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not match attestations: %v", err)
+	}
+	if best == nil || best.AggregationBits.Count() < match.AggregationBits.Count() {
+		best = match
+	}
+
+	return &ethpb.AttestationResponse{Data: migration.V1Alpha1AttestationToV1(best)}, nil
+}
+
+// SubmitAggregateAndProofs verifies given aggregate and proofs and publishes them on appropriate gossipsub topic.
+// The request only holds the unsigned aggregate and proofs, so their signatures are passed as request metadata
+// in the order of the items, as the gateway does for the signed items of the JSON request. Every item, including
+// its selection proof, aggregator signature and aggregate signature, is verified before any is broadcast.
+func (vs *Server) SubmitAggregateAndProofs(ctx context.Context, req *ethpb.AggregateAndProofsSubmit) (*ptypes.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.SubmitAggregateAndProofs")
+	defer span.End()
+
+	if len(req.Data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No aggregate and proofs to submit")
+	}
+	signatures := grpcutils.Signatures(ctx)
+	if len(signatures) != len(req.Data) {
+		return nil, status.Errorf(codes.InvalidArgument, "Expected %d signatures, received %d", len(req.Data), len(signatures))
+	}
+	s, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+
+	signed := make([]*ethpbalpha.SignedAggregateAttestationAndProof, len(req.Data))
+	for i, item := range req.Data {
+		sig, ok := decodeBytesParam([]byte(signatures[i]), params.BeaconConfig().BLSSignatureLength)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid signature of aggregate and proof %d", i)
+		}
+		if err := vs.verifyAggregateAndProof(s, item); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid aggregate and proof %d: %v", i, err)
+		}
+		signed[i] = &ethpbalpha.SignedAggregateAttestationAndProof{
+			Message: &ethpbalpha.AggregateAttestationAndProof{
+				AggregatorIndex: item.AggregatorIndex,
+				Aggregate:       migration.V1AttestationToV1Alpha1(item.Aggregate),
+				SelectionProof:  item.SelectionProof,
+			},
+			Signature: sig,
+		}
+		if err := verifyAggregateAndProofSignatures(ctx, s, signed[i]); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid aggregate and proof %d: %v", i, err)
+		}
+	}
+	for _, aggregate := range signed {
+		if err := vs.Broadcaster.Broadcast(ctx, aggregate); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast aggregate and proof: %v", err)
+		}
+	}
+
+	return &ptypes.Empty{}, nil
+}
+
+// SubmitBeaconCommitteeSubscription searches using discv5 for peers related to the provided subnet information
+// and replaces current peers with those ones if necessary.
+// The subnets are computed from the committee count given with each subscription and are added to the
+// subnet cache, from which the p2p service subscribes to the attestation subnets of upcoming slots.
+func (vs *Server) SubmitBeaconCommitteeSubscription(ctx context.Context, req *ethpb.BeaconCommitteeSubscribeSubmit) (*ptypes.Empty, error) {
+	ctx, span := trace.StartSpan(ctx, "validatorv1.SubmitBeaconCommitteeSubscription")
+	defer span.End()
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	if len(req.Data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No subscriptions provided")
+	}
+	for i, sub := range req.Data {
+		if sub.CommitteesAtSlot == 0 || sub.CommitteesAtSlot > params.BeaconConfig().MaxCommitteesPerSlot {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid committees at slot %d of subscription %d", sub.CommitteesAtSlot, i)
+		}
+		if uint64(sub.CommitteeIndex) >= sub.CommitteesAtSlot {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid committee index %d of subscription %d", sub.CommitteeIndex, i)
+		}
+	}
+
+	for _, sub := range req.Data {
+		subnet := committeeSubnet(sub.CommitteesAtSlot, sub.CommitteeIndex, sub.Slot)
+		cache.SubnetIDs.AddAttesterSubnetID(sub.Slot, subnet)
+		if sub.IsAggregator {
+			cache.SubnetIDs.AddAggregatorSubnetID(sub.Slot, subnet)
+		}
+	}
+
+	return &ptypes.Empty{}, nil
+}
+
+// dutiesState returns the head state, advanced with empty slots to the start of the epoch if the
+// head is in an earlier epoch.
+func (vs *Server) dutiesState(ctx context.Context, epoch types.Epoch) (*statetrie.BeaconState, error) {
+	s, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	epochStartSlot, err := helpers.StartSlot(epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get start slot of epoch %d: %v", epoch, err)
+	}
+	if s.Slot() < epochStartSlot {
+		s, err = state.ProcessSlots(ctx, s, epochStartSlot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", epochStartSlot, err)
+		}
+	}
+	return s, nil
+}
+
+// setDependentRoot passes the block root at the slot to the gateway as the dependent root of the
+// response. The genesis block root is read from the database, as a state at the genesis slot can not
+// look up the root of its own block.
+func (vs *Server) setDependentRoot(ctx context.Context, s *statetrie.BeaconState, slot types.Slot) error {
+	var root []byte
+	if slot == 0 {
+		genesisBlock, err := vs.BeaconDB.GenesisBlock(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not get genesis block: %v", err)
+		}
+		if err := helpers.VerifyNilBeaconBlock(genesisBlock); err != nil {
+			return status.Errorf(codes.Internal, "Could not get genesis block: %v", err)
+		}
+		genesisRoot, err := genesisBlock.Block.HashTreeRoot()
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not hash genesis block: %v", err)
+		}
+		root = genesisRoot[:]
+	} else {
+		var err error
+		root, err = helpers.BlockRootAtSlot(s, slot)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not get block root at slot %d: %v", slot, err)
+		}
+	}
+	if err := grpcutils.SetResponseField(ctx, dependentRootField, hexutil.Encode(root)); err != nil {
+		return status.Errorf(codes.Internal, "Could not set dependent root: %v", err)
+	}
+	return nil
+}
+
+// verifyAggregateAndProof checks that the aggregate is timely and that its aggregator is a member of the
+// committee selected as aggregator by the selection proof.
+func (vs *Server) verifyAggregateAndProof(s *statetrie.BeaconState, item *ethpb.AggregateAttestationAndProof) error {
+	if item.Aggregate == nil || item.Aggregate.Data == nil || item.Aggregate.Data.Source == nil || item.Aggregate.Data.Target == nil {
+		return errors.New("aggregate has no data")
+	}
+	if len(item.SelectionProof) != params.BeaconConfig().BLSSignatureLength ||
+		bytes.Equal(item.SelectionProof, make([]byte, params.BeaconConfig().BLSSignatureLength)) {
+		return errors.New("invalid selection proof")
+	}
+	data := item.Aggregate.Data
+	if err := helpers.ValidateAttestationTime(data.Slot, vs.TimeFetcher.GenesisTime()); err != nil {
+		return err
+	}
+	committee, err := helpers.BeaconCommitteeFromState(s, data.Slot, data.CommitteeIndex)
+	if err != nil {
+		return errors.Wrap(err, "could not get committee")
+	}
+	if item.Aggregate.AggregationBits.Len() != uint64(len(committee)) {
+		return errors.New("aggregation bits do not match the committee size")
+	}
+	inCommittee := false
+	for _, index := range committee {
+		if index == item.AggregatorIndex {
+			inCommittee = true
+			break
+		}
+	}
+	if !inCommittee {
+		return errors.New("aggregator is not a member of the committee")
+	}
+	isAggregator, err := helpers.IsAggregator(uint64(len(committee)), item.SelectionProof)
+	if err != nil {
+		return errors.Wrap(err, "could not get aggregator status")
+	}
+	if !isAggregator {
+		return errors.New("validator is not an aggregator")
+	}
+	return nil
+}
+
+// verifyAggregateAndProofSignatures batch verifies the selection proof, the aggregator signature and
+// the aggregate signature of a signed aggregate and proof, as the aggregate gossip validation does.
+func verifyAggregateAndProofSignatures(ctx context.Context, s *statetrie.BeaconState, signed *ethpbalpha.SignedAggregateAttestationAndProof) error {
+	aggregator, err := s.ValidatorAtIndexReadOnly(signed.Message.AggregatorIndex)
+	if err != nil {
+		return errors.Wrap(err, "could not get aggregator")
+	}
+	publicKey := aggregator.PublicKey()
+	pubKey, err := bls.PublicKeyFromBytes(publicKey[:])
+	if err != nil {
+		return errors.Wrap(err, "could not decode aggregator public key")
+	}
+
+	data := signed.Message.Aggregate.Data
+	epoch := helpers.SlotToEpoch(data.Slot)
+	selectionDomain, err := helpers.Domain(s.Fork(), epoch, params.BeaconConfig().DomainSelectionProof, s.GenesisValidatorRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get selection proof domain")
+	}
+	slot := types.SSZUint64(data.Slot)
+	selectionRoot, err := helpers.ComputeSigningRoot(&slot, selectionDomain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute selection proof signing root")
+	}
+	aggregatorDomain, err := helpers.Domain(s.Fork(), epoch, params.BeaconConfig().DomainAggregateAndProof, s.GenesisValidatorRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get aggregate and proof domain")
+	}
+	aggregatorRoot, err := helpers.ComputeSigningRoot(signed.Message, aggregatorDomain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute aggregate and proof signing root")
+	}
+	aggregateSet, err := blocks.AttestationSignatureSet(ctx, s, []*ethpbalpha.Attestation{signed.Message.Aggregate})
+	if err != nil {
+		return errors.Wrap(err, "could not get aggregate signature set")
+	}
+
+	set := bls.NewSet()
+	set.Join(&bls.SignatureSet{
+		Signatures: [][]byte{signed.Message.SelectionProof, signed.Signature},
+		PublicKeys: []bls.PublicKey{pubKey, pubKey},
+		Messages:   [][32]byte{selectionRoot, aggregatorRoot},
+	}).Join(aggregateSet)
+	valid, err := set.Verify()
+	if err != nil {
+		return errors.Wrap(err, "could not verify signatures")
+	}
+	if !valid {
+		return errors.New("invalid selection proof, aggregator signature or aggregate signature")
+	}
+	return nil
+}
+
+// bestAttestation returns the attestation with the most attesters among the attestations of the slot
+// whose data has the given root.
+func bestAttestation(atts []*ethpbalpha.Attestation, slot types.Slot, dataRoot []byte) (*ethpbalpha.Attestation, error) {
+	var best *ethpbalpha.Attestation
+	for _, att := range atts {
+		if att.Data == nil || att.Data.Slot != slot {
+			continue
+		}
+		root, err := att.Data.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(root[:], dataRoot) {
+			continue
+		}
+		if best == nil || att.AggregationBits.Count() > best.AggregationBits.Count() {
+			best = att
+		}
+	}
+	return best, nil
+}
+
+// committeeSubnet computes the attestation subnet of a committee, as compute_subnet_for_attestation
+// does from the committee count of the slot.
+func committeeSubnet(committeesAtSlot uint64, committeeIndex types.CommitteeIndex, slot types.Slot) uint64 {
+	committeesSinceEpochStart := uint64(helpers.SlotsSinceEpochStarts(slot)) * committeesAtSlot
+	return (committeesSinceEpochStart + uint64(committeeIndex)) % params.BeaconNetworkConfig().AttestationSubnetCount
+}
+
+// decodeBytesParam decodes a fixed size bytes parameter given either as raw bytes, as sent by gRPC
+// clients, or as a 0x-prefixed hex string, as sent through the REST gateway.
+func decodeBytesParam(param []byte, size int) ([]byte, bool) {
+	if len(param) == size {
+		return param, true
+	}
+	if len(param) == 2+2*size && bytes.HasPrefix(param, []byte("0x")) {
+		decoded, err := hexutil.Decode(string(param))
+		if err == nil {
+			return decoded, true
+		}
+	}
+	return nil, false
+}
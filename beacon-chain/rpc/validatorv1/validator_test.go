@@ -0,0 +1,556 @@
+package validatorv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
+	ethpbalpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// headerStream records the response header set by a server method.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// mockBlockProducer records the request it is given and produces an empty block for its slot.
+type mockBlockProducer struct {
+	req *ethpbalpha.BlockRequest
+}
+
+func (m *mockBlockProducer) GetBlock(_ context.Context, req *ethpbalpha.BlockRequest) (*ethpbalpha.BeaconBlock, error) {
+	m.req = req
+	block := testutil.NewBeaconBlock().Block
+	block.Slot = req.Slot
+	block.Body.RandaoReveal = req.RandaoReveal
+	graffiti := bytesutil.ToBytes32(req.Graffiti)
+	block.Body.Graffiti = graffiti[:]
+	return block, nil
+}
+
+func TestGetAttesterDuties(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	db := dbTest.SetupDB(t)
+	genesisBlock := testutil.NewBeaconBlock()
+	require.NoError(t, db.SaveBlock(ctx, genesisBlock))
+	genesisRoot, err := genesisBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	currentSlot := params.BeaconConfig().SlotsPerEpoch
+	newServer := func(t *testing.T) *Server {
+		st, _ := testutil.DeterministicGenesisState(t, 64)
+		return &Server{
+			BeaconDB:    db,
+			HeadFetcher: &mockChain.ChainService{State: st},
+			TimeFetcher: &mockChain.ChainService{Slot: &currentSlot},
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+	}
+
+	t.Run("Current epoch", func(t *testing.T) {
+		vs := newServer(t)
+		stream := &headerStream{}
+		resp, err := vs.GetAttesterDuties(grpc.NewContextWithServerTransportStream(ctx, stream), &ethpb.AttesterDutiesRequest{
+			Epoch: 1,
+			Index: []types.ValidatorIndex{0, 63},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+
+		st, _ := testutil.DeterministicGenesisState(t, 64)
+		st, err = state.ProcessSlots(ctx, st, params.BeaconConfig().SlotsPerEpoch)
+		require.NoError(t, err)
+		for i, index := range []types.ValidatorIndex{0, 63} {
+			duty := resp.Data[i]
+			assert.Equal(t, index, duty.ValidatorIndex)
+			pubkey := st.PubkeyAtIndex(index)
+			assert.DeepEqual(t, pubkey[:], duty.Pubkey)
+			assert.Equal(t, uint64(1), duty.CommitteesAtSlot)
+			assert.Equal(t, 1, int(helpers.SlotToEpoch(duty.Slot)))
+			committee, err := helpers.BeaconCommitteeFromState(st, duty.Slot, duty.CommitteeIndex)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(committee)), duty.CommitteeLength)
+			assert.Equal(t, index, committee[duty.ValidatorCommitteeIndex])
+		}
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), grpcutils.ResponseFields(stream.header)["dependent_root"])
+	})
+
+	t.Run("Next epoch", func(t *testing.T) {
+		vs := newServer(t)
+		stream := &headerStream{}
+		resp, err := vs.GetAttesterDuties(grpc.NewContextWithServerTransportStream(ctx, stream), &ethpb.AttesterDutiesRequest{
+			Epoch: 2,
+			Index: []types.ValidatorIndex{0},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, 2, int(helpers.SlotToEpoch(resp.Data[0].Slot)))
+
+		st, _ := testutil.DeterministicGenesisState(t, 64)
+		st, err = state.ProcessSlots(ctx, st, 2*params.BeaconConfig().SlotsPerEpoch)
+		require.NoError(t, err)
+		dependentRoot, err := helpers.BlockRootAtSlot(st, params.BeaconConfig().SlotsPerEpoch-1)
+		require.NoError(t, err)
+		assert.Equal(t, hexutil.Encode(dependentRoot), grpcutils.ResponseFields(stream.header)["dependent_root"])
+	})
+
+	t.Run("Epoch out of bound", func(t *testing.T) {
+		vs := newServer(t)
+		_, err := vs.GetAttesterDuties(ctx, &ethpb.AttesterDutiesRequest{Epoch: 3, Index: []types.ValidatorIndex{0}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "can not be greater than next epoch", err)
+	})
+
+	t.Run("Validator index out of bound", func(t *testing.T) {
+		vs := newServer(t)
+		_, err := vs.GetAttesterDuties(ctx, &ethpb.AttesterDutiesRequest{Epoch: 1, Index: []types.ValidatorIndex{64}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid validator index", err)
+	})
+
+	t.Run("Syncing", func(t *testing.T) {
+		vs := newServer(t)
+		vs.SyncChecker = &mockSync.Sync{IsSyncing: true}
+		_, err := vs.GetAttesterDuties(ctx, &ethpb.AttesterDutiesRequest{Epoch: 1, Index: []types.ValidatorIndex{0}})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestGetProposerDuties(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	db := dbTest.SetupDB(t)
+	genesisBlock := testutil.NewBeaconBlock()
+	require.NoError(t, db.SaveBlock(ctx, genesisBlock))
+	genesisRoot, err := genesisBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	currentSlot := params.BeaconConfig().SlotsPerEpoch
+	newServer := func(t *testing.T) *Server {
+		st, _ := testutil.DeterministicGenesisState(t, 64)
+		return &Server{
+			BeaconDB:    db,
+			HeadFetcher: &mockChain.ChainService{State: st},
+			TimeFetcher: &mockChain.ChainService{Slot: &currentSlot},
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+	}
+
+	t.Run("Current epoch", func(t *testing.T) {
+		vs := newServer(t)
+		stream := &headerStream{}
+		resp, err := vs.GetProposerDuties(grpc.NewContextWithServerTransportStream(ctx, stream), &ethpb.ProposerDutiesRequest{Epoch: 1})
+		require.NoError(t, err)
+		require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(resp.Data))
+
+		st, _ := testutil.DeterministicGenesisState(t, 64)
+		st, err = state.ProcessSlots(ctx, st, params.BeaconConfig().SlotsPerEpoch)
+		require.NoError(t, err)
+		dependentRoot, err := helpers.BlockRootAtSlot(st, params.BeaconConfig().SlotsPerEpoch-1)
+		require.NoError(t, err)
+		assert.Equal(t, hexutil.Encode(dependentRoot), grpcutils.ResponseFields(stream.header)["dependent_root"])
+		for i, duty := range resp.Data {
+			assert.Equal(t, params.BeaconConfig().SlotsPerEpoch+types.Slot(i), duty.Slot)
+			require.NoError(t, st.SetSlot(duty.Slot))
+			index, err := helpers.BeaconProposerIndex(st)
+			require.NoError(t, err)
+			assert.Equal(t, index, duty.ValidatorIndex)
+			pubkey := st.PubkeyAtIndex(index)
+			assert.DeepEqual(t, pubkey[:], duty.Pubkey)
+		}
+	})
+
+	t.Run("Genesis epoch", func(t *testing.T) {
+		vs := newServer(t)
+		stream := &headerStream{}
+		resp, err := vs.GetProposerDuties(grpc.NewContextWithServerTransportStream(ctx, stream), &ethpb.ProposerDutiesRequest{Epoch: 0})
+		require.NoError(t, err)
+		require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch)-1, len(resp.Data))
+		assert.Equal(t, types.Slot(1), resp.Data[0].Slot)
+		assert.Equal(t, hexutil.Encode(genesisRoot[:]), grpcutils.ResponseFields(stream.header)["dependent_root"])
+	})
+
+	t.Run("Future epoch", func(t *testing.T) {
+		vs := newServer(t)
+		_, err := vs.GetProposerDuties(ctx, &ethpb.ProposerDutiesRequest{Epoch: 2})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "can not be greater than current epoch", err)
+	})
+
+	t.Run("Epoch before head", func(t *testing.T) {
+		vs := newServer(t)
+		st, _ := testutil.DeterministicGenesisState(t, 64)
+		require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+		vs.HeadFetcher = &mockChain.ChainService{State: st}
+		_, err := vs.GetProposerDuties(ctx, &ethpb.ProposerDutiesRequest{Epoch: 0})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "can not be before head epoch", err)
+	})
+}
+
+func TestGetBlock(t *testing.T) {
+	ctx := context.Background()
+	randaoReveal := bytesutil.PadTo([]byte("randao"), 96)
+	graffiti := bytesutil.PadTo([]byte("graffiti"), 32)
+
+	t.Run("Hex parameters", func(t *testing.T) {
+		producer := &mockBlockProducer{}
+		vs := &Server{BlockProducer: producer}
+		resp, err := vs.GetBlock(ctx, &ethpb.ProposerBlockRequest{
+			Slot:         5,
+			RandaoReveal: []byte(hexutil.Encode(randaoReveal)),
+			Graffiti:     []byte(hexutil.Encode(graffiti)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, types.Slot(5), producer.req.Slot)
+		assert.DeepEqual(t, randaoReveal, producer.req.RandaoReveal)
+		assert.DeepEqual(t, graffiti, producer.req.Graffiti)
+		assert.Equal(t, types.Slot(5), resp.Data.Slot)
+		assert.DeepEqual(t, randaoReveal, resp.Data.Body.RandaoReveal)
+		assert.DeepEqual(t, graffiti, resp.Data.Body.Graffiti)
+	})
+
+	t.Run("Raw parameters without graffiti", func(t *testing.T) {
+		producer := &mockBlockProducer{}
+		vs := &Server{BlockProducer: producer}
+		resp, err := vs.GetBlock(ctx, &ethpb.ProposerBlockRequest{Slot: 5, RandaoReveal: randaoReveal})
+		require.NoError(t, err)
+		assert.DeepEqual(t, randaoReveal, producer.req.RandaoReveal)
+		assert.Equal(t, 0, len(producer.req.Graffiti))
+		assert.DeepEqual(t, make([]byte, 32), resp.Data.Body.Graffiti)
+	})
+
+	t.Run("Invalid randao reveal", func(t *testing.T) {
+		vs := &Server{BlockProducer: &mockBlockProducer{}}
+		_, err := vs.GetBlock(ctx, &ethpb.ProposerBlockRequest{Slot: 5, RandaoReveal: []byte("0x1234")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid randao reveal", err)
+	})
+
+	t.Run("Invalid graffiti", func(t *testing.T) {
+		vs := &Server{BlockProducer: &mockBlockProducer{}}
+		_, err := vs.GetBlock(ctx, &ethpb.ProposerBlockRequest{Slot: 5, RandaoReveal: randaoReveal, Graffiti: []byte("0xzz")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid graffiti", err)
+	})
+}
+
+// mockAttestationDataProducer records the request it is given and returns fixed checkpoints.
+type mockAttestationDataProducer struct {
+	req *ethpbalpha.AttestationDataRequest
+	err error
+}
+
+func (m *mockAttestationDataProducer) GetAttestationData(_ context.Context, req *ethpbalpha.AttestationDataRequest) (*ethpbalpha.AttestationData, error) {
+	m.req = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ethpbalpha.AttestationData{
+		Slot:            req.Slot,
+		CommitteeIndex:  req.CommitteeIndex,
+		BeaconBlockRoot: bytesutil.PadTo([]byte("head"), 32),
+		Source:          &ethpbalpha.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("source"), 32)},
+		Target:          &ethpbalpha.Checkpoint{Epoch: 2, Root: bytesutil.PadTo([]byte("target"), 32)},
+	}, nil
+}
+
+func TestGetAttestationData(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		producer := &mockAttestationDataProducer{}
+		vs := &Server{AttestationDataProducer: producer}
+		resp, err := vs.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: 70, CommitteeIndex: 3})
+		require.NoError(t, err)
+		assert.Equal(t, types.Slot(70), producer.req.Slot)
+		assert.Equal(t, types.CommitteeIndex(3), producer.req.CommitteeIndex)
+		assert.Equal(t, types.Slot(70), resp.Data.Slot)
+		assert.Equal(t, types.CommitteeIndex(3), resp.Data.CommitteeIndex)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte("head"), 32), resp.Data.BeaconBlockRoot)
+		assert.Equal(t, types.Epoch(1), resp.Data.Source.Epoch)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte("source"), 32), resp.Data.Source.Root)
+		assert.Equal(t, types.Epoch(2), resp.Data.Target.Epoch)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte("target"), 32), resp.Data.Target.Root)
+	})
+
+	t.Run("Producer error", func(t *testing.T) {
+		vs := &Server{AttestationDataProducer: &mockAttestationDataProducer{
+			err: status.Error(codes.InvalidArgument, "invalid request"),
+		}}
+		_, err := vs.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: 1000})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGetAggregateAttestation(t *testing.T) {
+	ctx := context.Background()
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := priv.Sign([]byte("attestation")).Marshal()
+	data := testutil.HydrateAttestationData(&ethpbalpha.AttestationData{Slot: 5, CommitteeIndex: 2})
+	otherData := testutil.HydrateAttestationData(&ethpbalpha.AttestationData{Slot: 5, CommitteeIndex: 2, BeaconBlockRoot: bytesutil.PadTo([]byte("other"), 32)})
+	dataRoot, err := data.HashTreeRoot()
+	require.NoError(t, err)
+
+	newPool := func(t *testing.T) attestations.Pool {
+		pool := attestations.NewPool()
+		for _, bits := range []bitfield.Bitlist{{0b10001}, {0b10010}, {0b10100}} {
+			require.NoError(t, pool.SaveUnaggregatedAttestation(&ethpbalpha.Attestation{AggregationBits: bits, Data: data, Signature: sig}))
+		}
+		require.NoError(t, pool.SaveUnaggregatedAttestation(&ethpbalpha.Attestation{AggregationBits: bitfield.Bitlist{0b11000}, Data: otherData, Signature: sig}))
+		return pool
+	}
+
+	t.Run("Aggregates matching attestations", func(t *testing.T) {
+		vs := &Server{AttestationsPool: newPool(t)}
+		resp, err := vs.GetAggregateAttestation(ctx, &ethpb.AggregateAttestationRequest{
+			AttestationDataRoot: []byte(hexutil.Encode(dataRoot[:])),
+			Slot:                5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), resp.Data.AggregationBits.Count())
+		assert.DeepEqual(t, data.BeaconBlockRoot, resp.Data.Data.BeaconBlockRoot)
+	})
+
+	t.Run("No matching attestation", func(t *testing.T) {
+		vs := &Server{AttestationsPool: newPool(t)}
+		_, err := vs.GetAggregateAttestation(ctx, &ethpb.AggregateAttestationRequest{AttestationDataRoot: dataRoot[:], Slot: 6})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Invalid data root", func(t *testing.T) {
+		vs := &Server{AttestationsPool: newPool(t)}
+		_, err := vs.GetAggregateAttestation(ctx, &ethpb.AggregateAttestationRequest{AttestationDataRoot: []byte("0x12"), Slot: 5})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubmitAggregateAndProofs(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	st, keys := testutil.DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(st, 1, 0)
+	require.NoError(t, err)
+	notInCommittee := types.ValidatorIndex(0)
+	for inCommittee(notInCommittee, committee) {
+		notInCommittee++
+	}
+	genesisTime := time.Now().Add(-time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+
+	newServer := func(t *testing.T) (*Server, *mockp2p.TestP2P) {
+		broadcaster := mockp2p.NewTestP2P(t)
+		return &Server{
+			HeadFetcher: &mockChain.ChainService{State: st},
+			TimeFetcher: &mockChain.ChainService{Genesis: genesisTime},
+			Broadcaster: broadcaster,
+		}, broadcaster
+	}
+	// aggregateAndProof returns an aggregate and proof of the first member of the committee, with
+	// the selection proof of the aggregator and its signature of the aggregate and proof.
+	aggregateAndProof := func(t *testing.T, aggregator types.ValidatorIndex) (*ethpb.AggregateAttestationAndProof, string) {
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(0, true)
+		att := &ethpbalpha.Attestation{
+			AggregationBits: bits,
+			Data: &ethpbalpha.AttestationData{
+				Slot:            1,
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpbalpha.Checkpoint{Root: make([]byte, 32)},
+				Target:          &ethpbalpha.Checkpoint{Root: make([]byte, 32)},
+			},
+		}
+		attSig, err := helpers.ComputeDomainAndSign(st, 0, att.Data, params.BeaconConfig().DomainBeaconAttester, keys[committee[0]])
+		require.NoError(t, err)
+		att.Signature = attSig
+		slot := types.SSZUint64(1)
+		proof, err := helpers.ComputeDomainAndSign(st, 0, &slot, params.BeaconConfig().DomainSelectionProof, keys[aggregator])
+		require.NoError(t, err)
+		msg := &ethpbalpha.AggregateAttestationAndProof{AggregatorIndex: aggregator, Aggregate: att, SelectionProof: proof}
+		sig, err := helpers.ComputeDomainAndSign(st, 0, msg, params.BeaconConfig().DomainAggregateAndProof, keys[aggregator])
+		require.NoError(t, err)
+		return &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: aggregator,
+			Aggregate:       migration.V1Alpha1AttestationToV1(att),
+			SelectionProof:  proof,
+		}, hexutil.Encode(sig)
+	}
+	withSignatures := func(signatures ...string) context.Context {
+		md := metadata.MD{}
+		md.Append(grpcutils.SignatureMetadataKey, signatures...)
+		return metadata.NewIncomingContext(ctx, md)
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		vs, broadcaster := newServer(t)
+		item, signature := aggregateAndProof(t, committee[0])
+		_, err := vs.SubmitAggregateAndProofs(withSignatures(signature), &ethpb.AggregateAndProofsSubmit{
+			Data: []*ethpb.AggregateAttestationAndProof{item},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, true, broadcaster.BroadcastCalled)
+	})
+
+	t.Run("Missing signature", func(t *testing.T) {
+		vs, broadcaster := newServer(t)
+		item, _ := aggregateAndProof(t, committee[0])
+		_, err := vs.SubmitAggregateAndProofs(ctx, &ethpb.AggregateAndProofsSubmit{
+			Data: []*ethpb.AggregateAttestationAndProof{item},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Expected 1 signatures, received 0", err)
+		assert.Equal(t, false, broadcaster.BroadcastCalled)
+	})
+
+	t.Run("Aggregator not in committee", func(t *testing.T) {
+		vs, broadcaster := newServer(t)
+		item, signature := aggregateAndProof(t, committee[0])
+		other, otherSignature := aggregateAndProof(t, notInCommittee)
+		_, err := vs.SubmitAggregateAndProofs(withSignatures(signature, otherSignature), &ethpb.AggregateAndProofsSubmit{
+			Data: []*ethpb.AggregateAttestationAndProof{item, other},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid aggregate and proof 1: aggregator is not a member of the committee", err)
+		assert.Equal(t, false, broadcaster.BroadcastCalled)
+	})
+
+	t.Run("Empty selection proof", func(t *testing.T) {
+		vs, _ := newServer(t)
+		item, signature := aggregateAndProof(t, committee[0])
+		item.SelectionProof = make([]byte, 96)
+		_, err := vs.SubmitAggregateAndProofs(withSignatures(signature), &ethpb.AggregateAndProofsSubmit{
+			Data: []*ethpb.AggregateAttestationAndProof{item},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "invalid selection proof", err)
+	})
+
+	t.Run("Invalid signatures", func(t *testing.T) {
+		otherMember := committee[len(committee)-1]
+		require.Equal(t, true, otherMember != committee[0])
+		_, otherSignature := aggregateAndProof(t, otherMember)
+		other, _ := aggregateAndProof(t, otherMember)
+		tests := []struct {
+			name   string
+			tamper func(item *ethpb.AggregateAttestationAndProof, signature string) string
+		}{
+			{
+				name: "selection proof",
+				tamper: func(item *ethpb.AggregateAttestationAndProof, signature string) string {
+					item.SelectionProof = other.SelectionProof
+					return signature
+				},
+			},
+			{
+				name: "aggregator signature",
+				tamper: func(_ *ethpb.AggregateAttestationAndProof, _ string) string {
+					return otherSignature
+				},
+			},
+			{
+				name: "aggregate signature",
+				tamper: func(item *ethpb.AggregateAttestationAndProof, signature string) string {
+					item.Aggregate.Signature = other.SelectionProof
+					return signature
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				vs, broadcaster := newServer(t)
+				item, signature := aggregateAndProof(t, committee[0])
+				signature = tt.tamper(item, signature)
+				_, err := vs.SubmitAggregateAndProofs(withSignatures(signature), &ethpb.AggregateAndProofsSubmit{
+					Data: []*ethpb.AggregateAttestationAndProof{item},
+				})
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.ErrorContains(t, "invalid selection proof, aggregator signature or aggregate signature", err)
+				assert.Equal(t, false, broadcaster.BroadcastCalled)
+			})
+		}
+	})
+}
+
+func TestSubmitBeaconCommitteeSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Subscribes to subnets", func(t *testing.T) {
+		cache.SubnetIDs.EmptyAllCaches()
+		defer cache.SubnetIDs.EmptyAllCaches()
+		vs := &Server{SyncChecker: &mockSync.Sync{IsSyncing: false}}
+		_, err := vs.SubmitBeaconCommitteeSubscription(ctx, &ethpb.BeaconCommitteeSubscribeSubmit{
+			Data: []*ethpb.BeaconCommitteeSubscribe{
+				{Slot: 33, CommitteeIndex: 2, CommitteesAtSlot: 4, IsAggregator: false},
+				{Slot: 34, CommitteeIndex: 1, CommitteesAtSlot: 4, IsAggregator: true},
+			},
+		})
+		require.NoError(t, err)
+		assert.DeepEqual(t, []uint64{6}, cache.SubnetIDs.GetAttesterSubnetIDs(33))
+		assert.Equal(t, 0, len(cache.SubnetIDs.GetAggregatorSubnetIDs(33)))
+		assert.DeepEqual(t, []uint64{9}, cache.SubnetIDs.GetAttesterSubnetIDs(34))
+		assert.DeepEqual(t, []uint64{9}, cache.SubnetIDs.GetAggregatorSubnetIDs(34))
+	})
+
+	t.Run("Invalid committee index", func(t *testing.T) {
+		vs := &Server{SyncChecker: &mockSync.Sync{IsSyncing: false}}
+		_, err := vs.SubmitBeaconCommitteeSubscription(ctx, &ethpb.BeaconCommitteeSubscribeSubmit{
+			Data: []*ethpb.BeaconCommitteeSubscribe{{Slot: 33, CommitteeIndex: 4, CommitteesAtSlot: 4}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, "Invalid committee index", err)
+	})
+
+	t.Run("No subscriptions", func(t *testing.T) {
+		vs := &Server{SyncChecker: &mockSync.Sync{IsSyncing: false}}
+		_, err := vs.SubmitBeaconCommitteeSubscription(ctx, &ethpb.BeaconCommitteeSubscribeSubmit{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Syncing", func(t *testing.T) {
+		vs := &Server{SyncChecker: &mockSync.Sync{IsSyncing: true}}
+		_, err := vs.SubmitBeaconCommitteeSubscription(ctx, &ethpb.BeaconCommitteeSubscribeSubmit{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func inCommittee(index types.ValidatorIndex, committee []types.ValidatorIndex) bool {
+	for _, member := range committee {
+		if member == index {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+// Package slasher runs slashing detection inside the beacon node, on the blocks and attestations
+// it receives, and inserts the slashings it finds into the slashings pool so that they are
+// included in blocks proposed by the node.
+package slasher
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/blockutil"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"github.com/sirupsen/logrus"
+)
+
+var _ shared.Service = (*Service)(nil)
+
+const (
+	// attestationQueueSize is the number of received attestations waiting for detection, beyond
+	// which attestations are dropped rather than holding up the node.
+	attestationQueueSize = 10000
+	// blockQueueSize is the number of processed blocks waiting for detection.
+	blockQueueSize = 64
+)
+
+// Config to set up the slasher service.
+type Config struct {
+	DataDir                     string
+	SpanCacheSize               int
+	HighestAttestationCacheSize int
+	StateNotifier               statefeed.Notifier
+	OperationNotifier           opfeed.Notifier
+	AttestationReceiver         blockchain.AttestationReceiver
+	HeadFetcher                 blockchain.HeadFetcher
+	SlashingPool                slashings.PoolManager
+}
+
+// Service detects slashable offences in the blocks processed and the attestations received by
+// the beacon node.
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	cfg         *Config
+	slasherDB   *kv.Store
+	detector    *detection.Service
+	attsQueue   chan *ethpb.Attestation
+	blocksQueue chan *ethpb.SignedBeaconBlock
+}
+
+// NewService opens the slasher database in the data directory of the beacon node and configures
+// the slasher service.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	slasherDB, err := kv.NewKVStore(filepath.Join(cfg.DataDir, kv.SlasherDbDirName), &kv.Config{
+		SpanCacheSize:               cfg.SpanCacheSize,
+		HighestAttestationCacheSize: cfg.HighestAttestationCacheSize,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open slasher database")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		cfg:         cfg,
+		slasherDB:   slasherDB,
+		detector:    detection.NewService(ctx, &detection.Config{SlasherDB: slasherDB}),
+		attsQueue:   make(chan *ethpb.Attestation, attestationQueueSize),
+		blocksQueue: make(chan *ethpb.SignedBeaconBlock, blockQueueSize),
+	}, nil
+}
+
+// Start listening for blocks and attestations, and running detection on them.
+func (s *Service) Start() {
+	go s.receiveBlocks()
+	go s.receiveAttestations()
+	go s.run()
+}
+
+// Stop the slasher service and close its database.
+func (s *Service) Stop() error {
+	s.cancel()
+	return s.slasherDB.Close()
+}
+
+// Status always returns nil.
+func (s *Service) Status() error {
+	return nil
+}
+
+// receiveBlocks queues the blocks processed by the node. Blocks of competing forks are processed
+// too, so double proposals are seen as long as both blocks are valid.
+func (s *Service) receiveBlocks() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.BlockProcessedData)
+			if !ok || data.SignedBlock == nil || data.SignedBlock.Block == nil {
+				continue
+			}
+			select {
+			case s.blocksQueue <- data.SignedBlock:
+			default:
+				log.WithField("slot", data.Slot).Debug("Slasher block queue is full, dropping block")
+			}
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// receiveAttestations queues the attestations received by the node, aggregated or not. The
+// feed is only read from here, so that detection never holds up the senders.
+func (s *Service) receiveAttestations() {
+	opChannel := make(chan *feed.Event, 1)
+	opSub := s.cfg.OperationNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+	for {
+		select {
+		case event := <-opChannel:
+			var att *ethpb.Attestation
+			switch data := event.Data.(type) {
+			case *opfeed.UnAggregatedAttReceivedData:
+				att = data.Attestation
+			case *opfeed.AggregatedAttReceivedData:
+				if data.Attestation != nil {
+					att = data.Attestation.Aggregate
+				}
+			}
+			if att == nil || att.Data == nil {
+				continue
+			}
+			s.queueAttestation(att)
+		case err := <-opSub.Err():
+			log.WithError(err).Error("Could not subscribe to operation notifier")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Service) queueAttestation(att *ethpb.Attestation) {
+	select {
+	case s.attsQueue <- att:
+	default:
+		log.WithField("slot", att.Data.Slot).Debug("Slasher attestation queue is full, dropping attestation")
+	}
+}
+
+func (s *Service) run() {
+	for {
+		select {
+		case blk := <-s.blocksQueue:
+			if err := s.detectBlock(s.ctx, blk); err != nil {
+				log.WithError(err).WithField("slot", blk.Block.Slot).Debug("Could not run detection on block")
+			}
+			if blk.Block.Body == nil {
+				continue
+			}
+			for _, att := range blk.Block.Body.Attestations {
+				s.queueAttestation(att)
+			}
+		case att := <-s.attsQueue:
+			if err := s.detectAttestation(s.ctx, att); err != nil {
+				log.WithError(err).WithField("slot", att.Data.Slot).Debug("Could not run detection on attestation")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// detectBlock checks whether the proposer of a block proposed another block at the same slot.
+func (s *Service) detectBlock(ctx context.Context, blk *ethpb.SignedBeaconBlock) error {
+	header, err := blockutil.SignedBeaconBlockHeaderFromBlock(blk)
+	if err != nil {
+		return errors.Wrap(err, "could not get block header")
+	}
+	slashing, err := s.detector.DetectDoubleProposals(ctx, header)
+	if err != nil {
+		return errors.Wrap(err, "could not detect double proposal")
+	}
+	if slashing == nil {
+		return nil
+	}
+	headState, err := s.cfg.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if err := s.cfg.SlashingPool.InsertProposerSlashing(ctx, headState, slashing); err != nil {
+		return errors.Wrap(err, "could not insert proposer slashing into pool")
+	}
+	log.WithFields(logrus.Fields{
+		"slot":          header.Header.Slot,
+		"proposerIndex": header.Header.ProposerIndex,
+	}).Info("Detected a double proposal, inserted proposer slashing into pool")
+	return nil
+}
+
+// detectAttestation checks whether the attesters of an attestation made a double or surround
+// vote, as in the standalone slasher, after recording the attestation.
+func (s *Service) detectAttestation(ctx context.Context, att *ethpb.Attestation) error {
+	preState, err := s.cfg.AttestationReceiver.AttestationPreState(ctx, att)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestation pre state")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(preState, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestation committee")
+	}
+	indexedAtt, err := attestationutil.ConvertToIndexed(ctx, att, committee)
+	if err != nil {
+		return errors.Wrap(err, "could not convert attestation to indexed form")
+	}
+	if err := s.slasherDB.SaveIndexedAttestation(ctx, indexedAtt); err != nil {
+		return errors.Wrap(err, "could not save indexed attestation")
+	}
+	attesterSlashings, err := s.detector.DetectAttesterSlashings(ctx, indexedAtt)
+	if err != nil {
+		return errors.Wrap(err, "could not detect attester slashings")
+	}
+	if len(attesterSlashings) == 0 {
+		if err := s.detector.UpdateSpans(ctx, indexedAtt); err != nil {
+			return errors.Wrap(err, "could not update spans")
+		}
+	}
+	if err := s.detector.UpdateHighestAttestation(ctx, indexedAtt); err != nil {
+		return errors.Wrap(err, "could not update highest attestation")
+	}
+	if len(attesterSlashings) == 0 {
+		return nil
+	}
+	headState, err := s.cfg.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	for _, slashing := range attesterSlashings {
+		if err := s.cfg.SlashingPool.InsertAttesterSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Could not insert attester slashing into pool")
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"targetEpoch": slashing.Attestation_2.Data.Target.Epoch,
+			"indices":     sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices),
+		}).Info("Detected a slashable attestation, inserted attester slashing into pool")
+	}
+	return nil
+}
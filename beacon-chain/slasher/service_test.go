@@ -0,0 +1,111 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func setupService(t *testing.T) (*Service, *slashings.PoolMock, *mock.ChainService) {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(1))
+	chain := &mock.ChainService{State: st}
+	pool := &slashings.PoolMock{}
+	s, err := NewService(context.Background(), &Config{
+		DataDir:                     t.TempDir(),
+		SpanCacheSize:               1500,
+		HighestAttestationCacheSize: 3000,
+		StateNotifier:               chain.StateNotifier(),
+		OperationNotifier:           chain.OperationNotifier(),
+		AttestationReceiver:         chain,
+		HeadFetcher:                 chain,
+		SlashingPool:                pool,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+	return s, pool, chain
+}
+
+func TestService_DetectAttestation_DoubleVote(t *testing.T) {
+	ctx := context.Background()
+	s, pool, chain := setupService(t)
+	_, keys, err := testutil.DeterministicDepositsAndKeys(64)
+	require.NoError(t, err)
+	st := chain.State
+	committee, err := helpers.BeaconCommitteeFromState(st, 1, 0)
+	require.NoError(t, err)
+	attester := committee[0]
+
+	signedAtt := func(blockRoot byte) *ethpb.Attestation {
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(0, true)
+		att := &ethpb.Attestation{
+			AggregationBits: bits,
+			Data: &ethpb.AttestationData{
+				Slot:            1,
+				CommitteeIndex:  0,
+				BeaconBlockRoot: bytesutil.PadTo([]byte{blockRoot}, 32),
+				Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+			},
+		}
+		sig, err := helpers.ComputeDomainAndSign(st, 0, att.Data, params.BeaconConfig().DomainBeaconAttester, keys[attester])
+		require.NoError(t, err)
+		att.Signature = sig
+		return att
+	}
+
+	require.NoError(t, s.detectAttestation(ctx, signedAtt(1)))
+	assert.Equal(t, 0, len(pool.PendingAttSlashings))
+	// The same vote again is not slashable.
+	require.NoError(t, s.detectAttestation(ctx, signedAtt(1)))
+	assert.Equal(t, 0, len(pool.PendingAttSlashings))
+
+	require.NoError(t, s.detectAttestation(ctx, signedAtt(2)))
+	require.Equal(t, 1, len(pool.PendingAttSlashings))
+	slashing := pool.PendingAttSlashings[0]
+	assert.DeepEqual(t, []uint64{uint64(attester)}, slashing.Attestation_1.AttestingIndices)
+	require.NoError(t, blocks.VerifyAttesterSlashing(ctx, st, slashing))
+}
+
+func TestService_DetectBlock_DoubleProposal(t *testing.T) {
+	ctx := context.Background()
+	s, pool, chain := setupService(t)
+	_, keys, err := testutil.DeterministicDepositsAndKeys(64)
+	require.NoError(t, err)
+	st := chain.State
+	proposer := types.ValidatorIndex(3)
+
+	signedBlock := func(graffiti byte) *ethpb.SignedBeaconBlock {
+		blk := testutil.NewBeaconBlock()
+		blk.Block.Slot = 1
+		blk.Block.ProposerIndex = proposer
+		blk.Block.Body.Graffiti = bytesutil.PadTo([]byte{graffiti}, 32)
+		sig, err := helpers.ComputeDomainAndSign(st, 0, blk.Block, params.BeaconConfig().DomainBeaconProposer, keys[proposer])
+		require.NoError(t, err)
+		blk.Signature = sig
+		return blk
+	}
+
+	require.NoError(t, s.detectBlock(ctx, signedBlock(1)))
+	assert.Equal(t, 0, len(pool.PendingPropSlashings))
+	require.NoError(t, s.detectBlock(ctx, signedBlock(2)))
+	require.Equal(t, 1, len(pool.PendingPropSlashings))
+	slashing := pool.PendingPropSlashings[0]
+	assert.Equal(t, proposer, slashing.Header_1.Header.ProposerIndex)
+	require.NoError(t, blocks.VerifyProposerSlashing(st, slashing))
+}
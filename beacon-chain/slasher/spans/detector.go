@@ -0,0 +1,195 @@
+// Package spans detects double and surround votes in the attestations received over gossip,
+// using min/max spans kept in memory for a short history of recent epochs. It trades the
+// completeness of the slasher database for detecting recent offences as soon as the offending
+// attestation is received.
+package spans
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// DefaultHistoryLength is the default number of recent epochs for which spans and attestations
+// are kept for every validator.
+const DefaultHistoryLength = types.Epoch(32)
+
+// noMinSpan marks epochs with no attestation having a later source.
+const noMinSpan = math.MaxUint16
+
+// attRecord is an attestation received by the detector, shared by the validators attesting to it.
+type attRecord struct {
+	att      *ethpb.IndexedAttestation
+	dataRoot [32]byte
+}
+
+// validatorHistory holds the spans and attestations of a validator for the epochs in
+// (latestEpoch - historyLength, latestEpoch], in ring buffers indexed by epoch.
+//
+// The min span of an epoch is the smallest distance from it to the target of an attestation
+// with a later source, and its max span is the largest distance from it to the target of an
+// attestation with an earlier source. An attestation with source s and target t surrounds a
+// previous one if the min span of s is smaller than t - s, and is surrounded by a previous one
+// if the max span of s is larger than t - s.
+type validatorHistory struct {
+	latestEpoch types.Epoch
+	minSpans    []uint16
+	maxSpans    []uint16
+	atts        []*attRecord
+}
+
+// Detector detects slashable attestations against the attestations it previously received.
+type Detector struct {
+	lock          sync.Mutex
+	historyLength types.Epoch
+	validators    map[types.ValidatorIndex]*validatorHistory
+}
+
+// NewDetector creates a detector keeping spans and attestations for the given number of recent
+// epochs.
+func NewDetector(historyLength types.Epoch) (*Detector, error) {
+	if historyLength == 0 || historyLength >= noMinSpan {
+		return nil, errors.New("history length must be between 1 and 65534 epochs")
+	}
+	return &Detector{
+		historyLength: historyLength,
+		validators:    make(map[types.ValidatorIndex]*validatorHistory),
+	}, nil
+}
+
+// DetectSlashableAttestation checks an attestation for double and surround votes against the
+// attestations previously received from its attesting validators, records it, and returns the
+// attester slashings for the offences found. Attestations whose target is older than the history
+// of a validator are not checked for that validator.
+func (d *Detector) DetectSlashableAttestation(att *ethpb.IndexedAttestation) ([]*ethpb.AttesterSlashing, error) {
+	if att == nil || att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+		return nil, errors.New("nil indexed attestation")
+	}
+	source, target := att.Data.Source.Epoch, att.Data.Target.Epoch
+	if source > target {
+		return nil, errors.New("attestation source epoch is later than its target epoch")
+	}
+	dataRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	rec := &attRecord{att: att, dataRoot: dataRoot}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	// Validators attesting together often make the same offence, reported by a single slashing.
+	seen := make(map[[2]*attRecord]bool)
+	var slashings []*ethpb.AttesterSlashing
+	report := func(first, second *attRecord) {
+		if first == nil || second == nil || seen[[2]*attRecord{first, second}] {
+			return
+		}
+		seen[[2]*attRecord{first, second}] = true
+		slashings = append(slashings, &ethpb.AttesterSlashing{
+			Attestation_1: first.att,
+			Attestation_2: second.att,
+		})
+	}
+	for _, idx := range att.AttestingIndices {
+		h := d.history(types.ValidatorIndex(idx))
+		h.advance(target)
+		if !h.covers(target) {
+			continue
+		}
+		if existing := h.atts[h.index(target)]; existing != nil {
+			if existing.dataRoot != dataRoot {
+				report(existing, rec)
+			} else if len(att.AttestingIndices) > len(existing.att.AttestingIndices) {
+				// Aggregates replace the attestations they include, so that fewer are kept.
+				h.atts[h.index(target)] = rec
+			}
+			continue
+		}
+		if h.covers(source) {
+			distance := uint16(target - source)
+			if minSpan := h.minSpans[h.index(source)]; minSpan < distance {
+				report(rec, h.atts[h.index(source+types.Epoch(minSpan))])
+			}
+			if maxSpan := h.maxSpans[h.index(source)]; maxSpan > distance {
+				report(h.atts[h.index(source+types.Epoch(maxSpan))], rec)
+			}
+		}
+		h.update(source, target)
+		h.atts[h.index(target)] = rec
+	}
+	return slashings, nil
+}
+
+func (d *Detector) history(idx types.ValidatorIndex) *validatorHistory {
+	h, ok := d.validators[idx]
+	if !ok {
+		h = &validatorHistory{
+			minSpans: make([]uint16, d.historyLength),
+			maxSpans: make([]uint16, d.historyLength),
+			atts:     make([]*attRecord, d.historyLength),
+		}
+		for i := range h.minSpans {
+			h.minSpans[i] = noMinSpan
+		}
+		d.validators[idx] = h
+	}
+	return h
+}
+
+func (h *validatorHistory) length() types.Epoch {
+	return types.Epoch(len(h.atts))
+}
+
+func (h *validatorHistory) index(epoch types.Epoch) uint64 {
+	return uint64(epoch % h.length())
+}
+
+func (h *validatorHistory) covers(epoch types.Epoch) bool {
+	return epoch <= h.latestEpoch && epoch+h.length() > h.latestEpoch
+}
+
+// advance moves the history forward to end at the given epoch, clearing the epochs it drops.
+func (h *validatorHistory) advance(epoch types.Epoch) {
+	if epoch <= h.latestEpoch {
+		return
+	}
+	start := h.latestEpoch + 1
+	if epoch-h.latestEpoch > h.length() {
+		start = epoch + 1 - h.length()
+	}
+	for e := start; e <= epoch; e++ {
+		i := h.index(e)
+		h.minSpans[i] = noMinSpan
+		h.maxSpans[i] = 0
+		h.atts[i] = nil
+	}
+	h.latestEpoch = epoch
+}
+
+// update records the spans of an attestation in the epochs of the history. Updates stop at the
+// first epoch whose span is not changed, as the spans of the epochs beyond it are not either.
+func (h *validatorHistory) update(source, target types.Epoch) {
+	for e := source; e > 0 && h.covers(e-1); e-- {
+		i := h.index(e - 1)
+		distance := uint16(target - (e - 1))
+		if h.minSpans[i] <= distance {
+			break
+		}
+		h.minSpans[i] = distance
+	}
+	for e := source + 1; e < target; e++ {
+		if !h.covers(e) {
+			continue
+		}
+		i := h.index(e)
+		distance := uint16(target - e)
+		if h.maxSpans[i] >= distance {
+			break
+		}
+		h.maxSpans[i] = distance
+	}
+}
@@ -0,0 +1,161 @@
+package spans
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func createAttestation(source, target types.Epoch, root byte, indices ...uint64) *ethpb.IndexedAttestation {
+	blockRoot := make([]byte, 32)
+	blockRoot[0] = root
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &ethpb.AttestationData{
+			Slot:            0,
+			BeaconBlockRoot: blockRoot,
+			Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+}
+
+func TestNewDetector_InvalidHistoryLength(t *testing.T) {
+	_, err := NewDetector(0)
+	assert.ErrorContains(t, "history length must be between", err)
+	_, err = NewDetector(1 << 16)
+	assert.ErrorContains(t, "history length must be between", err)
+}
+
+func TestDetector_DetectSlashableAttestation(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  *ethpb.IndexedAttestation
+		incoming  *ethpb.IndexedAttestation
+		slashable bool
+		// surrounding is true if the incoming attestation surrounds the existing one.
+		surrounding bool
+	}{
+		{
+			name:      "same attestation",
+			existing:  createAttestation(1, 2, 0, 1),
+			incoming:  createAttestation(1, 2, 0, 1),
+			slashable: false,
+		},
+		{
+			name:      "double vote",
+			existing:  createAttestation(1, 2, 0, 1),
+			incoming:  createAttestation(1, 2, 1, 1),
+			slashable: true,
+		},
+		{
+			name:        "surrounding vote",
+			existing:    createAttestation(3, 4, 0, 1),
+			incoming:    createAttestation(2, 5, 0, 1),
+			slashable:   true,
+			surrounding: true,
+		},
+		{
+			name:      "surrounded vote",
+			existing:  createAttestation(2, 8, 0, 1),
+			incoming:  createAttestation(3, 4, 0, 1),
+			slashable: true,
+		},
+		{
+			name:      "consecutive votes",
+			existing:  createAttestation(1, 2, 0, 1),
+			incoming:  createAttestation(2, 3, 0, 1),
+			slashable: false,
+		},
+		{
+			name:      "skipped epochs",
+			existing:  createAttestation(1, 4, 0, 1),
+			incoming:  createAttestation(4, 9, 0, 1),
+			slashable: false,
+		},
+		{
+			name:      "other validator",
+			existing:  createAttestation(1, 2, 0, 1),
+			incoming:  createAttestation(1, 2, 1, 2),
+			slashable: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDetector(DefaultHistoryLength)
+			require.NoError(t, err)
+			slashings, err := d.DetectSlashableAttestation(tt.existing)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(slashings))
+			slashings, err = d.DetectSlashableAttestation(tt.incoming)
+			require.NoError(t, err)
+			if !tt.slashable {
+				assert.Equal(t, 0, len(slashings))
+				return
+			}
+			require.Equal(t, 1, len(slashings))
+			first, second := tt.existing, tt.incoming
+			if tt.surrounding {
+				first, second = tt.incoming, tt.existing
+			}
+			assert.Equal(t, first, slashings[0].Attestation_1)
+			assert.Equal(t, second, slashings[0].Attestation_2)
+		})
+	}
+}
+
+func TestDetector_DetectSlashableAttestation_Aggregates(t *testing.T) {
+	d, err := NewDetector(DefaultHistoryLength)
+	require.NoError(t, err)
+	_, err = d.DetectSlashableAttestation(createAttestation(3, 4, 0, 1))
+	require.NoError(t, err)
+	_, err = d.DetectSlashableAttestation(createAttestation(3, 4, 0, 2))
+	require.NoError(t, err)
+	aggregate := createAttestation(3, 4, 0, 1, 2, 3)
+	slashings, err := d.DetectSlashableAttestation(aggregate)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slashings))
+
+	// The validators of the aggregate surrounded by the same attestation are reported together.
+	surrounding := createAttestation(2, 6, 0, 1, 2, 3, 4)
+	slashings, err = d.DetectSlashableAttestation(surrounding)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	assert.Equal(t, surrounding, slashings[0].Attestation_1)
+	assert.Equal(t, aggregate, slashings[0].Attestation_2)
+}
+
+func TestDetector_DetectSlashableAttestation_History(t *testing.T) {
+	d, err := NewDetector(4)
+	require.NoError(t, err)
+	_, err = d.DetectSlashableAttestation(createAttestation(2, 3, 0, 1))
+	require.NoError(t, err)
+	_, err = d.DetectSlashableAttestation(createAttestation(9, 10, 0, 1))
+	require.NoError(t, err)
+
+	// Attestations older than the history are neither checked nor recorded.
+	slashings, err := d.DetectSlashableAttestation(createAttestation(2, 3, 1, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slashings))
+	slashings, err = d.DetectSlashableAttestation(createAttestation(1, 4, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slashings))
+
+	// Surround votes are detected within the history.
+	slashings, err = d.DetectSlashableAttestation(createAttestation(8, 11, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(slashings))
+}
+
+func TestDetector_DetectSlashableAttestation_Invalid(t *testing.T) {
+	d, err := NewDetector(DefaultHistoryLength)
+	require.NoError(t, err)
+	_, err = d.DetectSlashableAttestation(&ethpb.IndexedAttestation{})
+	assert.ErrorContains(t, "nil indexed attestation", err)
+	_, err = d.DetectSlashableAttestation(createAttestation(3, 2, 0, 1))
+	assert.ErrorContains(t, "source epoch is later", err)
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"encoding/binary"
 	"fmt"
 	"reflect"
 	"sync"
@@ -13,6 +14,9 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
 
+// balancesPerChunk is the number of 8-byte balances packed into a 32-byte leaf of the balances trie.
+const balancesPerChunk = 4
+
 // FieldTrie is the representation of the representative
 // trie of the particular field.
 type FieldTrie struct {
@@ -20,6 +24,9 @@ type FieldTrie struct {
 	*reference
 	fieldLayers [][]*[32]byte
 	field       fieldIndex
+	// numOfElems is the number of elements of a compressed array, whose leaves each pack
+	// several elements.
+	numOfElems int
 }
 
 // NewFieldTrie is the constructor for the field trie data structure. It creates the corresponding
@@ -56,6 +63,18 @@ func NewFieldTrie(field fieldIndex, elements interface{}, length uint64) (*Field
 			reference:   &reference{refs: 1},
 			Mutex:       new(sync.Mutex),
 		}, nil
+	case compressedArray:
+		numOfElems, err := elementsLength(elements)
+		if err != nil {
+			return nil, err
+		}
+		return &FieldTrie{
+			fieldLayers: stateutil.ReturnTrieLayerVariable(fieldRoots, length),
+			field:       field,
+			reference:   &reference{refs: 1},
+			Mutex:       new(sync.Mutex),
+			numOfElems:  numOfElems,
+		}, nil
 	default:
 		return nil, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -76,6 +95,10 @@ func (f *FieldTrie) RecomputeTrie(indices []uint64, elements interface{}) ([32]b
 	if !ok {
 		return [32]byte{}, errors.Errorf("unrecognized field in trie")
 	}
+	if datType == compressedArray {
+		// The changed elements are converted to the indices of the chunks packing them.
+		indices = chunkIndices(indices)
+	}
 	fieldRoots, err := fieldConverters(f.field, indices, elements, false)
 	if err != nil {
 		return [32]byte{}, err
@@ -93,6 +116,17 @@ func (f *FieldTrie) RecomputeTrie(indices []uint64, elements interface{}) ([32]b
 			return [32]byte{}, err
 		}
 		return stateutil.AddInMixin(fieldRoot, uint64(len(f.fieldLayers[0])))
+	case compressedArray:
+		numOfElems, err := elementsLength(elements)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		fieldRoot, f.fieldLayers, err = stateutil.RecomputeFromLayerVariable(fieldRoots, indices, f.fieldLayers)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		f.numOfElems = numOfElems
+		return stateutil.AddInMixin(fieldRoot, uint64(f.numOfElems))
 	default:
 		return [32]byte{}, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -119,6 +153,7 @@ func (f *FieldTrie) CopyTrie() *FieldTrie {
 		field:       f.field,
 		reference:   &reference{refs: 1},
 		Mutex:       new(sync.Mutex),
+		numOfElems:  f.numOfElems,
 	}
 }
 
@@ -134,6 +169,9 @@ func (f *FieldTrie) TrieRoot() ([32]byte, error) {
 	case compositeArray:
 		trieRoot := *f.fieldLayers[len(f.fieldLayers)-1][0]
 		return stateutil.AddInMixin(trieRoot, uint64(len(f.fieldLayers[0])))
+	case compressedArray:
+		trieRoot := *f.fieldLayers[len(f.fieldLayers)-1][0]
+		return stateutil.AddInMixin(trieRoot, uint64(f.numOfElems))
 	default:
 		return [32]byte{}, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -170,6 +208,13 @@ func fieldConverters(field fieldIndex, indices []uint64, elements interface{}, c
 				reflect.TypeOf([]*pb.PendingAttestation{}).Name(), reflect.TypeOf(elements).Name())
 		}
 		return handlePendingAttestation(val, indices, convertAll)
+	case balances:
+		val, ok := elements.([]uint64)
+		if !ok {
+			return nil, errors.Errorf("Wanted type of %v but got %v",
+				reflect.TypeOf([]uint64{}).Name(), reflect.TypeOf(elements).Name())
+		}
+		return handleBalanceSlice(val, indices, convertAll)
 	default:
 		return [][32]byte{}, errors.Errorf("got unsupported type of %v", reflect.TypeOf(elements).Name())
 	}
@@ -315,3 +360,53 @@ func handlePendingAttestation(val []*pb.PendingAttestation, indices []uint64, co
 	}
 	return roots, nil
 }
+
+// handleBalanceSlice returns the roots of the chunks packing the balances, the indices being
+// those of the chunks.
+func handleBalanceSlice(val []uint64, indices []uint64, convertAll bool) ([][32]byte, error) {
+	numOfChunks := (uint64(len(val)) + balancesPerChunk - 1) / balancesPerChunk
+	chunkRoot := func(idx uint64) [32]byte {
+		var root [32]byte
+		for i := uint64(0); i < balancesPerChunk && idx*balancesPerChunk+i < uint64(len(val)); i++ {
+			binary.LittleEndian.PutUint64(root[i*8:(i+1)*8], val[idx*balancesPerChunk+i])
+		}
+		return root
+	}
+	if convertAll {
+		roots := make([][32]byte, 0, numOfChunks)
+		for i := uint64(0); i < numOfChunks; i++ {
+			roots = append(roots, chunkRoot(i))
+		}
+		return roots, nil
+	}
+	roots := make([][32]byte, 0, len(indices))
+	for _, idx := range indices {
+		if idx >= numOfChunks {
+			return nil, fmt.Errorf("index %d greater than number of balance chunks %d", idx, numOfChunks)
+		}
+		roots = append(roots, chunkRoot(idx))
+	}
+	return roots, nil
+}
+
+// chunkIndices converts the sorted indices of balances to the indices of the chunks packing them.
+func chunkIndices(indices []uint64) []uint64 {
+	chunks := make([]uint64, 0, len(indices))
+	for _, idx := range indices {
+		chunk := idx / balancesPerChunk
+		if len(chunks) == 0 || chunks[len(chunks)-1] != chunk {
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks
+}
+
+// elementsLength returns the number of elements of a compressed array.
+func elementsLength(elements interface{}) (int, error) {
+	val, ok := elements.([]uint64)
+	if !ok {
+		return 0, errors.Errorf("Wanted type of %v but got %v",
+			reflect.TypeOf([]uint64{}).Name(), reflect.TypeOf(elements).Name())
+	}
+	return len(val), nil
+}
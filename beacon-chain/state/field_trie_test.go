@@ -54,6 +54,32 @@ func TestFieldTrie_RecomputeTrie(t *testing.T) {
 	assert.Equal(t, expectedRoot, root)
 }
 
+func TestFieldTrie_RecomputeTrie_Balances(t *testing.T) {
+	newState, _ := testutil.DeterministicGenesisState(t, 30)
+	balLimit := (params.BeaconConfig().ValidatorRegistryLimit*8 + 31) / 32
+	// 12 represents the enum value of balances
+	trie, err := state.NewFieldTrie(12, newState.Balances(), balLimit)
+	require.NoError(t, err)
+	expectedRoot, err := stateutil.ValidatorBalancesRoot(newState.Balances())
+	require.NoError(t, err)
+	root, err := trie.TrieRoot()
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+
+	// Balances 5 and 6 are packed in the same chunk, and the appended balance in a new one.
+	changedIdx := []uint64{5, 6, 29, 30}
+	require.NoError(t, newState.UpdateBalancesAtIndex(5, 1))
+	require.NoError(t, newState.UpdateBalancesAtIndex(6, 2))
+	require.NoError(t, newState.UpdateBalancesAtIndex(29, 3))
+	require.NoError(t, newState.AppendBalance(4))
+
+	expectedRoot, err = stateutil.ValidatorBalancesRoot(newState.Balances())
+	require.NoError(t, err)
+	root, err = trie.RecomputeTrie(changedIdx, newState.Balances())
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+}
+
 func TestFieldTrie_CopyTrieImmutable(t *testing.T) {
 	newState, _ := testutil.DeterministicGenesisState(t, 32)
 	// 12 represents the enum value of randao mixes.
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"reflect"
 	"runtime"
 	"runtime/debug"
@@ -8,6 +9,7 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
@@ -304,6 +306,51 @@ func TestValidatorReferences_RemainsConsistent(t *testing.T) {
 	}))
 }
 
+func TestBalancesTrie_SharedUntilWritten(t *testing.T) {
+	ctx := context.Background()
+	a, err := InitializeFromProtoUnsafe(&p2ppb.BeaconState{
+		Balances: []uint64{1, 2, 3, 4, 5, 6},
+	})
+	require.NoError(t, err)
+	_, err = a.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	// The balances trie is built once balances are written after the first root computation.
+	require.NoError(t, a.UpdateBalancesAtIndex(0, 1))
+	_, err = a.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	// The copy shares the balances trie until it is written.
+	b := a.Copy()
+	assert.Equal(t, uint(2), b.stateFieldLeaves[balances].Refs())
+	require.NoError(t, b.UpdateBalancesAtIndex(5, 60))
+	require.NoError(t, b.AppendBalance(7))
+	assert.DeepEqual(t, []uint64{5}, b.dirtyIndices[balances][:1])
+	bRoot, err := b.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), a.stateFieldLeaves[balances].Refs())
+	assert.Equal(t, uint(1), b.stateFieldLeaves[balances].Refs())
+
+	wantRoot, err := stateutil.ValidatorBalancesRoot([]uint64{1, 2, 3, 4, 5, 60, 7})
+	require.NoError(t, err)
+	gotRoot, err := b.stateFieldLeaves[balances].TrieRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+	wantState, err := InitializeFromProtoUnsafe(&p2ppb.BeaconState{
+		Balances: []uint64{1, 2, 3, 4, 5, 60, 7},
+	})
+	require.NoError(t, err)
+	wantStateRoot, err := wantState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantStateRoot, bRoot)
+
+	// The original state keeps the root of its own balances.
+	wantRoot, err = stateutil.ValidatorBalancesRoot([]uint64{1, 2, 3, 4, 5, 6})
+	require.NoError(t, err)
+	gotRoot, err = a.stateFieldLeaves[balances].TrieRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+}
+
 // assertRefCount checks whether reference count for a given state
 // at a given index is equal to expected amount.
 func assertRefCount(t *testing.T, b *BeaconState, idx fieldIndex, want uint) {
@@ -2,6 +2,7 @@ package stategen
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
@@ -173,6 +174,24 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (*state
 	}
 	targetSlot := summary.Slot
 
+	// The state has to be regenerated, which waits for the regenerations in progress beyond the limit.
+	release, err := s.acquireRegen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	// The state may have been regenerated by one of them.
+	if cachedState := s.hotStateCache.get(blockRoot); cachedState != nil {
+		return cachedState, nil
+	}
+	if s.beaconDB.HasState(ctx, blockRoot) {
+		return s.beaconDB.State(ctx, blockRoot)
+	}
+	start := time.Now()
+	defer func() {
+		regenDuration.Observe(time.Since(start).Seconds())
+	}()
+
 	// Since the requested state is not in caches, start replaying using the last available ancestor state which is
 	// retrieved using input block's parent root.
 	startState, err := s.lastAncestorState(ctx, blockRoot)
@@ -195,7 +214,11 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (*state
 
 	replayBlockCount.Observe(float64(len(blks)))
 
-	return s.ReplayBlocks(ctx, startState, blks, targetSlot)
+	// Intermediate states are only saved for hot states, which are not regenerated from archived points.
+	s.finalizedInfo.lock.RLock()
+	hot := startState.Slot() >= s.finalizedInfo.slot
+	s.finalizedInfo.lock.RUnlock()
+	return s.replayBlocks(ctx, startState, blks, targetSlot, hot /* saveIntermediateStates */)
 }
 
 // This loads a state by slot.
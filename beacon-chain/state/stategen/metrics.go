@@ -13,4 +13,30 @@ var (
 			Buckets: []float64{64, 256, 1024, 2048, 4096},
 		},
 	)
+	regenDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "state_regen_duration_seconds",
+			Help:    "The time it takes to regenerate a state by replaying blocks",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+	)
+	regenWaitTime = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "state_regen_wait_seconds",
+			Help:    "The time a state regeneration waits for the other regenerations to complete",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10},
+		},
+	)
+	regenQueueLength = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "state_regen_queue_length",
+		Help: "The number of state regenerations waiting for the other regenerations to complete",
+	})
+	intermediateStatesSaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "intermediate_hot_states_saved_total",
+		Help: "The number of intermediate hot states persisted to the DB while replaying blocks",
+	})
+	archivedPointStatesSaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "archived_point_states_saved_total",
+		Help: "The number of missing archived point states regenerated and saved to the cold section",
+	})
 )
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	types "github.com/prysmaticlabs/eth2-types"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/sirupsen/logrus"
@@ -80,6 +81,8 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 			}
 
 			if s.beaconDB.HasState(ctx, aRoot) {
+				// The state of the archived point may have been saved as an intermediate state.
+				s.keepIntermediateState(aRoot)
 				// Remove hot state DB root to prevent it gets deleted later when we turn hot state save DB mode off.
 				s.saveHotStateDB.lock.Lock()
 				roots := s.saveHotStateDB.savedStateRoots
@@ -106,6 +109,10 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 		}
 	}
 
+	if err := s.deleteFinalizedIntermediateStates(ctx, fSlot); err != nil {
+		return err
+	}
+
 	// Update finalized info in memory.
 	fInfo, ok, err := s.epochBoundaryStateCache.getByRoot(fRoot)
 	if err != nil {
@@ -117,3 +124,46 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// This saves the states of the archived points below the finalized slot which are missing in the DB,
+// as happens once the slots per archived point is lowered. The state of each archived point is
+// replayed from the closest saved ancestor state, which is usually the previous archived point, so
+// the cold section is re-densified without a resync.
+func (s *State) densifyArchivedPoints(ctx context.Context, fSlot types.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.densifyArchivedPoints")
+	defer span.End()
+
+	saved := 0
+	for slot := s.slotsPerArchivedPoint; slot < fSlot; slot += s.slotsPerArchivedPoint {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Like during the migration to the cold section, the state of an archived point is the state
+		// of the highest block at or below the archived point slot.
+		aRoot, _, err := s.lastSavedBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if aRoot == [32]byte{} || s.beaconDB.HasState(ctx, aRoot) {
+			continue
+		}
+		aState, err := s.StateByRoot(ctx, aRoot)
+		if err != nil {
+			return err
+		}
+		if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
+			return err
+		}
+		saved++
+		archivedPointStatesSaved.Inc()
+	}
+
+	if saved > 0 {
+		log.WithFields(logrus.Fields{
+			"count":                 saved,
+			"slotsPerArchivedPoint": s.slotsPerArchivedPoint,
+		}).Info("Saved missing archived point states")
+	}
+	return nil
+}
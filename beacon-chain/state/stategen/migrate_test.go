@@ -134,3 +134,41 @@ func TestMigrateToCold_StateExistsInDB(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{{1}, {2}, {3}, {4}}, service.saveHotStateDB.savedStateRoots)
 	assert.LogsDoNotContain(t, hook, "Saved state in DB")
 }
+
+func TestDensifyArchivedPoints(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB)
+	service.slotsPerArchivedPoint = 2
+	beaconState, pks := testutil.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	require.NoError(t, beaconDB.SaveBlock(ctx, genesis))
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	b1, err := testutil.GenerateFullBlock(beaconState, pks, testutil.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, service.beaconDB.SaveBlock(ctx, b1))
+	require.NoError(t, service.beaconDB.SaveStateSummary(ctx, &pb.StateSummary{Slot: 1, Root: r1[:]}))
+
+	// The archived point at slot 2 has no state, as if it was pruned with a larger interval.
+	require.NoError(t, service.densifyArchivedPoints(ctx, 4))
+	s1, err := service.beaconDB.State(ctx, r1)
+	require.NoError(t, err)
+	require.NotNil(t, s1, "Did not save archived point state")
+	assert.Equal(t, types.Slot(1), s1.Slot())
+	require.LogsContain(t, hook, "Saved missing archived point states")
+
+	// Archived points already in the DB are not regenerated.
+	hook.Reset()
+	require.NoError(t, service.densifyArchivedPoints(ctx, 4))
+	require.LogsDoNotContain(t, hook, "Saved missing archived point states")
+}
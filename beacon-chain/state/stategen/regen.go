@@ -0,0 +1,99 @@
+package stategen
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// maxConcurrentRegens is the number of state regenerations running at once. Further regenerations
+// wait in line, so that bursts of historical queries don't starve block processing and the other
+// users of the CPU.
+const maxConcurrentRegens = 4
+
+// intermediateStateInterval is the number of slots between the intermediate hot states persisted
+// while replaying blocks.
+var intermediateStateInterval = defaultHotStateDBInterval
+
+// This tracks the hot states persisted to the DB while replaying blocks, so that they are deleted
+// once finalized.
+type intermediateStates struct {
+	lock  sync.Mutex
+	roots map[[32]byte]types.Slot
+}
+
+// This waits for a regeneration slot, and returns the function releasing it.
+func (s *State) acquireRegen(ctx context.Context) (func(), error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.acquireRegen")
+	defer span.End()
+
+	start := time.Now()
+	regenQueueLength.Inc()
+	defer regenQueueLength.Dec()
+	select {
+	case s.regenSem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	regenWaitTime.Observe(time.Since(start).Seconds())
+	return func() {
+		<-s.regenSem
+	}, nil
+}
+
+// This persists a hot state reached while replaying blocks as an intermediate state, from which
+// later regenerations of its descendant states start instead of the same distant ancestor.
+func (s *State) saveIntermediateState(ctx context.Context, blockRoot [32]byte, st *state.BeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.saveIntermediateState")
+	defer span.End()
+
+	if s.beaconDB.HasState(ctx, blockRoot) {
+		return nil
+	}
+	if err := s.beaconDB.SaveState(ctx, st, blockRoot); err != nil {
+		return err
+	}
+	s.intermediateStates.lock.Lock()
+	s.intermediateStates.roots[blockRoot] = st.Slot()
+	s.intermediateStates.lock.Unlock()
+	intermediateStatesSaved.Inc()
+	log.WithFields(logrus.Fields{
+		"slot": st.Slot(),
+	}).Debug("Saved intermediate hot state to DB")
+	return nil
+}
+
+// This stops tracking an intermediate state which is kept in the DB, such as an archived point state.
+func (s *State) keepIntermediateState(blockRoot [32]byte) {
+	s.intermediateStates.lock.Lock()
+	defer s.intermediateStates.lock.Unlock()
+	delete(s.intermediateStates.roots, blockRoot)
+}
+
+// This deletes the intermediate states below the finalized slot, which are no longer hot.
+func (s *State) deleteFinalizedIntermediateStates(ctx context.Context, fSlot types.Slot) error {
+	s.intermediateStates.lock.Lock()
+	defer s.intermediateStates.lock.Unlock()
+
+	roots := make([][32]byte, 0)
+	for root, slot := range s.intermediateStates.roots {
+		if slot < fSlot {
+			roots = append(roots, root)
+		}
+	}
+	if len(roots) == 0 {
+		return nil
+	}
+	if err := s.beaconDB.DeleteStates(ctx, roots); err != nil {
+		return err
+	}
+	for _, root := range roots {
+		delete(s.intermediateStates.roots, root)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestAcquireRegen_WaitsForRunningRegens(t *testing.T) {
+	service := New(testDB.SetupDB(t))
+	releases := make([]func(), 0, maxConcurrentRegens)
+	for i := 0; i < maxConcurrentRegens; i++ {
+		release, err := service.acquireRegen(context.Background())
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+
+	// All the regenerations are running, so the next one waits until its context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := service.acquireRegen(ctx)
+	assert.ErrorContains(t, context.Canceled.Error(), err)
+
+	releases[0]()
+	release, err := service.acquireRegen(context.Background())
+	require.NoError(t, err)
+	release()
+	for _, release := range releases[1:] {
+		release()
+	}
+	assert.Equal(t, 0, len(service.regenSem))
+}
+
+func TestIntermediateStates_DeletedOnceFinalized(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+
+	saveState := func(slot types.Slot, root [32]byte) {
+		st, _ := testutil.DeterministicGenesisState(t, 32)
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, service.saveIntermediateState(ctx, root, st))
+	}
+	saveState(10, [32]byte{'a'})
+	saveState(20, [32]byte{'b'})
+	saveState(30, [32]byte{'c'})
+	// The archived point state is kept once finalized.
+	service.keepIntermediateState([32]byte{'b'})
+
+	require.NoError(t, service.deleteFinalizedIntermediateStates(ctx, 30))
+	assert.Equal(t, false, beaconDB.HasState(ctx, [32]byte{'a'}))
+	assert.Equal(t, true, beaconDB.HasState(ctx, [32]byte{'b'}))
+	assert.Equal(t, true, beaconDB.HasState(ctx, [32]byte{'c'}))
+	assert.DeepEqual(t, map[[32]byte]types.Slot{{'c'}: 30}, service.intermediateStates.roots)
+}
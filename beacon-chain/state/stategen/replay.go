@@ -19,7 +19,21 @@ func (s *State) ReplayBlocks(ctx context.Context, state *stateTrie.BeaconState,
 	ctx, span := trace.StartSpan(ctx, "stateGen.ReplayBlocks")
 	defer span.End()
 
+	return s.replayBlocks(ctx, state, signed, targetSlot, false /* saveIntermediateStates */)
+}
+
+// This replays the input blocks on the input state until the target slot is reached. When saving
+// intermediate states, the post state of the first block of every intermediateStateInterval slots
+// is persisted to the DB along the way.
+func (s *State) replayBlocks(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	signed []*ethpb.SignedBeaconBlock,
+	targetSlot types.Slot,
+	saveIntermediateStates bool,
+) (*stateTrie.BeaconState, error) {
 	var err error
+	nextIntermediateSlot := (state.Slot()/intermediateStateInterval + 1) * intermediateStateInterval
 	// The input block list is sorted in decreasing slots order.
 	if len(signed) > 0 {
 		for i := len(signed) - 1; i >= 0; i-- {
@@ -37,6 +51,16 @@ func (s *State) ReplayBlocks(ctx context.Context, state *stateTrie.BeaconState,
 			if err != nil {
 				return nil, err
 			}
+			if saveIntermediateStates && state.Slot() >= nextIntermediateSlot && state.Slot() < targetSlot {
+				root, err := signed[i].Block.HashTreeRoot()
+				if err != nil {
+					return nil, err
+				}
+				if err := s.saveIntermediateState(ctx, root, state.Copy()); err != nil {
+					return nil, err
+				}
+				nextIntermediateSlot = (state.Slot()/intermediateStateInterval + 1) * intermediateStateInterval
+			}
 		}
 	}
 
@@ -48,6 +48,8 @@ type State struct {
 	finalizedInfo           *finalizedInfo
 	epochBoundaryStateCache *epochBoundaryState
 	saveHotStateDB          *saveHotStateDbConfig
+	regenSem                chan struct{}
+	intermediateStates      *intermediateStates
 }
 
 // This tracks the config in the event of long non-finality,
@@ -80,6 +82,10 @@ func New(beaconDB db.NoHeadAccessDatabase) *State {
 		saveHotStateDB: &saveHotStateDbConfig{
 			duration: defaultHotStateDBInterval,
 		},
+		regenSem: make(chan struct{}, maxConcurrentRegens),
+		intermediateStates: &intermediateStates{
+			roots: make(map[[32]byte]types.Slot),
+		},
 	}
 }
 
@@ -105,13 +111,18 @@ func (s *State) Resume(ctx context.Context) (*state.BeaconState, error) {
 		return nil, errors.New("finalized state not found in disk")
 	}
 
-	go func() {
+	s.finalizedInfo = &finalizedInfo{slot: fState.Slot(), root: fRoot, state: fState.Copy()}
+
+	// The archived points follow the current slots per archived point: the states which are no longer
+	// on an archived point are pruned, and the states of new archived points are regenerated.
+	go func(fSlot types.Slot) {
 		if err := s.beaconDB.CleanUpDirtyStates(ctx, s.slotsPerArchivedPoint); err != nil {
 			log.WithError(err).Error("Could not clean up dirty states")
 		}
-	}()
-
-	s.finalizedInfo = &finalizedInfo{slot: fState.Slot(), root: fRoot, state: fState.Copy()}
+		if err := s.densifyArchivedPoints(ctx, fSlot); err != nil {
+			log.WithError(err).Error("Could not save missing archived point states")
+		}
+	}(fState.Slot())
 
 	return fState, nil
 }
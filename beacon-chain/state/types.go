@@ -24,6 +24,9 @@ func init() {
 	fieldMap[validators] = compositeArray
 	fieldMap[previousEpochAttestations] = compositeArray
 	fieldMap[currentEpochAttestations] = compositeArray
+
+	// Initialize the compressed arrays.
+	fieldMap[balances] = compressedArray
 }
 
 type fieldIndex int
@@ -64,6 +67,8 @@ const (
 const (
 	basicArray dataType = iota
 	compositeArray
+	// compressedArray is a variable sized list of basic values packed into 32-byte chunks.
+	compressedArray
 )
 
 // fieldMap keeps track of each field
@@ -0,0 +1,303 @@
+// Package backfill downloads the blocks below the finalized checkpoint a node was started from,
+// back to genesis, so that a checkpoint synced node can serve the full chain to its peers.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/abool"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/rand"
+	"github.com/sirupsen/logrus"
+)
+
+var _ shared.Service = (*Service)(nil)
+
+const (
+	// batchSize is the number of slots requested from a peer at a time.
+	batchSize = 64
+	// retryInterval is the time waited for before retrying when no peer could serve a batch.
+	retryInterval = 5 * time.Second
+)
+
+var errNoPeers = errors.New("no peers to backfill blocks from")
+
+// Config to set up the backfill service.
+type Config struct {
+	P2P         p2p.P2P
+	DB          db.NoHeadAccessDatabase
+	InitialSync prysmsync.Checker
+}
+
+// Service backfills the blocks below the checkpoint sync origin.
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	p2p         p2p.P2P
+	db          db.NoHeadAccessDatabase
+	initialSync prysmsync.Checker
+	running     *abool.AtomicBool
+	// originState is used to verify the proposer signatures of the backfilled blocks, as the
+	// public keys of validators never change.
+	originState *stateTrie.BeaconState
+	originEpoch types.Epoch
+	genesisRoot [32]byte
+	// lowest is the lowest block in the db, whose parent is backfilled next.
+	lowest *ethpb.SignedBeaconBlock
+}
+
+// NewService configures the backfill service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		p2p:         cfg.P2P,
+		db:          cfg.DB,
+		initialSync: cfg.InitialSync,
+		running:     abool.New(),
+	}
+}
+
+// Start backfills blocks to genesis once initial sync is complete, if the node was started from a
+// checkpoint.
+func (s *Service) Start() {
+	originRoot, err := s.db.OriginBlockRoot(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get checkpoint sync origin")
+		return
+	}
+	if originRoot == params.BeaconConfig().ZeroHash {
+		return
+	}
+	if err := s.initialize(originRoot); err != nil {
+		log.WithError(err).Error("Could not initialize block backfill")
+		return
+	}
+	if s.done() {
+		log.Debug("Blocks are backfilled to genesis")
+		return
+	}
+	s.running.Set()
+	defer s.running.UnSet()
+	if !s.waitForInitialSync() {
+		return
+	}
+	log.WithField("slot", s.lowest.Block.Slot).Info("Backfilling blocks below the checkpoint sync origin")
+	for !s.done() {
+		if err := s.backfillBatch(); err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Debug("Could not backfill blocks")
+			select {
+			case <-time.After(retryInterval):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}
+	log.Info("Backfilled blocks to genesis")
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status returns an error while blocks are being backfilled.
+func (s *Service) Status() error {
+	if s.running.IsSet() {
+		return errors.New("backfilling blocks")
+	}
+	return nil
+}
+
+func (s *Service) initialize(originRoot [32]byte) error {
+	genesisBlock, err := s.db.GenesisBlock(s.ctx)
+	if err != nil {
+		return err
+	}
+	if genesisBlock == nil || genesisBlock.Block == nil {
+		return errors.New("no genesis block in db")
+	}
+	s.genesisRoot, err = genesisBlock.Block.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	s.originState, err = s.db.State(s.ctx, originRoot)
+	if err != nil {
+		return err
+	}
+	if s.originState == nil {
+		return errors.New("no checkpoint sync origin state in db")
+	}
+	s.originEpoch = helpers.SlotToEpoch(s.originState.Slot())
+	lowestRoot, err := s.db.BackfillBlockRoot(s.ctx)
+	if err != nil {
+		return err
+	}
+	if lowestRoot == params.BeaconConfig().ZeroHash {
+		lowestRoot = originRoot
+	}
+	lowest, err := s.db.Block(s.ctx, lowestRoot)
+	if err != nil {
+		return err
+	}
+	if lowest == nil || lowest.Block == nil {
+		return fmt.Errorf("no block in db for backfill root %#x", lowestRoot)
+	}
+	s.lowest = lowest
+	return nil
+}
+
+// done returns true once the parent of the lowest block is the genesis block.
+func (s *Service) done() bool {
+	return bytesutil.ToBytes32(s.lowest.Block.ParentRoot) == s.genesisRoot
+}
+
+// waitForInitialSync returns true once initial sync is complete, as following the head of the
+// chain takes priority over the history of the chain.
+func (s *Service) waitForInitialSync() bool {
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for s.initialSync.Syncing() {
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// backfillBatch requests the blocks of the slots below the lowest block from a peer, and saves
+// those descending from its parent. If the parent is not among them, as the slots of a whole batch
+// may be skipped, the parent is requested by root instead.
+func (s *Service) backfillBatch() error {
+	pid, err := s.pickPeer()
+	if err != nil {
+		return err
+	}
+	endSlot := s.lowest.Block.Slot
+	var startSlot types.Slot
+	if endSlot > batchSize {
+		startSlot = endSlot - batchSize
+	}
+	blks, err := prysmsync.SendBeaconBlocksByRangeRequest(s.ctx, s.p2p, pid, &p2ppb.BeaconBlocksByRangeRequest{
+		StartSlot: startSlot,
+		Count:     uint64(endSlot - startSlot),
+		Step:      1,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	chain, err := s.verifiedChain(blks)
+	if err != nil {
+		s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+		return err
+	}
+	if len(chain) == 0 {
+		req := p2ptypes.BeaconBlockByRootsReq{bytesutil.ToBytes32(s.lowest.Block.ParentRoot)}
+		blks, err := prysmsync.SendBeaconBlocksByRootRequest(s.ctx, s.p2p, pid, &req, nil)
+		if err != nil {
+			return err
+		}
+		chain, err = s.verifiedChain(blks)
+		if err != nil {
+			s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			return err
+		}
+		if len(chain) == 0 {
+			return fmt.Errorf("peer %s did not return the parent of block at slot %d", pid, endSlot)
+		}
+	}
+	if err := s.db.SaveBackfilledBlocks(s.ctx, chain); err != nil {
+		return err
+	}
+	s.lowest = chain[len(chain)-1]
+	log.WithFields(logrus.Fields{
+		"peer":      pid,
+		"numBlocks": len(chain),
+		"slot":      s.lowest.Block.Slot,
+	}).Debug("Backfilled blocks")
+	return nil
+}
+
+// verifiedChain returns the blocks that are ancestors of the lowest block, linked to it through their
+// parent roots and carrying valid proposer signatures, ordered by descending slot. The chain ends at
+// the first block that is not the parent of the block after it.
+func (s *Service) verifiedChain(blks []*ethpb.SignedBeaconBlock) ([]*ethpb.SignedBeaconBlock, error) {
+	expected := bytesutil.ToBytes32(s.lowest.Block.ParentRoot)
+	chain := make([]*ethpb.SignedBeaconBlock, 0, len(blks))
+	for i := len(blks) - 1; i >= 0; i-- {
+		blk := blks[i]
+		if blk == nil || blk.Block == nil {
+			return nil, errors.New("nil block")
+		}
+		root, err := blk.Block.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if root != expected {
+			if len(chain) > 0 {
+				break
+			}
+			continue
+		}
+		if err := s.verifySignature(blk); err != nil {
+			return nil, err
+		}
+		chain = append(chain, blk)
+		expected = bytesutil.ToBytes32(blk.Block.ParentRoot)
+		if expected == s.genesisRoot {
+			break
+		}
+	}
+	return chain, nil
+}
+
+func (s *Service) verifySignature(blk *ethpb.SignedBeaconBlock) error {
+	if uint64(blk.Block.ProposerIndex) >= uint64(s.originState.NumValidators()) {
+		return fmt.Errorf("block at slot %d has unknown proposer index %d", blk.Block.Slot, blk.Block.ProposerIndex)
+	}
+	pubKey := s.originState.PubkeyAtIndex(blk.Block.ProposerIndex)
+	domain, err := helpers.Domain(
+		s.originState.Fork(),
+		helpers.SlotToEpoch(blk.Block.Slot),
+		params.BeaconConfig().DomainBeaconProposer,
+		s.originState.GenesisValidatorRoot(),
+	)
+	if err != nil {
+		return err
+	}
+	if err := helpers.VerifyBlockSigningRoot(blk.Block, pubKey[:], blk.Signature, domain); err != nil {
+		return fmt.Errorf("invalid signature of block at slot %d: %v", blk.Block.Slot, err)
+	}
+	return nil
+}
+
+// pickPeer returns a random peer which finalized at least the epoch of the checkpoint sync origin.
+func (s *Service) pickPeer() (peer.ID, error) {
+	_, pids := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, s.originEpoch)
+	if len(pids) == 0 {
+		return "", errNoPeers
+	}
+	return pids[rand.NewGenerator().Intn(len(pids))], nil
+}
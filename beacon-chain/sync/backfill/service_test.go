@@ -0,0 +1,175 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// signedChain builds a chain of signed blocks on top of the genesis block at the given slots.
+func signedChain(t *testing.T, st *stateTrie.BeaconState, keys []bls.SecretKey, genesisRoot [32]byte, slots []types.Slot) []*ethpb.SignedBeaconBlock {
+	parentRoot := genesisRoot
+	chain := make([]*ethpb.SignedBeaconBlock, len(slots))
+	for i, slot := range slots {
+		blk := testutil.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.ProposerIndex = types.ValidatorIndex(uint64(slot) % uint64(len(keys)))
+		blk.Block.ParentRoot = bytesutil.SafeCopyBytes(parentRoot[:])
+		domain, err := helpers.Domain(st.Fork(), helpers.SlotToEpoch(slot), params.BeaconConfig().DomainBeaconProposer, st.GenesisValidatorRoot())
+		require.NoError(t, err)
+		signingRoot, err := helpers.ComputeSigningRoot(blk.Block, domain)
+		require.NoError(t, err)
+		blk.Signature = keys[blk.Block.ProposerIndex].Sign(signingRoot[:]).Marshal()
+		chain[i] = blk
+		parentRoot, err = blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	return chain
+}
+
+// connectBlockProvider connects a peer serving the blocks by range and by root.
+func connectBlockProvider(t *testing.T, p1 *p2pt.TestP2P, chain []*ethpb.SignedBeaconBlock) {
+	p2 := p2pt.NewTestP2P(t)
+	byRoot := make(map[[32]byte]*ethpb.SignedBeaconBlock)
+	for _, blk := range chain {
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		byRoot[root] = blk
+	}
+	p2.SetStreamHandler(p2p.RPCBlocksByRangeTopic+p2.Encoding().ProtocolSuffix(), func(stream network.Stream) {
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+		req := &p2ppb.BeaconBlocksByRangeRequest{}
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+		for _, blk := range chain {
+			if blk.Block.Slot >= req.StartSlot && blk.Block.Slot < req.StartSlot.Add(req.Count) {
+				assert.NoError(t, prysmsync.WriteChunk(stream, p2.Encoding(), blk))
+			}
+		}
+	})
+	p2.SetStreamHandler(p2p.RPCBlocksByRootTopic+p2.Encoding().ProtocolSuffix(), func(stream network.Stream) {
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+		req := new(p2ptypes.BeaconBlockByRootsReq)
+		assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+		for _, root := range *req {
+			if blk, ok := byRoot[root]; ok {
+				assert.NoError(t, prysmsync.WriteChunk(stream, p2.Encoding(), blk))
+			}
+		}
+	})
+	p1.Connect(p2)
+	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
+	p1.Peers().SetConnectionState(p2.PeerID(), peers.PeerConnected)
+	p1.Peers().SetChainState(p2.PeerID(), &p2ppb.Status{
+		ForkDigest:     params.BeaconConfig().GenesisForkVersion,
+		FinalizedRoot:  []byte(fmt.Sprintf("finalized_root %d", 10)),
+		FinalizedEpoch: 10,
+		HeadRoot:       make([]byte, 32),
+		HeadSlot:       chain[len(chain)-1].Block.Slot,
+	})
+}
+
+func TestService_Start_BackfillsToGenesis(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	st, keys := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesisBlk := blocks.NewGenesisBlock(stateRoot[:])
+	genesisRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, genesisBlk))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	// The slots in between 40 and 150 are skipped, so a whole batch of slots has no blocks.
+	var slots []types.Slot
+	for slot := types.Slot(1); slot <= 200; slot++ {
+		if slot <= 40 || slot >= 150 {
+			slots = append(slots, slot)
+		}
+	}
+	chain := signedChain(t, st, keys, genesisRoot, slots)
+	origin := chain[len(chain)-1]
+	originRoot, err := origin.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, origin))
+	originState := st.Copy()
+	require.NoError(t, originState.SetSlot(origin.Block.Slot))
+	require.NoError(t, beaconDB.SaveState(ctx, originState, originRoot))
+	require.NoError(t, beaconDB.SaveOriginBlockRoot(ctx, originRoot))
+
+	p1 := p2pt.NewTestP2P(t)
+	connectBlockProvider(t, p1, chain)
+	s := NewService(ctx, &Config{
+		P2P:         p1,
+		DB:          beaconDB,
+		InitialSync: &mockSync.Sync{IsSyncing: false},
+	})
+	s.Start()
+
+	for _, blk := range chain[:len(chain)-1] {
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, beaconDB.IsFinalizedBlock(ctx, root), "Block at slot %d was not backfilled", blk.Block.Slot)
+	}
+	lowestRoot, err := beaconDB.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	wantedRoot, err := chain[0].Block.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantedRoot, lowestRoot)
+	require.NoError(t, s.Status())
+}
+
+func TestService_VerifiedChain(t *testing.T) {
+	ctx := context.Background()
+	st, keys := testutil.DeterministicGenesisState(t, 64)
+	genesisRoot := [32]byte{'a'}
+	chain := signedChain(t, st, keys, genesisRoot, []types.Slot{1, 2, 3, 4})
+	s := &Service{
+		ctx:         ctx,
+		originState: st,
+		genesisRoot: genesisRoot,
+		lowest:      chain[3],
+	}
+
+	verified, err := s.verifiedChain(chain[:3])
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*ethpb.SignedBeaconBlock{chain[2], chain[1], chain[0]}, verified)
+
+	// Blocks that are not ancestors of the lowest block are ignored.
+	other := signedChain(t, st, keys, [32]byte{'b'}, []types.Slot{1, 2, 3})
+	verified, err = s.verifiedChain(other)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(verified))
+
+	forged := signedChain(t, st, keys, genesisRoot, []types.Slot{1, 2, 3, 4})
+	forged[2].Signature = forged[1].Signature
+	s.lowest = forged[3]
+	_, err = s.verifiedChain(forged[:3])
+	assert.ErrorContains(t, "invalid signature of block at slot 3", err)
+}
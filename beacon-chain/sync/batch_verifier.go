@@ -0,0 +1,109 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+// signatureVerificationInterval is the longest a gossip signature set waits to be batched with
+// others before being verified.
+const signatureVerificationInterval = 50 * time.Millisecond
+
+// verifierLimit is the number of signature sets which triggers the verification of a batch
+// before the interval is over.
+const verifierLimit = 50
+
+// signatureVerifier is a signature set waiting for batch verification, along with the channel
+// receiving the verification result.
+type signatureVerifier struct {
+	set     *bls.SignatureSet
+	resChan chan error
+}
+
+// verifierRoutine collects the signature sets of gossip messages and verifies them in batches,
+// either once verifierLimit sets are pending or every signatureVerificationInterval.
+func (s *Service) verifierRoutine() {
+	ticker := time.NewTicker(signatureVerificationInterval)
+	defer ticker.Stop()
+	verifierBatch := make([]*signatureVerifier, 0, verifierLimit)
+	for {
+		select {
+		case <-s.ctx.Done():
+			for _, v := range verifierBatch {
+				v.resChan <- s.ctx.Err()
+			}
+			return
+		case v := <-s.signatureChan:
+			verifierBatch = append(verifierBatch, v)
+			if len(verifierBatch) >= verifierLimit {
+				verifyBatch(verifierBatch)
+				verifierBatch = make([]*signatureVerifier, 0, verifierLimit)
+			}
+		case <-ticker.C:
+			if len(verifierBatch) > 0 {
+				verifyBatch(verifierBatch)
+				verifierBatch = make([]*signatureVerifier, 0, verifierLimit)
+			}
+		}
+	}
+}
+
+// validateWithBatchVerifier verifies the signature set of a gossip message as part of a batch.
+// As a failed batch does not tell which of its sets is invalid, the set is verified on its own
+// when its batch fails. The message is rejected only if its signatures are invalid, and ignored if
+// they could not be verified.
+func (s *Service) validateWithBatchVerifier(ctx context.Context, message string, set *bls.SignatureSet) pubsub.ValidationResult {
+	ctx, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
+	defer span.End()
+
+	resChan := make(chan error, 1)
+	select {
+	case s.signatureChan <- &signatureVerifier{set: set, resChan: resChan}:
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore
+	}
+	var resErr error
+	select {
+	case resErr = <-resChan:
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore
+	}
+	if resErr == nil {
+		return pubsub.ValidationAccept
+	}
+
+	batchVerificationFallbacks.Inc()
+	log.WithError(resErr).Tracef("Could not perform batch verification of %s", message)
+	verified, err := set.Verify()
+	if err != nil {
+		traceutil.AnnotateError(span, errors.Wrapf(err, "could not verify %s", message))
+		return pubsub.ValidationIgnore
+	}
+	if !verified {
+		traceutil.AnnotateError(span, errors.Errorf("verification of %s failed", message))
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationAccept
+}
+
+// verifyBatch verifies the signature sets of a batch at once, and sends the result to each of
+// them.
+func verifyBatch(verifierBatch []*signatureVerifier) {
+	aggSet := bls.NewSet()
+	for _, v := range verifierBatch {
+		aggSet.Join(v.set)
+	}
+	verified, err := aggSet.Verify()
+	if err == nil && !verified {
+		err = errors.New("batch signature verification failed")
+	}
+	for _, v := range verifierBatch {
+		v.resChan <- err
+	}
+}
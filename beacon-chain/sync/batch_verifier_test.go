@@ -0,0 +1,81 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestValidateWithBatchVerifier(t *testing.T) {
+	newSet := func(msg byte, valid bool) *bls.SignatureSet {
+		priv, err := bls.RandKey()
+		require.NoError(t, err)
+		root := [32]byte{msg}
+		sig := priv.Sign(root[:])
+		if !valid {
+			sig = priv.Sign([]byte("wrong message"))
+		}
+		return &bls.SignatureSet{
+			Signatures: [][]byte{sig.Marshal()},
+			PublicKeys: []bls.PublicKey{priv.PublicKey()},
+			Messages:   [][32]byte{root},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:           ctx,
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	go s.verifierRoutine()
+
+	sets := []*bls.SignatureSet{newSet(1, true), newSet(2, false), newSet(3, true)}
+	want := []pubsub.ValidationResult{pubsub.ValidationAccept, pubsub.ValidationReject, pubsub.ValidationAccept}
+	results := make([]chan pubsub.ValidationResult, len(sets))
+	// The sets are verified in the same batch, which fails because of the invalid one. The valid
+	// sets are still accepted once verified on their own.
+	for i, set := range sets {
+		results[i] = make(chan pubsub.ValidationResult, 1)
+		go func(set *bls.SignatureSet, res chan pubsub.ValidationResult) {
+			res <- s.validateWithBatchVerifier(ctx, "signature", set)
+		}(set, results[i])
+	}
+	for i := range sets {
+		assert.Equal(t, want[i], <-results[i], "Wrong result for set %d", i)
+		assert.Equal(t, 1, len(sets[i].Signatures), "Set %d was modified by batching", i)
+	}
+}
+
+func TestValidateWithBatchVerifier_VerificationError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:           ctx,
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	go s.verifierRoutine()
+
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	set := &bls.SignatureSet{
+		Signatures: [][]byte{{'m', 'a', 'l', 'f', 'o', 'r', 'm', 'e', 'd'}},
+		PublicKeys: []bls.PublicKey{priv.PublicKey()},
+		Messages:   [][32]byte{{1}},
+	}
+	assert.Equal(t, pubsub.ValidationIgnore, s.validateWithBatchVerifier(ctx, "signature", set))
+}
+
+func TestValidateWithBatchVerifier_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		ctx:           ctx,
+		signatureChan: make(chan *signatureVerifier),
+	}
+	cancel()
+	assert.Equal(t, pubsub.ValidationIgnore, s.validateWithBatchVerifier(ctx, "signature", bls.NewSet()))
+}
@@ -35,7 +35,9 @@ func NewRegularSyncFuzz(cfg *Config) *Service {
 		blockNotifier:        cfg.BlockNotifier,
 		stateGen:             cfg.StateGen,
 		rateLimiter:          rLimiter,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	return r
 }
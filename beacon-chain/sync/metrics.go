@@ -55,6 +55,18 @@ var (
 			Buckets: []float64{250, 500, 1000, 1500, 2000, 4000, 8000, 16000},
 		},
 	)
+	gossipAttesterSlashingsDetected = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_attester_slashings_detected_total",
+			Help: "Count of attester slashings detected on gossip attestations by the span detector.",
+		},
+	)
+	batchVerificationFallbacks = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_batch_verification_fallbacks_total",
+			Help: "Count of gossip signature sets verified on their own after their batch failed verification.",
+		},
+	)
 )
 
 func (s *Service) updateMetrics() {
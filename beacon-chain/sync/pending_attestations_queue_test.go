@@ -42,12 +42,15 @@ func TestProcessPendingAtts_NoBlockRequestBlock(t *testing.T) {
 	p1.Peers().SetChainState(p2.PeerID(), &pb.Status{})
 
 	r := &Service{
+		ctx:                  context.Background(),
 		p2p:                  p1,
 		db:                   db,
 		chain:                &mock.ChainService{Genesis: timeutils.Now(), FinalizedCheckPoint: &ethpb.Checkpoint{}},
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		chainStarted:         abool.New(),
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	a := &ethpb.AggregateAttestationAndProof{Aggregate: &ethpb.Attestation{Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{Root: make([]byte, 32)}}}}
 	r.blkRootToPendingAtts[[32]byte{'A'}] = []*ethpb.SignedAggregateAttestationAndProof{{Message: a}}
@@ -110,6 +113,7 @@ func TestProcessPendingAtts_HasBlockSaveUnAggregatedAtt(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx: context.Background(),
 		p2p: p1,
 		db:  db,
 		chain: &mock.ChainService{Genesis: time.Now(),
@@ -121,7 +125,9 @@ func TestProcessPendingAtts_HasBlockSaveUnAggregatedAtt(t *testing.T) {
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	sb = testutil.NewBeaconBlock()
 	r32, err := sb.Block.HashTreeRoot()
@@ -147,12 +153,15 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 	p1 := p2ptest.NewTestP2P(t)
 
 	r := &Service{
+		ctx:                  context.Background(),
 		p2p:                  p1,
 		db:                   db,
 		chain:                &mock.ChainService{Genesis: timeutils.Now(), FinalizedCheckPoint: &ethpb.Checkpoint{Root: make([]byte, 32)}},
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		attPool:              attestations.NewPool(),
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	priv, err := bls.RandKey()
 	require.NoError(t, err)
@@ -224,6 +233,7 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r = &Service{
+		ctx: context.Background(),
 		p2p: p1,
 		db:  db,
 		chain: &mock.ChainService{Genesis: time.Now(),
@@ -235,7 +245,9 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	r.blkRootToPendingAtts[r32] = []*ethpb.SignedAggregateAttestationAndProof{{Message: aggregateAndProof, Signature: aggreSig}}
 	require.NoError(t, r.processPendingAtts(context.Background()))
@@ -302,6 +314,7 @@ func TestProcessPendingAtts_HasBlockSaveAggregatedAtt(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx: context.Background(),
 		p2p: p1,
 		db:  db,
 		chain: &mock.ChainService{Genesis: time.Now(),
@@ -313,7 +326,9 @@ func TestProcessPendingAtts_HasBlockSaveAggregatedAtt(t *testing.T) {
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	sb = testutil.NewBeaconBlock()
 	r32, err := sb.Block.HashTreeRoot()
@@ -336,8 +351,11 @@ func TestProcessPendingAtts_HasBlockSaveAggregatedAtt(t *testing.T) {
 
 func TestValidatePendingAtts_CanPruneOldAtts(t *testing.T) {
 	s := &Service{
+		ctx:                  context.Background(),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go s.verifierRoutine()
 
 	// 100 Attestations per block root.
 	r1 := [32]byte{'A'}
@@ -384,8 +402,11 @@ func TestValidatePendingAtts_CanPruneOldAtts(t *testing.T) {
 
 func TestValidatePendingAtts_NoDuplicatingAggregatorIndex(t *testing.T) {
 	s := &Service{
+		ctx:                  context.Background(),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go s.verifierRoutine()
 
 	r1 := [32]byte{'A'}
 	r2 := [32]byte{'B'}
@@ -27,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher/spans"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/abool"
@@ -61,6 +62,7 @@ type Config struct {
 	BlockNotifier       blockfeed.Notifier
 	AttestationNotifier operation.Notifier
 	StateGen            *stategen.State
+	SpanDetector        *spans.Detector
 }
 
 // This defines the interface for interacting with block chain service
@@ -111,6 +113,8 @@ type Service struct {
 	badBlockCache             *lru.Cache
 	badBlockLock              sync.RWMutex
 	stateGen                  *stategen.State
+	spanDetector              *spans.Detector
+	signatureChan             chan *signatureVerifier
 }
 
 // NewService initializes new regular sync service.
@@ -138,9 +142,12 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		blockNotifier:        cfg.BlockNotifier,
 		stateGen:             cfg.StateGen,
 		rateLimiter:          rLimiter,
+		spanDetector:         cfg.SpanDetector,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
 
 	go r.registerHandlers()
+	go r.verifierRoutine()
 
 	return r
 }
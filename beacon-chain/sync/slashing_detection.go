@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// detectSlashableAttestation checks a gossip attestation for double and surround votes with the
+// span detector, if enabled, and inserts the attester slashings it finds into the slashings pool
+// and broadcasts them, without waiting for a slasher to detect them.
+func (s *Service) detectSlashableAttestation(ctx context.Context, att *ethpb.Attestation) error {
+	if s.spanDetector == nil {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "sync.detectSlashableAttestation")
+	defer span.End()
+
+	preState, err := s.chain.AttestationPreState(ctx, att)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve attestation pre state")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(preState, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestation committee")
+	}
+	indexedAtt, err := attestationutil.ConvertToIndexed(ctx, att, committee)
+	if err != nil {
+		return errors.Wrap(err, "could not convert to indexed attestation")
+	}
+	slashings, err := s.spanDetector.DetectSlashableAttestation(indexedAtt)
+	if err != nil {
+		return errors.Wrap(err, "could not detect slashable attestation")
+	}
+	if len(slashings) == 0 {
+		return nil
+	}
+	headState, err := s.chain.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve head state")
+	}
+	for _, slashing := range slashings {
+		slashedIndices := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+		log.WithFields(logrus.Fields{
+			"slashedIndices": slashedIndices,
+			"targetEpoch":    slashing.Attestation_2.Data.Target.Epoch,
+		}).Info("Detected slashable attestation on gossip")
+		gossipAttesterSlashingsDetected.Inc()
+		if err := s.slashingPool.InsertAttesterSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Error("Could not insert attester slashing into pool")
+			continue
+		}
+		if featureconfig.Get().DisableBroadcastSlashings {
+			continue
+		}
+		if err := s.p2p.Broadcast(ctx, slashing); err != nil {
+			log.WithError(err).Error("Could not broadcast attester slashing")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher/spans"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_DetectSlashableAttestation(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	detector, err := spans.NewDetector(spans.DefaultHistoryLength)
+	require.NoError(t, err)
+	c, err := lru.New(10)
+	require.NoError(t, err)
+	p := p2ptest.NewTestP2P(t)
+	pool := &slashings.PoolMock{}
+	chain := &mock.ChainService{State: beaconState}
+	r := &Service{
+		p2p:                  p,
+		chain:                chain,
+		attPool:              attestations.NewPool(),
+		slashingPool:         pool,
+		seenAttestationCache: c,
+		attestationNotifier:  chain.OperationNotifier(),
+		spanDetector:         detector,
+	}
+
+	att := testutil.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0x05},
+	})
+	att.Data.Target.Epoch = 2
+	require.NoError(t, r.committeeIndexBeaconAttestationSubscriber(context.Background(), att))
+	assert.Equal(t, 0, len(pool.PendingAttSlashings))
+	assert.Equal(t, false, p.BroadcastCalled)
+
+	// A vote for another block in the same target epoch is a double vote.
+	doubleVote := testutil.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0x05},
+	})
+	doubleVote.Data.Target.Epoch = 2
+	doubleVote.Data.BeaconBlockRoot = bytesutil.PadTo([]byte("other block"), 32)
+	require.NoError(t, r.committeeIndexBeaconAttestationSubscriber(context.Background(), doubleVote))
+	require.Equal(t, 1, len(pool.PendingAttSlashings))
+	assert.Equal(t, true, p.BroadcastCalled)
+	slashing := pool.PendingAttSlashings[0]
+	assert.Equal(t, types.Epoch(2), slashing.Attestation_1.Data.Target.Epoch)
+	assert.DeepEqual(t, att.Data.BeaconBlockRoot, slashing.Attestation_1.Data.BeaconBlockRoot)
+	assert.DeepEqual(t, doubleVote.Data.BeaconBlockRoot, slashing.Attestation_2.Data.BeaconBlockRoot)
+}
+
+func TestService_DetectSlashableAttestation_Disabled(t *testing.T) {
+	pool := &slashings.PoolMock{}
+	r := &Service{slashingPool: pool}
+	require.NoError(t, r.detectSlashableAttestation(context.Background(), testutil.HydrateAttestation(&ethpb.Attestation{})))
+	assert.Equal(t, 0, len(pool.PendingAttSlashings))
+}
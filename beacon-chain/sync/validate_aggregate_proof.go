@@ -163,17 +163,7 @@ func (s *Service) validateAggregatedAtt(ctx context.Context, signed *ethpb.Signe
 	}
 	set := bls.NewSet()
 	set.Join(selectionSigSet).Join(aggregatorSigSet).Join(attSigSet)
-	valid, err := set.Verify()
-	if err != nil {
-		traceutil.AnnotateError(span, errors.Errorf("Could not join signature set"))
-		return pubsub.ValidationIgnore
-	}
-	if !valid {
-		traceutil.AnnotateError(span, errors.Errorf("Could not verify selection or aggregator or attestation signature"))
-		return pubsub.ValidationReject
-	}
-
-	return pubsub.ValidationAccept
+	return s.validateWithBatchVerifier(ctx, "selection, aggregator and attestation signatures", set)
 }
 
 func (s *Service) validateBlockInAttestation(ctx context.Context, satt *ethpb.SignedAggregateAttestationAndProof) bool {
@@ -113,6 +113,7 @@ func TestValidateAggregateAndProof_NoBlock(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:                  context.Background(),
 		p2p:                  p,
 		db:                   db,
 		initialSync:          &mockSync.Sync{IsSyncing: false},
@@ -120,7 +121,9 @@ func TestValidateAggregateAndProof_NoBlock(t *testing.T) {
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		seenAttestationCache: c,
 		chain:                &mock.ChainService{},
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 
@@ -180,6 +183,7 @@ func TestValidateAggregateAndProof_NotWithinSlotRange(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:         context.Background(),
 		p2p:         p,
 		db:          db,
 		initialSync: &mockSync.Sync{IsSyncing: false},
@@ -189,7 +193,9 @@ func TestValidateAggregateAndProof_NotWithinSlotRange(t *testing.T) {
 		},
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 
@@ -261,6 +267,7 @@ func TestValidateAggregateAndProof_ExistedInPool(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:         context.Background(),
 		attPool:     attestations.NewPool(),
 		p2p:         p,
 		db:          db,
@@ -269,7 +276,9 @@ func TestValidateAggregateAndProof_ExistedInPool(t *testing.T) {
 			State: beaconState},
 		seenAttestationCache: c,
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 
@@ -349,6 +358,7 @@ func TestValidateAggregateAndProof_CanValidate(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:         context.Background(),
 		p2p:         p,
 		db:          db,
 		initialSync: &mockSync.Sync{IsSyncing: false},
@@ -361,7 +371,9 @@ func TestValidateAggregateAndProof_CanValidate(t *testing.T) {
 			}},
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 
@@ -438,6 +450,7 @@ func TestVerifyIndexInCommittee_SeenAggregatorEpoch(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:         context.Background(),
 		p2p:         p,
 		db:          db,
 		initialSync: &mockSync.Sync{IsSyncing: false},
@@ -452,7 +465,9 @@ func TestVerifyIndexInCommittee_SeenAggregatorEpoch(t *testing.T) {
 
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 
@@ -547,6 +562,7 @@ func TestValidateAggregateAndProof_BadBlock(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:         context.Background(),
 		p2p:         p,
 		db:          db,
 		initialSync: &mockSync.Sync{IsSyncing: false},
@@ -558,7 +574,9 @@ func TestValidateAggregateAndProof_BadBlock(t *testing.T) {
 			}},
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 	// Set beacon block as bad.
@@ -636,6 +654,7 @@ func TestValidateAggregateAndProof_RejectWhenAttEpochDoesntEqualTargetEpoch(t *t
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	r := &Service{
+		ctx:         context.Background(),
 		p2p:         p,
 		db:          db,
 		initialSync: &mockSync.Sync{IsSyncing: false},
@@ -648,7 +667,9 @@ func TestValidateAggregateAndProof_RejectWhenAttEpochDoesntEqualTargetEpoch(t *t
 			}},
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 	err = r.initCaches()
 	require.NoError(t, err)
 
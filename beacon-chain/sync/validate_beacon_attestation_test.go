@@ -37,13 +37,16 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 	c, err := lru.New(10)
 	require.NoError(t, err)
 	s := &Service{
+		ctx:                  context.Background(),
 		initialSync:          &mockSync.Sync{IsSyncing: false},
 		p2p:                  p,
 		db:                   db,
 		chain:                chain,
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		seenAttestationCache: c,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go s.verifierRoutine()
 	err = s.initCaches()
 	require.NoError(t, err)
 
@@ -54,6 +54,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PTCPPort,
 			cmd.DataDirFlag,
 			cmd.VerbosityFlag,
+			cmd.ModuleLogLevelsFlag,
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
@@ -73,6 +74,8 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.BoltMMapInitialSizeFlag,
+			cmd.BoltFreelistTypeFlag,
+			cmd.BoltNoFreelistSyncFlag,
 		},
 	},
 	{
@@ -99,26 +102,60 @@ var appHelpFlagGroups = []flagGroup{
 			flags.RPCPort,
 			flags.CertFlag,
 			flags.KeyFlag,
+			flags.ClientCAFlag,
 			flags.DisableGRPCGateway,
 			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
+			flags.GRPCGatewayMaxBodySize,
+			flags.GRPCGatewayRequestTimeout,
+			flags.GRPCGatewayClientCertFlag,
+			flags.GRPCGatewayClientKeyFlag,
+			flags.GRPCGatewayAuthTokenFile,
+			flags.GRPCGatewayJWTSecretFile,
+			flags.GRPCGatewayAuthExemptPaths,
+			flags.EnableAdminEndpoints,
 			flags.HTTPWeb3ProviderFlag,
 			flags.FallbackWeb3ProviderFlag,
 			flags.SetGCPercent,
 			flags.HeadSync,
 			flags.DisableSync,
 			flags.SlotsPerArchivedPoint,
+			flags.DatabaseBackend,
 			flags.DisableDiscv5,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
 			flags.EnableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
+			flags.SlasherFlag,
+			flags.MonitorIndicesFlag,
+			flags.ArchiveAttestationInclusionsFlag,
+			flags.GossipSlashingDetectionFlag,
+			flags.GossipSlashingDetectionEpochsFlag,
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpt,
 			flags.Eth1HeaderReqLimit,
+			flags.SlashingWebhookURL,
+			flags.RPCMaxBatchSize,
+			flags.RPCRateLimit,
+			flags.RPCRateLimitBurst,
+			flags.RPCMaxConcurrentRequests,
+			flags.RPCRateLimitOverrides,
+			flags.ExitInclusionOrdering,
+			flags.MaxPoolAggregatedAttestations,
+			flags.MaxPoolUnaggregatedAttestations,
+			flags.MaxPoolSlashings,
+			flags.MaxPoolVoluntaryExits,
+			flags.SlashingReplayCacheSize,
+			flags.SlashingReplayCacheTTL,
+			flags.CommitteeCacheSize,
+			flags.VerifyExitsAgainstPool,
+			flags.CheckpointSyncURL,
+			flags.CheckpointGenesisState,
+			flags.CheckpointState,
+			flags.CheckpointBlock,
 		},
 	},
 	{
@@ -142,6 +179,9 @@ var appHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			cmd.LogFormat,
 			cmd.LogFileName,
+			cmd.LogMaxSize,
+			cmd.LogMaxAge,
+			cmd.LogMaxBackups,
 		},
 	},
 	{
@@ -154,6 +194,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.InteropGenesisStateFlag,
 			flags.InteropGenesisTimeFlag,
 			flags.InteropNumValidatorsFlag,
+			flags.DevModeFlag,
 		},
 	},
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb_alpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
 // V1Alpha1BlockToV1BlockHeader converts a v1alpha1 SignedBeaconBlock proto to a v1 SignedBeaconBlockHeader proto.
@@ -63,6 +64,18 @@ func V1Alpha1IndexedAttToV1(v1alpha1Att *ethpb_alpha.IndexedAttestation) *ethpb.
 	}
 }
 
+// V1Alpha1AttestationToV1 converts a v1alpha1 attestation to v1.
+func V1Alpha1AttestationToV1(v1alpha1Att *ethpb_alpha.Attestation) *ethpb.Attestation {
+	if v1alpha1Att == nil {
+		return &ethpb.Attestation{}
+	}
+	return &ethpb.Attestation{
+		AggregationBits: v1alpha1Att.AggregationBits,
+		Data:            V1Alpha1AttDataToV1(v1alpha1Att.Data),
+		Signature:       v1alpha1Att.Signature,
+	}
+}
+
 // V1Alpha1AttDataToV1 converts a v1alpha1 attestation data to v1.
 func V1Alpha1AttDataToV1(v1alpha1AttData *ethpb_alpha.AttestationData) *ethpb.AttestationData {
 	if v1alpha1AttData == nil || v1alpha1AttData.Source == nil || v1alpha1AttData.Target == nil {
@@ -153,6 +166,23 @@ func V1Alpha1ExitToV1(v1alpha1Exit *ethpb_alpha.SignedVoluntaryExit) *ethpb.Sign
 	}
 }
 
+// V1Alpha1ValidatorToV1 converts a v1alpha1 validator to v1.
+func V1Alpha1ValidatorToV1(v1alpha1Validator *ethpb_alpha.Validator) *ethpb.Validator {
+	if v1alpha1Validator == nil {
+		return &ethpb.Validator{}
+	}
+	return &ethpb.Validator{
+		PublicKey:                  v1alpha1Validator.PublicKey,
+		WithdrawalCredentials:      v1alpha1Validator.WithdrawalCredentials,
+		EffectiveBalance:           v1alpha1Validator.EffectiveBalance,
+		Slashed:                    v1alpha1Validator.Slashed,
+		ActivationEligibilityEpoch: v1alpha1Validator.ActivationEligibilityEpoch,
+		ActivationEpoch:            v1alpha1Validator.ActivationEpoch,
+		ExitEpoch:                  v1alpha1Validator.ExitEpoch,
+		WithdrawableEpoch:          v1alpha1Validator.WithdrawableEpoch,
+	}
+}
+
 // V1ExitToV1Alpha1 converts a v1 SignedVoluntaryExit to v1alpha1.
 func V1ExitToV1Alpha1(v1Exit *ethpb.SignedVoluntaryExit) *ethpb_alpha.SignedVoluntaryExit {
 	if v1Exit == nil || v1Exit.Exit == nil {
@@ -179,6 +209,18 @@ func V1IndexedAttToV1Alpha1(v1Att *ethpb.IndexedAttestation) *ethpb_alpha.Indexe
 	}
 }
 
+// V1AttestationToV1Alpha1 converts a v1 attestation to v1alpha1.
+func V1AttestationToV1Alpha1(v1Att *ethpb.Attestation) *ethpb_alpha.Attestation {
+	if v1Att == nil {
+		return &ethpb_alpha.Attestation{}
+	}
+	return &ethpb_alpha.Attestation{
+		AggregationBits: v1Att.AggregationBits,
+		Data:            V1AttDataToV1Alpha1(v1Att.Data),
+		Signature:       v1Att.Signature,
+	}
+}
+
 // V1AttDataToV1Alpha1 converts a v1 attestation data to v1alpha1.
 func V1AttDataToV1Alpha1(v1AttData *ethpb.AttestationData) *ethpb_alpha.AttestationData {
 	if v1AttData == nil || v1AttData.Source == nil || v1AttData.Target == nil {
@@ -220,3 +262,183 @@ func V1ProposerSlashingToV1Alpha1(v1Slashing *ethpb.ProposerSlashing) *ethpb_alp
 		Header_2: V1SignedHeaderToV1Alpha1(v1Slashing.Header_2),
 	}
 }
+
+// V1ToPbp2pState converts a v1 BeaconState proto to the beacon node's own state proto, which
+// carries the SSZ encoding of the state.
+func V1ToPbp2pState(v1State *ethpb.BeaconState) *pbp2p.BeaconState {
+	if v1State == nil {
+		return &pbp2p.BeaconState{}
+	}
+	state := &pbp2p.BeaconState{
+		GenesisTime:                 v1State.GenesisTime,
+		GenesisValidatorsRoot:       v1State.GenesisValidatorsRoot,
+		Slot:                        v1State.Slot,
+		BlockRoots:                  v1State.BlockRoots,
+		StateRoots:                  v1State.StateRoots,
+		HistoricalRoots:             v1State.HistoricalRoots,
+		Eth1Data:                    v1Eth1DataToV1Alpha1(v1State.Eth1Data),
+		Eth1DepositIndex:            v1State.Eth1DepositIndex,
+		Balances:                    v1State.Balances,
+		RandaoMixes:                 v1State.RandaoMixes,
+		Slashings:                   v1State.Slashings,
+		JustificationBits:           v1State.JustificationBits,
+		PreviousJustifiedCheckpoint: v1CheckpointToV1Alpha1(v1State.PreviousJustifiedCheckpoint),
+		CurrentJustifiedCheckpoint:  v1CheckpointToV1Alpha1(v1State.CurrentJustifiedCheckpoint),
+		FinalizedCheckpoint:         v1CheckpointToV1Alpha1(v1State.FinalizedCheckpoint),
+	}
+	if v1State.Fork != nil {
+		state.Fork = &pbp2p.Fork{
+			PreviousVersion: v1State.Fork.PreviousVersion,
+			CurrentVersion:  v1State.Fork.CurrentVersion,
+			Epoch:           v1State.Fork.Epoch,
+		}
+	}
+	if v1State.LatestBlockHeader != nil {
+		state.LatestBlockHeader = &ethpb_alpha.BeaconBlockHeader{
+			Slot:          v1State.LatestBlockHeader.Slot,
+			ProposerIndex: v1State.LatestBlockHeader.ProposerIndex,
+			ParentRoot:    v1State.LatestBlockHeader.ParentRoot,
+			StateRoot:     v1State.LatestBlockHeader.StateRoot,
+			BodyRoot:      v1State.LatestBlockHeader.BodyRoot,
+		}
+	}
+	state.Eth1DataVotes = make([]*ethpb_alpha.Eth1Data, len(v1State.Eth1DataVotes))
+	for i, vote := range v1State.Eth1DataVotes {
+		state.Eth1DataVotes[i] = v1Eth1DataToV1Alpha1(vote)
+	}
+	state.Validators = make([]*ethpb_alpha.Validator, len(v1State.Validators))
+	for i, val := range v1State.Validators {
+		state.Validators[i] = &ethpb_alpha.Validator{
+			PublicKey:                  val.PublicKey,
+			WithdrawalCredentials:      val.WithdrawalCredentials,
+			EffectiveBalance:           val.EffectiveBalance,
+			Slashed:                    val.Slashed,
+			ActivationEligibilityEpoch: val.ActivationEligibilityEpoch,
+			ActivationEpoch:            val.ActivationEpoch,
+			ExitEpoch:                  val.ExitEpoch,
+			WithdrawableEpoch:          val.WithdrawableEpoch,
+		}
+	}
+	state.PreviousEpochAttestations = v1PendingAttsToPbp2p(v1State.PreviousEpochAttestations)
+	state.CurrentEpochAttestations = v1PendingAttsToPbp2p(v1State.CurrentEpochAttestations)
+	return state
+}
+
+// Pbp2pToV1State converts the beacon node's own state proto to a v1 BeaconState proto.
+func Pbp2pToV1State(state *pbp2p.BeaconState) *ethpb.BeaconState {
+	if state == nil {
+		return &ethpb.BeaconState{}
+	}
+	v1State := &ethpb.BeaconState{
+		GenesisTime:                 state.GenesisTime,
+		GenesisValidatorsRoot:       state.GenesisValidatorsRoot,
+		Slot:                        state.Slot,
+		BlockRoots:                  state.BlockRoots,
+		StateRoots:                  state.StateRoots,
+		HistoricalRoots:             state.HistoricalRoots,
+		Eth1Data:                    v1Alpha1Eth1DataToV1(state.Eth1Data),
+		Eth1DepositIndex:            state.Eth1DepositIndex,
+		Balances:                    state.Balances,
+		RandaoMixes:                 state.RandaoMixes,
+		Slashings:                   state.Slashings,
+		JustificationBits:           state.JustificationBits,
+		PreviousJustifiedCheckpoint: v1Alpha1CheckpointToV1(state.PreviousJustifiedCheckpoint),
+		CurrentJustifiedCheckpoint:  v1Alpha1CheckpointToV1(state.CurrentJustifiedCheckpoint),
+		FinalizedCheckpoint:         v1Alpha1CheckpointToV1(state.FinalizedCheckpoint),
+	}
+	if state.Fork != nil {
+		v1State.Fork = &ethpb.Fork{
+			PreviousVersion: state.Fork.PreviousVersion,
+			CurrentVersion:  state.Fork.CurrentVersion,
+			Epoch:           state.Fork.Epoch,
+		}
+	}
+	if state.LatestBlockHeader != nil {
+		v1State.LatestBlockHeader = &ethpb.BeaconBlockHeader{
+			Slot:          state.LatestBlockHeader.Slot,
+			ProposerIndex: state.LatestBlockHeader.ProposerIndex,
+			ParentRoot:    state.LatestBlockHeader.ParentRoot,
+			StateRoot:     state.LatestBlockHeader.StateRoot,
+			BodyRoot:      state.LatestBlockHeader.BodyRoot,
+		}
+	}
+	v1State.Eth1DataVotes = make([]*ethpb.Eth1Data, len(state.Eth1DataVotes))
+	for i, vote := range state.Eth1DataVotes {
+		v1State.Eth1DataVotes[i] = v1Alpha1Eth1DataToV1(vote)
+	}
+	v1State.Validators = make([]*ethpb.Validator, len(state.Validators))
+	for i, val := range state.Validators {
+		v1State.Validators[i] = V1Alpha1ValidatorToV1(val)
+	}
+	v1State.PreviousEpochAttestations = pbp2pPendingAttsToV1(state.PreviousEpochAttestations)
+	v1State.CurrentEpochAttestations = pbp2pPendingAttsToV1(state.CurrentEpochAttestations)
+	return v1State
+}
+
+func v1Eth1DataToV1Alpha1(v1Data *ethpb.Eth1Data) *ethpb_alpha.Eth1Data {
+	if v1Data == nil {
+		return nil
+	}
+	return &ethpb_alpha.Eth1Data{
+		DepositRoot:  v1Data.DepositRoot,
+		DepositCount: v1Data.DepositCount,
+		BlockHash:    v1Data.BlockHash,
+	}
+}
+
+func v1CheckpointToV1Alpha1(v1Checkpoint *ethpb.Checkpoint) *ethpb_alpha.Checkpoint {
+	if v1Checkpoint == nil {
+		return nil
+	}
+	return &ethpb_alpha.Checkpoint{
+		Epoch: v1Checkpoint.Epoch,
+		Root:  v1Checkpoint.Root,
+	}
+}
+
+func v1PendingAttsToPbp2p(v1Atts []*ethpb.PendingAttestation) []*pbp2p.PendingAttestation {
+	atts := make([]*pbp2p.PendingAttestation, len(v1Atts))
+	for i, att := range v1Atts {
+		atts[i] = &pbp2p.PendingAttestation{
+			AggregationBits: att.AggregationBits,
+			Data:            V1AttDataToV1Alpha1(att.Data),
+			InclusionDelay:  att.InclusionDelay,
+			ProposerIndex:   att.ProposerIndex,
+		}
+	}
+	return atts
+}
+
+func v1Alpha1Eth1DataToV1(v1alpha1Data *ethpb_alpha.Eth1Data) *ethpb.Eth1Data {
+	if v1alpha1Data == nil {
+		return nil
+	}
+	return &ethpb.Eth1Data{
+		DepositRoot:  v1alpha1Data.DepositRoot,
+		DepositCount: v1alpha1Data.DepositCount,
+		BlockHash:    v1alpha1Data.BlockHash,
+	}
+}
+
+func v1Alpha1CheckpointToV1(v1alpha1Checkpoint *ethpb_alpha.Checkpoint) *ethpb.Checkpoint {
+	if v1alpha1Checkpoint == nil {
+		return nil
+	}
+	return &ethpb.Checkpoint{
+		Epoch: v1alpha1Checkpoint.Epoch,
+		Root:  v1alpha1Checkpoint.Root,
+	}
+}
+
+func pbp2pPendingAttsToV1(atts []*pbp2p.PendingAttestation) []*ethpb.PendingAttestation {
+	v1Atts := make([]*ethpb.PendingAttestation, len(atts))
+	for i, att := range atts {
+		v1Atts[i] = &ethpb.PendingAttestation{
+			AggregationBits: att.AggregationBits,
+			Data:            V1Alpha1AttDataToV1(att.Data),
+			InclusionDelay:  att.InclusionDelay,
+			ProposerIndex:   att.ProposerIndex,
+		}
+	}
+	return v1Atts
+}
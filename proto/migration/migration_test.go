@@ -6,7 +6,10 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb_alpha "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
@@ -166,6 +169,26 @@ func Test_V1Alpha1ExitToV1(t *testing.T) {
 	assert.DeepEqual(t, alphaRoot, v1Root)
 }
 
+func Test_V1Alpha1ValidatorToV1(t *testing.T) {
+	alphaValidator := &ethpb_alpha.Validator{
+		PublicKey:                  bytesutil.PadTo([]byte("publickey"), 48),
+		WithdrawalCredentials:      bytesutil.PadTo([]byte("withdrawalcredentials"), 32),
+		EffectiveBalance:           32,
+		Slashed:                    true,
+		ActivationEligibilityEpoch: 1,
+		ActivationEpoch:            2,
+		ExitEpoch:                  3,
+		WithdrawableEpoch:          4,
+	}
+
+	v1Validator := V1Alpha1ValidatorToV1(alphaValidator)
+	alphaRoot, err := alphaValidator.HashTreeRoot()
+	require.NoError(t, err)
+	v1Root, err := v1Validator.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, alphaRoot, v1Root)
+}
+
 func Test_V1ExitToV1Alpha1(t *testing.T) {
 	v1Exit := &ethpb.SignedVoluntaryExit{
 		Exit: &ethpb.VoluntaryExit{
@@ -183,6 +206,60 @@ func Test_V1ExitToV1Alpha1(t *testing.T) {
 	assert.DeepEqual(t, alphaRoot, v1Root)
 }
 
+func Test_V1Alpha1AttestationToV1(t *testing.T) {
+	alphaAtt := &ethpb_alpha.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1101},
+		Data: &ethpb_alpha.AttestationData{
+			Slot:            slot,
+			CommitteeIndex:  committeeIndex,
+			BeaconBlockRoot: beaconBlockRoot,
+			Source: &ethpb_alpha.Checkpoint{
+				Epoch: epoch,
+				Root:  sourceRoot,
+			},
+			Target: &ethpb_alpha.Checkpoint{
+				Epoch: epoch,
+				Root:  targetRoot,
+			},
+		},
+		Signature: signature,
+	}
+
+	v1Att := V1Alpha1AttestationToV1(alphaAtt)
+	alphaRoot, err := alphaAtt.HashTreeRoot()
+	require.NoError(t, err)
+	v1Root, err := v1Att.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, alphaRoot, v1Root)
+}
+
+func Test_V1AttestationToV1Alpha1(t *testing.T) {
+	v1Att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1101},
+		Data: &ethpb.AttestationData{
+			Slot:            slot,
+			CommitteeIndex:  committeeIndex,
+			BeaconBlockRoot: beaconBlockRoot,
+			Source: &ethpb.Checkpoint{
+				Epoch: epoch,
+				Root:  sourceRoot,
+			},
+			Target: &ethpb.Checkpoint{
+				Epoch: epoch,
+				Root:  targetRoot,
+			},
+		},
+		Signature: signature,
+	}
+
+	alphaAtt := V1AttestationToV1Alpha1(v1Att)
+	alphaRoot, err := alphaAtt.HashTreeRoot()
+	require.NoError(t, err)
+	v1Root, err := v1Att.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, v1Root, alphaRoot)
+}
+
 func Test_V1AttSlashingToV1Alpha1(t *testing.T) {
 	v1Attestation := &ethpb.IndexedAttestation{
 		AttestingIndices: attestingIndices,
@@ -237,3 +314,96 @@ func Test_V1ProposerSlashingToV1Alpha1(t *testing.T) {
 	require.NoError(t, err)
 	assert.DeepEqual(t, alphaRoot, v1Root)
 }
+
+func Test_V1ToPbp2pState(t *testing.T) {
+	cfg := params.MainnetConfig()
+	roots := func(n uint64) [][]byte {
+		r := make([][]byte, n)
+		for i := range r {
+			r[i] = make([]byte, 32)
+		}
+		return r
+	}
+	v1State := &ethpb.BeaconState{
+		GenesisTime:           1,
+		GenesisValidatorsRoot: make([]byte, 32),
+		Slot:                  slot,
+		Fork: &ethpb.Fork{
+			PreviousVersion: make([]byte, 4),
+			CurrentVersion:  make([]byte, 4),
+			Epoch:           epoch,
+		},
+		LatestBlockHeader: &ethpb.BeaconBlockHeader{
+			Slot:       slot,
+			ParentRoot: parentRoot,
+			StateRoot:  stateRoot,
+			BodyRoot:   bodyRoot,
+		},
+		BlockRoots:    roots(uint64(cfg.SlotsPerHistoricalRoot)),
+		StateRoots:    roots(uint64(cfg.SlotsPerHistoricalRoot)),
+		Eth1Data:      &ethpb.Eth1Data{DepositRoot: depositRoot, DepositCount: depositCount, BlockHash: blockHash},
+		Eth1DataVotes: []*ethpb.Eth1Data{{DepositRoot: depositRoot, BlockHash: blockHash}},
+		Validators: []*ethpb.Validator{{
+			PublicKey:             bytesutil.PadTo([]byte("pubkey"), 48),
+			WithdrawalCredentials: make([]byte, 32),
+			ExitEpoch:             epoch,
+		}},
+		Balances:    []uint64{cfg.MaxEffectiveBalance},
+		RandaoMixes: roots(uint64(cfg.EpochsPerHistoricalVector)),
+		Slashings:   make([]uint64, cfg.EpochsPerSlashingsVector),
+		PreviousEpochAttestations: []*ethpb.PendingAttestation{{
+			AggregationBits: bitfield.NewBitlist(8),
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: beaconBlockRoot,
+				Source:          &ethpb.Checkpoint{Root: sourceRoot},
+				Target:          &ethpb.Checkpoint{Root: targetRoot},
+			},
+			ProposerIndex: validatorIndex,
+		}},
+		JustificationBits:           bitfield.Bitvector4{0},
+		PreviousJustifiedCheckpoint: &ethpb.Checkpoint{Root: sourceRoot},
+		CurrentJustifiedCheckpoint:  &ethpb.Checkpoint{Root: sourceRoot},
+		FinalizedCheckpoint:         &ethpb.Checkpoint{Epoch: epoch, Root: targetRoot},
+	}
+
+	state := V1ToPbp2pState(v1State)
+	enc, err := state.MarshalSSZ()
+	require.NoError(t, err)
+	decoded := &pbp2p.BeaconState{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	assert.Equal(t, slot, decoded.Slot)
+	assert.Equal(t, epoch, decoded.Fork.Epoch)
+	assert.DeepEqual(t, v1State.Validators[0].PublicKey, decoded.Validators[0].PublicKey)
+	assert.Equal(t, epoch, decoded.Validators[0].ExitEpoch)
+	assert.Equal(t, validatorIndex, decoded.PreviousEpochAttestations[0].ProposerIndex)
+	assert.DeepEqual(t, targetRoot, decoded.FinalizedCheckpoint.Root)
+	assert.Equal(t, depositCount, decoded.Eth1Data.DepositCount)
+}
+
+func Test_Pbp2pToV1State(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 8)
+	require.NoError(t, st.SetSlot(slot))
+	require.NoError(t, st.AppendPreviousEpochAttestations(&pbp2p.PendingAttestation{
+		AggregationBits: bitfield.NewBitlist(8),
+		Data: &ethpb_alpha.AttestationData{
+			BeaconBlockRoot: beaconBlockRoot,
+			Source:          &ethpb_alpha.Checkpoint{Root: sourceRoot},
+			Target:          &ethpb_alpha.Checkpoint{Epoch: epoch, Root: targetRoot},
+		},
+		ProposerIndex: validatorIndex,
+	}))
+	state := st.InnerStateUnsafe()
+
+	v1State := Pbp2pToV1State(state)
+	assert.Equal(t, slot, v1State.Slot)
+	assert.Equal(t, len(state.Validators), len(v1State.Validators))
+	assert.DeepEqual(t, state.Validators[0].PublicKey, v1State.Validators[0].PublicKey)
+	assert.Equal(t, validatorIndex, v1State.PreviousEpochAttestations[0].ProposerIndex)
+	assert.Equal(t, epoch, v1State.PreviousEpochAttestations[0].Data.Target.Epoch)
+
+	expectedRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	root, err := V1ToPbp2pState(v1State).HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedRoot, root)
+}
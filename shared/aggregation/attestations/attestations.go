@@ -41,7 +41,9 @@ var _ = logrus.WithField("prefix", "aggregation.attestations")
 // of attestations is provided for aggregation.
 var ErrInvalidAttestationCount = errors.New("invalid number of attestations")
 
-// Aggregate aggregates attestations. The minimal number of attestations is returned.
+// Aggregate aggregates attestations. The minimal number of attestations is returned. Unless
+// another strategy is configured, attestations are aggregated with the optimized Maximum
+// Coverage strategy.
 // Aggregation occurs in-place i.e. contents of input array will be modified. Should you need to
 // preserve input attestations, clone them before aggregating:
 //
@@ -53,11 +55,11 @@ var ErrInvalidAttestationCount = errors.New("invalid number of attestations")
 func Aggregate(atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
 	strategy := AttestationAggregationStrategy(featureconfig.Get().AttestationAggregationStrategy)
 	switch strategy {
-	case "", NaiveAggregation:
+	case NaiveAggregation:
 		return NaiveAttestationAggregation(atts)
 	case MaxCoverAggregation:
 		return MaxCoverAttestationAggregation(atts)
-	case OptMaxCoverAggregation:
+	case "", OptMaxCoverAggregation:
 		return optMaxCoverAttestationAggregation(atts)
 	default:
 		return nil, errors.Wrapf(aggregation.ErrInvalidStrategy, "%q", strategy)
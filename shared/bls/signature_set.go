@@ -1,5 +1,9 @@
 package bls
 
+import (
+	"github.com/pkg/errors"
+)
+
 // SignatureSet refers to the defined set of
 // signatures and its respective public keys and
 // messages required to verify it.
@@ -7,19 +11,25 @@ type SignatureSet struct {
 	Signatures [][]byte
 	PublicKeys []PublicKey
 	Messages   [][32]byte
+	// Descriptions of the signatures, used to report which one is invalid. Sets built without
+	// descriptions are padded when joined.
+	Descriptions []string
 }
 
 // NewSet constructs an empty signature set object.
 func NewSet() *SignatureSet {
 	return &SignatureSet{
-		Signatures: [][]byte{},
-		PublicKeys: []PublicKey{},
-		Messages:   [][32]byte{},
+		Signatures:   [][]byte{},
+		PublicKeys:   []PublicKey{},
+		Messages:     [][32]byte{},
+		Descriptions: []string{},
 	}
 }
 
 // Join merges the provided signature set to out current one.
 func (s *SignatureSet) Join(set *SignatureSet) *SignatureSet {
+	s.Descriptions = s.paddedDescriptions()
+	s.Descriptions = append(s.Descriptions, set.paddedDescriptions()...)
 	s.Signatures = append(s.Signatures, set.Signatures...)
 	s.PublicKeys = append(s.PublicKeys, set.PublicKeys...)
 	s.Messages = append(s.Messages, set.Messages...)
@@ -30,3 +40,45 @@ func (s *SignatureSet) Join(set *SignatureSet) *SignatureSet {
 func (s *SignatureSet) Verify() (bool, error) {
 	return VerifyMultipleSignatures(s.Signatures, s.Messages, s.PublicKeys)
 }
+
+// VerifyVerbosely verifies the current signature set using the batch verify algorithm. As a
+// failed batch does not tell which signature is invalid, the signatures are then verified one by
+// one, and the returned error describes the first invalid signature.
+func (s *SignatureSet) VerifyVerbosely() (bool, error) {
+	valid, err := s.Verify()
+	if err == nil && valid {
+		return true, nil
+	}
+	if len(s.PublicKeys) != len(s.Signatures) || len(s.Messages) != len(s.Signatures) {
+		return false, errors.Errorf("signature set has %d signatures, %d public keys and %d messages",
+			len(s.Signatures), len(s.PublicKeys), len(s.Messages))
+	}
+	descriptions := s.paddedDescriptions()
+	for i := range s.Signatures {
+		sig, sigErr := SignatureFromBytes(s.Signatures[i])
+		if sigErr != nil {
+			return false, errors.Wrapf(sigErr, "could not deserialize %s", descriptions[i])
+		}
+		if !sig.Verify(s.PublicKeys[i], s.Messages[i][:]) {
+			return false, errors.Errorf("%s is invalid", descriptions[i])
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, errors.New("signature set failed batch verification although each signature is valid")
+}
+
+// paddedDescriptions returns the descriptions of the set, with a placeholder for each signature
+// it does not describe.
+func (s *SignatureSet) paddedDescriptions() []string {
+	if len(s.Descriptions) >= len(s.Signatures) {
+		return s.Descriptions
+	}
+	descriptions := make([]string, len(s.Signatures))
+	copy(descriptions, s.Descriptions)
+	for i := len(s.Descriptions); i < len(descriptions); i++ {
+		descriptions[i] = "signature without description"
+	}
+	return descriptions
+}
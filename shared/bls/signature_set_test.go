@@ -0,0 +1,42 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSignatureSet_VerifyVerbosely(t *testing.T) {
+	newSet := func(msg byte, description string) *SignatureSet {
+		priv, err := RandKey()
+		require.NoError(t, err)
+		root := [32]byte{msg}
+		set := &SignatureSet{
+			Signatures: [][]byte{priv.Sign(root[:]).Marshal()},
+			PublicKeys: []PublicKey{priv.PublicKey()},
+			Messages:   [][32]byte{root},
+		}
+		if description != "" {
+			set.Descriptions = []string{description}
+		}
+		return set
+	}
+
+	set := NewSet().Join(newSet(1, "block signature")).Join(newSet(2, "")).Join(newSet(3, "exit signature"))
+	require.DeepEqual(t, []string{"block signature", "signature without description", "exit signature"}, set.Descriptions)
+	valid, err := set.VerifyVerbosely()
+	require.NoError(t, err)
+	assert.Equal(t, true, valid)
+
+	// The invalid signature is pinpointed.
+	set.Messages[2] = [32]byte{4}
+	valid, err = set.VerifyVerbosely()
+	assert.Equal(t, false, valid)
+	assert.ErrorContains(t, "exit signature is invalid", err)
+
+	set.Signatures[0] = []byte{'b', 'a', 'd'}
+	valid, err = set.VerifyVerbosely()
+	assert.Equal(t, false, valid)
+	assert.ErrorContains(t, "could not deserialize block signature", err)
+}
@@ -30,6 +30,12 @@ var (
 		Usage: "Logging verbosity (trace, debug, info=default, warn, error, fatal, panic)",
 		Value: "info",
 	}
+	// ModuleLogLevelsFlag defines the logging verbosity of individual modules.
+	ModuleLogLevelsFlag = &cli.StringFlag{
+		Name: "log-level",
+		Usage: "Logging verbosity of individual modules overriding --verbosity, as comma separated module=level " +
+			"pairs, for example p2p=debug,sync=info. Modules are the prefixes of the log messages.",
+	}
 	// DataDirFlag defines a path on disk.
 	DataDirFlag = &cli.StringFlag{
 		Name:  "datadir",
@@ -193,6 +199,21 @@ var (
 		Name:  "log-file",
 		Usage: "Specify log file name, relative or absolute",
 	}
+	// LogMaxSize specifies the size of the log file beyond which it is rotated.
+	LogMaxSize = &cli.IntFlag{
+		Name:  "log-max-size",
+		Usage: "The size in megabytes beyond which the log file is rotated. 0 disables the rotation.",
+	}
+	// LogMaxAge specifies how long rotated log files are kept.
+	LogMaxAge = &cli.DurationFlag{
+		Name:  "log-max-age",
+		Usage: "The duration after which rotated log files are removed, for example 168h. 0 keeps them.",
+	}
+	// LogMaxBackups specifies the number of rotated log files kept.
+	LogMaxBackups = &cli.IntFlag{
+		Name:  "log-max-backups",
+		Usage: "The number of rotated log files kept. 0 keeps them all.",
+	}
 	// EnableUPnPFlag specifies if UPnP should be enabled or not. The default value is false.
 	EnableUPnPFlag = &cli.BoolFlag{
 		Name:  "enable-upnp",
@@ -206,7 +227,7 @@ var (
 	// ChainConfigFileFlag specifies the filepath to load flag values.
 	ChainConfigFileFlag = &cli.StringFlag{
 		Name:  "chain-config-file",
-		Usage: "The path to a YAML file with chain config values",
+		Usage: "The path to a YAML file with chain config values, in the consensus spec config format. BOOTNODES and DEPOSIT_CONTRACT_BLOCK may also be set to run a custom network",
 	}
 	// GrpcMaxCallRecvMsgSizeFlag defines the max call message size for GRPC
 	GrpcMaxCallRecvMsgSizeFlag = &cli.IntFlag{
@@ -222,8 +243,9 @@ var (
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
 	// which will be used to restore the database.
 	RestoreSourceFileFlag = &cli.StringFlag{
-		Name:  "restore-source-file",
-		Usage: "Filepath to the backed-up database file which will be used to restore the database",
+		Name:    "restore-source-file",
+		Aliases: []string{"backup-file"},
+		Usage:   "Filepath to the backed-up database file which will be used to restore the database",
 	}
 	// RestoreTargetDirFlag specifies the target directory of the restored database.
 	RestoreTargetDirFlag = &cli.StringFlag{
@@ -231,12 +253,30 @@ var (
 		Usage: "Target directory of the restored database",
 		Value: DefaultDataDir(),
 	}
+	// MigrationDryRunFlag applies the pending database schema migrations without writing the changes.
+	MigrationDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Applies the pending database schema migrations without writing the changes, to check that they succeed",
+	}
 	// BoltMMapInitialSizeFlag specifies the initial size in bytes of boltdb's mmap syscall.
 	BoltMMapInitialSizeFlag = &cli.IntFlag{
 		Name:  "bolt-mmap-initial-size",
 		Usage: "Specifies the size in bytes of bolt db's mmap syscall allocation",
 		Value: 536870912, // 512 Mb as a default value.
 	}
+	// BoltFreelistTypeFlag specifies the type of boltdb's freelist.
+	BoltFreelistTypeFlag = &cli.StringFlag{
+		Name: "bolt-freelist-type",
+		Usage: "Specifies the type of bolt db's freelist, array or hashmap. The hashmap freelist is faster to update " +
+			"in large databases with many free pages",
+		Value: "array",
+	}
+	// BoltNoFreelistSyncFlag disables writing boltdb's freelist to disk.
+	BoltNoFreelistSyncFlag = &cli.BoolFlag{
+		Name: "bolt-no-freelist-sync",
+		Usage: "Does not write bolt db's freelist to disk on each commit, which reduces writes at the cost of " +
+			"rebuilding the freelist when opening the database",
+	}
 )
 
 // LoadFlagsFromConfig sets flags values from config file if ConfigFileFlag is set.
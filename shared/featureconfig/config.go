@@ -43,6 +43,7 @@ type Flags struct {
 	SkipBLSVerify                      bool // Skips BLS verification across the runtime.
 	EnableBlst                         bool // Enables new BLS library from supranational.
 	SlasherProtection                  bool // SlasherProtection protects validator fron sending over a slashable offense over the network using external slasher.
+	SlasherHighestAttestationCheck     bool // SlasherHighestAttestationCheck compares attestations with the highest attestations seen by the external slasher before signing.
 	EnablePeerScorer                   bool // EnablePeerScorer enables experimental peer scoring in p2p.
 	EnableLargerGossipHistory          bool // EnableLargerGossipHistory increases the gossip history we store in our caches.
 	WriteWalletPasswordOnWebOnboarding bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
@@ -65,6 +66,7 @@ type Flags struct {
 
 	KafkaBootstrapServers          string // KafkaBootstrapServers to find kafka servers to stream blocks, attestations, etc.
 	AttestationAggregationStrategy string // AttestationAggregationStrategy defines aggregation strategy to be used when aggregating.
+	BulkVerificationWorkers        int    // BulkVerificationWorkers bounds concurrent item verifications of bulk pool submissions, 0 means GOMAXPROCS.
 
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
@@ -184,6 +186,7 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		log.WithField(updateHeadTimely.Name, updateHeadTimely.Usage).Warn(enabledFeatureFlag)
 		cfg.UpdateHeadTimely = true
 	}
+	cfg.BulkVerificationWorkers = ctx.Int(bulkVerificationWorkers.Name)
 	Init(cfg)
 }
 
@@ -211,6 +214,10 @@ func ConfigureValidator(ctx *cli.Context) {
 		log.WithField(enableExternalSlasherProtectionFlag.Name, enableExternalSlasherProtectionFlag.Usage).Warn(enabledFeatureFlag)
 		cfg.SlasherProtection = true
 	}
+	if ctx.Bool(enableSlasherHighestAttestationCheckFlag.Name) {
+		log.WithField(enableSlasherHighestAttestationCheckFlag.Name, enableSlasherHighestAttestationCheckFlag.Usage).Warn(enabledFeatureFlag)
+		cfg.SlasherHighestAttestationCheck = true
+	}
 	if ctx.Bool(writeWalletPasswordOnWebOnboarding.Name) {
 		log.WithField(writeWalletPasswordOnWebOnboarding.Name, writeWalletPasswordOnWebOnboarding.Usage).Warn(enabledFeatureFlag)
 		cfg.WriteWalletPasswordOnWebOnboarding = true
@@ -52,3 +52,13 @@ func TestConfigureBeaconConfig(t *testing.T) {
 	c := Get()
 	assert.Equal(t, true, c.PyrmontTestnet)
 }
+
+func TestConfigureBeaconConfig_BulkVerificationWorkers(t *testing.T) {
+	defer Init(&Flags{})
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Int(bulkVerificationWorkers.Name, 3, "test")
+	context := cli.NewContext(&app, set, nil)
+	ConfigureBeaconChain(context)
+	assert.Equal(t, 3, Get().BulkVerificationWorkers)
+}
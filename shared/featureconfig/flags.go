@@ -40,6 +40,11 @@ var (
 		Usage: "Enables the validator to connect to external slasher to prevent it from " +
 			"transmitting a slashable offence over the network.",
 	}
+	enableSlasherHighestAttestationCheckFlag = &cli.BoolFlag{
+		Name: "enable-slasher-highest-attestation-check",
+		Usage: "Enables the validator to compare attestations with the highest source and target epochs " +
+			"seen by the external slasher before signing them. Requires --enable-external-slasher-protection.",
+	}
 	disableLookbackFlag = &cli.BoolFlag{
 		Name:  "disable-lookback",
 		Usage: "Disables use of the lookback feature and updates attestation history for validators from head to epoch 0",
@@ -51,7 +56,7 @@ var (
 	attestationAggregationStrategy = &cli.StringFlag{
 		Name:  "attestation-aggregation-strategy",
 		Usage: "Which strategy to use when aggregating attestations, one of: naive, max_cover, opt_max_cover.",
-		Value: "max_cover",
+		Value: "opt_max_cover",
 	}
 	forceOptMaxCoverAggregationStategy = &cli.BoolFlag{
 		Name:  "attestation-aggregation-force-opt-maxcover",
@@ -109,6 +114,11 @@ var (
 		Name:  "update-head-timely",
 		Usage: "Improves update head time by updating head right after state transition",
 	}
+	bulkVerificationWorkers = &cli.IntFlag{
+		Name: "bulk-verification-workers",
+		Usage: "Maximum number of items of a bulk pool submission verified concurrently. " +
+			"Defaults to GOMAXPROCS when unset or zero.",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -123,6 +133,7 @@ var devModeFlags = []cli.Flag{
 var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	writeWalletPasswordOnWebOnboarding,
 	enableExternalSlasherProtectionFlag,
+	enableSlasherHighestAttestationCheckFlag,
 	disableAttestingHistoryDBCache,
 	ToledoTestnet,
 	PyrmontTestnet,
@@ -162,6 +173,7 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	enableNextSlotStateCache,
 	forceOptMaxCoverAggregationStategy,
 	updateHeadTimely,
+	bulkVerificationWorkers,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -0,0 +1,40 @@
+package featureconfig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSetToggle(t *testing.T) {
+	defer Init(&Flags{})
+	defer func() {
+		toggleChanges = nil
+	}()
+	Init(&Flags{PyrmontTestnet: true})
+	prev := Get()
+
+	_, err := SetToggle("enable-blst", true, "test")
+	assert.ErrorContains(t, "cannot be changed at runtime", err)
+
+	change, err := SetToggle(disableBroadcastSlashingFlag.Name, true, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, false, change.Previous)
+	assert.Equal(t, true, Get().DisableBroadcastSlashings)
+	assert.Equal(t, true, Get().PyrmontTestnet)
+	// The config held before the change is left unchanged.
+	assert.Equal(t, false, prev.DisableBroadcastSlashings)
+	assert.Equal(t, true, Toggles()[disableBroadcastSlashingFlag.Name])
+	assert.Equal(t, false, Toggles()[disableGRPCConnectionLogging.Name])
+
+	for i := 0; i < maxToggleChanges; i++ {
+		_, err := SetToggle(disableGRPCConnectionLogging.Name, i%2 == 0, fmt.Sprintf("%d", i))
+		require.NoError(t, err)
+	}
+	changes := ToggleChanges()
+	require.Equal(t, maxToggleChanges, len(changes))
+	assert.Equal(t, "0", changes[0].Source)
+	assert.Equal(t, fmt.Sprintf("%d", maxToggleChanges-1), changes[len(changes)-1].Source)
+}
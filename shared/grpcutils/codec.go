@@ -0,0 +1,34 @@
+package grpcutils
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// JSONCodecName is the content subtype of the gRPC calls whose messages are plain Go structs encoded
+// as JSON, such as the Prysm specific methods of the v1 services which have no proto definition.
+// Clients select it with grpc.CallContentSubtype(JSONCodecName).
+const JSONCodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes gRPC messages with encoding/json.
+type jsonCodec struct{}
+
+// Marshal returns the JSON encoding of v.
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes the JSON encoded data into v.
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name returns the content subtype of the codec.
+func (jsonCodec) Name() string {
+	return JSONCodecName
+}
@@ -0,0 +1,21 @@
+package grpcutils
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestJSONCodec(t *testing.T) {
+	codec := encoding.GetCodec(JSONCodecName)
+	require.NotNil(t, codec)
+
+	failure := &IndexedFailure{Index: 2, Message: "Invalid exit"}
+	enc, err := codec.Marshal(failure)
+	require.NoError(t, err)
+	decoded := &IndexedFailure{}
+	require.NoError(t, codec.Unmarshal(enc, decoded))
+	assert.DeepEqual(t, failure, decoded)
+}
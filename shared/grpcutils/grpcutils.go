@@ -2,6 +2,8 @@ package grpcutils
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +12,34 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// HTTPCodeMetadataKey is the gRPC response header with which a server asks the HTTP gateway for a
+// successful status code other than 200.
+const HTTPCodeMetadataKey = "x-http-code"
+
+// ResponseFieldMetadataPrefix prefixes the gRPC response headers with which a server passes the HTTP
+// gateway top level fields of a JSON response that are missing from the response message.
+const ResponseFieldMetadataPrefix = "x-response-field-"
+
+// SignatureMetadataKey is the gRPC request header with which the HTTP gateway passes the signatures
+// of signed request items to a server whose request message only holds the unsigned items.
+const SignatureMetadataKey = "x-signature"
+
+// RequestParamMetadataPrefix prefixes the gRPC request headers with which the HTTP gateway passes a
+// server the query parameters that are missing from the request message, such as page tokens, and
+// the presence of parameters whose zero value is meaningful.
+const RequestParamMetadataPrefix = "x-request-param-"
+
+// FailuresMetadataKey is the gRPC response trailer with which a server rejecting a batch request
+// passes the HTTP gateway the failures of the individual items. Being a binary key, its JSON value
+// is base64 encoded on the wire.
+const FailuresMetadataKey = "x-failures-bin"
+
+// IndexedFailure reports why the item at Index of a batch request failed.
+type IndexedFailure struct {
+	Index   uint64 `json:"index"`
+	Message string `json:"message"`
+}
+
 // LogRequests logs the gRPC backend as well as request duration when the log level is set to debug
 // or higher.
 func LogRequests(
@@ -79,3 +109,99 @@ func AppendHeaders(parent context.Context, headers []string) context.Context {
 	}
 	return parent
 }
+
+// SetHTTPCode sets the HTTP status code with which the gateway answers a successful call.
+func SetHTTPCode(ctx context.Context, code int) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(HTTPCodeMetadataKey, strconv.Itoa(code)))
+}
+
+// HTTPCode returns the HTTP status code set with SetHTTPCode in the response header md, if any.
+func HTTPCode(md metadata.MD) (int, bool) {
+	values := md.Get(HTTPCodeMetadataKey)
+	if len(values) == 0 {
+		return 0, false
+	}
+	code, err := strconv.Atoi(values[len(values)-1])
+	if err != nil {
+		return 0, false
+	}
+	return code, true
+}
+
+// SetResponseField sets a top level field, such as the dependent_root of the duties endpoints, which
+// the gateway adds to the JSON response of a successful call.
+func SetResponseField(ctx context.Context, name, value string) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(ResponseFieldMetadataPrefix+name, value))
+}
+
+// ResponseFields returns the fields set with SetResponseField in the response header md.
+func ResponseFields(md metadata.MD) map[string]string {
+	fields := make(map[string]string)
+	for key, values := range md {
+		if !strings.HasPrefix(key, ResponseFieldMetadataPrefix) || len(values) == 0 {
+			continue
+		}
+		fields[strings.TrimPrefix(key, ResponseFieldMetadataPrefix)] = values[len(values)-1]
+	}
+	return fields
+}
+
+// AppendSignatures attaches the 0x-prefixed hex signatures of the request items, in the order of the
+// items, to the outgoing context.
+func AppendSignatures(ctx context.Context, signatures ...string) context.Context {
+	for _, sig := range signatures {
+		ctx = metadata.AppendToOutgoingContext(ctx, SignatureMetadataKey, sig)
+	}
+	return ctx
+}
+
+// Signatures returns the signatures attached with AppendSignatures to the incoming context.
+func Signatures(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	return md.Get(SignatureMetadataKey)
+}
+
+// AppendRequestParam attaches the value of a query parameter to the outgoing context.
+func AppendRequestParam(ctx context.Context, name, value string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestParamMetadataPrefix+name, value)
+}
+
+// RequestParam returns the value of a query parameter attached with AppendRequestParam to the
+// incoming context, and whether it was attached.
+func RequestParam(ctx context.Context, name string) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(RequestParamMetadataPrefix + name)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
+
+// SetFailures sets the failures of the items of a batch request, which the gateway reports along
+// with the error the call returns.
+func SetFailures(ctx context.Context, failures []*IndexedFailure) error {
+	enc, err := json.Marshal(failures)
+	if err != nil {
+		return err
+	}
+	return grpc.SetTrailer(ctx, metadata.Pairs(FailuresMetadataKey, string(enc)))
+}
+
+// Failures returns the failures set with SetFailures in the response trailer md, if any.
+func Failures(md metadata.MD) []*IndexedFailure {
+	values := md.Get(FailuresMetadataKey)
+	if len(values) == 0 {
+		return nil
+	}
+	var failures []*IndexedFailure
+	if err := json.Unmarshal([]byte(values[len(values)-1]), &failures); err != nil {
+		return nil
+	}
+	return failures
+}
@@ -2,6 +2,7 @@ package grpcutils
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
@@ -54,3 +55,55 @@ func TestAppendHeaders(t *testing.T) {
 		assert.Equal(t, "value=1", md.Get("first")[0])
 	})
 }
+
+func TestHTTPCode(t *testing.T) {
+	code, ok := HTTPCode(metadata.Pairs(HTTPCodeMetadataKey, "206"))
+	require.Equal(t, true, ok)
+	assert.Equal(t, 206, code)
+
+	_, ok = HTTPCode(metadata.Pairs(HTTPCodeMetadataKey, "partial"))
+	assert.Equal(t, false, ok)
+	_, ok = HTTPCode(metadata.MD{})
+	assert.Equal(t, false, ok)
+}
+
+func TestResponseFields(t *testing.T) {
+	md := metadata.Pairs(
+		ResponseFieldMetadataPrefix+"dependent_root", "0xcf8e",
+		HTTPCodeMetadataKey, "206",
+	)
+	assert.DeepEqual(t, map[string]string{"dependent_root": "0xcf8e"}, ResponseFields(md))
+	assert.Equal(t, 0, len(ResponseFields(metadata.MD{})))
+}
+
+func TestSignatures(t *testing.T) {
+	ctx := AppendSignatures(context.Background(), "0x01", "0x02")
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, []string{"0x01", "0x02"}, Signatures(metadata.NewIncomingContext(context.Background(), md)))
+	assert.Equal(t, 0, len(Signatures(context.Background())))
+}
+
+func TestRequestParam(t *testing.T) {
+	ctx := AppendRequestParam(context.Background(), "page_token", "2")
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.Equal(t, true, ok)
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+
+	value, ok := RequestParam(ctx, "page_token")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "2", value)
+	_, ok = RequestParam(ctx, "page_size")
+	assert.Equal(t, false, ok)
+	_, ok = RequestParam(context.Background(), "page_token")
+	assert.Equal(t, false, ok)
+}
+
+func TestFailures(t *testing.T) {
+	failures := []*IndexedFailure{{Index: 1, Message: "Invalid attestation"}}
+	enc, err := json.Marshal(failures)
+	require.NoError(t, err)
+	assert.DeepEqual(t, failures, Failures(metadata.Pairs(FailuresMetadataKey, string(enc))))
+	assert.Equal(t, 0, len(Failures(metadata.Pairs(FailuresMetadataKey, "invalid"))))
+	assert.Equal(t, 0, len(Failures(metadata.MD{})))
+}
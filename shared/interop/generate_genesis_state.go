@@ -38,6 +38,21 @@ func GenerateGenesisState(genesisTime, numValidators uint64) (*pb.BeaconState, [
 	return GenerateGenesisStateFromDepositData(genesisTime, depositDataItems, depositDataRoots)
 }
 
+// GenerateGenesisStateFromMnemonic creates a genesis state given a genesis time and the number of
+// validators whose keys are derived from a mnemonic, as in KeysFromMnemonic.
+// If a genesis time of 0 is supplied it is set to the current time.
+func GenerateGenesisStateFromMnemonic(genesisTime uint64, mnemonic string, numValidators uint64) (*pb.BeaconState, []*ethpb.Deposit, error) {
+	privKeys, pubKeys, err := KeysFromMnemonic(mnemonic, numValidators)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not derive keys for %d validators from mnemonic", numValidators)
+	}
+	depositDataItems, depositDataRoots, err := DepositDataFromKeys(privKeys, pubKeys)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not generate deposit data from keys")
+	}
+	return GenerateGenesisStateFromDepositData(genesisTime, depositDataItems, depositDataRoots)
+}
+
 // GenerateGenesisStateFromDepositData creates a genesis state given a list of
 // deposit data items and their corresponding roots.
 func GenerateGenesisStateFromDepositData(
@@ -0,0 +1,39 @@
+package interop
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/tyler-smith/go-bip39"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// validatingKeyDerivationPathTemplate is the EIP-2334 path of the validating key of an account,
+// as used by derived wallets.
+const validatingKeyDerivationPathTemplate = "m/12381/3600/%d/0/0"
+
+// KeysFromMnemonic derives the validating keys of the first numKeys accounts of a mnemonic, along
+// the same EIP-2334 paths as a derived wallet recovered from it, so that the validators of a
+// generated genesis state can be run from such a wallet.
+func KeysFromMnemonic(mnemonic string, numKeys uint64) ([]bls.SecretKey, []bls.PublicKey, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, nil, bip39.ErrInvalidMnemonic
+	}
+	seed := bip39.NewSeed(mnemonic, "" /*password*/)
+	privKeys := make([]bls.SecretKey, numKeys)
+	pubKeys := make([]bls.PublicKey, numKeys)
+	for i := uint64(0); i < numKeys; i++ {
+		key, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf(validatingKeyDerivationPathTemplate, i))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not derive key %d", i)
+		}
+		privKey, err := bls.SecretKeyFromBytes(key.Marshal())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not convert key %d", i)
+		}
+		privKeys[i] = privKey
+		pubKeys[i] = privKey.PublicKey()
+	}
+	return privKeys, pubKeys, nil
+}
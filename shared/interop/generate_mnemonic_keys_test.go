@@ -0,0 +1,43 @@
+package interop_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/tyler-smith/go-bip39"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+const testMnemonic = "tumble turn jewel sudden social great water general cabin jacket bounce dry flip monster advance problem social half flee inform century chicken hard reason"
+
+func TestKeysFromMnemonic(t *testing.T) {
+	privKeys, pubKeys, err := interop.KeysFromMnemonic(testMnemonic, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(privKeys))
+	seed := bip39.NewSeed(testMnemonic, "")
+	for i := range privKeys {
+		wanted, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0/0", i))
+		require.NoError(t, err)
+		assert.DeepEqual(t, wanted.Marshal(), privKeys[i].Marshal())
+		assert.DeepEqual(t, wanted.PublicKey().Marshal(), pubKeys[i].Marshal())
+	}
+
+	_, _, err = interop.KeysFromMnemonic("not a mnemonic", 1)
+	assert.ErrorContains(t, bip39.ErrInvalidMnemonic.Error(), err)
+}
+
+func TestGenerateGenesisStateFromMnemonic(t *testing.T) {
+	_, pubKeys, err := interop.KeysFromMnemonic(testMnemonic, 8)
+	require.NoError(t, err)
+	genesisState, deposits, err := interop.GenerateGenesisStateFromMnemonic(1000, testMnemonic, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 8, len(deposits))
+	assert.Equal(t, uint64(1000), genesisState.GenesisTime)
+	require.Equal(t, 8, len(genesisState.Validators))
+	for i, v := range genesisState.Validators {
+		assert.DeepEqual(t, pubKeys[i].Marshal(), v.PublicKey)
+	}
+}
@@ -0,0 +1,191 @@
+package logutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// prefixKey is the field naming the module of a log entry.
+const prefixKey = "prefix"
+
+var levels = &moduleLevels{defaultLevel: logrus.InfoLevel, modules: make(map[string]logrus.Level)}
+
+// moduleLevels are the log levels of the modules, which are the prefixes of the log entries.
+type moduleLevels struct {
+	lock         sync.RWMutex
+	defaultLevel logrus.Level
+	modules      map[string]logrus.Level
+}
+
+// ParseModuleLevels parses log levels given as module=level pairs separated by commas, for example
+// p2p=debug,sync=info.
+func ParseModuleLevels(s string) (map[string]logrus.Level, error) {
+	modules := make(map[string]logrus.Level)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, errors.Errorf("invalid module log level %q, expected module=level", pair)
+		}
+		level, err := logrus.ParseLevel(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid log level of module %s", parts[0])
+		}
+		modules[strings.TrimSpace(parts[0])] = level
+	}
+	return modules, nil
+}
+
+// ConfigureLevels sets the log level of the entries of each module, and the default level of the
+// entries of the other modules. It must be called once the log formatter is set, as the entries
+// are filtered by wrapping the formatter. Log hooks, such as journald's, receive the entries of
+// every module up to the most verbose level.
+func ConfigureLevels(defaultLevel logrus.Level, modules map[string]logrus.Level) {
+	levels.lock.Lock()
+	levels.defaultLevel = defaultLevel
+	levels.modules = make(map[string]logrus.Level, len(modules))
+	for m, l := range modules {
+		levels.modules[m] = l
+	}
+	levels.lock.Unlock()
+	levels.apply()
+}
+
+// SetDefaultLevel sets the log level of the modules without a level of their own.
+func SetDefaultLevel(level logrus.Level) {
+	levels.lock.Lock()
+	levels.defaultLevel = level
+	levels.lock.Unlock()
+	levels.apply()
+}
+
+// SetModuleLevel sets the log level of a module, or removes it so that the module logs at the
+// default level if level is nil.
+func SetModuleLevel(module string, level *logrus.Level) {
+	levels.lock.Lock()
+	if level == nil {
+		delete(levels.modules, module)
+	} else {
+		levels.modules[module] = *level
+	}
+	levels.lock.Unlock()
+	levels.apply()
+}
+
+// apply sets the level of the logger to the most verbose level, so that the entries of each module
+// reach the formatter filtering them. The lock must not be held, as the logger holds its own lock
+// while formatting an entry.
+func (m *moduleLevels) apply() {
+	m.lock.RLock()
+	max := m.defaultLevel
+	for _, l := range m.modules {
+		if l > max {
+			max = l
+		}
+	}
+	m.lock.RUnlock()
+	logger := logrus.StandardLogger()
+	if _, ok := logger.Formatter.(*levelFilter); !ok {
+		logger.SetFormatter(&levelFilter{next: logger.Formatter})
+	}
+	logger.SetLevel(max)
+}
+
+func (m *moduleLevels) enabled(entry *logrus.Entry) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	level := m.defaultLevel
+	if prefix, ok := entry.Data[prefixKey].(string); ok {
+		if l, ok := m.modules[prefix]; ok {
+			level = l
+		}
+	}
+	return entry.Level <= level
+}
+
+// levelFilter drops the entries below the level of their module before formatting them.
+type levelFilter struct {
+	next logrus.Formatter
+}
+
+// Format the entry with the wrapped formatter, or return no output if its module does not log at
+// its level.
+func (f *levelFilter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !levels.enabled(entry) {
+		return nil, nil
+	}
+	return f.next.Format(entry)
+}
+
+// levelsJSON is the representation of the log levels served by LevelsHandler.
+type levelsJSON struct {
+	Default string            `json:"default"`
+	Modules map[string]string `json:"modules"`
+}
+
+// levelRequestJSON is a request to LevelsHandler to change a log level.
+type levelRequestJSON struct {
+	// Module is the module to set the level of, or the default level if empty.
+	Module string `json:"module"`
+	// Level is the level to set. An empty level removes the level of the module.
+	Level string `json:"level"`
+}
+
+// LevelsHandler serves the log levels on GET requests, and sets the log level of a module or the
+// default level on POST requests with a body such as {"module": "sync", "level": "debug"}.
+func LevelsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req levelRequestJSON
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Could not decode request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if req.Level == "" {
+			if req.Module == "" {
+				http.Error(w, "The default level cannot be removed", http.StatusBadRequest)
+				return
+			}
+			SetModuleLevel(req.Module, nil)
+			logrus.WithField("module", req.Module).Info("Removed module log level")
+			break
+		}
+		level, err := logrus.ParseLevel(req.Level)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Module == "" {
+			SetDefaultLevel(level)
+		} else {
+			SetModuleLevel(req.Module, &level)
+		}
+		logrus.WithFields(logrus.Fields{
+			"module": req.Module,
+			"level":  level,
+		}).Info("Changed log level")
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	levels.lock.RLock()
+	resp := levelsJSON{Default: levels.defaultLevel.String(), Modules: make(map[string]string, len(levels.modules))}
+	for m, l := range levels.modules {
+		resp.Modules[m] = l.String()
+	}
+	levels.lock.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logrus.WithError(err).Error("Could not write log levels")
+	}
+}
@@ -0,0 +1,77 @@
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseModuleLevels(t *testing.T) {
+	modules, err := ParseModuleLevels("p2p=debug, sync=info,")
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[string]logrus.Level{"p2p": logrus.DebugLevel, "sync": logrus.InfoLevel}, modules)
+
+	_, err = ParseModuleLevels("p2p")
+	assert.ErrorContains(t, "expected module=level", err)
+	_, err = ParseModuleLevels("p2p=loud")
+	assert.ErrorContains(t, "invalid log level of module p2p", err)
+}
+
+func TestConfigureLevels(t *testing.T) {
+	logger := logrus.StandardLogger()
+	formatter, level, out := logger.Formatter, logger.GetLevel(), logger.Out
+	defer func() {
+		ConfigureLevels(logrus.InfoLevel, nil)
+		logger.SetFormatter(formatter)
+		logger.SetLevel(level)
+		logger.SetOutput(out)
+	}()
+	buf := new(bytes.Buffer)
+	logger.SetOutput(buf)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	ConfigureLevels(logrus.InfoLevel, map[string]logrus.Level{"p2p": logrus.DebugLevel, "sync": logrus.WarnLevel})
+	assert.Equal(t, logrus.DebugLevel, logger.GetLevel())
+	logrus.WithField("prefix", "p2p").Debug("p2p debug")
+	logrus.WithField("prefix", "sync").Info("sync info")
+	logrus.WithField("prefix", "node").Debug("node debug")
+	logrus.WithField("prefix", "node").Info("node info")
+	assert.Equal(t, true, strings.Contains(buf.String(), "p2p debug"))
+	assert.Equal(t, false, strings.Contains(buf.String(), "sync info"))
+	assert.Equal(t, false, strings.Contains(buf.String(), "node debug"))
+	assert.Equal(t, true, strings.Contains(buf.String(), "node info"))
+
+	// Levels are changed at runtime through the handler.
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		LevelsHandler(rec, httptest.NewRequest(http.MethodPost, "/logging", strings.NewReader(body)))
+		return rec
+	}
+	rec := post(`{"module": "sync", "level": "debug"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	resp := &levelsJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	assert.DeepEqual(t, &levelsJSON{Default: "info", Modules: map[string]string{"p2p": "debug", "sync": "debug"}}, resp)
+	logrus.WithField("prefix", "sync").Debug("sync debug")
+	assert.Equal(t, true, strings.Contains(buf.String(), "sync debug"))
+
+	require.Equal(t, http.StatusOK, post(`{"module": "p2p"}`).Code)
+	require.Equal(t, http.StatusOK, post(`{"level": "trace"}`).Code)
+	assert.Equal(t, logrus.TraceLevel, logger.GetLevel())
+	assert.Equal(t, http.StatusBadRequest, post(`{"level": ""}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"module": "p2p", "level": "loud"}`).Code)
+
+	rec = httptest.NewRecorder()
+	LevelsHandler(rec, httptest.NewRequest(http.MethodGet, "/logging", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	resp = &levelsJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	assert.DeepEqual(t, &levelsJSON{Default: "trace", Modules: map[string]string{"sync": "debug"}}, resp)
+}
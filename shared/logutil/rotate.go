@@ -0,0 +1,155 @@
+package logutil
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// backupTimeFormat is the format of the timestamp appended to the name of a rotated log file.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// Rotation configures the rotation of a log file. A zero field disables the corresponding limit.
+type Rotation struct {
+	// MaxSize is the size in bytes beyond which the log file is rotated.
+	MaxSize int64
+	// MaxAge is the duration after which rotated log files are removed.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated log files kept.
+	MaxBackups int
+}
+
+// ConfigureRotatingLogging adds a log-to-file writer like ConfigurePersistentLogging, which rotates
+// the log file once it grows beyond the maximum size. A rotated file is renamed with the time of its
+// rotation appended to its name.
+func ConfigureRotatingLogging(logFileName string, rotation Rotation) error {
+	if rotation == (Rotation{}) {
+		return ConfigurePersistentLogging(logFileName)
+	}
+	logrus.WithFields(logrus.Fields{
+		"logFileName": logFileName,
+		"maxSize":     rotation.MaxSize,
+		"maxAge":      rotation.MaxAge,
+		"maxBackups":  rotation.MaxBackups,
+	}).Info("Logs will be made persistent and rotated")
+	f, err := newRotatingFile(logFileName, rotation)
+	if err != nil {
+		return err
+	}
+
+	addLogWriter(f)
+
+	logrus.Info("File logging initialized")
+	return nil
+}
+
+// rotatingFile is a log file rotated once it grows beyond its maximum size.
+type rotatingFile struct {
+	path     string
+	rotation Rotation
+	lock     sync.Mutex
+	file     *os.File
+	size     int64
+}
+
+func newRotatingFile(path string, rotation Rotation) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, rotation: rotation}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	r.prune()
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		// Nothing is logged, as the file may be opened while writing a log entry.
+		_ = f.Close()
+		return errors.Wrap(err, "could not read log file size")
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// Write writes p to the log file, rotating it first if p would grow it beyond its maximum size.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.rotation.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.rotation.MaxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	backup := r.path + "." + time.Now().Format(backupTimeFormat)
+	if err := os.Rename(r.path, backup); err != nil {
+		return err
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	// Backups are pruned in the background, as the rotation happens while writing a log entry, which
+	// cannot log itself.
+	go r.prune()
+	return nil
+}
+
+// prune removes the rotated log files beyond the maximum number of backups, oldest first, and those
+// older than the maximum age.
+func (r *rotatingFile) prune() {
+	if r.rotation.MaxBackups <= 0 && r.rotation.MaxAge <= 0 {
+		return
+	}
+	backups, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		return
+	}
+	type backup struct {
+		path string
+		time time.Time
+	}
+	rotated := make([]backup, 0, len(backups))
+	for _, b := range backups {
+		t, err := time.ParseInLocation(backupTimeFormat, strings.TrimPrefix(b, r.path+"."), time.Local)
+		if err != nil {
+			// Not a rotated log file.
+			continue
+		}
+		rotated = append(rotated, backup{path: b, time: t})
+	}
+	// Newest first.
+	sort.Slice(rotated, func(i, j int) bool {
+		return rotated[i].time.After(rotated[j].time)
+	})
+	for i, b := range rotated {
+		tooMany := r.rotation.MaxBackups > 0 && i >= r.rotation.MaxBackups
+		tooOld := r.rotation.MaxAge > 0 && time.Since(b.time) > r.rotation.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			logrus.WithError(err).WithField("file", b.path).Warn("Could not remove rotated log file")
+		}
+	}
+}
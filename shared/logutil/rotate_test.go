@@ -0,0 +1,51 @@
+package logutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beacon.log")
+	// A stale backup, older than the maximum age.
+	stale := path + "." + time.Now().Add(-48*time.Hour).Format(backupTimeFormat)
+	require.NoError(t, ioutil.WriteFile(stale, []byte("stale"), 0600))
+	// A file which is not a backup.
+	other := path + ".old"
+	require.NoError(t, ioutil.WriteFile(other, []byte("other"), 0600))
+
+	f, err := newRotatingFile(path, Rotation{MaxSize: 10, MaxAge: 24 * time.Hour, MaxBackups: 2})
+	require.NoError(t, err)
+	_, err = os.Stat(stale)
+	assert.Equal(t, true, os.IsNotExist(err), "Stale backup was not removed")
+
+	for _, line := range []string{"12345\n", "1234\n", "abcdef\n", "ghijkl\n", "mnopqr\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+		// Backups are named after the time of their rotation.
+		time.Sleep(2 * time.Millisecond)
+	}
+	f.prune()
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "mnopqr\n", string(content))
+	backups, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	// The two newest backups and the file which is not a backup.
+	require.Equal(t, 3, len(backups))
+	assert.Equal(t, other, backups[2])
+	content, err = ioutil.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef\n", string(content))
+	content, err = ioutil.ReadFile(backups[1])
+	require.NoError(t, err)
+	assert.Equal(t, "ghijkl\n", string(content))
+}
@@ -9,8 +9,17 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// chainNetworkConfig holds the values of a chain config file which belong to the network
+// config rather than to the beacon chain config. Both are optional, so that files in the
+// standard consensus spec format leave the current network config untouched.
+type chainNetworkConfig struct {
+	BootstrapNodes          []string `yaml:"BOOTNODES"`
+	ContractDeploymentBlock *uint64  `yaml:"DEPOSIT_CONTRACT_BLOCK"`
+}
+
 // LoadChainConfigFile load, convert hex values into valid param yaml format,
-// unmarshal , and apply beacon chain config file.
+// unmarshal , and apply beacon chain config file. The bootnodes and deposit
+// contract deployment block of the file, if any, are applied to the network config.
 func LoadChainConfigFile(chainConfigFileName string) {
 	yamlFile, err := ioutil.ReadFile(chainConfigFileName)
 	if err != nil {
@@ -23,6 +32,10 @@ func LoadChainConfigFile(chainConfigFileName string) {
 		if strings.HasPrefix(line, "DEPOSIT_CONTRACT_ADDRESS") {
 			continue
 		}
+		// Bootnode records are base64 encoded, and may contain 0x by chance.
+		if strings.Contains(line, "enr:") {
+			continue
+		}
 		if !strings.HasPrefix(line, "#") && strings.Contains(line, "0x") {
 			parts := replaceHexStringWithYAMLFormat(line)
 			lines[i] = strings.Join(parts, "\n")
@@ -35,6 +48,22 @@ func LoadChainConfigFile(chainConfigFileName string) {
 	}
 	log.Debugf("Config file values: %+v", conf)
 	OverrideBeaconConfig(conf)
+
+	netConf := &chainNetworkConfig{}
+	if err := yaml.Unmarshal(yamlFile, netConf); err != nil {
+		log.WithError(err).Fatal("Failed to parse chain config yaml file.")
+	}
+	if len(netConf.BootstrapNodes) == 0 && netConf.ContractDeploymentBlock == nil {
+		return
+	}
+	networkCfg := BeaconNetworkConfig().Copy()
+	if len(netConf.BootstrapNodes) > 0 {
+		networkCfg.BootstrapNodes = netConf.BootstrapNodes
+	}
+	if netConf.ContractDeploymentBlock != nil {
+		networkCfg.ContractDeploymentBlock = *netConf.ContractDeploymentBlock
+	}
+	OverrideBeaconNetworkConfig(networkCfg)
 }
 
 func replaceHexStringWithYAMLFormat(line string) []string {
@@ -129,6 +129,48 @@ func TestLoadConfigFile_OverwriteCorrectly(t *testing.T) {
 	}
 }
 
+func TestLoadConfigFile_CustomNetwork(t *testing.T) {
+	defer OverrideBeaconConfig(MainnetConfig())
+	defer OverrideBeaconNetworkConfig(BeaconNetworkConfig().Copy())
+
+	// The second record contains 0x, which must not be converted.
+	enr1 := "enr:-Ku4QImhMc1z8yCiNJ1TyUxdcfNucje3BGwEHzodEZUan8PherEo4sF7pPHPSIB1NNuSg5fZy7qFsjmUKs2ea1Whi0EBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__________gmlkgnY0gmlwhBLf22SJc2VjcDI1NmsxoQOVphkDqal4QzPMksc5wnpuC3gvSC8AfbFOnZY_On34wIN1ZHCCIyg"
+	enr2 := "enr:-Ku4QP2xDnEtUXIjzJ_DhlCRN9SN99RYQPJL92TMlSv7U5C1YnYLjwOQHgZIUXw6c-BvRg2Yc2QsZxxoS_pPRVe0yK8Bh2F0dG5ldHOIAAAAAAAAAACEZXRoMpD1pf1CAAAAAP__0xAAAAAAAgmlkgnY0gmlwhBLf22SJc2VjcDI1NmsxoQMeFF5GrS7UZpAH2Ly84aLK-TyvH-dRo0JM1i8yygH50YN1ZHCCJxA"
+	content := strings.Join([]string{
+		"CONFIG_NAME: \"devnet\"",
+		"MIN_GENESIS_ACTIVE_VALIDATOR_COUNT: 64",
+		"GENESIS_FORK_VERSION: 0x00000064",
+		"DEPOSIT_CHAIN_ID: 1337",
+		"DEPOSIT_NETWORK_ID: 1337",
+		"DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242",
+		"DEPOSIT_CONTRACT_BLOCK: 12345",
+		"BOOTNODES:",
+		"  - \"" + enr1 + "\"",
+		"  - \"" + enr2 + "\"",
+	}, "\n")
+	file, err := ioutil.TempFile(t.TempDir(), "")
+	require.NoError(t, err)
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	LoadChainConfigFile(file.Name())
+	assert.Equal(t, "devnet", BeaconConfig().ConfigName)
+	assert.Equal(t, uint64(64), BeaconConfig().MinGenesisActiveValidatorCount)
+	assert.DeepEqual(t, []byte{0, 0, 0, 0x64}, BeaconConfig().GenesisForkVersion)
+	assert.Equal(t, uint64(1337), BeaconConfig().DepositChainID)
+	assert.Equal(t, "0x4242424242424242424242424242424242424242", BeaconConfig().DepositContractAddress)
+	assert.Equal(t, uint64(12345), BeaconNetworkConfig().ContractDeploymentBlock)
+	assert.DeepEqual(t, []string{enr1, enr2}, BeaconNetworkConfig().BootstrapNodes)
+
+	// A file without network values leaves the network config untouched.
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte("CONFIG_NAME: \"other\""), 0600))
+	LoadChainConfigFile(file.Name())
+	assert.Equal(t, "other", BeaconConfig().ConfigName)
+	assert.Equal(t, uint64(12345), BeaconNetworkConfig().ContractDeploymentBlock)
+	assert.DeepEqual(t, []string{enr1, enr2}, BeaconNetworkConfig().BootstrapNodes)
+}
+
 func Test_replaceHexStringWithYAMLFormat(t *testing.T) {
 
 	testLines := []struct {
@@ -0,0 +1,300 @@
+// Package ratelimit limits the rate and the concurrency of the requests served by the gRPC server
+// and the HTTP gateway of a node, so that a single client cannot starve the others. Each client,
+// identified by its remote host, is limited separately.
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	reasonRate        = "rate"
+	reasonConcurrency = "concurrency"
+	// defaultKey is the metrics label of the requests limited by the default limit.
+	defaultKey = "default"
+	// maxClients bounds the number of clients whose limits are tracked. The state of the least
+	// recently seen clients is dropped beyond it, which resets their limits.
+	maxClients = 1024
+	// ExemptionMetadataKey is the gRPC metadata key of the token with which a trusted in-process
+	// client, such as the gateway, is exempted from the limits of the gRPC server.
+	ExemptionMetadataKey = "prysm-rate-limit-exemption"
+)
+
+var rejectedRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "rpc_rate_limited_requests_total",
+		Help: "Count of requests rejected by the rate limiter, by server, limit and reason.",
+	},
+	[]string{"server", "limit", "reason"},
+)
+
+// Limit of a set of requests. A zero field disables the corresponding limit.
+type Limit struct {
+	// Rate is the sustained number of requests per second.
+	Rate float64
+	// Burst is the number of requests allowed at once. It defaults to the rate rounded up when the
+	// rate is limited.
+	Burst int
+	// MaxConcurrent is the number of requests served at the same time.
+	MaxConcurrent int
+}
+
+// ParseOverride parses a per-method limit given as prefix=rate,burst,max-concurrent, for example
+// /ethereum.eth.v1alpha1.BeaconChain/GetBeaconState=1,2,1.
+func ParseOverride(s string) (string, Limit, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", Limit{}, errors.Errorf("invalid rate limit override %q, expected prefix=rate,burst,max-concurrent", s)
+	}
+	values := strings.Split(parts[1], ",")
+	if len(values) != 3 {
+		return "", Limit{}, errors.Errorf("invalid rate limit override %q, expected prefix=rate,burst,max-concurrent", s)
+	}
+	r, err := strconv.ParseFloat(values[0], 64)
+	if err != nil || r < 0 {
+		return "", Limit{}, errors.Errorf("invalid rate in rate limit override %q", s)
+	}
+	burst, err := strconv.Atoi(values[1])
+	if err != nil || burst < 0 {
+		return "", Limit{}, errors.Errorf("invalid burst in rate limit override %q", s)
+	}
+	maxConcurrent, err := strconv.Atoi(values[2])
+	if err != nil || maxConcurrent < 0 {
+		return "", Limit{}, errors.Errorf("invalid max concurrent requests in rate limit override %q", s)
+	}
+	return parts[0], Limit{Rate: r, Burst: burst, MaxConcurrent: maxConcurrent}, nil
+}
+
+// Limiter limits the requests of a server. Requests are matched to the override with the longest
+// prefix of their gRPC method or HTTP path, and limited by the default limit otherwise. Each client
+// has its own token bucket and concurrency cap per override, shared by all the requests of the
+// client it matches, so that a busy client does not exhaust the limits of the others, such as a
+// validator client requesting its duties.
+type Limiter struct {
+	server    string
+	fallback  Limit
+	overrides []override
+	lock      sync.Mutex
+	clients   *lru.Cache
+	exemption string
+}
+
+// override is a limit of the requests whose method or path starts with prefix.
+type override struct {
+	prefix string
+	limit  Limit
+}
+
+// clientBuckets are the states of the limits of a client, with the buckets of the overrides in the
+// order of the overrides of the limiter.
+type clientBuckets struct {
+	fallback  *bucket
+	overrides []*bucket
+}
+
+// bucket is the state of a limit.
+type bucket struct {
+	prefix string
+	rate   *rate.Limiter
+	slots  chan struct{}
+}
+
+func newBucket(prefix string, l Limit) *bucket {
+	b := &bucket{prefix: prefix}
+	if l.Rate > 0 {
+		burst := l.Burst
+		if burst < 1 {
+			burst = int(math.Ceil(l.Rate))
+		}
+		b.rate = rate.NewLimiter(rate.Limit(l.Rate), burst)
+	}
+	if l.MaxConcurrent > 0 {
+		b.slots = make(chan struct{}, l.MaxConcurrent)
+	}
+	return b
+}
+
+// New returns a limiter of the requests of the named server, which is used as metrics label.
+func New(server string, fallback Limit, overrides map[string]Limit) *Limiter {
+	clients, err := lru.New(maxClients)
+	if err != nil {
+		panic(err) // Only errors on a non positive size.
+	}
+	l := &Limiter{server: server, fallback: fallback, clients: clients}
+	for prefix, limit := range overrides {
+		l.overrides = append(l.overrides, override{prefix: prefix, limit: limit})
+	}
+	// The longest prefix is matched first.
+	sort.Slice(l.overrides, func(i, j int) bool {
+		return len(l.overrides[i].prefix) > len(l.overrides[j].prefix)
+	})
+	return l
+}
+
+// NewExemptionToken returns a random token exempting the gRPC calls carrying it in their
+// ExemptionMetadataKey metadata from the limits, replacing any previous token. It is meant for a
+// client whose own clients are limited separately, such as the gateway, which would otherwise
+// share the limits of its host with the other gRPC clients of that host.
+func (l *Limiter) NewExemptionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate rate limit exemption token")
+	}
+	token := hex.EncodeToString(b)
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.exemption = token
+	return token, nil
+}
+
+// exempt returns true if the gRPC call carries the exemption token of the limiter.
+func (l *Limiter) exempt(ctx context.Context) bool {
+	l.lock.Lock()
+	token := l.exemption
+	l.lock.Unlock()
+	if token == "" {
+		return false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, v := range md.Get(ExemptionMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(v), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// bucket returns the bucket of the client limiting the requests to method.
+func (l *Limiter) bucket(client, method string) *bucket {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var buckets *clientBuckets
+	if v, ok := l.clients.Get(client); ok {
+		buckets = v.(*clientBuckets)
+	} else {
+		buckets = &clientBuckets{fallback: newBucket(defaultKey, l.fallback)}
+		for _, o := range l.overrides {
+			buckets.overrides = append(buckets.overrides, newBucket(o.prefix, o.limit))
+		}
+		l.clients.Add(client, buckets)
+	}
+	for i, o := range l.overrides {
+		if strings.HasPrefix(method, o.prefix) {
+			return buckets.overrides[i]
+		}
+	}
+	return buckets.fallback
+}
+
+// acquire admits a request of client to method, returning a function releasing its concurrency
+// slot, or the reason the request is rejected. Long lived requests, such as streams, only count
+// against the rate, as they would otherwise hold a slot for their whole lifetime.
+func (l *Limiter) acquire(client, method string, longLived bool) (func(), string) {
+	b := l.bucket(client, method)
+	if b.rate != nil && !b.rate.Allow() {
+		rejectedRequests.WithLabelValues(l.server, b.prefix, reasonRate).Inc()
+		return nil, reasonRate
+	}
+	if b.slots == nil || longLived {
+		return func() {}, ""
+	}
+	select {
+	case b.slots <- struct{}{}:
+		return func() { <-b.slots }, ""
+	default:
+		rejectedRequests.WithLabelValues(l.server, b.prefix, reasonConcurrency).Inc()
+		return nil, reasonConcurrency
+	}
+}
+
+// UnaryServerInterceptor rejects the unary calls above the limits with a ResourceExhausted error.
+// Clients are identified by the host of the peer. The calls carrying the exemption token are not
+// limited, as the HTTP clients of the gateway are limited by the gateway itself.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if l.exempt(ctx) {
+			return handler(ctx, req)
+		}
+		release, reason := l.acquire(peerHost(ctx), info.FullMethod, false /* longLived */)
+		if release == nil {
+			return nil, status.Errorf(codes.ResourceExhausted, "Too many requests to %s, %s limit exceeded", info.FullMethod, reason)
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streams opened above the rate limit with a
+// ResourceExhausted error. Streams do not count against the concurrency limit, and those carrying
+// the exemption token are not limited.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if l.exempt(ss.Context()) {
+			return handler(srv, ss)
+		}
+		release, reason := l.acquire(peerHost(ss.Context()), info.FullMethod, true /* longLived */)
+		if release == nil {
+			return status.Errorf(codes.ResourceExhausted, "Too many requests to %s, %s limit exceeded", info.FullMethod, reason)
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
+
+// HTTPHandler rejects the requests above the limits with a 429 status before passing them to next.
+// Clients are identified by the host of their remote address. Requests to a path for which isStream
+// returns true do not count against the concurrency limit.
+func (l *Limiter) HTTPHandler(next http.Handler, isStream func(path string) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release, reason := l.acquire(host(r.RemoteAddr), r.URL.Path, isStream != nil && isStream(r.URL.Path))
+		if release == nil {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Too many requests, "+reason+" limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// peerHost returns the host of the peer of a gRPC call, or an empty string if it is unknown.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return host(p.Addr.String())
+}
+
+// host returns the host of a network address, or the address itself if it has no port.
+func host(addr string) string {
+	h, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return h
+}
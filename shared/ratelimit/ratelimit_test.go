@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseOverride(t *testing.T) {
+	prefix, limit, err := ParseOverride("/ethereum.eth.v1alpha1.BeaconChain/GetBeaconState=0.5,2,1")
+	require.NoError(t, err)
+	assert.Equal(t, "/ethereum.eth.v1alpha1.BeaconChain/GetBeaconState", prefix)
+	assert.DeepEqual(t, Limit{Rate: 0.5, Burst: 2, MaxConcurrent: 1}, limit)
+
+	for _, s := range []string{"", "=1,2,3", "/path", "/path=1,2", "/path=a,2,3", "/path=1,-2,3", "/path=1,2,b"} {
+		_, _, err := ParseOverride(s)
+		assert.NotNil(t, err, "Parsed invalid override %q", s)
+	}
+}
+
+func TestLimiter_Rate(t *testing.T) {
+	l := New("test", Limit{Rate: 0.001, Burst: 2}, map[string]Limit{
+		"/service/":       {Rate: 0.001, Burst: 1},
+		"/service/Method": {},
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/other/Method"}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	intercept := l.UnaryServerInterceptor()
+	call := func(method string) error {
+		info.FullMethod = method
+		_, err := intercept(context.Background(), nil, info, handler)
+		return err
+	}
+
+	// The default limit allows a burst of 2 requests.
+	require.NoError(t, call("/other/Method"))
+	require.NoError(t, call("/other/Method"))
+	err := call("/other/Method")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	// An override is limited separately from the default limit.
+	require.NoError(t, call("/service/Other"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("/service/Other")))
+	// The longest prefix is matched, which has no limit.
+	for i := 0; i < 5; i++ {
+		require.NoError(t, call("/service/Method"))
+	}
+}
+
+func TestLimiter_PerClient(t *testing.T) {
+	l := New("test", Limit{Rate: 0.001, Burst: 1}, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/service/Method"}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	intercept := l.UnaryServerInterceptor()
+	call := func(ip string, port int) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: port}})
+		_, err := intercept(ctx, nil, info, handler)
+		return err
+	}
+
+	require.NoError(t, call("10.0.0.1", 1000))
+	// The connections of a host share its limits.
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("10.0.0.1", 1001)))
+	// Another host is limited separately.
+	require.NoError(t, call("10.0.0.2", 1000))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("10.0.0.2", 1000)))
+}
+
+func TestLimiter_Exemption(t *testing.T) {
+	l := New("test", Limit{Rate: 0.001, Burst: 1}, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/service/Method"}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	intercept := l.UnaryServerInterceptor()
+	call := func(token string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1000}})
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ExemptionMetadataKey, token))
+		}
+		_, err := intercept(ctx, nil, info, handler)
+		return err
+	}
+
+	// Without an exemption token, no call is exempted.
+	require.NoError(t, call(""))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("")))
+
+	token, err := l.NewExemptionToken()
+	require.NoError(t, err)
+	// The calls carrying the token are not limited, nor do they count against the limits of the
+	// other clients of their host.
+	for i := 0; i < 5; i++ {
+		require.NoError(t, call(token))
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("")))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("invalid")))
+
+	// Streams carrying the token are not limited either.
+	streamIntercept := l.StreamServerInterceptor()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ExemptionMetadataKey, token))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, streamIntercept(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/service/Stream"}, func(interface{}, grpc.ServerStream) error {
+			return nil
+		}))
+	}
+}
+
+// contextStream is a server stream with a given context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestLimiter_Concurrency(t *testing.T) {
+	l := New("test", Limit{MaxConcurrent: 1}, nil)
+	release := make(chan struct{})
+	entered := make(chan struct{})
+	srv := httptest.NewServer(l.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" || r.URL.Path == "/stream" {
+			entered <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}), func(path string) bool {
+		return path == "/stream"
+	}))
+	defer srv.Close()
+
+	get := func(path string) int {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+	done := make(chan int, 2)
+	go func() {
+		done <- get("/stream")
+	}()
+	<-entered
+	// A stream does not hold a slot.
+	go func() {
+		done <- get("/slow")
+	}()
+	<-entered
+	assert.Equal(t, http.StatusTooManyRequests, get("/fast"))
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, get("/fast"))
+}
@@ -0,0 +1,222 @@
+// Package tlsutil builds the TLS credentials of gRPC servers and clients from certificate files.
+// Certificates are reloaded when their files are modified, so that they are rotated without a
+// restart.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = logrus.WithField("prefix", "tls")
+
+// ServerCredentials returns the credentials of a gRPC server presenting the certificate at
+// certPath. If clientCAPath is set, clients must present a certificate signed by the certificate
+// authority at clientCAPath.
+func ServerCredentials(certPath, keyPath, clientCAPath string) (credentials.TransportCredentials, error) {
+	kp, err := loadKeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return kp.certificate(), nil
+		},
+	}
+	if clientCAPath != "" {
+		cas, err := loadCertPool(clientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		// Clients are verified by VerifyPeerCertificate rather than with ClientCAs, so that the
+		// certificate authority is reloaded.
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyClient(rawCerts, cas.pool())
+		}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// ClientCredentials returns the credentials of a gRPC client verifying the server with the
+// certificate authority at caCertPath, or the system certificate authorities if it is empty. If
+// certPath and keyPath are set, the client presents that certificate to the server.
+func ClientCredentials(caCertPath, certPath, keyPath string) (credentials.TransportCredentials, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caCertPath != "" {
+		cas, err := loadCertPool(caCertPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = cas.pool()
+	}
+	if certPath != "" || keyPath != "" {
+		if certPath == "" || keyPath == "" {
+			return nil, errors.New("both a client certificate and a client key are required")
+		}
+		kp, err := loadKeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return kp.certificate(), nil
+		}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+func verifyClient(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no client certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return errors.Wrap(err, "could not parse client certificate")
+		}
+		certs[i] = cert
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return errors.Wrap(err, "could not verify client certificate")
+	}
+	return nil
+}
+
+// keyPair is a certificate and key loaded from files, which is reloaded when the files are
+// modified.
+type keyPair struct {
+	certPath    string
+	keyPath     string
+	lock        sync.RWMutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+func loadKeyPair(certPath, keyPath string) (*keyPair, error) {
+	kp := &keyPair{certPath: certPath, keyPath: keyPath}
+	if err := kp.reload(); err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
+
+// certificate returns the key pair, reloading it first if its files were modified. A key pair
+// which fails to reload, for example when only one of its files was written yet, is retried on the
+// next call and the previous key pair is returned meanwhile.
+func (kp *keyPair) certificate() *tls.Certificate {
+	certInfo, certErr := os.Stat(kp.certPath)
+	keyInfo, keyErr := os.Stat(kp.keyPath)
+	kp.lock.RLock()
+	modified := certErr == nil && keyErr == nil &&
+		(!certInfo.ModTime().Equal(kp.certModTime) || !keyInfo.ModTime().Equal(kp.keyModTime))
+	kp.lock.RUnlock()
+	if modified {
+		if err := kp.reload(); err != nil {
+			log.WithError(err).Error("Could not reload TLS certificate, using the previous one")
+		} else {
+			log.WithField("cert", kp.certPath).Info("Reloaded TLS certificate")
+		}
+	}
+	kp.lock.RLock()
+	defer kp.lock.RUnlock()
+	return kp.cert
+}
+
+func (kp *keyPair) reload() error {
+	// The modification times are read first, so that a write during the reload is picked up by
+	// the next one.
+	certInfo, err := os.Stat(kp.certPath)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := os.Stat(kp.keyPath)
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(kp.certPath, kp.keyPath)
+	if err != nil {
+		return errors.Wrap(err, "could not load TLS certificate and key")
+	}
+	kp.lock.Lock()
+	defer kp.lock.Unlock()
+	kp.cert = &cert
+	kp.certModTime = certInfo.ModTime()
+	kp.keyModTime = keyInfo.ModTime()
+	return nil
+}
+
+// certPool is a set of certificate authorities loaded from a file, which is reloaded when the file
+// is modified.
+type certPool struct {
+	path    string
+	lock    sync.RWMutex
+	certs   *x509.CertPool
+	modTime time.Time
+}
+
+func loadCertPool(path string) (*certPool, error) {
+	cp := &certPool{path: path}
+	if err := cp.reload(); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// pool returns the certificate authorities, reloading them first if their file was modified.
+func (cp *certPool) pool() *x509.CertPool {
+	info, err := os.Stat(cp.path)
+	cp.lock.RLock()
+	modified := err == nil && !info.ModTime().Equal(cp.modTime)
+	cp.lock.RUnlock()
+	if modified {
+		if err := cp.reload(); err != nil {
+			log.WithError(err).Error("Could not reload TLS certificate authority, using the previous one")
+		} else {
+			log.WithField("ca", cp.path).Info("Reloaded TLS certificate authority")
+		}
+	}
+	cp.lock.RLock()
+	defer cp.lock.RUnlock()
+	return cp.certs
+}
+
+func (cp *certPool) reload() error {
+	info, err := os.Stat(cp.path)
+	if err != nil {
+		return err
+	}
+	pem, err := ioutil.ReadFile(cp.path)
+	if err != nil {
+		return errors.Wrap(err, "could not read TLS certificate authority")
+	}
+	certs := x509.NewCertPool()
+	if !certs.AppendCertsFromPEM(pem) {
+		return errors.Errorf("no certificate found in %s", cp.path)
+	}
+	cp.lock.Lock()
+	defer cp.lock.Unlock()
+	cp.certs = certs
+	cp.modTime = info.ModTime()
+	return nil
+}
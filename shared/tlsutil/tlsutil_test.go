@@ -0,0 +1,183 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, name string, usage x509.ExtKeyUsage, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// write writes the certificate and its key to files in dir, with a modification time in the
+// future so that rewriting a file is detected within the resolution of the file system.
+func (c *testCert) write(t *testing.T, dir, name string, modTime time.Time) (string, string) {
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(certPath, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyPath, modTime, modTime))
+	return certPath, keyPath
+}
+
+// handshake runs a TLS handshake between the credentials and returns the certificate presented by
+// the server, or the error of the server.
+func handshake(t *testing.T, server, client credentials.TransportCredentials) (*x509.Certificate, error) {
+	// A TCP connection is used rather than net.Pipe, as the server writes session tickets the
+	// client does not read.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, listener.Close())
+	}()
+	// The connections are already closed by a failed handshake.
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer func() {
+		_ = clientConn.Close()
+	}()
+	serverConn, err := listener.Accept()
+	require.NoError(t, err)
+	defer func() {
+		_ = serverConn.Close()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	type result struct {
+		info credentials.AuthInfo
+		err  error
+	}
+	clientResult := make(chan result, 1)
+	go func() {
+		_, info, err := client.ClientHandshake(ctx, "localhost", clientConn)
+		clientResult <- result{info: info, err: err}
+	}()
+	_, _, serverErr := server.ServerHandshake(serverConn)
+	if serverErr != nil {
+		return nil, serverErr
+	}
+	res := <-clientResult
+	require.NoError(t, res.err)
+	return res.info.(credentials.TLSInfo).State.PeerCertificates[0], nil
+}
+
+func TestCredentials_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	ca := newTestCert(t, "ca", x509.ExtKeyUsageAny, nil)
+	otherCA := newTestCert(t, "other-ca", x509.ExtKeyUsageAny, nil)
+	server := newTestCert(t, "server", x509.ExtKeyUsageServerAuth, ca)
+	validClient := newTestCert(t, "client", x509.ExtKeyUsageClientAuth, ca)
+	rogueClient := newTestCert(t, "rogue", x509.ExtKeyUsageClientAuth, otherCA)
+
+	caPath, _ := ca.write(t, dir, "ca", now)
+	serverCert, serverKey := server.write(t, dir, "server", now)
+	serverCreds, err := ServerCredentials(serverCert, serverKey, caPath)
+	require.NoError(t, err)
+
+	clientCreds := func(cert *testCert, name string) credentials.TransportCredentials {
+		var certPath, keyPath string
+		if cert != nil {
+			certPath, keyPath = cert.write(t, dir, name, now)
+		}
+		creds, err := ClientCredentials(caPath, certPath, keyPath)
+		require.NoError(t, err)
+		return creds
+	}
+	validCreds := clientCreds(validClient, "client")
+	rogueCreds := clientCreds(rogueClient, "rogue")
+
+	presented, err := handshake(t, serverCreds, validCreds)
+	require.NoError(t, err)
+	assert.Equal(t, "server", presented.Subject.CommonName)
+	_, err = handshake(t, serverCreds, rogueCreds)
+	assert.ErrorContains(t, "could not verify client certificate", err)
+	_, err = handshake(t, serverCreds, clientCreds(nil, ""))
+	assert.NotNil(t, err, "Accepted a client without a certificate")
+	// The server's own keypair is not a client identity.
+	selfCreds, err := ClientCredentials(caPath, serverCert, serverKey)
+	require.NoError(t, err)
+	_, err = handshake(t, serverCreds, selfCreds)
+	assert.ErrorContains(t, "could not verify client certificate", err)
+
+	// Rotating the client certificate authority and the server certificate takes effect without
+	// recreating the credentials.
+	otherCA.write(t, dir, "ca", now.Add(time.Minute))
+	newServer := newTestCert(t, "new-server", x509.ExtKeyUsageServerAuth, ca)
+	newServer.write(t, dir, "server", now.Add(time.Minute))
+	_, err = handshake(t, serverCreds, validCreds)
+	assert.ErrorContains(t, "could not verify client certificate", err)
+	presented, err = handshake(t, serverCreds, rogueCreds)
+	require.NoError(t, err)
+	assert.Equal(t, "new-server", presented.Subject.CommonName)
+}
+
+func TestKeyPair_KeepsCertificateOnFailedReload(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	cert := newTestCert(t, "server", x509.ExtKeyUsageServerAuth, nil)
+	certPath, keyPath := cert.write(t, dir, "server", now)
+	kp, err := loadKeyPair(certPath, keyPath)
+	require.NoError(t, err)
+
+	// Only the certificate of the new key pair was written yet.
+	newCert := newTestCert(t, "new-server", x509.ExtKeyUsageServerAuth, nil)
+	require.NoError(t, ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newCert.der}), 0600))
+	require.NoError(t, os.Chtimes(certPath, now.Add(time.Minute), now.Add(time.Minute)))
+	assert.DeepEqual(t, cert.der, kp.certificate().Certificate[0])
+
+	newCert.write(t, dir, "server", now.Add(2*time.Minute))
+	assert.DeepEqual(t, newCert.der, kp.certificate().Certificate[0])
+
+	_, err = ClientCredentials("", certPath, "")
+	assert.ErrorContains(t, "both a client certificate and a client key are required", err)
+}
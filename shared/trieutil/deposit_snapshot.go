@@ -0,0 +1,177 @@
+package trieutil
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+// snapshotHeaderLength is the length of the encoded deposit count, execution block height
+// and deposit root of a snapshot, which precede its finalized hashes.
+const snapshotHeaderLength = 48
+
+// prunedNode stands in for the leaves and nodes dropped from a trie rebuilt from a snapshot. It
+// differs from the zero leaf, so that such a trie is never mistaken for an empty one.
+var prunedNode = hashutil.Hash([]byte("pruned deposit tree node"))
+
+// DepositTreeSnapshot is the finalized part of a deposit tree, as described in EIP-4881. Instead of
+// all the finalized leaves, it keeps the roots of the largest complete subtrees covering them, which
+// is enough to compute the root of the tree and the proofs of the deposits that come after them.
+type DepositTreeSnapshot struct {
+	// Finalized holds the roots of the complete subtrees covering the finalized deposits,
+	// ordered from left to right.
+	Finalized            [][32]byte
+	DepositRoot          [32]byte
+	DepositCount         uint64
+	ExecutionBlockHeight uint64
+}
+
+// Snapshot returns the snapshot of the first depositCount leaves of the trie, which were all
+// included by the eth1 block at the given height.
+func (m *SparseMerkleTrie) Snapshot(depositCount, executionBlockHeight uint64) (*DepositTreeSnapshot, error) {
+	if depositCount > uint64(len(m.originalItems)) {
+		return nil, fmt.Errorf("cannot snapshot %d deposits of a trie with %d items", depositCount, len(m.originalItems))
+	}
+	finalized := make([][32]byte, 0, bits.OnesCount64(depositCount))
+	for level := int(m.depth); level >= 0; level-- {
+		if depositCount&(1<<uint(level)) == 0 {
+			continue
+		}
+		index := depositCount>>uint(level) - 1
+		if level >= len(m.branches) || index >= uint64(len(m.branches[level])) {
+			return nil, fmt.Errorf("trie is missing node %d at level %d", index, level)
+		}
+		finalized = append(finalized, bytesutil.ToBytes32(m.branches[level][index]))
+	}
+	s := &DepositTreeSnapshot{
+		Finalized:            finalized,
+		DepositCount:         depositCount,
+		ExecutionBlockHeight: executionBlockHeight,
+	}
+	s.DepositRoot = s.CalculateRoot(uint64(m.depth))
+	return s, nil
+}
+
+// CalculateRoot computes the deposit root of the snapshot, mixed in with its deposit count, for a
+// tree of the given depth.
+func (s *DepositTreeSnapshot) CalculateRoot(depth uint64) [32]byte {
+	size := s.DepositCount
+	index := len(s.Finalized)
+	root := ZeroHashes[0]
+	for level := uint64(0); level < depth; level++ {
+		if size&1 == 1 {
+			index--
+			root = hashutil.Hash(append(s.Finalized[index][:], root[:]...))
+		} else {
+			root = hashutil.Hash(append(root[:], ZeroHashes[level][:]...))
+		}
+		size >>= 1
+	}
+	enc := [32]byte{}
+	binary.LittleEndian.PutUint64(enc[:], s.DepositCount)
+	return hashutil.Hash(append(root[:], enc[:]...))
+}
+
+// Marshal encodes the snapshot as its deposit count, execution block height, deposit root and
+// finalized hashes.
+func (s *DepositTreeSnapshot) Marshal() []byte {
+	enc := make([]byte, snapshotHeaderLength, snapshotHeaderLength+32*len(s.Finalized))
+	binary.LittleEndian.PutUint64(enc[0:8], s.DepositCount)
+	binary.LittleEndian.PutUint64(enc[8:16], s.ExecutionBlockHeight)
+	copy(enc[16:48], s.DepositRoot[:])
+	for _, h := range s.Finalized {
+		enc = append(enc, h[:]...)
+	}
+	return enc
+}
+
+// UnmarshalDepositTreeSnapshot decodes a snapshot encoded with Marshal, and checks that its
+// finalized hashes add up to its deposit root in a tree of the given depth.
+func UnmarshalDepositTreeSnapshot(enc []byte, depth uint64) (*DepositTreeSnapshot, error) {
+	if len(enc) < snapshotHeaderLength || (len(enc)-snapshotHeaderLength)%32 != 0 {
+		return nil, fmt.Errorf("invalid deposit snapshot length %d", len(enc))
+	}
+	s := &DepositTreeSnapshot{
+		DepositCount:         binary.LittleEndian.Uint64(enc[0:8]),
+		ExecutionBlockHeight: binary.LittleEndian.Uint64(enc[8:16]),
+		DepositRoot:          bytesutil.ToBytes32(enc[16:48]),
+	}
+	for i := snapshotHeaderLength; i < len(enc); i += 32 {
+		s.Finalized = append(s.Finalized, bytesutil.ToBytes32(enc[i:i+32]))
+	}
+	if len(s.Finalized) != bits.OnesCount64(s.DepositCount) {
+		return nil, fmt.Errorf("deposit snapshot has %d finalized hashes for %d deposits", len(s.Finalized), s.DepositCount)
+	}
+	if s.CalculateRoot(depth) != s.DepositRoot {
+		return nil, errors.New("deposit snapshot finalized hashes do not match its deposit root")
+	}
+	return s, nil
+}
+
+// TrieFromSnapshot rebuilds a trie from a snapshot and the leaves of the deposits that came after it.
+// The finalized leaves are pruned: they, and the nodes built only from them, are replaced by a
+// placeholder, except for the roots of the snapshot. The resulting trie has the root of the full
+// deposit tree, and can insert and prove the deposits after the snapshot, but cannot prove the
+// finalized deposits.
+func TrieFromSnapshot(s *DepositTreeSnapshot, leaves [][]byte, depth uint64) (*SparseMerkleTrie, error) {
+	if len(s.Finalized) != bits.OnesCount64(s.DepositCount) {
+		return nil, fmt.Errorf("deposit snapshot has %d finalized hashes for %d deposits", len(s.Finalized), s.DepositCount)
+	}
+	if depth < 64 && s.DepositCount>>depth != 0 {
+		return nil, fmt.Errorf("deposit snapshot has %d deposits, more than a trie of depth %d can hold", s.DepositCount, depth)
+	}
+	pruned := prunedNode[:]
+	items := make([][]byte, 0, s.DepositCount+uint64(len(leaves)))
+	for i := uint64(0); i < s.DepositCount; i++ {
+		items = append(items, pruned)
+	}
+	for _, leaf := range leaves {
+		item := bytesutil.ToBytes32(leaf)
+		items = append(items, item[:])
+	}
+	if len(items) == 0 {
+		return NewTrie(depth)
+	}
+
+	layers := make([][][]byte, depth+1)
+	layers[0] = make([][]byte, len(items))
+	copy(layers[0], items)
+	// The roots of the snapshot are ordered from the highest level to the lowest one.
+	finalized := len(s.Finalized)
+	for level := uint64(0); level <= depth; level++ {
+		if level > 0 {
+			below := layers[level-1]
+			if len(below)%2 == 1 {
+				below = append(below, ZeroHashes[level-1][:])
+				layers[level-1] = below
+			}
+			layer := make([][]byte, len(below)/2)
+			// Nodes built only from pruned leaves are never read when inserting or proving the
+			// leaves after the snapshot, so they are not hashed.
+			prunedNodes := s.DepositCount >> level
+			for j := range layer {
+				if uint64(j) < prunedNodes {
+					layer[j] = pruned
+					continue
+				}
+				h := hashutil.Hash(append(append([]byte{}, below[2*j]...), below[2*j+1]...))
+				layer[j] = h[:]
+			}
+			layers[level] = layer
+		}
+		if s.DepositCount&(1<<level) != 0 {
+			finalized--
+			root := s.Finalized[finalized]
+			layers[level][s.DepositCount>>level-1] = root[:]
+		}
+	}
+	return &SparseMerkleTrie{
+		branches:      layers,
+		originalItems: items,
+		depth:         uint(depth),
+	}, nil
+}
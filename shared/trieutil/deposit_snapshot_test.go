@@ -0,0 +1,97 @@
+package trieutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func snapshotTestItems(n int) [][]byte {
+	items := make([][]byte, n)
+	for i := range items {
+		h := hashutil.Hash([]byte(fmt.Sprintf("deposit %d", i)))
+		items[i] = h[:]
+	}
+	return items
+}
+
+func TestSparseMerkleTrie_Snapshot(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := snapshotTestItems(21)
+	for count := 1; count <= len(items); count++ {
+		finalizedTrie, err := GenerateTrieFromItems(items[:count], depth)
+		require.NoError(t, err)
+		fullTrie, err := GenerateTrieFromItems(items, depth)
+		require.NoError(t, err)
+
+		s, err := fullTrie.Snapshot(uint64(count), 100)
+		require.NoError(t, err)
+		assert.Equal(t, finalizedTrie.HashTreeRoot(), s.DepositRoot, "Wrong deposit root for %d deposits", count)
+		assert.Equal(t, uint64(100), s.ExecutionBlockHeight)
+
+		decoded, err := UnmarshalDepositTreeSnapshot(s.Marshal(), depth)
+		require.NoError(t, err)
+		assert.DeepEqual(t, s, decoded)
+	}
+
+	trie, err := GenerateTrieFromItems(items[:3], depth)
+	require.NoError(t, err)
+	_, err = trie.Snapshot(4, 0)
+	assert.ErrorContains(t, "cannot snapshot 4 deposits", err)
+}
+
+func TestUnmarshalDepositTreeSnapshot_Invalid(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	trie, err := GenerateTrieFromItems(snapshotTestItems(5), depth)
+	require.NoError(t, err)
+	s, err := trie.Snapshot(5, 0)
+	require.NoError(t, err)
+	enc := s.Marshal()
+
+	_, err = UnmarshalDepositTreeSnapshot(enc[:len(enc)-1], depth)
+	assert.ErrorContains(t, "invalid deposit snapshot length", err)
+	_, err = UnmarshalDepositTreeSnapshot(enc[:len(enc)-32], depth)
+	assert.ErrorContains(t, "has 1 finalized hashes for 5 deposits", err)
+	enc[len(enc)-1] ^= 1
+	_, err = UnmarshalDepositTreeSnapshot(enc, depth)
+	assert.ErrorContains(t, "do not match its deposit root", err)
+}
+
+func TestTrieFromSnapshot(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := snapshotTestItems(21)
+	fullTrie, err := GenerateTrieFromItems(items, depth)
+	require.NoError(t, err)
+	for count := 1; count < len(items); count++ {
+		s, err := fullTrie.Snapshot(uint64(count), 0)
+		require.NoError(t, err)
+
+		pruned, err := TrieFromSnapshot(s, items[count:], depth)
+		require.NoError(t, err)
+		assert.Equal(t, fullTrie.Root(), pruned.Root(), "Wrong root for snapshot of %d deposits", count)
+		assert.Equal(t, len(items), len(pruned.Items()))
+		for i := count; i < len(items); i++ {
+			want, err := fullTrie.MerkleProof(i)
+			require.NoError(t, err)
+			proof, err := pruned.MerkleProof(i)
+			require.NoError(t, err)
+			assert.DeepEqual(t, want, proof, "Wrong proof of deposit %d for snapshot of %d deposits", i, count)
+		}
+
+		// Deposits inserted after the snapshot produce the same trie as without pruning.
+		pruned, err = TrieFromSnapshot(s, nil, depth)
+		require.NoError(t, err)
+		assert.Equal(t, s.DepositRoot, pruned.HashTreeRoot())
+		for i := count; i < len(items); i++ {
+			pruned.Insert(items[i], i)
+		}
+		assert.Equal(t, fullTrie.Root(), pruned.Root(), "Wrong root after inserting into snapshot of %d deposits", count)
+		resnapshot, err := pruned.Snapshot(uint64(len(items)), 0)
+		require.NoError(t, err)
+		assert.Equal(t, fullTrie.HashTreeRoot(), resnapshot.DepositRoot)
+	}
+}
@@ -27,5 +27,19 @@ var DatabaseCommands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name: "prune",
+			Description: `removes the block headers, attestations and span maps older than the weak ` +
+				`subjectivity period before the last processed chain head from the database`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := prune(cliCtx); err != nil {
+					log.Fatalf("Could not prune database: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
@@ -76,6 +76,10 @@ type WriteAccessDatabase interface {
 
 	// MinMaxSpan related methods.
 	SaveEpochSpans(ctx context.Context, epoch types.Epoch, spans *slashertypes.EpochStore, toCache bool) error
+	PruneEpochSpans(ctx context.Context, currentEpoch, pruningEpochAge types.Epoch) error
+
+	// Pruning related methods.
+	Prune(ctx context.Context, currentEpoch, pruningEpochAge types.Epoch) error
 
 	// ProposerSlashing related methods.
 	DeleteProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error
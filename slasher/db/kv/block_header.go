@@ -9,7 +9,6 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
@@ -121,14 +120,8 @@ func (s *Store) PruneBlockHistory(ctx context.Context, currentEpoch, pruningEpoc
 		return nil
 	}
 	pruneTillSlot := uint64(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(pruneTill)))
-	return s.update(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(historicBlockHeadersBucket)
-		c := tx.Bucket(historicBlockHeadersBucket).Cursor()
-		for k, _ := c.First(); k != nil && bytesutil.FromBytes8(k[:8]) <= pruneTillSlot; k, _ = c.Next() {
-			if err := bucket.Delete(k); err != nil {
-				return errors.Wrap(err, "failed to delete the block header from historical bucket")
-			}
-		}
-		return nil
-	})
+	if err := s.pruneBucket(historicBlockHeadersBucket, pruneTillSlot); err != nil {
+		return errors.Wrap(err, "failed to delete the block headers from historical bucket")
+	}
+	return nil
 }
@@ -171,17 +171,10 @@ func (s *Store) PruneAttHistory(ctx context.Context, currentEpoch, pruningEpochA
 		return nil
 	}
 
-	return s.update(func(tx *bolt.Tx) error {
-		attBucket := tx.Bucket(historicIndexedAttestationsBucket)
-		c := tx.Bucket(historicIndexedAttestationsBucket).Cursor()
-		max := bytesutil.Bytes8(uint64(pruneFromEpoch))
-		for k, _ := c.First(); k != nil && bytes.Compare(k[:8], max) <= 0; k, _ = c.Next() {
-			if err := attBucket.Delete(k); err != nil {
-				return errors.Wrap(err, "failed to delete indexed attestation from historical bucket")
-			}
-		}
-		return nil
-	})
+	if err := s.pruneBucket(historicIndexedAttestationsBucket, uint64(pruneFromEpoch)); err != nil {
+		return errors.Wrap(err, "failed to delete indexed attestations from historical bucket")
+	}
+	return nil
 }
 
 // LatestIndexedAttestationsTargetEpoch returns latest target epoch in db
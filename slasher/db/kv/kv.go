@@ -141,6 +141,9 @@ func NewKVStore(dirPath string, cfg *Config) (*Store, error) {
 	}); err != nil {
 		return nil, err
 	}
+	if err := kv.updateSizeMetrics(); err != nil {
+		return nil, err
+	}
 
 	return kv, err
 }
@@ -0,0 +1,122 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+var (
+	slasherDBSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_db_size_bytes",
+		Help: "The size of the slasher database in bytes",
+	})
+	slasherDBRecords = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "slasher_db_records",
+		Help: "The number of records in the slasher database buckets subject to pruning",
+	}, []string{"bucket"})
+	slasherDBPrunedRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_db_pruned_records_total",
+		Help: "The number of records pruned from the slasher database buckets",
+	}, []string{"bucket"})
+)
+
+// prunedBuckets are the buckets whose records are keyed by the little endian encoded slot or
+// epoch they were made in, and are pruned once older than the weak subjectivity period.
+var prunedBuckets = [][]byte{
+	historicBlockHeadersBucket,
+	historicIndexedAttestationsBucket,
+	validatorsMinMaxSpanBucketNew,
+}
+
+// Prune removes the block headers, indexed attestations and span maps older than the pruning
+// epoch age from the DB. Nothing needed to detect slashable offences made in the pruning epoch
+// age before the current epoch is removed.
+func (s *Store) Prune(ctx context.Context, currentEpoch, pruningEpochAge types.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.Prune")
+	defer span.End()
+	if err := s.PruneBlockHistory(ctx, currentEpoch, pruningEpochAge); err != nil {
+		return errors.Wrap(err, "failed to prune block headers")
+	}
+	if err := s.PruneAttHistory(ctx, currentEpoch, pruningEpochAge); err != nil {
+		return errors.Wrap(err, "failed to prune indexed attestations")
+	}
+	if err := s.PruneEpochSpans(ctx, currentEpoch, pruningEpochAge); err != nil {
+		return errors.Wrap(err, "failed to prune span maps")
+	}
+	return nil
+}
+
+// PruneEpochSpans removes the span maps of the epochs older than the pruning epoch age from the DB.
+func (s *Store) PruneEpochSpans(ctx context.Context, currentEpoch, pruningEpochAge types.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.PruneEpochSpans")
+	defer span.End()
+	pruneTill := int64(currentEpoch) - int64(pruningEpochAge)
+	if pruneTill <= 0 {
+		return nil
+	}
+	return s.pruneBucket(validatorsMinMaxSpanBucketNew, uint64(pruneTill))
+}
+
+// pruneBucket deletes the records of a bucket whose key starts with a slot or epoch lower than or
+// equal to the given one. As these are little endian encoded, keys are not ordered by slot or
+// epoch, and the whole bucket is scanned.
+func (s *Store) pruneBucket(bucketName []byte, pruneTill uint64) error {
+	var pruned int
+	err := s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		if bucket == nil {
+			return nil
+		}
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			if len(k) >= 8 && bytesutil.FromBytes8(k[:8]) <= pruneTill {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		pruned = len(keys)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		slasherDBPrunedRecords.WithLabelValues(string(bucketName)).Add(float64(pruned))
+		log.WithFields(logrus.Fields{
+			"bucket":    string(bucketName),
+			"numPruned": pruned,
+			"pruneTill": pruneTill,
+		}).Debug("Pruned slasher database records")
+	}
+	return s.updateSizeMetrics()
+}
+
+// updateSizeMetrics reports the size of the DB and the number of records of the pruned buckets.
+func (s *Store) updateSizeMetrics() error {
+	return s.view(func(tx *bolt.Tx) error {
+		slasherDBSize.Set(float64(tx.Size()))
+		for _, bucketName := range prunedBuckets {
+			bucket := tx.Bucket(bucketName)
+			if bucket == nil {
+				continue
+			}
+			slasherDBRecords.WithLabelValues(string(bucketName)).Set(float64(bucket.Stats().KeyN))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,69 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	dbtypes "github.com/prysmaticlabs/prysm/slasher/db/types"
+	slashertypes "github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+)
+
+func TestStore_Prune(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	// Avoid the automatic pruning when saving spans.
+	prevHighestObservedEpoch := highestObservedEpoch
+	highestObservedEpoch = params.BeaconConfig().FarFutureEpoch
+	defer func() {
+		highestObservedEpoch = prevHighestObservedEpoch
+	}()
+
+	// Little endian encoded keys are not ordered by epoch, 256 coming first and 255 last.
+	epochs := []types.Epoch{1, 2, 255, 256, 257, 300}
+	atts := make([]*ethpb.IndexedAttestation, len(epochs))
+	headers := make([]*ethpb.SignedBeaconBlockHeader, len(epochs))
+	epochStore, err := slashertypes.EpochStoreFromMap(map[uint64]slashertypes.Span{
+		1: {MinSpan: 5, MaxSpan: 69, SigBytes: [2]byte{40, 219}, HasAttested: true},
+	})
+	require.NoError(t, err)
+	for i, epoch := range epochs {
+		sig := bytesutil.PadTo([]byte(fmt.Sprintf("signature %d", epoch)), 96)
+		atts[i] = &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1},
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Epoch: epoch - 1, Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: epoch, Root: make([]byte, 32)},
+			},
+			Signature: sig,
+		}
+		require.NoError(t, db.SaveIndexedAttestation(ctx, atts[i]))
+		headers[i] = &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:          params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch)),
+				ProposerIndex: 1,
+			},
+			Signature: sig,
+		}
+		require.NoError(t, db.SaveBlockHeader(ctx, headers[i]))
+		require.NoError(t, db.SaveEpochSpans(ctx, epoch, epochStore, dbtypes.UseDB))
+	}
+
+	require.NoError(t, db.Prune(ctx, 300, 44))
+	for i, epoch := range epochs {
+		kept := epoch > 256
+		exists, err := db.HasIndexedAttestation(ctx, atts[i])
+		require.NoError(t, err)
+		require.Equal(t, kept, exists, "Wrong indexed attestation pruning at epoch %d", epoch)
+		require.Equal(t, kept, db.HasBlockHeader(ctx, headers[i].Header.Slot, 1), "Wrong block header pruning at epoch %d", epoch)
+		spans, err := db.EpochSpans(ctx, epoch, dbtypes.UseDB)
+		require.NoError(t, err)
+		require.Equal(t, kept, len(spans.Bytes()) > 0, "Wrong span map pruning at epoch %d", epoch)
+	}
+}
@@ -141,11 +141,14 @@ func (s *Store) setObservedEpochs(ctx context.Context, epoch types.Epoch) error
 	if epoch > highestObservedEpoch {
 		slasherHighestObservedEpoch.Set(float64(epoch))
 		highestObservedEpoch = epoch
-		// Prune block header history every PruneSlasherStoragePeriod epoch.
+		// Prune attestation history and span maps every PruneSlasherStoragePeriod epoch.
 		if highestObservedEpoch%params.BeaconConfig().PruneSlasherStoragePeriod == 0 {
 			if err = s.PruneAttHistory(ctx, epoch, params.BeaconConfig().WeakSubjectivityPeriod); err != nil {
 				return errors.Wrap(err, "failed to prune indexed attestations store")
 			}
+			if err = s.PruneEpochSpans(ctx, epoch, params.BeaconConfig().WeakSubjectivityPeriod); err != nil {
+				return errors.Wrap(err, "failed to prune span maps store")
+			}
 		}
 	}
 	if epoch < lowestObservedEpoch {
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// prune removes the records older than the weak subjectivity period before the chain head
+// last processed by the slasher from its database.
+func prune(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.SlasherDbDirName)
+	d, err := NewDB(dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open slasher database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+	head, err := d.ChainHead(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get chain head from slasher database")
+	}
+	if head == nil {
+		return errors.New("no chain head in slasher database, nothing to prune")
+	}
+	if err := d.Prune(ctx, head.HeadEpoch, params.BeaconConfig().WeakSubjectivityPeriod); err != nil {
+		return err
+	}
+	size, err := d.Size()
+	if err != nil {
+		return errors.Wrap(err, "could not get slasher database size")
+	}
+	// Bolt reuses the pages freed by pruning instead of shrinking the database file.
+	log.WithFields(logrus.Fields{
+		"headEpoch": head.HeadEpoch,
+		"sizeBytes": size,
+	}).Info("Pruning completed successfully")
+	return nil
+}
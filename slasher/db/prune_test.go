@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"path"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPrune(t *testing.T) {
+	logHook := logTest.NewGlobal()
+	ctx := context.Background()
+	dataDir := t.TempDir()
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, "", "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, dataDir))
+	cliCtx := cli.NewContext(&app, set, nil)
+	cliCtx.Context = ctx
+
+	d, err := kv.NewKVStore(path.Join(dataDir, kv.SlasherDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	require.NoError(t, d.Close())
+	assert.ErrorContains(t, "no chain head in slasher database", prune(cliCtx))
+
+	headEpoch := params.BeaconConfig().WeakSubjectivityPeriod + 10
+	oldHeader := &ethpb.SignedBeaconBlockHeader{
+		Header:    &ethpb.BeaconBlockHeader{Slot: params.BeaconConfig().SlotsPerEpoch, ProposerIndex: 1},
+		Signature: bytesutil.PadTo([]byte("old"), 96),
+	}
+	newHeader := &ethpb.SignedBeaconBlockHeader{
+		Header:    &ethpb.BeaconBlockHeader{Slot: params.BeaconConfig().SlotsPerEpoch.Mul(uint64(headEpoch)), ProposerIndex: 1},
+		Signature: bytesutil.PadTo([]byte("new"), 96),
+	}
+	d, err = kv.NewKVStore(path.Join(dataDir, kv.SlasherDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	require.NoError(t, d.SaveBlockHeader(ctx, oldHeader))
+	require.NoError(t, d.SaveBlockHeader(ctx, newHeader))
+	require.NoError(t, d.SaveChainHead(ctx, &ethpb.ChainHead{HeadEpoch: headEpoch}))
+	require.NoError(t, d.Close())
+
+	require.NoError(t, prune(cliCtx))
+	assert.LogsContain(t, logHook, "Pruning completed successfully")
+
+	d, err = kv.NewKVStore(path.Join(dataDir, kv.SlasherDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, d.Close())
+	}()
+	assert.Equal(t, false, d.HasBlockHeader(ctx, oldHeader.Header.Slot, types.ValidatorIndex(1)))
+	assert.Equal(t, true, d.HasBlockHeader(ctx, newHeader.Header.Slot, types.ValidatorIndex(1)))
+}
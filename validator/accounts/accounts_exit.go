@@ -8,6 +8,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -21,16 +23,19 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/client"
 	"github.com/prysmaticlabs/prysm/validator/flags"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
 )
 
 type performExitCfg struct {
 	validatorClient  ethpb.BeaconNodeValidatorClient
-	nodeClient       ethpb.NodeClient
+	beaconClient     ethpbv1.BeaconChainClient
 	keymanager       keymanager.IKeymanager
 	rawPubKeys       [][]byte
 	formattedPubKeys []string
+	epoch            types.Epoch
+	dryRun           bool
 }
 
 const exitPassphrase = "Exit my validator"
@@ -51,17 +56,26 @@ func ExitAccountsCli(cliCtx *cli.Context, r io.Reader) error {
 		return nil
 	}
 
-	validatorClient, nodeClient, err := prepareClients(cliCtx)
+	validatorClient, nodeClient, beaconClient, err := prepareClients(cliCtx)
+	if err != nil {
+		return err
+	}
+	epoch, err := exitEpoch(cliCtx, *nodeClient)
 	if err != nil {
 		return err
 	}
 
 	cfg := performExitCfg{
 		*validatorClient,
-		*nodeClient,
+		*beaconClient,
 		kManager,
 		rawPubKeys,
 		trimmedPubKeys,
+		epoch,
+		cliCtx.Bool(flags.VoluntaryExitDryRunFlag.Name),
+	}
+	if cfg.dryRun {
+		return dryRunExit(cliCtx, cfg)
 	}
 	rawExitedKeys, trimmedExitedKeys, err := performExit(cliCtx, cfg)
 	if err != nil {
@@ -160,6 +174,11 @@ func interact(
 		fmt.Printf("About to perform a voluntary exit of %d accounts\n", len(rawPubKeys))
 	}
 
+	// Nothing is submitted in a dry run, so the final confirmation is not needed.
+	if cliCtx.Bool(flags.VoluntaryExitDryRunFlag.Name) {
+		return rawPubKeys, formattedPubKeys, nil
+	}
+
 	promptHeader := au.Red("===============IMPORTANT===============")
 	promptDescription := "Withdrawing funds is not possible in Phase 0 of the system. " +
 		"Please navigate to the following website and make sure you understand the current implications " +
@@ -181,15 +200,17 @@ func interact(
 	return rawPubKeys, formattedPubKeys, nil
 }
 
-func prepareClients(cliCtx *cli.Context) (*ethpb.BeaconNodeValidatorClient, *ethpb.NodeClient, error) {
+func prepareClients(cliCtx *cli.Context) (*ethpb.BeaconNodeValidatorClient, *ethpb.NodeClient, *ethpbv1.BeaconChainClient, error) {
 	dialOpts := client.ConstructDialOptions(
 		cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
 		cliCtx.String(flags.CertFlag.Name),
+		cliCtx.String(flags.ClientCertFlag.Name),
+		cliCtx.String(flags.ClientKeyFlag.Name),
 		cliCtx.Uint(flags.GrpcRetriesFlag.Name),
 		cliCtx.Duration(flags.GrpcRetryDelayFlag.Name),
 	)
 	if dialOpts == nil {
-		return nil, nil, errors.New("failed to construct dial options")
+		return nil, nil, nil, errors.New("failed to construct dial options")
 	}
 
 	grpcHeaders := strings.Split(cliCtx.String(flags.GrpcHeadersFlag.Name), ",")
@@ -197,17 +218,35 @@ func prepareClients(cliCtx *cli.Context) (*ethpb.BeaconNodeValidatorClient, *eth
 
 	conn, err := grpc.DialContext(cliCtx.Context, cliCtx.String(flags.BeaconRPCProviderFlag.Name), dialOpts...)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "could not dial endpoint %s", flags.BeaconRPCProviderFlag.Name)
+		return nil, nil, nil, errors.Wrapf(err, "could not dial endpoint %s", flags.BeaconRPCProviderFlag.Name)
 	}
 	validatorClient := ethpb.NewBeaconNodeValidatorClient(conn)
 	nodeClient := ethpb.NewNodeClient(conn)
-	return &validatorClient, &nodeClient, nil
+	beaconClient := ethpbv1.NewBeaconChainClient(conn)
+	return &validatorClient, &nodeClient, &beaconClient, nil
+}
+
+// exitEpoch returns the epoch at which voluntary exits are signed, which is the current epoch
+// unless overridden by the user. Exits for a future epoch would be rejected by the beacon node.
+func exitEpoch(cliCtx *cli.Context, nodeClient ethpb.NodeClient) (types.Epoch, error) {
+	currentEpoch, err := client.CurrentEpoch(cliCtx.Context, nodeClient)
+	if err != nil {
+		return 0, err
+	}
+	if !cliCtx.IsSet(flags.VoluntaryExitEpochFlag.Name) {
+		return currentEpoch, nil
+	}
+	epoch := types.Epoch(cliCtx.Uint64(flags.VoluntaryExitEpochFlag.Name))
+	if epoch > currentEpoch {
+		return 0, errors.Errorf("voluntary exit epoch %d is in the future, current epoch is %d", epoch, currentEpoch)
+	}
+	return epoch, nil
 }
 
 func performExit(cliCtx *cli.Context, cfg performExitCfg) (rawExitedKeys [][]byte, formattedExitedKeys []string, err error) {
 	var rawNotExitedKeys [][]byte
 	for i, key := range cfg.rawPubKeys {
-		if err := client.ProposeExit(cliCtx.Context, cfg.validatorClient, cfg.nodeClient, cfg.keymanager.Sign, key); err != nil {
+		if err := client.SubmitExit(cliCtx.Context, cfg.validatorClient, cfg.beaconClient, cfg.keymanager.Sign, key, cfg.epoch); err != nil {
 			rawNotExitedKeys = append(rawNotExitedKeys, key)
 
 			msg := err.Error()
@@ -217,8 +256,14 @@ func performExit(cliCtx *cli.Context, cfg performExitCfg) (rawExitedKeys [][]byt
 			} else {
 				log.WithError(err).Errorf("voluntary exit failed for account %s", cfg.formattedPubKeys[i])
 			}
+			continue
 		}
+		log.WithField("publicKey", cfg.formattedPubKeys[i]).Info("Submitted voluntary exit")
 	}
+	log.WithFields(logrus.Fields{
+		"succeeded": len(cfg.rawPubKeys) - len(rawNotExitedKeys),
+		"failed":    len(rawNotExitedKeys),
+	}).Infof("Performed voluntary exits for %d accounts", len(cfg.rawPubKeys))
 
 	rawExitedKeys = make([][]byte, 0)
 	formattedExitedKeys = make([]string, 0)
@@ -239,6 +284,24 @@ func performExit(cliCtx *cli.Context, cfg performExitCfg) (rawExitedKeys [][]byt
 	return rawExitedKeys, formattedExitedKeys, nil
 }
 
+// dryRunExit reports the voluntary exits that would be performed, checking that each account
+// is a known validator, without signing or submitting anything.
+func dryRunExit(cliCtx *cli.Context, cfg performExitCfg) error {
+	for i, key := range cfg.rawPubKeys {
+		indexResponse, err := cfg.validatorClient.ValidatorIndex(cliCtx.Context, &ethpb.ValidatorIndexRequest{PublicKey: key})
+		if err != nil {
+			log.WithError(err).Errorf("Could not get validator index for account %s", cfg.formattedPubKeys[i])
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"publicKey":      cfg.formattedPubKeys[i],
+			"validatorIndex": indexResponse.Index,
+			"epoch":          cfg.epoch,
+		}).Info("Dry run: would perform voluntary exit")
+	}
+	return nil
+}
+
 func displayExitInfo(rawExitedKeys [][]byte, trimmedExitedKeys []string) {
 	if len(rawExitedKeys) > 0 {
 		urlFormattedPubKeys := make([]string, len(rawExitedKeys))
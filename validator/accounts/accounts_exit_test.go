@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"sort"
@@ -10,44 +11,47 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
+	eth2types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
 	"github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+type mockV1BeaconChainClient struct {
+	ethpbv1.BeaconChainClient
+	exits []*ethpbv1.SignedVoluntaryExit
+}
+
+func (m *mockV1BeaconChainClient) SubmitVoluntaryExit(
+	_ context.Context, exit *ethpbv1.SignedVoluntaryExit, _ ...grpc.CallOption,
+) (*types.Empty, error) {
+	m.exits = append(m.exits, exit)
+	return &types.Empty{}, nil
+}
+
 func TestExitAccountsCli_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockValidatorClient := mock.NewMockBeaconNodeValidatorClient(ctrl)
-	mockNodeClient := mock.NewMockNodeClient(ctrl)
+	mockBeaconClient := &mockV1BeaconChainClient{}
 
 	mockValidatorClient.EXPECT().
 		ValidatorIndex(gomock.Any(), gomock.Any()).
 		Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil)
 
-	// Any time in the past will suffice
-	genesisTime := &types.Timestamp{
-		Seconds: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
-	}
-
-	mockNodeClient.EXPECT().
-		GetGenesis(gomock.Any(), gomock.Any()).
-		Return(&ethpb.Genesis{GenesisTime: genesisTime}, nil)
-
 	mockValidatorClient.EXPECT().
 		DomainData(gomock.Any(), gomock.Any()).
 		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
 
-	mockValidatorClient.EXPECT().
-		ProposeExit(gomock.Any(), gomock.AssignableToTypeOf(&ethpb.SignedVoluntaryExit{})).
-		Return(&ethpb.ProposeExitResponse{}, nil)
-
 	walletDir, _, passwordFilePath := setupWalletAndPasswordsDir(t)
 	// Write a directory where we will import keys from.
 	keysDir := filepath.Join(t.TempDir(), "keysDir")
@@ -94,10 +98,12 @@ func TestExitAccountsCli_OK(t *testing.T) {
 
 	cfg := performExitCfg{
 		mockValidatorClient,
-		mockNodeClient,
+		mockBeaconClient,
 		keymanager,
 		rawPubKeys,
 		formattedPubKeys,
+		10,
+		false,
 	}
 	rawExitedKeys, formattedExitedKeys, err := performExit(cliCtx, cfg)
 	require.NoError(t, err)
@@ -105,13 +111,16 @@ func TestExitAccountsCli_OK(t *testing.T) {
 	assert.DeepEqual(t, rawPubKeys[0], rawExitedKeys[0])
 	require.Equal(t, 1, len(formattedExitedKeys))
 	assert.Equal(t, "0x"+keystore.Pubkey[:12], formattedExitedKeys[0])
+	require.Equal(t, 1, len(mockBeaconClient.exits))
+	assert.Equal(t, eth2types.Epoch(10), mockBeaconClient.exits[0].Exit.Epoch)
+	assert.Equal(t, eth2types.ValidatorIndex(1), mockBeaconClient.exits[0].Exit.ValidatorIndex)
 }
 
 func TestExitAccountsCli_OK_AllPublicKeys(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockValidatorClient := mock.NewMockBeaconNodeValidatorClient(ctrl)
-	mockNodeClient := mock.NewMockNodeClient(ctrl)
+	mockBeaconClient := &mockV1BeaconChainClient{}
 
 	mockValidatorClient.EXPECT().
 		ValidatorIndex(gomock.Any(), gomock.Any()).
@@ -121,26 +130,11 @@ func TestExitAccountsCli_OK_AllPublicKeys(t *testing.T) {
 		ValidatorIndex(gomock.Any(), gomock.Any()).
 		Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil)
 
-	// Any time in the past will suffice
-	genesisTime := &types.Timestamp{
-		Seconds: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
-	}
-
-	mockNodeClient.EXPECT().
-		GetGenesis(gomock.Any(), gomock.Any()).
-		Times(2).
-		Return(&ethpb.Genesis{GenesisTime: genesisTime}, nil)
-
 	mockValidatorClient.EXPECT().
 		DomainData(gomock.Any(), gomock.Any()).
 		Times(2).
 		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
 
-	mockValidatorClient.EXPECT().
-		ProposeExit(gomock.Any(), gomock.AssignableToTypeOf(&ethpb.SignedVoluntaryExit{})).
-		Times(2).
-		Return(&ethpb.ProposeExitResponse{}, nil)
-
 	walletDir, _, passwordFilePath := setupWalletAndPasswordsDir(t)
 	// Write a directory where we will import keys from.
 	keysDir := filepath.Join(t.TempDir(), "keysDir")
@@ -189,10 +183,12 @@ func TestExitAccountsCli_OK_AllPublicKeys(t *testing.T) {
 
 	cfg := performExitCfg{
 		mockValidatorClient,
-		mockNodeClient,
+		mockBeaconClient,
 		keymanager,
 		rawPubKeys,
 		formattedPubKeys,
+		10,
+		false,
 	}
 	rawExitedKeys, formattedExitedKeys, err := performExit(cliCtx, cfg)
 	require.NoError(t, err)
@@ -206,6 +202,96 @@ func TestExitAccountsCli_OK_AllPublicKeys(t *testing.T) {
 	sort.Strings(wantedFormatted)
 	sort.Strings(formattedExitedKeys)
 	require.DeepEqual(t, wantedFormatted, formattedExitedKeys)
+	require.Equal(t, 2, len(mockBeaconClient.exits))
+}
+
+func TestExitAccountsCli_DryRun(t *testing.T) {
+	hook := test.NewGlobal()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockValidatorClient := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	mockBeaconClient := &mockV1BeaconChainClient{}
+
+	mockValidatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), gomock.Any()).
+		Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil)
+
+	walletDir, _, passwordFilePath := setupWalletAndPasswordsDir(t)
+	keysDir := filepath.Join(t.TempDir(), "keysDir")
+	require.NoError(t, os.MkdirAll(keysDir, os.ModePerm))
+	keystore, _ := createKeystore(t, keysDir)
+	time.Sleep(time.Second)
+
+	cliCtx := setupWalletCtx(t, &testWalletConfig{
+		walletDir:               walletDir,
+		keymanagerKind:          keymanager.Imported,
+		walletPasswordFile:      passwordFilePath,
+		accountPasswordFile:     passwordFilePath,
+		keysDir:                 keysDir,
+		voluntaryExitPublicKeys: keystore.Pubkey,
+		exitDryRun:              true,
+	})
+	_, err := CreateWalletWithKeymanager(cliCtx.Context, &CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      walletDir,
+			KeymanagerKind: keymanager.Imported,
+			WalletPassword: password,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ImportAccountsCli(cliCtx))
+
+	validatingPublicKeys, keymanager, err := prepareWallet(cliCtx)
+	require.NoError(t, err)
+
+	// No confirmation phrase is required in a dry run.
+	var stdin bytes.Buffer
+	rawPubKeys, formattedPubKeys, err := interact(cliCtx, &stdin, validatingPublicKeys)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(rawPubKeys))
+
+	cfg := performExitCfg{
+		mockValidatorClient,
+		mockBeaconClient,
+		keymanager,
+		rawPubKeys,
+		formattedPubKeys,
+		10,
+		true,
+	}
+	require.NoError(t, dryRunExit(cliCtx, cfg))
+	assert.Equal(t, 0, len(mockBeaconClient.exits))
+	assert.LogsContain(t, hook, "Dry run: would perform voluntary exit")
+}
+
+func TestExitEpoch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockNodeClient := mock.NewMockNodeClient(ctrl)
+
+	// Genesis was 20 epochs ago.
+	epochDuration := time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second
+	genesisTime := &types.Timestamp{
+		Seconds: time.Now().Add(-20 * epochDuration).Unix(),
+	}
+	mockNodeClient.EXPECT().
+		GetGenesis(gomock.Any(), gomock.Any()).
+		Times(3).
+		Return(&ethpb.Genesis{GenesisTime: genesisTime}, nil)
+
+	cliCtx := setupWalletCtx(t, &testWalletConfig{})
+	epoch, err := exitEpoch(cliCtx, mockNodeClient)
+	require.NoError(t, err)
+	assert.Equal(t, eth2types.Epoch(20), epoch)
+
+	cliCtx = setupWalletCtx(t, &testWalletConfig{voluntaryExitEpoch: "5"})
+	epoch, err = exitEpoch(cliCtx, mockNodeClient)
+	require.NoError(t, err)
+	assert.Equal(t, eth2types.Epoch(5), epoch)
+
+	cliCtx = setupWalletCtx(t, &testWalletConfig{voluntaryExitEpoch: "21"})
+	_, err = exitEpoch(cliCtx, mockNodeClient)
+	assert.ErrorContains(t, "voluntary exit epoch 21 is in the future", err)
 }
 
 func TestPrepareWallet_EmptyWalletReturnsError(t *testing.T) {
@@ -247,7 +333,7 @@ func TestPrepareClients_AddsGRPCHeaders(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	_, _, err = prepareClients(cliCtx)
+	_, _, _, err = prepareClients(cliCtx)
 	require.NoError(t, err)
 	md, _ := metadata.FromOutgoingContext(cliCtx.Context)
 	assert.Equal(t, "Basic some-token", md.Get("Authorization")[0])
@@ -42,7 +42,7 @@ func ListAccountsCli(cliCtx *cli.Context) error {
 	listIndices := cliCtx.Bool(flags.ListValidatorIndices.Name)
 
 	if listIndices {
-		client, _, err := prepareClients(cliCtx)
+		client, _, _, err := prepareClients(cliCtx)
 		if err != nil {
 			return err
 		}
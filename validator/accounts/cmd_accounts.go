@@ -54,6 +54,8 @@ var AccountCommands = &cli.Command{
 				flags.BeaconRPCProviderFlag,
 				cmd.GrpcMaxCallRecvMsgSizeFlag,
 				flags.CertFlag,
+				flags.ClientCertFlag,
+				flags.ClientKeyFlag,
 				flags.GrpcHeadersFlag,
 				flags.GrpcRetriesFlag,
 				flags.GrpcRetryDelayFlag,
@@ -146,10 +148,14 @@ var AccountCommands = &cli.Command{
 				flags.BeaconRPCProviderFlag,
 				cmd.GrpcMaxCallRecvMsgSizeFlag,
 				flags.CertFlag,
+				flags.ClientCertFlag,
+				flags.ClientKeyFlag,
 				flags.GrpcHeadersFlag,
 				flags.GrpcRetriesFlag,
 				flags.GrpcRetryDelayFlag,
 				flags.ExitAllFlag,
+				flags.VoluntaryExitEpochFlag,
+				flags.VoluntaryExitDryRunFlag,
 				featureconfig.Mainnet,
 				featureconfig.PyrmontTestnet,
 				featureconfig.ToledoTestnet,
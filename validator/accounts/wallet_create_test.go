@@ -38,6 +38,7 @@ func init() {
 
 type testWalletConfig struct {
 	exitAll                 bool
+	exitDryRun              bool
 	skipDepositConfirm      bool
 	keymanagerKind          keymanager.Kind
 	numAccounts             int64
@@ -48,6 +49,7 @@ type testWalletConfig struct {
 	backupPasswordFile      string
 	backupPublicKeys        string
 	voluntaryExitPublicKeys string
+	voluntaryExitEpoch      string
 	disablePublicKeys       string
 	enablePublicKeys        string
 	deletePublicKeys        string
@@ -79,8 +81,13 @@ func setupWalletCtx(
 	set.Bool(flags.SkipDepositConfirmationFlag.Name, cfg.skipDepositConfirm, "")
 	set.Bool(flags.SkipMnemonic25thWordCheckFlag.Name, true, "")
 	set.Bool(flags.ExitAllFlag.Name, cfg.exitAll, "")
+	set.Bool(flags.VoluntaryExitDryRunFlag.Name, cfg.exitDryRun, "")
 	set.String(flags.GrpcHeadersFlag.Name, cfg.grpcHeaders, "")
 
+	if cfg.voluntaryExitEpoch != "" {
+		set.Uint64(flags.VoluntaryExitEpochFlag.Name, 0, "")
+		assert.NoError(tb, set.Set(flags.VoluntaryExitEpochFlag.Name, cfg.voluntaryExitEpoch))
+	}
 	if cfg.privateKeyFile != "" {
 		set.String(flags.ImportPrivateKeyFileFlag.Name, cfg.privateKeyFile, "")
 		assert.NoError(tb, set.Set(flags.ImportPrivateKeyFileFlag.Name, cfg.privateKeyFile))
@@ -101,6 +108,7 @@ func setupWalletCtx(
 	assert.NoError(tb, set.Set(flags.NumAccountsFlag.Name, strconv.Itoa(int(cfg.numAccounts))))
 	assert.NoError(tb, set.Set(flags.SkipDepositConfirmationFlag.Name, strconv.FormatBool(cfg.skipDepositConfirm)))
 	assert.NoError(tb, set.Set(flags.ExitAllFlag.Name, strconv.FormatBool(cfg.exitAll)))
+	assert.NoError(tb, set.Set(flags.VoluntaryExitDryRunFlag.Name, strconv.FormatBool(cfg.exitDryRun)))
 	assert.NoError(tb, set.Set(flags.GrpcHeadersFlag.Name, cfg.grpcHeaders))
 	return cli.NewContext(&app, set, nil)
 }
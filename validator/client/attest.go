@@ -3,11 +3,11 @@ package client
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -198,6 +198,11 @@ func (v *validator) signAtt(ctx context.Context, pubKey [48]byte, data *ethpb.At
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
+	if v.remoteProtection != nil {
+		if err := v.remoteProtection.CheckAttestation(ctx, pubKey, root, data); err != nil {
+			return nil, [32]byte{}, errors.Wrap(err, failedAttRemoteProtectionErr)
+		}
+	}
 
 	sig, err := v.keyManager.Sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
@@ -15,6 +15,8 @@ import (
 
 var failedAttLocalProtectionErr = "attempted to make slashable attestation, rejected by local slashing protection"
 var failedPostAttSignExternalErr = "attempted to make slashable attestation, rejected by external slasher service"
+var failedAttRemoteProtectionErr = "attestation rejected by remote slashing protection service before signing"
+var failedPreAttSignExternalErr = "attempted to make attestation lower than highest attestation seen by external slasher service"
 
 // Checks if an attestation is slashable by comparing it with the attesting
 // history for the given public key in our DB. If it is not, we then update the history
@@ -61,6 +63,16 @@ func (v *validator) slashableAttestationCheck(
 		)
 	}
 	fmtKey := "0x" + hex.EncodeToString(pubKey[:])
+	// The external slasher also sees the attestations made over the network, for example by another
+	// instance of the same validator, which are missing from our DB.
+	if featureconfig.Get().SlasherProtection && featureconfig.Get().SlasherHighestAttestationCheck && v.protector != nil {
+		if !v.protector.CheckHighestAttestation(ctx, indexedAtt) {
+			if v.emitAccountMetrics {
+				ValidatorAttestFailVecSlasher.WithLabelValues(fmtKey).Inc()
+			}
+			return errors.New(failedPreAttSignExternalErr)
+		}
+	}
 	slashingKind, err := v.db.CheckSlashableAttestation(ctx, pubKey, signingRoot, indexedAtt)
 	if err != nil {
 		if v.emitAccountMetrics {
@@ -100,6 +100,48 @@ func Test_slashableAttestationCheck_UpdatesLowestSignedEpochs(t *testing.T) {
 	require.Equal(t, types.Epoch(10), e)
 }
 
+func Test_slashableAttestationCheck_HighestAttestation(t *testing.T) {
+	config := &featureconfig.Flags{
+		SlasherProtection:              true,
+		SlasherHighestAttestationCheck: true,
+	}
+	reset := featureconfig.InitWithReset(config)
+	defer reset()
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	pubKey := [48]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	att := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			Slot:            5,
+			CommitteeIndex:  2,
+			BeaconBlockRoot: bytesutil.PadTo([]byte("great block"), 32),
+			Source: &ethpb.Checkpoint{
+				Epoch: 4,
+				Root:  bytesutil.PadTo([]byte("good source"), 32),
+			},
+			Target: &ethpb.Checkpoint{
+				Epoch: 10,
+				Root:  bytesutil.PadTo([]byte("good target"), 32),
+			},
+		},
+	}
+	mockProtector := &mockSlasher.MockProtector{AllowAttestation: false}
+	validator.protector = mockProtector
+	err := validator.slashableAttestationCheck(context.Background(), att, pubKey, [32]byte{1})
+	require.ErrorContains(t, failedPreAttSignExternalErr, err)
+
+	// The rejected attestation is not saved to the attesting history.
+	_, exists, err := validator.db.LowestSignedTargetEpoch(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+
+	mockProtector.AllowAttestation = true
+	err = validator.slashableAttestationCheck(context.Background(), att, pubKey, [32]byte{1})
+	require.NoError(t, err, "Expected allowed attestation not to throw error")
+}
+
 func Test_slashableAttestationCheck_OK(t *testing.T) {
 	config := &featureconfig.Flags{
 		SlasherProtection: false,
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sync"
 	"testing"
@@ -25,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	"github.com/prysmaticlabs/prysm/shared/timeutils"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/remote"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"gopkg.in/d4l3k/messagediff.v1"
 )
@@ -484,6 +487,34 @@ func TestSignAttestation(t *testing.T) {
 		"e74b8cf2c0f2c1b79b8e17e7b21ed1694305", hex.EncodeToString(sr[:]))
 }
 
+func TestSignAttestation_RemoteProtection(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	allow := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allow {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+	var err error
+	validator.remoteProtection, err = remote.NewClient(&remote.Config{URL: srv.URL, Timeout: time.Second})
+	require.NoError(t, err)
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), gomock.Any()).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil).AnyTimes()
+	var pubKey [48]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	att := testutil.NewAttestation()
+
+	_, _, err = validator.signAtt(context.Background(), pubKey, att.Data)
+	assert.ErrorContains(t, failedAttRemoteProtectionErr, err)
+	allow = true
+	_, _, err = validator.signAtt(context.Background(), pubKey, att.Data)
+	require.NoError(t, err)
+}
+
 func TestServer_WaitToSlotOneThird_CanWait(t *testing.T) {
 	currentTime := uint64(time.Now().Unix())
 	currentSlot := types.Slot(4)
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/grpcutils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+)
+
+// livenessMethod is the Prysm specific gRPC method of the beacon node reporting the liveness of
+// validators. Its messages are plain structs, encoded with the grpcutils.JSONCodecName codec.
+const livenessMethod = "/prysm.eth.v1.BeaconChain/GetLiveness"
+
+var errDoppelgangerDetected = errors.New("validator keys are already attesting elsewhere")
+
+// livenessChecker determines which validators were seen attesting in an epoch.
+type livenessChecker interface {
+	Liveness(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) (map[types.ValidatorIndex]bool, error)
+}
+
+// grpcLivenessClient checks the liveness of validators over the gRPC connection to the beacon
+// node, which carries the TLS and request headers configured for the other calls of the client.
+type grpcLivenessClient struct {
+	conn grpc.ClientConnInterface
+}
+
+// livenessRequest mirrors the request message of the liveness method.
+type livenessRequest struct {
+	Epoch types.Epoch            `json:"epoch"`
+	Index []types.ValidatorIndex `json:"index"`
+}
+
+// livenessResponse mirrors the response message of the liveness method.
+type livenessResponse struct {
+	Data []*struct {
+		Index  types.ValidatorIndex `json:"index"`
+		IsLive bool                 `json:"is_live"`
+	} `json:"data"`
+}
+
+// Liveness requests the liveness of validator indices in an epoch from the beacon node.
+func (c *grpcLivenessClient) Liveness(
+	ctx context.Context,
+	epoch types.Epoch,
+	indices []types.ValidatorIndex,
+) (map[types.ValidatorIndex]bool, error) {
+	resp := &livenessResponse{}
+	req := &livenessRequest{Epoch: epoch, Index: indices}
+	if err := c.conn.Invoke(ctx, livenessMethod, req, resp, grpc.CallContentSubtype(grpcutils.JSONCodecName)); err != nil {
+		return nil, errors.Wrap(errConnectionIssue, err.Error())
+	}
+	live := make(map[types.ValidatorIndex]bool, len(resp.Data))
+	for _, item := range resp.Data {
+		if item != nil {
+			live[item.Index] = item.IsLive
+		}
+	}
+	return live, nil
+}
+
+// CheckDoppelgangers watches the network for the configured number of epochs before duties are
+// started, and returns an error if any of the validating keys is seen attesting meanwhile, as the
+// keys are then run by another validator client as well. Attestations of the current epoch may
+// be those of a previous run of this validator client, so only the epochs following it are watched.
+func (v *validator) CheckDoppelgangers(ctx context.Context) error {
+	if v.doppelgangerEpochs == 0 {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelgangers")
+	defer span.End()
+
+	pubKeys, err := v.activeValidatorIndices(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get indices of active validators")
+	}
+	if len(pubKeys) == 0 {
+		return nil
+	}
+	indices := make([]types.ValidatorIndex, 0, len(pubKeys))
+	for idx := range pubKeys {
+		indices = append(indices, idx)
+	}
+
+	currentEpoch := slotutil.EpochsSinceGenesis(time.Unix(int64(v.genesisTime), 0))
+	lastEpoch := currentEpoch + types.Epoch(v.doppelgangerEpochs)
+	log.WithFields(logrus.Fields{
+		"numValidators": len(indices),
+		"epochs":        v.doppelgangerEpochs,
+	}).Info("Checking the network for doppelganger validators before starting duties")
+	for epoch := currentEpoch + 1; epoch <= lastEpoch; epoch++ {
+		// An epoch is checked in the last slot of the epoch following it, once its attestations
+		// had a chance to be included in blocks while it is still the previous epoch of the head.
+		checkSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch+2)) - 1
+		select {
+		case <-time.After(time.Until(slotutil.SlotStartTime(v.genesisTime, checkSlot))):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		live, err := v.livenessClient.Liveness(ctx, epoch, indices)
+		if err != nil {
+			return errors.Wrapf(err, "could not check liveness of validators in epoch %d", epoch)
+		}
+		var detected []string
+		for _, idx := range indices {
+			if live[idx] {
+				pubKey := pubKeys[idx]
+				detected = append(detected, fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
+			}
+		}
+		if len(detected) > 0 {
+			return errors.Wrapf(errDoppelgangerDetected, "validators %s attested in epoch %d", strings.Join(detected, ", "), epoch)
+		}
+		log.WithField("epoch", epoch).Info("No doppelganger validators detected")
+	}
+	return nil
+}
+
+// activeValidatorIndices maps the indices of the validating keys able to attest to their keys.
+func (v *validator) activeValidatorIndices(ctx context.Context) (map[types.ValidatorIndex][48]byte, error) {
+	validatingKeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, msgCouldNotFetchKeys)
+	}
+	if len(validatingKeys) == 0 {
+		return nil, nil
+	}
+	publicKeys := make([][]byte, len(validatingKeys))
+	for i := range validatingKeys {
+		publicKeys[i] = validatingKeys[i][:]
+	}
+	resp, err := v.validatorClient.MultipleValidatorStatus(ctx, &ethpb.MultipleValidatorStatusRequest{
+		PublicKeys: publicKeys,
+	})
+	if err != nil {
+		return nil, errors.Wrap(errConnectionIssue, err.Error())
+	}
+	if len(resp.Statuses) != len(resp.PublicKeys) || len(resp.Indices) != len(resp.PublicKeys) {
+		return nil, errors.New("number of status responses did not match number of requested keys")
+	}
+	indices := make(map[types.ValidatorIndex][48]byte)
+	for i, status := range resp.Statuses {
+		switch status.Status {
+		case ethpb.ValidatorStatus_ACTIVE, ethpb.ValidatorStatus_EXITING, ethpb.ValidatorStatus_SLASHING:
+			indices[resp.Indices[i]] = bytesutil.ToBytes48(resp.PublicKeys[i])
+		}
+	}
+	return indices, nil
+}
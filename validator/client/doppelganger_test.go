@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockLivenessChecker struct {
+	live   map[types.ValidatorIndex]bool
+	epochs []types.Epoch
+}
+
+func (m *mockLivenessChecker) Liveness(
+	_ context.Context,
+	epoch types.Epoch,
+	indices []types.ValidatorIndex,
+) (map[types.ValidatorIndex]bool, error) {
+	m.epochs = append(m.epochs, epoch)
+	live := make(map[types.ValidatorIndex]bool)
+	for _, idx := range indices {
+		live[idx] = m.live[idx]
+	}
+	return live, nil
+}
+
+// livenessServer serves the liveness method of the beacon node, answering that the odd validator
+// indices are live.
+type livenessServer struct {
+	requests []*livenessRequest
+}
+
+func (s *livenessServer) liveness(_ context.Context, req *livenessRequest) (interface{}, error) {
+	s.requests = append(s.requests, req)
+	if req.Epoch > 10 {
+		return nil, status.Errorf(codes.InvalidArgument, "Epoch %d is not the current or previous epoch of the head state", req.Epoch)
+	}
+	resp := map[string][]map[string]interface{}{"data": {}}
+	for _, idx := range req.Index {
+		resp["data"] = append(resp["data"], map[string]interface{}{"index": idx, "epoch": req.Epoch, "is_live": idx%2 == 1})
+	}
+	return resp, nil
+}
+
+func setupLivenessServer(t *testing.T) (*livenessServer, *grpc.ClientConn) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	srv := &livenessServer{}
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "prysm.eth.v1.BeaconChain",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetLiveness",
+				Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					req := &livenessRequest{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+					return srv.liveness(ctx, req)
+				},
+			},
+		},
+	}, srv)
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			t.Log(err)
+		}
+	}()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	return srv, conn
+}
+
+func TestGRPCLivenessClient_Liveness(t *testing.T) {
+	srv, conn := setupLivenessServer(t)
+
+	live, err := (&grpcLivenessClient{conn: conn}).Liveness(context.Background(), 7, []types.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[types.ValidatorIndex]bool{1: true, 2: false}, live)
+	require.Equal(t, 1, len(srv.requests))
+	assert.DeepEqual(t, &livenessRequest{Epoch: 7, Index: []types.ValidatorIndex{1, 2}}, srv.requests[0])
+}
+
+func TestGRPCLivenessClient_Liveness_Error(t *testing.T) {
+	_, conn := setupLivenessServer(t)
+
+	_, err := (&grpcLivenessClient{conn: conn}).Liveness(context.Background(), 11, []types.ValidatorIndex{1})
+	assert.ErrorContains(t, "not the current or previous epoch", err)
+}
+
+func TestCheckDoppelgangers_Disabled(t *testing.T) {
+	checker := &mockLivenessChecker{}
+	v := validator{keyManager: genMockKeymanger(1), livenessClient: checker}
+	require.NoError(t, v.CheckDoppelgangers(context.Background()))
+	assert.Equal(t, 0, len(checker.epochs))
+}
+
+func TestCheckDoppelgangers(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.SecondsPerSlot = 1
+	cfg.SlotsPerEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	tests := []struct {
+		name      string
+		live      map[types.ValidatorIndex]bool
+		wantedErr string
+	}{
+		{
+			name: "no doppelganger",
+			// Index 2 is pending, so it cannot be attesting.
+			live: map[types.ValidatorIndex]bool{2: true, 3: true},
+		},
+		{
+			name:      "doppelganger detected",
+			live:      map[types.ValidatorIndex]bool{1: true},
+			wantedErr: errDoppelgangerDetected.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+			km := genMockKeymanger(2)
+			keys, err := km.FetchValidatingPublicKeys(context.Background())
+			require.NoError(t, err)
+			client.EXPECT().MultipleValidatorStatus(
+				gomock.Any(), // ctx
+				gomock.Any(), // request
+			).Return(&ethpb.MultipleValidatorStatusResponse{
+				PublicKeys: [][]byte{keys[0][:], keys[1][:]},
+				Statuses: []*ethpb.ValidatorStatusResponse{
+					{Status: ethpb.ValidatorStatus_ACTIVE},
+					{Status: ethpb.ValidatorStatus_PENDING},
+				},
+				Indices: []types.ValidatorIndex{1, 2},
+			}, nil /*err*/)
+
+			checker := &mockLivenessChecker{live: tt.live}
+			genesisTime := uint64(time.Now().Unix()) - 10
+			v := validator{
+				keyManager:         km,
+				validatorClient:    client,
+				livenessClient:     checker,
+				genesisTime:        genesisTime,
+				doppelgangerEpochs: 1,
+			}
+			err = v.CheckDoppelgangers(context.Background())
+			if tt.wantedErr != "" {
+				assert.ErrorContains(t, tt.wantedErr, err)
+			} else {
+				require.NoError(t, err)
+			}
+			// Only the epoch following the current one is watched.
+			require.Equal(t, 1, len(checker.epochs))
+			assert.Equal(t, true, checker.epochs[0] > 10)
+		})
+	}
+}
+
+func TestCheckDoppelgangers_ContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	client.EXPECT().MultipleValidatorStatus(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{make([]byte, 48)},
+		Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}},
+		Indices:    []types.ValidatorIndex{1},
+	}, nil /*err*/)
+
+	checker := &mockLivenessChecker{}
+	v := validator{
+		keyManager:         genMockKeymanger(1),
+		validatorClient:    client,
+		livenessClient:     checker,
+		genesisTime:        uint64(time.Now().Unix()),
+		doppelgangerEpochs: 2,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorContains(t, "context canceled", v.CheckDoppelgangers(ctx))
+	assert.Equal(t, 0, len(checker.epochs))
+}
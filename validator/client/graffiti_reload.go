@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/asyncutil"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
+)
+
+// graffitiFileDebounceInterval is the time to wait after the last change to the graffiti
+// file before reloading it, as editors may write a file in several steps.
+var graffitiFileDebounceInterval = time.Second
+
+// Listen for changes to the graffiti file and reload it into the validator, so graffiti
+// values can be updated without restarting the validator client. The parent directory
+// is watched rather than the file itself, as many editors replace a file when saving it.
+func (v *validator) listenForGraffitiFileChanges(ctx context.Context, graffitiFile string) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.WithError(err).Error("Could not initialize graffiti file watcher")
+		return
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.WithError(err).Error("Could not close graffiti file watcher")
+		}
+	}()
+	graffitiFile = filepath.Clean(graffitiFile)
+	if err := watcher.Add(filepath.Dir(graffitiFile)); err != nil {
+		log.WithError(err).Errorf("Could not add directory of %s to file watcher", graffitiFile)
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fileChangesChan := make(chan interface{}, 100)
+	defer close(fileChangesChan)
+
+	go asyncutil.Debounce(ctx, graffitiFileDebounceInterval, fileChangesChan, func(_ interface{}) {
+		g, err := graffiti.ParseGraffitiFile(graffitiFile)
+		if err != nil {
+			log.WithError(err).Errorf("Could not parse graffiti file at path: %s", graffitiFile)
+			return
+		}
+		if err := v.setGraffitiStruct(ctx, g); err != nil {
+			log.WithError(err).Error("Could not reload graffiti file")
+			return
+		}
+		log.WithField("path", graffitiFile).Info("Reloaded graffiti file")
+	})
+	for {
+		select {
+		case event := <-watcher.Events:
+			if filepath.Clean(event.Name) != graffitiFile || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			fileChangesChan <- event
+		case err := <-watcher.Errors:
+			log.WithError(err).Errorf("Could not watch for file changes for: %s", graffitiFile)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// setGraffitiStruct replaces the graffiti values of the validator, resuming the ordered
+// graffiti list from where it was left off if the file content is unchanged.
+func (v *validator) setGraffitiStruct(ctx context.Context, g *graffiti.Graffiti) error {
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+	if v.graffitiStruct != nil && v.graffitiStruct.Hash == g.Hash {
+		return nil
+	}
+	orderedIndex, err := v.db.GraffitiOrderedIndex(ctx, g.Hash)
+	if err != nil {
+		return errors.Wrap(err, "could not read graffiti ordered index")
+	}
+	v.graffitiStruct = g
+	v.graffitiOrderedIndex = orderedIndex
+	return nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/graffiti"
+)
+
+func TestSetGraffitiStruct_ResetsOrderedIndex(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [48]byte{'a'}
+	v := &validator{
+		db:             dbTest.SetupDB(t, [][48]byte{pubKey}),
+		graffitiStruct: &graffiti.Graffiti{Hash: [32]byte{'a'}, Ordered: []string{"a", "b"}},
+	}
+	g := &graffiti.Graffiti{Hash: [32]byte{'a'}, Ordered: []string{"c"}}
+	v.graffitiOrderedIndex = 1
+
+	// The same file content is not reloaded.
+	require.NoError(t, v.setGraffitiStruct(ctx, g))
+	assert.DeepEqual(t, []string{"a", "b"}, v.graffitiStruct.Ordered)
+	assert.Equal(t, uint64(1), v.graffitiOrderedIndex)
+
+	g.Hash = [32]byte{'b'}
+	require.NoError(t, v.setGraffitiStruct(ctx, g))
+	assert.DeepEqual(t, g, v.graffitiStruct)
+	assert.Equal(t, uint64(0), v.graffitiOrderedIndex)
+}
+
+func TestListenForGraffitiFileChanges(t *testing.T) {
+	graffitiFileDebounceInterval = 10 * time.Millisecond
+	pubKey := [48]byte{'a'}
+	v := &validator{
+		db:             dbTest.SetupDB(t, [][48]byte{pubKey}),
+		graffitiStruct: &graffiti.Graffiti{},
+	}
+	graffitiFile := filepath.Join(t.TempDir(), "graffiti.yaml")
+	require.NoError(t, ioutil.WriteFile(graffitiFile, []byte(`default: "a"`), os.ModePerm))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go v.listenForGraffitiFileChanges(ctx, graffitiFile)
+	// Give the watcher time to start before writing the file.
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, ioutil.WriteFile(graffitiFile, []byte(`default: "b"`), os.ModePerm))
+
+	require.NoError(t, waitForGraffiti(v, "b"))
+}
+
+func waitForGraffiti(v *validator, want string) error {
+	for i := 0; i < 100; i++ {
+		v.graffitiLock.Lock()
+		got := v.graffitiStruct.Default
+		v.graffitiLock.Unlock()
+		if got == want {
+			return nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return context.DeadlineExceeded
+}
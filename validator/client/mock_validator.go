@@ -27,6 +27,7 @@ type FakeValidator struct {
 	SaveProtectionsCalled             bool
 	DeleteProtectionCalled            bool
 	SlotDeadlineCalled                bool
+	CheckDoppelgangersCalled          bool
 	WaitForChainStartCalled           int
 	WaitForSyncCalled                 int
 	WaitForActivationCalled           int
@@ -216,3 +217,9 @@ func (fv *FakeValidator) ReceiveBlocks(ctx context.Context, connectionErrorChann
 		connectionErrorChannel <- errConnectionIssue
 	}
 }
+
+// CheckDoppelgangers for mocking
+func (fv *FakeValidator) CheckDoppelgangers(_ context.Context) error {
+	fv.CheckDoppelgangersCalled = true
+	return nil
+}
@@ -1,23 +1,47 @@
 package client
 
 import (
+	"context"
 	"strings"
+	"sync"
+	"time"
 
+	ptypes "github.com/gogo/protobuf/types"
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/resolver"
 )
 
+// endpointCheckPeriod is the frequency at which beacon node endpoints are health checked
+// when failing over between multiple endpoints.
+var endpointCheckPeriod = 10 * time.Second
+
+// endpointCheckTimeout bounds the time a single endpoint health check may take.
+var endpointCheckTimeout = 5 * time.Second
+
 // Modification of a default grpc passthrough resolver (google.golang.org/grpc/resolver/passthrough) allowing to use multiple addresses
 // in grpc endpoint. Example:
 // conn, err := grpc.DialContext(ctx, "127.0.0.1:4000,127.0.0.1:4001", grpc.WithInsecure(), grpc.WithResolvers(&multipleEndpointsGrpcResolverBuilder{}))
 // It can be used with any grpc load balancer (pick_first, round_robin). Default is pick_first.
 // Round robin can be used by adding the following option:
 // grpc.WithDefaultServiceConfig("{\"loadBalancingConfig\":[{\"round_robin\":{}}]}")
-type multipleEndpointsGrpcResolverBuilder struct{}
+//
+// If a checker is set, the resolver instead only resolves to the first healthy endpoint in the
+// order given, so the connection fails over to the next endpoint when the preferred one is not
+// reachable or syncing, and falls back to the preferred endpoint once it recovers.
+type multipleEndpointsGrpcResolverBuilder struct {
+	checker endpointChecker
+}
 
-func (*multipleEndpointsGrpcResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+func (b *multipleEndpointsGrpcResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
 	r := &multipleEndpointsGrpcResolver{
-		target: target,
-		cc:     cc,
+		target:  target,
+		cc:      cc,
+		checker: b.checker,
+		ctx:     ctx,
+		cancel:  cancel,
 	}
 	r.start()
 	return r, nil
@@ -28,19 +52,131 @@ func (*multipleEndpointsGrpcResolverBuilder) Scheme() string {
 }
 
 type multipleEndpointsGrpcResolver struct {
-	target resolver.Target
-	cc     resolver.ClientConn
+	target  resolver.Target
+	cc      resolver.ClientConn
+	checker endpointChecker
+	ctx     context.Context
+	cancel  context.CancelFunc
+	current string
 }
 
 func (r *multipleEndpointsGrpcResolver) start() {
 	endpoints := strings.Split(r.target.Endpoint, ",")
-	var addrs []resolver.Address
+	if r.checker == nil || len(endpoints) == 1 {
+		var addrs []resolver.Address
+		for _, endpoint := range endpoints {
+			addrs = append(addrs, resolver.Address{Addr: endpoint})
+		}
+		r.cc.UpdateState(resolver.State{Addresses: addrs})
+		return
+	}
+	// Start with the preferred endpoint until it is known to be unhealthy.
+	r.use(endpoints[0])
+	go r.checkEndpoints(endpoints)
+}
+
+// checkEndpoints periodically switches to the first healthy endpoint until the resolver is closed.
+func (r *multipleEndpointsGrpcResolver) checkEndpoints(endpoints []string) {
+	ticker := time.NewTicker(endpointCheckPeriod)
+	defer ticker.Stop()
+	for {
+		r.selectEndpoint(endpoints)
+		select {
+		case <-ticker.C:
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *multipleEndpointsGrpcResolver) selectEndpoint(endpoints []string) {
 	for _, endpoint := range endpoints {
-		addrs = append(addrs, resolver.Address{Addr: endpoint})
+		if r.checker.healthy(r.ctx, endpoint) {
+			if endpoint != r.current {
+				log.WithField("endpoint", endpoint).Warn("Switching to beacon node endpoint")
+				r.use(endpoint)
+			}
+			return
+		}
+		if r.ctx.Err() != nil {
+			return
+		}
 	}
-	r.cc.UpdateState(resolver.State{Addresses: addrs})
+	log.WithField("endpoint", r.current).Warn("No healthy beacon node endpoint, keeping current endpoint")
+}
+
+func (r *multipleEndpointsGrpcResolver) use(endpoint string) {
+	r.current = endpoint
+	r.cc.UpdateState(resolver.State{Addresses: []resolver.Address{{Addr: endpoint}}})
 }
 
 func (*multipleEndpointsGrpcResolver) ResolveNow(_ resolver.ResolveNowOptions) {}
 
-func (*multipleEndpointsGrpcResolver) Close() {}
+func (r *multipleEndpointsGrpcResolver) Close() {
+	r.cancel()
+}
+
+// endpointChecker determines whether a beacon node endpoint is able to serve the validator client.
+type endpointChecker interface {
+	healthy(ctx context.Context, endpoint string) bool
+}
+
+// beaconNodeChecker considers a beacon node endpoint healthy when it is reachable and not
+// syncing. It keeps a connection to each endpoint checked, until closed.
+type beaconNodeChecker struct {
+	dialOpts []grpc.DialOption
+	lock     sync.Mutex
+	conns    map[string]*grpc.ClientConn
+}
+
+func newBeaconNodeChecker(dialOpts []grpc.DialOption) *beaconNodeChecker {
+	return &beaconNodeChecker{
+		dialOpts: dialOpts,
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+}
+
+func (c *beaconNodeChecker) healthy(ctx context.Context, endpoint string) bool {
+	conn, err := c.conn(ctx, endpoint)
+	if err != nil {
+		log.WithError(err).WithField("endpoint", endpoint).Debug("Could not dial beacon node endpoint")
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, endpointCheckTimeout)
+	defer cancel()
+	syncStatus, err := ethpb.NewNodeClient(conn).GetSyncStatus(ctx, &ptypes.Empty{}, grpc_retry.WithMax(0))
+	if err != nil {
+		log.WithError(err).WithField("endpoint", endpoint).Debug("Beacon node endpoint is not reachable")
+		return false
+	}
+	if syncStatus.Syncing {
+		log.WithField("endpoint", endpoint).Debug("Beacon node endpoint is syncing")
+		return false
+	}
+	return true
+}
+
+func (c *beaconNodeChecker) conn(ctx context.Context, endpoint string) (*grpc.ClientConn, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if conn, ok := c.conns[endpoint]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.DialContext(ctx, endpoint, c.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c.conns[endpoint] = conn
+	return conn, nil
+}
+
+func (c *beaconNodeChecker) close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for endpoint, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).WithField("endpoint", endpoint).Debug("Could not close beacon node connection")
+		}
+	}
+	c.conns = make(map[string]*grpc.ClientConn)
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/resolver"
+)
+
+type mockClientConn struct {
+	resolver.ClientConn
+	states []resolver.State
+}
+
+func (m *mockClientConn) UpdateState(s resolver.State) {
+	m.states = append(m.states, s)
+}
+
+type mockEndpointChecker struct {
+	healthyEndpoints map[string]bool
+}
+
+func (m *mockEndpointChecker) healthy(_ context.Context, endpoint string) bool {
+	return m.healthyEndpoints[endpoint]
+}
+
+func TestMultipleEndpointsGrpcResolver_NoChecker(t *testing.T) {
+	cc := &mockClientConn{}
+	r, err := (&multipleEndpointsGrpcResolverBuilder{}).Build(resolver.Target{Endpoint: "a:4000,b:4000"}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, 1, len(cc.states))
+	assert.DeepEqual(t, []resolver.Address{{Addr: "a:4000"}, {Addr: "b:4000"}}, cc.states[0].Addresses)
+}
+
+func TestMultipleEndpointsGrpcResolver_SelectEndpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	checker := &mockEndpointChecker{healthyEndpoints: map[string]bool{"b:4000": true, "c:4000": true}}
+	cc := &mockClientConn{}
+	r := &multipleEndpointsGrpcResolver{cc: cc, checker: checker, ctx: ctx, cancel: cancel}
+	endpoints := []string{"a:4000", "b:4000", "c:4000"}
+	r.use(endpoints[0])
+
+	// Fail over to the first healthy endpoint.
+	r.selectEndpoint(endpoints)
+	assert.Equal(t, "b:4000", r.current)
+	require.Equal(t, 2, len(cc.states))
+	assert.DeepEqual(t, []resolver.Address{{Addr: "b:4000"}}, cc.states[1].Addresses)
+
+	// Keep the current endpoint while nothing changes.
+	r.selectEndpoint(endpoints)
+	assert.Equal(t, 2, len(cc.states))
+
+	// Fall back to the preferred endpoint once it recovers.
+	checker.healthyEndpoints["a:4000"] = true
+	r.selectEndpoint(endpoints)
+	assert.Equal(t, "a:4000", r.current)
+	assert.Equal(t, 3, len(cc.states))
+
+	// Keep the current endpoint when none are healthy.
+	checker.healthyEndpoints = map[string]bool{}
+	r.selectEndpoint(endpoints)
+	assert.Equal(t, "a:4000", r.current)
+	assert.Equal(t, 3, len(cc.states))
+}
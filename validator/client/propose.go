@@ -9,8 +9,10 @@ import (
 	pbtypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/proto/migration"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -164,12 +166,10 @@ func ProposeExit(
 	if err != nil {
 		return errors.Wrap(err, "gRPC call to get validator index failed")
 	}
-	genesisResponse, err := nodeClient.GetGenesis(ctx, &pbtypes.Empty{})
+	currentEpoch, err := CurrentEpoch(ctx, nodeClient)
 	if err != nil {
-		return errors.Wrap(err, "gRPC call to get genesis time failed")
+		return err
 	}
-	totalSecondsPassed := timeutils.Now().Unix() - genesisResponse.GenesisTime.Seconds
-	currentEpoch := types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)))
 
 	exit := &ethpb.VoluntaryExit{Epoch: currentEpoch, ValidatorIndex: indexResponse.Index}
 	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
@@ -190,6 +190,54 @@ func ProposeExit(
 	return nil
 }
 
+// SubmitExit performs a voluntary exit on a validator at the given epoch. The exit is signed
+// by the validator before being submitted through the standard beacon node API, which verifies
+// it and broadcasts it to the network.
+func SubmitExit(
+	ctx context.Context,
+	validatorClient ethpb.BeaconNodeValidatorClient,
+	beaconClient ethpbv1.BeaconChainClient,
+	signer signingFunc,
+	pubKey []byte,
+	epoch types.Epoch,
+) error {
+	ctx, span := trace.StartSpan(ctx, "validator.SubmitExit")
+	defer span.End()
+
+	indexResponse, err := validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey})
+	if err != nil {
+		return errors.Wrap(err, "gRPC call to get validator index failed")
+	}
+
+	exit := &ethpb.VoluntaryExit{Epoch: epoch, ValidatorIndex: indexResponse.Index}
+	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign voluntary exit")
+	}
+
+	signedExit := &ethpb.SignedVoluntaryExit{Exit: exit, Signature: sig}
+	if _, err := beaconClient.SubmitVoluntaryExit(ctx, migration.V1Alpha1ExitToV1(signedExit)); err != nil {
+		return errors.Wrap(err, "failed to submit voluntary exit")
+	}
+
+	span.AddAttributes(
+		trace.Int64Attribute("epoch", int64(epoch)),
+		trace.Int64Attribute("validatorIndex", int64(indexResponse.Index)),
+	)
+
+	return nil
+}
+
+// CurrentEpoch computes the current epoch from the genesis time of the beacon node.
+func CurrentEpoch(ctx context.Context, nodeClient ethpb.NodeClient) (types.Epoch, error) {
+	genesisResponse, err := nodeClient.GetGenesis(ctx, &pbtypes.Empty{})
+	if err != nil {
+		return 0, errors.Wrap(err, "gRPC call to get genesis time failed")
+	}
+	totalSecondsPassed := timeutils.Now().Unix() - genesisResponse.GenesisTime.Seconds
+	return types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))), nil
+}
+
 // Sign randao reveal with randao domain and private key.
 func (v *validator) signRandaoReveal(ctx context.Context, pubKey [48]byte, epoch types.Epoch) ([]byte, error) {
 	domain, err := v.domainData(ctx, epoch, params.BeaconConfig().DomainRandao[:])
@@ -233,6 +281,11 @@ func (v *validator) signBlock(ctx context.Context, pubKey [48]byte, epoch types.
 	if err != nil {
 		return nil, nil, errors.Wrap(err, signingRootErr)
 	}
+	if v.remoteProtection != nil {
+		if err := v.remoteProtection.CheckBlock(ctx, pubKey, blockRoot, b); err != nil {
+			return nil, nil, errors.Wrap(err, failedPreBlockSignRemoteErr)
+		}
+	}
 	sig, err = v.keyManager.Sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     blockRoot[:],
@@ -290,11 +343,17 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [48]byte) ([]byte, e
 		return v.graffiti, nil
 	}
 
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
 	if v.graffitiStruct == nil {
 		return nil, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
+	// When specified, individual validator specified graffiti takes the second priority,
+	// looked up first by public key and then by validator index.
+	if g, ok := v.graffitiStruct.PublicKeyGraffiti(pubKey); ok {
+		return []byte(g), nil
+	}
 	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
 	if err != nil {
 		return []byte{}, err
@@ -14,6 +14,7 @@ import (
 
 var failedPreBlockSignLocalErr = "attempted to sign a double proposal, block rejected by local protection"
 var failedPreBlockSignExternalErr = "attempted a double proposal, block rejected by remote slashing protection"
+var failedPreBlockSignRemoteErr = "block rejected by remote slashing protection service before signing"
 var failedPostBlockSignErr = "made a double proposal, considered slashable by remote slashing protection"
 
 func (v *validator) preBlockSignValidations(
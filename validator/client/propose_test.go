@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +15,7 @@ import (
 	"github.com/golang/mock/gomock"
 	lru "github.com/hashicorp/golang-lru"
 	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/ethereumapis/eth/v1"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
 	"github.com/prysmaticlabs/prysm/shared/bls"
@@ -23,7 +27,9 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	testing2 "github.com/prysmaticlabs/prysm/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/remote"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
 )
 
 type mocks struct {
@@ -560,6 +566,45 @@ func TestProposeExit_DomainDataIsNil(t *testing.T) {
 	assert.ErrorContains(t, "failed to sign voluntary exit", err)
 }
 
+type mockV1BeaconChainClient struct {
+	ethpbv1.BeaconChainClient
+	exits []*ethpbv1.SignedVoluntaryExit
+}
+
+func (m *mockV1BeaconChainClient) SubmitVoluntaryExit(
+	_ context.Context, exit *ethpbv1.SignedVoluntaryExit, _ ...grpc.CallOption,
+) (*ptypes.Empty, error) {
+	m.exits = append(m.exits, exit)
+	return &ptypes.Empty{}, nil
+}
+
+func TestSubmitExit_OK(t *testing.T) {
+	_, m, validatorKey, finish := setup(t)
+	defer finish()
+	beaconClient := &mockV1BeaconChainClient{}
+
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), gomock.Any()).
+		Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil)
+
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), gomock.Any()).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	err := SubmitExit(
+		context.Background(),
+		m.validatorClient,
+		beaconClient,
+		m.signExitFunc,
+		validatorKey.PublicKey().Marshal(),
+		5,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(beaconClient.exits))
+	assert.Equal(t, types.Epoch(5), beaconClient.exits[0].Exit.Epoch)
+	assert.Equal(t, types.ValidatorIndex(1), beaconClient.exits[0].Exit.ValidatorIndex)
+}
+
 func TestProposeBlock_ProposeExitFailed(t *testing.T) {
 	_, m, validatorKey, finish := setup(t)
 	defer finish()
@@ -664,6 +709,35 @@ func TestSignBlock(t *testing.T) {
 	require.DeepEqual(t, proposerDomain, domain.SignatureDomain)
 }
 
+func TestSignBlock_RemoteProtection(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	allow := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allow {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+	var err error
+	validator.remoteProtection, err = remote.NewClient(&remote.Config{URL: srv.URL, Timeout: time.Second})
+	require.NoError(t, err)
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), gomock.Any()).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil).AnyTimes()
+	var pubKey [48]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = 1
+
+	_, _, err = validator.signBlock(context.Background(), pubKey, 0, blk.Block)
+	assert.ErrorContains(t, failedPreBlockSignRemoteErr, err)
+	allow = true
+	_, _, err = validator.signBlock(context.Background(), pubKey, 0, blk.Block)
+	require.NoError(t, err)
+}
+
 func TestGetGraffiti_Ok(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := &mocks{
@@ -722,6 +796,21 @@ func TestGetGraffiti_Ok(t *testing.T) {
 			},
 			want: []byte{'g'},
 		},
+		{name: "use public key file graffiti",
+			v: &validator{
+				validatorClient: m.validatorClient,
+				graffitiStruct: &graffiti.Graffiti{
+					Default: "c",
+					Specific: map[types.ValidatorIndex]string{
+						2: "g",
+					},
+					PublicKeys: map[string]string{
+						fmt.Sprintf("%x", pubKey): "h",
+					},
+				},
+			},
+			want: []byte{'h'},
+		},
 		{name: "use validator file graffiti, none specified",
 			v: &validator{
 				validatorClient: m.validatorClient,
@@ -733,7 +822,7 @@ func TestGetGraffiti_Ok(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if !strings.Contains(tt.name, "use default cli graffiti") {
+			if !strings.Contains(tt.name, "use default cli graffiti") && !strings.Contains(tt.name, "use public key file graffiti") {
 				m.validatorClient.EXPECT().
 					ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
 					Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
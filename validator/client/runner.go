@@ -45,6 +45,7 @@ type Validator interface {
 	AllValidatorsAreExited(ctx context.Context) (bool, error)
 	GetKeymanager() keymanager.IKeymanager
 	ReceiveBlocks(ctx context.Context, connectionErrorChannel chan<- error)
+	CheckDoppelgangers(ctx context.Context) error
 }
 
 // Run the main validator routine. This routine exits if the context is
@@ -53,10 +54,11 @@ type Validator interface {
 // Order of operations:
 // 1 - Initialize validator data
 // 2 - Wait for validator activation
-// 3 - Wait for the next slot start
-// 4 - Update assignments
-// 5 - Determine role at current slot
-// 6 - Perform assigned role, if any
+// 3 - Check the network for doppelganger validators
+// 4 - Wait for the next slot start
+// 5 - Update assignments
+// 6 - Determine role at current slot
+// 7 - Perform assigned role, if any
 func run(ctx context.Context, v Validator) {
 	cleanup := v.Done
 	defer cleanup()
@@ -120,6 +122,13 @@ func run(ctx context.Context, v Validator) {
 		}
 		break
 	}
+	if err := v.CheckDoppelgangers(ctx); err != nil {
+		if ctx.Err() != nil {
+			log.Info("Context canceled, stopping validator")
+			return
+		}
+		log.Fatalf("Refusing to start validator duties: %v", err)
+	}
 
 	go handleAccountsChanged(ctx, v, accountsChangedChan)
 	connectionErrorChannel := make(chan error, 1)
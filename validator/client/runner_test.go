@@ -59,6 +59,12 @@ func TestCancelledContext_WaitsForActivation(t *testing.T) {
 	assert.Equal(t, 1, v.WaitForActivationCalled, "Expected WaitForActivation() to be called")
 }
 
+func TestCancelledContext_ChecksDoppelgangers(t *testing.T) {
+	v := &FakeValidator{Keymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
+	run(cancelledContext(), v)
+	assert.Equal(t, true, v.CheckDoppelgangersCalled, "Expected CheckDoppelgangers() to be called")
+}
+
 func TestCancelledContext_ChecksSlasherReady(t *testing.T) {
 	v := &FakeValidator{Keymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
 	cfg := &featureconfig.Flags{
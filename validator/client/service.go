@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/grpcutils"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/tlsutil"
 	accountsiface "github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/validator/db"
@@ -27,9 +28,9 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
 	"github.com/prysmaticlabs/prysm/validator/slashing-protection/iface"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/remote"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 // SyncChecker is able to determine if a beacon node is currently
@@ -51,7 +52,9 @@ type ValidatorService struct {
 	emitAccountMetrics    bool
 	logValidatorBalances  bool
 	logDutyCountDown      bool
+	doppelgangerEpochs    uint64
 	conn                  *grpc.ClientConn
+	endpointChecker       *beaconNodeChecker
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -60,14 +63,18 @@ type ValidatorService struct {
 	db                    db.Database
 	dataDir               string
 	withCert              string
+	withClientCert        string
+	withClientKey         string
 	endpoint              string
 	validator             Validator
 	protector             iface.Protector
+	remoteProtection      *remote.Client
 	ctx                   context.Context
 	keyManager            keymanager.IKeymanager
 	grpcHeaders           []string
 	graffiti              []byte
 	graffitiStruct        *graffiti.Graffiti
+	graffitiFile          string
 }
 
 // Config for the validator service.
@@ -81,15 +88,20 @@ type Config struct {
 	GrpcRetryDelay             time.Duration
 	GrpcMaxCallRecvMsgSizeFlag int
 	Protector                  iface.Protector
+	RemoteProtection           *remote.Client
 	Endpoint                   string
+	DoppelgangerEpochs         uint64
 	Validator                  Validator
 	ValDB                      db.Database
 	KeyManager                 keymanager.IKeymanager
 	GraffitiFlag               string
 	CertFlag                   string
+	ClientCertFlag             string
+	ClientKeyFlag              string
 	DataDir                    string
 	GrpcHeadersFlag            string
 	GraffitiStruct             *graffiti.Graffiti
+	GraffitiFile               string
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -101,6 +113,8 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		cancel:                cancel,
 		endpoint:              cfg.Endpoint,
 		withCert:              cfg.CertFlag,
+		withClientCert:        cfg.ClientCertFlag,
+		withClientKey:         cfg.ClientKeyFlag,
 		dataDir:               cfg.DataDir,
 		graffiti:              []byte(cfg.GraffitiFlag),
 		keyManager:            cfg.KeyManager,
@@ -111,12 +125,15 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		grpcRetryDelay:        cfg.GrpcRetryDelay,
 		grpcHeaders:           strings.Split(cfg.GrpcHeadersFlag, ","),
 		protector:             cfg.Protector,
+		remoteProtection:      cfg.RemoteProtection,
 		validator:             cfg.Validator,
 		db:                    cfg.ValDB,
 		walletInitializedFeed: cfg.WalletInitializedFeed,
 		useWeb:                cfg.UseWeb,
 		graffitiStruct:        cfg.GraffitiStruct,
+		graffitiFile:          cfg.GraffitiFile,
 		logDutyCountDown:      cfg.LogDutyCountDown,
+		doppelgangerEpochs:    cfg.DoppelgangerEpochs,
 	}, nil
 }
 
@@ -131,6 +148,8 @@ func (v *ValidatorService) Start() {
 	dialOpts := ConstructDialOptions(
 		v.maxCallRecvMsgSize,
 		v.withCert,
+		v.withClientCert,
+		v.withClientKey,
 		v.grpcRetries,
 		v.grpcRetryDelay,
 		streamInterceptor,
@@ -138,6 +157,14 @@ func (v *ValidatorService) Start() {
 	if dialOpts == nil {
 		return
 	}
+	if strings.Contains(v.endpoint, ",") {
+		// The failover resolver is placed first so it takes precedence over the default
+		// multiple endpoints resolver, which is still used by the checker's own connections.
+		v.endpointChecker = newBeaconNodeChecker(dialOpts)
+		dialOpts = append([]grpc.DialOption{
+			grpc.WithResolvers(&multipleEndpointsGrpcResolverBuilder{checker: v.endpointChecker}),
+		}, dialOpts...)
+	}
 
 	v.ctx = grpcutils.AppendHeaders(v.ctx, v.grpcHeaders)
 
@@ -182,7 +209,7 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
-	v.validator = &validator{
+	val := &validator{
 		db:                             v.db,
 		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
 		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
@@ -197,6 +224,7 @@ func (v *ValidatorService) Start() {
 		domainDataCache:                cache,
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
 		protector:                      v.protector,
+		remoteProtection:               v.remoteProtection,
 		voteStats:                      voteStats{startEpoch: types.Epoch(^uint64(0))},
 		useWeb:                         v.useWeb,
 		walletInitializedFeed:          v.walletInitializedFeed,
@@ -205,6 +233,12 @@ func (v *ValidatorService) Start() {
 		graffitiOrderedIndex:           graffitiOrderedIndex,
 		eipImportBlacklistedPublicKeys: slashablePublicKeys,
 		logDutyCountDown:               v.logDutyCountDown,
+		doppelgangerEpochs:             v.doppelgangerEpochs,
+		livenessClient:                 &grpcLivenessClient{conn: v.conn},
+	}
+	v.validator = val
+	if v.graffitiFile != "" {
+		go val.listenForGraffitiFileChanges(v.ctx, v.graffitiFile)
 	}
 	go run(v.ctx, v.validator)
 	go v.recheckKeys(v.ctx)
@@ -214,6 +248,9 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	if v.endpointChecker != nil {
+		v.endpointChecker.close()
+	}
 	if v.conn != nil {
 		return v.conn.Close()
 	}
@@ -264,13 +301,15 @@ func (v *ValidatorService) recheckKeys(ctx context.Context) {
 func ConstructDialOptions(
 	maxCallRecvMsgSize int,
 	withCert string,
+	withClientCert string,
+	withClientKey string,
 	grpcRetries uint,
 	grpcRetryDelay time.Duration,
 	extraOpts ...grpc.DialOption,
 ) []grpc.DialOption {
 	var transportSecurity grpc.DialOption
 	if withCert != "" {
-		creds, err := credentials.NewClientTLSFromFile(withCert, "")
+		creds, err := tlsutil.ClientCredentials(withCert, withClientCert, withClientKey)
 		if err != nil {
 			log.Errorf("Could not get valid credentials: %v", err)
 			return nil
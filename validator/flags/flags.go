@@ -29,7 +29,7 @@ var (
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Usage: "Beacon node RPC provider endpoint. Accepts a comma-separated list of endpoints to fail over between, in order of preference",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -43,6 +43,17 @@ var (
 		Name:  "tls-cert",
 		Usage: "Certificate for secure gRPC. Pass this and the tls-key flag in order to use gRPC securely.",
 	}
+	// ClientCertFlag defines a flag for the TLS certificate the validator client presents to the beacon node.
+	ClientCertFlag = &cli.StringFlag{
+		Name: "tls-client-cert",
+		Usage: "Client certificate presented to the beacon node for mutual TLS. Pass this and the tls-client-key flag " +
+			"when the beacon node is run with --tls-client-ca. The certificate is reloaded when its files change.",
+	}
+	// ClientKeyFlag defines a flag for the key of the TLS certificate the validator client presents to the beacon node.
+	ClientKeyFlag = &cli.StringFlag{
+		Name:  "tls-client-key",
+		Usage: "Key of the client certificate presented to the beacon node for mutual TLS.",
+	}
 	// EnableRPCFlag enables controlling the validator client via gRPC (without web UI).
 	EnableRPCFlag = &cli.BoolFlag{
 		Name:  "rpc",
@@ -226,6 +237,17 @@ var (
 		Name:  "exit-all",
 		Usage: "Exit all validators. This will still require the staker to confirm a prompt for the action",
 	}
+	// VoluntaryExitEpochFlag overrides the epoch at which voluntary exits are signed, which
+	// otherwise defaults to the current epoch.
+	VoluntaryExitEpochFlag = &cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "The epoch at which to sign the voluntary exit. Defaults to the current epoch and may not be in the future",
+	}
+	// VoluntaryExitDryRunFlag reports the voluntary exits that would be performed without submitting them.
+	VoluntaryExitDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Report the validator accounts that would be exited without signing or submitting any voluntary exits",
+	}
 	// BackupPasswordFile for encrypting accounts a user wishes to back up.
 	BackupPasswordFile = &cli.StringFlag{
 		Name:  "backup-password-file",
@@ -309,7 +331,7 @@ var (
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
 		Name:  "graffiti-file",
-		Usage: "The path to a YAML file with graffiti values",
+		Usage: "The path to a YAML file with graffiti values, which is reloaded when changed",
 	}
 	// EnableDutyCountDown enables more verbose logging for counting down to duty.
 	EnableDutyCountDown = &cli.BoolFlag{
@@ -317,6 +339,66 @@ var (
 		Usage: "Enables more verbose logging for counting down to duty",
 		Value: false,
 	}
+	// DoppelgangerEpochsFlag defines how many epochs the validator client watches the network for its
+	// keys attesting elsewhere before starting duties.
+	DoppelgangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-detection-epochs",
+		Usage: "Number of epochs to watch the network for the validator keys attesting elsewhere before " +
+			"starting duties, refusing to start if they do. 0 disables the check",
+		Value: 0,
+	}
+	// Web3SignerURLFlag defines the URL of a Web3Signer instance to sign with, in which case
+	// validating keys are fetched from it instead of from a wallet.
+	Web3SignerURLFlag = &cli.StringFlag{
+		Name:  "validators-external-signer-url",
+		Usage: "URL of a Web3Signer instance holding the validating keys, used instead of a wallet",
+		Value: "",
+	}
+	// Web3SignerTLSCACertFlag defines the path to a ca.crt file used to verify a Web3Signer instance.
+	Web3SignerTLSCACertFlag = &cli.StringFlag{
+		Name:  "validators-external-signer-tls-ca-cert",
+		Usage: "/path/to/ca.crt for verifying the TLS certificate of the Web3Signer instance",
+		Value: "",
+	}
+	// Web3SignerTLSClientCertFlag defines the path to a client.crt file presented to a Web3Signer instance.
+	Web3SignerTLSClientCertFlag = &cli.StringFlag{
+		Name:  "validators-external-signer-tls-client-cert",
+		Usage: "/path/to/client.crt for authenticating to the Web3Signer instance via TLS",
+		Value: "",
+	}
+	// Web3SignerTLSClientKeyFlag defines the path to a client.key file presented to a Web3Signer instance.
+	Web3SignerTLSClientKeyFlag = &cli.StringFlag{
+		Name:  "validators-external-signer-tls-client-key",
+		Usage: "/path/to/client.key for authenticating to the Web3Signer instance via TLS",
+		Value: "",
+	}
+	// Web3SignerKeysRefreshIntervalFlag defines how often the keys held by a Web3Signer instance are refreshed.
+	Web3SignerKeysRefreshIntervalFlag = &cli.DurationFlag{
+		Name:  "validators-external-signer-keys-refresh-interval",
+		Usage: "The amount of time between refreshes of the public keys held by the Web3Signer instance",
+		Value: 1 * time.Minute,
+	}
+	// RemoteSlashingProtectionURLFlag defines the URL of a remote slashing protection service
+	// consulted before signing attestations and blocks.
+	RemoteSlashingProtectionURLFlag = &cli.StringFlag{
+		Name:  "remote-slashing-protection-url",
+		Usage: "URL of a remote slashing protection service which must allow attestations and blocks before they are signed",
+		Value: "",
+	}
+	// RemoteSlashingProtectionTimeoutFlag defines how long to wait for the remote slashing protection
+	// service to allow a signing request.
+	RemoteSlashingProtectionTimeoutFlag = &cli.DurationFlag{
+		Name:  "remote-slashing-protection-timeout",
+		Usage: "The amount of time to wait for a decision of the remote slashing protection service",
+		Value: 1 * time.Second,
+	}
+	// RemoteSlashingProtectionFailOpenFlag allows signing when the remote slashing protection service
+	// cannot be reached or times out.
+	RemoteSlashingProtectionFailOpenFlag = &cli.BoolFlag{
+		Name: "remote-slashing-protection-fail-open",
+		Usage: "Signs attestations and blocks when the remote slashing protection service is unavailable, " +
+			"instead of refusing to sign them. Rejections by the service are always enforced",
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
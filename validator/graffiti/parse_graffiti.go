@@ -1,7 +1,9 @@
 package graffiti
 
 import (
+	"fmt"
 	"io/ioutil"
+	"strings"
 
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
@@ -9,11 +11,12 @@ import (
 )
 
 type Graffiti struct {
-	Hash     [32]byte
-	Default  string                          `yaml:"default,omitempty"`
-	Ordered  []string                        `yaml:"ordered,omitempty"`
-	Random   []string                        `yaml:"random,omitempty"`
-	Specific map[types.ValidatorIndex]string `yaml:"specific,omitempty"`
+	Hash       [32]byte
+	Default    string                          `yaml:"default,omitempty"`
+	Ordered    []string                        `yaml:"ordered,omitempty"`
+	Random     []string                        `yaml:"random,omitempty"`
+	Specific   map[types.ValidatorIndex]string `yaml:"specific,omitempty"`
+	PublicKeys map[string]string               `yaml:"public_keys,omitempty"`
 }
 
 // ParseGraffitiFile parses the graffiti file and returns the graffiti struct.
@@ -26,6 +29,20 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 	if err := yaml.Unmarshal(yamlFile, g); err != nil {
 		return nil, err
 	}
+	// Public keys are matched case-insensitively and with or without the 0x prefix.
+	if g.PublicKeys != nil {
+		publicKeys := make(map[string]string, len(g.PublicKeys))
+		for k, v := range g.PublicKeys {
+			publicKeys[strings.TrimPrefix(strings.ToLower(k), "0x")] = v
+		}
+		g.PublicKeys = publicKeys
+	}
 	g.Hash = hashutil.Hash(yamlFile)
 	return g, nil
 }
+
+// PublicKeyGraffiti returns the graffiti specified for a validator public key, if any.
+func (g *Graffiti) PublicKeyGraffiti(pubKey [48]byte) (string, bool) {
+	s, ok := g.PublicKeys[fmt.Sprintf("%x", pubKey)]
+	return s, ok
+}
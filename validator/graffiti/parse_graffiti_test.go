@@ -1,6 +1,7 @@
 package graffiti
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -109,6 +110,35 @@ specific:
 	require.DeepEqual(t, wanted, got)
 }
 
+func TestParseGraffitiFile_PublicKeys(t *testing.T) {
+	pubKey := [48]byte{'a'}
+	input := []byte(fmt.Sprintf(`
+public_keys:
+  "%#X": Yolo`, pubKey))
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, ioutil.WriteFile(someFileName, input, os.ModePerm))
+
+	got, err := ParseGraffitiFile(someFileName)
+	require.NoError(t, err)
+
+	wanted := &Graffiti{
+		Hash: hashutil.Hash(input),
+		PublicKeys: map[string]string{
+			fmt.Sprintf("%x", pubKey): "Yolo",
+		},
+	}
+	require.DeepEqual(t, wanted, got)
+	g, ok := got.PublicKeyGraffiti(pubKey)
+	require.Equal(t, true, ok)
+	require.Equal(t, "Yolo", g)
+	_, ok = got.PublicKeyGraffiti([48]byte{'b'})
+	require.Equal(t, false, ok)
+}
+
 func TestParseGraffitiFile_AllFields(t *testing.T) {
 	input := []byte(`default: "Mr T was here"
 
@@ -2,7 +2,9 @@ package derived
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
@@ -21,6 +23,9 @@ const (
 	// keys for Prysm eth2 validators. According to EIP-2334, the format is as follows:
 	// m / purpose / coin_type / account_index / withdrawal_key / validating_key
 	ValidatingKeyDerivationPathTemplate = "m/12381/3600/%d/0/0"
+	// derivationPathsFileName is the wallet file recording the derivation path of each validating
+	// key recovered from the mnemonic, by hex encoded public key.
+	derivationPathsFileName = "derivation-paths.json"
 )
 
 // SetupConfig includes configuration values for initializing
@@ -32,6 +37,7 @@ type SetupConfig struct {
 
 // Keymanager implementation for derived, HD keymanager using EIP-2333 and EIP-2334.
 type Keymanager struct {
+	wallet     iface.Wallet
 	importedKM *imported.Keymanager
 }
 
@@ -48,6 +54,7 @@ func NewKeymanager(
 		return nil, err
 	}
 	return &Keymanager{
+		wallet:     cfg.Wallet,
 		importedKM: importedKM,
 	}, nil
 }
@@ -62,19 +69,49 @@ func (km *Keymanager) RecoverAccountsFromMnemonic(
 	if err != nil {
 		return errors.Wrap(err, "could not initialize new wallet seed file")
 	}
+	paths, err := km.DerivationPaths(ctx)
+	if err != nil {
+		return err
+	}
 	privKeys := make([][]byte, numAccounts)
 	pubKeys := make([][]byte, numAccounts)
 	for i := 0; i < numAccounts; i++ {
-		privKey, err := util.PrivateKeyFromSeedAndPath(
-			seed, fmt.Sprintf(ValidatingKeyDerivationPathTemplate, i),
-		)
+		path := fmt.Sprintf(ValidatingKeyDerivationPathTemplate, i)
+		privKey, err := util.PrivateKeyFromSeedAndPath(seed, path)
 		if err != nil {
 			return err
 		}
 		privKeys[i] = privKey.Marshal()
 		pubKeys[i] = privKey.PublicKey().Marshal()
+		paths[fmt.Sprintf("%#x", pubKeys[i])] = path
+	}
+	if err := km.importedKM.ImportKeypairs(ctx, privKeys, pubKeys); err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(paths, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal derivation paths")
+	}
+	return km.wallet.WriteFileAtPath(ctx, imported.AccountsPath, derivationPathsFileName, encoded)
+}
+
+// DerivationPaths returns the derivation paths of the validating keys recovered from the mnemonic,
+// by hex encoded public key. Keys are looked up by public key rather than by their position, as
+// deleted and disabled accounts shift the positions of the others. Keys recovered before their
+// paths were recorded in the wallet have no path.
+func (km *Keymanager) DerivationPaths(ctx context.Context) (map[string]string, error) {
+	paths := make(map[string]string)
+	encoded, err := km.wallet.ReadFileAtPath(ctx, imported.AccountsPath, derivationPathsFileName)
+	if err != nil && strings.Contains(err.Error(), "no files found") {
+		// No derivation paths were recorded in the wallet.
+		return paths, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "could not read derivation paths file %s", derivationPathsFileName)
+	}
+	if err := json.Unmarshal(encoded, &paths); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal derivation paths")
 	}
-	return km.importedKM.ImportKeypairs(ctx, privKeys, pubKeys)
+	return paths, nil
 }
 
 // ExtractKeystores retrieves the secret keys for specified public keys
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
 	"github.com/tyler-smith/go-bip39"
 	util "github.com/wealdtech/go-eth2-util"
 )
@@ -111,6 +112,59 @@ func TestDerivedKeymanager_FetchValidatingPublicKeys(t *testing.T) {
 	}
 }
 
+func TestDerivedKeymanager_DerivationPaths(t *testing.T) {
+	sampleMnemonic := "tumble turn jewel sudden social great water general cabin jacket bounce dry flip monster advance problem social half flee inform century chicken hard reason"
+	wallet := &mock.Wallet{
+		Files:            make(map[string]map[string][]byte),
+		AccountPasswords: make(map[string]string),
+		WalletPassword:   "secretPassw0rd$1999",
+	}
+	ctx := context.Background()
+	dr, err := NewKeymanager(ctx, &SetupConfig{
+		Wallet:           wallet,
+		ListenForChanges: false,
+	})
+	require.NoError(t, err)
+	paths, err := dr.DerivationPaths(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(paths))
+
+	numAccounts := 3
+	require.NoError(t, dr.RecoverAccountsFromMnemonic(ctx, sampleMnemonic, "", numAccounts))
+	publicKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, numAccounts, len(publicKeys))
+
+	// Deleting the first account shifts the others, which keep their derivation paths.
+	require.NoError(t, dr.DeleteAccounts(ctx, [][]byte{publicKeys[0][:]}))
+	remaining, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, numAccounts-1, len(remaining))
+	paths, err = dr.DerivationPaths(ctx)
+	require.NoError(t, err)
+	for i, key := range remaining {
+		assert.Equal(t, fmt.Sprintf(ValidatingKeyDerivationPathTemplate, i+1), paths[fmt.Sprintf("%#x", key)])
+	}
+}
+
+func TestDerivedKeymanager_DerivationPaths_Corrupted(t *testing.T) {
+	wallet := &mock.Wallet{
+		Files: map[string]map[string][]byte{
+			imported.AccountsPath: {derivationPathsFileName: []byte("corrupted")},
+		},
+		AccountPasswords: make(map[string]string),
+		WalletPassword:   "secretPassw0rd$1999",
+	}
+	ctx := context.Background()
+	dr, err := NewKeymanager(ctx, &SetupConfig{
+		Wallet:           wallet,
+		ListenForChanges: false,
+	})
+	require.NoError(t, err)
+	_, err = dr.DerivationPaths(ctx)
+	assert.ErrorContains(t, "could not unmarshal derivation paths", err)
+}
+
 func TestDerivedKeymanager_FetchValidatingPrivateKeys(t *testing.T) {
 	sampleMnemonic := "tumble turn jewel sudden social great water general cabin jacket bounce dry flip monster advance problem social half flee inform century chicken hard reason"
 	derivedSeed, err := seedFromMnemonic(sampleMnemonic, "")
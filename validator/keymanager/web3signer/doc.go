@@ -0,0 +1,31 @@
+/*
+Package web3signer defines a keymanager implementation which signs via the Web3Signer
+REST API, so that validating keys never need to be present on the validator host.
+
+The keymanager lists its validating public keys from the signer with
+
+	GET /api/v1/eth2/publicKeys
+
+and refreshes them periodically, notifying subscribers of account changes whenever the
+set of keys held by the signer changes. Signing requests are sent as
+
+	POST /api/v1/eth2/sign/{public key}
+
+with a JSON body carrying the type of the request, the fork info of the chain, the
+signing root computed by the validator client, and the object being signed:
+
+	{
+	  "type": "ATTESTATION",
+	  "fork_info": {
+	    "fork": {"previous_version": "0x00000000", "current_version": "0x00000000", "epoch": "0"},
+	    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	  },
+	  "signingRoot": "0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69",
+	  "attestation": {...}
+	}
+
+Blocks, attestations, aggregate and proofs, voluntary exits, randao reveals and aggregation
+slot selection proofs are supported. Connections to the signer may use TLS with a custom
+certificate authority, as well as a client certificate for mutual authentication.
+*/
+package web3signer
@@ -0,0 +1,290 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
+)
+
+const (
+	publicKeysPath = "/api/v1/eth2/publicKeys"
+	signPath       = "/api/v1/eth2/sign/"
+	// maxResponseSize bounds the size of the responses read from the signer.
+	maxResponseSize = 1 << 20
+	requestTimeout  = 10 * time.Second
+)
+
+var (
+	// ErrSigningFailed defines a failure from the remote signer
+	// when performing a signing operation.
+	ErrSigningFailed = errors.New("signing failed in the remote signer")
+	// ErrSigningDenied defines a failure from the remote signer when
+	// a signing operation was denied by its slashing protection.
+	ErrSigningDenied = errors.New("signing request was denied by remote signer")
+	// ErrUnknownPublicKey defines a failure from the remote signer when
+	// it does not hold the key requested to sign.
+	ErrUnknownPublicKey = errors.New("public key not found in remote signer")
+)
+
+// GenesisValidatorsRootFetcher retrieves the genesis validators root of the chain being
+// validated, which signing requests carry as part of their fork info.
+type GenesisValidatorsRootFetcher interface {
+	GenesisValidatorsRoot(ctx context.Context) ([]byte, error)
+}
+
+// SetupConfig includes configuration values for initializing
+// a Web3Signer keymanager.
+type SetupConfig struct {
+	BaseEndpoint                 string
+	CACertPath                   string
+	ClientCertPath               string
+	ClientKeyPath                string
+	KeysRefreshInterval          time.Duration
+	GenesisValidatorsRootFetcher GenesisValidatorsRootFetcher
+}
+
+// Keymanager implementation signing with keys held by a Web3Signer instance.
+type Keymanager struct {
+	client              *http.Client
+	baseEndpoint        string
+	gvrFetcher          GenesisValidatorsRootFetcher
+	accountsChangedFeed *event.Feed
+	keysLock            sync.RWMutex
+	keys                [][48]byte
+}
+
+// NewKeymanager instantiates a new Web3Signer keymanager from configuration options. The keys
+// held by the signer are fetched once on startup and, if a refresh interval is configured,
+// periodically thereafter until the context is canceled.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	if _, err := url.ParseRequestURI(cfg.BaseEndpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid remote signer URL %s", cfg.BaseEndpoint)
+	}
+	if cfg.GenesisValidatorsRootFetcher == nil {
+		return nil, errors.New("no genesis validators root fetcher specified")
+	}
+	tlsCfg, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	km := &Keymanager{
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsCfg},
+		},
+		baseEndpoint:        strings.TrimSuffix(cfg.BaseEndpoint, "/"),
+		gvrFetcher:          cfg.GenesisValidatorsRootFetcher,
+		accountsChangedFeed: new(event.Feed),
+	}
+	if _, err := km.refreshKeys(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not fetch public keys from remote signer")
+	}
+	if cfg.KeysRefreshInterval > 0 {
+		go km.refreshKeysPeriodically(ctx, cfg.KeysRefreshInterval)
+	}
+	return km, nil
+}
+
+// tlsConfig loads the certificate authority used to verify the signer, as well as the client
+// certificate presented to it. It returns nil when neither is configured.
+func tlsConfig(cfg *SetupConfig) (*tls.Config, error) {
+	if cfg.CACertPath == "" && cfg.ClientCertPath == "" && cfg.ClientKeyPath == "" {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.CACertPath != "" {
+		caCert, err := ioutil.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain remote signer's CA certificate")
+		}
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to add remote signer's CA certificate to pool")
+		}
+		tlsCfg.RootCAs = cp
+	}
+	if cfg.ClientCertPath != "" || cfg.ClientKeyPath != "" {
+		if cfg.ClientCertPath == "" || cfg.ClientKeyPath == "" {
+			return nil, errors.New("both a client certificate and a client key are required")
+		}
+		clientPair, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain client's certificate and/or key")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientPair}
+	}
+	return tlsCfg, nil
+}
+
+// FetchValidatingPublicKeys fetches the list of public keys held by the remote signer.
+func (km *Keymanager) FetchValidatingPublicKeys(_ context.Context) ([][48]byte, error) {
+	km.keysLock.RLock()
+	defer km.keysLock.RUnlock()
+	keys := make([][48]byte, len(km.keys))
+	copy(keys, km.keys)
+	return keys, nil
+}
+
+// FetchAllValidatingPublicKeys fetches the list of all public keys, including disabled ones.
+func (km *Keymanager) FetchAllValidatingPublicKeys(ctx context.Context) ([][48]byte, error) {
+	return km.FetchValidatingPublicKeys(ctx)
+}
+
+// Sign signs a message for a validator key via a request to the remote signer.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	gvr, err := km.gvrFetcher.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis validators root")
+	}
+	if len(gvr) == 0 {
+		return nil, errors.New("genesis validators root is not known yet")
+	}
+	signReq, err := newSignRequest(req, gvr)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(signReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal sign request")
+	}
+	endpoint := km.baseEndpoint + signPath + hexutil.Encode(req.PublicKey)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	resp, err := km.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send sign request to remote signer")
+	}
+	defer closeBody(resp)
+	enc, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read sign response")
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrUnknownPublicKey
+	case http.StatusPreconditionFailed:
+		return nil, ErrSigningDenied
+	default:
+		return nil, errors.Wrapf(ErrSigningFailed, "status %d: %s", resp.StatusCode, strings.TrimSpace(string(enc)))
+	}
+	// Web3Signer responds with a JSON object when requested, but older versions
+	// respond with the hex encoded signature as plain text.
+	sigHex := strings.TrimSpace(string(enc))
+	signResp := &signResponseJSON{}
+	if err := json.Unmarshal(enc, signResp); err == nil {
+		sigHex = signResp.Signature
+	}
+	sig, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature from remote signer")
+	}
+	return bls.SignatureFromBytes(sig)
+}
+
+// SubscribeAccountChanges creates an event subscription for a channel
+// to listen for changes in the public keys held by the remote signer.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][48]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+func (km *Keymanager) refreshKeysPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := km.refreshKeys(ctx)
+			if err != nil {
+				log.WithError(err).Error("Could not refresh public keys from remote signer")
+				continue
+			}
+			if changed {
+				keys, err := km.FetchValidatingPublicKeys(ctx)
+				if err != nil {
+					log.WithError(err).Error("Could not fetch validating public keys")
+					continue
+				}
+				log.WithField("numKeys", len(keys)).Info("Public keys held by remote signer changed")
+				km.accountsChangedFeed.Send(keys)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshKeys fetches the public keys held by the remote signer, returning whether they
+// differ from the ones known so far.
+func (km *Keymanager) refreshKeys(ctx context.Context) (bool, error) {
+	keys, err := km.remotePublicKeys(ctx)
+	if err != nil {
+		return false, err
+	}
+	km.keysLock.Lock()
+	defer km.keysLock.Unlock()
+	changed := len(keys) != len(km.keys)
+	for i := 0; !changed && i < len(keys); i++ {
+		changed = keys[i] != km.keys[i]
+	}
+	km.keys = keys
+	return changed, nil
+}
+
+func (km *Keymanager) remotePublicKeys(ctx context.Context) ([][48]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, km.baseEndpoint+publicKeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	resp, err := km.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from remote signer", resp.StatusCode)
+	}
+	var keysHex []string
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&keysHex); err != nil {
+		return nil, errors.Wrap(err, "could not decode public keys")
+	}
+	keys := make([][48]byte, len(keysHex))
+	for i, keyHex := range keysHex {
+		key, err := hexutil.Decode(keyHex)
+		if err != nil || len(key) != 48 {
+			return nil, fmt.Errorf("%s is not a valid BLS public key", keyHex)
+		}
+		keys[i] = bytesutil.ToBytes48(key)
+	}
+	return keys, nil
+}
+
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		log.WithError(err).Debug("Could not close response body")
+	}
+}
@@ -0,0 +1,349 @@
+package web3signer
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+type mockGenesisFetcher struct {
+	root []byte
+}
+
+func (m *mockGenesisFetcher) GenesisValidatorsRoot(_ context.Context) ([]byte, error) {
+	return m.root, nil
+}
+
+// mockSigner is a Web3Signer stand-in holding BLS secret keys.
+type mockSigner struct {
+	sync.Mutex
+	keys     map[string]bls.SecretKey
+	requests []map[string]interface{}
+}
+
+func newMockSigner(t *testing.T, numKeys int) *mockSigner {
+	s := &mockSigner{keys: make(map[string]bls.SecretKey)}
+	for i := 0; i < numKeys; i++ {
+		s.addKey(t)
+	}
+	return s
+}
+
+func (s *mockSigner) addKey(t *testing.T) bls.SecretKey {
+	secretKey, err := bls.RandKey()
+	require.NoError(t, err)
+	s.Lock()
+	defer s.Unlock()
+	s.keys[hexutil.Encode(secretKey.PublicKey().Marshal())] = secretKey
+	return secretKey
+}
+
+func (s *mockSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	if r.Method == http.MethodGet && r.URL.Path == publicKeysPath {
+		keys := make([]string, 0, len(s.keys))
+		for key := range s.keys {
+			keys = append(keys, key)
+		}
+		if err := json.NewEncoder(w).Encode(keys); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, signPath) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	secretKey, ok := s.keys[strings.TrimPrefix(r.URL.Path, signPath)]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	req := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.requests = append(s.requests, req)
+	root, err := hexutil.Decode(req["signingRoot"].(string))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if req["type"] == attestationType {
+		// Plain text responses are served by older versions of Web3Signer.
+		if _, err := w.Write([]byte(hexutil.Encode(secretKey.Sign(root).Marshal()))); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(&signResponseJSON{Signature: hexutil.Encode(secretKey.Sign(root).Marshal())}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *mockSigner) lastRequest() map[string]interface{} {
+	s.Lock()
+	defer s.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func TestNewKeymanager_BadConfig(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:                 "not a url",
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	assert.ErrorContains(t, "invalid remote signer URL", err)
+
+	_, err = NewKeymanager(ctx, &SetupConfig{BaseEndpoint: "http://localhost:9000"})
+	assert.ErrorContains(t, "no genesis validators root fetcher", err)
+
+	_, err = NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:                 "http://localhost:9000",
+		ClientCertPath:               "client.crt",
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	assert.ErrorContains(t, "both a client certificate and a client key are required", err)
+}
+
+func TestKeymanager_FetchValidatingPublicKeys(t *testing.T) {
+	signer := newMockSigner(t, 3)
+	srv := httptest.NewServer(signer)
+	defer srv.Close()
+
+	km, err := NewKeymanager(context.Background(), &SetupConfig{
+		BaseEndpoint:                 srv.URL,
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, len(keys))
+	for _, key := range keys {
+		_, ok := signer.keys[hexutil.Encode(key[:])]
+		assert.Equal(t, true, ok)
+	}
+}
+
+func TestKeymanager_RefreshKeys(t *testing.T) {
+	signer := newMockSigner(t, 1)
+	srv := httptest.NewServer(signer)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:                 srv.URL,
+		KeysRefreshInterval:          10 * time.Millisecond,
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	require.NoError(t, err)
+	keysChan := make(chan [][48]byte, 1)
+	sub := km.SubscribeAccountChanges(keysChan)
+	defer sub.Unsubscribe()
+
+	newKey := signer.addKey(t)
+	select {
+	case keys := <-keysChan:
+		require.Equal(t, 2, len(keys))
+		found := false
+		for _, key := range keys {
+			found = found || key == bytesutil.ToBytes48(newKey.PublicKey().Marshal())
+		}
+		assert.Equal(t, true, found)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Did not receive account changes")
+	}
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	signer := newMockSigner(t, 1)
+	srv := httptest.NewServer(signer)
+	defer srv.Close()
+
+	gvr := bytesutil.PadTo([]byte("genesis"), 32)
+	km, err := NewKeymanager(context.Background(), &SetupConfig{
+		BaseEndpoint:                 srv.URL,
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{root: gvr},
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	pubKey := keys[0]
+	secretKey := signer.keys[hexutil.Encode(pubKey[:])]
+
+	block := testutil.NewBeaconBlock().Block
+	block.Slot = 40
+	tests := []struct {
+		name       string
+		object     *validatorpb.SignRequest
+		wantedType string
+		field      string
+	}{
+		{
+			name:       "block",
+			object:     &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Block{Block: block}},
+			wantedType: blockType,
+			field:      "block",
+		},
+		{
+			name: "attestation",
+			object: &validatorpb.SignRequest{Object: &validatorpb.SignRequest_AttestationData{
+				AttestationData: &ethpb.AttestationData{
+					Slot:            1,
+					BeaconBlockRoot: make([]byte, 32),
+					Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+					Target:          &ethpb.Checkpoint{Epoch: 3, Root: make([]byte, 32)},
+				},
+			}},
+			wantedType: attestationType,
+			field:      "attestation",
+		},
+		{
+			name: "aggregate and proof",
+			object: &validatorpb.SignRequest{Object: &validatorpb.SignRequest_AggregateAttestationAndProof{
+				AggregateAttestationAndProof: &ethpb.AggregateAttestationAndProof{
+					AggregatorIndex: 2,
+					Aggregate:       testutil.HydrateAttestation(&ethpb.Attestation{}),
+					SelectionProof:  make([]byte, 96),
+				},
+			}},
+			wantedType: aggregateAndProofType,
+			field:      "aggregate_and_proof",
+		},
+		{
+			name: "voluntary exit",
+			object: &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Exit{
+				Exit: &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 1},
+			}},
+			wantedType: voluntaryExitType,
+			field:      "voluntary_exit",
+		},
+		{
+			name:       "randao reveal",
+			object:     &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Epoch{Epoch: 5}},
+			wantedType: randaoRevealType,
+			field:      "randao_reveal",
+		},
+		{
+			name:       "aggregation slot",
+			object:     &validatorpb.SignRequest{Object: &validatorpb.SignRequest_Slot{Slot: 5}},
+			wantedType: aggregationSlotType,
+			field:      "aggregation_slot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := bytesutil.PadTo([]byte(tt.name), 32)
+			signReq := tt.object
+			signReq.PublicKey = pubKey[:]
+			signReq.SigningRoot = root
+			sig, err := km.Sign(context.Background(), signReq)
+			require.NoError(t, err)
+			assert.DeepEqual(t, secretKey.Sign(root).Marshal(), sig.Marshal())
+
+			req := signer.lastRequest()
+			assert.Equal(t, tt.wantedType, req["type"])
+			assert.Equal(t, hexutil.Encode(root), req["signingRoot"])
+			forkInfo, ok := req["fork_info"].(map[string]interface{})
+			require.Equal(t, true, ok)
+			assert.Equal(t, hexutil.Encode(gvr), forkInfo["genesis_validators_root"])
+			_, ok = req[tt.field].(map[string]interface{})
+			assert.Equal(t, true, ok)
+		})
+	}
+
+	t.Run("block is encoded as standard JSON", func(t *testing.T) {
+		_, err := km.Sign(context.Background(), &validatorpb.SignRequest{
+			PublicKey:   pubKey[:],
+			SigningRoot: make([]byte, 32),
+			Object:      &validatorpb.SignRequest_Block{Block: block},
+		})
+		require.NoError(t, err)
+		encoded := signer.lastRequest()["block"].(map[string]interface{})
+		assert.Equal(t, "40", encoded["slot"])
+		assert.Equal(t, hexutil.Encode(block.ParentRoot), encoded["parent_root"])
+		body := encoded["body"].(map[string]interface{})
+		assert.Equal(t, hexutil.Encode(block.Body.RandaoReveal), body["randao_reveal"])
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		otherKey, err := bls.RandKey()
+		require.NoError(t, err)
+		_, err = km.Sign(context.Background(), &validatorpb.SignRequest{
+			PublicKey:   otherKey.PublicKey().Marshal(),
+			SigningRoot: make([]byte, 32),
+			Object:      &validatorpb.SignRequest_Epoch{Epoch: 1},
+		})
+		assert.ErrorContains(t, ErrUnknownPublicKey.Error(), err)
+	})
+
+	t.Run("unsupported object", func(t *testing.T) {
+		_, err := km.Sign(context.Background(), &validatorpb.SignRequest{
+			PublicKey:   pubKey[:],
+			SigningRoot: make([]byte, 32),
+		})
+		assert.ErrorContains(t, "unsupported sign request object", err)
+	})
+}
+
+func TestKeymanager_Sign_UnknownGenesisValidatorsRoot(t *testing.T) {
+	srv := httptest.NewServer(newMockSigner(t, 1))
+	defer srv.Close()
+
+	km, err := NewKeymanager(context.Background(), &SetupConfig{
+		BaseEndpoint:                 srv.URL,
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	require.NoError(t, err)
+	_, err = km.Sign(context.Background(), &validatorpb.SignRequest{
+		SigningRoot: make([]byte, 32),
+		Object:      &validatorpb.SignRequest_Epoch{Epoch: 1},
+	})
+	assert.ErrorContains(t, "genesis validators root is not known yet", err)
+}
+
+func TestKeymanager_TLS(t *testing.T) {
+	signer := newMockSigner(t, 2)
+	srv := httptest.NewTLSServer(signer)
+	defer srv.Close()
+
+	_, err := NewKeymanager(context.Background(), &SetupConfig{
+		BaseEndpoint:                 srv.URL,
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	assert.ErrorContains(t, "could not fetch public keys from remote signer", err)
+
+	caCertPath := filepath.Join(t.TempDir(), "ca.crt")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caCertPath, caCert, 0600))
+	km, err := NewKeymanager(context.Background(), &SetupConfig{
+		BaseEndpoint:                 srv.URL,
+		CACertPath:                   caCertPath,
+		GenesisValidatorsRootFetcher: &mockGenesisFetcher{},
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(keys), fmt.Sprintf("Unexpected keys %v", keys))
+}
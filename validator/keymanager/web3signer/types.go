@@ -0,0 +1,184 @@
+package web3signer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/p2putils"
+)
+
+// Types of the signing requests accepted by Web3Signer.
+const (
+	blockType             = "BLOCK"
+	attestationType       = "ATTESTATION"
+	aggregateAndProofType = "AGGREGATE_AND_PROOF"
+	voluntaryExitType     = "VOLUNTARY_EXIT"
+	randaoRevealType      = "RANDAO_REVEAL"
+	aggregationSlotType   = "AGGREGATION_SLOT"
+)
+
+// specFieldNames renames the proto fields whose JSON names differ from the standard API.
+var specFieldNames = map[reflect.Type]map[string]string{
+	reflect.TypeOf(ethpb.SignedBeaconBlockHeader{}): {"header": "message"},
+	reflect.TypeOf(ethpb.SignedVoluntaryExit{}):     {"exit": "message"},
+	reflect.TypeOf(ethpb.ProposerSlashing{}):        {"header_1": "signed_header_1", "header_2": "signed_header_2"},
+	reflect.TypeOf(ethpb.Deposit_Data{}):            {"public_key": "pubkey"},
+}
+
+type forkJSON struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+
+type forkInfoJSON struct {
+	Fork                  *forkJSON `json:"fork"`
+	GenesisValidatorsRoot string    `json:"genesis_validators_root"`
+}
+
+type randaoRevealJSON struct {
+	Epoch string `json:"epoch"`
+}
+
+type aggregationSlotJSON struct {
+	Slot string `json:"slot"`
+}
+
+type signRequestJSON struct {
+	Type              string               `json:"type"`
+	ForkInfo          *forkInfoJSON        `json:"fork_info"`
+	SigningRoot       string               `json:"signingRoot"`
+	Block             interface{}          `json:"block,omitempty"`
+	Attestation       interface{}          `json:"attestation,omitempty"`
+	AggregateAndProof interface{}          `json:"aggregate_and_proof,omitempty"`
+	VoluntaryExit     interface{}          `json:"voluntary_exit,omitempty"`
+	RandaoReveal      *randaoRevealJSON    `json:"randao_reveal,omitempty"`
+	AggregationSlot   *aggregationSlotJSON `json:"aggregation_slot,omitempty"`
+}
+
+type signResponseJSON struct {
+	Signature string `json:"signature"`
+}
+
+// newSignRequest builds the Web3Signer signing request for the object of a sign request. The fork
+// info is that of the epoch the object is signed in.
+func newSignRequest(req *validatorpb.SignRequest, genesisValidatorsRoot []byte) (*signRequestJSON, error) {
+	signReq := &signRequestJSON{
+		SigningRoot: hexutil.Encode(req.SigningRoot),
+	}
+	var epoch types.Epoch
+	switch obj := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		if obj.Block == nil {
+			return nil, fmt.Errorf("nil block in sign request")
+		}
+		signReq.Type = blockType
+		signReq.Block = specValue(reflect.ValueOf(obj.Block))
+		epoch = helpers.SlotToEpoch(obj.Block.Slot)
+	case *validatorpb.SignRequest_AttestationData:
+		if obj.AttestationData == nil || obj.AttestationData.Target == nil {
+			return nil, fmt.Errorf("nil attestation data in sign request")
+		}
+		signReq.Type = attestationType
+		signReq.Attestation = specValue(reflect.ValueOf(obj.AttestationData))
+		epoch = obj.AttestationData.Target.Epoch
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		agg := obj.AggregateAttestationAndProof
+		if agg == nil || agg.Aggregate == nil || agg.Aggregate.Data == nil {
+			return nil, fmt.Errorf("nil aggregate attestation and proof in sign request")
+		}
+		signReq.Type = aggregateAndProofType
+		signReq.AggregateAndProof = specValue(reflect.ValueOf(agg))
+		epoch = helpers.SlotToEpoch(agg.Aggregate.Data.Slot)
+	case *validatorpb.SignRequest_Exit:
+		if obj.Exit == nil {
+			return nil, fmt.Errorf("nil voluntary exit in sign request")
+		}
+		signReq.Type = voluntaryExitType
+		signReq.VoluntaryExit = specValue(reflect.ValueOf(obj.Exit))
+		epoch = obj.Exit.Epoch
+	case *validatorpb.SignRequest_Epoch:
+		signReq.Type = randaoRevealType
+		signReq.RandaoReveal = &randaoRevealJSON{Epoch: strconv.FormatUint(uint64(obj.Epoch), 10)}
+		epoch = obj.Epoch
+	case *validatorpb.SignRequest_Slot:
+		signReq.Type = aggregationSlotType
+		signReq.AggregationSlot = &aggregationSlotJSON{Slot: strconv.FormatUint(uint64(obj.Slot), 10)}
+		epoch = helpers.SlotToEpoch(obj.Slot)
+	default:
+		return nil, fmt.Errorf("unsupported sign request object %T", req.Object)
+	}
+	fork, err := p2putils.Fork(epoch)
+	if err != nil {
+		return nil, err
+	}
+	signReq.ForkInfo = &forkInfoJSON{
+		Fork: &forkJSON{
+			PreviousVersion: hexutil.Encode(fork.PreviousVersion),
+			CurrentVersion:  hexutil.Encode(fork.CurrentVersion),
+			Epoch:           strconv.FormatUint(uint64(fork.Epoch), 10),
+		},
+		GenesisValidatorsRoot: hexutil.Encode(genesisValidatorsRoot),
+	}
+	return signReq, nil
+}
+
+// specValue converts a proto message into its standard API JSON representation: bytes are
+// 0x-prefixed hex and integers are decimal strings.
+func specValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return specValue(v.Elem())
+	case reflect.Struct:
+		renames := specFieldNames[v.Type()]
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") {
+				continue
+			}
+			name := protoFieldName(f)
+			if name == "" {
+				continue
+			}
+			if renamed, ok := renames[name]; ok {
+				name = renamed
+			}
+			fields[name] = specValue(v.Field(i))
+		}
+		return fields
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return hexutil.Encode(v.Bytes())
+		}
+		items := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = specValue(v.Index(i))
+		}
+		return items
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	default:
+		return v.Interface()
+	}
+}
+
+// protoFieldName returns the original proto name of a field from its protobuf struct tag.
+func protoFieldName(f reflect.StructField) string {
+	for _, part := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
@@ -48,6 +48,8 @@ var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
 	flags.BeaconRPCGatewayProviderFlag,
 	flags.CertFlag,
+	flags.ClientCertFlag,
+	flags.ClientKeyFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
 	flags.InteropStartIndex,
@@ -72,11 +74,21 @@ var appFlags = []cli.Flag{
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
 	flags.EnableDutyCountDown,
+	flags.DoppelgangerEpochsFlag,
+	flags.Web3SignerURLFlag,
+	flags.Web3SignerTLSCACertFlag,
+	flags.Web3SignerTLSClientCertFlag,
+	flags.Web3SignerTLSClientKeyFlag,
+	flags.Web3SignerKeysRefreshIntervalFlag,
+	flags.RemoteSlashingProtectionURLFlag,
+	flags.RemoteSlashingProtectionTimeoutFlag,
+	flags.RemoteSlashingProtectionFailOpenFlag,
 	cmd.BackupWebhookOutputDir,
 	cmd.EnableBackupWebhookFlag,
 	cmd.MinimalConfigFlag,
 	cmd.E2EConfigFlag,
 	cmd.VerbosityFlag,
+	cmd.ModuleLogLevelsFlag,
 	cmd.DataDirFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
@@ -86,6 +98,9 @@ var appFlags = []cli.Flag{
 	cmd.TraceSampleFractionFlag,
 	cmd.LogFormat,
 	cmd.LogFileName,
+	cmd.LogMaxSize,
+	cmd.LogMaxAge,
+	cmd.LogMaxBackups,
 	cmd.ConfigFileFlag,
 	cmd.ChainConfigFileFlag,
 	cmd.GrpcMaxCallRecvMsgSizeFlag,
@@ -154,7 +169,12 @@ func main() {
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
-			if err := logutil.ConfigurePersistentLogging(logFileName); err != nil {
+			rotation := logutil.Rotation{
+				MaxSize:    int64(ctx.Int(cmd.LogMaxSize.Name)) << 20,
+				MaxAge:     ctx.Duration(cmd.LogMaxAge.Name),
+				MaxBackups: ctx.Int(cmd.LogMaxBackups.Name),
+			}
+			if err := logutil.ConfigureRotatingLogging(logFileName, rotation); err != nil {
 				log.WithError(err).Error("Failed to configuring logging to disk.")
 			}
 		}
@@ -21,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/prereq"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
@@ -34,10 +35,12 @@ import (
 	g "github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/web3signer"
 	"github.com/prysmaticlabs/prysm/validator/rpc"
 	"github.com/prysmaticlabs/prysm/validator/rpc/gateway"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection"
 	"github.com/prysmaticlabs/prysm/validator/slashing-protection/iface"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/remote"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -73,7 +76,11 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	logrus.SetLevel(level)
+	moduleLevels, err := logutil.ParseModuleLevels(cliCtx.String(cmd.ModuleLogLevelsFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	logutil.ConfigureLevels(level, moduleLevels)
 
 	// Warn if user's platform is not supported
 	prereq.WarnIfNotSupported(cliCtx.Context)
@@ -174,7 +181,7 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "could not generate interop keys")
 		}
-	} else {
+	} else if !cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
 		// Read the wallet from the specified path.
 		w, err := wallet.OpenWalletOrElseCli(cliCtx, func(cliCtx *cli.Context) (*wallet.Wallet, error) {
 			return nil, wallet.ErrNoWalletFound
@@ -236,6 +243,22 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	if err := valDB.RunUpMigrations(cliCtx.Context); err != nil {
 		return errors.Wrap(err, "could not run database migration")
 	}
+	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
+		// The remote signer needs the genesis validators root, which the
+		// validator client records in its database once the chain has started.
+		keyManager, err = web3signer.NewKeymanager(cliCtx.Context, &web3signer.SetupConfig{
+			BaseEndpoint:                 cliCtx.String(flags.Web3SignerURLFlag.Name),
+			CACertPath:                   cliCtx.String(flags.Web3SignerTLSCACertFlag.Name),
+			ClientCertPath:               cliCtx.String(flags.Web3SignerTLSClientCertFlag.Name),
+			ClientKeyPath:                cliCtx.String(flags.Web3SignerTLSClientKeyFlag.Name),
+			KeysRefreshInterval:          cliCtx.Duration(flags.Web3SignerKeysRefreshIntervalFlag.Name),
+			GenesisValidatorsRootFetcher: valDB,
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not initialize web3signer keymanager")
+		}
+		log.WithField("url", cliCtx.String(flags.Web3SignerURLFlag.Name)).Info("Signing with remote Web3Signer keymanager")
+	}
 
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		if err := c.registerPrometheusService(cliCtx); err != nil {
@@ -391,34 +414,53 @@ func (c *ValidatorClient) registerValidatorService(
 	if err := c.services.FetchService(&sp); err == nil {
 		protector = sp
 	}
+	var remoteProtection *remote.Client
+	if remoteURL := c.cliCtx.String(flags.RemoteSlashingProtectionURLFlag.Name); remoteURL != "" {
+		var err error
+		remoteProtection, err = remote.NewClient(&remote.Config{
+			URL:      remoteURL,
+			Timeout:  c.cliCtx.Duration(flags.RemoteSlashingProtectionTimeoutFlag.Name),
+			FailOpen: c.cliCtx.Bool(flags.RemoteSlashingProtectionFailOpenFlag.Name),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not initialize remote slashing protection")
+		}
+	}
 
 	gStruct := &g.Graffiti{}
 	var err error
-	if c.cliCtx.IsSet(flags.GraffitiFileFlag.Name) {
-		n := c.cliCtx.String(flags.GraffitiFileFlag.Name)
-		gStruct, err = g.ParseGraffitiFile(n)
+	graffitiFile := c.cliCtx.String(flags.GraffitiFileFlag.Name)
+	if graffitiFile != "" {
+		parsed, err := g.ParseGraffitiFile(graffitiFile)
 		if err != nil {
 			log.WithError(err).Warn("Could not parse graffiti file")
+		} else {
+			gStruct = parsed
 		}
 	}
 
 	v, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
+		DoppelgangerEpochs:         c.cliCtx.Uint64(flags.DoppelgangerEpochsFlag.Name),
 		DataDir:                    dataDir,
 		KeyManager:                 keyManager,
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
 		CertFlag:                   cert,
+		ClientCertFlag:             c.cliCtx.String(flags.ClientCertFlag.Name),
+		ClientKeyFlag:              c.cliCtx.String(flags.ClientKeyFlag.Name),
 		GraffitiFlag:               graffiti,
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
 		GrpcHeadersFlag:            c.cliCtx.String(flags.GrpcHeadersFlag.Name),
 		Protector:                  protector,
+		RemoteProtection:           remoteProtection,
 		ValDB:                      c.db,
 		UseWeb:                     c.cliCtx.Bool(flags.EnableWebFlag.Name),
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
+		GraffitiFile:               graffitiFile,
 		LogDutyCountDown:           c.cliCtx.Bool(flags.EnableDutyCountDown.Name),
 	})
 
@@ -492,6 +534,8 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context, km keymanager.
 		ClientGrpcRetryDelay:     grpcRetryDelay,
 		ClientGrpcHeaders:        strings.Split(grpcHeaders, ","),
 		ClientWithCert:           clientCert,
+		ClientWithClientCert:     c.cliCtx.String(flags.ClientCertFlag.Name),
+		ClientWithClientKey:      c.cliCtx.String(flags.ClientKeyFlag.Name),
 	})
 	return c.services.RegisterService(server)
 }
@@ -510,11 +554,19 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 	rpcAddr := fmt.Sprintf("%s:%d", rpcHost, rpcPort)
 	gatewayAddress := fmt.Sprintf("%s:%d", gatewayHost, gatewayPort)
 	allowedOrigins := strings.Split(cliCtx.String(flags.GPRCGatewayCorsDomain.Name), ",")
+	var rpcServer *rpc.Server
+	if err := c.services.FetchService(&rpcServer); err != nil {
+		return err
+	}
 	gatewaySrv := gateway.New(
 		cliCtx.Context,
 		rpcAddr,
 		gatewayAddress,
 		allowedOrigins,
+		gateway.Handler{
+			Path:    rpc.KeystoresPath,
+			Handler: rpcServer.KeymanagerAPIHandler(),
+		},
 	)
 	return c.services.RegisterService(gatewaySrv)
 }
@@ -28,6 +28,8 @@ func (s *Server) registerBeaconClient() error {
 	dialOpts := client.ConstructDialOptions(
 		s.clientMaxCallRecvMsgSize,
 		s.clientWithCert,
+		s.clientWithClientCert,
+		s.clientWithClientKey,
 		s.clientGrpcRetries,
 		s.clientGrpcRetryDelay,
 		streamInterceptor,
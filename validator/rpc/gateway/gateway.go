@@ -22,16 +22,25 @@ type Gateway struct {
 	server         *http.Server
 	mux            *http.ServeMux
 	allowedOrigins []string
+	handlers       []Handler
 	startFailure   error
 }
 
+// Handler represents a path and handler to serve alongside the gRPC gateway, such as
+// APIs which are not backed by the gRPC server.
+type Handler struct {
+	Path    string
+	Handler http.Handler
+}
+
 // New returns a new gateway server which translates HTTP into gRPC.
-// Accepts a context and optional http.ServeMux.
+// Accepts a context and optional handlers to serve on the same address.
 func New(
 	ctx context.Context,
 	remoteAddress,
 	gatewayAddress string,
 	allowedOrigins []string,
+	additionalHandlers ...Handler,
 ) *Gateway {
 	return &Gateway{
 		remoteAddr:     remoteAddress,
@@ -39,6 +48,7 @@ func New(
 		ctx:            ctx,
 		mux:            http.NewServeMux(),
 		allowedOrigins: allowedOrigins,
+		handlers:       additionalHandlers,
 	}
 }
 
@@ -74,6 +84,9 @@ func (g *Gateway) Start() {
 			web.Handler(w, r)
 		}
 	})
+	for _, h := range g.handlers {
+		g.mux.Handle(h.Path, g.corsMiddleware(h.Handler))
+	}
 	g.server = &http.Server{
 		Addr:    g.gatewayAddr,
 		Handler: g.mux,
@@ -114,7 +127,7 @@ func (g *Gateway) Stop() error {
 func (g *Gateway) corsMiddleware(h http.Handler) http.Handler {
 	c := cors.New(cors.Options{
 		AllowedOrigins:   g.allowedOrigins,
-		AllowedMethods:   []string{http.MethodPost, http.MethodGet, http.MethodOptions},
+		AllowedMethods:   []string{http.MethodPost, http.MethodGet, http.MethodDelete, http.MethodOptions},
 		AllowCredentials: true,
 		MaxAge:           600,
 		AllowedHeaders:   []string{"*"},
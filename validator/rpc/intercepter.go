@@ -70,10 +70,18 @@ func (s *Server) authorize(ctx context.Context) error {
 	if !ok {
 		return status.Errorf(codes.Unauthenticated, "Authorization token could not be found")
 	}
-	if len(authHeader) < 1 || !strings.Contains(authHeader[0], "Bearer ") {
+	if len(authHeader) < 1 {
 		return status.Error(codes.Unauthenticated, "Invalid auth header, needs Bearer {token}")
 	}
-	token := strings.Split(authHeader[0], "Bearer ")[1]
+	return s.authorizeHeader(authHeader[0])
+}
+
+// Authorize the token carried by an authorization header of the form Bearer {token}.
+func (s *Server) authorizeHeader(authHeader string) error {
+	if !strings.Contains(authHeader, "Bearer ") {
+		return status.Error(codes.Unauthenticated, "Invalid auth header, needs Bearer {token}")
+	}
+	token := strings.Split(authHeader, "Bearer ")[1]
 	_, err := jwt.Parse(token, s.validateJWT)
 	if err != nil {
 		return status.Errorf(codes.Unauthenticated, "Could not parse JWT token: %v", err)
@@ -0,0 +1,379 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"google.golang.org/grpc/status"
+)
+
+// KeystoresPath is the path under which the standard keymanager API serves keystores.
+const KeystoresPath = "/eth/v1/keystores"
+
+// Statuses reported for each keystore in import and delete responses
+// of the standard keymanager API.
+const (
+	keystoreStatusImported  = "imported"
+	keystoreStatusDuplicate = "duplicate"
+	keystoreStatusDeleted   = "deleted"
+	keystoreStatusNotActive = "not_active"
+	keystoreStatusNotFound  = "not_found"
+	keystoreStatusError     = "error"
+)
+
+type keystoreJSON struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	// DerivationPath is omitted for keys without a known derivation path, which includes the keys
+	// of derived wallets recovered before their derivation paths were recorded.
+	DerivationPath string `json:"derivation_path,omitempty"`
+	Readonly       bool   `json:"readonly"`
+}
+
+type listKeystoresResponseJSON struct {
+	Data []*keystoreJSON `json:"data"`
+}
+
+type importKeystoresRequestJSON struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection"`
+}
+
+type keystoreStatusJSON struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type importKeystoresResponseJSON struct {
+	Data []*keystoreStatusJSON `json:"data"`
+}
+
+type deleteKeystoresRequestJSON struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+type deleteKeystoresResponseJSON struct {
+	Data               []*keystoreStatusJSON `json:"data"`
+	SlashingProtection string                `json:"slashing_protection"`
+}
+
+type keymanagerErrorJSON struct {
+	Message string `json:"message"`
+}
+
+// KeymanagerAPIHandler serves the standard keymanager API, which lists, imports and deletes
+// the keystores of the validator client. Requests must carry the same bearer token as those
+// made to the web API.
+func (s *Server) KeymanagerAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			writeKeymanagerError(w, http.StatusUnauthorized, "Authorization token could not be found")
+			return
+		}
+		if err := s.authorizeHeader(authHeader); err != nil {
+			writeKeymanagerError(w, http.StatusUnauthorized, status.Convert(err).Message())
+			return
+		}
+		if s.wallet == nil || s.keymanager == nil {
+			writeKeymanagerError(w, http.StatusBadRequest, "No wallet initialized")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			s.listKeystores(w, r)
+		case http.MethodPost:
+			s.importKeystores(w, r)
+		case http.MethodDelete:
+			s.deleteKeystores(w, r)
+		default:
+			writeKeymanagerError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed", r.Method))
+		}
+	})
+}
+
+// listKeystores returns the validating public keys known to the keymanager. Keys held by a
+// remote signer are reported as read-only, as they cannot be deleted through the API. The keys of
+// a derived wallet are reported with the derivation path recorded when they were recovered, and
+// without one if the wallet was recovered before derivation paths were recorded.
+func (s *Server) listKeystores(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.keymanager.FetchValidatingPublicKeys(r.Context())
+	if err != nil {
+		writeKeymanagerError(w, http.StatusInternalServerError, fmt.Sprintf("Could not retrieve public keys: %v", err))
+		return
+	}
+	var paths map[string]string
+	if km, ok := s.keymanager.(*derived.Keymanager); ok {
+		paths, err = km.DerivationPaths(r.Context())
+		if err != nil {
+			writeKeymanagerError(w, http.StatusInternalServerError, fmt.Sprintf("Could not retrieve derivation paths: %v", err))
+			return
+		}
+	}
+	kind := s.wallet.KeymanagerKind()
+	data := make([]*keystoreJSON, len(keys))
+	for i := 0; i < len(keys); i++ {
+		pubKey := fmt.Sprintf("%#x", keys[i])
+		data[i] = &keystoreJSON{
+			ValidatingPubkey: pubKey,
+			DerivationPath:   paths[pubKey],
+			Readonly:         kind == keymanager.Remote,
+		}
+		if kind == keymanager.Derived && data[i].DerivationPath == "" {
+			log.WithField("publicKey", pubKey).Debug("No derivation path recorded for key, recover the wallet to record it")
+		}
+	}
+	writeKeymanagerResponse(w, http.StatusOK, &listKeystoresResponseJSON{Data: data})
+}
+
+// importKeystores imports EIP-2335 keystores, each decrypted with its own password, after
+// importing the accompanying EIP-3076 slashing protection data. Keystores whose key is already
+// known are reported as duplicates. When the slashing protection data cannot be imported, no
+// keystore is imported.
+func (s *Server) importKeystores(w http.ResponseWriter, r *http.Request) {
+	km, ok := s.keymanager.(*imported.Keymanager)
+	if !ok {
+		writeKeymanagerError(w, http.StatusBadRequest, "Only imported wallets can import keystores")
+		return
+	}
+	req := &importKeystoresRequestJSON{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeKeymanagerError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode request body: %v", err))
+		return
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		writeKeymanagerError(
+			w,
+			http.StatusBadRequest,
+			fmt.Sprintf("Number of keystores %d does not match number of passwords %d", len(req.Keystores), len(req.Passwords)),
+		)
+		return
+	}
+	ctx := r.Context()
+	statuses := make([]*keystoreStatusJSON, len(req.Keystores))
+	if req.SlashingProtection != "" {
+		if err := slashingprotection.ImportStandardProtectionJSON(
+			ctx, s.valDB, strings.NewReader(req.SlashingProtection),
+		); err != nil {
+			for i := range statuses {
+				statuses[i] = &keystoreStatusJSON{
+					Status:  keystoreStatusError,
+					Message: fmt.Sprintf("Could not import slashing protection data: %v", err),
+				}
+			}
+			writeKeymanagerResponse(w, http.StatusOK, &importKeystoresResponseJSON{Data: statuses})
+			return
+		}
+	}
+
+	existingKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		writeKeymanagerError(w, http.StatusInternalServerError, fmt.Sprintf("Could not retrieve public keys: %v", err))
+		return
+	}
+	seen := make(map[[48]byte]bool, len(existingKeys)+len(req.Keystores))
+	for _, key := range existingKeys {
+		seen[key] = true
+	}
+	decryptor := keystorev4.New()
+	privKeys := make([][]byte, 0, len(req.Keystores))
+	pubKeys := make([][]byte, 0, len(req.Keystores))
+	importedIndices := make([]int, 0, len(req.Keystores))
+	for i, encoded := range req.Keystores {
+		privKey, err := decryptKeystore(decryptor, encoded, req.Passwords[i])
+		if err != nil {
+			statuses[i] = &keystoreStatusJSON{Status: keystoreStatusError, Message: err.Error()}
+			continue
+		}
+		pubKey := privKey.PublicKey().Marshal()
+		var key [48]byte
+		copy(key[:], pubKey)
+		if seen[key] {
+			statuses[i] = &keystoreStatusJSON{Status: keystoreStatusDuplicate}
+			continue
+		}
+		seen[key] = true
+		privKeys = append(privKeys, privKey.Marshal())
+		pubKeys = append(pubKeys, pubKey)
+		importedIndices = append(importedIndices, i)
+	}
+	if len(privKeys) > 0 {
+		importErr := km.ImportKeypairs(ctx, privKeys, pubKeys)
+		for _, i := range importedIndices {
+			statuses[i] = &keystoreStatusJSON{Status: keystoreStatusImported}
+			if importErr != nil {
+				statuses[i] = &keystoreStatusJSON{Status: keystoreStatusError, Message: importErr.Error()}
+			}
+		}
+		if importErr == nil {
+			s.walletInitializedFeed.Send(s.wallet)
+		}
+	}
+	writeKeymanagerResponse(w, http.StatusOK, &importKeystoresResponseJSON{Data: statuses})
+}
+
+// deleteKeystores deletes the keystores of the requested public keys and returns the slashing
+// protection data of those keys, so that they can safely validate elsewhere. Keys which are not
+// in the wallet but for which slashing protection data exists are reported as not active.
+func (s *Server) deleteKeystores(w http.ResponseWriter, r *http.Request) {
+	km, ok := s.keymanager.(*imported.Keymanager)
+	if !ok {
+		writeKeymanagerError(w, http.StatusBadRequest, "Only imported wallets can delete keystores")
+		return
+	}
+	req := &deleteKeystoresRequestJSON{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeKeymanagerError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode request body: %v", err))
+		return
+	}
+	pubKeys := make([][]byte, len(req.Pubkeys))
+	for i, pubKeyHex := range req.Pubkeys {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(pubKeyHex, "0x"))
+		if err != nil || len(pubKey) != 48 {
+			writeKeymanagerError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid BLS public key", pubKeyHex))
+			return
+		}
+		pubKeys[i] = pubKey
+	}
+
+	// The keys are deleted before their slashing protection data is exported, so that nothing they
+	// sign is missing from the exported data.
+	ctx := r.Context()
+	statuses, err := deleteActiveKeys(ctx, km, pubKeys)
+	if err != nil {
+		writeKeymanagerError(w, http.StatusInternalServerError, fmt.Sprintf("Could not retrieve public keys: %v", err))
+		return
+	}
+	protection, err := s.exportSlashingProtection(ctx, pubKeys)
+	if err != nil {
+		writeKeymanagerError(w, http.StatusInternalServerError, fmt.Sprintf("Could not export slashing protection data: %v", err))
+		return
+	}
+	protected := make(map[string]bool, len(protection.Data))
+	for _, item := range protection.Data {
+		protected[item.Pubkey] = true
+	}
+	for i, pubKey := range pubKeys {
+		if statuses[i] != nil {
+			continue
+		}
+		statuses[i] = &keystoreStatusJSON{Status: keystoreStatusNotFound}
+		if protected[fmt.Sprintf("%#x", pubKey)] {
+			statuses[i] = &keystoreStatusJSON{Status: keystoreStatusNotActive}
+		}
+	}
+	encodedProtection, err := json.Marshal(protection)
+	if err != nil {
+		writeKeymanagerError(w, http.StatusInternalServerError, fmt.Sprintf("Could not encode slashing protection data: %v", err))
+		return
+	}
+	writeKeymanagerResponse(w, http.StatusOK, &deleteKeystoresResponseJSON{
+		Data:               statuses,
+		SlashingProtection: string(encodedProtection),
+	})
+}
+
+// deleteActiveKeys deletes the requested keys held by the keymanager and returns their statuses.
+// The status of a key which is not held by the keymanager is left nil.
+func deleteActiveKeys(ctx context.Context, km *imported.Keymanager, pubKeys [][]byte) ([]*keystoreStatusJSON, error) {
+	existingKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[[48]byte]bool, len(existingKeys))
+	for _, key := range existingKeys {
+		active[key] = true
+	}
+	statuses := make([]*keystoreStatusJSON, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		var key [48]byte
+		copy(key[:], pubKey)
+		if !active[key] {
+			continue
+		}
+		if err := km.DeleteAccounts(ctx, [][]byte{pubKey}); err != nil {
+			statuses[i] = &keystoreStatusJSON{Status: keystoreStatusError, Message: err.Error()}
+			continue
+		}
+		// A key may be requested more than once, only its first occurrence is deleted.
+		active[key] = false
+		statuses[i] = &keystoreStatusJSON{Status: keystoreStatusDeleted}
+	}
+	return statuses, nil
+}
+
+// exportSlashingProtection returns the EIP-3076 slashing protection data of the given public keys.
+func (s *Server) exportSlashingProtection(ctx context.Context, pubKeys [][]byte) (*format.EIPSlashingProtectionFormat, error) {
+	protection, err := slashingprotection.ExportStandardProtectionJSON(ctx, s.valDB)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*format.ProtectionData, 0, len(pubKeys))
+	for _, item := range protection.Data {
+		for _, pubKey := range pubKeys {
+			if item.Pubkey == fmt.Sprintf("%#x", pubKey) {
+				data = append(data, item)
+				break
+			}
+		}
+	}
+	protection.Data = data
+	return protection, nil
+}
+
+// decryptKeystore decrypts an encoded EIP-2335 keystore with the given password.
+func decryptKeystore(decryptor *keystorev4.Encryptor, encoded, password string) (bls.SecretKey, error) {
+	keystore := &keymanager.Keystore{}
+	if err := json.Unmarshal([]byte(encoded), keystore); err != nil {
+		return nil, errors.Wrap(err, "not a valid EIP-2335 keystore JSON file")
+	}
+	privKeyBytes, err := decryptor.Decrypt(keystore.Crypto, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt keystore")
+	}
+	privKey, err := bls.SecretKeyFromBytes(privKeyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize private key from bytes")
+	}
+	if keystore.Pubkey != "" {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(keystore.Pubkey, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode pubkey from keystore")
+		}
+		if !bytes.Equal(pubKey, privKey.PublicKey().Marshal()) {
+			return nil, errors.New("keystore pubkey does not match its private key")
+		}
+	}
+	return privKey, nil
+}
+
+func writeKeymanagerResponse(w http.ResponseWriter, code int, resp interface{}) {
+	encoded, err := json.Marshal(resp)
+	if err != nil {
+		log.WithError(err).Error("Could not encode keymanager API response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(encoded); err != nil {
+		log.WithError(err).Error("Could not write keymanager API response")
+	}
+}
+
+func writeKeymanagerError(w http.ResponseWriter, code int, message string) {
+	writeKeymanagerResponse(w, code, &keymanagerErrorJSON{Message: message})
+}
@@ -0,0 +1,261 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	pb "github.com/prysmaticlabs/prysm/proto/validator/accounts/v2"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func setupKeymanagerAPIServer(t *testing.T) *Server {
+	imported.ResetCaches()
+	localWalletDir := setupWalletDir(t)
+	defaultWalletPath = localWalletDir
+	ctx := context.Background()
+	strongPass := "29384283xasjasd32%%&*@*#*"
+	w, err := accounts.CreateWalletWithKeymanager(ctx, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      defaultWalletPath,
+			KeymanagerKind: keymanager.Imported,
+			WalletPassword: strongPass,
+		},
+		SkipMnemonicConfirm: true,
+	})
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	return &Server{
+		keymanager:            km,
+		wallet:                w,
+		walletInitializedFeed: new(event.Feed),
+		valDB:                 dbtest.SetupDB(t, [][48]byte{}),
+		jwtKey:                []byte("testKey"),
+	}
+}
+
+func encryptedKeystore(t *testing.T, privKey bls.SecretKey, password string) string {
+	encryptor := keystorev4.New()
+	id, err := uuid.NewRandom()
+	require.NoError(t, err)
+	cryptoFields, err := encryptor.Encrypt(privKey.Marshal(), password)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(&keymanager.Keystore{
+		Crypto:  cryptoFields,
+		ID:      id.String(),
+		Version: encryptor.Version(),
+		Pubkey:  fmt.Sprintf("%x", privKey.PublicKey().Marshal()),
+		Name:    encryptor.Name(),
+	})
+	require.NoError(t, err)
+	return string(encoded)
+}
+
+func keymanagerAPIRequest(t *testing.T, s *Server, method string, body interface{}) *httptest.ResponseRecorder {
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	req := httptest.NewRequest(method, KeystoresPath, &reqBody)
+	token, _, err := s.createTokenString()
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.KeymanagerAPIHandler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_KeymanagerAPI_Unauthorized(t *testing.T) {
+	s := &Server{jwtKey: []byte("testKey")}
+	handler := s.KeymanagerAPIHandler()
+
+	req := httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	badServer := &Server{jwtKey: []byte("badTestKey")}
+	token, _, err := badServer.createTokenString()
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, true, strings.Contains(rec.Body.String(), "Could not parse JWT token"))
+}
+
+func TestServer_KeymanagerAPI_ImportListDelete(t *testing.T) {
+	s := setupKeymanagerAPIServer(t)
+	ctx := context.Background()
+
+	privKeys := make([]bls.SecretKey, 2)
+	for i := range privKeys {
+		privKey, err := bls.RandKey()
+		require.NoError(t, err)
+		privKeys[i] = privKey
+	}
+	var pubKey0 [48]byte
+	copy(pubKey0[:], privKeys[0].PublicKey().Marshal())
+	require.NoError(t, s.valDB.SaveProposalHistoryForSlot(ctx, pubKey0, 5, make([]byte, 32)))
+
+	t.Run("import", func(t *testing.T) {
+		rec := keymanagerAPIRequest(t, s, http.MethodPost, &importKeystoresRequestJSON{
+			Keystores: []string{
+				encryptedKeystore(t, privKeys[0], "password0"),
+				encryptedKeystore(t, privKeys[1], "password1"),
+				encryptedKeystore(t, privKeys[0], "password0"),
+				encryptedKeystore(t, privKeys[1], "password0"),
+			},
+			Passwords: []string{"password0", "password1", "password0", "wrong"},
+		})
+		require.Equal(t, http.StatusOK, rec.Code)
+		resp := &importKeystoresResponseJSON{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+		assert.Equal(t, keystoreStatusImported, resp.Data[0].Status)
+		assert.Equal(t, keystoreStatusImported, resp.Data[1].Status)
+		assert.Equal(t, keystoreStatusDuplicate, resp.Data[2].Status)
+		assert.Equal(t, keystoreStatusError, resp.Data[3].Status)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		rec := keymanagerAPIRequest(t, s, http.MethodGet, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		resp := &listKeystoresResponseJSON{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		listed := map[string]bool{}
+		for _, item := range resp.Data {
+			listed[item.ValidatingPubkey] = true
+			assert.Equal(t, false, item.Readonly)
+		}
+		for _, privKey := range privKeys {
+			assert.Equal(t, true, listed[fmt.Sprintf("%#x", privKey.PublicKey().Marshal())])
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		unknownKey, err := bls.RandKey()
+		require.NoError(t, err)
+		pubKey0Hex := fmt.Sprintf("%#x", pubKey0)
+		rec := keymanagerAPIRequest(t, s, http.MethodDelete, &deleteKeystoresRequestJSON{
+			Pubkeys: []string{pubKey0Hex, pubKey0Hex, fmt.Sprintf("%#x", unknownKey.PublicKey().Marshal())},
+		})
+		require.Equal(t, http.StatusOK, rec.Code)
+		resp := &deleteKeystoresResponseJSON{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, keystoreStatusDeleted, resp.Data[0].Status)
+		assert.Equal(t, keystoreStatusNotActive, resp.Data[1].Status)
+		assert.Equal(t, keystoreStatusNotFound, resp.Data[2].Status)
+
+		protection := &format.EIPSlashingProtectionFormat{}
+		require.NoError(t, json.Unmarshal([]byte(resp.SlashingProtection), protection))
+		require.Equal(t, 1, len(protection.Data))
+		assert.Equal(t, pubKey0Hex, protection.Data[0].Pubkey)
+		require.Equal(t, 1, len(protection.Data[0].SignedBlocks))
+		assert.Equal(t, "5", protection.Data[0].SignedBlocks[0].Slot)
+
+		keys, err := s.keymanager.FetchValidatingPublicKeys(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(keys))
+		assert.DeepEqual(t, privKeys[1].PublicKey().Marshal(), keys[0][:])
+	})
+
+	t.Run("import with slashing protection", func(t *testing.T) {
+		protection, err := s.exportSlashingProtection(ctx, [][]byte{pubKey0[:]})
+		require.NoError(t, err)
+		protection.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", make([]byte, 32))
+		encodedProtection, err := json.Marshal(protection)
+		require.NoError(t, err)
+		rec := keymanagerAPIRequest(t, s, http.MethodPost, &importKeystoresRequestJSON{
+			Keystores:          []string{encryptedKeystore(t, privKeys[0], "password0")},
+			Passwords:          []string{"password0"},
+			SlashingProtection: string(encodedProtection),
+		})
+		require.Equal(t, http.StatusOK, rec.Code)
+		resp := &importKeystoresResponseJSON{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, keystoreStatusImported, resp.Data[0].Status)
+
+		rec = keymanagerAPIRequest(t, s, http.MethodPost, &importKeystoresRequestJSON{
+			Keystores:          []string{encryptedKeystore(t, privKeys[0], "password0")},
+			Passwords:          []string{"password0"},
+			SlashingProtection: "badjson",
+		})
+		require.Equal(t, http.StatusOK, rec.Code)
+		resp = &importKeystoresResponseJSON{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, keystoreStatusError, resp.Data[0].Status)
+	})
+}
+
+func TestServer_KeymanagerAPI_BadRequests(t *testing.T) {
+	s := setupKeymanagerAPIServer(t)
+
+	rec := keymanagerAPIRequest(t, s, http.MethodPost, &importKeystoresRequestJSON{
+		Keystores: []string{"{}"},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, true, strings.Contains(rec.Body.String(), "does not match number of passwords"))
+
+	rec = keymanagerAPIRequest(t, s, http.MethodDelete, &deleteKeystoresRequestJSON{
+		Pubkeys: []string{"0x1234"},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, true, strings.Contains(rec.Body.String(), "is not a valid BLS public key"))
+
+	rec = keymanagerAPIRequest(t, s, http.MethodPut, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServer_KeymanagerAPI_DeleteBeforeExport(t *testing.T) {
+	s := setupKeymanagerAPIServer(t)
+	ctx := context.Background()
+	km, ok := s.keymanager.(*imported.Keymanager)
+	require.Equal(t, true, ok)
+	privKey, err := bls.RandKey()
+	require.NoError(t, err)
+	pubKey := privKey.PublicKey().Marshal()
+	require.NoError(t, km.ImportKeypairs(ctx, [][]byte{privKey.Marshal()}, [][]byte{pubKey}))
+	var key [48]byte
+	copy(key[:], pubKey)
+	require.NoError(t, s.valDB.SaveProposalHistoryForSlot(ctx, key, 5, make([]byte, 32)))
+
+	statuses, err := deleteActiveKeys(ctx, km, [][]byte{pubKey})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(statuses))
+	assert.Equal(t, keystoreStatusDeleted, statuses[0].Status)
+
+	// Between the deletion and the export, the key can no longer sign anything that would be
+	// missing from the exported slashing protection data.
+	_, err = km.Sign(ctx, &pb.SignRequest{PublicKey: pubKey, SigningRoot: make([]byte, 32)})
+	assert.NotNil(t, err)
+
+	protection, err := s.exportSlashingProtection(ctx, [][]byte{pubKey})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(protection.Data))
+	require.Equal(t, 1, len(protection.Data[0].SignedBlocks))
+	assert.Equal(t, "5", protection.Data[0].SignedBlocks[0].Slot)
+}
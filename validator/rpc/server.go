@@ -41,6 +41,8 @@ type Config struct {
 	ClientGrpcRetryDelay     time.Duration
 	ClientGrpcHeaders        []string
 	ClientWithCert           string
+	ClientWithClientCert     string
+	ClientWithClientKey      string
 	Host                     string
 	Port                     string
 	CertFlag                 string
@@ -72,6 +74,8 @@ type Server struct {
 	clientGrpcRetryDelay     time.Duration
 	clientGrpcHeaders        []string
 	clientWithCert           string
+	clientWithClientCert     string
+	clientWithClientKey      string
 	host                     string
 	port                     string
 	listener                 net.Listener
@@ -113,6 +117,8 @@ func NewServer(ctx context.Context, cfg *Config) *Server {
 		clientGrpcRetryDelay:     cfg.ClientGrpcRetryDelay,
 		clientGrpcHeaders:        cfg.ClientGrpcHeaders,
 		clientWithCert:           cfg.ClientWithCert,
+		clientWithClientCert:     cfg.ClientWithClientCert,
+		clientWithClientKey:      cfg.ClientWithClientKey,
 		valDB:                    cfg.ValDB,
 		validatorService:         cfg.ValidatorService,
 		syncChecker:              cfg.SyncChecker,
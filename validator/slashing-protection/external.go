@@ -4,6 +4,7 @@ import (
 	"context"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 )
 
 // CheckBlockSafety this function is part of slashing protection for block proposals it performs
@@ -49,6 +50,40 @@ func (s *Service) CheckAttestationSafety(ctx context.Context, attestation *ethpb
 	return !slashable.Slashable
 }
 
+// CheckHighestAttestation compares an attestation with the highest source and target epochs the
+// external slasher has seen for its attesting validators, on chain and over the network. To be used
+// before signing, on top of the local slashing protection.
+func (s *Service) CheckHighestAttestation(ctx context.Context, attestation *ethpb.IndexedAttestation) bool {
+	res, err := s.slasherClient.HighestAttestations(ctx, &slashpb.HighestAttestationRequest{
+		ValidatorIds: attestation.AttestingIndices,
+	})
+	if err != nil {
+		log.Errorf("External slashing attestation protection returned an error: %v", err)
+		return false
+	}
+	for _, highest := range res.Attestations {
+		if attestation.Data.Source.Epoch < highest.HighestSourceEpoch {
+			log.Warnf(
+				"External slashing attestation protection found source epoch %d lower than highest source epoch %d of validator %d",
+				attestation.Data.Source.Epoch,
+				highest.HighestSourceEpoch,
+				highest.ValidatorId,
+			)
+			return false
+		}
+		if attestation.Data.Target.Epoch <= highest.HighestTargetEpoch {
+			log.Warnf(
+				"External slashing attestation protection found target epoch %d lower than or equal to highest target epoch %d of validator %d",
+				attestation.Data.Target.Epoch,
+				highest.HighestTargetEpoch,
+				highest.ValidatorId,
+			)
+			return false
+		}
+	}
+	return true
+}
+
 // CommitAttestation implements the slashing protection for attestations it performs
 // validation and db update. To be used after the attestation is proposed.
 func (s *Service) CommitAttestation(ctx context.Context, attestation *ethpb.IndexedAttestation) bool {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	mockSlasher "github.com/prysmaticlabs/prysm/validator/testing"
@@ -33,6 +34,41 @@ func TestService_VerifyAttestation(t *testing.T) {
 	assert.Equal(t, true, s.CheckAttestationSafety(context.Background(), att), "Expected verify attestation to pass verification")
 }
 
+func TestService_CheckHighestAttestation(t *testing.T) {
+	att := &eth.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &eth.AttestationData{
+			Slot:            5,
+			CommitteeIndex:  2,
+			BeaconBlockRoot: []byte("great block"),
+			Source: &eth.Checkpoint{
+				Epoch: 4,
+				Root:  []byte("good source"),
+			},
+			Target: &eth.Checkpoint{
+				Epoch: 10,
+				Root:  []byte("good target"),
+			},
+		},
+	}
+	s := &Service{slasherClient: mockSlasher.MockSlasher{}}
+	assert.Equal(t, true, s.CheckHighestAttestation(context.Background(), att), "Expected attestation without history to pass verification")
+	s = &Service{slasherClient: mockSlasher.MockSlasher{HighestAttestationsResponse: []*slashpb.HighestAttestation{
+		{ValidatorId: 1, HighestSourceEpoch: 4, HighestTargetEpoch: 9},
+		{ValidatorId: 2, HighestSourceEpoch: 3, HighestTargetEpoch: 8},
+	}}}
+	assert.Equal(t, true, s.CheckHighestAttestation(context.Background(), att), "Expected attestation above highest attestations to pass verification")
+	s = &Service{slasherClient: mockSlasher.MockSlasher{HighestAttestationsResponse: []*slashpb.HighestAttestation{
+		{ValidatorId: 1, HighestSourceEpoch: 4, HighestTargetEpoch: 9},
+		{ValidatorId: 2, HighestSourceEpoch: 5, HighestTargetEpoch: 9},
+	}}}
+	assert.Equal(t, false, s.CheckHighestAttestation(context.Background(), att), "Expected attestation with lower source to fail verification")
+	s = &Service{slasherClient: mockSlasher.MockSlasher{HighestAttestationsResponse: []*slashpb.HighestAttestation{
+		{ValidatorId: 2, HighestSourceEpoch: 4, HighestTargetEpoch: 10},
+	}}}
+	assert.Equal(t, false, s.CheckHighestAttestation(context.Background(), att), "Expected attestation with equal target to fail verification")
+}
+
 func TestService_CommitAttestation(t *testing.T) {
 	s := &Service{slasherClient: mockSlasher.MockSlasher{SlashAttestation: true}}
 	att := &eth.IndexedAttestation{
@@ -12,6 +12,7 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/slashutil"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
@@ -76,7 +77,10 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 
 	// We validate and filter out public keys parsed from JSON to ensure we are
 	// not importing those which are slashable with respect to other data within the same JSON.
-	slashableProposerKeys := filterSlashablePubKeysFromBlocks(ctx, proposalHistoryByPubKey)
+	slashableProposerKeys, err := filterSlashablePubKeysFromBlocks(ctx, validatorDB, proposalHistoryByPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not filter slashable proposer public keys from JSON data")
+	}
 	slashableAttesterKeys, err := filterSlashablePubKeysFromAttestations(
 		ctx, validatorDB, attestingHistoryByPubKey,
 	)
@@ -235,7 +239,11 @@ func parseAttestationsForUniquePublicKeys(data []*format.ProtectionData) (map[[4
 	return signedAttestationsByPubKey, nil
 }
 
-func filterSlashablePubKeysFromBlocks(ctx context.Context, historyByPubKey map[[48]byte]kv.ProposalHistoryForPubkey) [][48]byte {
+func filterSlashablePubKeysFromBlocks(
+	ctx context.Context,
+	validatorDB db.Database,
+	historyByPubKey map[[48]byte]kv.ProposalHistoryForPubkey,
+) ([][48]byte, error) {
 	// Given signing roots are optional in the EIP standard, we behave as follows:
 	// For a given block:
 	//   If we have a previous block with the same slot in our history:
@@ -243,19 +251,44 @@ func filterSlashablePubKeysFromBlocks(ctx context.Context, historyByPubKey map[[
 	//     If signing root is not nil , then we compare signing roots. If they are different,
 	//     then we consider that proposer public key as slashable.
 	slashablePubKeys := make([][48]byte, 0)
+	// First we need to find blocks that are slashable with respect to other
+	// blocks within the same JSON import.
+	slashable := make(map[[48]byte]bool)
 	for pubKey, proposals := range historyByPubKey {
 		seenSigningRootsBySlot := make(map[types.Slot][]byte)
 		for _, blk := range proposals.Proposals {
 			if signingRoot, ok := seenSigningRootsBySlot[blk.Slot]; ok {
 				if signingRoot == nil || !bytes.Equal(signingRoot, blk.SigningRoot) {
 					slashablePubKeys = append(slashablePubKeys, pubKey)
+					slashable[pubKey] = true
 					break
 				}
 			}
 			seenSigningRootsBySlot[blk.Slot] = blk.SigningRoot
 		}
 	}
-	return slashablePubKeys
+	// Then, we need to find blocks that are slashable with respect to our database,
+	// so that merging the imported history never overrides a proposal we already signed.
+	// A proposal recorded without a signing root is treated as unknown rather than conflicting.
+	for pubKey, proposals := range historyByPubKey {
+		if slashable[pubKey] {
+			continue
+		}
+		for _, blk := range proposals.Proposals {
+			existingSigningRoot, exists, err := validatorDB.ProposalHistoryForSlot(ctx, pubKey, blk.Slot)
+			if err != nil {
+				return nil, err
+			}
+			if !exists || existingSigningRoot == params.BeaconConfig().ZeroHash {
+				continue
+			}
+			if existingSigningRoot != bytesutil.ToBytes32(blk.SigningRoot) {
+				slashablePubKeys = append(slashablePubKeys, pubKey)
+				break
+			}
+		}
+	}
+	return slashablePubKeys, nil
 }
 
 func filterSlashablePubKeysFromAttestations(
@@ -904,7 +904,9 @@ func Test_filterSlashablePubKeysFromBlocks(t *testing.T) {
 				require.NoError(t, err)
 				historyByPubKey[pubKey] = *proposalHistory
 			}
-			slashablePubKeys := filterSlashablePubKeysFromBlocks(context.Background(), historyByPubKey)
+			validatorDB := dbtest.SetupDB(t, nil)
+			slashablePubKeys, err := filterSlashablePubKeysFromBlocks(ctx, validatorDB, historyByPubKey)
+			require.NoError(t, err)
 			wantedPubKeys := make(map[[48]byte]bool)
 			for _, pk := range tt.expected {
 				wantedPubKeys[pk] = true
@@ -918,6 +920,57 @@ func Test_filterSlashablePubKeysFromBlocks(t *testing.T) {
 	}
 }
 
+func Test_filterSlashablePubKeysFromBlocks_ExistingHistory(t *testing.T) {
+	ctx := context.Background()
+	validatorDB := dbtest.SetupDB(t, nil)
+	// Keys {1} and {3} signed blocks with known signing roots, key {2} signed a block
+	// without one being recorded.
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, [48]byte{1}, 1, []byte{1}))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, [48]byte{2}, 2, nil))
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, [48]byte{3}, 3, []byte{4}))
+
+	given := map[[48]byte][]*format.SignedBlock{
+		// Same slot and signing root as our history is not slashable, neither is a new slot.
+		{1}: {
+			{
+				Slot:        "1",
+				SigningRoot: fmt.Sprintf("%#x", [32]byte{1}),
+			},
+			{
+				Slot: "5",
+			},
+		},
+		// A block at a slot without a recorded signing root is not slashable, as the
+		// recorded proposal is unknown.
+		{2}: {
+			{
+				Slot:        "2",
+				SigningRoot: fmt.Sprintf("%#x", [32]byte{2}),
+			},
+		},
+		// A different signing root at a slot in our history is slashable.
+		{3}: {
+			{
+				Slot:        "3",
+				SigningRoot: fmt.Sprintf("%#x", [32]byte{3}),
+			},
+		},
+	}
+	historyByPubKey := make(map[[48]byte]kv.ProposalHistoryForPubkey)
+	for pubKey, signedBlocks := range given {
+		proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
+		require.NoError(t, err)
+		historyByPubKey[pubKey] = *proposalHistory
+	}
+	slashablePubKeys, err := filterSlashablePubKeysFromBlocks(ctx, validatorDB, historyByPubKey)
+	require.NoError(t, err)
+	wantedPubKeys := map[[48]byte]bool{{3}: true}
+	require.Equal(t, len(wantedPubKeys), len(slashablePubKeys))
+	for _, pk := range slashablePubKeys {
+		require.Equal(t, true, wantedPubKeys[pk])
+	}
+}
+
 func Test_filterSlashablePubKeysFromAttestations(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
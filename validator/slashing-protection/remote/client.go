@@ -0,0 +1,182 @@
+/*
+Package remote defines a client for an external slashing protection web service, which the
+validator client consults before signing attestations and blocks. A single service can protect
+many validator client instances sharing the same keys.
+
+Attestations are checked with
+
+	POST /attestation
+
+	{
+	  "pubkey": "0xa99a...",
+	  "signing_root": "0x1f4e...",
+	  "slot": "37",
+	  "source_epoch": "3",
+	  "target_epoch": "4"
+	}
+
+and blocks with
+
+	POST /block
+
+	{
+	  "pubkey": "0xa99a...",
+	  "signing_root": "0x7c2b...",
+	  "slot": "37",
+	  "proposer_index": "12"
+	}
+
+The service responds with status 200 to allow signing, and with status 403 to reject it. Any other
+response, as well as an unreachable service or a request timing out, is a failure, which rejects
+signing unless the client is configured to fail open.
+*/
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	attestationPath = "/attestation"
+	blockPath       = "/block"
+	// maxResponseSize bounds the size of the responses read from the service.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	// ErrRejected defines a rejection of a signing request by the remote slashing protection service.
+	ErrRejected = errors.New("signing rejected by remote slashing protection")
+	// ErrUnavailable defines a failure to get a decision from the remote slashing protection service.
+	ErrUnavailable = errors.New("remote slashing protection is unavailable")
+)
+
+// Config includes configuration values for initializing a remote slashing protection client.
+type Config struct {
+	URL      string
+	Timeout  time.Duration
+	FailOpen bool
+}
+
+// Client checks signing requests with a remote slashing protection service.
+type Client struct {
+	client   *http.Client
+	endpoint string
+	failOpen bool
+}
+
+type attestationRequestJSON struct {
+	PubKey      string `json:"pubkey"`
+	SigningRoot string `json:"signing_root"`
+	Slot        string `json:"slot"`
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+}
+
+type blockRequestJSON struct {
+	PubKey        string `json:"pubkey"`
+	SigningRoot   string `json:"signing_root"`
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+}
+
+// NewClient instantiates a remote slashing protection client from configuration options.
+func NewClient(cfg *Config) (*Client, error) {
+	if _, err := url.ParseRequestURI(cfg.URL); err != nil {
+		return nil, errors.Wrapf(err, "invalid remote slashing protection URL %s", cfg.URL)
+	}
+	if cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("invalid remote slashing protection timeout %v", cfg.Timeout)
+	}
+	return &Client{
+		client:   &http.Client{Timeout: cfg.Timeout},
+		endpoint: strings.TrimSuffix(cfg.URL, "/"),
+		failOpen: cfg.FailOpen,
+	}, nil
+}
+
+// CheckAttestation asks the remote service whether the attestation data may be signed by the
+// public key. It returns an error if signing is rejected, or if no decision could be obtained
+// and the client fails closed.
+func (c *Client) CheckAttestation(ctx context.Context, pubKey [48]byte, signingRoot [32]byte, data *ethpb.AttestationData) error {
+	if data == nil || data.Source == nil || data.Target == nil {
+		return errors.New("nil attestation data")
+	}
+	return c.check(ctx, attestationPath, &attestationRequestJSON{
+		PubKey:      hexutil.Encode(pubKey[:]),
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+		Slot:        strconv.FormatUint(uint64(data.Slot), 10),
+		SourceEpoch: strconv.FormatUint(uint64(data.Source.Epoch), 10),
+		TargetEpoch: strconv.FormatUint(uint64(data.Target.Epoch), 10),
+	})
+}
+
+// CheckBlock asks the remote service whether the block may be signed by the public key. It
+// returns an error if signing is rejected, or if no decision could be obtained and the client
+// fails closed.
+func (c *Client) CheckBlock(ctx context.Context, pubKey [48]byte, signingRoot [32]byte, block *ethpb.BeaconBlock) error {
+	if block == nil {
+		return errors.New("nil block")
+	}
+	return c.check(ctx, blockPath, &blockRequestJSON{
+		PubKey:        hexutil.Encode(pubKey[:]),
+		SigningRoot:   hexutil.Encode(signingRoot[:]),
+		Slot:          strconv.FormatUint(uint64(block.Slot), 10),
+		ProposerIndex: strconv.FormatUint(uint64(block.ProposerIndex), 10),
+	})
+}
+
+func (c *Client) check(ctx context.Context, path string, req interface{}) error {
+	err := c.send(ctx, path, req)
+	if errors.Is(err, ErrUnavailable) && c.failOpen {
+		log.WithError(err).Warn("Could not check signing request with remote slashing protection, signing anyway")
+		return nil
+	}
+	return err
+}
+
+func (c *Client) send(ctx context.Context, path string, req interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal remote slashing protection request")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(ErrUnavailable, err.Error())
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden:
+		reason, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		if err != nil || len(bytes.TrimSpace(reason)) == 0 {
+			return ErrRejected
+		}
+		return errors.Wrap(ErrRejected, strings.TrimSpace(string(reason)))
+	default:
+		return errors.Wrapf(ErrUnavailable, "status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,195 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// mockService is a remote slashing protection service allowing attestations with increasing target
+// epochs and blocks with increasing slots for each public key.
+type mockService struct {
+	sync.Mutex
+	targets map[string]uint64
+	slots   map[string]uint64
+	delay   time.Duration
+}
+
+func newMockService() *mockService {
+	return &mockService{
+		targets: make(map[string]uint64),
+		slots:   make(map[string]uint64),
+	}
+}
+
+func (s *mockService) setDelay(delay time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	s.delay = delay
+}
+
+func (s *mockService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	delay := s.delay
+	s.Unlock()
+	time.Sleep(delay)
+	s.Lock()
+	defer s.Unlock()
+	switch r.URL.Path {
+	case attestationPath:
+		req := &attestationRequestJSON{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		target, err := strconv.ParseUint(req.TargetEpoch, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if highest, ok := s.targets[req.PubKey]; ok && highest >= target {
+			w.WriteHeader(http.StatusForbidden)
+			_, err := w.Write([]byte("target epoch already signed"))
+			if err != nil {
+				panic(err)
+			}
+			return
+		}
+		s.targets[req.PubKey] = target
+	case blockPath:
+		req := &blockRequestJSON{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		slot, err := strconv.ParseUint(req.Slot, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if highest, ok := s.slots[req.PubKey]; ok && highest >= slot {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		s.slots[req.PubKey] = slot
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testAttestationData(target types.Epoch) *ethpb.AttestationData {
+	return &ethpb.AttestationData{
+		Slot:            5,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+	}
+}
+
+func TestNewClient_InvalidConfig(t *testing.T) {
+	_, err := NewClient(&Config{URL: "not a url", Timeout: time.Second})
+	assert.ErrorContains(t, "invalid remote slashing protection URL", err)
+	_, err = NewClient(&Config{URL: "http://localhost:9000"})
+	assert.ErrorContains(t, "invalid remote slashing protection timeout", err)
+}
+
+func TestClient_CheckAttestation(t *testing.T) {
+	srv := httptest.NewServer(newMockService())
+	defer srv.Close()
+	c, err := NewClient(&Config{URL: srv.URL + "/", Timeout: time.Second})
+	require.NoError(t, err)
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+
+	data := testAttestationData(2)
+	require.NoError(t, c.CheckAttestation(ctx, pubKey, [32]byte{1}, data))
+	err = c.CheckAttestation(ctx, pubKey, [32]byte{2}, data)
+	assert.ErrorContains(t, ErrRejected.Error(), err)
+	assert.ErrorContains(t, "target epoch already signed", err)
+
+	// The service protects each public key separately.
+	require.NoError(t, c.CheckAttestation(ctx, [48]byte{2}, [32]byte{1}, data))
+	data.Target.Epoch = 3
+	require.NoError(t, c.CheckAttestation(ctx, pubKey, [32]byte{3}, data))
+}
+
+func TestClient_CheckBlock(t *testing.T) {
+	srv := httptest.NewServer(newMockService())
+	defer srv.Close()
+	c, err := NewClient(&Config{URL: srv.URL, Timeout: time.Second})
+	require.NoError(t, err)
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+
+	blk := &ethpb.BeaconBlock{Slot: 5, ProposerIndex: 3}
+	require.NoError(t, c.CheckBlock(ctx, pubKey, [32]byte{1}, blk))
+	err = c.CheckBlock(ctx, pubKey, [32]byte{2}, blk)
+	assert.ErrorContains(t, ErrRejected.Error(), err)
+	blk.Slot = 6
+	require.NoError(t, c.CheckBlock(ctx, pubKey, [32]byte{3}, blk))
+}
+
+func TestClient_Unavailable(t *testing.T) {
+	svc := newMockService()
+	svc.setDelay(200 * time.Millisecond)
+	srv := httptest.NewServer(svc)
+	defer srv.Close()
+	ctx := context.Background()
+	pubKey := [48]byte{1}
+	blk := &ethpb.BeaconBlock{Slot: 5}
+
+	// Timeouts reject signing when failing closed, and allow it when failing open.
+	c, err := NewClient(&Config{URL: srv.URL, Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	err = c.CheckBlock(ctx, pubKey, [32]byte{}, blk)
+	assert.ErrorContains(t, ErrUnavailable.Error(), err)
+	c, err = NewClient(&Config{URL: srv.URL, Timeout: 50 * time.Millisecond, FailOpen: true})
+	require.NoError(t, err)
+	require.NoError(t, c.CheckBlock(ctx, pubKey, [32]byte{}, blk))
+
+	// Unexpected responses are failures, while rejections are enforced even when failing open.
+	svc.setDelay(0)
+	c, err = NewClient(&Config{URL: srv.URL + "/unknown", Timeout: time.Second})
+	require.NoError(t, err)
+	err = c.CheckBlock(ctx, pubKey, [32]byte{}, blk)
+	assert.ErrorContains(t, "status 404", err)
+	c, err = NewClient(&Config{URL: srv.URL, Timeout: time.Second, FailOpen: true})
+	require.NoError(t, err)
+	require.NoError(t, c.CheckBlock(ctx, [48]byte{2}, [32]byte{}, blk))
+	err = c.CheckBlock(ctx, [48]byte{2}, [32]byte{1}, blk)
+	assert.ErrorContains(t, ErrRejected.Error(), err)
+}
+
+func TestClient_RequestEncoding(t *testing.T) {
+	var got *attestationRequestJSON
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = &attestationRequestJSON{}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewClient(&Config{URL: srv.URL, Timeout: time.Second})
+	require.NoError(t, err)
+	pubKey := [48]byte{1, 2, 3}
+	root := [32]byte{4, 5, 6}
+	require.NoError(t, c.CheckAttestation(context.Background(), pubKey, root, testAttestationData(2)))
+	assert.DeepEqual(t, &attestationRequestJSON{
+		PubKey:      hexutil.Encode(pubKey[:]),
+		SigningRoot: hexutil.Encode(root[:]),
+		Slot:        "5",
+		SourceEpoch: "1",
+		TargetEpoch: "2",
+	}, got)
+}
@@ -8,13 +8,14 @@ import (
 
 // MockProtector mocks the protector.
 type MockProtector struct {
-	AllowAttestation        bool
-	AllowBlock              bool
-	VerifyAttestationCalled bool
-	CommitAttestationCalled bool
-	VerifyBlockCalled       bool
-	CommitBlockCalled       bool
-	StatusCalled            bool
+	AllowAttestation         bool
+	AllowBlock               bool
+	VerifyAttestationCalled  bool
+	HighestAttestationCalled bool
+	CommitAttestationCalled  bool
+	VerifyBlockCalled        bool
+	CommitBlockCalled        bool
+	StatusCalled             bool
 }
 
 // CheckAttestationSafety returns bool with allow attestation value.
@@ -23,6 +24,12 @@ func (mp MockProtector) CheckAttestationSafety(_ context.Context, _ *eth.Indexed
 	return mp.AllowAttestation
 }
 
+// CheckHighestAttestation returns bool with allow attestation value.
+func (mp MockProtector) CheckHighestAttestation(_ context.Context, _ *eth.IndexedAttestation) bool {
+	mp.HighestAttestationCalled = true
+	return mp.AllowAttestation
+}
+
 // CommitAttestation returns bool with allow attestation value.
 func (mp MockProtector) CommitAttestation(_ context.Context, _ *eth.IndexedAttestation) bool {
 	mp.CommitAttestationCalled = true
@@ -18,12 +18,13 @@ type MockSlasher struct {
 	IsSlashableAttestationNoUpdateCalled bool
 	IsSlashableBlockCalled               bool
 	IsSlashableBlockNoUpdateCalled       bool
+	HighestAttestationsResponse          []*slashpb.HighestAttestation
 }
 
-// HighestAttestations will return an empty array of attestations.
+// HighestAttestations returns the highest attestations set in the mock.
 func (ms MockSlasher) HighestAttestations(ctx context.Context, req *slashpb.HighestAttestationRequest, _ ...grpc.CallOption) (*slashpb.HighestAttestationResponse, error) {
 	return &slashpb.HighestAttestationResponse{
-		Attestations: nil,
+		Attestations: ms.HighestAttestationsResponse,
 	}, nil
 }
 
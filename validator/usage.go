@@ -47,6 +47,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.MinimalConfigFlag,
 			cmd.E2EConfigFlag,
 			cmd.VerbosityFlag,
+			cmd.ModuleLogLevelsFlag,
 			cmd.DataDirFlag,
 			cmd.ClearDB,
 			cmd.ForceClearDB,
@@ -61,6 +62,9 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.DisableMonitoringFlag,
 			cmd.LogFormat,
 			cmd.LogFileName,
+			cmd.LogMaxSize,
+			cmd.LogMaxAge,
+			cmd.LogMaxBackups,
 			cmd.ConfigFileFlag,
 			cmd.ChainConfigFileFlag,
 			cmd.GrpcMaxCallRecvMsgSizeFlag,
@@ -87,6 +91,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BeaconRPCProviderFlag,
 			flags.BeaconRPCGatewayProviderFlag,
 			flags.CertFlag,
+			flags.ClientCertFlag,
+			flags.ClientKeyFlag,
 			flags.EnableWebFlag,
 			flags.DisablePenaltyRewardLogFlag,
 			flags.GraffitiFlag,
@@ -106,6 +112,15 @@ var appHelpFlagGroups = []flagGroup{
 			flags.WalletPasswordFileFlag,
 			flags.GraffitiFileFlag,
 			flags.EnableDutyCountDown,
+			flags.DoppelgangerEpochsFlag,
+			flags.Web3SignerURLFlag,
+			flags.Web3SignerTLSCACertFlag,
+			flags.Web3SignerTLSClientCertFlag,
+			flags.Web3SignerTLSClientKeyFlag,
+			flags.Web3SignerKeysRefreshIntervalFlag,
+			flags.RemoteSlashingProtectionURLFlag,
+			flags.RemoteSlashingProtectionTimeoutFlag,
+			flags.RemoteSlashingProtectionFailOpenFlag,
 		},
 	},
 	{